@@ -0,0 +1,362 @@
+// Package infragpt is a Go client for the InfraGPT public API. It lists the
+// integrations of an organization, starts agent conversations and reads their
+// results.
+package infragpt
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/73ai/infragpt/clients/go/proto/infragpt/v1"
+)
+
+// Client calls the InfraGPT API on behalf of the organization its API key
+// belongs to. It is safe for concurrent use.
+type Client struct {
+	conn          *grpc.ClientConn
+	integrations  pb.IntegrationServiceClient
+	conversations pb.ConversationServiceClient
+	config        Config
+}
+
+// Config holds the configuration of a Client. Zero durations and retry
+// attempts take the values of DefaultConfig.
+type Config struct {
+	// Endpoint is the host:port of the InfraGPT gRPC API.
+	Endpoint string
+	// APIKey authenticates the client and selects the organization.
+	APIKey string
+	// Insecure connects without TLS, e.g. to a backend on localhost.
+	Insecure bool
+	// Timeout bounds each call, including its retries.
+	Timeout time.Duration
+	// RetryAttempts is how many times a read is tried while the API is
+	// unavailable. StartConversation is never retried, since a retry could
+	// start a second conversation.
+	RetryAttempts int
+	// InitialBackoff is the delay before the first retry; it doubles with
+	// each retry up to MaxBackoff.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// DialOptions are appended to the client's default dial options, e.g. for
+	// instrumentation.
+	DialOptions []grpc.DialOption
+}
+
+// DefaultConfig returns the defaults used for fields left unset.
+func DefaultConfig() Config {
+	return Config{
+		Timeout:        30 * time.Second,
+		RetryAttempts:  4,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+	}
+}
+
+// NewClient creates a client for the API at config.Endpoint. It does not
+// connect until the first call.
+func NewClient(config Config) (*Client, error) {
+	if config.Endpoint == "" {
+		return nil, errors.New("infragpt: endpoint is required")
+	}
+	if config.APIKey == "" {
+		return nil, errors.New("infragpt: API key is required")
+	}
+
+	defaults := DefaultConfig()
+	if config.Timeout <= 0 {
+		config.Timeout = defaults.Timeout
+	}
+	if config.RetryAttempts <= 0 {
+		config.RetryAttempts = defaults.RetryAttempts
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = defaults.InitialBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaults.MaxBackoff
+	}
+
+	transport := credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	if config.Insecure {
+		transport = insecure.NewCredentials()
+	}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(transport),
+		grpc.WithPerRPCCredentials(apiKeyCredentials{key: config.APIKey, secure: !config.Insecure}),
+	}
+	opts = append(opts, config.DialOptions...)
+
+	conn, err := grpc.NewClient(config.Endpoint, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("infragpt: failed to create connection to %s: %w", config.Endpoint, err)
+	}
+
+	return &Client{
+		conn:          conn,
+		integrations:  pb.NewIntegrationServiceClient(conn),
+		conversations: pb.NewConversationServiceClient(conn),
+		config:        config,
+	}, nil
+}
+
+// Close closes the connection to the API.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+type Integration struct {
+	ID            string
+	ConnectorType string
+	Status        string
+	Grants        []string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	// LastSyncedAt is zero for integrations that were never synced.
+	LastSyncedAt time.Time
+}
+
+// ListIntegrationsOptions filters ListIntegrations; empty fields match all
+// integrations.
+type ListIntegrationsOptions struct {
+	ConnectorType string
+	Status        string
+}
+
+func (c *Client) ListIntegrations(ctx context.Context, opts ListIntegrationsOptions) ([]Integration, error) {
+	resp, err := call(ctx, c, true, func(ctx context.Context) (*pb.ListIntegrationsResponse, error) {
+		return c.integrations.ListIntegrations(ctx, &pb.ListIntegrationsRequest{
+			ConnectorType: opts.ConnectorType,
+			Status:        opts.Status,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	integrations := make([]Integration, len(resp.Integrations))
+	for i, integration := range resp.Integrations {
+		integrations[i] = newIntegration(integration)
+	}
+	return integrations, nil
+}
+
+func (c *Client) GetIntegration(ctx context.Context, id string) (Integration, error) {
+	resp, err := call(ctx, c, true, func(ctx context.Context) (*pb.Integration, error) {
+		return c.integrations.GetIntegration(ctx, &pb.GetIntegrationRequest{Id: id})
+	})
+	if err != nil {
+		return Integration{}, err
+	}
+	return newIntegration(resp), nil
+}
+
+type ConversationStatus string
+
+const (
+	ConversationStatusActive ConversationStatus = "active"
+	// ConversationStatusEscalated conversations were handed off to a human;
+	// the agent does not reply in them until someone resumes it.
+	ConversationStatusEscalated ConversationStatus = "escalated"
+)
+
+// Conversation is a Slack thread the agent answers in.
+type Conversation struct {
+	ID        string
+	ChannelID string
+	ThreadTS  string
+	Status    ConversationStatus
+	CreatedAt time.Time
+}
+
+type StartConversationRequest struct {
+	// ChannelID is a Slack channel the InfraGPT app has been added to.
+	ChannelID string
+	Message   string
+	// Model picks the LLM model; empty uses the default.
+	Model string
+}
+
+// StartConversation posts the message to the channel and asks the agent about
+// it. It returns once the thread is posted; poll ListMessages for the agent's
+// reply.
+func (c *Client) StartConversation(ctx context.Context, req StartConversationRequest) (Conversation, error) {
+	resp, err := call(ctx, c, false, func(ctx context.Context) (*pb.Conversation, error) {
+		return c.conversations.StartConversation(ctx, &pb.StartConversationRequest{
+			ChannelId: req.ChannelID,
+			Message:   req.Message,
+			Model:     req.Model,
+		})
+	})
+	if err != nil {
+		return Conversation{}, err
+	}
+	return newConversation(resp), nil
+}
+
+func (c *Client) GetConversation(ctx context.Context, id string) (Conversation, error) {
+	resp, err := call(ctx, c, true, func(ctx context.Context) (*pb.Conversation, error) {
+		return c.conversations.GetConversation(ctx, &pb.GetConversationRequest{Id: id})
+	})
+	if err != nil {
+		return Conversation{}, err
+	}
+	return newConversation(resp), nil
+}
+
+// Message is a message in a conversation. Steps holds the tools the agent
+// used answering a user message, oldest first.
+type Message struct {
+	ID         string
+	SenderID   string
+	SenderName string
+	Text       string
+	FromAgent  bool
+	CreatedAt  time.Time
+	Steps      []Step
+}
+
+// Step is a tool the agent used, such as a command it ran.
+type Step struct {
+	ID   string
+	Tool string
+	// Arguments have secrets redacted.
+	Arguments []string
+	Duration  time.Duration
+	Outcome   string
+	// Result holds the start of the tool's output.
+	Result          string
+	ResultTruncated bool
+	StartedAt       time.Time
+}
+
+// ListMessages lists a conversation's messages, oldest first.
+func (c *Client) ListMessages(ctx context.Context, conversationID string) ([]Message, error) {
+	resp, err := call(ctx, c, true, func(ctx context.Context) (*pb.ListMessagesResponse, error) {
+		return c.conversations.ListMessages(ctx, &pb.ListMessagesRequest{ConversationId: conversationID})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]Message, len(resp.Messages))
+	for i, message := range resp.Messages {
+		steps := make([]Step, len(message.Steps))
+		for j, step := range message.Steps {
+			steps[j] = Step{
+				ID:              step.Id,
+				Tool:            step.Tool,
+				Arguments:       step.Arguments,
+				Duration:        time.Duration(step.DurationMs) * time.Millisecond,
+				Outcome:         step.Outcome,
+				Result:          step.Result,
+				ResultTruncated: step.ResultTruncated,
+				StartedAt:       timeOf(step.StartedAt),
+			}
+		}
+		messages[i] = Message{
+			ID:         message.Id,
+			SenderID:   message.SenderId,
+			SenderName: message.SenderName,
+			Text:       message.Text,
+			FromAgent:  message.FromAgent,
+			CreatedAt:  timeOf(message.CreatedAt),
+			Steps:      steps,
+		}
+	}
+	return messages, nil
+}
+
+// call runs fn within the configured timeout. When retry is set, calls
+// failing with codes.Unavailable are tried again with exponential backoff
+// and jitter. gRPC errors are returned as *Error.
+func call[T any](ctx context.Context, c *Client, retry bool, fn func(ctx context.Context) (T, error)) (T, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
+
+	attempts := 1
+	if retry {
+		attempts = c.config.RetryAttempts
+	}
+
+	var zero T
+	backoff := c.config.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		resp, err := fn(ctx)
+		if err == nil {
+			return resp, nil
+		}
+
+		err = fromStatus(err)
+		var apiErr *Error
+		if attempt == attempts || !errors.As(err, &apiErr) || apiErr.Code != codes.Unavailable {
+			return zero, err
+		}
+
+		delay := backoff/2 + rand.N(backoff/2+1)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return zero, err
+		}
+		backoff = min(backoff*2, c.config.MaxBackoff)
+	}
+}
+
+// apiKeyCredentials sends the API key as a bearer token with every call.
+type apiKeyCredentials struct {
+	key    string
+	secure bool
+}
+
+func (a apiKeyCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + a.key}, nil
+}
+
+func (a apiKeyCredentials) RequireTransportSecurity() bool {
+	return a.secure
+}
+
+func newIntegration(integration *pb.Integration) Integration {
+	return Integration{
+		ID:            integration.Id,
+		ConnectorType: integration.ConnectorType,
+		Status:        integration.Status,
+		Grants:        integration.Grants,
+		CreatedAt:     timeOf(integration.CreatedAt),
+		UpdatedAt:     timeOf(integration.UpdatedAt),
+		LastSyncedAt:  timeOf(integration.LastSyncedAt),
+	}
+}
+
+func newConversation(conversation *pb.Conversation) Conversation {
+	status := ConversationStatusActive
+	if conversation.Status == pb.ConversationStatus_CONVERSATION_STATUS_ESCALATED {
+		status = ConversationStatusEscalated
+	}
+	return Conversation{
+		ID:        conversation.Id,
+		ChannelID: conversation.ChannelId,
+		ThreadTS:  conversation.ThreadTs,
+		Status:    status,
+		CreatedAt: timeOf(conversation.CreatedAt),
+	}
+}
+
+// timeOf returns the zero time for unset timestamps.
+func timeOf(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,146 @@
+package infragpt
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	pb "github.com/73ai/infragpt/clients/go/proto/infragpt/v1"
+)
+
+type fakeServer struct {
+	pb.UnimplementedIntegrationServiceServer
+	pb.UnimplementedConversationServiceServer
+
+	errs          []error
+	calls         int
+	authorization []string
+}
+
+func (f *fakeServer) next(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	f.authorization = md.Get("authorization")
+	f.calls++
+	if len(f.errs) == 0 {
+		return nil
+	}
+	err := f.errs[0]
+	f.errs = f.errs[1:]
+	return err
+}
+
+func (f *fakeServer) ListIntegrations(ctx context.Context, req *pb.ListIntegrationsRequest) (*pb.ListIntegrationsResponse, error) {
+	if err := f.next(ctx); err != nil {
+		return nil, err
+	}
+	return &pb.ListIntegrationsResponse{Integrations: []*pb.Integration{{Id: "i-1", ConnectorType: req.ConnectorType}}}, nil
+}
+
+func (f *fakeServer) StartConversation(ctx context.Context, req *pb.StartConversationRequest) (*pb.Conversation, error) {
+	if err := f.next(ctx); err != nil {
+		return nil, err
+	}
+	return &pb.Conversation{Id: "c-1", ChannelId: req.ChannelId}, nil
+}
+
+func newTestClient(t *testing.T, server *fakeServer) *Client {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	pb.RegisterIntegrationServiceServer(s, server)
+	pb.RegisterConversationServiceServer(s, server)
+	go s.Serve(listener)
+	t.Cleanup(s.Stop)
+
+	client, err := NewClient(Config{
+		Endpoint:       "passthrough:///bufnet",
+		APIKey:         "key-123",
+		Insecure:       true,
+		InitialBackoff: time.Millisecond,
+		DialOptions: []grpc.DialOption{
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return listener.DialContext(ctx)
+			}),
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestClient(t *testing.T) {
+	ctx := context.Background()
+	unavailable := status.Error(codes.Unavailable, "connection reset")
+
+	t.Run("retries reads while unavailable", func(t *testing.T) {
+		server := &fakeServer{errs: []error{unavailable, unavailable}}
+		client := newTestClient(t, server)
+
+		integrations, err := client.ListIntegrations(ctx, ListIntegrationsOptions{ConnectorType: "github"})
+		if err != nil {
+			t.Fatalf("ListIntegrations() error = %v", err)
+		}
+		if len(integrations) != 1 || integrations[0].ConnectorType != "github" {
+			t.Errorf("ListIntegrations() = %+v", integrations)
+		}
+		if server.calls != 3 {
+			t.Errorf("calls = %d, want 3", server.calls)
+		}
+		if len(server.authorization) != 1 || server.authorization[0] != "Bearer key-123" {
+			t.Errorf("authorization = %v, want the API key as bearer token", server.authorization)
+		}
+	})
+
+	t.Run("gives up after the retry attempts", func(t *testing.T) {
+		server := &fakeServer{errs: []error{unavailable, unavailable, unavailable, unavailable, unavailable}}
+		client := newTestClient(t, server)
+
+		_, err := client.ListIntegrations(ctx, ListIntegrationsOptions{})
+		if !errors.Is(err, ErrUnavailable) {
+			t.Errorf("ListIntegrations() error = %v, want ErrUnavailable", err)
+		}
+		if server.calls != DefaultConfig().RetryAttempts {
+			t.Errorf("calls = %d, want %d", server.calls, DefaultConfig().RetryAttempts)
+		}
+	})
+
+	t.Run("does not retry starting a conversation", func(t *testing.T) {
+		server := &fakeServer{errs: []error{unavailable}}
+		client := newTestClient(t, server)
+
+		if _, err := client.StartConversation(ctx, StartConversationRequest{ChannelID: "C1", Message: "hi"}); !errors.Is(err, ErrUnavailable) {
+			t.Errorf("StartConversation() error = %v, want ErrUnavailable", err)
+		}
+		if server.calls != 1 {
+			t.Errorf("calls = %d, want 1", server.calls)
+		}
+	})
+
+	t.Run("maps status codes to typed errors", func(t *testing.T) {
+		server := &fakeServer{errs: []error{
+			status.Error(codes.NotFound, "conversation not found"),
+			status.Error(codes.ResourceExhausted, "quota exceeded: requests"),
+		}}
+		client := newTestClient(t, server)
+
+		_, err := client.ListIntegrations(ctx, ListIntegrationsOptions{})
+		var apiErr *Error
+		if !errors.Is(err, ErrNotFound) || !errors.As(err, &apiErr) || apiErr.Message != "conversation not found" {
+			t.Errorf("ListIntegrations() error = %v, want ErrNotFound with the server's message", err)
+		}
+		if _, err := client.StartConversation(ctx, StartConversationRequest{}); !errors.Is(err, ErrQuotaExceeded) {
+			t.Errorf("StartConversation() error = %v, want ErrQuotaExceeded", err)
+		}
+	})
+}
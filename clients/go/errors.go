@@ -0,0 +1,82 @@
+package infragpt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Errors returned by the client can be matched with errors.Is against these,
+// or unwrapped with errors.As into *Error for the gRPC code and message.
+var (
+	// ErrUnauthenticated is returned when the API key is missing, unknown or
+	// revoked.
+	ErrUnauthenticated  = errors.New("infragpt: unauthenticated")
+	ErrPermissionDenied = errors.New("infragpt: permission denied")
+	// ErrNotFound is also returned for resources of other organizations.
+	ErrNotFound        = errors.New("infragpt: not found")
+	ErrInvalidArgument = errors.New("infragpt: invalid argument")
+	ErrConflict        = errors.New("infragpt: conflict")
+	// ErrQuotaExceeded is returned when the organization has used up one of
+	// its quotas.
+	ErrQuotaExceeded = errors.New("infragpt: quota exceeded")
+	// ErrUnavailable is returned when the API is unreachable or briefly
+	// read-only for maintenance, after the client's retries.
+	ErrUnavailable = errors.New("infragpt: unavailable")
+	ErrInternal    = errors.New("infragpt: internal error")
+)
+
+// Error is an error reported by the API.
+type Error struct {
+	Code    codes.Code
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("infragpt: %s: %s", e.Code, e.Message)
+}
+
+// Is reports whether target is the sentinel error for e's code.
+func (e *Error) Is(target error) bool {
+	return target == sentinel(e.Code)
+}
+
+func sentinel(code codes.Code) error {
+	switch code {
+	case codes.Unauthenticated:
+		return ErrUnauthenticated
+	case codes.PermissionDenied:
+		return ErrPermissionDenied
+	case codes.NotFound:
+		return ErrNotFound
+	case codes.InvalidArgument, codes.OutOfRange:
+		return ErrInvalidArgument
+	case codes.AlreadyExists, codes.FailedPrecondition, codes.Aborted:
+		return ErrConflict
+	case codes.ResourceExhausted:
+		return ErrQuotaExceeded
+	case codes.Unavailable:
+		return ErrUnavailable
+	default:
+		return ErrInternal
+	}
+}
+
+// fromStatus converts a gRPC status error to *Error. Cancelled and timed out
+// calls are reported as the context error.
+func fromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	switch st.Code() {
+	case codes.Canceled:
+		return context.Canceled
+	case codes.DeadlineExceeded:
+		return context.DeadlineExceeded
+	}
+	return &Error{Code: st.Code(), Message: st.Message()}
+}
@@ -0,0 +1,80 @@
+// Command start_conversation lists the organization's integrations, asks the
+// agent a question in a Slack channel and prints the agent's reply.
+//
+//	INFRAGPT_API_KEY=... go run ./examples/start_conversation \
+//	    -endpoint localhost:9091 -insecure -channel C0123456789 \
+//	    "Why is the payments deployment restarting?"
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	infragpt "github.com/73ai/infragpt/clients/go"
+)
+
+func main() {
+	endpoint := flag.String("endpoint", "localhost:9091", "host:port of the InfraGPT gRPC API")
+	insecure := flag.Bool("insecure", false, "connect without TLS")
+	channel := flag.String("channel", "", "Slack channel ID to start the conversation in")
+	wait := flag.Duration("wait", 5*time.Minute, "how long to wait for the agent's reply")
+	flag.Parse()
+
+	if *channel == "" || flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	client, err := infragpt.NewClient(infragpt.Config{
+		Endpoint: *endpoint,
+		APIKey:   os.Getenv("INFRAGPT_API_KEY"),
+		Insecure: *insecure,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+
+	integrations, err := client.ListIntegrations(ctx, infragpt.ListIntegrationsOptions{Status: "active"})
+	if err != nil {
+		log.Fatalf("failed to list integrations: %v", err)
+	}
+	for _, integration := range integrations {
+		fmt.Printf("integration %s: %s (%s)\n", integration.ID, integration.ConnectorType, strings.Join(integration.Grants, ", "))
+	}
+
+	conversation, err := client.StartConversation(ctx, infragpt.StartConversationRequest{
+		ChannelID: *channel,
+		Message:   strings.Join(flag.Args(), " "),
+	})
+	if err != nil {
+		log.Fatalf("failed to start conversation: %v", err)
+	}
+	fmt.Printf("started conversation %s in thread %s\n", conversation.ID, conversation.ThreadTS)
+
+	deadline := time.Now().Add(*wait)
+	for time.Now().Before(deadline) {
+		time.Sleep(5 * time.Second)
+
+		messages, err := client.ListMessages(ctx, conversation.ID)
+		if err != nil {
+			log.Fatalf("failed to list messages: %v", err)
+		}
+		if len(messages) < 2 || !messages[len(messages)-1].FromAgent {
+			continue
+		}
+		for _, step := range messages[0].Steps {
+			fmt.Printf("step: %s %s (%s, %s)\n", step.Tool, strings.Join(step.Arguments, " "), step.Outcome, step.Duration)
+		}
+		fmt.Printf("agent: %s\n", messages[len(messages)-1].Text)
+		return
+	}
+	log.Fatalf("no reply from the agent within %s", *wait)
+}
@@ -5,6 +5,7 @@ from enum import Enum
 from typing import Optional
 import logging
 
+from src.llm import LiteLLMClient
 from src.models.agent import AgentResponse
 from src.models.context import AgentContext
 
@@ -65,5 +66,26 @@ class BaseAgent(ABC):
         self.llm_client = llm_client
         self.logger.debug(f"Set LLM client for {self.name} agent")
 
+    def llm_client_for(self, context: AgentContext) -> object:
+        """Get the LLM client to answer the request with.
+
+        Requests that name a model get a client for it with this agent's
+        sampling settings; others use the injected client.
+        """
+        if not context.model or context.model == self.llm_client.model:
+            return self.llm_client
+        return LiteLLMClient(
+            model=context.model,
+            temperature=self.llm_client.temperature,
+            max_tokens=self.llm_client.max_tokens,
+        )
+
+    def record_usage(self, context: AgentContext, llm_response: object) -> None:
+        """Add the tokens an LLM call used to the request's total.
+
+        The backend charges the total against the organization's token quota.
+        """
+        context.tokens_used += llm_response.tokens_used
+
     def __str__(self) -> str:
         return f"{self.agent_type.value.title()}Agent"
@@ -22,7 +22,7 @@ class ConversationAgent(BaseAgent):
         """
         Determine if this is a conversational request using LLM intent analysis.
         """
-        intent = await self._analyze_intent(context.current_message)
+        intent = await self._analyze_intent(context)
 
         # Handle conversational intents
         conversational_intents = [IntentType.CONVERSATION, IntentType.UNKNOWN]
@@ -34,7 +34,7 @@ class ConversationAgent(BaseAgent):
 
         return False
 
-    async def _analyze_intent(self, message: str) -> Intent:
+    async def _analyze_intent(self, context: AgentContext) -> Intent:
         """Analyze user intent using LLM."""
         system_prompt = """You are an intent analysis system for an infrastructure management AI agent.
         Analyze the user's message and determine their intent. Respond with ONLY a JSON object containing:
@@ -45,8 +45,10 @@ class ConversationAgent(BaseAgent):
         }"""
 
         llm_response = await self.llm_client.generate_response(
-            prompt=f"Analyze this message: {message}", system_prompt=system_prompt
+            prompt=f"Analyze this message: {context.current_message}",
+            system_prompt=system_prompt,
         )
+        self.record_usage(context, llm_response)
 
         # Handle LLM failure case
         if llm_response.metadata.get("error"):
@@ -91,11 +93,12 @@ class ConversationAgent(BaseAgent):
             "Be friendly, helpful, and professional. Keep responses concise but informative."
         )
 
-        llm_response = await self.llm_client.generate_response(
+        llm_response = await self.llm_client_for(context).generate_response(
             prompt=context.current_message,
             context=llm_context,
             system_prompt=system_prompt,
         )
+        self.record_usage(context, llm_response)
 
         # Handle LLM failure with customized error message
         if llm_response.metadata.get("error"):
@@ -114,6 +117,7 @@ class ConversationAgent(BaseAgent):
             agent_type=self.name,
             confidence=0.9,  # Static confidence since LLM confidence is not reliable
             tools_used=[],
+            tokens_used=context.tokens_used,
             metadata=metadata,
         )
 
@@ -79,6 +79,7 @@ class MainAgent(BaseAgent):
                 agent_type=self.name,
                 confidence=0.0,
                 tools_used=[],
+                tokens_used=context.tokens_used,
             )
 
     async def _select_agent(self, context: AgentContext) -> Optional[BaseAgent]:
@@ -117,6 +118,7 @@ class MainAgent(BaseAgent):
             agent_type=self.name,
             confidence=0.3,
             tools_used=[],
+            tokens_used=context.tokens_used,
         )
 
     def set_llm_client(self, llm_client: object) -> None:
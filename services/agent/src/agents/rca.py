@@ -24,7 +24,7 @@ class RCAAgent(BaseAgent):
         """
         Determine if this requires root cause analysis using LLM intent analysis.
         """
-        intent = await self._analyze_intent(context.current_message)
+        intent = await self._analyze_intent(context)
 
         # Handle troubleshooting intents - include deployment failures
         rca_intents = [
@@ -39,7 +39,7 @@ class RCAAgent(BaseAgent):
 
         return False
 
-    async def _analyze_intent(self, message: str) -> Intent:
+    async def _analyze_intent(self, context: AgentContext) -> Intent:
         """Analyze user intent using LLM for RCA classification."""
         system_prompt = """You are an intent analysis system for an infrastructure management AI agent.
         Analyze the user's message and determine their intent. Respond with ONLY a JSON object containing:
@@ -50,8 +50,10 @@ class RCAAgent(BaseAgent):
         }"""
 
         llm_response = await self.llm_client.generate_response(
-            prompt=f"Analyze this message: {message}", system_prompt=system_prompt
+            prompt=f"Analyze this message: {context.current_message}",
+            system_prompt=system_prompt,
         )
+        self.record_usage(context, llm_response)
 
         # Handle LLM failure case
         if llm_response.metadata.get("error"):
@@ -104,11 +106,12 @@ class RCAAgent(BaseAgent):
             "Be technical but clear, and focus on actionable recommendations."
         )
 
-        llm_response = await self.llm_client.generate_response(
+        llm_response = await self.llm_client_for(context).generate_response(
             prompt=context.current_message,
             context=llm_context,
             system_prompt=system_prompt,
         )
+        self.record_usage(context, llm_response)
 
         # Handle LLM failure with customized error message for RCA
         if llm_response.metadata.get("error"):
@@ -128,6 +131,7 @@ class RCAAgent(BaseAgent):
             agent_type=self.name,
             confidence=0.8,  # High confidence for RCA analysis when successful
             tools_used=[],
+            tokens_used=context.tokens_used,
             metadata=metadata,
         )
 
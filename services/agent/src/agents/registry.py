@@ -81,6 +81,7 @@ class AgentSystem:
             conversation_id=request.conversation_id,
             user_id=request.user_id,
             channel_id=request.channel_id,
+            model=request.model,
             current_message=request.current_message,
             message_history=request.past_messages,
             metadata={"context": request.context},
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 
@@ -28,6 +29,8 @@ type Config struct {
 	Timeout        time.Duration `mapstructure:"-"`
 	RetryAttempts  int           `mapstructure:"retry_attempts"`
 	ConnectTimeout time.Duration `mapstructure:"-"`
+	// DialOptions are appended to the client's default dial options, e.g. for instrumentation.
+	DialOptions []grpc.DialOption `mapstructure:"-"`
 }
 
 // DefaultConfig returns a default configuration
@@ -55,6 +58,7 @@ func NewClient(config *Config) (*Client, error) {
 			PermitWithoutStream: true,
 		}),
 	}
+	opts = append(opts, config.DialOptions...)
 
 	ctx, cancel := context.WithTimeout(context.Background(), config.ConnectTimeout)
 	defer cancel()
@@ -80,6 +84,8 @@ type AgentRequest struct {
 	Context        string
 	UserId         string
 	ChannelId      string
+	// Model is the LLM model the agent answers with; empty uses the agent default.
+	Model string
 }
 
 type AgentResponse struct {
@@ -89,6 +95,9 @@ type AgentResponse struct {
 	AgentType    string
 	Confidence   float32
 	ToolsUsed    []string
+	// TokensUsed is the LLM tokens the agent used answering, prompt and
+	// completion together.
+	TokensUsed int64
 }
 
 // ProcessMessage sends a message to the agent for processing
@@ -101,6 +110,7 @@ func (c *Client) ProcessMessage(ctx context.Context, req AgentRequest) (AgentRes
 		Context:        req.Context,
 		UserId:         req.UserId,
 		ChannelId:      req.ChannelId,
+		Model:          req.Model,
 	}
 
 	// Set timeout for the request
@@ -128,20 +138,38 @@ func (c *Client) ProcessMessage(ctx context.Context, req AgentRequest) (AgentRes
 				AgentType:    resp.AgentType,
 				Confidence:   resp.Confidence,
 				ToolsUsed:    resp.ToolsUsed,
+				TokensUsed:   resp.TokensUsed,
 			}, nil
 		}
 
 		lastErr = err
 
-		// Don't retry on context cancellation/timeout
+		// Don't retry on context cancellation/timeout. gRPC has already
+		// aborted the call, so report the context error to let callers tell a
+		// cancelled request from a failed one.
 		if ctx.Err() != nil {
-			break
+			return AgentResponse{}, fmt.Errorf("agent request aborted: %w", ctx.Err())
 		}
 	}
 
 	return AgentResponse{}, fmt.Errorf("failed to process message after %d attempts: %w", c.config.RetryAttempts, lastErr)
 }
 
+// Ready waits until the connection to the agent service is established, or
+// ctx is done.
+func (c *Client) Ready(ctx context.Context) error {
+	c.conn.Connect()
+	for {
+		state := c.conn.GetState()
+		if state == connectivity.Ready {
+			return nil
+		}
+		if !c.conn.WaitForStateChange(ctx, state) {
+			return fmt.Errorf("agent service at %s is not reachable, connection is %s: %w", c.config.Endpoint, state, ctx.Err())
+		}
+	}
+}
+
 // Close closes the connection to the agent service
 func (c *Client) Close() error {
 	if c.conn != nil {
@@ -0,0 +1,119 @@
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// EnvelopeVersion is the newest response envelope version this client reads.
+// Agents set it in envelope_version; newer versions may only add fields and
+// kinds, so replies from a newer agent are read as far as they are understood.
+const EnvelopeVersion = 1
+
+// ErrInvalidEnvelope is returned when a reply declares an envelope version but
+// cannot be read as one.
+var ErrInvalidEnvelope = errors.New("invalid agent response envelope")
+
+// ResponseKind tells the backend what to do with an agent reply.
+type ResponseKind string
+
+const (
+	// ResponseKindAnswer is posted as is. Replies without an envelope, such as
+	// those from agents built before envelopes existed, are answers.
+	ResponseKindAnswer ResponseKind = "answer"
+	// ResponseKindPlan proposes changes that need approval before they run.
+	ResponseKindPlan ResponseKind = "plan"
+	// ResponseKindNeedsClarification asks the user for more detail.
+	ResponseKindNeedsClarification ResponseKind = "needs_clarification"
+)
+
+// RiskLevel is the agent's estimate of how much a plan could break.
+type RiskLevel string
+
+const (
+	RiskLevelLow    RiskLevel = "low"
+	RiskLevelMedium RiskLevel = "medium"
+	RiskLevelHigh   RiskLevel = "high"
+)
+
+// Envelope is the structured form of an agent reply, sent as a JSON object in
+// the reply text.
+type Envelope struct {
+	Version int          `json:"envelope_version"`
+	Kind    ResponseKind `json:"kind"`
+	// Text is the answer, the plan's summary or the clarifying question.
+	Text string `json:"text"`
+	// Plan is set for plans.
+	Plan *Plan `json:"plan,omitempty"`
+	// Questions lists what the agent needs to know, for clarifications.
+	Questions []string `json:"questions,omitempty"`
+}
+
+// Plan describes the changes an agent proposes.
+type Plan struct {
+	Steps []PlanStep `json:"steps"`
+	// AffectedResources names the resources the plan changes, such as
+	// "deployment/api in namespace prod".
+	AffectedResources []string  `json:"affected_resources,omitempty"`
+	RiskLevel         RiskLevel `json:"risk_level"`
+	// RollbackNotes explains how to undo the plan.
+	RollbackNotes string `json:"rollback_notes,omitempty"`
+}
+
+type PlanStep struct {
+	Description string `json:"description"`
+	// Command is the command the step runs, when there is one.
+	Command string `json:"command,omitempty"`
+}
+
+// ParseEnvelope reads an agent reply. Replies that do not declare an envelope
+// version are plain answers. It returns ErrInvalidEnvelope for replies that
+// declare one but are malformed or of an unknown kind.
+func ParseEnvelope(reply string) (Envelope, error) {
+	answer := Envelope{Kind: ResponseKindAnswer, Text: reply}
+
+	trimmed := strings.TrimSpace(reply)
+	if !strings.HasPrefix(trimmed, "{") {
+		return answer, nil
+	}
+	var probe struct {
+		Version *int `json:"envelope_version"`
+	}
+	if err := json.Unmarshal([]byte(trimmed), &probe); err != nil || probe.Version == nil {
+		// A JSON answer, such as a manifest the user asked for.
+		return answer, nil
+	}
+
+	var envelope Envelope
+	if err := json.Unmarshal([]byte(trimmed), &envelope); err != nil {
+		return Envelope{}, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+	if envelope.Version < 1 {
+		return Envelope{}, fmt.Errorf("%w: version %d", ErrInvalidEnvelope, envelope.Version)
+	}
+
+	switch envelope.Kind {
+	case ResponseKindAnswer:
+		if envelope.Text == "" {
+			return Envelope{}, fmt.Errorf("%w: answer has no text", ErrInvalidEnvelope)
+		}
+	case ResponseKindPlan:
+		if envelope.Plan == nil || len(envelope.Plan.Steps) == 0 {
+			return Envelope{}, fmt.Errorf("%w: plan has no steps", ErrInvalidEnvelope)
+		}
+		switch envelope.Plan.RiskLevel {
+		case RiskLevelLow, RiskLevelMedium, RiskLevelHigh:
+		default:
+			return Envelope{}, fmt.Errorf("%w: unknown risk level %q", ErrInvalidEnvelope, envelope.Plan.RiskLevel)
+		}
+	case ResponseKindNeedsClarification:
+		if envelope.Text == "" && len(envelope.Questions) == 0 {
+			return Envelope{}, fmt.Errorf("%w: clarification has no question", ErrInvalidEnvelope)
+		}
+	default:
+		return Envelope{}, fmt.Errorf("%w: unknown kind %q in version %d", ErrInvalidEnvelope, envelope.Kind, envelope.Version)
+	}
+	return envelope, nil
+}
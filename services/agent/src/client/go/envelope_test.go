@@ -0,0 +1,92 @@
+package agent
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseEnvelope(t *testing.T) {
+	tests := []struct {
+		name    string
+		reply   string
+		want    Envelope
+		wantErr bool
+	}{
+		{
+			name:  "plain text from an older agent",
+			reply: "The pod restarted because it ran out of memory.",
+			want:  Envelope{Kind: ResponseKindAnswer, Text: "The pod restarted because it ran out of memory."},
+		},
+		{
+			name:  "JSON answer without an envelope",
+			reply: `{"apiVersion": "v1", "kind": "Pod"}`,
+			want:  Envelope{Kind: ResponseKindAnswer, Text: `{"apiVersion": "v1", "kind": "Pod"}`},
+		},
+		{
+			name:  "answer",
+			reply: `{"envelope_version": 1, "kind": "answer", "text": "All green."}`,
+			want:  Envelope{Version: 1, Kind: ResponseKindAnswer, Text: "All green."},
+		},
+		{
+			name: "plan",
+			reply: `{"envelope_version": 1, "kind": "plan", "text": "Scale the API up.", "plan": {
+				"steps": [{"description": "Scale to 5 replicas", "command": "kubectl scale deployment/api --replicas=5"}],
+				"affected_resources": ["deployment/api"], "risk_level": "medium", "rollback_notes": "Scale back to 3."}}`,
+			want: Envelope{Version: 1, Kind: ResponseKindPlan, Text: "Scale the API up.", Plan: &Plan{
+				Steps:             []PlanStep{{Description: "Scale to 5 replicas", Command: "kubectl scale deployment/api --replicas=5"}},
+				AffectedResources: []string{"deployment/api"},
+				RiskLevel:         RiskLevelMedium,
+				RollbackNotes:     "Scale back to 3.",
+			}},
+		},
+		{
+			name:  "clarification",
+			reply: `{"envelope_version": 1, "kind": "needs_clarification", "questions": ["Which cluster?"]}`,
+			want:  Envelope{Version: 1, Kind: ResponseKindNeedsClarification, Questions: []string{"Which cluster?"}},
+		},
+		{
+			name:  "newer version with extra fields",
+			reply: `{"envelope_version": 3, "kind": "answer", "text": "Done.", "citations": ["runbook"]}`,
+			want:  Envelope{Version: 3, Kind: ResponseKindAnswer, Text: "Done."},
+		},
+		{
+			name:    "unknown kind",
+			reply:   `{"envelope_version": 2, "kind": "poll", "text": "Pick one"}`,
+			wantErr: true,
+		},
+		{
+			name:    "plan without steps",
+			reply:   `{"envelope_version": 1, "kind": "plan", "plan": {"steps": [], "risk_level": "low"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "plan with unknown risk",
+			reply:   `{"envelope_version": 1, "kind": "plan", "plan": {"steps": [{"description": "x"}], "risk_level": "spicy"}}`,
+			wantErr: true,
+		},
+		{
+			name:    "malformed fields",
+			reply:   `{"envelope_version": 1, "kind": "plan", "plan": "restart everything"}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEnvelope(tt.reply)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidEnvelope) {
+					t.Fatalf("ParseEnvelope() error = %v, want %v", err, ErrInvalidEnvelope)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseEnvelope() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseEnvelope() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
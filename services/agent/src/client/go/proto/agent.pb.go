@@ -108,7 +108,9 @@ type AgentRequest struct {
 	// Optional: User ID for personalization
 	UserId string `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	// Optional: Channel/workspace information
-	ChannelId     string `protobuf:"bytes,6,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	ChannelId string `protobuf:"bytes,6,opt,name=channel_id,json=channelId,proto3" json:"channel_id,omitempty"`
+	// Optional: LLM model to answer with, e.g. "gpt-4o"; empty uses the agent default
+	Model         string `protobuf:"bytes,7,opt,name=model,proto3" json:"model,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -185,6 +187,13 @@ func (x *AgentRequest) GetChannelId() string {
 	return ""
 }
 
+func (x *AgentRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
 // Response from the agent
 type AgentResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -199,7 +208,9 @@ type AgentResponse struct {
 	// Optional: Confidence score (0.0 to 1.0)
 	Confidence float32 `protobuf:"fixed32,5,opt,name=confidence,proto3" json:"confidence,omitempty"`
 	// Optional: List of tools used in processing
-	ToolsUsed     []string `protobuf:"bytes,6,rep,name=tools_used,json=toolsUsed,proto3" json:"tools_used,omitempty"`
+	ToolsUsed []string `protobuf:"bytes,6,rep,name=tools_used,json=toolsUsed,proto3" json:"tools_used,omitempty"`
+	// Optional: LLM tokens used answering the request, prompt and completion together
+	TokensUsed    int64 `protobuf:"varint,7,opt,name=tokens_used,json=tokensUsed,proto3" json:"tokens_used,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -276,6 +287,13 @@ func (x *AgentResponse) GetToolsUsed() []string {
 	return nil
 }
 
+func (x *AgentResponse) GetTokensUsed() int64 {
+	if x != nil {
+		return x.TokensUsed
+	}
+	return 0
+}
+
 var File_agent_proto protoreflect.FileDescriptor
 
 const file_agent_proto_rawDesc = "" +
@@ -286,7 +304,7 @@ const file_agent_proto_rawDesc = "" +
 	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12\x16\n" +
 	"\x06sender\x18\x03 \x01(\tR\x06sender\x12\x1c\n" +
-	"\ttimestamp\x18\x04 \x01(\tR\ttimestamp\"\xe7\x01\n" +
+	"\ttimestamp\x18\x04 \x01(\tR\ttimestamp\"\xfd\x01\n" +
 	"\fAgentRequest\x12'\n" +
 	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12'\n" +
 	"\x0fcurrent_message\x18\x02 \x01(\tR\x0ecurrentMessage\x123\n" +
@@ -294,7 +312,8 @@ const file_agent_proto_rawDesc = "" +
 	"\acontext\x18\x04 \x01(\tR\acontext\x12\x17\n" +
 	"\auser_id\x18\x05 \x01(\tR\x06userId\x12\x1d\n" +
 	"\n" +
-	"channel_id\x18\x06 \x01(\tR\tchannelId\"\xd1\x01\n" +
+	"channel_id\x18\x06 \x01(\tR\tchannelId\x12\x14\n" +
+	"\x05model\x18\a \x01(\tR\x05model\"\xf2\x01\n" +
 	"\rAgentResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12#\n" +
 	"\rresponse_text\x18\x02 \x01(\tR\fresponseText\x12#\n" +
@@ -305,7 +324,9 @@ const file_agent_proto_rawDesc = "" +
 	"confidence\x18\x05 \x01(\x02R\n" +
 	"confidence\x12\x1d\n" +
 	"\n" +
-	"tools_used\x18\x06 \x03(\tR\ttoolsUsed2K\n" +
+	"tools_used\x18\x06 \x03(\tR\ttoolsUsed\x12\x1f\n" +
+	"\vtokens_used\x18\a \x01(\x03R\n" +
+	"tokensUsed2K\n" +
 	"\fAgentService\x12;\n" +
 	"\x0eProcessMessage\x12\x13.agent.AgentRequest\x1a\x14.agent.AgentResponseB\x0fZ\r./proto;agentb\x06proto3"
 
@@ -103,6 +103,7 @@ class AgentServiceHandler(agent_pb2_grpc.AgentServiceServicer):
             context=pb_request.context,
             user_id=pb_request.user_id if pb_request.user_id else None,
             channel_id=pb_request.channel_id if pb_request.channel_id else None,
+            model=pb_request.model if pb_request.model else None,
         )
 
     def _convert_response(
@@ -116,6 +117,7 @@ class AgentServiceHandler(agent_pb2_grpc.AgentServiceServicer):
             agent_type=agent_response.agent_type or "",
             confidence=agent_response.confidence or 0.0,
             tools_used=agent_response.tools_used,
+            tokens_used=agent_response.tokens_used,
         )
 
     async def _send_reply_to_slack(
@@ -83,7 +83,7 @@ class LiteLLMClient:
                 "max_tokens": self.max_tokens,
             }
 
-            # Add token usage if available (future token counting)
+            # Add token usage if available; it is reported to the backend for quotas
             if response.usage:
                 try:
                     metadata["usage"] = {
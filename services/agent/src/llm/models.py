@@ -46,3 +46,8 @@ class LLMResponse(BaseModel):
     metadata: Dict[str, Any] = Field(
         default_factory=dict, description="Model and performance metadata"
     )
+
+    @property
+    def tokens_used(self) -> int:
+        """Tokens the provider reported for the call, 0 when it reported none."""
+        return self.metadata.get("usage", {}).get("total_tokens", 0) or 0
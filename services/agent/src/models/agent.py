@@ -22,6 +22,9 @@ class AgentRequest(BaseModel):
     channel_id: Optional[str] = Field(
         default=None, description="Channel/workspace information"
     )
+    model: Optional[str] = Field(
+        default=None, description="LLM model to answer with; None uses the default"
+    )
 
 
 class AgentResponse(BaseModel):
@@ -41,3 +44,7 @@ class AgentResponse(BaseModel):
     tools_used: List[str] = Field(
         default_factory=list, description="List of tools used in processing"
     )
+    tokens_used: int = Field(
+        default=0,
+        description="LLM tokens used answering the request, prompt and completion together",
+    )
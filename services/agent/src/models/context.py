@@ -21,6 +21,7 @@ class AgentContext(BaseModel):
     conversation_id: str = Field(..., description="Conversation identifier")
     user_id: Optional[str] = Field(default=None, description="User identifier")
     channel_id: Optional[str] = Field(default=None, description="Channel identifier")
+    model: Optional[str] = Field(default=None, description="Requested LLM model")
 
     # Message context
     current_message: str = Field(..., description="Current message being processed")
@@ -40,3 +41,6 @@ class AgentContext(BaseModel):
     available_tools: list[str] = Field(
         default_factory=list, description="Available tools for processing"
     )
+    tokens_used: int = Field(
+        default=0, description="LLM tokens used so far answering the request"
+    )
@@ -20,7 +20,7 @@ _sym_db = _symbol_database.Default()
 
 
 DESCRIPTOR = _descriptor_pool.Default().AddSerializedFile(
-    b'\n\x0b\x61gent.proto\x12\x05\x61gent"Q\n\x07Message\x12\x12\n\nmessage_id\x18\x01 \x01(\t\x12\x0f\n\x07\x63ontent\x18\x02 \x01(\t\x12\x0e\n\x06sender\x18\x03 \x01(\t\x12\x11\n\ttimestamp\x18\x04 \x01(\t"\x9d\x01\n\x0c\x41gentRequest\x12\x17\n\x0f\x63onversation_id\x18\x01 \x01(\t\x12\x17\n\x0f\x63urrent_message\x18\x02 \x01(\t\x12%\n\rpast_messages\x18\x03 \x03(\x0b\x32\x0e.agent.Message\x12\x0f\n\x07\x63ontext\x18\x04 \x01(\t\x12\x0f\n\x07user_id\x18\x05 \x01(\t\x12\x12\n\nchannel_id\x18\x06 \x01(\t"\x8a\x01\n\rAgentResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\x12\x15\n\rresponse_text\x18\x02 \x01(\t\x12\x15\n\rerror_message\x18\x03 \x01(\t\x12\x12\n\nagent_type\x18\x04 \x01(\t\x12\x12\n\nconfidence\x18\x05 \x01(\x02\x12\x12\n\ntools_used\x18\x06 \x03(\t2K\n\x0c\x41gentService\x12;\n\x0eProcessMessage\x12\x13.agent.AgentRequest\x1a\x14.agent.AgentResponseB\x0fZ\r./proto;agentb\x06proto3'
+    b'\n\x0b\x61gent.proto\x12\x05\x61gent"Q\n\x07Message\x12\x12\n\nmessage_id\x18\x01 \x01(\t\x12\x0f\n\x07\x63ontent\x18\x02 \x01(\t\x12\x0e\n\x06sender\x18\x03 \x01(\t\x12\x11\n\ttimestamp\x18\x04 \x01(\t"\xac\x01\n\x0c\x41gentRequest\x12\x17\n\x0f\x63onversation_id\x18\x01 \x01(\t\x12\x17\n\x0f\x63urrent_message\x18\x02 \x01(\t\x12%\n\rpast_messages\x18\x03 \x03(\x0b\x32\x0e.agent.Message\x12\x0f\n\x07\x63ontext\x18\x04 \x01(\t\x12\x0f\n\x07user_id\x18\x05 \x01(\t\x12\x12\n\nchannel_id\x18\x06 \x01(\t\x12\r\n\x05model\x18\x07 \x01(\t"\x9f\x01\n\rAgentResponse\x12\x0f\n\x07success\x18\x01 \x01(\x08\x12\x15\n\rresponse_text\x18\x02 \x01(\t\x12\x15\n\rerror_message\x18\x03 \x01(\t\x12\x12\n\nagent_type\x18\x04 \x01(\t\x12\x12\n\nconfidence\x18\x05 \x01(\x02\x12\x12\n\ntools_used\x18\x06 \x03(\t\x12\x13\n\x0btokens_used\x18\x07 \x01(\x032K\n\x0c\x41gentService\x12;\n\x0eProcessMessage\x12\x13.agent.AgentRequest\x1a\x14.agent.AgentResponseB\x0fZ\r./proto;agentb\x06proto3'
 )
 
 _globals = globals()
@@ -32,9 +32,9 @@ if not _descriptor._USE_C_DESCRIPTORS:
     _globals["_MESSAGE"]._serialized_start = 22
     _globals["_MESSAGE"]._serialized_end = 103
     _globals["_AGENTREQUEST"]._serialized_start = 106
-    _globals["_AGENTREQUEST"]._serialized_end = 263
-    _globals["_AGENTRESPONSE"]._serialized_start = 266
-    _globals["_AGENTRESPONSE"]._serialized_end = 404
-    _globals["_AGENTSERVICE"]._serialized_start = 406
-    _globals["_AGENTSERVICE"]._serialized_end = 481
+    _globals["_AGENTREQUEST"]._serialized_end = 278
+    _globals["_AGENTRESPONSE"]._serialized_start = 281
+    _globals["_AGENTRESPONSE"]._serialized_end = 440
+    _globals["_AGENTSERVICE"]._serialized_start = 442
+    _globals["_AGENTSERVICE"]._serialized_end = 517
 # @@protoc_insertion_point(module_scope)
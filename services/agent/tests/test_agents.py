@@ -3,6 +3,7 @@
 import pytest
 
 from src.agents import AgentSystem, ConversationAgent, RCAAgent, MainAgent
+from src.llm import LLMResponse
 from src.models.agent import AgentRequest
 from src.models.context import AgentContext
 
@@ -51,6 +52,31 @@ class TestConversationAgent:
         assert "Hello" in response.response_text
         assert response.confidence > 0
 
+    def test_uses_default_model(self, agent, context):
+        """Test that requests without a model use the injected client."""
+        assert agent.llm_client_for(context) is agent.llm_client
+
+    def test_uses_requested_model(self, agent, context):
+        """Test that requests naming a model are answered with it."""
+        context.model = "gpt-4o"
+        client = agent.llm_client_for(context)
+
+        assert client.model == "gpt-4o"
+        assert client.temperature == agent.llm_client.temperature
+        assert client.max_tokens == agent.llm_client.max_tokens
+
+    def test_records_usage(self, agent, context):
+        """Test that reported token usage adds up across LLM calls."""
+        agent.record_usage(
+            context, LLMResponse(content="", metadata={"usage": {"total_tokens": 42}})
+        )
+        agent.record_usage(context, LLMResponse(content="", metadata={"error": True}))
+        agent.record_usage(
+            context, LLMResponse(content="", metadata={"usage": {"total_tokens": 8}})
+        )
+
+        assert context.tokens_used == 50
+
 
 class TestRCAAgent:
     """Tests for the RCA agent."""
@@ -0,0 +1,83 @@
+package backendapi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/backendapi/proto"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+)
+
+type executionServer struct {
+	proto.UnimplementedExecutionServiceServer
+	svc backend.ExecutionService
+}
+
+func (s *executionServer) ExecuteCommand(req *proto.ExecuteCommandRequest, stream grpc.ServerStreamingServer[proto.ExecuteCommandEvent]) error {
+	cmd, err := executeCommandCommand(req)
+	if err != nil {
+		return stream.Send(&proto.ExecuteCommandEvent{
+			Result: &proto.CommandResult{Error: err.Error()},
+		})
+	}
+
+	result, err := s.svc.ExecuteCommand(stream.Context(), cmd, func(out backend.CommandOutput) error {
+		return stream.Send(&proto.ExecuteCommandEvent{
+			Stream: outputStream(out.Stream),
+			Data:   out.Data,
+		})
+	})
+	if err != nil {
+		return stream.Send(&proto.ExecuteCommandEvent{
+			Result: &proto.CommandResult{Error: err.Error()},
+		})
+	}
+
+	return stream.Send(&proto.ExecuteCommandEvent{
+		Result: &proto.CommandResult{
+			Allowed:    result.Allowed,
+			DeniedRule: result.DeniedRule,
+			Reason:     result.Reason,
+			ExitCode:   int32(result.ExitCode),
+			TimedOut:   result.TimedOut,
+		},
+	})
+}
+
+func executeCommandCommand(req *proto.ExecuteCommandRequest) (backend.ExecuteCommandCommand, error) {
+	organizationID, err := uuid.Parse(req.OrganizationId)
+	if err != nil {
+		return backend.ExecuteCommandCommand{}, fmt.Errorf("invalid organization_id: %w", err)
+	}
+
+	var integrationID uuid.UUID
+	if req.IntegrationId != "" {
+		integrationID, err = uuid.Parse(req.IntegrationId)
+		if err != nil {
+			return backend.ExecuteCommandCommand{}, fmt.Errorf("invalid integration_id: %w", err)
+		}
+	}
+
+	return backend.ExecuteCommandCommand{
+		OrganizationID: organizationID,
+		IntegrationID:  integrationID,
+		ConversationID: req.ConversationId,
+		ToolCallID:     req.ToolCallId,
+		Binary:         req.Binary,
+		Args:           req.Args,
+		Timeout:        time.Duration(req.TimeoutSeconds) * time.Second,
+	}, nil
+}
+
+func outputStream(stream backend.OutputStream) proto.OutputStream {
+	switch stream {
+	case backend.OutputStreamStdout:
+		return proto.OutputStream_OUTPUT_STREAM_STDOUT
+	case backend.OutputStreamStderr:
+		return proto.OutputStream_OUTPUT_STREAM_STDERR
+	default:
+		return proto.OutputStream_OUTPUT_STREAM_UNSPECIFIED
+	}
+}
@@ -13,11 +13,14 @@ type grpcServer struct {
 	svc backend.ConversationService
 }
 
-func NewGRPCServer(svc backend.ConversationService) *grpc.Server {
-	server := grpc.NewServer()
+func NewGRPCServer(svc backend.ConversationService, execution backend.ExecutionService, opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
 	proto.RegisterBackendServiceServer(server, &grpcServer{
 		svc: svc,
 	})
+	proto.RegisterExecutionServiceServer(server, &executionServer{
+		svc: execution,
+	})
 	return server
 }
 
@@ -3,6 +3,7 @@ package backendapi
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
@@ -71,27 +72,26 @@ func (h *httpHandler) sendReply(w http.ResponseWriter, r *http.Request) {
 
 func ApiHandlerFunc[X any, Y any](api func(
 	context.Context, X) (Y, error)) func(http.ResponseWriter, *http.Request) {
-	const RequestIDHeader = "x-request-id"
-
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		request := new(X)
 		bodyBytes, err := io.ReadAll(r.Body)
+		if err != nil {
+			httperrors.Write(w, r, fmt.Errorf("failed to read request body: %w", err))
+			return
+		}
 
 		if err := json.Unmarshal(bodyBytes, request); err != nil {
-			http.Error(w, "invalid JSON payload", http.StatusBadRequest)
+			httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
 			return
 		}
 
-		w.Header().Set("Content-Type", "application/json")
 		res, err := api(ctx, *request)
 		if err != nil {
-			slog.Error("error in api handler", "path", r.URL, "request", request, "err", err)
-			var httpError = httperrors.From(err)
-			w.WriteHeader(httpError.HttpStatus)
-			_ = json.NewEncoder(w).Encode(httpError)
+			httperrors.Write(w, r, err)
 			return
 		}
+		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		_ = json.NewEncoder(w).Encode(res)
 	}
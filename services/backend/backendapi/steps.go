@@ -0,0 +1,172 @@
+package backendapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/google/uuid"
+)
+
+// NewAdminHandler serves the conversation endpoints reserved for admins, such
+// as the timeline of tools the agent used, the history they belong to and the
+// conversation's handoffs to a human.
+func NewAdminHandler(svc backend.ConversationService,
+	adminMiddleware func(handler http.Handler) http.Handler) http.Handler {
+	h := &httpHandler{
+		svc: svc,
+	}
+
+	h.HandleFunc("/conversations/steps/", h.conversationSteps())
+	h.HandleFunc("/conversations/history/", h.conversationHistory())
+	h.HandleFunc("/conversations/status/", h.conversationStatus())
+	return adminMiddleware(h)
+}
+
+type conversationStep struct {
+	ID              string   `json:"id"`
+	Tool            string   `json:"tool"`
+	Arguments       []string `json:"arguments"`
+	DurationMs      int64    `json:"duration_ms"`
+	Outcome         string   `json:"outcome"`
+	Result          string   `json:"result"`
+	ResultTruncated bool     `json:"result_truncated"`
+	StartedAt       string   `json:"started_at"`
+}
+
+// conversationSteps lists the tools the agent used in a conversation, oldest
+// first, so a thread can be audited or replayed.
+func (h *httpHandler) conversationSteps() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		ConversationID string `json:"conversation_id"`
+	}
+	type response struct {
+		Steps []conversationStep `json:"steps"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		conversationID, err := uuid.Parse(req.ConversationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid conversation_id", "conversation_id")
+		}
+
+		steps, err := h.svc.ConversationSteps(ctx, backend.ConversationStepsQuery{ConversationID: conversationID})
+		if err != nil {
+			return response{}, err
+		}
+
+		return response{Steps: newConversationSteps(steps)}, nil
+	})
+}
+
+type conversationMessage struct {
+	ID         string             `json:"id"`
+	SenderID   string             `json:"sender_id"`
+	SenderName string             `json:"sender_name"`
+	Text       string             `json:"text"`
+	IsBot      bool               `json:"is_bot"`
+	CreatedAt  string             `json:"created_at"`
+	Steps      []conversationStep `json:"steps"`
+}
+
+// conversationHistory lists a conversation's messages, oldest first, with the
+// tools the agent used in each turn under the user message that started it.
+func (h *httpHandler) conversationHistory() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		ConversationID string `json:"conversation_id"`
+	}
+	type response struct {
+		Messages []conversationMessage `json:"messages"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		conversationID, err := uuid.Parse(req.ConversationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid conversation_id", "conversation_id")
+		}
+
+		messages, err := h.svc.ConversationHistory(ctx, backend.ConversationHistoryQuery{ConversationID: conversationID})
+		if err != nil {
+			return response{}, err
+		}
+
+		entries := make([]conversationMessage, len(messages))
+		for i, message := range messages {
+			entries[i] = conversationMessage{
+				ID:         message.ID.String(),
+				SenderID:   message.SenderID,
+				SenderName: message.SenderName,
+				Text:       message.Text,
+				IsBot:      message.IsBot,
+				CreatedAt:  message.CreatedAt.Format(time.RFC3339),
+				Steps:      newConversationSteps(message.Steps),
+			}
+		}
+		return response{Messages: entries}, nil
+	})
+}
+
+type conversationStatusChange struct {
+	Status    string `json:"status"`
+	ChangedBy string `json:"changed_by"`
+	Reason    string `json:"reason"`
+	ChangedAt string `json:"changed_at"`
+}
+
+// conversationStatus reports whether a conversation is escalated to a human
+// and every status change it went through, oldest first.
+func (h *httpHandler) conversationStatus() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		ConversationID string `json:"conversation_id"`
+	}
+	type response struct {
+		Status  string                     `json:"status"`
+		Changes []conversationStatusChange `json:"changes"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		conversationID, err := uuid.Parse(req.ConversationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid conversation_id", "conversation_id")
+		}
+
+		history, err := h.svc.ConversationStatus(ctx, backend.ConversationStatusQuery{ConversationID: conversationID})
+		if err != nil {
+			return response{}, err
+		}
+
+		changes := make([]conversationStatusChange, len(history.Changes))
+		for i, change := range history.Changes {
+			changes[i] = conversationStatusChange{
+				Status:    string(change.Status),
+				ChangedBy: change.ChangedBy,
+				Reason:    change.Reason,
+				ChangedAt: change.ChangedAt.Format(time.RFC3339),
+			}
+		}
+		return response{Status: string(history.Status), Changes: changes}, nil
+	})
+}
+
+func newConversationSteps(steps []backend.ConversationStep) []conversationStep {
+	entries := make([]conversationStep, len(steps))
+	for i, step := range steps {
+		arguments := step.Arguments
+		if arguments == nil {
+			arguments = []string{}
+		}
+		entries[i] = conversationStep{
+			ID:              step.ID.String(),
+			Tool:            step.Tool,
+			Arguments:       arguments,
+			DurationMs:      step.Duration.Milliseconds(),
+			Outcome:         string(step.Outcome),
+			Result:          step.Result,
+			ResultTruncated: step.ResultTruncated,
+			StartedAt:       step.StartedAt.Format(time.RFC3339),
+		}
+	}
+	return entries
+}
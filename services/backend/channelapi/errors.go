@@ -0,0 +1,13 @@
+package channelapi
+
+import (
+	"net/http"
+
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+)
+
+var errorMappings = []httperrors.Mapping{
+	{Target: domain.ErrInvalidChannelContext, HttpStatus: http.StatusBadRequest, Code: httperrors.CodeValidation},
+	{Target: domain.ErrWorkspaceNotLinked, HttpStatus: http.StatusNotFound, Code: httperrors.CodeNotFound},
+}
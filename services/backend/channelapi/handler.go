@@ -0,0 +1,150 @@
+package channelapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/google/uuid"
+)
+
+type httpHandler struct {
+	http.ServeMux
+	svc backend.ConversationService
+}
+
+func (h *httpHandler) init() {
+	h.HandleFunc("/channels/context/configure/", h.configureContext())
+	h.HandleFunc("/channels/context/list/", h.listContexts())
+}
+
+func NewHandler(conversationService backend.ConversationService,
+	authMiddleware func(handler http.Handler) http.Handler) http.Handler {
+	h := &httpHandler{
+		svc: conversationService,
+	}
+
+	h.init()
+	return authMiddleware(h)
+}
+
+type channelContext struct {
+	TeamID               string   `json:"team_id"`
+	ChannelID            string   `json:"channel_id"`
+	ChannelName          string   `json:"channel_name,omitempty"`
+	Repositories         []string `json:"repositories"`
+	KubernetesNamespaces []string `json:"kubernetes_namespaces"`
+	GCPProjects          []string `json:"gcp_projects"`
+	UpdatedAt            string   `json:"updated_at,omitempty"`
+}
+
+func toChannelContext(c backend.ChannelContext) channelContext {
+	resp := channelContext{
+		TeamID:               c.TeamID,
+		ChannelID:            c.ChannelID,
+		ChannelName:          c.ChannelName,
+		Repositories:         nonNil(c.Repositories),
+		KubernetesNamespaces: nonNil(c.KubernetesNamespaces),
+		GCPProjects:          nonNil(c.GCPProjects),
+	}
+	if !c.UpdatedAt.IsZero() {
+		resp.UpdatedAt = c.UpdatedAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func (h *httpHandler) configureContext() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		OrganizationID       string   `json:"organization_id"`
+		TeamID               string   `json:"team_id,omitempty"`
+		ChannelID            string   `json:"channel_id"`
+		Repositories         []string `json:"repositories"`
+		KubernetesNamespaces []string `json:"kubernetes_namespaces"`
+		GCPProjects          []string `json:"gcp_projects"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (channelContext, error) {
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return channelContext{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+		if req.ChannelID == "" {
+			return channelContext{}, httperrors.Validation("channel_id is required", "channel_id")
+		}
+
+		configured, err := h.svc.ConfigureChannelContext(ctx, backend.ConfigureChannelContextCommand{
+			OrganizationID:       organizationID,
+			TeamID:               req.TeamID,
+			ChannelID:            req.ChannelID,
+			Repositories:         req.Repositories,
+			KubernetesNamespaces: req.KubernetesNamespaces,
+			GCPProjects:          req.GCPProjects,
+		})
+		if err != nil {
+			return channelContext{}, err
+		}
+
+		return toChannelContext(configured), nil
+	})
+}
+
+func (h *httpHandler) listContexts() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		OrganizationID string `json:"organization_id"`
+	}
+	type response struct {
+		Channels []channelContext `json:"channels"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		contexts, err := h.svc.ChannelContexts(ctx, backend.ChannelContextsQuery{OrganizationID: organizationID})
+		if err != nil {
+			return response{}, err
+		}
+
+		resp := response{Channels: make([]channelContext, len(contexts))}
+		for i, c := range contexts {
+			resp.Channels[i] = toChannelContext(c)
+		}
+		return resp, nil
+	})
+}
+
+func ApiHandlerFunc[T any, R any](handler func(context.Context, T) (R, error)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var request T
+		if r.Method == http.MethodPost && r.Body != nil {
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
+				return
+			}
+		}
+
+		response, err := handler(ctx, request)
+		if err != nil {
+			httperrors.Write(w, r, err, errorMappings...)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"log/slog"
@@ -13,8 +15,12 @@ import (
 	"time"
 
 	agentclient "github.com/73ai/infragpt/services/agent/src/client/go"
+	"github.com/73ai/infragpt/services/backend"
 	"github.com/73ai/infragpt/services/backend/backendapi"
+	"github.com/73ai/infragpt/services/backend/channelapi"
 	"github.com/73ai/infragpt/services/backend/deviceapi"
+	"github.com/73ai/infragpt/services/backend/featureapi"
+	"github.com/73ai/infragpt/services/backend/feedbackapi"
 	"github.com/73ai/infragpt/services/backend/identityapi"
 	"github.com/73ai/infragpt/services/backend/integrationapi"
 	"github.com/73ai/infragpt/services/backend/internal/conversationsvc"
@@ -22,11 +28,34 @@ import (
 	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/supporting/agent"
 	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/supporting/postgres"
 	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/supporting/slack"
+	"github.com/73ai/infragpt/services/backend/internal/devenv"
 	"github.com/73ai/infragpt/services/backend/internal/devicesvc"
+	"github.com/73ai/infragpt/services/backend/internal/executionsvc"
+	"github.com/73ai/infragpt/services/backend/internal/featuresvc"
+	"github.com/73ai/infragpt/services/backend/internal/generic/envconfig"
 	"github.com/73ai/infragpt/services/backend/internal/generic/httplog"
+	"github.com/73ai/infragpt/services/backend/internal/generic/leader"
+	"github.com/73ai/infragpt/services/backend/internal/generic/maintenance"
 	"github.com/73ai/infragpt/services/backend/internal/generic/postgresconfig"
+	"github.com/73ai/infragpt/services/backend/internal/generic/preflight"
+	"github.com/73ai/infragpt/services/backend/internal/generic/recovery"
+	"github.com/73ai/infragpt/services/backend/internal/generic/redact"
+	"github.com/73ai/infragpt/services/backend/internal/generic/secrets"
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
 	"github.com/73ai/infragpt/services/backend/internal/identitysvc"
 	"github.com/73ai/infragpt/services/backend/internal/integrationsvc"
+	"github.com/73ai/infragpt/services/backend/internal/onboardingsvc"
+	"github.com/73ai/infragpt/services/backend/internal/organizationsvc"
+	"github.com/73ai/infragpt/services/backend/internal/quotasvc"
+	"github.com/73ai/infragpt/services/backend/maintenanceapi"
+	"github.com/73ai/infragpt/services/backend/migrations"
+	"github.com/73ai/infragpt/services/backend/onboardingapi"
+	"github.com/73ai/infragpt/services/backend/organizationapi"
+	"github.com/73ai/infragpt/services/backend/preflightapi"
+	"github.com/73ai/infragpt/services/backend/publicapi"
+	"github.com/73ai/infragpt/services/backend/quotaapi"
+	"github.com/73ai/infragpt/services/backend/slackuserapi"
+	"github.com/google/uuid"
 	"github.com/m-mizutani/masq"
 	"golang.org/x/sync/errgroup"
 
@@ -38,6 +67,9 @@ import (
 func main() {
 	time.Local = time.UTC
 
+	preflightOnly := flag.Bool("preflight", false, "check the connections to external services, print the results and exit")
+	flag.Parse()
+
 	ctx := context.Background()
 	g, ctx := errgroup.WithContext(ctx)
 
@@ -52,19 +84,50 @@ func main() {
 	}
 
 	type Config struct {
-		LogLevel     string                `mapstructure:"log_level"`
-		Port         int                   `mapstructure:"port"`
-		GrpcPort     int                   `mapstructure:"grpc_port"`
-		HttpLog      bool                  `mapstructure:"http_log"`
-		Slack        slack.Config          `mapstructure:"slack"`
-		Database     postgresconfig.Config `mapstructure:"database"`
-		Agent        agentclient.Config    `mapstructure:"agent"`
-		Identity     identitysvc.Config    `mapstructure:"identity"`
-		Integrations integrationsvc.Config `mapstructure:"integrations"`
+		LogLevel       string                             `mapstructure:"log_level"`
+		Port           int                                `mapstructure:"port"`
+		GrpcPort       int                                `mapstructure:"grpc_port"`
+		PublicGrpcPort int                                `mapstructure:"public_grpc_port"`
+		HttpLog        bool                               `mapstructure:"http_log"`
+		Tracing        tracing.Config                     `mapstructure:"tracing"`
+		Recovery       recovery.Config                    `mapstructure:"recovery"`
+		Slack          slack.Config                       `mapstructure:"slack"`
+		Database       postgresconfig.Config              `mapstructure:"database"`
+		Agent          agentclient.Config                 `mapstructure:"agent"`
+		Models         conversationsvc.ModelConfig        `mapstructure:"models"`
+		ChannelIntro   conversationsvc.IntroConfig        `mapstructure:"channel_intro"`
+		Identity       identitysvc.Config                 `mapstructure:"identity"`
+		Device         devicesvc.Config                   `mapstructure:"device"`
+		Integrations   integrationsvc.Config              `mapstructure:"integrations"`
+		FeatureFlags   featuresvc.Config                  `mapstructure:"feature_flags"`
+		Execution      executionsvc.Config                `mapstructure:"execution"`
+		Maintenance    maintenance.Config                 `mapstructure:"maintenance"`
+		Redaction      redact.Config                      `mapstructure:"redaction"`
+		Notification   conversationsvc.NotificationConfig `mapstructure:"notifications"`
+		Leader         leader.Config                      `mapstructure:"leader_election"`
+		Secrets        secrets.Config                     `mapstructure:"secrets"`
+		Quotas         quotasvc.Config                    `mapstructure:"quotas"`
+		Preflight      preflight.Config                   `mapstructure:"preflight"`
+		Dev            devenv.Config                      `mapstructure:"dev"`
+	}
+
+	if yamlMap == nil {
+		yamlMap = make(map[string]any)
 	}
 
 	var c Config
-	if err := mapstructure.Decode(yamlMap, &c); err != nil {
+	// Environment variables such as INFRAGPT_DATABASE_PASSWORD take precedence
+	// over config.yaml, so secrets can be injected without mounting files.
+	envOverrides := envconfig.Override(yamlMap, &c, "INFRAGPT", os.LookupEnv)
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: envconfig.DecodeHook(),
+		Result:     &c,
+	})
+	if err != nil {
+		log.Fatalf("Error creating config decoder: %v", err)
+	}
+	if err := decoder.Decode(yamlMap); err != nil {
 		log.Fatalf("Error decoding config: %v", err)
 	}
 
@@ -74,20 +137,64 @@ func main() {
 	}
 
 	// NOTE: masq library sanitizes sensitive data in logs
+	masqOptions := []masq.Option{masq.WithTag("sensitive")}
+	for _, field := range redact.SensitiveFieldNames {
+		masqOptions = append(masqOptions, masq.WithFieldName(field))
+	}
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		Level: level,
-		ReplaceAttr: masq.New(
-			masq.WithFieldName("password"),
-			masq.WithFieldName("token"),
-			masq.WithFieldName("secret"),
-			masq.WithFieldName("key"),
-			masq.WithFieldName("credential"),
-			masq.WithFieldName("auth"),
-			masq.WithTag("sensitive"),
-		),
+		Level:       level,
+		ReplaceAttr: masq.New(masqOptions...),
 	}))
 	slog.SetDefault(logger)
 
+	if len(envOverrides) > 0 {
+		slog.Info("backend: config keys overridden from environment", "keys", envOverrides)
+	}
+
+	if c.Dev.Enabled {
+		if err := runDev(ctx, c.Dev, c.Port, c.HttpLog); err != nil {
+			panic(fmt.Errorf("error running development mode: %w", err))
+		}
+		return
+	}
+
+	secretResolver := c.Secrets.New()
+	for _, value := range []*string{
+		&c.Database.Password,
+		&c.Database.ReadReplicaDSN,
+		&c.Integrations.GitHub.PrivateKey,
+		&c.Integrations.GitHub.WebhookSecret,
+		&c.Integrations.AzureDevOps.WebhookSecret,
+		&c.Identity.Clerk.WebhookSecret,
+	} {
+		if *value, err = secretResolver.Resolve(ctx, *value); err != nil {
+			log.Fatalf("Error resolving secret: %v", err)
+		}
+	}
+
+	shutdownTracing, err := c.Tracing.New(ctx)
+	if err != nil {
+		panic(fmt.Errorf("error configuring tracing: %w", err))
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Error("backend: failed to flush traces and metrics", "error", err)
+		}
+	}()
+
+	flushPanicReports, err := c.Recovery.New()
+	if err != nil {
+		panic(fmt.Errorf("error configuring panic reporting: %w", err))
+	}
+	defer flushPanicReports()
+
+	maintenanceMode := c.Maintenance.New()
+
+	redactor, err := c.Redaction.New()
+	if err != nil {
+		panic(fmt.Errorf("error configuring redaction: %w", err))
+	}
+
 	slackConfig := c.Slack
 	db, err := postgres.Config{Config: c.Database}.New()
 	if err != nil {
@@ -96,14 +203,94 @@ func main() {
 	slackConfig.WorkSpaceTokenRepository = db
 	slackConfig.ChannelRepository = db
 
+	var agentService domain.AgentService
+	c.Agent.Timeout = 5 * 60 * time.Second
+	c.Agent.ConnectTimeout = 10 * time.Second
+	c.Agent.DialOptions = append(c.Agent.DialOptions, tracing.GRPCDialOption())
+	agentClient, agentErr := agent.NewClient(&c.Agent)
+	if agentErr != nil {
+		log.Printf("Failed to create agent client, falling back to DumbClient: %v", agentErr)
+	} else {
+		agentService = agentClient
+	}
+
+	checks := []preflight.Check{
+		{Name: "database", Run: func(ctx context.Context) error { return checkDatabase(ctx, db.DB()) }},
+		{Name: "slack", Run: c.Slack.Check},
+		{Name: "agent", Run: func(ctx context.Context) error {
+			if agentErr != nil {
+				return fmt.Errorf("failed to create agent client, check agent.endpoint: %w", agentErr)
+			}
+			return agentClient.Ready(ctx)
+		}},
+		{Name: "clerk", Run: c.Identity.Clerk.Check},
+	}
+	if c.Integrations.GitHub.AppID != "" {
+		checks = append(checks, preflight.Check{Name: "github", Run: c.Integrations.GitHub.Check})
+	}
+	checker := c.Preflight.New(checks)
+	if *preflightOnly {
+		if !printPreflight(checker.Run(ctx)) {
+			os.Exit(1)
+		}
+		return
+	}
+
 	identityService := c.Identity.New(db.DB())
+
+	c.FeatureFlags.Database = db.DB()
+	featureFlagService := c.FeatureFlags.New()
+
+	c.Quotas.Database = db.DB()
+	quotaService, err := c.Quotas.New()
+	if err != nil {
+		panic(fmt.Errorf("error configuring quotas: %w", err))
+	}
+
 	c.Integrations.Database = db.DB()
+	c.Integrations.ReadDatabase = db.ReadDB()
+	c.Integrations.FeatureFlags = featureFlagService
+	integrationStatus := &integrationsvc.StatusNotifier{}
+	c.Integrations.StatusListener = integrationStatus
+	credentialAccessAlerts := &integrationsvc.CredentialAccessAlertNotifier{}
+	c.Integrations.CredentialAccessAlertListener = credentialAccessAlerts
+	repositoryEvents := &integrationsvc.RepositoryEventNotifier{}
+	c.Integrations.RepositoryEventListener = repositoryEvents
 	integrationService, err := c.Integrations.New()
 	if err != nil {
 		panic(fmt.Errorf("error creating integration service: %w", err))
 	}
 
-	deviceService := devicesvc.Config{Database: db.DB()}.New()
+	if args := flag.Args(); len(args) > 0 {
+		switch args[0] {
+		case "remove-retried-messages":
+			err = runRemoveRetriedMessages(ctx, args, db)
+		default:
+			err = runBundleCommand(ctx, args, integrationService)
+		}
+		if err != nil {
+			log.Fatalf("%s: %v", args[0], err)
+		}
+		return
+	}
+
+	c.Device.Database = db.DB()
+	deviceService := c.Device.New()
+
+	c.Execution.Database = db.DB()
+	c.Execution.Integrations = integrationService
+	conversationSteps := &executionsvc.StepNotifier{}
+	c.Execution.StepListener = conversationSteps
+	executionService := c.Execution.New()
+
+	// The conversation service reports Slack and conversation steps once it is
+	// built, since it consults onboarding itself.
+	onboardingSteps := &onboardingsvc.StepNotifier{}
+	onboardingService := onboardingsvc.Config{
+		Database:  db.DB(),
+		Reporters: []backend.OnboardingReporter{identityService, integrationService},
+		Listener:  onboardingSteps,
+	}.New()
 
 	authMiddleware := c.Identity.Clerk.NewAuthMiddleware()
 
@@ -112,31 +299,47 @@ func main() {
 		panic(fmt.Errorf("error connecting to slack: %w", err))
 	}
 
-	var agentService domain.AgentService
-	c.Agent.Timeout = 5 * 60 * time.Second
-	c.Agent.ConnectTimeout = 10 * time.Second
-	agentClient, err := agent.NewClient(&c.Agent)
-	if err != nil {
-		log.Printf("Failed to create agent client, falling back to DumbClient: %v", err)
-	} else {
-		agentService = agentClient
-	}
-
 	svcConfig := conversationsvc.Config{
-		SlackGateway:           sr,
-		IntegrationRepository:  db,
-		ConversationRepository: db,
-		ChannelRepository:      db,
-		AgentService:           agentService,
+		SlackGateway:               sr,
+		IntegrationRepository:      db,
+		ConversationRepository:     db,
+		HistoryRepository:          db.ReadReplica(),
+		ChannelRepository:          db,
+		FeedbackRepository:         db,
+		UserMappingRepository:      db,
+		OrganizationDataRepository: db,
+		AgentService:               agentService,
+		Models:                     c.Models,
+		Intro:                      c.ChannelIntro,
+		FeatureFlags:               featureFlagService,
+		Maintenance:                maintenanceMode,
+		Integrations:               integrationService,
+		Identity:                   identityService,
+		Redactor:                   redactor,
+		Notifications:              c.Notification,
+		Quotas:                     quotaService,
+		Onboarding:                 onboardingService,
 	}
 
 	svc, err := svcConfig.New(ctx)
 	if err != nil {
 		panic(fmt.Errorf("error connecting to slack: %w", err))
 	}
-
+	integrationStatus.Listen(svc)
+	credentialAccessAlerts.Listen(svc)
+	repositoryEvents.Listen(svc)
+	conversationSteps.Listen(svc)
+	onboardingSteps.Listen(svc)
+	onboardingService.AddReporter(svc)
+
+	// Every replica serves HTTP and gRPC, but only the lease holder subscribes
+	// to Slack so each event is handled once.
+	slackLeader := c.Leader
+	slackLeader.Name = "slack_subscription"
+	slackLeader.Store = leader.NewPostgresStore(db.DB())
+	slackElector := slackLeader.New()
 	g.Go(func() error {
-		err = svc.SubscribeSlackNotifications(ctx)
+		err := slackElector.Run(ctx, svc.SubscribeSlackNotifications)
 		if err == nil || errors.Is(err, context.Canceled) {
 			slog.Info("slack notification subscription stopped")
 		}
@@ -146,36 +349,145 @@ func main() {
 		return nil
 	})
 
+	// Integrations go last: their credentials are needed to revoke access with
+	// the providers, and a failed revocation keeps them for the next run.
+	organizationService := organizationsvc.Config{
+		Steps: []organizationsvc.Step{
+			{Name: "conversations", Owner: svc},
+			{Name: "devices", Owner: deviceService},
+			{Name: "integrations", Owner: integrationService},
+		},
+	}.New()
+
 	coreAPIHandler := backendapi.NewHandler(svc)
 	identityAPIHandler := identityapi.NewHandler(identityService, authMiddleware)
 	integrationAPIHandler := integrationapi.NewHandler(integrationService, authMiddleware)
-	deviceAPIHandler := deviceapi.NewHandler(deviceService, integrationService, authMiddleware)
+	channelAPIHandler := channelapi.NewHandler(svc, authMiddleware)
+	feedbackAPIHandler := feedbackapi.NewHandler(svc, authMiddleware)
+	deviceAPIHandler := deviceapi.NewHandler(deviceService, integrationService, identityService, authMiddleware, c.Identity.Clerk.NewSessionAuthMiddleware())
+	adminMiddleware := featureapi.AdminTokenMiddleware(c.FeatureFlags.AdminToken)
+	integrationAdminAPIHandler := integrationapi.NewAdminHandler(integrationService, adminMiddleware)
+	conversationAdminAPIHandler := backendapi.NewAdminHandler(svc, adminMiddleware)
+	featureAPIHandler := featureapi.NewHandler(featureFlagService, adminMiddleware)
+	quotaAPIHandler := quotaapi.NewHandler(quotaService, adminMiddleware)
+	maintenanceAPIHandler := maintenanceapi.NewHandler(maintenanceMode, adminMiddleware)
+	slackUserAPIHandler := slackuserapi.NewHandler(svc, adminMiddleware)
+	organizationAPIHandler := organizationapi.NewHandler(organizationService, adminMiddleware)
+	onboardingAPIHandler := onboardingapi.NewHandler(onboardingService, authMiddleware)
+	preflightAPIHandler := preflightapi.NewHandler(checker, adminMiddleware)
+	probeHandler := preflightapi.NewProbeHandler(checker)
+	webhookHandler := http.NewServeMux()
+	integrationService.RegisterWebhookRoutes(webhookHandler)
 
 	httpHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		defer func() {
-			if r := recover(); r != nil {
-				slog.Info("backend: http server panic", "recover", r)
-			}
-		}()
+		if r.URL.Path == "/livez" || r.URL.Path == "/readyz" {
+			probeHandler.ServeHTTP(w, r)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/preflight/") {
+			preflightAPIHandler.ServeHTTP(w, r)
+			return
+		}
 		if strings.HasPrefix(r.URL.Path, "/identity/") {
 			identityAPIHandler.ServeHTTP(w, r)
 			return
 		}
+		if strings.HasPrefix(r.URL.Path, "/integrations/credential-access/") || strings.HasPrefix(r.URL.Path, "/integrations/grants/") {
+			integrationAdminAPIHandler.ServeHTTP(w, r)
+			return
+		}
 		if strings.HasPrefix(r.URL.Path, "/integrations/") {
 			integrationAPIHandler.ServeHTTP(w, r)
 			return
 		}
+		if strings.HasPrefix(r.URL.Path, "/channels/") {
+			channelAPIHandler.ServeHTTP(w, r)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/feedback/") {
+			feedbackAPIHandler.ServeHTTP(w, r)
+			return
+		}
 		if strings.HasPrefix(r.URL.Path, "/device/") {
 			deviceAPIHandler.ServeHTTP(w, r)
 			return
 		}
+		if strings.HasPrefix(r.URL.Path, "/features/") {
+			featureAPIHandler.ServeHTTP(w, r)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/quotas/") {
+			quotaAPIHandler.ServeHTTP(w, r)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/maintenance/") {
+			maintenanceAPIHandler.ServeHTTP(w, r)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/slack-users/") {
+			slackUserAPIHandler.ServeHTTP(w, r)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/organizations/") {
+			organizationAPIHandler.ServeHTTP(w, r)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/onboarding/") {
+			onboardingAPIHandler.ServeHTTP(w, r)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/conversations/") {
+			conversationAdminAPIHandler.ServeHTTP(w, r)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/webhooks/") {
+			webhookHandler.ServeHTTP(w, r)
+			return
+		}
 		coreAPIHandler.ServeHTTP(w, r)
 	})
 
+	// Everything not listed here mutates state and is rejected in read-only mode.
+	readOnlyMiddleware := maintenanceMode.Middleware(
+		"/maintenance/status/",
+		"/maintenance/set/",
+		"/identity/organization/",
+		"/identity/me/",
+		"/integrations/list/",
+		"/integrations/status/",
+		"/integrations/repositories/",
+		"/integrations/permissions/",
+		"/integrations/activity/",
+		"/integrations/activity/export/",
+		"/integrations/credential-access/",
+		"/integrations/validate/",
+		"/integrations/triggers/list/",
+		"/channels/context/list/",
+		"/feedback/summary/",
+		"/device/credentials/gcp",
+		"/device/credentials/gke",
+		"/device/credentials/objectstore",
+		"/device/history/pull",
+		"/device/api-keys/list",
+		"/features/list/",
+		"/quotas/usage/",
+		"/slack-users/list/",
+		"/onboarding/status/",
+		"/conversations/steps/",
+		"/conversations/history/",
+		"/conversations/status/",
+		"/livez",
+		"/readyz",
+		"/preflight/run/",
+		// GitHub does not redeliver webhooks that fail, so they are handled
+		// in read-only mode rather than lost.
+		"/webhooks/*",
+	)
+
 	httpServer := &http.Server{
 		Addr:        fmt.Sprintf(":%d", c.Port),
 		BaseContext: func(net.Listener) context.Context { return ctx },
-		Handler:     httplog.Middleware(c.HttpLog)(corsHandler(httpHandler)),
+		Handler:     tracing.Middleware("backend.http")(httplog.Middleware(c.HttpLog)(recovery.Middleware("backend.http")(corsHandler(readOnlyMiddleware(httpHandler))))),
 	}
 
 	g.Go(func() error {
@@ -189,7 +501,8 @@ func main() {
 		return fmt.Errorf("http server failed: %w", err)
 	})
 
-	grpcServer := backendapi.NewGRPCServer(svc)
+	grpcOptions := append(recovery.GRPCServerOptions(), tracing.GRPCServerOption())
+	grpcServer := backendapi.NewGRPCServer(svc, executionService, grpcOptions...)
 	grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%d", c.GrpcPort))
 	if err != nil {
 		panic(fmt.Errorf("error creating grpc listener: %w", err))
@@ -205,6 +518,25 @@ func main() {
 		return nil
 	})
 
+	// The public API gets its own server and port so customers who can reach
+	// it can't call the internal, unauthenticated services.
+	if c.PublicGrpcPort != 0 {
+		publicServer := publicapi.NewServer(deviceService, svc, integrationService, grpcOptions...)
+		publicListener, err := net.Listen("tcp", fmt.Sprintf(":%d", c.PublicGrpcPort))
+		if err != nil {
+			panic(fmt.Errorf("error creating public grpc listener: %w", err))
+		}
+
+		g.Go(func() error {
+			slog.Info("backend: public grpc server starting", "port", c.PublicGrpcPort)
+			if err := publicServer.Serve(publicListener); err != nil {
+				slog.Error("backend: public grpc server failed", "error", err)
+				return fmt.Errorf("public grpc server failed: %w", err)
+			}
+			return nil
+		})
+	}
+
 	g.Go(func() error {
 		slog.Info("backend: identity service webhook server starting", "port", c.Identity.Clerk.Port)
 		err = identityService.Subscribe(ctx)
@@ -246,3 +578,164 @@ func corsHandler(h http.Handler) http.Handler {
 		h.ServeHTTP(w, r)
 	})
 }
+
+// runDev serves the backend from in-memory repositories with a simulated
+// GitHub App and seeded demo data. Nothing is persisted across restarts.
+func runDev(ctx context.Context, c devenv.Config, port int, httpLog bool) error {
+	env, err := c.New(ctx)
+	if err != nil {
+		return err
+	}
+	slog.Warn("backend: development mode, data is kept in memory only",
+		"clerk_user_id", env.Seed.ClerkUserID,
+		"clerk_org_id", env.Seed.ClerkOrgID,
+		"organization_id", env.Seed.OrganizationID,
+		"user_id", env.Seed.UserID,
+		"integration_id", env.Seed.IntegrationID,
+		"conversation_id", env.Seed.ConversationID)
+
+	httpServer := &http.Server{
+		Addr:        fmt.Sprintf(":%d", port),
+		BaseContext: func(net.Listener) context.Context { return ctx },
+		Handler:     httplog.Middleware(httpLog)(corsHandler(env.Handler)),
+	}
+	slog.Info("backend: http server starting", "port", port)
+	if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("http server failed: %w", err)
+	}
+	return nil
+}
+
+func checkDatabase(ctx context.Context, db *sql.DB) error {
+	if err := db.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to connect to the database, check database.host, port, user and password: %w", err)
+	}
+	pending, err := migrations.Pending(ctx, db)
+	if err != nil {
+		return fmt.Errorf("failed to check migrations: %w", err)
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%d migrations are not applied, apply them in order: %s", len(pending), strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// printPreflight writes one line per check and reports whether all passed.
+func printPreflight(results []preflight.Result) bool {
+	for _, r := range results {
+		if r.Passed {
+			fmt.Printf("PASS  %s\n", r.Name)
+		} else {
+			fmt.Printf("FAIL  %s: %s\n", r.Name, r.Message)
+		}
+	}
+	return len(preflight.Failed(results)) == 0
+}
+
+// slackRetryWindow covers Slack's event retries, which arrive up to about five
+// minutes after the first delivery.
+const slackRetryWindow = 6 * time.Minute
+
+// runRemoveRetriedMessages removes user messages that Slack retries stored
+// more than once before messages carried Slack event IDs:
+//
+//	backend remove-retried-messages [-window 6m] [-delete]
+//
+// Without -delete it only reports how many messages would be removed.
+func runRemoveRetriedMessages(ctx context.Context, args []string, db *postgres.BackendDB) error {
+	fs := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	window := fs.Duration("window", slackRetryWindow, "how long after a message a copy of it counts as a retry")
+	remove := fs.Bool("delete", false, "delete the retried messages instead of only counting them")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *window <= 0 || *window > 10*time.Minute {
+		return fmt.Errorf("-window must be between 0 and 10m, got %s", *window)
+	}
+
+	count, err := db.RemoveRetriedMessages(ctx, *window, !*remove)
+	if err != nil {
+		return err
+	}
+	if !*remove {
+		slog.Info("found retried messages, rerun with -delete to remove them", "count", count, "window", *window)
+		return nil
+	}
+	slog.Info("removed retried messages", "count", count, "window", *window)
+	return nil
+}
+
+// bundlePassphraseEnv names the environment variable holding the passphrase for
+// integration bundles so it never ends up in shell history.
+const bundlePassphraseEnv = "INFRAGPT_BUNDLE_PASSPHRASE"
+
+// runBundleCommand runs the disaster recovery subcommands:
+//
+//	backend export-integrations -org <organization_id> -out bundle.json
+//	backend import-integrations -org <organization_id> -in bundle.json
+func runBundleCommand(ctx context.Context, args []string, svc backend.IntegrationService) error {
+	passphrase := os.Getenv(bundlePassphraseEnv)
+	if passphrase == "" {
+		return fmt.Errorf("%s must be set", bundlePassphraseEnv)
+	}
+
+	fs := flag.NewFlagSet(args[0], flag.ContinueOnError)
+	org := fs.String("org", "", "organization ID")
+	in := fs.String("in", "", "bundle to import")
+	out := fs.String("out", "", "file to write the bundle to")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	organizationID, err := uuid.Parse(*org)
+	if err != nil {
+		return fmt.Errorf("invalid -org: %w", err)
+	}
+
+	switch args[0] {
+	case "export-integrations":
+		if *out == "" {
+			return fmt.Errorf("-out is required")
+		}
+		bundle, err := svc.ExportIntegrations(ctx, backend.ExportIntegrationsQuery{
+			OrganizationID: organizationID,
+			Passphrase:     passphrase,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to export integrations: %w", err)
+		}
+		if err := os.WriteFile(*out, bundle, 0o600); err != nil {
+			return fmt.Errorf("failed to write bundle: %w", err)
+		}
+		slog.Info("exported integrations", "organization_id", organizationID, "path", *out)
+	case "import-integrations":
+		if *in == "" {
+			return fmt.Errorf("-in is required")
+		}
+		bundle, err := os.ReadFile(*in)
+		if err != nil {
+			return fmt.Errorf("failed to read bundle: %w", err)
+		}
+		result, err := svc.ImportIntegrations(ctx, backend.ImportIntegrationsCommand{
+			OrganizationID: organizationID,
+			Bundle:         bundle,
+			Passphrase:     passphrase,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to import integrations: %w", err)
+		}
+		for _, i := range result.Restored {
+			slog.Info("restored integration", "integration_id", i.ID, "connector_type", i.ConnectorType)
+		}
+		for _, i := range result.NeedsReauthorization {
+			slog.Warn("integration needs reauthorization", "integration_id", i.ID, "connector_type", i.ConnectorType)
+		}
+		for _, i := range result.Skipped {
+			slog.Warn("skipped integration, connector unsupported or already connected", "connector_type", i.ConnectorType)
+		}
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+
+	return nil
+}
@@ -2,12 +2,34 @@ package backend
 
 import (
 	"context"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type ConversationService interface {
 	CompleteSlackIntegration(context.Context, CompleteSlackIntegrationCommand) error
 
 	SendReply(context.Context, SendReplyCommand) error
+
+	ConfigureChannelContext(context.Context, ConfigureChannelContextCommand) (ChannelContext, error)
+	ChannelContexts(context.Context, ChannelContextsQuery) ([]ChannelContext, error)
+
+	FeedbackSummary(context.Context, FeedbackSummaryQuery) (FeedbackSummary, error)
+
+	SlackUserMappings(context.Context, SlackUserMappingsQuery) ([]SlackUserMapping, error)
+	MapSlackUser(context.Context, MapSlackUserCommand) (SlackUserMapping, error)
+	UnmapSlackUser(context.Context, UnmapSlackUserCommand) error
+
+	ConversationSteps(context.Context, ConversationStepsQuery) ([]ConversationStep, error)
+	ConversationHistory(context.Context, ConversationHistoryQuery) ([]ConversationMessage, error)
+	ConversationStatus(context.Context, ConversationStatusQuery) (ConversationStatusHistory, error)
+
+	// StartConversation posts the message as a new thread in one of the
+	// organization's Slack channels and returns once it is posted; the agent
+	// answers in the thread in the background.
+	StartConversation(context.Context, StartConversationCommand) (Conversation, error)
+	Conversation(context.Context, ConversationQuery) (Conversation, error)
 }
 
 type CompleteSlackIntegrationCommand struct {
@@ -19,3 +41,224 @@ type SendReplyCommand struct {
 	ConversationID string
 	Message        string
 }
+
+// ChannelContext is the default context bound to a Slack channel and passed to
+// the agent for every conversation in that channel.
+type ChannelContext struct {
+	TeamID               string
+	ChannelID            string
+	ChannelName          string
+	Repositories         []string
+	KubernetesNamespaces []string
+	GCPProjects          []string
+	UpdatedAt            time.Time
+}
+
+// ConfigureChannelContextCommand replaces a channel's bindings. TeamID may be
+// empty when the organization has a single Slack workspace; empty bindings
+// clear the channel's context.
+type ConfigureChannelContextCommand struct {
+	OrganizationID       uuid.UUID
+	TeamID               string
+	ChannelID            string
+	Repositories         []string
+	KubernetesNamespaces []string
+	GCPProjects          []string
+}
+
+type ChannelContextsQuery struct {
+	OrganizationID uuid.UUID
+}
+
+type FeedbackBucket string
+
+const (
+	FeedbackBucketDay   FeedbackBucket = "day"
+	FeedbackBucketWeek  FeedbackBucket = "week"
+	FeedbackBucketMonth FeedbackBucket = "month"
+)
+
+// FeedbackSummaryQuery aggregates ratings of agent replies created in
+// [Since, Until). Zero values default to the last 30 days in daily buckets.
+type FeedbackSummaryQuery struct {
+	OrganizationID uuid.UUID
+	Bucket         FeedbackBucket
+	Since          time.Time
+	Until          time.Time
+}
+
+// FeedbackSummary reports satisfaction as the share of positive ratings; it is
+// zero when there are no ratings.
+type FeedbackSummary struct {
+	Positive         int
+	Negative         int
+	SatisfactionRate float64
+	// Buckets only include periods with at least one rating.
+	Buckets []FeedbackSummaryBucket
+}
+
+type FeedbackSummaryBucket struct {
+	Start            time.Time
+	Positive         int
+	Negative         int
+	SatisfactionRate float64
+}
+
+type SlackUserMappingSource string
+
+const (
+	// SlackUserMappingSourceEmail mappings were matched on the Slack profile
+	// email when the user first talked to the bot.
+	SlackUserMappingSourceEmail  SlackUserMappingSource = "email"
+	SlackUserMappingSourceManual SlackUserMappingSource = "manual"
+)
+
+// SlackUserMapping links a Slack user to the InfraGPT user acting on their
+// behalf in the workspace's organization.
+type SlackUserMapping struct {
+	TeamID         string
+	SlackUserID    string
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	Source         SlackUserMappingSource
+	UpdatedAt      time.Time
+}
+
+type SlackUserMappingsQuery struct {
+	OrganizationID uuid.UUID
+}
+
+// MapSlackUserCommand overrides the mapping of a Slack user, for when their
+// Slack email does not match their InfraGPT account. TeamID may be empty when
+// the organization has a single Slack workspace.
+type MapSlackUserCommand struct {
+	OrganizationID uuid.UUID
+	TeamID         string
+	SlackUserID    string
+	UserID         uuid.UUID
+}
+
+type UnmapSlackUserCommand struct {
+	OrganizationID uuid.UUID
+	TeamID         string
+	SlackUserID    string
+}
+
+type ConversationStepOutcome string
+
+const (
+	ConversationStepSucceeded ConversationStepOutcome = "succeeded"
+	ConversationStepFailed    ConversationStepOutcome = "failed"
+	ConversationStepDenied    ConversationStepOutcome = "denied"
+	ConversationStepTimedOut  ConversationStepOutcome = "timed_out"
+	// ConversationStepReported marks tools the agent reported using without
+	// running them through the backend, so only their name is known.
+	ConversationStepReported ConversationStepOutcome = "reported"
+	// ConversationStepReadOnly marks commands refused because the integration
+	// they needed was not granted write access.
+	ConversationStepReadOnly ConversationStepOutcome = "read_only"
+)
+
+// ConversationStep is a tool the agent used while answering in a
+// conversation, such as a command it ran through the execution service.
+type ConversationStep struct {
+	ID             uuid.UUID
+	ConversationID uuid.UUID
+	Tool           string
+	// Arguments have secrets redacted before they are stored.
+	Arguments []string
+	Duration  time.Duration
+	Outcome   ConversationStepOutcome
+	// Result holds the start of the tool's output.
+	Result          string
+	ResultTruncated bool
+	StartedAt       time.Time
+}
+
+type ConversationStepsQuery struct {
+	ConversationID uuid.UUID
+}
+
+// ConversationMessage is a message in a conversation's history. Steps holds
+// the tools the agent used answering a user message, oldest first; it is
+// empty for the agent's own messages.
+type ConversationMessage struct {
+	ID         uuid.UUID
+	SenderID   string
+	SenderName string
+	Text       string
+	IsBot      bool
+	CreatedAt  time.Time
+	Steps      []ConversationStep
+}
+
+type ConversationHistoryQuery struct {
+	ConversationID uuid.UUID
+	// OrganizationID, when set, limits the query to conversations in the
+	// organization's Slack workspaces.
+	OrganizationID uuid.UUID
+}
+
+// Conversation is a Slack thread the agent takes part in.
+type Conversation struct {
+	ID        uuid.UUID
+	TeamID    string
+	ChannelID string
+	ThreadTS  string
+	Status    ConversationStatus
+	CreatedAt time.Time
+}
+
+type ConversationQuery struct {
+	ConversationID uuid.UUID
+	// OrganizationID, when set, limits the query to conversations in the
+	// organization's Slack workspaces.
+	OrganizationID uuid.UUID
+}
+
+type StartConversationCommand struct {
+	OrganizationID uuid.UUID
+	// UserID is the InfraGPT user the conversation is started for.
+	UserID    uuid.UUID
+	ChannelID string
+	Message   string
+	// Model is the LLM model to use; empty means the default.
+	Model string
+}
+
+type ConversationStatus string
+
+const (
+	ConversationStatusActive ConversationStatus = "active"
+	// ConversationStatusEscalated conversations were handed off to a human;
+	// the agent does not reply in them until someone resumes it.
+	ConversationStatusEscalated ConversationStatus = "escalated"
+)
+
+// ConversationStatusChange records who moved a conversation to Status and
+// why. ChangedBy is a Slack user ID, or "agent" when the agent asked for a
+// handoff.
+type ConversationStatusChange struct {
+	ConversationID uuid.UUID
+	Status         ConversationStatus
+	ChangedBy      string
+	Reason         string
+	ChangedAt      time.Time
+}
+
+type ConversationStatusQuery struct {
+	ConversationID uuid.UUID
+}
+
+// ConversationStatusHistory is a conversation's current status and the
+// changes that led to it, oldest first.
+type ConversationStatusHistory struct {
+	Status  ConversationStatus
+	Changes []ConversationStatusChange
+}
+
+// ConversationStepListener is told about each tool run on behalf of a
+// conversation.
+type ConversationStepListener interface {
+	ConversationStepTaken(ctx context.Context, step ConversationStep)
+}
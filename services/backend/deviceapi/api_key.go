@@ -0,0 +1,169 @@
+package deviceapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/google/uuid"
+)
+
+type apiKey struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Prefix    string `json:"prefix"`
+	UserID    string `json:"user_id"`
+	CreatedAt string `json:"created_at"`
+	RevokedAt string `json:"revoked_at,omitempty"`
+}
+
+func newAPIKey(key domain.APIKey) apiKey {
+	k := apiKey{
+		ID:        key.ID.String(),
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		UserID:    key.UserID.String(),
+		CreatedAt: key.CreatedAt.Format(time.RFC3339),
+	}
+	if key.RevokedAt != nil {
+		k.RevokedAt = key.RevokedAt.Format(time.RFC3339)
+	}
+	return k
+}
+
+func (h *httpHandler) createAPIKey() http.HandlerFunc {
+	type request struct {
+		OrganizationID string `json:"organization_id"`
+		UserID         string `json:"user_id"`
+		Name           string `json:"name"`
+	}
+	type response struct {
+		// Key is shown once; only its prefix can be listed later.
+		Key    string `json:"key"`
+		APIKey apiKey `json:"api_key"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			httperrors.Write(w, r, errMethodNotAllowed)
+			return
+		}
+
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
+			return
+		}
+
+		orgID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			httperrors.Write(w, r, httperrors.Validation("invalid organization_id", "organization_id"))
+			return
+		}
+
+		userID, err := uuid.Parse(req.UserID)
+		if err != nil {
+			httperrors.Write(w, r, httperrors.Validation("invalid user_id", "user_id"))
+			return
+		}
+
+		result, err := h.svc.IssueAPIKey(r.Context(), orgID, userID, req.Name)
+		if err != nil {
+			httperrors.Write(w, r, err, apiKeyErrorMappings...)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(response{Key: result.Key, APIKey: newAPIKey(result.APIKey)})
+	}
+}
+
+func (h *httpHandler) listAPIKeys() http.HandlerFunc {
+	type request struct {
+		OrganizationID string `json:"organization_id"`
+	}
+	type response struct {
+		APIKeys []apiKey `json:"api_keys"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			httperrors.Write(w, r, errMethodNotAllowed)
+			return
+		}
+
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
+			return
+		}
+
+		orgID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			httperrors.Write(w, r, httperrors.Validation("invalid organization_id", "organization_id"))
+			return
+		}
+
+		keys, err := h.svc.APIKeys(r.Context(), orgID)
+		if err != nil {
+			httperrors.Write(w, r, err)
+			return
+		}
+
+		resp := response{APIKeys: make([]apiKey, len(keys))}
+		for i, key := range keys {
+			resp.APIKeys[i] = newAPIKey(key)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func (h *httpHandler) revokeAPIKey() http.HandlerFunc {
+	type request struct {
+		OrganizationID string `json:"organization_id"`
+		ID             string `json:"id"`
+	}
+	type response struct {
+		Success bool `json:"success"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			httperrors.Write(w, r, errMethodNotAllowed)
+			return
+		}
+
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
+			return
+		}
+
+		orgID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			httperrors.Write(w, r, httperrors.Validation("invalid organization_id", "organization_id"))
+			return
+		}
+
+		id, err := uuid.Parse(req.ID)
+		if err != nil {
+			httperrors.Write(w, r, httperrors.Validation("invalid id", "id"))
+			return
+		}
+
+		if err := h.svc.RevokeAPIKey(r.Context(), orgID, id); err != nil {
+			httperrors.Write(w, r, err, apiKeyErrorMappings...)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(response{Success: true})
+	}
+}
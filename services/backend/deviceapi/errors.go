@@ -0,0 +1,53 @@
+package deviceapi
+
+import (
+	"net/http"
+
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+)
+
+var (
+	errMethodNotAllowed     = httperrors.New(http.StatusMethodNotAllowed, "method_not_allowed", "method not allowed", nil)
+	errMissingAuthorization = httperrors.Unauthorized("missing authorization header")
+)
+
+var pollErrorMappings = []httperrors.Mapping{
+	{Target: domain.ErrDeviceCodeExpired, HttpStatus: http.StatusGone, Code: "expired_token"},
+	{Target: domain.ErrDeviceCodeNotFound, HttpStatus: http.StatusNotFound, Code: "invalid_device_code"},
+}
+
+var authorizeErrorMappings = []httperrors.Mapping{
+	{Target: domain.ErrDeviceCodeNotFound, HttpStatus: http.StatusNotFound, Code: "invalid_user_code", Message: "invalid user code"},
+	{Target: domain.ErrInvalidUserCode, HttpStatus: http.StatusNotFound, Code: "invalid_user_code"},
+	{Target: domain.ErrDeviceCodeExpired, HttpStatus: http.StatusGone, Code: "expired_code"},
+	{Target: domain.ErrDeviceCodeUsed, HttpStatus: http.StatusConflict, Code: "code_already_used"},
+}
+
+var refreshErrorMappings = []httperrors.Mapping{
+	{Target: domain.ErrDeviceTokenNotFound, HttpStatus: http.StatusUnauthorized, Code: httperrors.CodeUnauthorized, Message: "invalid refresh token"},
+	{Target: domain.ErrDeviceTokenRevoked, HttpStatus: http.StatusUnauthorized, Code: httperrors.CodeUnauthorized, Message: "token has been revoked"},
+}
+
+// Token error codes tell the CLI whether to refresh (token_expired) or start a
+// new device flow (token_revoked, invalid_token).
+const (
+	codeInvalidToken = "invalid_token"
+	codeTokenExpired = "token_expired"
+	codeTokenRevoked = "token_revoked"
+)
+
+var tokenErrorMappings = []httperrors.Mapping{
+	{Target: domain.ErrDeviceTokenNotFound, HttpStatus: http.StatusUnauthorized, Code: codeInvalidToken, Message: "invalid token"},
+	{Target: domain.ErrDeviceTokenRevoked, HttpStatus: http.StatusUnauthorized, Code: codeTokenRevoked, Message: "token has been revoked"},
+	{Target: domain.ErrDeviceTokenExpired, HttpStatus: http.StatusUnauthorized, Code: codeTokenExpired, Message: "token has expired"},
+}
+
+var apiKeyErrorMappings = []httperrors.Mapping{
+	{Target: domain.ErrInvalidAPIKeyName, HttpStatus: http.StatusBadRequest, Code: httperrors.CodeValidation},
+	{Target: domain.ErrAPIKeyNotFound, HttpStatus: http.StatusNotFound, Code: httperrors.CodeNotFound},
+}
+
+var historyErrorMappings = []httperrors.Mapping{
+	{Target: domain.ErrInvalidHistoryCursor, HttpStatus: http.StatusBadRequest, Code: httperrors.CodeValidation},
+}
@@ -0,0 +1,173 @@
+package deviceapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc"
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/google/uuid"
+)
+
+type fakeDeviceCodeRepository struct {
+	domain.DeviceCodeRepository
+	err error
+}
+
+func (f fakeDeviceCodeRepository) GetByDeviceCode(ctx context.Context, deviceCode string) (*domain.DeviceCode, error) {
+	return nil, f.err
+}
+
+func (f fakeDeviceCodeRepository) GetByUserCode(ctx context.Context, userCode string) (*domain.DeviceCode, error) {
+	return nil, f.err
+}
+
+type fakeAPIKeyRepository struct {
+	domain.APIKeyRepository
+	err error
+}
+
+func (f fakeAPIKeyRepository) Revoke(ctx context.Context, organizationID, id uuid.UUID) error {
+	return f.err
+}
+
+func TestErrorEnvelope(t *testing.T) {
+	noAuth := func(h http.Handler) http.Handler { return h }
+	revokeBody := fmt.Sprintf(`{"organization_id":%q,"id":%q}`, uuid.NewString(), uuid.NewString())
+	authorizeBody := fmt.Sprintf(`{"user_code":"ABCD-EFGH","organization_id":%q,"user_id":%q}`, uuid.NewString(), uuid.NewString())
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		repoErr    error
+		wantStatus int
+		wantCode   string
+		wantFields []string
+	}{
+		{
+			name:       "method not allowed",
+			method:     http.MethodGet,
+			path:       "/device/auth/poll",
+			wantStatus: http.StatusMethodNotAllowed,
+			wantCode:   "method_not_allowed",
+		},
+		{
+			name:       "malformed json",
+			path:       "/device/auth/poll",
+			body:       `{`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   httperrors.CodeValidation,
+		},
+		{
+			name:       "validation",
+			path:       "/device/api-keys/revoke",
+			body:       `{"organization_id":"not-a-uuid"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   httperrors.CodeValidation,
+			wantFields: []string{"organization_id"},
+		},
+		{
+			name:       "unknown device code",
+			path:       "/device/auth/poll",
+			body:       `{"device_code":"abc"}`,
+			repoErr:    domain.ErrDeviceCodeNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   "invalid_device_code",
+		},
+		{
+			name:       "expired device code",
+			path:       "/device/auth/poll",
+			body:       `{"device_code":"abc"}`,
+			repoErr:    domain.ErrDeviceCodeExpired,
+			wantStatus: http.StatusGone,
+			wantCode:   "expired_token",
+		},
+		{
+			name:       "used user code",
+			path:       "/device/auth/authorize",
+			body:       authorizeBody,
+			repoErr:    domain.ErrDeviceCodeUsed,
+			wantStatus: http.StatusConflict,
+			wantCode:   "code_already_used",
+		},
+		{
+			name:       "missing authorization",
+			path:       "/device/auth/revoke",
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   httperrors.CodeUnauthorized,
+		},
+		{
+			name:       "unknown api key",
+			path:       "/device/api-keys/revoke",
+			body:       revokeBody,
+			repoErr:    fmt.Errorf("failed to revoke api key: %w", domain.ErrAPIKeyNotFound),
+			wantStatus: http.StatusNotFound,
+			wantCode:   httperrors.CodeNotFound,
+		},
+		{
+			name:       "internal",
+			path:       "/device/api-keys/revoke",
+			body:       revokeBody,
+			repoErr:    errors.New(`pq: relation "api_keys" does not exist`),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   httperrors.CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := devicesvc.NewService(fakeDeviceCodeRepository{err: tt.repoErr}, nil, fakeAPIKeyRepository{err: tt.repoErr}, nil, nil, devicesvc.HistoryConfig{})
+			h := NewHandler(svc, nil, nil, noAuth, noAuth)
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req := httptest.NewRequest(method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(httperrors.RequestIDHeader, "req-123")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", ct)
+			}
+
+			var envelope map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+				t.Fatalf("response is not JSON: %v", err)
+			}
+			for _, key := range []string{"code", "message", "fields", "request_id"} {
+				if _, ok := envelope[key]; !ok {
+					t.Errorf("envelope is missing %q: %s", key, rec.Body.String())
+				}
+			}
+			if envelope["code"] != tt.wantCode {
+				t.Errorf("code = %v, want %s", envelope["code"], tt.wantCode)
+			}
+			if envelope["request_id"] != "req-123" {
+				t.Errorf("request_id = %v, want req-123", envelope["request_id"])
+			}
+			if tt.wantFields != nil {
+				fields, _ := envelope["fields"].([]any)
+				if len(fields) != len(tt.wantFields) || fields[0] != tt.wantFields[0] {
+					t.Errorf("fields = %v, want %v", fields, tt.wantFields)
+				}
+			}
+			if tt.wantCode == httperrors.CodeInternal && strings.Contains(rec.Body.String(), "relation") {
+				t.Errorf("internal error text leaked to client: %s", rec.Body.String())
+			}
+		})
+	}
+}
@@ -4,8 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/73ai/infragpt/services/backend"
 	"github.com/73ai/infragpt/services/backend/internal/devicesvc"
@@ -16,9 +21,11 @@ import (
 
 type httpHandler struct {
 	http.ServeMux
-	svc                 *devicesvc.Service
-	integrationService  backend.IntegrationService
-	clerkAuthMiddleware func(http.Handler) http.Handler
+	svc                   *devicesvc.Service
+	integrationService    backend.IntegrationService
+	identityService       backend.IdentityService
+	clerkAuthMiddleware   func(http.Handler) http.Handler
+	sessionAuthMiddleware func(http.Handler) http.Handler
 }
 
 func (h *httpHandler) init() {
@@ -29,22 +36,37 @@ func (h *httpHandler) init() {
 	// Clerk-protected endpoint (for web app)
 	h.Handle("/device/auth/authorize", h.clerkAuthMiddleware(http.HandlerFunc(h.authorizeDevice())))
 
+	// Clerk-protected API key management (for web app)
+	h.Handle("/device/api-keys/create", h.clerkAuthMiddleware(http.HandlerFunc(h.createAPIKey())))
+	h.Handle("/device/api-keys/list", h.clerkAuthMiddleware(http.HandlerFunc(h.listAPIKeys())))
+	h.Handle("/device/api-keys/revoke", h.clerkAuthMiddleware(http.HandlerFunc(h.revokeAPIKey())))
+
+	// Clerk session-protected page (for the browser, without the web app)
+	h.Handle(verifyPath, h.sessionAuthMiddleware(h.verifyDevice()))
+
 	// Device token-protected endpoints
 	h.HandleFunc("/device/auth/refresh", h.refreshToken())
 	h.HandleFunc("/device/auth/revoke", h.revokeToken())
 	h.HandleFunc("/device/credentials/gcp", h.getGCPCredentials())
 	h.HandleFunc("/device/credentials/gke", h.getGKEClusterInfo())
+	h.HandleFunc("/device/credentials/objectstore", h.getObjectStoreCredentials())
+	h.HandleFunc("/device/history/push", h.pushHistory())
+	h.HandleFunc("/device/history/pull", h.pullHistory())
 }
 
 func NewHandler(
 	deviceService *devicesvc.Service,
 	integrationService backend.IntegrationService,
+	identityService backend.IdentityService,
 	clerkAuthMiddleware func(http.Handler) http.Handler,
+	sessionAuthMiddleware func(http.Handler) http.Handler,
 ) http.Handler {
 	h := &httpHandler{
-		svc:                 deviceService,
-		integrationService:  integrationService,
-		clerkAuthMiddleware: clerkAuthMiddleware,
+		svc:                   deviceService,
+		integrationService:    integrationService,
+		identityService:       identityService,
+		clerkAuthMiddleware:   clerkAuthMiddleware,
+		sessionAuthMiddleware: sessionAuthMiddleware,
 	}
 	h.init()
 	return h
@@ -61,14 +83,13 @@ func (h *httpHandler) initiateDeviceFlow() http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			httperrors.Write(w, r, errMethodNotAllowed)
 			return
 		}
 
 		result, err := h.svc.InitiateDeviceFlow(r.Context())
 		if err != nil {
-			slog.Error("failed to initiate device flow", "error", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			httperrors.Write(w, r, fmt.Errorf("failed to initiate device flow: %w", err))
 			return
 		}
 
@@ -102,21 +123,20 @@ func (h *httpHandler) pollDeviceFlow() http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			httperrors.Write(w, r, errMethodNotAllowed)
 			return
 		}
 
 		var req request
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
 			return
 		}
 
 		result, err := h.svc.PollDeviceFlow(r.Context(), req.DeviceCode)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-
 			if errors.Is(err, domain.ErrAuthorizationPending) {
+				w.Header().Set("Content-Type", "application/json")
 				w.WriteHeader(http.StatusOK)
 				_ = json.NewEncoder(w).Encode(response{
 					Authorized: false,
@@ -125,26 +145,7 @@ func (h *httpHandler) pollDeviceFlow() http.HandlerFunc {
 				return
 			}
 
-			if errors.Is(err, domain.ErrDeviceCodeExpired) {
-				w.WriteHeader(http.StatusGone)
-				_ = json.NewEncoder(w).Encode(response{
-					Authorized: false,
-					Error:      "expired_token",
-				})
-				return
-			}
-
-			if errors.Is(err, domain.ErrDeviceCodeNotFound) {
-				w.WriteHeader(http.StatusNotFound)
-				_ = json.NewEncoder(w).Encode(response{
-					Authorized: false,
-					Error:      "invalid_device_code",
-				})
-				return
-			}
-
-			slog.Error("failed to poll device flow", "error", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			httperrors.Write(w, r, err, pollErrorMappings...)
 			return
 		}
 
@@ -170,62 +171,36 @@ func (h *httpHandler) authorizeDevice() http.HandlerFunc {
 		UserID         string `json:"user_id"`
 	}
 	type response struct {
-		Success bool   `json:"success"`
-		Error   string `json:"error,omitempty"`
+		Success bool `json:"success"`
 	}
 
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			httperrors.Write(w, r, errMethodNotAllowed)
 			return
 		}
 
 		var req request
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
 			return
 		}
 
 		orgID, err := uuid.Parse(req.OrganizationID)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_ = json.NewEncoder(w).Encode(response{Success: false, Error: "invalid organization_id"})
+			httperrors.Write(w, r, httperrors.Validation("invalid organization_id", "organization_id"))
 			return
 		}
 
 		userID, err := uuid.Parse(req.UserID)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_ = json.NewEncoder(w).Encode(response{Success: false, Error: "invalid user_id"})
+			httperrors.Write(w, r, httperrors.Validation("invalid user_id", "user_id"))
 			return
 		}
 
 		err = h.svc.AuthorizeDevice(r.Context(), req.UserCode, orgID, userID)
 		if err != nil {
-			w.Header().Set("Content-Type", "application/json")
-
-			if errors.Is(err, domain.ErrDeviceCodeNotFound) || errors.Is(err, domain.ErrInvalidUserCode) {
-				w.WriteHeader(http.StatusNotFound)
-				_ = json.NewEncoder(w).Encode(response{Success: false, Error: "invalid_user_code"})
-				return
-			}
-
-			if errors.Is(err, domain.ErrDeviceCodeExpired) {
-				w.WriteHeader(http.StatusGone)
-				_ = json.NewEncoder(w).Encode(response{Success: false, Error: "expired_code"})
-				return
-			}
-
-			if errors.Is(err, domain.ErrDeviceCodeUsed) {
-				w.WriteHeader(http.StatusConflict)
-				_ = json.NewEncoder(w).Encode(response{Success: false, Error: "code_already_used"})
-				return
-			}
-
-			slog.Error("failed to authorize device", "error", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			httperrors.Write(w, r, err, authorizeErrorMappings...)
 			return
 		}
 
@@ -247,28 +222,19 @@ func (h *httpHandler) refreshToken() http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			httperrors.Write(w, r, errMethodNotAllowed)
 			return
 		}
 
 		var req request
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
 			return
 		}
 
 		result, err := h.svc.RefreshToken(r.Context(), req.RefreshToken)
 		if err != nil {
-			if errors.Is(err, domain.ErrDeviceTokenNotFound) {
-				http.Error(w, "Invalid refresh token", http.StatusUnauthorized)
-				return
-			}
-			if errors.Is(err, domain.ErrDeviceTokenRevoked) {
-				http.Error(w, "Token has been revoked", http.StatusUnauthorized)
-				return
-			}
-			slog.Error("failed to refresh token", "error", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			httperrors.Write(w, r, err, refreshErrorMappings...)
 			return
 		}
 
@@ -291,19 +257,18 @@ func (h *httpHandler) revokeToken() http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			httperrors.Write(w, r, errMethodNotAllowed)
 			return
 		}
 
 		accessToken := extractBearerToken(r)
 		if accessToken == "" {
-			http.Error(w, "Missing authorization header", http.StatusUnauthorized)
+			httperrors.Write(w, r, errMissingAuthorization)
 			return
 		}
 
 		if err := h.svc.RevokeToken(r.Context(), accessToken); err != nil {
-			slog.Error("failed to revoke token", "error", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			httperrors.Write(w, r, fmt.Errorf("failed to revoke token: %w", err))
 			return
 		}
 
@@ -321,13 +286,13 @@ func (h *httpHandler) getGCPCredentials() http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			httperrors.Write(w, r, errMethodNotAllowed)
 			return
 		}
 
 		ctx, orgID, err := h.validateDeviceToken(r)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusUnauthorized)
+			httperrors.Write(w, r, err, tokenErrorMappings...)
 			return
 		}
 
@@ -337,18 +302,12 @@ func (h *httpHandler) getGCPCredentials() http.HandlerFunc {
 			Status:         backend.IntegrationStatusActive,
 		})
 		if err != nil {
-			slog.Error("failed to get integrations", "error", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			httperrors.Write(w, r, fmt.Errorf("failed to get integrations: %w", err))
 			return
 		}
 
 		if len(integrations) == 0 {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusNotFound)
-			_ = json.NewEncoder(w).Encode(httperrors.Error{
-				Message:    "No GCP integration found",
-				HttpStatus: http.StatusNotFound,
-			})
+			httperrors.Write(w, r, httperrors.NotFound("No GCP integration found"))
 			return
 		}
 
@@ -360,8 +319,7 @@ func (h *httpHandler) getGCPCredentials() http.HandlerFunc {
 			OrganizationID: orgID,
 		})
 		if err != nil {
-			slog.Error("failed to fetch GCP credentials", "error", err)
-			http.Error(w, "Failed to fetch credentials", http.StatusInternalServerError)
+			httperrors.Write(w, r, fmt.Errorf("failed to fetch GCP credentials: %w", err))
 			return
 		}
 
@@ -389,13 +347,13 @@ func (h *httpHandler) getGKEClusterInfo() http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			httperrors.Write(w, r, errMethodNotAllowed)
 			return
 		}
 
 		ctx, orgID, err := h.validateDeviceToken(r)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusUnauthorized)
+			httperrors.Write(w, r, err, tokenErrorMappings...)
 			return
 		}
 
@@ -405,18 +363,12 @@ func (h *httpHandler) getGKEClusterInfo() http.HandlerFunc {
 			Status:         backend.IntegrationStatusActive,
 		})
 		if err != nil {
-			slog.Error("failed to get integrations", "error", err)
-			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			httperrors.Write(w, r, fmt.Errorf("failed to get integrations: %w", err))
 			return
 		}
 
 		if len(integrations) == 0 {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusNotFound)
-			_ = json.NewEncoder(w).Encode(httperrors.Error{
-				Message:    "No GCP integration found",
-				HttpStatus: http.StatusNotFound,
-			})
+			httperrors.Write(w, r, httperrors.NotFound("No GCP integration found"))
 			return
 		}
 
@@ -429,12 +381,7 @@ func (h *httpHandler) getGKEClusterInfo() http.HandlerFunc {
 		projectID := integration.Metadata["project_id"]
 
 		if clusterName == "" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusNotFound)
-			_ = json.NewEncoder(w).Encode(httperrors.Error{
-				Message:    "No GKE cluster configured",
-				HttpStatus: http.StatusNotFound,
-			})
+			httperrors.Write(w, r, httperrors.NotFound("No GKE cluster configured"))
 			return
 		}
 
@@ -451,25 +398,124 @@ func (h *httpHandler) getGKEClusterInfo() http.HandlerFunc {
 	}
 }
 
+func (h *httpHandler) getObjectStoreCredentials() http.HandlerFunc {
+	type response struct {
+		EndpointURL     string `json:"endpoint_url"`
+		Bucket          string `json:"bucket"`
+		Region          string `json:"region,omitempty"`
+		AccessKeyID     string `json:"access_key_id"`
+		SecretAccessKey string `json:"secret_access_key" masq:"sensitive"`
+		SessionToken    string `json:"session_token,omitempty" masq:"sensitive"`
+		ExpiresAt       string `json:"expires_at,omitempty"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			httperrors.Write(w, r, errMethodNotAllowed)
+			return
+		}
+
+		ctx, orgID, err := h.validateDeviceToken(r)
+		if err != nil {
+			httperrors.Write(w, r, err, tokenErrorMappings...)
+			return
+		}
+
+		integrations, err := h.integrationService.Integrations(ctx, backend.IntegrationsQuery{
+			OrganizationID: orgID,
+			ConnectorType:  backend.ConnectorTypeObjectStore,
+			Status:         backend.IntegrationStatusActive,
+		})
+		if err != nil {
+			httperrors.Write(w, r, fmt.Errorf("failed to get integrations: %w", err))
+			return
+		}
+
+		if len(integrations) == 0 {
+			httperrors.Write(w, r, httperrors.NotFound("No object store integration found"))
+			return
+		}
+
+		integration := integrations[0]
+
+		credentials, err := h.integrationService.IntegrationCredentials(ctx, backend.IntegrationCredentialsQuery{
+			IntegrationID:  integration.ID,
+			OrganizationID: orgID,
+		})
+		if err != nil {
+			httperrors.Write(w, r, fmt.Errorf("failed to fetch object store credentials: %w", err))
+			return
+		}
+
+		resp := response{
+			EndpointURL:     integration.Metadata["endpoint_url"],
+			Bucket:          integration.Metadata["bucket"],
+			Region:          integration.Metadata["region"],
+			AccessKeyID:     credentials.Data["access_key_id"],
+			SecretAccessKey: credentials.Data["secret_access_key"],
+			SessionToken:    credentials.Data["session_token"],
+		}
+
+		var missing []string
+		for field, value := range map[string]string{
+			"endpoint_url":      resp.EndpointURL,
+			"bucket":            resp.Bucket,
+			"access_key_id":     resp.AccessKeyID,
+			"secret_access_key": resp.SecretAccessKey,
+		} {
+			if value == "" {
+				missing = append(missing, field)
+			}
+		}
+		if len(missing) > 0 {
+			slices.Sort(missing)
+			httperrors.Write(w, r, httperrors.Conflict("Object store integration is missing "+strings.Join(missing, ", ")+"; reconnect it"))
+			return
+		}
+
+		if credentials.ExpiresAt != nil {
+			if !credentials.ExpiresAt.After(time.Now()) {
+				httperrors.Write(w, r, httperrors.Conflict("Object store credentials have expired; reconnect the integration"))
+				return
+			}
+			resp.ExpiresAt = credentials.ExpiresAt.Format(time.RFC3339)
+		}
+
+		slog.Info("device: issued object store credentials", "organization_id", orgID, "integration_id", integration.ID, "credentials", resp)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// validateDeviceToken returns the domain token errors unchanged; handlers
+// report them with tokenErrorMappings.
 func (h *httpHandler) validateDeviceToken(r *http.Request) (context.Context, uuid.UUID, error) {
 	accessToken := extractBearerToken(r)
 	if accessToken == "" {
-		return nil, uuid.UUID{}, errors.New("missing authorization header")
+		return nil, uuid.UUID{}, errMissingAuthorization
 	}
 
 	result, err := h.svc.ValidateToken(r.Context(), accessToken)
 	if err != nil {
-		if errors.Is(err, domain.ErrDeviceTokenNotFound) {
-			return nil, uuid.UUID{}, errors.New("invalid token")
-		}
-		if errors.Is(err, domain.ErrDeviceTokenRevoked) {
-			return nil, uuid.UUID{}, errors.New("token has been revoked")
-		}
-		if errors.Is(err, domain.ErrDeviceTokenExpired) {
-			return nil, uuid.UUID{}, errors.New("token has expired")
-		}
-		return nil, uuid.UUID{}, errors.New("token validation failed")
+		return nil, uuid.UUID{}, fmt.Errorf("token validation failed: %w", err)
 	}
 
-	return r.Context(), result.OrganizationID, nil
+	ctx := backend.WithCredentialAccessor(r.Context(), backend.CredentialAccessor{
+		Type:   backend.CredentialAccessorDevice,
+		ID:     result.DeviceID.String(),
+		UserID: result.UserID.String(),
+		IP:     remoteIP(r),
+		Reason: r.URL.Path,
+	})
+	return ctx, result.OrganizationID, nil
+}
+
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
 }
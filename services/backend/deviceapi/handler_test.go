@@ -0,0 +1,69 @@
+package deviceapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc"
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+)
+
+type fakeTokenRepository struct {
+	domain.DeviceTokenRepository
+	tokens map[string]domain.DeviceToken
+}
+
+func (f fakeTokenRepository) GetByAccessToken(ctx context.Context, accessToken string) (*domain.DeviceToken, error) {
+	token, ok := f.tokens[accessToken]
+	if !ok {
+		return nil, domain.ErrDeviceTokenNotFound
+	}
+	return &token, nil
+}
+
+func TestCredentialTokenErrors(t *testing.T) {
+	revokedAt := time.Now().Add(-time.Minute)
+	tokens := fakeTokenRepository{tokens: map[string]domain.DeviceToken{
+		"expired": {ExpiresAt: time.Now().Add(-time.Hour)},
+		"revoked": {ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt},
+	}}
+	svc := devicesvc.NewService(nil, tokens, nil, nil, nil, devicesvc.HistoryConfig{})
+	noAuth := func(h http.Handler) http.Handler { return h }
+	handler := NewHandler(svc, nil, nil, noAuth, noAuth)
+
+	tests := []struct {
+		token    string
+		wantCode string
+	}{
+		{token: "unknown", wantCode: codeInvalidToken},
+		{token: "expired", wantCode: codeTokenExpired},
+		{token: "revoked", wantCode: codeTokenRevoked},
+	}
+
+	for _, path := range []string{"/device/credentials/gcp", "/device/credentials/gke", "/device/credentials/objectstore"} {
+		for _, tt := range tests {
+			t.Run(path+"/"+tt.token, func(t *testing.T) {
+				req := httptest.NewRequest(http.MethodPost, path, nil)
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+				rec := httptest.NewRecorder()
+				handler.ServeHTTP(rec, req)
+
+				if rec.Code != http.StatusUnauthorized {
+					t.Fatalf("status = %d, want 401: %s", rec.Code, rec.Body.String())
+				}
+				var body httperrors.Error
+				if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+					t.Fatalf("failed to decode body: %v", err)
+				}
+				if body.Code != tt.wantCode {
+					t.Errorf("code = %q, want %q", body.Code, tt.wantCode)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package deviceapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc"
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+)
+
+type historyEntry struct {
+	ID             string `json:"id"`
+	Command        string `json:"command"`
+	Timestamp      string `json:"timestamp"`
+	ExitCode       *int   `json:"exit_code,omitempty"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	DeviceID       string `json:"device_id,omitempty"`
+}
+
+func (h *httpHandler) pushHistory() http.HandlerFunc {
+	type request struct {
+		Entries []historyEntry `json:"entries"`
+	}
+	type response struct {
+		Accepted   int    `json:"accepted"`
+		Duplicates int    `json:"duplicates"`
+		Evicted    int    `json:"evicted"`
+		DeviceID   string `json:"device_id"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			httperrors.Write(w, r, errMethodNotAllowed)
+			return
+		}
+
+		device, err := h.svc.ValidateToken(r.Context(), extractBearerToken(r))
+		if err != nil {
+			httperrors.Write(w, r, err, tokenErrorMappings...)
+			return
+		}
+
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
+			return
+		}
+
+		entries := make([]domain.HistoryEntry, len(req.Entries))
+		for i, e := range req.Entries {
+			executedAt, err := time.Parse(time.RFC3339, e.Timestamp)
+			if err != nil {
+				httperrors.Write(w, r, httperrors.Validation("entry "+strconv.Itoa(i)+": timestamp must be RFC 3339", "entries"))
+				return
+			}
+			entries[i] = domain.HistoryEntry{
+				EntryID:        e.ID,
+				Command:        e.Command,
+				ExitCode:       e.ExitCode,
+				ConversationID: e.ConversationID,
+				ExecutedAt:     executedAt,
+			}
+		}
+
+		result, err := h.svc.PushHistory(r.Context(), device, entries)
+		if err != nil {
+			writeHistoryError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(response{
+			Accepted:   result.Accepted,
+			Duplicates: result.Duplicates,
+			Evicted:    result.Evicted,
+			DeviceID:   device.DeviceID.String(),
+		})
+	}
+}
+
+func (h *httpHandler) pullHistory() http.HandlerFunc {
+	type request struct {
+		Cursor     string `json:"cursor"`
+		DeviceOnly bool   `json:"device_only"`
+		Limit      int    `json:"limit"`
+	}
+	type response struct {
+		Entries    []historyEntry `json:"entries"`
+		NextCursor string         `json:"next_cursor"`
+		HasMore    bool           `json:"has_more"`
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			httperrors.Write(w, r, errMethodNotAllowed)
+			return
+		}
+
+		device, err := h.svc.ValidateToken(r.Context(), extractBearerToken(r))
+		if err != nil {
+			httperrors.Write(w, r, err, tokenErrorMappings...)
+			return
+		}
+
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
+			return
+		}
+
+		query := devicesvc.PullHistoryQuery{DeviceOnly: req.DeviceOnly, Limit: req.Limit}
+		if req.Cursor != "" {
+			query.Cursor, err = strconv.ParseInt(req.Cursor, 10, 64)
+			if err != nil {
+				httperrors.Write(w, r, domain.ErrInvalidHistoryCursor, historyErrorMappings...)
+				return
+			}
+		}
+
+		result, err := h.svc.PullHistory(r.Context(), device, query)
+		if err != nil {
+			writeHistoryError(w, r, err)
+			return
+		}
+
+		resp := response{
+			Entries:    make([]historyEntry, len(result.Entries)),
+			NextCursor: strconv.FormatInt(result.NextCursor, 10),
+			HasMore:    result.HasMore,
+		}
+		for i, e := range result.Entries {
+			resp.Entries[i] = historyEntry{
+				ID:             e.EntryID,
+				Command:        e.Command,
+				Timestamp:      e.ExecutedAt.UTC().Format(time.RFC3339),
+				ExitCode:       e.ExitCode,
+				ConversationID: e.ConversationID,
+				DeviceID:       e.DeviceID.String(),
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// writeHistoryError keeps the detail of validation failures, which name the
+// offending entry, so the CLI can drop it and retry the rest.
+func writeHistoryError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, domain.ErrInvalidHistoryEntry) {
+		httperrors.Write(w, r, httperrors.Validation(err.Error(), "entries"))
+		return
+	}
+	httperrors.Write(w, r, err, historyErrorMappings...)
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/73ai/infragpt/services/backend/internal/devicesvc"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
 	"github.com/google/uuid"
 )
 
@@ -28,13 +29,13 @@ func (m *DeviceTokenMiddleware) Handler(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		accessToken := extractBearerToken(r)
 		if accessToken == "" {
-			http.Error(w, "Missing authorization header", http.StatusUnauthorized)
+			httperrors.Write(w, r, errMissingAuthorization)
 			return
 		}
 
 		result, err := m.svc.ValidateToken(r.Context(), accessToken)
 		if err != nil {
-			http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+			httperrors.Write(w, r, err, tokenErrorMappings...)
 			return
 		}
 
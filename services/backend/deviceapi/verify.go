@@ -0,0 +1,216 @@
+package deviceapi
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc/domain"
+	clerkapi "github.com/clerk/clerk-sdk-go/v2"
+)
+
+// verifyPath serves the page users open from the CLI to authorize a device
+// without the console. Point the device service's verification URL at it.
+const verifyPath = "/device/verify"
+
+type verifyPage struct {
+	Title            string
+	Message          string
+	UserCode         string
+	OrganizationName string
+	// Form shows the code entry and confirmation form.
+	Form bool
+}
+
+var verifyTemplate = template.Must(template.New("verify").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}} · InfraGPT</title>
+<style>
+body { font-family: system-ui, sans-serif; background: #f9fafb; display: flex; justify-content: center; padding: 4rem 1rem; margin: 0; }
+main { background: #fff; border: 1px solid #e5e7eb; border-radius: 8px; padding: 2rem; max-width: 26rem; width: 100%; text-align: center; }
+h1 { font-size: 1.25rem; margin: 0 0 .75rem; }
+p { color: #4b5563; }
+input { font: 1.5rem monospace; letter-spacing: .15em; text-align: center; text-transform: uppercase; width: 100%; box-sizing: border-box; padding: .5rem; margin: .5rem 0 1rem; }
+button { background: #111827; color: #fff; border: 0; border-radius: 6px; padding: .6rem 1rem; width: 100%; font-size: 1rem; cursor: pointer; }
+</style>
+</head>
+<body>
+<main>
+<h1>{{.Title}}</h1>
+{{if .Message}}<p>{{.Message}}</p>{{end}}
+{{if .Form}}
+<form method="post" action="` + verifyPath + `">
+<label for="user_code">Verification code</label>
+<input id="user_code" name="user_code" value="{{.UserCode}}" placeholder="XXXX-XXXX" maxlength="9" autocomplete="off" autofocus required>
+<p>The InfraGPT CLI will act on behalf of <strong>{{.OrganizationName}}</strong>. Switch organizations in the console to authorize it for another one.</p>
+<button type="submit">Authorize CLI</button>
+</form>
+{{end}}
+</main>
+</body>
+</html>
+`))
+
+func (h *httpHandler) verifyDevice() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodPost {
+			writeVerifyPage(w, http.StatusMethodNotAllowed, verifyPage{
+				Title:   "Method not allowed",
+				Message: "Open this page from the link the CLI printed.",
+			})
+			return
+		}
+
+		profile, status, page := h.verifyProfile(r.Context())
+		if page != nil {
+			writeVerifyPage(w, status, *page)
+			return
+		}
+
+		if r.Method == http.MethodGet {
+			userCode := normalizeUserCode(r.URL.Query().Get("user_code"))
+			if userCode != "" {
+				if err := h.svc.CheckUserCode(r.Context(), userCode); err != nil {
+					writeVerifyError(w, err, profile, userCode)
+					return
+				}
+			}
+			writeVerifyPage(w, http.StatusOK, verifyPage{
+				Title:            "Authorize the InfraGPT CLI",
+				Message:          "Confirm the code matches the one shown in your terminal.",
+				UserCode:         userCode,
+				OrganizationName: profile.Name,
+				Form:             true,
+			})
+			return
+		}
+
+		// Forms can be posted from any site; only accept our own.
+		if !sameOrigin(r) {
+			writeVerifyPage(w, http.StatusForbidden, verifyPage{
+				Title:   "Request blocked",
+				Message: "This form can only be submitted from the verification page.",
+			})
+			return
+		}
+		userCode := normalizeUserCode(r.PostFormValue("user_code"))
+		if userCode == "" {
+			writeVerifyPage(w, http.StatusBadRequest, verifyPage{
+				Title:            "Enter your code",
+				Message:          "Enter the verification code shown in your terminal.",
+				OrganizationName: profile.Name,
+				Form:             true,
+			})
+			return
+		}
+
+		if err := h.svc.AuthorizeDevice(r.Context(), userCode, profile.OrganizationID, profile.UserID); err != nil {
+			writeVerifyError(w, err, profile, userCode)
+			return
+		}
+
+		slog.Info("device: authorized from verification page", "organization_id", profile.OrganizationID, "user_id", profile.UserID)
+		writeVerifyPage(w, http.StatusOK, verifyPage{
+			Title:   "CLI authorized",
+			Message: "The InfraGPT CLI is now signed in to " + profile.Name + ". You can return to your terminal.",
+		})
+	}
+}
+
+// verifyProfile resolves the signed-in Clerk session to the user and their
+// active organization. It returns the page to show instead when there is none.
+func (h *httpHandler) verifyProfile(ctx context.Context) (backend.Profile, int, *verifyPage) {
+	claims, ok := clerkapi.SessionClaimsFromContext(ctx)
+	if !ok {
+		return backend.Profile{}, http.StatusUnauthorized, &verifyPage{
+			Title:   "Sign in to continue",
+			Message: "Sign in to the InfraGPT console in this browser, then open the link from the CLI again.",
+		}
+	}
+	if claims.ActiveOrganizationID == "" {
+		return backend.Profile{}, http.StatusForbidden, &verifyPage{
+			Title:   "Choose an organization",
+			Message: "Select an organization in the InfraGPT console, then open the link from the CLI again.",
+		}
+	}
+
+	profile, err := h.identityService.Profile(ctx, backend.ProfileQuery{
+		ClerkUserID: claims.Subject,
+		ClerkOrgID:  claims.ActiveOrganizationID,
+	})
+	if err != nil {
+		slog.Error("device: failed to resolve profile for verification page", "clerk_user_id", claims.Subject, "clerk_org_id", claims.ActiveOrganizationID, "error", err)
+		return backend.Profile{}, http.StatusForbidden, &verifyPage{
+			Title:   "Account not ready",
+			Message: "Your account is still being set up. Try again in a minute from the InfraGPT console.",
+		}
+	}
+	return profile, 0, nil
+}
+
+func writeVerifyError(w http.ResponseWriter, err error, profile backend.Profile, userCode string) {
+	switch {
+	case errors.Is(err, domain.ErrDeviceCodeNotFound), errors.Is(err, domain.ErrInvalidUserCode):
+		writeVerifyPage(w, http.StatusNotFound, verifyPage{
+			Title:            "Code not recognised",
+			Message:          "No CLI login is waiting for " + userCode + ". Check the code in your terminal and try again.",
+			UserCode:         userCode,
+			OrganizationName: profile.Name,
+			Form:             true,
+		})
+	case errors.Is(err, domain.ErrDeviceCodeExpired):
+		writeVerifyPage(w, http.StatusGone, verifyPage{
+			Title:   "Code expired",
+			Message: "This code has expired. Run infragpt auth login again to get a new one.",
+		})
+	case errors.Is(err, domain.ErrDeviceCodeUsed):
+		writeVerifyPage(w, http.StatusConflict, verifyPage{
+			Title:   "Code already used",
+			Message: "This code has already been used. Run infragpt auth login again if the CLI is not signed in.",
+		})
+	default:
+		slog.Error("device: verification page failed", "error", err)
+		writeVerifyPage(w, http.StatusInternalServerError, verifyPage{
+			Title:   "Something went wrong",
+			Message: "We could not authorize the CLI. Try again in a moment.",
+		})
+	}
+}
+
+func writeVerifyPage(w http.ResponseWriter, status int, page verifyPage) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; form-action 'self'; frame-ancestors 'none'")
+	w.WriteHeader(status)
+	if err := verifyTemplate.Execute(w, page); err != nil {
+		slog.Error("device: failed to render verification page", "error", err)
+	}
+}
+
+// normalizeUserCode accepts codes typed in lower case or without the hyphen.
+func normalizeUserCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) == 8 && !strings.Contains(code, "-") {
+		code = code[:4] + "-" + code[4:]
+	}
+	return code
+}
+
+// sameOrigin rejects cross-site form posts. Browsers send Origin on POST;
+// requests without it are not from a browser form.
+func sameOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host == r.Host
+}
@@ -0,0 +1,178 @@
+package deviceapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc"
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc/domain"
+	clerkapi "github.com/clerk/clerk-sdk-go/v2"
+	"github.com/google/uuid"
+)
+
+type fakeCodeRepository struct {
+	domain.DeviceCodeRepository
+	codes map[string]*domain.DeviceCode
+}
+
+func (f fakeCodeRepository) GetByUserCode(ctx context.Context, userCode string) (*domain.DeviceCode, error) {
+	code, ok := f.codes[userCode]
+	if !ok {
+		return nil, domain.ErrDeviceCodeNotFound
+	}
+	return code, nil
+}
+
+func (f fakeCodeRepository) Authorize(ctx context.Context, userCode string, organizationID, userID uuid.UUID) error {
+	code := f.codes[userCode]
+	code.Status = domain.DeviceCodeStatusAuthorized
+	code.OrganizationID = organizationID
+	code.UserID = userID
+	return nil
+}
+
+type fakeIdentityService struct {
+	backend.IdentityService
+	profile backend.Profile
+}
+
+func (f fakeIdentityService) Profile(ctx context.Context, query backend.ProfileQuery) (backend.Profile, error) {
+	if query.ClerkUserID != "user_1" || query.ClerkOrgID != "org_1" {
+		return backend.Profile{}, backend.ErrUserNotFound
+	}
+	return f.profile, nil
+}
+
+// fakeSession signs in as the Clerk user and organization in the X-Test-Session
+// header, "user:org".
+func fakeSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if userID, orgID, ok := strings.Cut(r.Header.Get("X-Test-Session"), ":"); ok {
+			claims := &clerkapi.SessionClaims{}
+			claims.Subject = userID
+			claims.ActiveOrganizationID = orgID
+			r = r.WithContext(clerkapi.ContextWithSessionClaims(r.Context(), claims))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func TestVerificationPage(t *testing.T) {
+	profile := backend.Profile{Name: "Acme & Co", OrganizationID: uuid.New(), UserID: uuid.New()}
+	codes := fakeCodeRepository{codes: map[string]*domain.DeviceCode{
+		"ABCD-EFGH": {Status: domain.DeviceCodeStatusPending, ExpiresAt: time.Now().Add(time.Minute)},
+		"EXPI-RED2": {Status: domain.DeviceCodeStatusPending, ExpiresAt: time.Now().Add(-time.Minute)},
+		"USED-CODE": {Status: domain.DeviceCodeStatusUsed, ExpiresAt: time.Now().Add(time.Minute)},
+	}}
+	svc := devicesvc.NewService(codes, nil, nil, nil, nil, devicesvc.HistoryConfig{})
+	noAuth := func(h http.Handler) http.Handler { return h }
+	handler := NewHandler(svc, nil, fakeIdentityService{profile: profile}, noAuth, fakeSession)
+
+	serve := func(req *http.Request, session string) *httptest.ResponseRecorder {
+		if session != "" {
+			req.Header.Set("X-Test-Session", session)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+	post := func(userCode, origin string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "http://api.example.com/device/verify", strings.NewReader(url.Values{"user_code": {userCode}}.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		return req
+	}
+
+	tests := []struct {
+		name     string
+		req      *http.Request
+		session  string
+		wantCode int
+		wantBody []string
+	}{
+		{
+			name:     "asks to sign in without a session",
+			req:      httptest.NewRequest(http.MethodGet, "/device/verify?user_code=ABCD-EFGH", nil),
+			wantCode: http.StatusUnauthorized,
+			wantBody: []string{"Sign in to continue"},
+		},
+		{
+			name:     "asks to choose an organization",
+			req:      httptest.NewRequest(http.MethodGet, "/device/verify", nil),
+			session:  "user_1:",
+			wantCode: http.StatusForbidden,
+			wantBody: []string{"Choose an organization"},
+		},
+		{
+			name:     "prefills the code and names the organization",
+			req:      httptest.NewRequest(http.MethodGet, "/device/verify?user_code=abcdefgh", nil),
+			session:  "user_1:org_1",
+			wantCode: http.StatusOK,
+			wantBody: []string{`value="ABCD-EFGH"`, "Acme &amp; Co", `method="post"`},
+		},
+		{
+			name:     "reports an unknown code",
+			req:      httptest.NewRequest(http.MethodGet, "/device/verify?user_code=ZZZZ-ZZZZ", nil),
+			session:  "user_1:org_1",
+			wantCode: http.StatusNotFound,
+			wantBody: []string{"Code not recognised", `method="post"`},
+		},
+		{
+			name:     "reports an expired code",
+			req:      httptest.NewRequest(http.MethodGet, "/device/verify?user_code=EXPI-RED2", nil),
+			session:  "user_1:org_1",
+			wantCode: http.StatusGone,
+			wantBody: []string{"Code expired"},
+		},
+		{
+			name:     "reports a used code on submit",
+			req:      post("USED-CODE", ""),
+			session:  "user_1:org_1",
+			wantCode: http.StatusConflict,
+			wantBody: []string{"Code already used"},
+		},
+		{
+			name:     "rejects cross-site submits",
+			req:      post("ABCD-EFGH", "https://evil.example.net"),
+			session:  "user_1:org_1",
+			wantCode: http.StatusForbidden,
+			wantBody: []string{"Request blocked"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(tt.req, tt.session)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if got := rec.Header().Get("Content-Type"); got != "text/html; charset=utf-8" {
+				t.Errorf("Content-Type = %q", got)
+			}
+			for _, want := range tt.wantBody {
+				if !strings.Contains(rec.Body.String(), want) {
+					t.Errorf("body does not contain %q:\n%s", want, rec.Body.String())
+				}
+			}
+		})
+	}
+
+	t.Run("authorizes the code for the session's organization", func(t *testing.T) {
+		rec := serve(post("abcd-efgh", "http://api.example.com"), "user_1:org_1")
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "CLI authorized") {
+			t.Fatalf("status = %d: %s", rec.Code, rec.Body.String())
+		}
+		code := codes.codes["ABCD-EFGH"]
+		if code.Status != domain.DeviceCodeStatusAuthorized || code.OrganizationID != profile.OrganizationID || code.UserID != profile.UserID {
+			t.Errorf("code = %+v, want authorized for %s/%s", code, profile.OrganizationID, profile.UserID)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package backend
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExecutionService runs read-only CLI commands on the agent's behalf in an
+// isolated container, after checking them against the execution policy.
+type ExecutionService interface {
+	// ExecuteCommand streams the command's output to output as it is produced.
+	// A command the policy denies is not an error: the result names the rule.
+	ExecuteCommand(ctx context.Context, cmd ExecuteCommandCommand, output func(CommandOutput) error) (CommandResult, error)
+}
+
+// ExecuteCommandCommand runs Binary with Args. When IntegrationID is set,
+// short-lived credentials minted from that integration's for the conversation
+// are mounted into the container.
+type ExecuteCommandCommand struct {
+	OrganizationID uuid.UUID
+	IntegrationID  uuid.UUID
+	ConversationID string
+	ToolCallID     string
+	Binary         string
+	Args           []string
+	Timeout        time.Duration
+}
+
+type OutputStream string
+
+const (
+	OutputStreamStdout OutputStream = "stdout"
+	OutputStreamStderr OutputStream = "stderr"
+)
+
+type CommandOutput struct {
+	Stream OutputStream
+	Data   []byte
+}
+
+type CommandResult struct {
+	Allowed bool
+	// DeniedRule and Reason explain why the policy refused the command.
+	DeniedRule string
+	Reason     string
+	ExitCode   int
+	TimedOut   bool
+}
@@ -0,0 +1,51 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type FeatureFlag string
+
+const (
+	// FeatureFlagModelSelection lets Slack users pick a model per message with --model.
+	FeatureFlagModelSelection FeatureFlag = "model_selection"
+	// FeatureFlagToolFooter adds a "what I did" footer listing the tools used
+	// to agent replies in Slack.
+	FeatureFlagToolFooter FeatureFlag = "tool_footer"
+)
+
+// ConnectorFeatureFlag gates a connector listed under integrations.flagged_connectors.
+func ConnectorFeatureFlag(connectorType ConnectorType) FeatureFlag {
+	return FeatureFlag("connector_" + string(connectorType))
+}
+
+// FeatureFlags is the read side consulted on hot paths. Unknown flags and
+// lookup failures resolve to off.
+type FeatureFlags interface {
+	Enabled(ctx context.Context, organizationID uuid.UUID, flag FeatureFlag) bool
+	Value(ctx context.Context, organizationID uuid.UUID, flag FeatureFlag) string
+}
+
+type FeatureFlagService interface {
+	FeatureFlags
+	OrganizationFeatureFlags(ctx context.Context, query OrganizationFeatureFlagsQuery) (map[FeatureFlag]string, error)
+	SetFeatureFlag(ctx context.Context, cmd SetFeatureFlagCommand) error
+	UnsetFeatureFlag(ctx context.Context, cmd UnsetFeatureFlagCommand) error
+}
+
+type OrganizationFeatureFlagsQuery struct {
+	OrganizationID uuid.UUID
+}
+
+type SetFeatureFlagCommand struct {
+	OrganizationID uuid.UUID
+	Flag           FeatureFlag
+	Value          string
+}
+
+type UnsetFeatureFlagCommand struct {
+	OrganizationID uuid.UUID
+	Flag           FeatureFlag
+}
@@ -0,0 +1,19 @@
+package featureapi
+
+import (
+	"net/http"
+
+	"github.com/73ai/infragpt/services/backend/internal/featuresvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+)
+
+var (
+	errMissingAuthorization = httperrors.Unauthorized("missing admin token")
+	errInvalidAuthorization = httperrors.Unauthorized("invalid admin token")
+	errAdminDisabled        = httperrors.NotFound("feature flag admin is disabled")
+)
+
+var errorMappings = []httperrors.Mapping{
+	{Target: domain.ErrFeatureFlagNotFound, HttpStatus: http.StatusNotFound, Code: httperrors.CodeNotFound},
+	{Target: domain.ErrInvalidFeatureFlag, HttpStatus: http.StatusBadRequest, Code: httperrors.CodeValidation},
+}
@@ -0,0 +1,129 @@
+package featureapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/google/uuid"
+)
+
+type httpHandler struct {
+	http.ServeMux
+	svc backend.FeatureFlagService
+}
+
+func (h *httpHandler) init() {
+	h.HandleFunc("/features/list/", h.list())
+	h.HandleFunc("/features/set/", h.set())
+	h.HandleFunc("/features/unset/", h.unset())
+}
+
+func NewHandler(featureFlagService backend.FeatureFlagService,
+	adminMiddleware func(handler http.Handler) http.Handler) http.Handler {
+	h := &httpHandler{
+		svc: featureFlagService,
+	}
+
+	h.init()
+	return adminMiddleware(h)
+}
+
+func (h *httpHandler) list() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		OrganizationID string `json:"organization_id"`
+	}
+	type response struct {
+		Flags map[string]string `json:"flags"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		flags, err := h.svc.OrganizationFeatureFlags(ctx, backend.OrganizationFeatureFlagsQuery{
+			OrganizationID: organizationID,
+		})
+		if err != nil {
+			return response{}, err
+		}
+
+		resp := response{Flags: make(map[string]string, len(flags))}
+		for flag, value := range flags {
+			resp.Flags[string(flag)] = value
+		}
+		return resp, nil
+	})
+}
+
+func (h *httpHandler) set() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		OrganizationID string `json:"organization_id"`
+		Flag           string `json:"flag"`
+		Value          string `json:"value"`
+	}
+	type response struct{}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		err = h.svc.SetFeatureFlag(ctx, backend.SetFeatureFlagCommand{
+			OrganizationID: organizationID,
+			Flag:           backend.FeatureFlag(req.Flag),
+			Value:          req.Value,
+		})
+		return response{}, err
+	})
+}
+
+func (h *httpHandler) unset() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		OrganizationID string `json:"organization_id"`
+		Flag           string `json:"flag"`
+	}
+	type response struct{}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		err = h.svc.UnsetFeatureFlag(ctx, backend.UnsetFeatureFlagCommand{
+			OrganizationID: organizationID,
+			Flag:           backend.FeatureFlag(req.Flag),
+		})
+		return response{}, err
+	})
+}
+
+func ApiHandlerFunc[T any, R any](handler func(context.Context, T) (R, error)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var request T
+		if r.Method == http.MethodPost && r.Body != nil {
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
+				return
+			}
+		}
+
+		response, err := handler(ctx, request)
+		if err != nil {
+			httperrors.Write(w, r, err, errorMappings...)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
@@ -0,0 +1,34 @@
+package featureapi
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+)
+
+// AdminTokenMiddleware only lets through requests bearing the configured admin
+// token. With no token configured every request is rejected.
+func AdminTokenMiddleware(adminToken string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if adminToken == "" {
+				httperrors.Write(w, r, errAdminDisabled)
+				return
+			}
+
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || token == "" {
+				httperrors.Write(w, r, errMissingAuthorization)
+				return
+			}
+			if subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+				httperrors.Write(w, r, errInvalidAuthorization)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package feedbackapi
+
+import (
+	"net/http"
+
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+)
+
+var errorMappings = []httperrors.Mapping{
+	{Target: domain.ErrInvalidFeedbackQuery, HttpStatus: http.StatusBadRequest, Code: httperrors.CodeValidation},
+}
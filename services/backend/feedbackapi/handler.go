@@ -0,0 +1,122 @@
+package feedbackapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/google/uuid"
+)
+
+type httpHandler struct {
+	http.ServeMux
+	svc backend.ConversationService
+}
+
+func (h *httpHandler) init() {
+	h.HandleFunc("/feedback/summary/", h.summary())
+}
+
+func NewHandler(conversationService backend.ConversationService,
+	authMiddleware func(handler http.Handler) http.Handler) http.Handler {
+	h := &httpHandler{
+		svc: conversationService,
+	}
+
+	h.init()
+	return authMiddleware(h)
+}
+
+func (h *httpHandler) summary() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		OrganizationID string `json:"organization_id"`
+		// Bucket is "day", "week" or "month"; it defaults to "day".
+		Bucket string `json:"bucket,omitempty"`
+		Since  string `json:"since,omitempty"`
+		Until  string `json:"until,omitempty"`
+	}
+	type bucket struct {
+		Start            string  `json:"start"`
+		Positive         int     `json:"positive"`
+		Negative         int     `json:"negative"`
+		SatisfactionRate float64 `json:"satisfaction_rate"`
+	}
+	type response struct {
+		Positive         int      `json:"positive"`
+		Negative         int      `json:"negative"`
+		SatisfactionRate float64  `json:"satisfaction_rate"`
+		Buckets          []bucket `json:"buckets"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		var since, until time.Time
+		if req.Since != "" {
+			if since, err = time.Parse(time.RFC3339, req.Since); err != nil {
+				return response{}, httperrors.Validation("since must be an RFC 3339 timestamp", "since")
+			}
+		}
+		if req.Until != "" {
+			if until, err = time.Parse(time.RFC3339, req.Until); err != nil {
+				return response{}, httperrors.Validation("until must be an RFC 3339 timestamp", "until")
+			}
+		}
+
+		summary, err := h.svc.FeedbackSummary(ctx, backend.FeedbackSummaryQuery{
+			OrganizationID: organizationID,
+			Bucket:         backend.FeedbackBucket(req.Bucket),
+			Since:          since,
+			Until:          until,
+		})
+		if err != nil {
+			return response{}, err
+		}
+
+		resp := response{
+			Positive:         summary.Positive,
+			Negative:         summary.Negative,
+			SatisfactionRate: summary.SatisfactionRate,
+			Buckets:          make([]bucket, len(summary.Buckets)),
+		}
+		for i, b := range summary.Buckets {
+			resp.Buckets[i] = bucket{
+				Start:            b.Start.Format(time.RFC3339),
+				Positive:         b.Positive,
+				Negative:         b.Negative,
+				SatisfactionRate: b.SatisfactionRate,
+			}
+		}
+		return resp, nil
+	})
+}
+
+func ApiHandlerFunc[T any, R any](handler func(context.Context, T) (R, error)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var request T
+		if r.Method == http.MethodPost && r.Body != nil {
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
+				return
+			}
+		}
+
+		response, err := handler(ctx, request)
+		if err != nil {
+			httperrors.Write(w, r, err, errorMappings...)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
@@ -2,11 +2,14 @@ package backend
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var ErrUserNotFound = errors.New("user not found")
+
 type User struct {
 	ID          uuid.UUID
 	ClerkUserID string
@@ -121,6 +124,7 @@ type IdentityService interface {
 
 	SetOrganizationMetadata(context.Context, OrganizationMetadataCommand) error
 	Profile(context.Context, ProfileQuery) (Profile, error)
+	OrganizationUser(context.Context, OrganizationUserQuery) (User, error)
 }
 
 type OrganizationMetadataCommand struct {
@@ -136,6 +140,15 @@ type ProfileQuery struct {
 	ClerkOrgID  string
 }
 
+// OrganizationUserQuery finds a member of the organization by ID or, when
+// UserID is empty, by case-insensitive email. It returns ErrUserNotFound when
+// no member matches.
+type OrganizationUserQuery struct {
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	Email          string
+}
+
 type UserCreatedEvent struct {
 	ClerkUserID string
 	Email       string
@@ -0,0 +1,14 @@
+package identityapi
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/73ai/infragpt/services/backend/internal/identitysvc/domain"
+)
+
+var errorMappings = []httperrors.Mapping{
+	{Target: sql.ErrNoRows, HttpStatus: http.StatusNotFound, Code: httperrors.CodeNotFound, Message: "resource not found"},
+	{Target: domain.ErrDuplicateKey, HttpStatus: http.StatusConflict, Code: httperrors.CodeConflict},
+}
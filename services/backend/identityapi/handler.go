@@ -3,7 +3,6 @@ package identityapi
 import (
 	"context"
 	"encoding/json"
-	"log/slog"
 	"net/http"
 	"time"
 
@@ -183,7 +182,7 @@ func (h *httpHandler) setOrganizationMetadata() func(w http.ResponseWriter, r *h
 	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
 		orgID, err := uuid.Parse(req.OrganizationID)
 		if err != nil {
-			return response{}, err
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
 		}
 
 		useCases := make([]backend.UseCase, len(req.UseCases))
@@ -216,20 +215,18 @@ func ApiHandlerFunc[T any, R any](handler func(context.Context, T) (R, error)) f
 		var request T
 		if r.Method == http.MethodPost && r.Body != nil {
 			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-				http.Error(w, "Invalid JSON", http.StatusBadRequest)
+				httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
 				return
 			}
 		}
 
 		response, err := handler(ctx, request)
 		if err != nil {
-			slog.Error("error in identity api handler", "path", r.URL, "request", request, "err", err)
-			var httpError = httperrors.From(err)
-			w.WriteHeader(httpError.HttpStatus)
-			_ = json.NewEncoder(w).Encode(httpError)
+			httperrors.Write(w, r, err, errorMappings...)
 			return
 		}
 
+		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		_ = json.NewEncoder(w).Encode(response)
 	}
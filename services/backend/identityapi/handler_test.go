@@ -0,0 +1,137 @@
+package identityapi
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/73ai/infragpt/services/backend/internal/identitysvc/domain"
+	"github.com/google/uuid"
+)
+
+type fakeIdentityService struct {
+	backend.IdentityService
+	err error
+}
+
+func (f fakeIdentityService) Profile(ctx context.Context, query backend.ProfileQuery) (backend.Profile, error) {
+	return backend.Profile{}, f.err
+}
+
+func (f fakeIdentityService) SetOrganizationMetadata(ctx context.Context, cmd backend.OrganizationMetadataCommand) error {
+	return f.err
+}
+
+func TestErrorEnvelope(t *testing.T) {
+	noAuth := func(h http.Handler) http.Handler { return h }
+	profileBody := `{"clerk_org_id":"org_123","clerk_user_id":"user_123"}`
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		svcErr     error
+		wantStatus int
+		wantCode   string
+		wantFields []string
+	}{
+		{
+			name:       "validation",
+			path:       "/identity/organization/set-metadata/",
+			body:       `{"organization_id":"not-a-uuid"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   httperrors.CodeValidation,
+			wantFields: []string{"organization_id"},
+		},
+		{
+			name:       "malformed json",
+			path:       "/identity/me/",
+			body:       `{`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   httperrors.CodeValidation,
+		},
+		{
+			name:       "not found",
+			path:       "/identity/organization/",
+			body:       profileBody,
+			svcErr:     fmt.Errorf("failed to get organization: %w", sql.ErrNoRows),
+			wantStatus: http.StatusNotFound,
+			wantCode:   httperrors.CodeNotFound,
+		},
+		{
+			name:       "conflict",
+			path:       "/identity/organization/set-metadata/",
+			body:       fmt.Sprintf(`{"organization_id":%q}`, uuid.NewString()),
+			svcErr:     fmt.Errorf("failed to set metadata: %w", domain.ErrDuplicateKey),
+			wantStatus: http.StatusConflict,
+			wantCode:   httperrors.CodeConflict,
+		},
+		{
+			name:       "unauthorized",
+			path:       "/identity/me/",
+			body:       profileBody,
+			svcErr:     httperrors.Unauthorized("not a member of this organization"),
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   httperrors.CodeUnauthorized,
+		},
+		{
+			name:       "internal",
+			path:       "/identity/me/",
+			body:       profileBody,
+			svcErr:     errors.New(`pq: relation "organizations" does not exist`),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   httperrors.CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(fakeIdentityService{err: tt.svcErr}, noAuth)
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(httperrors.RequestIDHeader, "req-123")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", ct)
+			}
+
+			var envelope map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+				t.Fatalf("response is not JSON: %v", err)
+			}
+			for _, key := range []string{"code", "message", "fields", "request_id"} {
+				if _, ok := envelope[key]; !ok {
+					t.Errorf("envelope is missing %q: %s", key, rec.Body.String())
+				}
+			}
+			if envelope["code"] != tt.wantCode {
+				t.Errorf("code = %v, want %s", envelope["code"], tt.wantCode)
+			}
+			if envelope["request_id"] != "req-123" {
+				t.Errorf("request_id = %v, want req-123", envelope["request_id"])
+			}
+			if tt.wantFields != nil {
+				fields, _ := envelope["fields"].([]any)
+				if len(fields) != len(tt.wantFields) || fields[0] != tt.wantFields[0] {
+					t.Errorf("fields = %v, want %v", fields, tt.wantFields)
+				}
+			}
+			if tt.wantCode == httperrors.CodeInternal && strings.Contains(rec.Body.String(), "relation") {
+				t.Errorf("internal error text leaked to client: %s", rec.Body.String())
+			}
+		})
+	}
+}
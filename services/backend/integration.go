@@ -2,20 +2,73 @@ package backend
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+var (
+	ErrIntegrationNotFound      = errors.New("integration not found")
+	ErrIntegrationAlreadyExists = errors.New("integration already exists")
+	ErrUnsupportedConnector     = errors.New("unsupported connector type")
+	// ErrInvalidState is returned when an authorization callback carries a
+	// state the connector cannot parse.
+	ErrInvalidState      = errors.New("invalid authorization state")
+	ErrCredentialExpired = errors.New("credentials have expired")
+	// ErrInvalidRepositorySelection is returned when a repository selection
+	// names no repositories or has a malformed pattern.
+	ErrInvalidRepositorySelection = errors.New("invalid repository selection")
+	// ErrIntegrationSuspended and ErrIntegrationInactive are returned instead
+	// of using the credentials of an integration the provider has cut off.
+	ErrIntegrationSuspended = errors.New("integration suspended")
+	ErrIntegrationInactive  = errors.New("integration inactive")
+	// ErrGrantMissing is returned by CheckGrant when the organization has not
+	// granted InfraGPT the capability over the integration.
+	ErrGrantMissing = errors.New("integration grant missing")
+	ErrInvalidGrant = errors.New("invalid integration grant")
+	// ErrCredentialMintingUnsupported is returned by MintCredentials for
+	// connectors that cannot issue short-lived credentials.
+	ErrCredentialMintingUnsupported = errors.New("connector cannot mint short-lived credentials")
+)
+
+// IntegrationGrant is a capability an organization grants InfraGPT over one
+// of its integrations.
+type IntegrationGrant string
+
+const (
+	IntegrationGrantReadContent  IntegrationGrant = "read_content"
+	IntegrationGrantWriteContent IntegrationGrant = "write_content"
+	IntegrationGrantReadInfra    IntegrationGrant = "read_infra"
+	IntegrationGrantWriteInfra   IntegrationGrant = "write_infra"
+)
+
+// DefaultIntegrationGrants are given to integrations authorized without
+// choosing grants.
+var DefaultIntegrationGrants = []IntegrationGrant{
+	IntegrationGrantReadContent,
+	IntegrationGrantWriteContent,
+	IntegrationGrantReadInfra,
+	IntegrationGrantWriteInfra,
+}
+
+func (g IntegrationGrant) Valid() bool {
+	return slices.Contains(DefaultIntegrationGrants, g)
+}
+
 type ConnectorType string
 
 const (
-	ConnectorTypeSlack     ConnectorType = "slack"
-	ConnectorTypeGithub    ConnectorType = "github"
-	ConnectorTypeGCP       ConnectorType = "gcp"
-	ConnectorTypeAWS       ConnectorType = "aws"
-	ConnectorTypePagerDuty ConnectorType = "pagerduty"
-	ConnectorTypeDatadog   ConnectorType = "datadog"
+	ConnectorTypeSlack       ConnectorType = "slack"
+	ConnectorTypeGithub      ConnectorType = "github"
+	ConnectorTypeGCP         ConnectorType = "gcp"
+	ConnectorTypeAWS         ConnectorType = "aws"
+	ConnectorTypePagerDuty   ConnectorType = "pagerduty"
+	ConnectorTypeDatadog     ConnectorType = "datadog"
+	ConnectorTypeObjectStore ConnectorType = "objectstore"
+	ConnectorTypeAzureDevOps ConnectorType = "azure_devops"
 )
 
 type AuthorizationType string
@@ -32,6 +85,7 @@ const (
 	CredentialTypeOAuth2         CredentialType = "oauth2"
 	CredentialTypeToken          CredentialType = "token"
 	CredentialTypeServiceAccount CredentialType = "service_account"
+	CredentialTypeAccessKey      CredentialType = "access_key"
 )
 
 type IntegrationStatus string
@@ -43,6 +97,9 @@ const (
 	IntegrationStatusNotStarted IntegrationStatus = "not_started"
 	IntegrationStatusSuspended  IntegrationStatus = "suspended"
 	IntegrationStatusDeleted    IntegrationStatus = "deleted"
+	// IntegrationStatusNeedsReauthorization marks integrations whose stored
+	// credentials no longer validate, e.g. after a restore from an export bundle.
+	IntegrationStatusNeedsReauthorization IntegrationStatus = "needs_reauthorization"
 )
 
 type Integration struct {
@@ -58,6 +115,198 @@ type Integration struct {
 	CreatedAt               time.Time
 	UpdatedAt               time.Time
 	LastUsedAt              *time.Time
+	LastSyncedAt            *time.Time
+	Grants                  []IntegrationGrant
+}
+
+func (i Integration) Granted(grant IntegrationGrant) bool {
+	return slices.Contains(i.Grants, grant)
+}
+
+// CheckCredentialsUsable returns ErrIntegrationSuspended or
+// ErrIntegrationInactive when the provider no longer accepts the
+// integration's credentials.
+func (i Integration) CheckCredentialsUsable() error {
+	switch i.Status {
+	case IntegrationStatusSuspended:
+		return ErrIntegrationSuspended
+	case IntegrationStatusInactive, IntegrationStatusDeleted:
+		return ErrIntegrationInactive
+	default:
+		return nil
+	}
+}
+
+// IntegrationSyncStatus describes how fresh an integration's synced data is.
+// Fields are nil when the connector does not track them.
+type IntegrationSyncStatus struct {
+	LastSyncedAt              *time.Time
+	RepositoryCount           *int
+	AccessibleRepositoryCount *int
+	// Health is nil when sync jobs are not tracked.
+	Health *IntegrationSyncHealth
+}
+
+// IntegrationSyncHealth summarises an integration's recent sync jobs.
+type IntegrationSyncHealth struct {
+	LastSuccessfulSyncAt *time.Time
+	// ConsecutiveFailures counts the failed syncs since the last one that
+	// succeeded, including syncs lost with their worker.
+	ConsecutiveFailures int
+	Running             bool
+	// LastSyncResult is what the last successful sync changed.
+	LastSyncResult *SyncResult
+}
+
+// SyncResult counts the resources a connector sync added, removed and
+// updated. Errors lists the resources it skipped and why; a sync with errors
+// still succeeded for everything else.
+type SyncResult struct {
+	Added   int
+	Removed int
+	Updated int
+	Errors  []string
+}
+
+// SyncedRepository is a source repository synced from an integration.
+type SyncedRepository struct {
+	ID            int64
+	Name          string
+	FullName      string
+	URL           string
+	Private       bool
+	DefaultBranch string
+	Permissions   RepositoryPermissions
+	// Enabled is false when the repository was deselected for agent access.
+	// Deselected repositories only show up in repository listings.
+	Enabled      bool
+	LastSyncedAt time.Time
+	// Profile is nil for repositories that have not been profiled.
+	Profile *RepositoryProfile
+}
+
+// RepositoryProfile describes what a repository is built with. ProfiledAt
+// shows how current it is.
+type RepositoryProfile struct {
+	// Languages maps each detected language to its size in bytes.
+	Languages       map[string]int64
+	PrimaryLanguage string
+	// Markers maps a kind of marker file, such as "go", "node", "docker",
+	// "terraform" or "helm", to the paths it was found at.
+	Markers map[string][]string
+	// TreeTruncated means markers deep in the repository may be missing.
+	TreeTruncated bool
+	ProfiledAt    time.Time
+}
+
+type RepositoryPermissions struct {
+	Admin bool
+	Push  bool
+	Pull  bool
+}
+
+type SyncedRepositoriesPage struct {
+	Repositories []SyncedRepository
+	// Total counts the repositories matching the query across all pages.
+	Total int
+}
+
+type IntegrationActivityType string
+
+const (
+	IntegrationActivityAuthorized          IntegrationActivityType = "authorized"
+	IntegrationActivityCredentialRefreshed IntegrationActivityType = "credential_refreshed"
+	IntegrationActivitySyncStarted         IntegrationActivityType = "sync_started"
+	IntegrationActivitySyncCompleted       IntegrationActivityType = "sync_completed"
+	IntegrationActivitySyncFailed          IntegrationActivityType = "sync_failed"
+	IntegrationActivityWebhookProcessed    IntegrationActivityType = "webhook_processed"
+	IntegrationActivityStatusChanged       IntegrationActivityType = "status_changed"
+	IntegrationActivityValidationFailed    IntegrationActivityType = "validation_failed"
+	IntegrationActivityGrantsChanged       IntegrationActivityType = "grants_changed"
+	IntegrationActivityPermissionsChanged  IntegrationActivityType = "permissions_changed"
+	IntegrationActivityRevoked             IntegrationActivityType = "revoked"
+)
+
+// IntegrationActivity is an entry in an integration's activity feed.
+type IntegrationActivity struct {
+	ID             uuid.UUID
+	IntegrationID  uuid.UUID
+	OrganizationID uuid.UUID
+	Type           IntegrationActivityType
+	Details        map[string]string
+	CreatedAt      time.Time
+}
+
+type IntegrationActivityPage struct {
+	Activities []IntegrationActivity
+	// Total counts the entries matching the query across all pages.
+	Total int
+}
+
+type CredentialAccessorType string
+
+const (
+	CredentialAccessorUser    CredentialAccessorType = "user"
+	CredentialAccessorDevice  CredentialAccessorType = "device"
+	CredentialAccessorService CredentialAccessorType = "service"
+)
+
+// CredentialAccessor identifies who read an integration's credentials, from
+// where and why. ID names the user, device or service by Type, and UserID is
+// the user a device acts for. Reason is the endpoint or an internal caller tag.
+// ConversationID and ToolCallID name the agent operation that consumed
+// credentials minted by MintCredentials.
+type CredentialAccessor struct {
+	Type           CredentialAccessorType
+	ID             string
+	UserID         string
+	IP             string
+	Reason         string
+	ConversationID string
+	ToolCallID     string
+}
+
+type credentialAccessorKey struct{}
+
+// WithCredentialAccessor attributes credential reads made with ctx to accessor.
+func WithCredentialAccessor(ctx context.Context, accessor CredentialAccessor) context.Context {
+	return context.WithValue(ctx, credentialAccessorKey{}, accessor)
+}
+
+func CredentialAccessorFromContext(ctx context.Context) (CredentialAccessor, bool) {
+	accessor, ok := ctx.Value(credentialAccessorKey{}).(CredentialAccessor)
+	return accessor, ok
+}
+
+// CredentialAccess is an entry in an integration's append-only credential
+// access log. Fields lists the credential fields that were handed out.
+type CredentialAccess struct {
+	ID             uuid.UUID
+	IntegrationID  uuid.UUID
+	OrganizationID uuid.UUID
+	Accessor       CredentialAccessor
+	Fields         []string
+	AccessedAt     time.Time
+}
+
+type CredentialAccessPage struct {
+	Accesses []CredentialAccess
+	// Total counts the entries matching the query across all pages.
+	Total int
+}
+
+// CredentialAccessAlert reports an integration whose credentials were read
+// more than Threshold times in the last hour.
+type CredentialAccessAlert struct {
+	Integration Integration
+	Accesses    int
+	Threshold   int
+}
+
+// CredentialAccessAlertListener is notified when an integration's credentials
+// are read unusually often.
+type CredentialAccessAlertListener interface {
+	CredentialAccessThresholdExceeded(ctx context.Context, alert CredentialAccessAlert)
 }
 
 type IntegrationAuthorizationIntent struct {
@@ -79,6 +328,14 @@ type Credentials struct {
 	OrganizationInfo *OrganizationInfo
 }
 
+// MintedCredentials are short-lived credentials scoped to one agent operation.
+// Data holds only the minted token, never the integration's stored secrets.
+type MintedCredentials struct {
+	Type      CredentialType
+	Data      map[string]string
+	ExpiresAt time.Time
+}
+
 type OrganizationInfo struct {
 	ExternalID string
 	Name       string
@@ -96,18 +353,99 @@ type IntegrationService interface {
 	AuthorizeIntegration(ctx context.Context, cmd AuthorizeIntegrationCommand) (Integration, error)
 	SyncIntegration(ctx context.Context, cmd SyncIntegrationCommand) error
 	RevokeIntegration(ctx context.Context, cmd RevokeIntegrationCommand) error
+	// DeleteOrganizationData revokes and deletes all of an organization's
+	// integrations when it offboards.
+	DeleteOrganizationData(ctx context.Context, cmd DeleteOrganizationCommand) ([]DeletedResource, error)
+	// CompletedOnboardingSteps reports the connect steps done by an active
+	// GitHub or cloud integration.
+	OnboardingReporter
 	Integrations(ctx context.Context, query IntegrationsQuery) ([]Integration, error)
 	Integration(ctx context.Context, query IntegrationQuery) (Integration, error)
+	IntegrationSyncStatus(ctx context.Context, query IntegrationQuery) (IntegrationSyncStatus, error)
+	IntegrationRepositories(ctx context.Context, query IntegrationRepositoriesQuery) (SyncedRepositoriesPage, error)
+	// SetRepositoriesEnabled selects or deselects repositories for agent
+	// access and returns how many were updated.
+	SetRepositoriesEnabled(ctx context.Context, cmd SetRepositoriesEnabledCommand) (int, error)
+	RepositoryTriggers(ctx context.Context, query RepositoryTriggersQuery) ([]RepositoryTrigger, error)
+	CreateRepositoryTrigger(ctx context.Context, cmd CreateRepositoryTriggerCommand) (RepositoryTrigger, error)
+	UpdateRepositoryTrigger(ctx context.Context, cmd UpdateRepositoryTriggerCommand) (RepositoryTrigger, error)
+	DeleteRepositoryTrigger(ctx context.Context, cmd DeleteRepositoryTriggerCommand) error
 	IntegrationCredentials(ctx context.Context, query IntegrationCredentialsQuery) (Credentials, error)
+	// MintCredentials returns short-lived credentials derived from the
+	// integration's stored ones for an agent operation, reusing those minted
+	// earlier in the conversation until they are about to expire.
+	MintCredentials(ctx context.Context, cmd MintCredentialsCommand) (MintedCredentials, error)
+	IntegrationPermissions(ctx context.Context, query IntegrationQuery) (map[string]string, error)
+	// SetIntegrationGrants replaces the capabilities granted over an integration.
+	SetIntegrationGrants(ctx context.Context, cmd SetIntegrationGrantsCommand) (Integration, error)
+	// CheckGrant returns ErrGrantMissing unless the integration has the grant.
+	// Every action on an organization's behalf is checked here first.
+	CheckGrant(ctx context.Context, query CheckGrantQuery) error
+	IntegrationActivity(ctx context.Context, query IntegrationActivityQuery) (IntegrationActivityPage, error)
+	CredentialAccessHistory(ctx context.Context, query CredentialAccessQuery) (CredentialAccessPage, error)
 	ValidateCredentials(ctx context.Context, connectorType ConnectorType, credentials map[string]any) (CredentialValidationResult, error)
+	ExportIntegrations(ctx context.Context, query ExportIntegrationsQuery) ([]byte, error)
+	ImportIntegrations(ctx context.Context, cmd ImportIntegrationsCommand) (ImportIntegrationsResult, error)
+	// RegisterWebhookRoutes adds connector webhook routes to the main HTTP
+	// server. Call it before Subscribe, which then leaves those connectors alone.
+	RegisterWebhookRoutes(mux *http.ServeMux)
 	Subscribe(ctx context.Context) error
 }
 
+// IntegrationStatusListener is notified after an integration is created,
+// deleted or changes status.
+type IntegrationStatusListener interface {
+	IntegrationStatusChanged(ctx context.Context, integration Integration)
+}
+
+// ExportIntegrationsQuery exports an organization's integrations and their
+// credentials as a bundle encrypted with Passphrase.
+type ExportIntegrationsQuery struct {
+	OrganizationID uuid.UUID
+	Passphrase     string
+}
+
+// ImportIntegrationsCommand restores a bundle produced by ExportIntegrations
+// into OrganizationID, which need not be the organization it was exported from.
+type ImportIntegrationsCommand struct {
+	OrganizationID uuid.UUID
+	Bundle         []byte
+	Passphrase     string
+}
+
+type ImportIntegrationsResult struct {
+	Restored             []Integration
+	NeedsReauthorization []Integration
+	// Skipped lists bundle entries for connectors the organization already has.
+	Skipped []Integration
+}
+
 type IntegrationCredentialsQuery struct {
 	IntegrationID  uuid.UUID
 	OrganizationID uuid.UUID
 }
 
+// MintCredentialsCommand asks for credentials limited to Scope for the tool
+// call ToolCallID in ConversationID. MinValidity is how long the credentials
+// must remain valid, such as the operation's timeout.
+type MintCredentialsCommand struct {
+	IntegrationID  uuid.UUID
+	OrganizationID uuid.UUID
+	ConversationID string
+	ToolCallID     string
+	Scope          CredentialScope
+	MinValidity    time.Duration
+}
+
+// CredentialScope narrows minted credentials. GCP uses OAuthScopes and GitHub
+// uses Repositories, given as "owner/name", and Permissions, such as
+// "contents": "read"; empty Permissions keep the installation's.
+type CredentialScope struct {
+	OAuthScopes  []string
+	Repositories []string
+	Permissions  map[string]string
+}
+
 type NewIntegrationCommand struct {
 	OrganizationID uuid.UUID
 	UserID         uuid.UUID
@@ -119,6 +457,10 @@ type AuthorizeIntegrationCommand struct {
 	Code           string
 	State          string
 	InstallationID string
+	// IdempotencyKey is combined with State and InstallationID to deduplicate repeated callbacks.
+	IdempotencyKey string
+	// Grants defaults to DefaultIntegrationGrants when empty.
+	Grants []IntegrationGrant
 }
 
 type RevokeIntegrationCommand struct {
@@ -137,6 +479,59 @@ type IntegrationQuery struct {
 	OrganizationID uuid.UUID
 }
 
+type SetIntegrationGrantsCommand struct {
+	IntegrationID  uuid.UUID
+	OrganizationID uuid.UUID
+	Grants         []IntegrationGrant
+}
+
+type CheckGrantQuery struct {
+	IntegrationID  uuid.UUID
+	OrganizationID uuid.UUID
+	Grant          IntegrationGrant
+}
+
+type IntegrationRepositoriesQuery struct {
+	IntegrationID  uuid.UUID
+	OrganizationID uuid.UUID
+	// Name filters repositories whose full name contains it, case-insensitively.
+	Name   string
+	Limit  int
+	Offset int
+}
+
+// SetRepositoriesEnabledCommand selects repositories by ID and by a glob
+// over full names, such as "acme/infra-*". Matching is case-insensitive.
+type SetRepositoriesEnabledCommand struct {
+	IntegrationID  uuid.UUID
+	OrganizationID uuid.UUID
+	RepositoryIDs  []int64
+	NamePattern    string
+	Enabled        bool
+}
+
+// IntegrationActivityQuery lists activity newest first. Zero Since and Until
+// leave that end of the time range open.
+type IntegrationActivityQuery struct {
+	IntegrationID  uuid.UUID
+	OrganizationID uuid.UUID
+	Since          time.Time
+	Until          time.Time
+	Limit          int
+	Offset         int
+}
+
+// CredentialAccessQuery lists credential accesses newest first. Zero Since and
+// Until leave that end of the time range open.
+type CredentialAccessQuery struct {
+	IntegrationID  uuid.UUID
+	OrganizationID uuid.UUID
+	Since          time.Time
+	Until          time.Time
+	Limit          int
+	Offset         int
+}
+
 type SyncIntegrationCommand struct {
 	IntegrationID  uuid.UUID
 	OrganizationID uuid.UUID
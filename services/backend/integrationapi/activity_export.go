@@ -0,0 +1,79 @@
+package integrationapi
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+)
+
+// activityExportPageSize is the page size used to read the whole history; the
+// service caps pages at this size.
+const activityExportPageSize = 200
+
+// exportActivity downloads an integration's complete activity history, oldest
+// first, as CSV or, with format=json, as a JSON array. It accepts the same
+// integration_id, organization_id, since and until query parameters as the
+// activity list.
+func (h *httpHandler) exportActivity() func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		params := r.URL.Query()
+		query, err := activityQuery(params.Get("integration_id"), params.Get("organization_id"), params.Get("since"), params.Get("until"))
+		if err != nil {
+			httperrors.Write(w, r, err)
+			return
+		}
+		format := params.Get("format")
+		if format == "" {
+			format = "csv"
+		}
+		if format != "csv" && format != "json" {
+			httperrors.Write(w, r, httperrors.Validation("format must be csv or json", "format"))
+			return
+		}
+
+		var activities []backend.IntegrationActivity
+		query.Limit = activityExportPageSize
+		for {
+			page, err := h.svc.IntegrationActivity(r.Context(), query)
+			if err != nil {
+				httperrors.Write(w, r, err, errorMappings...)
+				return
+			}
+			activities = append(activities, page.Activities...)
+			if len(page.Activities) == 0 || len(activities) >= page.Total {
+				break
+			}
+			query.Offset += len(page.Activities)
+		}
+		for i, j := 0, len(activities)-1; i < j; i, j = i+1, j-1 {
+			activities[i], activities[j] = activities[j], activities[i]
+		}
+
+		filename := fmt.Sprintf("integration-%s-activity.%s", query.IntegrationID, format)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		if format == "json" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_ = json.NewEncoder(w).Encode(activityEntries(activities))
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/csv")
+		w.WriteHeader(http.StatusOK)
+		out := csv.NewWriter(w)
+		_ = out.Write([]string{"id", "type", "created_at", "details"})
+		for _, activity := range activities {
+			details, _ := json.Marshal(activity.Details)
+			if activity.Details == nil {
+				details = []byte("{}")
+			}
+			_ = out.Write([]string{activity.ID.String(), string(activity.Type), activity.CreatedAt.Format(time.RFC3339), string(details)})
+		}
+		out.Flush()
+	}
+}
@@ -0,0 +1,112 @@
+package integrationapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/google/uuid"
+)
+
+// NewAdminHandler serves the integration endpoints reserved for admins, such
+// as the credential access history and the grants over each integration.
+func NewAdminHandler(integrationService backend.IntegrationService,
+	adminMiddleware func(handler http.Handler) http.Handler) http.Handler {
+	h := &httpHandler{
+		svc: integrationService,
+	}
+
+	h.HandleFunc("/integrations/credential-access/", h.credentialAccess())
+	h.HandleFunc("/integrations/grants/set/", h.setGrants())
+	return adminMiddleware(h)
+}
+
+type credentialAccessEntry struct {
+	ID           string   `json:"id"`
+	AccessorType string   `json:"accessor_type"`
+	AccessorID   string   `json:"accessor_id"`
+	UserID       string   `json:"user_id,omitempty"`
+	IPAddress    string   `json:"ip_address,omitempty"`
+	Reason       string   `json:"reason"`
+	Fields       []string `json:"fields"`
+	AccessedAt   string   `json:"accessed_at"`
+}
+
+// credentialAccess lists who read an integration's credentials, newest first.
+// since and until are RFC 3339 timestamps.
+func (h *httpHandler) credentialAccess() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		IntegrationID  string `json:"integration_id"`
+		OrganizationID string `json:"organization_id"`
+		Since          string `json:"since"`
+		Until          string `json:"until"`
+		Limit          int    `json:"limit"`
+		Offset         int    `json:"offset"`
+	}
+	type response struct {
+		Accesses []credentialAccessEntry `json:"accesses"`
+		Total    int                     `json:"total"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		integrationID, err := uuid.Parse(req.IntegrationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid integration_id", "integration_id")
+		}
+
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		if req.Limit < 0 || req.Offset < 0 {
+			return response{}, httperrors.Validation("limit and offset must not be negative", "limit", "offset")
+		}
+
+		query := backend.CredentialAccessQuery{
+			IntegrationID:  integrationID,
+			OrganizationID: organizationID,
+			Limit:          req.Limit,
+			Offset:         req.Offset,
+		}
+		if req.Since != "" {
+			if query.Since, err = time.Parse(time.RFC3339, req.Since); err != nil {
+				return response{}, httperrors.Validation("since must be an RFC 3339 timestamp", "since")
+			}
+		}
+		if req.Until != "" {
+			if query.Until, err = time.Parse(time.RFC3339, req.Until); err != nil {
+				return response{}, httperrors.Validation("until must be an RFC 3339 timestamp", "until")
+			}
+		}
+		if !query.Since.IsZero() && !query.Until.IsZero() && !query.Since.Before(query.Until) {
+			return response{}, httperrors.Validation("since must be before until", "since", "until")
+		}
+
+		page, err := h.svc.CredentialAccessHistory(ctx, query)
+		if err != nil {
+			return response{}, err
+		}
+
+		entries := make([]credentialAccessEntry, len(page.Accesses))
+		for i, access := range page.Accesses {
+			fields := access.Fields
+			if fields == nil {
+				fields = []string{}
+			}
+			entries[i] = credentialAccessEntry{
+				ID:           access.ID.String(),
+				AccessorType: string(access.Accessor.Type),
+				AccessorID:   access.Accessor.ID,
+				UserID:       access.Accessor.UserID,
+				IPAddress:    access.Accessor.IP,
+				Reason:       access.Accessor.Reason,
+				Fields:       fields,
+				AccessedAt:   access.AccessedAt.Format(time.RFC3339),
+			}
+		}
+		return response{Accesses: entries, Total: page.Total}, nil
+	})
+}
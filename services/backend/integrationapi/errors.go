@@ -0,0 +1,41 @@
+package integrationapi
+
+import (
+	"net/http"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+)
+
+const (
+	codeIntegrationNotFound      = "integration_not_found"
+	codeIntegrationAlreadyExists = "integration_already_exists"
+	codeUnsupportedConnector     = "unsupported_connector"
+	codeInvalidState             = "invalid_state"
+	codeCredentialExpired        = "credential_expired"
+	codeSyncInProgress           = "sync_in_progress"
+	codeInvalidSelection         = "invalid_repository_selection"
+	codeIntegrationSuspended     = "integration_suspended"
+	codeIntegrationInactive      = "integration_inactive"
+	codeInvalidTrigger           = "invalid_repository_trigger"
+	codeTriggerNotFound          = "repository_trigger_not_found"
+	codeInvalidGrant             = "invalid_grant"
+	codeGrantMissing             = "grant_missing"
+)
+
+var errorMappings = []httperrors.Mapping{
+	{Target: backend.ErrIntegrationNotFound, HttpStatus: http.StatusNotFound, Code: codeIntegrationNotFound},
+	{Target: backend.ErrIntegrationAlreadyExists, HttpStatus: http.StatusConflict, Code: codeIntegrationAlreadyExists},
+	{Target: backend.ErrUnsupportedConnector, HttpStatus: http.StatusBadRequest, Code: codeUnsupportedConnector},
+	{Target: backend.ErrInvalidState, HttpStatus: http.StatusBadRequest, Code: codeInvalidState},
+	{Target: backend.ErrCredentialExpired, HttpStatus: http.StatusBadRequest, Code: codeCredentialExpired},
+	{Target: backend.ErrInvalidRepositorySelection, HttpStatus: http.StatusBadRequest, Code: codeInvalidSelection},
+	{Target: backend.ErrIntegrationSuspended, HttpStatus: http.StatusConflict, Code: codeIntegrationSuspended},
+	{Target: backend.ErrIntegrationInactive, HttpStatus: http.StatusConflict, Code: codeIntegrationInactive},
+	{Target: backend.ErrInvalidRepositoryTrigger, HttpStatus: http.StatusBadRequest, Code: codeInvalidTrigger},
+	{Target: backend.ErrRepositoryTriggerNotFound, HttpStatus: http.StatusNotFound, Code: codeTriggerNotFound},
+	{Target: backend.ErrInvalidGrant, HttpStatus: http.StatusBadRequest, Code: codeInvalidGrant},
+	{Target: backend.ErrGrantMissing, HttpStatus: http.StatusForbidden, Code: codeGrantMissing},
+	{Target: domain.ErrSyncInProgress, HttpStatus: http.StatusConflict, Code: codeSyncInProgress},
+}
@@ -0,0 +1,69 @@
+package integrationapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/google/uuid"
+)
+
+// setGrants replaces what InfraGPT may do with an integration, e.g.
+// ["read_content", "read_infra"] to make it read-only.
+func (h *httpHandler) setGrants() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		IntegrationID  string   `json:"integration_id"`
+		OrganizationID string   `json:"organization_id"`
+		Grants         []string `json:"grants"`
+	}
+	type response struct {
+		Grants []string `json:"grants"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		integrationID, err := uuid.Parse(req.IntegrationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid integration_id", "integration_id")
+		}
+
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		if req.Grants == nil {
+			return response{}, httperrors.Validation("grants is required", "grants")
+		}
+
+		integration, err := h.svc.SetIntegrationGrants(ctx, backend.SetIntegrationGrantsCommand{
+			IntegrationID:  integrationID,
+			OrganizationID: organizationID,
+			Grants:         toGrants(req.Grants),
+		})
+		if err != nil {
+			return response{}, err
+		}
+
+		return response{Grants: fromGrants(integration.Grants)}, nil
+	})
+}
+
+func toGrants(strs []string) []backend.IntegrationGrant {
+	if strs == nil {
+		return nil
+	}
+	grants := make([]backend.IntegrationGrant, len(strs))
+	for i, s := range strs {
+		grants[i] = backend.IntegrationGrant(s)
+	}
+	return grants
+}
+
+func fromGrants(grants []backend.IntegrationGrant) []string {
+	strs := make([]string, len(grants))
+	for i, grant := range grants {
+		strs[i] = string(grant)
+	}
+	return strs
+}
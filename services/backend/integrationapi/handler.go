@@ -4,8 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/73ai/infragpt/services/backend"
@@ -25,7 +25,16 @@ func (h *httpHandler) init() {
 	h.HandleFunc("/integrations/list/", h.list())
 	h.HandleFunc("/integrations/revoke/", h.revoke())
 	h.HandleFunc("/integrations/status/", h.status())
+	h.HandleFunc("/integrations/repositories/", h.repositories())
+	h.HandleFunc("/integrations/repositories/enabled/", h.setRepositoriesEnabled())
+	h.HandleFunc("/integrations/permissions/", h.permissions())
+	h.HandleFunc("/integrations/activity/", h.activity())
+	h.HandleFunc("/integrations/activity/export/", h.exportActivity())
 	h.HandleFunc("/integrations/validate/", h.validateCredentials())
+	h.HandleFunc("/integrations/triggers/list/", h.listTriggers())
+	h.HandleFunc("/integrations/triggers/create/", h.createTrigger())
+	h.HandleFunc("/integrations/triggers/update/", h.updateTrigger())
+	h.HandleFunc("/integrations/triggers/delete/", h.deleteTrigger())
 }
 
 func NewHandler(integrationService backend.IntegrationService,
@@ -52,12 +61,12 @@ func (h *httpHandler) initiate() func(w http.ResponseWriter, r *http.Request) {
 	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
 		organizationID, err := uuid.Parse(req.OrganizationID)
 		if err != nil {
-			return response{}, fmt.Errorf("invalid organization_id: %w", err)
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
 		}
 
 		userID, err := uuid.Parse(req.UserID)
 		if err != nil {
-			return response{}, fmt.Errorf("invalid user_id: %w", err)
+			return response{}, httperrors.Validation("invalid user_id", "user_id")
 		}
 
 		cmd := backend.NewIntegrationCommand{
@@ -80,10 +89,12 @@ func (h *httpHandler) initiate() func(w http.ResponseWriter, r *http.Request) {
 
 func (h *httpHandler) authorize() func(w http.ResponseWriter, r *http.Request) {
 	type request struct {
-		ConnectorType  string `json:"connector_type"`
-		Code           string `json:"code"`
-		State          string `json:"state"`
-		InstallationID string `json:"installation_id"`
+		ConnectorType  string   `json:"connector_type"`
+		Code           string   `json:"code"`
+		State          string   `json:"state"`
+		InstallationID string   `json:"installation_id"`
+		IdempotencyKey string   `json:"idempotency_key,omitempty"`
+		Grants         []string `json:"grants,omitempty"`
 	}
 	type response struct {
 		ID                      string            `json:"id"`
@@ -98,6 +109,7 @@ func (h *httpHandler) authorize() func(w http.ResponseWriter, r *http.Request) {
 		CreatedAt               string            `json:"created_at"`
 		UpdatedAt               string            `json:"updated_at"`
 		LastUsedAt              string            `json:"last_used_at,omitempty"`
+		Grants                  []string          `json:"grants"`
 	}
 
 	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
@@ -106,6 +118,8 @@ func (h *httpHandler) authorize() func(w http.ResponseWriter, r *http.Request) {
 			Code:           req.Code,
 			State:          req.State,
 			InstallationID: req.InstallationID,
+			IdempotencyKey: req.IdempotencyKey,
+			Grants:         toGrants(req.Grants),
 		}
 
 		integration, err := h.svc.AuthorizeIntegration(ctx, cmd)
@@ -125,6 +139,7 @@ func (h *httpHandler) authorize() func(w http.ResponseWriter, r *http.Request) {
 			Metadata:                integration.Metadata,
 			CreatedAt:               integration.CreatedAt.Format(time.RFC3339),
 			UpdatedAt:               integration.UpdatedAt.Format(time.RFC3339),
+			Grants:                  fromGrants(integration.Grants),
 		}
 
 		if integration.LastUsedAt != nil {
@@ -153,6 +168,7 @@ func (h *httpHandler) list() func(w http.ResponseWriter, r *http.Request) {
 		CreatedAt               string            `json:"created_at"`
 		UpdatedAt               string            `json:"updated_at"`
 		LastUsedAt              string            `json:"last_used_at,omitempty"`
+		Grants                  []string          `json:"grants"`
 	}
 	type response struct {
 		Integrations []integration `json:"integrations"`
@@ -161,7 +177,7 @@ func (h *httpHandler) list() func(w http.ResponseWriter, r *http.Request) {
 	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
 		organizationID, err := uuid.Parse(req.OrganizationID)
 		if err != nil {
-			return response{}, fmt.Errorf("invalid organization_id: %w", err)
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
 		}
 
 		query := backend.IntegrationsQuery{
@@ -194,6 +210,7 @@ func (h *httpHandler) list() func(w http.ResponseWriter, r *http.Request) {
 				Metadata:                integ.Metadata,
 				CreatedAt:               integ.CreatedAt.Format(time.RFC3339),
 				UpdatedAt:               integ.UpdatedAt.Format(time.RFC3339),
+				Grants:                  fromGrants(integ.Grants),
 			}
 
 			if integ.LastUsedAt != nil {
@@ -215,12 +232,12 @@ func (h *httpHandler) revoke() func(w http.ResponseWriter, r *http.Request) {
 	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
 		integrationID, err := uuid.Parse(req.IntegrationID)
 		if err != nil {
-			return response{}, fmt.Errorf("invalid integration_id: %w", err)
+			return response{}, httperrors.Validation("invalid integration_id", "integration_id")
 		}
 
 		organizationID, err := uuid.Parse(req.OrganizationID)
 		if err != nil {
-			return response{}, fmt.Errorf("invalid organization_id: %w", err)
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
 		}
 
 		cmd := backend.RevokeIntegrationCommand{
@@ -239,30 +256,35 @@ func (h *httpHandler) status() func(w http.ResponseWriter, r *http.Request) {
 		OrganizationID string `json:"organization_id"`
 	}
 	type response struct {
-		ID                      string            `json:"id"`
-		OrganizationID          string            `json:"organization_id"`
-		UserID                  string            `json:"user_id"`
-		ConnectorType           string            `json:"connector_type"`
-		Status                  string            `json:"status"`
-		BotID                   string            `json:"bot_id,omitempty"`
-		ConnectorUserID         string            `json:"connector_user_id,omitempty"`
-		ConnectorOrganizationID string            `json:"connector_organization_id,omitempty"`
-		Metadata                map[string]string `json:"metadata"`
-		CreatedAt               string            `json:"created_at"`
-		UpdatedAt               string            `json:"updated_at"`
-		LastUsedAt              string            `json:"last_used_at,omitempty"`
-		HealthStatus            string            `json:"health_status"`
+		ID                        string            `json:"id"`
+		OrganizationID            string            `json:"organization_id"`
+		UserID                    string            `json:"user_id"`
+		ConnectorType             string            `json:"connector_type"`
+		Status                    string            `json:"status"`
+		BotID                     string            `json:"bot_id,omitempty"`
+		ConnectorUserID           string            `json:"connector_user_id,omitempty"`
+		ConnectorOrganizationID   string            `json:"connector_organization_id,omitempty"`
+		Metadata                  map[string]string `json:"metadata"`
+		CreatedAt                 string            `json:"created_at"`
+		UpdatedAt                 string            `json:"updated_at"`
+		LastUsedAt                string            `json:"last_used_at,omitempty"`
+		HealthStatus              string            `json:"health_status"`
+		LastSyncedAt              *string           `json:"last_synced_at"`
+		RepositoryCount           *int              `json:"repository_count"`
+		AccessibleRepositoryCount *int              `json:"accessible_repository_count"`
+		SyncHealth                *syncHealth       `json:"sync_health,omitempty"`
+		RecentActivity            []activityEntry   `json:"recent_activity"`
 	}
 
 	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
 		integrationID, err := uuid.Parse(req.IntegrationID)
 		if err != nil {
-			return response{}, fmt.Errorf("invalid integration_id: %w", err)
+			return response{}, httperrors.Validation("invalid integration_id", "integration_id")
 		}
 
 		organizationID, err := uuid.Parse(req.OrganizationID)
 		if err != nil {
-			return response{}, fmt.Errorf("invalid organization_id: %w", err)
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
 		}
 
 		query := backend.IntegrationQuery{
@@ -275,31 +297,218 @@ func (h *httpHandler) status() func(w http.ResponseWriter, r *http.Request) {
 			return response{}, err
 		}
 
+		syncStatus, err := h.svc.IntegrationSyncStatus(ctx, query)
+		if err != nil {
+			return response{}, err
+		}
+
+		recentActivity, err := h.svc.IntegrationActivity(ctx, backend.IntegrationActivityQuery{
+			IntegrationID:  integrationID,
+			OrganizationID: organizationID,
+			Limit:          recentActivityLimit,
+		})
+		if err != nil {
+			return response{}, err
+		}
+
 		healthStatus := "unknown"
 
 		resp := response{
-			ID:                      integration.ID.String(),
-			OrganizationID:          integration.OrganizationID.String(),
-			UserID:                  integration.UserID.String(),
-			ConnectorType:           string(integration.ConnectorType),
-			Status:                  string(integration.Status),
-			BotID:                   integration.BotID,
-			ConnectorUserID:         integration.ConnectorUserID,
-			ConnectorOrganizationID: integration.ConnectorOrganizationID,
-			Metadata:                integration.Metadata,
-			CreatedAt:               integration.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:               integration.UpdatedAt.Format(time.RFC3339),
-			HealthStatus:            healthStatus,
+			ID:                        integration.ID.String(),
+			OrganizationID:            integration.OrganizationID.String(),
+			UserID:                    integration.UserID.String(),
+			ConnectorType:             string(integration.ConnectorType),
+			Status:                    string(integration.Status),
+			BotID:                     integration.BotID,
+			ConnectorUserID:           integration.ConnectorUserID,
+			ConnectorOrganizationID:   integration.ConnectorOrganizationID,
+			Metadata:                  integration.Metadata,
+			CreatedAt:                 integration.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:                 integration.UpdatedAt.Format(time.RFC3339),
+			HealthStatus:              healthStatus,
+			RepositoryCount:           syncStatus.RepositoryCount,
+			AccessibleRepositoryCount: syncStatus.AccessibleRepositoryCount,
+			RecentActivity:            activityEntries(recentActivity.Activities),
 		}
 
 		if integration.LastUsedAt != nil {
 			resp.LastUsedAt = integration.LastUsedAt.Format(time.RFC3339)
 		}
 
+		if syncStatus.LastSyncedAt != nil {
+			lastSyncedAt := syncStatus.LastSyncedAt.Format(time.RFC3339)
+			resp.LastSyncedAt = &lastSyncedAt
+		}
+
+		if health := syncStatus.Health; health != nil {
+			resp.SyncHealth = &syncHealth{
+				ConsecutiveFailures: health.ConsecutiveFailures,
+				Running:             health.Running,
+			}
+			if health.LastSuccessfulSyncAt != nil {
+				lastSuccessfulSyncAt := health.LastSuccessfulSyncAt.Format(time.RFC3339)
+				resp.SyncHealth.LastSuccessfulSyncAt = &lastSuccessfulSyncAt
+			}
+			if result := health.LastSyncResult; result != nil {
+				errs := result.Errors
+				if errs == nil {
+					errs = []string{}
+				}
+				resp.SyncHealth.LastSyncResult = &syncResult{
+					Added:   result.Added,
+					Removed: result.Removed,
+					Updated: result.Updated,
+					Errors:  errs,
+				}
+			}
+		}
+
+		return resp, nil
+	})
+}
+
+func (h *httpHandler) repositories() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		IntegrationID  string `json:"integration_id"`
+		OrganizationID string `json:"organization_id"`
+		Name           string `json:"name"`
+		Limit          int    `json:"limit"`
+		Offset         int    `json:"offset"`
+	}
+	type permissions struct {
+		Admin bool `json:"admin"`
+		Push  bool `json:"push"`
+		Pull  bool `json:"pull"`
+	}
+	type profile struct {
+		Languages       map[string]int64    `json:"languages"`
+		PrimaryLanguage string              `json:"primary_language,omitempty"`
+		Markers         map[string][]string `json:"markers"`
+		TreeTruncated   bool                `json:"tree_truncated"`
+		ProfiledAt      string              `json:"profiled_at"`
+	}
+	type repository struct {
+		ID            int64       `json:"id"`
+		Name          string      `json:"name"`
+		FullName      string      `json:"full_name"`
+		URL           string      `json:"url"`
+		Private       bool        `json:"private"`
+		DefaultBranch string      `json:"default_branch"`
+		Permissions   permissions `json:"permissions"`
+		Enabled       bool        `json:"enabled"`
+		LastSyncedAt  string      `json:"last_synced_at,omitempty"`
+		Profile       *profile    `json:"profile,omitempty"`
+	}
+	type response struct {
+		Repositories []repository `json:"repositories"`
+		Total        int          `json:"total"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		integrationID, err := uuid.Parse(req.IntegrationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid integration_id", "integration_id")
+		}
+
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		if req.Limit < 0 || req.Offset < 0 {
+			return response{}, httperrors.Validation("limit and offset must not be negative", "limit", "offset")
+		}
+
+		page, err := h.svc.IntegrationRepositories(ctx, backend.IntegrationRepositoriesQuery{
+			IntegrationID:  integrationID,
+			OrganizationID: organizationID,
+			Name:           req.Name,
+			Limit:          req.Limit,
+			Offset:         req.Offset,
+		})
+		if err != nil {
+			return response{}, err
+		}
+
+		resp := response{
+			Repositories: make([]repository, len(page.Repositories)),
+			Total:        page.Total,
+		}
+		for i, repo := range page.Repositories {
+			resp.Repositories[i] = repository{
+				ID:            repo.ID,
+				Name:          repo.Name,
+				FullName:      repo.FullName,
+				URL:           repo.URL,
+				Private:       repo.Private,
+				DefaultBranch: repo.DefaultBranch,
+				Permissions: permissions{
+					Admin: repo.Permissions.Admin,
+					Push:  repo.Permissions.Push,
+					Pull:  repo.Permissions.Pull,
+				},
+				Enabled: repo.Enabled,
+			}
+			if !repo.LastSyncedAt.IsZero() {
+				resp.Repositories[i].LastSyncedAt = repo.LastSyncedAt.Format(time.RFC3339)
+			}
+			if repo.Profile != nil {
+				resp.Repositories[i].Profile = &profile{
+					Languages:       repo.Profile.Languages,
+					PrimaryLanguage: repo.Profile.PrimaryLanguage,
+					Markers:         repo.Profile.Markers,
+					TreeTruncated:   repo.Profile.TreeTruncated,
+					ProfiledAt:      repo.Profile.ProfiledAt.Format(time.RFC3339),
+				}
+			}
+		}
+
 		return resp, nil
 	})
 }
 
+func (h *httpHandler) setRepositoriesEnabled() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		IntegrationID  string  `json:"integration_id"`
+		OrganizationID string  `json:"organization_id"`
+		RepositoryIDs  []int64 `json:"repository_ids"`
+		NamePattern    string  `json:"name_pattern"`
+		Enabled        *bool   `json:"enabled"`
+	}
+	type response struct {
+		Updated int `json:"updated"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		integrationID, err := uuid.Parse(req.IntegrationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid integration_id", "integration_id")
+		}
+
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		if req.Enabled == nil {
+			return response{}, httperrors.Validation("enabled is required", "enabled")
+		}
+
+		updated, err := h.svc.SetRepositoriesEnabled(ctx, backend.SetRepositoriesEnabledCommand{
+			IntegrationID:  integrationID,
+			OrganizationID: organizationID,
+			RepositoryIDs:  req.RepositoryIDs,
+			NamePattern:    req.NamePattern,
+			Enabled:        *req.Enabled,
+		})
+		if err != nil {
+			return response{}, err
+		}
+
+		return response{Updated: updated}, nil
+	})
+}
+
 func ApiHandlerFunc[T any, R any](handler func(context.Context, T) (R, error)) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -307,23 +516,190 @@ func ApiHandlerFunc[T any, R any](handler func(context.Context, T) (R, error)) f
 		var request T
 		if r.Method == http.MethodPost && r.Body != nil {
 			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-				http.Error(w, "Invalid JSON", http.StatusBadRequest)
+				httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
 				return
 			}
 		}
 
 		response, err := handler(ctx, request)
+		writeResponse(w, r, response, err)
+	}
+}
+
+func writeResponse[R any](w http.ResponseWriter, r *http.Request, response R, err error) {
+	if err != nil {
+		httperrors.Write(w, r, err, errorMappings...)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+type syncHealth struct {
+	LastSuccessfulSyncAt *string     `json:"last_successful_sync_at"`
+	ConsecutiveFailures  int         `json:"consecutive_failures"`
+	Running              bool        `json:"running"`
+	LastSyncResult       *syncResult `json:"last_sync_result,omitempty"`
+}
+
+type syncResult struct {
+	Added   int      `json:"added"`
+	Removed int      `json:"removed"`
+	Updated int      `json:"updated"`
+	Errors  []string `json:"errors"`
+}
+
+// recentActivityLimit is how many activity entries /integrations/status/ includes.
+const recentActivityLimit = 5
+
+type activityEntry struct {
+	ID        string            `json:"id"`
+	Type      string            `json:"type"`
+	Details   map[string]string `json:"details"`
+	CreatedAt string            `json:"created_at"`
+}
+
+func activityEntries(activities []backend.IntegrationActivity) []activityEntry {
+	entries := make([]activityEntry, len(activities))
+	for i, activity := range activities {
+		details := activity.Details
+		if details == nil {
+			details = map[string]string{}
+		}
+		entries[i] = activityEntry{
+			ID:        activity.ID.String(),
+			Type:      string(activity.Type),
+			Details:   details,
+			CreatedAt: activity.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	return entries
+}
+
+// activityQuery validates the filters shared by the activity list and export.
+func activityQuery(integrationID, organizationID, since, until string) (backend.IntegrationActivityQuery, error) {
+	var query backend.IntegrationActivityQuery
+	var err error
+	if query.IntegrationID, err = uuid.Parse(integrationID); err != nil {
+		return query, httperrors.Validation("invalid integration_id", "integration_id")
+	}
+	if query.OrganizationID, err = uuid.Parse(organizationID); err != nil {
+		return query, httperrors.Validation("invalid organization_id", "organization_id")
+	}
+
+	if since != "" {
+		if query.Since, err = time.Parse(time.RFC3339, since); err != nil {
+			return query, httperrors.Validation("since must be an RFC 3339 timestamp", "since")
+		}
+	}
+	if until != "" {
+		if query.Until, err = time.Parse(time.RFC3339, until); err != nil {
+			return query, httperrors.Validation("until must be an RFC 3339 timestamp", "until")
+		}
+	}
+	if !query.Since.IsZero() && !query.Until.IsZero() && !query.Since.Before(query.Until) {
+		return query, httperrors.Validation("since must be before until", "since", "until")
+	}
+	return query, nil
+}
+
+// activity accepts its filters as GET query parameters or a POST JSON body.
+// since and until are RFC 3339 timestamps.
+func (h *httpHandler) activity() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		IntegrationID  string `json:"integration_id"`
+		OrganizationID string `json:"organization_id"`
+		Since          string `json:"since"`
+		Until          string `json:"until"`
+		Limit          int    `json:"limit"`
+		Offset         int    `json:"offset"`
+	}
+	type response struct {
+		Activities []activityEntry `json:"activities"`
+		Total      int             `json:"total"`
+	}
+
+	list := func(ctx context.Context, req request) (response, error) {
+		query, err := activityQuery(req.IntegrationID, req.OrganizationID, req.Since, req.Until)
 		if err != nil {
-			slog.Error("error in integration api handler", "path", r.URL, "request", request, "err", err)
-			var httpError = httperrors.From(err)
-			w.WriteHeader(httpError.HttpStatus)
-			_ = json.NewEncoder(w).Encode(httpError)
+			return response{}, err
+		}
+
+		if req.Limit < 0 || req.Offset < 0 {
+			return response{}, httperrors.Validation("limit and offset must not be negative", "limit", "offset")
+		}
+		query.Limit, query.Offset = req.Limit, req.Offset
+
+		page, err := h.svc.IntegrationActivity(ctx, query)
+		if err != nil {
+			return response{}, err
+		}
+
+		return response{Activities: activityEntries(page.Activities), Total: page.Total}, nil
+	}
+
+	post := ApiHandlerFunc(list)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			post(w, r)
 			return
 		}
 
-		w.WriteHeader(http.StatusOK)
-		_ = json.NewEncoder(w).Encode(response)
+		params := r.URL.Query()
+		req := request{
+			IntegrationID:  params.Get("integration_id"),
+			OrganizationID: params.Get("organization_id"),
+			Since:          params.Get("since"),
+			Until:          params.Get("until"),
+		}
+		for name, value := range map[string]*int{"limit": &req.Limit, "offset": &req.Offset} {
+			if raw := params.Get(name); raw != "" {
+				n, err := strconv.Atoi(raw)
+				if err != nil {
+					httperrors.Write(w, r, httperrors.Validation(name+" must be an integer", name))
+					return
+				}
+				*value = n
+			}
+		}
+
+		resp, err := list(r.Context(), req)
+		writeResponse(w, r, resp, err)
+	}
+}
+
+func (h *httpHandler) permissions() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		IntegrationID  string `json:"integration_id"`
+		OrganizationID string `json:"organization_id"`
 	}
+	type response struct {
+		Permissions map[string]string `json:"permissions"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		integrationID, err := uuid.Parse(req.IntegrationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid integration_id", "integration_id")
+		}
+
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		permissions, err := h.svc.IntegrationPermissions(ctx, backend.IntegrationQuery{
+			IntegrationID:  integrationID,
+			OrganizationID: organizationID,
+		})
+		if err != nil {
+			return response{}, err
+		}
+
+		return response{Permissions: permissions}, nil
+	})
 }
 
 func (h *httpHandler) sync() func(w http.ResponseWriter, r *http.Request) {
@@ -339,12 +715,12 @@ func (h *httpHandler) sync() func(w http.ResponseWriter, r *http.Request) {
 	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
 		integrationID, err := uuid.Parse(req.IntegrationID)
 		if err != nil {
-			return response{}, fmt.Errorf("invalid integration_id: %w", err)
+			return response{}, httperrors.Validation("invalid integration_id", "integration_id")
 		}
 
 		organizationID, err := uuid.Parse(req.OrganizationID)
 		if err != nil {
-			return response{}, fmt.Errorf("invalid organization_id: %w", err)
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
 		}
 
 		cmd := backend.SyncIntegrationCommand{
@@ -0,0 +1,304 @@
+package integrationapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/google/uuid"
+)
+
+type fakeIntegrationService struct {
+	backend.IntegrationService
+	err error
+}
+
+func (f fakeIntegrationService) Integration(ctx context.Context, query backend.IntegrationQuery) (backend.Integration, error) {
+	return backend.Integration{}, f.err
+}
+
+func (f fakeIntegrationService) NewIntegration(ctx context.Context, cmd backend.NewIntegrationCommand) (backend.IntegrationAuthorizationIntent, error) {
+	return backend.IntegrationAuthorizationIntent{}, f.err
+}
+
+func (f fakeIntegrationService) AuthorizeIntegration(ctx context.Context, cmd backend.AuthorizeIntegrationCommand) (backend.Integration, error) {
+	return backend.Integration{}, f.err
+}
+
+type activityService struct {
+	backend.IntegrationService
+	query backend.IntegrationActivityQuery
+}
+
+func (s *activityService) IntegrationActivity(ctx context.Context, query backend.IntegrationActivityQuery) (backend.IntegrationActivityPage, error) {
+	s.query = query
+	return backend.IntegrationActivityPage{
+		Activities: []backend.IntegrationActivity{{ID: uuid.New(), Type: backend.IntegrationActivitySyncStarted}},
+		Total:      7,
+	}, nil
+}
+
+func TestActivityQueryParameters(t *testing.T) {
+	noAuth := func(h http.Handler) http.Handler { return h }
+	integrationID, organizationID := uuid.New(), uuid.New()
+
+	t.Run("parses filters from the query string", func(t *testing.T) {
+		svc := &activityService{}
+		target := fmt.Sprintf("/integrations/activity/?integration_id=%s&organization_id=%s&since=2025-01-02T00:00:00Z&limit=10&offset=20", integrationID, organizationID)
+		rec := httptest.NewRecorder()
+		NewHandler(svc, noAuth).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+		}
+		want := backend.IntegrationActivityQuery{
+			IntegrationID:  integrationID,
+			OrganizationID: organizationID,
+			Since:          time.Date(2025, time.January, 2, 0, 0, 0, 0, time.UTC),
+			Limit:          10,
+			Offset:         20,
+		}
+		if svc.query != want {
+			t.Errorf("query = %+v, want %+v", svc.query, want)
+		}
+		var resp struct {
+			Activities []struct {
+				Type string `json:"type"`
+			} `json:"activities"`
+			Total int `json:"total"`
+		}
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("response is not JSON: %v", err)
+		}
+		if resp.Total != 7 || len(resp.Activities) != 1 || resp.Activities[0].Type != "sync_started" {
+			t.Errorf("response = %+v, want one sync_started entry of 7", resp)
+		}
+	})
+
+	for name, params := range map[string]string{
+		"non-numeric limit": "limit=ten",
+		"malformed since":   "since=yesterday",
+		"inverted range":    "since=2025-01-02T00:00:00Z&until=2025-01-01T00:00:00Z",
+	} {
+		t.Run(name, func(t *testing.T) {
+			target := fmt.Sprintf("/integrations/activity/?integration_id=%s&organization_id=%s&%s", integrationID, organizationID, params)
+			rec := httptest.NewRecorder()
+			NewHandler(&activityService{}, noAuth).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", rec.Code)
+			}
+		})
+	}
+}
+
+type historyService struct {
+	backend.IntegrationService
+	activities []backend.IntegrationActivity
+}
+
+func (s *historyService) IntegrationActivity(ctx context.Context, query backend.IntegrationActivityQuery) (backend.IntegrationActivityPage, error) {
+	start := min(query.Offset, len(s.activities))
+	end := min(start+query.Limit, len(s.activities))
+	return backend.IntegrationActivityPage{Activities: s.activities[start:end], Total: len(s.activities)}, nil
+}
+
+func TestExportActivity(t *testing.T) {
+	noAuth := func(h http.Handler) http.Handler { return h }
+	integrationID, organizationID := uuid.New(), uuid.New()
+
+	// Newest first, as the service lists them, and more than one page.
+	svc := &historyService{}
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	for i := 250; i > 0; i-- {
+		svc.activities = append(svc.activities, backend.IntegrationActivity{
+			ID:        uuid.New(),
+			Type:      backend.IntegrationActivitySyncCompleted,
+			Details:   map[string]string{"n": fmt.Sprint(i)},
+			CreatedAt: start.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		target := fmt.Sprintf("/integrations/activity/export/?integration_id=%s&organization_id=%s", integrationID, organizationID)
+		rec := httptest.NewRecorder()
+		NewHandler(svc, noAuth).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+		}
+		if got := rec.Header().Get("Content-Disposition"); !strings.Contains(got, integrationID.String()+"-activity.csv") {
+			t.Errorf("Content-Disposition = %q, want a csv attachment", got)
+		}
+		lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+		if len(lines) != 251 || lines[0] != "id,type,created_at,details" {
+			t.Fatalf("export has %d lines starting %q, want a header and 250 entries", len(lines), lines[0])
+		}
+		if !strings.HasSuffix(lines[1], `2025-01-01T00:01:00Z,"{""n"":""1""}"`) {
+			t.Errorf("first entry = %q, want the oldest", lines[1])
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		target := fmt.Sprintf("/integrations/activity/export/?integration_id=%s&organization_id=%s&format=json", integrationID, organizationID)
+		rec := httptest.NewRecorder()
+		NewHandler(svc, noAuth).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+		var entries []activityEntry
+		if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil {
+			t.Fatalf("response is not JSON: %v", err)
+		}
+		if len(entries) != 250 || entries[249].Details["n"] != "250" {
+			t.Errorf("export has %d entries, want 250 ending with the newest", len(entries))
+		}
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		target := fmt.Sprintf("/integrations/activity/export/?integration_id=%s&organization_id=%s&format=xml", integrationID, organizationID)
+		rec := httptest.NewRecorder()
+		NewHandler(svc, noAuth).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want 400", rec.Code)
+		}
+	})
+}
+
+func TestErrorEnvelope(t *testing.T) {
+	noAuth := func(h http.Handler) http.Handler { return h }
+	validStatusBody := fmt.Sprintf(`{"integration_id":%q,"organization_id":%q}`, uuid.NewString(), uuid.NewString())
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		svcErr     error
+		wantStatus int
+		wantCode   string
+		wantFields []string
+	}{
+		{
+			name:       "validation",
+			path:       "/integrations/status/",
+			body:       `{"integration_id":"not-a-uuid"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   httperrors.CodeValidation,
+			wantFields: []string{"integration_id"},
+		},
+		{
+			name:       "malformed json",
+			path:       "/integrations/status/",
+			body:       `{`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   httperrors.CodeValidation,
+		},
+		{
+			name:       "not found",
+			path:       "/integrations/status/",
+			body:       validStatusBody,
+			svcErr:     fmt.Errorf("failed to find integration: %w", backend.ErrIntegrationNotFound),
+			wantStatus: http.StatusNotFound,
+			wantCode:   "integration_not_found",
+		},
+		{
+			name:       "conflict",
+			path:       "/integrations/initiate/",
+			body:       fmt.Sprintf(`{"organization_id":%q,"user_id":%q,"connector_type":"github"}`, uuid.NewString(), uuid.NewString()),
+			svcErr:     fmt.Errorf("%w for connector type github", backend.ErrIntegrationAlreadyExists),
+			wantStatus: http.StatusConflict,
+			wantCode:   "integration_already_exists",
+		},
+		{
+			name:       "duplicate authorize",
+			path:       "/integrations/authorize/",
+			body:       `{"connector_type":"github","state":"abc","installation_id":"42"}`,
+			svcErr:     fmt.Errorf("%w for connector type github", backend.ErrIntegrationAlreadyExists),
+			wantStatus: http.StatusConflict,
+			wantCode:   "integration_already_exists",
+		},
+		{
+			name:       "invalid state",
+			path:       "/integrations/authorize/",
+			body:       `{"connector_type":"github","state":"abc","installation_id":"42"}`,
+			svcErr:     fmt.Errorf("failed to parse state: %w: invalid state format", backend.ErrInvalidState),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   "invalid_state",
+		},
+		{
+			name:       "unauthorized",
+			path:       "/integrations/status/",
+			body:       validStatusBody,
+			svcErr:     httperrors.Unauthorized("not a member of this organization"),
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   httperrors.CodeUnauthorized,
+		},
+		{
+			name:       "rate limited",
+			path:       "/integrations/status/",
+			body:       validStatusBody,
+			svcErr:     httperrors.RateLimited("too many requests"),
+			wantStatus: http.StatusTooManyRequests,
+			wantCode:   httperrors.CodeRateLimited,
+		},
+		{
+			name:       "internal",
+			path:       "/integrations/status/",
+			body:       validStatusBody,
+			svcErr:     errors.New(`pq: relation "integrations" does not exist`),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   httperrors.CodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(fakeIntegrationService{err: tt.svcErr}, noAuth)
+
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(httperrors.RequestIDHeader, "req-123")
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", ct)
+			}
+
+			var envelope map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+				t.Fatalf("response is not JSON: %v", err)
+			}
+			for _, key := range []string{"code", "message", "fields", "request_id"} {
+				if _, ok := envelope[key]; !ok {
+					t.Errorf("envelope is missing %q: %s", key, rec.Body.String())
+				}
+			}
+			if envelope["code"] != tt.wantCode {
+				t.Errorf("code = %v, want %s", envelope["code"], tt.wantCode)
+			}
+			if envelope["request_id"] != "req-123" {
+				t.Errorf("request_id = %v, want req-123", envelope["request_id"])
+			}
+			if tt.wantFields != nil {
+				fields, _ := envelope["fields"].([]any)
+				if len(fields) != len(tt.wantFields) || fields[0] != tt.wantFields[0] {
+					t.Errorf("fields = %v, want %v", fields, tt.wantFields)
+				}
+			}
+			if tt.svcErr != nil && tt.wantCode == httperrors.CodeInternal &&
+				strings.Contains(rec.Body.String(), "relation") {
+				t.Errorf("internal error text leaked to client: %s", rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package integrationapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/google/uuid"
+)
+
+type repositoryTrigger struct {
+	ID                string `json:"id"`
+	RepositoryPattern string `json:"repository_pattern"`
+	EventType         string `json:"event_type"`
+	PathGlob          string `json:"path_glob,omitempty"`
+	Channel           string `json:"channel"`
+	CreatedAt         string `json:"created_at"`
+	UpdatedAt         string `json:"updated_at"`
+}
+
+func toRepositoryTrigger(trigger backend.RepositoryTrigger) repositoryTrigger {
+	return repositoryTrigger{
+		ID:                trigger.ID.String(),
+		RepositoryPattern: trigger.RepositoryPattern,
+		EventType:         string(trigger.EventType),
+		PathGlob:          trigger.PathGlob,
+		Channel:           trigger.Channel,
+		CreatedAt:         trigger.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         trigger.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (h *httpHandler) listTriggers() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		OrganizationID string `json:"organization_id"`
+	}
+	type response struct {
+		Triggers []repositoryTrigger `json:"triggers"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		triggers, err := h.svc.RepositoryTriggers(ctx, backend.RepositoryTriggersQuery{OrganizationID: organizationID})
+		if err != nil {
+			return response{}, err
+		}
+
+		resp := response{Triggers: make([]repositoryTrigger, len(triggers))}
+		for i, trigger := range triggers {
+			resp.Triggers[i] = toRepositoryTrigger(trigger)
+		}
+		return resp, nil
+	})
+}
+
+// createTrigger starts a conversation in channel for every push or pull
+// request to a repository matching repository_pattern that touches path_glob.
+func (h *httpHandler) createTrigger() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		OrganizationID    string `json:"organization_id"`
+		RepositoryPattern string `json:"repository_pattern"`
+		EventType         string `json:"event_type"`
+		PathGlob          string `json:"path_glob"`
+		Channel           string `json:"channel"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (repositoryTrigger, error) {
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return repositoryTrigger{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		trigger, err := h.svc.CreateRepositoryTrigger(ctx, backend.CreateRepositoryTriggerCommand{
+			OrganizationID:    organizationID,
+			RepositoryPattern: req.RepositoryPattern,
+			EventType:         backend.RepositoryEventType(req.EventType),
+			PathGlob:          req.PathGlob,
+			Channel:           req.Channel,
+		})
+		if err != nil {
+			return repositoryTrigger{}, err
+		}
+		return toRepositoryTrigger(trigger), nil
+	})
+}
+
+func (h *httpHandler) updateTrigger() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		ID                string `json:"id"`
+		OrganizationID    string `json:"organization_id"`
+		RepositoryPattern string `json:"repository_pattern"`
+		EventType         string `json:"event_type"`
+		PathGlob          string `json:"path_glob"`
+		Channel           string `json:"channel"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (repositoryTrigger, error) {
+		id, err := uuid.Parse(req.ID)
+		if err != nil {
+			return repositoryTrigger{}, httperrors.Validation("invalid id", "id")
+		}
+
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return repositoryTrigger{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		trigger, err := h.svc.UpdateRepositoryTrigger(ctx, backend.UpdateRepositoryTriggerCommand{
+			ID:                id,
+			OrganizationID:    organizationID,
+			RepositoryPattern: req.RepositoryPattern,
+			EventType:         backend.RepositoryEventType(req.EventType),
+			PathGlob:          req.PathGlob,
+			Channel:           req.Channel,
+		})
+		if err != nil {
+			return repositoryTrigger{}, err
+		}
+		return toRepositoryTrigger(trigger), nil
+	})
+}
+
+func (h *httpHandler) deleteTrigger() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		ID             string `json:"id"`
+		OrganizationID string `json:"organization_id"`
+	}
+	type response struct{}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		id, err := uuid.Parse(req.ID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid id", "id")
+		}
+
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		err = h.svc.DeleteRepositoryTrigger(ctx, backend.DeleteRepositoryTriggerCommand{
+			ID:             id,
+			OrganizationID: organizationID,
+		})
+		return response{}, err
+	})
+}
@@ -0,0 +1,323 @@
+package conversationsvc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/google/uuid"
+)
+
+// kubernetesNamespacePattern is the RFC 1123 label format Kubernetes requires
+// for namespace names.
+var kubernetesNamespacePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$`)
+
+const channelContextUsage = "Usage:\n" +
+	"• `/infragpt context` shows this channel's default context\n" +
+	"• `/infragpt context set repo=acme/payments namespace=payments project=acme-prod` replaces it; separate multiple values with commas\n" +
+	"• `/infragpt context clear` removes it"
+
+func (s *Service) ConfigureChannelContext(ctx context.Context, cmd backend.ConfigureChannelContextCommand) (backend.ChannelContext, error) {
+	if cmd.ChannelID == "" {
+		return backend.ChannelContext{}, fmt.Errorf("%w: channel is required", domain.ErrInvalidChannelContext)
+	}
+
+	teamID, err := s.organizationWorkspace(ctx, cmd.OrganizationID, cmd.TeamID)
+	if err != nil {
+		return backend.ChannelContext{}, err
+	}
+
+	channelContext, err := s.validateChannelContext(ctx, cmd.OrganizationID, backend.ChannelContext{
+		TeamID:               teamID,
+		ChannelID:            cmd.ChannelID,
+		Repositories:         cmd.Repositories,
+		KubernetesNamespaces: cmd.KubernetesNamespaces,
+		GCPProjects:          cmd.GCPProjects,
+	})
+	if err != nil {
+		return backend.ChannelContext{}, err
+	}
+
+	if err := s.channelRepository.SetChannelContext(ctx, channelContext); err != nil {
+		return backend.ChannelContext{}, fmt.Errorf("failed to save channel context: %w", err)
+	}
+	return channelContext, nil
+}
+
+func (s *Service) ChannelContexts(ctx context.Context, query backend.ChannelContextsQuery) ([]backend.ChannelContext, error) {
+	workspaces, err := s.organizationWorkspaces(ctx, query.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	var contexts []backend.ChannelContext
+	for _, teamID := range workspaces {
+		channelContexts, err := s.channelRepository.ChannelContexts(ctx, teamID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list channel contexts: %w", err)
+		}
+		contexts = append(contexts, channelContexts...)
+	}
+	return contexts, nil
+}
+
+func (s *Service) handleSlashCommand(ctx context.Context, command domain.SlashCommand) (string, error) {
+	args := strings.Fields(command.Text)
+	if len(args) == 0 || args[0] != "context" {
+		return channelContextUsage, nil
+	}
+	args = args[1:]
+
+	organizationID, err := s.integrationRepository.BusinessIDByProviderProjectID(ctx, backend.ConnectorTypeSlack, command.TeamID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "This workspace isn't connected to an InfraGPT organization yet. Finish setup in the dashboard first.", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find organization for team: %w", err)
+	}
+
+	if len(args) == 0 || args[0] == "show" {
+		channelContext, err := s.channelRepository.ChannelContext(ctx, command.TeamID, command.ChannelID)
+		if err != nil {
+			return "", fmt.Errorf("failed to get channel context: %w", err)
+		}
+		return describeChannelContext(channelContext), nil
+	}
+
+	var cmd backend.ConfigureChannelContextCommand
+	switch args[0] {
+	case "clear":
+	case "set":
+		cmd, err = parseChannelContextBindings(args[1:])
+		if err != nil {
+			return "Couldn't read the bindings: " + err.Error() + "\n\n" + channelContextUsage, nil
+		}
+	default:
+		return channelContextUsage, nil
+	}
+
+	if s.maintenance.ReadOnly() {
+		return readOnlyReply, nil
+	}
+
+	cmd.OrganizationID = organizationID
+	cmd.TeamID = command.TeamID
+	cmd.ChannelID = command.ChannelID
+	channelContext, err := s.ConfigureChannelContext(ctx, cmd)
+	if errors.Is(err, domain.ErrInvalidChannelContext) {
+		return "Couldn't save the channel context: " + err.Error(), nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	slog.Info("Configured channel context", "team_id", command.TeamID, "channel", command.ChannelID, "user", command.UserID)
+	return describeChannelContext(channelContext), nil
+}
+
+func parseChannelContextBindings(args []string) (backend.ConfigureChannelContextCommand, error) {
+	var cmd backend.ConfigureChannelContextCommand
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || value == "" {
+			return cmd, fmt.Errorf("expected key=value, got `%s`", arg)
+		}
+		values := strings.Split(value, ",")
+		switch key {
+		case "repo", "repos", "repository":
+			cmd.Repositories = append(cmd.Repositories, values...)
+		case "namespace", "namespaces", "ns":
+			cmd.KubernetesNamespaces = append(cmd.KubernetesNamespaces, values...)
+		case "project", "projects":
+			cmd.GCPProjects = append(cmd.GCPProjects, values...)
+		default:
+			return cmd, fmt.Errorf("unknown binding `%s`, use repo, namespace or project", key)
+		}
+	}
+	return cmd, nil
+}
+
+func describeChannelContext(c backend.ChannelContext) string {
+	if len(c.Repositories) == 0 && len(c.KubernetesNamespaces) == 0 && len(c.GCPProjects) == 0 {
+		return "This channel has no default context.\n\n" + channelContextUsage
+	}
+
+	lines := []string{"Default context for <#" + c.ChannelID + ">:"}
+	for _, binding := range []struct {
+		name   string
+		values []string
+	}{
+		{"Repositories", c.Repositories},
+		{"Kubernetes namespaces", c.KubernetesNamespaces},
+		{"GCP projects", c.GCPProjects},
+	} {
+		if len(binding.values) > 0 {
+			lines = append(lines, fmt.Sprintf("• *%s:* %s", binding.name, strings.Join(binding.values, ", ")))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// validateChannelContext checks each binding against the organization's
+// active integrations and returns the context with bindings normalized.
+func (s *Service) validateChannelContext(ctx context.Context, organizationID uuid.UUID, c backend.ChannelContext) (backend.ChannelContext, error) {
+	c.Repositories = normalizeBindings(c.Repositories)
+	c.KubernetesNamespaces = normalizeBindings(c.KubernetesNamespaces)
+	c.GCPProjects = normalizeBindings(c.GCPProjects)
+	if len(c.Repositories) == 0 && len(c.KubernetesNamespaces) == 0 && len(c.GCPProjects) == 0 {
+		return c, nil
+	}
+	if s.integrations == nil {
+		return backend.ChannelContext{}, fmt.Errorf("integration service is required to validate channel context")
+	}
+
+	if len(c.Repositories) > 0 {
+		repositories, err := s.validateRepositories(ctx, organizationID, c.Repositories)
+		if err != nil {
+			return backend.ChannelContext{}, err
+		}
+		c.Repositories = repositories
+	}
+
+	if len(c.KubernetesNamespaces) == 0 && len(c.GCPProjects) == 0 {
+		return c, nil
+	}
+
+	gcp, err := s.activeIntegrations(ctx, organizationID, backend.ConnectorTypeGCP)
+	if err != nil {
+		return backend.ChannelContext{}, err
+	}
+
+	projects := make(map[string]bool)
+	hasCluster := false
+	for _, integration := range gcp {
+		projects[integration.Metadata["project_id"]] = true
+		hasCluster = hasCluster || integration.Metadata["gke_cluster_name"] != ""
+	}
+	for _, project := range c.GCPProjects {
+		if !projects[project] {
+			return backend.ChannelContext{}, fmt.Errorf("%w: GCP project %s is not connected", domain.ErrInvalidChannelContext, project)
+		}
+	}
+
+	if len(c.KubernetesNamespaces) > 0 && !hasCluster {
+		return backend.ChannelContext{}, fmt.Errorf("%w: no GKE cluster is connected", domain.ErrInvalidChannelContext)
+	}
+	for _, namespace := range c.KubernetesNamespaces {
+		if !kubernetesNamespacePattern.MatchString(namespace) {
+			return backend.ChannelContext{}, fmt.Errorf("%w: %s is not a valid Kubernetes namespace", domain.ErrInvalidChannelContext, namespace)
+		}
+	}
+
+	return c, nil
+}
+
+// validateRepositories returns the repositories' canonical full names as
+// synced from the organization's GitHub and Azure DevOps integrations.
+func (s *Service) validateRepositories(ctx context.Context, organizationID uuid.UUID, names []string) ([]string, error) {
+	var sources []backend.Integration
+	for _, connectorType := range []backend.ConnectorType{backend.ConnectorTypeGithub, backend.ConnectorTypeAzureDevOps} {
+		integrations, err := s.activeIntegrations(ctx, organizationID, connectorType)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, integrations...)
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("%w: no GitHub or Azure DevOps integration is connected", domain.ErrInvalidChannelContext)
+	}
+
+	canonical := make([]string, 0, len(names))
+	for _, name := range names {
+		fullName, err := s.findRepository(ctx, sources, name)
+		if err != nil {
+			return nil, err
+		}
+		if fullName == "" {
+			return nil, fmt.Errorf("%w: repository %s is not available to the connected integrations", domain.ErrInvalidChannelContext, name)
+		}
+		canonical = append(canonical, fullName)
+	}
+	return normalizeBindings(canonical), nil
+}
+
+func (s *Service) findRepository(ctx context.Context, integrations []backend.Integration, name string) (string, error) {
+	for _, integration := range integrations {
+		page, err := s.integrations.IntegrationRepositories(ctx, backend.IntegrationRepositoriesQuery{
+			IntegrationID:  integration.ID,
+			OrganizationID: integration.OrganizationID,
+			Name:           name,
+			Limit:          200,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to list repositories: %w", err)
+		}
+		for _, repository := range page.Repositories {
+			if repository.Enabled && strings.EqualFold(repository.FullName, name) {
+				return repository.FullName, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+func (s *Service) activeIntegrations(ctx context.Context, organizationID uuid.UUID, connectorType backend.ConnectorType) ([]backend.Integration, error) {
+	integrations, err := s.integrations.Integrations(ctx, backend.IntegrationsQuery{
+		OrganizationID: organizationID,
+		ConnectorType:  connectorType,
+		Status:         backend.IntegrationStatusActive,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s integrations: %w", connectorType, err)
+	}
+	return integrations, nil
+}
+
+// organizationWorkspace resolves the Slack workspace a request refers to,
+// defaulting to the organization's only workspace when teamID is empty.
+func (s *Service) organizationWorkspace(ctx context.Context, organizationID uuid.UUID, teamID string) (string, error) {
+	workspaces, err := s.organizationWorkspaces(ctx, organizationID)
+	if err != nil {
+		return "", err
+	}
+	if teamID == "" && len(workspaces) == 1 {
+		return workspaces[0], nil
+	}
+	if teamID == "" || !slices.Contains(workspaces, teamID) {
+		return "", domain.ErrWorkspaceNotLinked
+	}
+	return teamID, nil
+}
+
+func (s *Service) organizationWorkspaces(ctx context.Context, organizationID uuid.UUID) ([]string, error) {
+	integrations, err := s.integrationRepository.Integrations(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get integrations: %w", err)
+	}
+
+	var workspaces []string
+	for _, integration := range integrations {
+		if integration.ConnectorType == backend.ConnectorTypeSlack && !slices.Contains(workspaces, integration.ProviderProjectID) {
+			workspaces = append(workspaces, integration.ProviderProjectID)
+		}
+	}
+	return workspaces, nil
+}
+
+func normalizeBindings(values []string) []string {
+	var normalized []string
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" && !slices.Contains(normalized, value) {
+			normalized = append(normalized, value)
+		}
+	}
+	return normalized
+}
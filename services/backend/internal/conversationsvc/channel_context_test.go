@@ -0,0 +1,141 @@
+package conversationsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/google/uuid"
+)
+
+type integrationService struct {
+	backend.IntegrationService
+	integrations []backend.Integration
+	repositories []backend.SyncedRepository
+}
+
+func (s integrationService) Integrations(ctx context.Context, query backend.IntegrationsQuery) ([]backend.Integration, error) {
+	var integrations []backend.Integration
+	for _, integration := range s.integrations {
+		if integration.ConnectorType == query.ConnectorType {
+			integrations = append(integrations, integration)
+		}
+	}
+	return integrations, nil
+}
+
+func (s integrationService) IntegrationRepositories(ctx context.Context, query backend.IntegrationRepositoriesQuery) (backend.SyncedRepositoriesPage, error) {
+	var page backend.SyncedRepositoriesPage
+	for _, repository := range s.repositories {
+		if strings.Contains(strings.ToLower(repository.FullName), strings.ToLower(query.Name)) {
+			page.Repositories = append(page.Repositories, repository)
+		}
+	}
+	page.Total = len(page.Repositories)
+	return page, nil
+}
+
+func TestValidateChannelContext(t *testing.T) {
+	orgID := uuid.New()
+	github := backend.Integration{ID: uuid.New(), OrganizationID: orgID, ConnectorType: backend.ConnectorTypeGithub}
+	gcp := backend.Integration{ID: uuid.New(), OrganizationID: orgID, ConnectorType: backend.ConnectorTypeGCP, Metadata: map[string]string{"project_id": "acme-prod"}}
+	gke := backend.Integration{ID: uuid.New(), OrganizationID: orgID, ConnectorType: backend.ConnectorTypeGCP, Metadata: map[string]string{"project_id": "acme-prod", "gke_cluster_name": "prod"}}
+	repositories := []backend.SyncedRepository{
+		{FullName: "acme/Payments", Enabled: true},
+		{FullName: "acme/payments-worker", Enabled: true},
+		{FullName: "acme/legacy", Enabled: false},
+	}
+
+	tests := []struct {
+		name         string
+		integrations []backend.Integration
+		context      backend.ChannelContext
+		want         backend.ChannelContext
+		wantErr      error
+	}{
+		{
+			name:         "canonicalizes repositories",
+			integrations: []backend.Integration{github},
+			context:      backend.ChannelContext{Repositories: []string{" acme/payments ", "acme/PAYMENTS"}},
+			want:         backend.ChannelContext{Repositories: []string{"acme/Payments"}},
+		},
+		{
+			name:         "unknown repository",
+			integrations: []backend.Integration{github},
+			context:      backend.ChannelContext{Repositories: []string{"acme/billing"}},
+			wantErr:      domain.ErrInvalidChannelContext,
+		},
+		{
+			name:         "deselected repository",
+			integrations: []backend.Integration{github},
+			context:      backend.ChannelContext{Repositories: []string{"acme/legacy"}},
+			wantErr:      domain.ErrInvalidChannelContext,
+		},
+		{
+			name:    "repository without github integration",
+			context: backend.ChannelContext{Repositories: []string{"acme/payments"}},
+			wantErr: domain.ErrInvalidChannelContext,
+		},
+		{
+			name:         "connected project and namespace",
+			integrations: []backend.Integration{gke},
+			context:      backend.ChannelContext{GCPProjects: []string{"acme-prod"}, KubernetesNamespaces: []string{"payments"}},
+			want:         backend.ChannelContext{GCPProjects: []string{"acme-prod"}, KubernetesNamespaces: []string{"payments"}},
+		},
+		{
+			name:         "unknown project",
+			integrations: []backend.Integration{gcp},
+			context:      backend.ChannelContext{GCPProjects: []string{"acme-staging"}},
+			wantErr:      domain.ErrInvalidChannelContext,
+		},
+		{
+			name:         "namespace without cluster",
+			integrations: []backend.Integration{gcp},
+			context:      backend.ChannelContext{KubernetesNamespaces: []string{"payments"}},
+			wantErr:      domain.ErrInvalidChannelContext,
+		},
+		{
+			name:         "invalid namespace",
+			integrations: []backend.Integration{gke},
+			context:      backend.ChannelContext{KubernetesNamespaces: []string{"Payments_Prod"}},
+			wantErr:      domain.ErrInvalidChannelContext,
+		},
+		{
+			name: "empty bindings need no integrations",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &Service{integrations: integrationService{integrations: tt.integrations, repositories: repositories}}
+
+			got, err := svc.validateChannelContext(context.Background(), orgID, tt.context)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("validateChannelContext() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("validateChannelContext() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseChannelContextBindings(t *testing.T) {
+	cmd, err := parseChannelContextBindings([]string{"repo=acme/payments,acme/infra", "ns=payments", "project=acme-prod"})
+	if err != nil {
+		t.Fatalf("parseChannelContextBindings() error = %v", err)
+	}
+	if fmt.Sprint(cmd.Repositories, cmd.KubernetesNamespaces, cmd.GCPProjects) != "[acme/payments acme/infra] [payments] [acme-prod]" {
+		t.Errorf("parseChannelContextBindings() = %+v", cmd)
+	}
+
+	for _, args := range [][]string{{"payments"}, {"cluster=prod"}, {"repo="}} {
+		if _, err := parseChannelContextBindings(args); err == nil {
+			t.Errorf("parseChannelContextBindings(%q) error = nil, want an error", args)
+		}
+	}
+}
@@ -4,15 +4,44 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/73ai/infragpt/services/backend"
 	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/maintenance"
+	"github.com/73ai/infragpt/services/backend/internal/generic/redact"
 )
 
 type Config struct {
 	SlackGateway           domain.SlackGateway
 	IntegrationRepository  domain.IntegrationRepository
 	ConversationRepository domain.ConversationRepository
-	ChannelRepository      domain.ChannelRepository
-	AgentService           domain.AgentService
+	// HistoryRepository serves read-only conversation listings and history,
+	// such as from a read replica. ConversationRepository is used when it is nil.
+	HistoryRepository     domain.ConversationRepository
+	ChannelRepository     domain.ChannelRepository
+	FeedbackRepository    domain.FeedbackRepository
+	UserMappingRepository domain.UserMappingRepository
+	// OrganizationDataRepository deletes an offboarded organization's Slack
+	// data and reports its activity for onboarding.
+	OrganizationDataRepository domain.OrganizationDataRepository
+	AgentService               domain.AgentService
+	Models                     ModelConfig
+	Intro                      IntroConfig
+	FeatureFlags               backend.FeatureFlags
+	Maintenance                *maintenance.Mode
+	// Integrations lists the organization's integrations on the App Home tab
+	// and validates channel context bindings.
+	Integrations backend.IntegrationService
+	// Identity matches Slack users to the organization's InfraGPT users.
+	Identity backend.IdentityService
+	// Redactor strips secrets from messages sent to the agent and Slack.
+	Redactor      *redact.Redactor
+	Notifications NotificationConfig
+	// Quotas rejects messages from organizations over their usage quotas.
+	// Usage is not limited when it is nil.
+	Quotas backend.Quotas
+	// Onboarding points an organization's first mention at the next setup step
+	// when it has not connected GitHub or a cloud yet. It may be nil.
+	Onboarding backend.OnboardingService
 }
 
 func (c Config) New(ctx context.Context) (*Service, error) {
@@ -28,14 +57,44 @@ func (c Config) New(ctx context.Context) (*Service, error) {
 	if c.ChannelRepository == nil {
 		return nil, fmt.Errorf("channel repository is required")
 	}
+	if c.FeedbackRepository == nil {
+		return nil, fmt.Errorf("feedback repository is required")
+	}
+	if c.UserMappingRepository == nil {
+		return nil, fmt.Errorf("user mapping repository is required")
+	}
+	if c.OrganizationDataRepository == nil {
+		return nil, fmt.Errorf("organization data repository is required")
+	}
+	if c.Identity == nil {
+		return nil, fmt.Errorf("identity service is required")
+	}
 	if c.AgentService == nil {
 		return nil, fmt.Errorf("agent service is required")
 	}
+	historyRepository := c.HistoryRepository
+	if historyRepository == nil {
+		historyRepository = c.ConversationRepository
+	}
 	return &Service{
-		slackGateway:           c.SlackGateway,
-		integrationRepository:  c.IntegrationRepository,
-		conversationRepository: c.ConversationRepository,
-		channelRepository:      c.ChannelRepository,
-		agentService:           c.AgentService,
+		slackGateway:               c.SlackGateway,
+		integrationRepository:      c.IntegrationRepository,
+		conversationRepository:     c.ConversationRepository,
+		historyRepository:          historyRepository,
+		channelRepository:          c.ChannelRepository,
+		feedbackRepository:         c.FeedbackRepository,
+		userMappingRepository:      c.UserMappingRepository,
+		organizationDataRepository: c.OrganizationDataRepository,
+		agentService:               c.AgentService,
+		models:                     c.Models,
+		intro:                      c.Intro,
+		featureFlags:               c.FeatureFlags,
+		maintenance:                c.Maintenance,
+		integrations:               c.Integrations,
+		identity:                   c.Identity,
+		redactor:                   c.Redactor,
+		notifications:              c.Notifications,
+		quotas:                     c.Quotas,
+		onboarding:                 c.Onboarding,
 	}, nil
 }
@@ -0,0 +1,78 @@
+package conversationsvc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domaintest"
+	"github.com/google/uuid"
+)
+
+type feedbackRepository struct {
+	domain.FeedbackRepository
+}
+
+type organizationDataRepository struct {
+	domain.OrganizationDataRepository
+}
+
+func TestConfigHistoryRepository(t *testing.T) {
+	ctx := context.Background()
+	primary := domaintest.NewConversationRepository()
+	replica := domaintest.NewConversationRepository()
+
+	// The conversation has only reached the replica, so reading it succeeds
+	// only when the replica serves history.
+	conversation, err := replica.CreateConversation(ctx, "T1", "C1", "1700000000.000100")
+	if err != nil {
+		t.Fatalf("CreateConversation() error = %v", err)
+	}
+	if err := replica.StoreStep(ctx, backend.ConversationStep{ID: uuid.New(), ConversationID: conversation.ID, Tool: "kubectl", StartedAt: time.Now()}); err != nil {
+		t.Fatalf("StoreStep() error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		history     domain.ConversationRepository
+		wantReplica bool
+	}{
+		{name: "reads history from the replica", history: replica, wantReplica: true},
+		{name: "reads history from the primary without a replica", wantReplica: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, err := Config{
+				SlackGateway:               &threadGateway{},
+				IntegrationRepository:      workspaceRepository{},
+				ConversationRepository:     primary,
+				HistoryRepository:          tt.history,
+				ChannelRepository:          channelRepository{},
+				FeedbackRepository:         feedbackRepository{},
+				UserMappingRepository:      &userMappingRepository{},
+				OrganizationDataRepository: organizationDataRepository{},
+				Identity:                   &identityService{},
+				AgentService:               &agentService{},
+			}.New(ctx)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			steps, err := svc.ConversationSteps(ctx, backend.ConversationStepsQuery{ConversationID: conversation.ID})
+			if !tt.wantReplica {
+				if err == nil {
+					t.Errorf("ConversationSteps() = %v, want the conversation missing from the primary", steps)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ConversationSteps() error = %v", err)
+			}
+			if len(steps) != 1 || steps[0].Tool != "kubectl" {
+				t.Errorf("ConversationSteps() = %+v, want the step stored on the replica", steps)
+			}
+		})
+	}
+}
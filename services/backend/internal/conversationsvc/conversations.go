@@ -0,0 +1,205 @@
+package conversationsvc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/73ai/infragpt/services/backend/internal/generic/maintenance"
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
+	"github.com/google/uuid"
+)
+
+// apiSender is recorded as the author of messages started through the public
+// API rather than by a Slack user.
+var apiSender = domain.SlackUser{ID: "api", Name: "InfraGPT API", Username: "api"}
+
+// StartConversation posts the message as a new thread and runs the agent turn
+// in the background, like a message mentioning the bot in the channel.
+func (s *Service) StartConversation(ctx context.Context, cmd backend.StartConversationCommand) (backend.Conversation, error) {
+	if strings.TrimSpace(cmd.Message) == "" {
+		return backend.Conversation{}, httperrors.Validation("message is required", "message")
+	}
+	if cmd.ChannelID == "" {
+		return backend.Conversation{}, httperrors.Validation("channel_id is required", "channel_id")
+	}
+	if s.maintenance.ReadOnly() {
+		return backend.Conversation{}, maintenance.ErrReadOnly
+	}
+
+	model, err := s.models.resolve(cmd.Model)
+	if err != nil {
+		return backend.Conversation{}, httperrors.Validation(err.Error(), "model")
+	}
+	if cmd.Model != "" && (s.featureFlags == nil || !s.featureFlags.Enabled(ctx, cmd.OrganizationID, backend.FeatureFlagModelSelection)) {
+		return backend.Conversation{}, httperrors.Validation(domain.ErrModelSelectionDisabled.Error(), "model")
+	}
+
+	if s.quotas != nil {
+		err := s.quotas.CheckQuotas(ctx, cmd.OrganizationID)
+		if errors.Is(err, backend.ErrQuotaExceeded) {
+			return backend.Conversation{}, err
+		}
+		if err != nil {
+			slog.Error("Failed to check quotas, allowing request", "organization_id", cmd.OrganizationID, "error", err)
+		}
+	}
+
+	// The message is posted to Slack as the thread's root, so it is redacted
+	// before posting rather than only before it is stored.
+	messageText, redactions := s.redactor.Redact(ctx, cmd.OrganizationID, cmd.Message)
+	thread, err := s.postThread(ctx, cmd.OrganizationID, cmd.ChannelID, messageText)
+	if err != nil {
+		return backend.Conversation{}, err
+	}
+
+	conversation, err := s.conversationRepository.CreateConversation(ctx, thread.TeamID, thread.Channel, thread.ThreadTS)
+	if err != nil {
+		return backend.Conversation{}, fmt.Errorf("failed to create conversation: %w", err)
+	}
+
+	message, err := s.conversationRepository.StoreMessage(ctx, conversation.ID, domain.Message{
+		ConversationID: conversation.ID,
+		SlackMessageTS: thread.ThreadTS,
+		Sender:         apiSender,
+		MessageText:    messageText,
+		Model:          model,
+	})
+	if err != nil {
+		return backend.Conversation{}, fmt.Errorf("failed to store message: %w", err)
+	}
+	s.recordRedactions(ctx, conversation.ID, redactions)
+
+	go func() {
+		if err := s.runThreadTurn(tracing.Detach(ctx), thread, conversation, message, cmd.Model, cmd.OrganizationID, cmd.UserID); err != nil {
+			slog.Error("Failed to run agent turn for API conversation", "error", err, "conversation_id", conversation.ID)
+		}
+	}()
+
+	return newConversation(conversation), nil
+}
+
+func (s *Service) Conversation(ctx context.Context, query backend.ConversationQuery) (backend.Conversation, error) {
+	conversation, err := s.organizationConversation(ctx, query.ConversationID, query.OrganizationID)
+	if err != nil {
+		return backend.Conversation{}, err
+	}
+	return newConversation(conversation), nil
+}
+
+// organizationConversation returns the conversation, reporting it as not found
+// when organizationID is set and the conversation's workspace belongs to
+// another organization.
+func (s *Service) organizationConversation(ctx context.Context, conversationID, organizationID uuid.UUID) (domain.Conversation, error) {
+	conversation, err := s.historyRepository.Conversation(ctx, conversationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Conversation{}, httperrors.NotFound("conversation not found")
+		}
+		return domain.Conversation{}, fmt.Errorf("failed to get conversation: %w", err)
+	}
+	if organizationID == uuid.Nil {
+		return conversation, nil
+	}
+
+	owner, err := s.integrationRepository.BusinessIDByProviderProjectID(ctx, backend.ConnectorTypeSlack, conversation.TeamID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return domain.Conversation{}, fmt.Errorf("failed to get conversation organization: %w", err)
+	}
+	if owner != organizationID {
+		return domain.Conversation{}, httperrors.NotFound("conversation not found")
+	}
+	return conversation, nil
+}
+
+// postThread posts text as a new thread in the channel. The channel belongs to
+// one of the organization's workspaces; the first that accepts the post owns
+// the thread.
+func (s *Service) postThread(ctx context.Context, organizationID uuid.UUID, channelID, text string) (domain.SlackThread, error) {
+	workspaces, err := s.integrationRepository.Integrations(ctx, organizationID)
+	if err != nil {
+		return domain.SlackThread{}, fmt.Errorf("failed to find workspaces: %w", err)
+	}
+
+	linked := false
+	var errs []error
+	for _, workspace := range workspaces {
+		if workspace.ConnectorType != backend.ConnectorTypeSlack {
+			continue
+		}
+		linked = true
+		thread := domain.SlackThread{TeamID: workspace.ProviderProjectID, Channel: channelID}
+		threadTS, err := s.slackGateway.StartThread(ctx, thread, text)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		thread.ThreadTS = threadTS
+		return thread, nil
+	}
+	if !linked {
+		return domain.SlackThread{}, domain.ErrWorkspaceNotLinked
+	}
+	return domain.SlackThread{}, fmt.Errorf("failed to post to any Slack workspace: %w", errors.Join(errs...))
+}
+
+// runThreadTurn asks the agent about the first message of a thread the
+// backend started itself. requestedModel is empty when no model was asked for
+// and userID is uuid.Nil when no user asked.
+func (s *Service) runThreadTurn(ctx context.Context, thread domain.SlackThread, conversation domain.Conversation, message domain.Message, requestedModel string, organizationID, userID uuid.UUID) error {
+	channelContext, err := s.channelRepository.ChannelContext(ctx, conversation.TeamID, conversation.ChannelID)
+	if err != nil {
+		slog.Error("Failed to get channel context, continuing without it", "error", err, "channel", conversation.ChannelID)
+	}
+
+	if s.quotas != nil {
+		s.quotas.RecordUsage(ctx, organizationID, backend.QuotaMetricRequests, 1)
+	}
+
+	startedAt := time.Now()
+	turnCtx, finishTurn := s.turns.start(ctx, thread.Channel, thread.ThreadTS, thread.ThreadTS)
+	response, err := s.agentService.ProcessMessage(turnCtx, domain.AgentRequest{
+		Conversation:   conversation,
+		Message:        message,
+		ChannelContext: channelContext,
+		UserID:         userID,
+		Model:          requestedModel,
+	})
+	if cause := finishTurn(); cause != nil {
+		recordTurn(ctx, turnCancelled)
+		if err := s.slackGateway.ReplyMessage(ctx, thread, turnCancelledReply(cause)); err != nil {
+			return fmt.Errorf("failed to confirm cancellation: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		recordTurn(ctx, turnFailed)
+		return fmt.Errorf("failed to process message with agent service: %w", err)
+	}
+	s.recordTokens(ctx, organizationID, response.TokensUsed)
+	if response.Success {
+		recordTurn(ctx, turnCompleted)
+	} else {
+		recordTurn(ctx, turnFailed)
+	}
+	s.recordReportedTools(ctx, conversation.ID, response.ToolsUsed, startedAt)
+	return nil
+}
+
+func newConversation(conversation domain.Conversation) backend.Conversation {
+	return backend.Conversation{
+		ID:        conversation.ID,
+		TeamID:    conversation.TeamID,
+		ChannelID: conversation.ChannelID,
+		ThreadTS:  conversation.ThreadTS,
+		Status:    conversation.Status,
+		CreatedAt: conversation.CreatedAt,
+	}
+}
@@ -1,19 +1,83 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"errors"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrUnknownModel           = errors.New("unknown model")
+	ErrModelSelectionDisabled = errors.New("model selection is not enabled for this workspace")
+)
 
 type AgentRequest struct {
 	Conversation Conversation
 	Message      Message
 	PastMessages []Message
+	// ChannelContext holds the default context bound to the conversation's channel.
+	ChannelContext backend.ChannelContext
+	// UserID is the InfraGPT user behind the Slack sender; it is empty when
+	// the workspace is not linked to an organization.
+	UserID uuid.UUID
+	// Model is the LLM model the user asked for. It is empty when none was
+	// requested, so the agent keeps its own defaults.
+	Model string
 }
 
 type AgentResponse struct {
 	ResponseText string
 	Success      bool
 	ErrorMessage string
+	// ToolsUsed names the tools the agent used to answer.
+	ToolsUsed []string
+	// TokensUsed is the LLM tokens the agent reported using to answer.
+	TokensUsed int64
+	// Handoff is set when the agent asks for a human to take over the
+	// conversation.
+	Handoff bool
+}
+
+// ReplyKind tells SendReply how to present an agent reply.
+type ReplyKind string
+
+const (
+	ReplyKindAnswer             ReplyKind = "answer"
+	ReplyKindPlan               ReplyKind = "plan"
+	ReplyKindNeedsClarification ReplyKind = "needs_clarification"
+)
+
+// AgentReply is a reply the agent sent, read from its response envelope.
+type AgentReply struct {
+	Kind ReplyKind
+	// Text is the answer, the plan's summary or the clarifying question.
+	Text string
+	// Plan is set for plans.
+	Plan *Plan
+	// Questions lists what the agent needs to know, for clarifications.
+	Questions []string
+}
+
+// Plan describes the changes the agent proposes.
+type Plan struct {
+	Steps             []PlanStep
+	AffectedResources []string
+	// RiskLevel is low, medium or high.
+	RiskLevel     string
+	RollbackNotes string
+}
+
+type PlanStep struct {
+	Description string
+	Command     string
 }
 
 type AgentService interface {
 	ProcessMessage(ctx context.Context, request AgentRequest) (AgentResponse, error)
+	// ParseReply reads the response envelope of a reply the agent sent.
+	// Replies without one are answers; an error means the reply declared an
+	// envelope that could not be read.
+	ParseReply(reply string) (AgentReply, error)
 }
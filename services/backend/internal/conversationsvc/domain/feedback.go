@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrFeedbackMessageNotFound = errors.New("feedback message not found")
+	ErrInvalidFeedbackQuery    = errors.New("invalid feedback query")
+)
+
+type FeedbackRating string
+
+const (
+	FeedbackRatingPositive FeedbackRating = "positive"
+	FeedbackRatingNegative FeedbackRating = "negative"
+)
+
+// Feedback is a Slack user's rating of an agent reply. Each user has at most
+// one rating per reply; rating again replaces it.
+type Feedback struct {
+	ID        uuid.UUID
+	MessageID uuid.UUID
+	// ConversationID and InvocationMessageID are derived from the rated reply:
+	// the invocation is the user message the agent was answering.
+	ConversationID      uuid.UUID
+	InvocationMessageID uuid.UUID
+	TeamID              string
+	UserID              string
+	Rating              FeedbackRating
+	// Comment is kept when a later rating arrives without one.
+	Comment   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// FeedbackEvent is sent when a user presses a feedback button on an agent
+// reply or submits the feedback modal.
+type FeedbackEvent struct {
+	TeamID    string
+	UserID    string
+	MessageID uuid.UUID
+	Rating    FeedbackRating
+	Comment   string
+}
+
+type FeedbackCount struct {
+	BucketStart time.Time
+	Positive    int
+	Negative    int
+}
+
+type FeedbackRepository interface {
+	// SaveFeedback returns ErrFeedbackMessageNotFound unless MessageID is an
+	// agent reply in a conversation of TeamID.
+	SaveFeedback(ctx context.Context, feedback Feedback) (Feedback, error)
+	// FeedbackCounts counts ratings created in [since, until), grouped by
+	// bucket ("day", "week" or "month").
+	FeedbackCounts(ctx context.Context, teamIDs []string, bucket string, since, until time.Time) ([]FeedbackCount, error)
+}
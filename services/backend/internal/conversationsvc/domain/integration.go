@@ -16,4 +16,5 @@ type Integration struct {
 type IntegrationRepository interface {
 	Integrations(ctx context.Context, businessID uuid.UUID) ([]Integration, error)
 	SaveIntegration(ctx context.Context, integration Integration) error
+	BusinessIDByProviderProjectID(ctx context.Context, provider backend.ConnectorType, providerProjectID string) (uuid.UUID, error)
 }
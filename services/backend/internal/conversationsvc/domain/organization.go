@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationData counts the records stored for an organization's Slack
+// workspaces. Messages and feedback are deleted with their conversations.
+type OrganizationData struct {
+	Workspaces        int
+	Conversations     int
+	Channels          int
+	ChannelContexts   int
+	ChannelIntros     int
+	SlackTokens       int
+	SlackUserMappings int
+}
+
+// OrganizationActivity counts an organization's linked Slack workspaces and
+// the conversations held in them.
+type OrganizationActivity struct {
+	Workspaces    int
+	Conversations int
+}
+
+type OrganizationDataRepository interface {
+	// DeleteOrganizationData deletes everything stored for the organization's
+	// Slack workspaces in one transaction. With dryRun the transaction is rolled
+	// back, so the counts report what would be deleted.
+	DeleteOrganizationData(ctx context.Context, organizationID uuid.UUID, dryRun bool) (OrganizationData, error)
+	OrganizationActivity(ctx context.Context, organizationID uuid.UUID) (OrganizationActivity, error)
+}
@@ -2,11 +2,22 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/73ai/infragpt/services/backend"
 	"github.com/google/uuid"
 )
 
+// ErrDuplicateMessage is returned by StoreMessage when the Slack event or client
+// message has already been stored, e.g. because Slack retried the delivery.
+var ErrDuplicateMessage = errors.New("duplicate message")
+
+var (
+	ErrInvalidChannelContext = errors.New("invalid channel context")
+	ErrWorkspaceNotLinked    = errors.New("slack workspace is not linked to the organization")
+)
+
 type RequestApprovalCommand struct {
 }
 
@@ -18,6 +29,7 @@ type Conversation struct {
 	TeamID    string
 	ChannelID string
 	ThreadTS  string
+	Status    backend.ConversationStatus
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -29,7 +41,12 @@ type Message struct {
 	Sender         SlackUser
 	MessageText    string
 	IsBotMessage   bool
-	CreatedAt      time.Time
+	// Model is the LLM model recorded for this message: the requested one, or
+	// the configured default when none was requested.
+	Model        string
+	SlackEventID string
+	ClientMsgID  string
+	CreatedAt    time.Time
 }
 
 type Channel struct {
@@ -40,6 +57,16 @@ type Channel struct {
 	CreatedAt   time.Time
 }
 
+// SlashCommand is an invocation of the app's slash command, e.g.
+// "/infragpt context set repo=acme/payments".
+type SlashCommand struct {
+	TeamID    string
+	ChannelID string
+	UserID    string
+	Command   string
+	Text      string
+}
+
 type SlackUser struct {
 	ID       string
 	Email    string
@@ -61,6 +88,7 @@ const (
 	MessageTypeAppMention MessageType = "app_mention"
 	MessageTypeChannel    MessageType = "channel_message"
 	MessageTypeThread     MessageType = "thread_message"
+	MessageTypeShortcut   MessageType = "message_shortcut"
 )
 
 type UserCommand struct {
@@ -68,6 +96,56 @@ type UserCommand struct {
 	MessageTS   string
 	InReply     bool
 	MessageType MessageType
+	// EventID and ClientMsgID identify Slack retries of the same delivery.
+	EventID     string
+	ClientMsgID string
+}
+
+// MessageDeleted is sent when a user deletes a message in a thread the bot
+// can see. ThreadTS is the message's own timestamp for top-level messages.
+type MessageDeleted struct {
+	TeamID    string
+	Channel   string
+	ThreadTS  string
+	MessageTS string
+}
+
+// HomeOpened is sent when a user opens the bot's App Home tab.
+type HomeOpened struct {
+	TeamID string
+	UserID string
+}
+
+// HomeView is what a user sees on the bot's App Home tab.
+type HomeView struct {
+	TeamID string
+	UserID string
+	// Linked is false when the workspace is not connected to an organization yet.
+	Linked        bool
+	Integrations  []backend.Integration
+	Conversations []Conversation
+}
+
+// BotJoinedChannel is sent when the bot itself is added to a channel.
+type BotJoinedChannel struct {
+	TeamID    string
+	ChannelID string
+	BotUserID string
+}
+
+// ChannelIntro is the message the bot posts when it joins a channel.
+type ChannelIntro struct {
+	TeamID    string
+	ChannelID string
+	BotUserID string
+	// Message opens the intro; "{bot}" stands for a mention of the bot.
+	Message string
+	// Linked is false when the workspace is not connected to an organization yet.
+	Linked       bool
+	Integrations []backend.Integration
+	// Models lists the models users can pick with --model; empty when model
+	// selection is off for the workspace.
+	Models []string
 }
 
 type SlackIntegration struct {
@@ -81,12 +159,57 @@ type SlackGateway interface {
 
 	SubscribeAllMessages(context.Context, func(ctx context.Context, command UserCommand) error) error
 
+	// OnHomeOpened registers the handler for App Home opens; call it before subscribing.
+	OnHomeOpened(func(ctx context.Context, event HomeOpened) error)
+
+	// OnSlashCommand registers the slash command handler; the returned text is
+	// shown only to the invoking user. Call it before subscribing.
+	OnSlashCommand(func(ctx context.Context, command SlashCommand) (string, error))
+
 	ReplyMessage(ctx context.Context, t SlackThread, message string) error
+
+	// StartThread posts message to the thread's channel as a new top-level
+	// message and returns its timestamp, which identifies the thread.
+	StartThread(ctx context.Context, t SlackThread, message string) (threadTS string, err error)
+
+	// ReplyWithFeedback posts an agent reply with buttons to rate it.
+	// messageID identifies the stored reply the feedback is linked to.
+	ReplyWithFeedback(ctx context.Context, t SlackThread, message string, messageID uuid.UUID) error
+
+	// OnFeedback registers the handler for feedback buttons and the feedback
+	// modal; call it before subscribing.
+	OnFeedback(func(ctx context.Context, event FeedbackEvent) error)
+
+	PublishHome(ctx context.Context, view HomeView) error
+
+	// OnBotJoinedChannel registers the handler for the bot being added to a
+	// channel; call it before subscribing.
+	OnBotJoinedChannel(func(ctx context.Context, event BotJoinedChannel) error)
+
+	PostChannelIntro(ctx context.Context, intro ChannelIntro) error
+
+	// OnMessageDeleted registers the handler for deleted messages; call it
+	// before subscribing.
+	OnMessageDeleted(func(ctx context.Context, event MessageDeleted) error)
+
+	// ReplyUnmappedUser tells a Slack user whose account could not be matched
+	// to an InfraGPT user how to connect it.
+	ReplyUnmappedUser(ctx context.Context, t SlackThread) error
+
+	// ReplyReadOnlyIntegration tells the thread that a command was refused
+	// because the integration it needed is read-only, and where an admin can
+	// grant write access.
+	ReplyReadOnlyIntegration(ctx context.Context, t SlackThread) error
 }
 
 type WorkSpaceTokenRepository interface {
 	SaveToken(ctx context.Context, teamID, token string) error
+	// GetToken returns the workspace's newest bot token, falling back to its
+	// Enterprise Grid organization's token for org-wide installs.
 	GetToken(ctx context.Context, teamID string) (string, error)
+	// LinkWorkspace records that teamID belongs to the Enterprise Grid
+	// organization enterpriseID.
+	LinkWorkspace(ctx context.Context, teamID, enterpriseID string) error
 }
 
 type ConversationRepository interface {
@@ -95,7 +218,21 @@ type ConversationRepository interface {
 	CreateConversation(ctx context.Context, teamID, channelID, threadTS string) (Conversation, error)
 	StoreMessage(ctx context.Context, conversationID uuid.UUID, message Message) (Message, error)
 	MessageBySlackTS(ctx context.Context, conversationID uuid.UUID, senderID, slackMessageTS string) (Message, error)
+	// RecentConversations returns the conversations userID last posted in, most recent first.
+	RecentConversations(ctx context.Context, teamID, userID string, limit int) ([]Conversation, error)
 	GetConversationHistory(ctx context.Context, conversationID uuid.UUID) ([]Message, error)
+	// AddRedactions adds to the number of secrets redacted from the conversation.
+	AddRedactions(ctx context.Context, conversationID uuid.UUID, count int) error
+	// StoreStep records a tool the agent used; storing a step twice is a no-op.
+	StoreStep(ctx context.Context, step backend.ConversationStep) error
+	// Steps returns the conversation's steps started at or after since, oldest first.
+	Steps(ctx context.Context, conversationID uuid.UUID, since time.Time) ([]backend.ConversationStep, error)
+	// SetStatus moves the conversation to change.Status and records the
+	// change. It reports false, recording nothing, when the conversation
+	// already has that status.
+	SetStatus(ctx context.Context, change backend.ConversationStatusChange) (bool, error)
+	// StatusChanges returns the conversation's status changes, oldest first.
+	StatusChanges(ctx context.Context, conversationID uuid.UUID) ([]backend.ConversationStatusChange, error)
 }
 
 type ChannelRepository interface {
@@ -103,4 +240,12 @@ type ChannelRepository interface {
 	SetChannelMonitoring(ctx context.Context, teamID, channelID string, isMonitored bool) error
 	GetMonitoredChannels(ctx context.Context, teamID string) ([]Channel, error)
 	IsChannelMonitored(ctx context.Context, teamID, channelID string) (bool, error)
+	// SetChannelContext replaces the channel's default context; empty bindings remove it.
+	SetChannelContext(ctx context.Context, channelContext backend.ChannelContext) error
+	// ChannelContext returns the channel's default context, which is empty when none is set.
+	ChannelContext(ctx context.Context, teamID, channelID string) (backend.ChannelContext, error)
+	ChannelContexts(ctx context.Context, teamID string) ([]backend.ChannelContext, error)
+	// ClaimChannelIntro records an intro in the channel unless one was posted
+	// after postedBefore, and reports whether the caller should post it.
+	ClaimChannelIntro(ctx context.Context, teamID, channelID string, postedBefore time.Time) (bool, error)
 }
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSlackUserMappingNotFound = errors.New("slack user mapping not found")
+	ErrInvalidSlackUserMapping  = errors.New("invalid slack user mapping")
+	ErrSlackUserNotMapped       = errors.New("slack user is not mapped to an infragpt user")
+)
+
+type UserMappingRepository interface {
+	// SlackUserMapping returns sql.ErrNoRows when the Slack user is not mapped.
+	SlackUserMapping(ctx context.Context, teamID, slackUserID string) (backend.SlackUserMapping, error)
+	SlackUserMappings(ctx context.Context, organizationID uuid.UUID) ([]backend.SlackUserMapping, error)
+	// SaveSlackUserMapping replaces any existing mapping of the Slack user.
+	SaveSlackUserMapping(ctx context.Context, mapping backend.SlackUserMapping) (backend.SlackUserMapping, error)
+	DeleteSlackUserMapping(ctx context.Context, organizationID uuid.UUID, teamID, slackUserID string) error
+}
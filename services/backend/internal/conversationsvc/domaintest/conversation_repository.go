@@ -0,0 +1,226 @@
+// Package domaintest provides in-memory implementations of the conversation
+// service repositories.
+package domaintest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/google/uuid"
+)
+
+type conversationRepository struct {
+	mu            sync.RWMutex
+	conversations map[uuid.UUID]domain.Conversation
+	messages      map[uuid.UUID][]domain.Message
+	redactions    map[uuid.UUID]int
+	steps         map[uuid.UUID]backend.ConversationStep
+	statusChanges map[uuid.UUID][]backend.ConversationStatusChange
+}
+
+// NewConversationRepository returns a domain.ConversationRepository that
+// reports missing conversations and messages as sql.ErrNoRows, like the
+// Postgres implementation.
+func NewConversationRepository() domain.ConversationRepository {
+	return &conversationRepository{
+		conversations: make(map[uuid.UUID]domain.Conversation),
+		messages:      make(map[uuid.UUID][]domain.Message),
+		redactions:    make(map[uuid.UUID]int),
+		steps:         make(map[uuid.UUID]backend.ConversationStep),
+		statusChanges: make(map[uuid.UUID][]backend.ConversationStatusChange),
+	}
+}
+
+func (r *conversationRepository) GetConversationByThread(ctx context.Context, teamID, channelID, threadTS string) (domain.Conversation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, conversation := range r.conversations {
+		if conversation.TeamID == teamID && conversation.ChannelID == channelID && conversation.ThreadTS == threadTS {
+			return conversation, nil
+		}
+	}
+	return domain.Conversation{}, fmt.Errorf("failed to get conversation: %w", sql.ErrNoRows)
+}
+
+func (r *conversationRepository) Conversation(ctx context.Context, conversationID uuid.UUID) (domain.Conversation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	conversation, ok := r.conversations[conversationID]
+	if !ok {
+		return domain.Conversation{}, fmt.Errorf("failed to get conversation: %w", sql.ErrNoRows)
+	}
+	return conversation, nil
+}
+
+func (r *conversationRepository) CreateConversation(ctx context.Context, teamID, channelID, threadTS string) (domain.Conversation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, conversation := range r.conversations {
+		if conversation.TeamID == teamID && conversation.ChannelID == channelID && conversation.ThreadTS == threadTS {
+			return domain.Conversation{}, fmt.Errorf("conversation for thread %s in %s/%s already exists", threadTS, teamID, channelID)
+		}
+	}
+
+	now := time.Now()
+	conversation := domain.Conversation{
+		ID:        uuid.New(),
+		TeamID:    teamID,
+		ChannelID: channelID,
+		ThreadTS:  threadTS,
+		Status:    backend.ConversationStatusActive,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	r.conversations[conversation.ID] = conversation
+	return conversation, nil
+}
+
+func (r *conversationRepository) StoreMessage(ctx context.Context, conversationID uuid.UUID, message domain.Message) (domain.Message, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.conversations[conversationID]; !ok {
+		return domain.Message{}, fmt.Errorf("conversation %s not found", conversationID)
+	}
+
+	for _, messages := range r.messages {
+		for _, existing := range messages {
+			if message.SlackEventID != "" && existing.SlackEventID == message.SlackEventID {
+				return domain.Message{}, domain.ErrDuplicateMessage
+			}
+		}
+	}
+	for _, existing := range r.messages[conversationID] {
+		if existing.SlackMessageTS == message.SlackMessageTS ||
+			(message.ClientMsgID != "" && existing.ClientMsgID == message.ClientMsgID) {
+			return domain.Message{}, domain.ErrDuplicateMessage
+		}
+	}
+
+	message.ID = uuid.New()
+	message.ConversationID = conversationID
+	message.CreatedAt = time.Now()
+	r.messages[conversationID] = append(r.messages[conversationID], message)
+	return message, nil
+}
+
+func (r *conversationRepository) MessageBySlackTS(ctx context.Context, conversationID uuid.UUID, senderID, slackMessageTS string) (domain.Message, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, message := range r.messages[conversationID] {
+		if message.Sender.ID == senderID && message.SlackMessageTS == slackMessageTS {
+			return message, nil
+		}
+	}
+	return domain.Message{}, fmt.Errorf("failed to get message: %w", sql.ErrNoRows)
+}
+
+func (r *conversationRepository) RecentConversations(ctx context.Context, teamID, userID string, limit int) ([]domain.Conversation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type participation struct {
+		conversation domain.Conversation
+		lastPosted   time.Time
+	}
+	var participations []participation
+	for id, messages := range r.messages {
+		conversation := r.conversations[id]
+		if conversation.TeamID != teamID {
+			continue
+		}
+		var lastPosted time.Time
+		for _, message := range messages {
+			if message.Sender.ID == userID && message.CreatedAt.After(lastPosted) {
+				lastPosted = message.CreatedAt
+			}
+		}
+		if !lastPosted.IsZero() {
+			participations = append(participations, participation{conversation, lastPosted})
+		}
+	}
+	slices.SortFunc(participations, func(a, b participation) int {
+		return b.lastPosted.Compare(a.lastPosted)
+	})
+
+	conversations := make([]domain.Conversation, 0, min(limit, len(participations)))
+	for _, p := range participations[:min(limit, len(participations))] {
+		conversations = append(conversations, p.conversation)
+	}
+	return conversations, nil
+}
+
+func (r *conversationRepository) GetConversationHistory(ctx context.Context, conversationID uuid.UUID) ([]domain.Message, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.messages[conversationID]), nil
+}
+
+func (r *conversationRepository) AddRedactions(ctx context.Context, conversationID uuid.UUID, count int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.redactions[conversationID] += count
+	return nil
+}
+
+func (r *conversationRepository) StoreStep(ctx context.Context, step backend.ConversationStep) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.steps[step.ID]; !exists {
+		r.steps[step.ID] = step
+	}
+	return nil
+}
+
+func (r *conversationRepository) Steps(ctx context.Context, conversationID uuid.UUID, since time.Time) ([]backend.ConversationStep, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var steps []backend.ConversationStep
+	for _, step := range r.steps {
+		if step.ConversationID == conversationID && !step.StartedAt.Before(since) {
+			steps = append(steps, step)
+		}
+	}
+	slices.SortFunc(steps, func(a, b backend.ConversationStep) int {
+		return a.StartedAt.Compare(b.StartedAt)
+	})
+	return steps, nil
+}
+
+func (r *conversationRepository) SetStatus(ctx context.Context, change backend.ConversationStatusChange) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conversation, ok := r.conversations[change.ConversationID]
+	if !ok || conversation.Status == change.Status {
+		return false, nil
+	}
+	conversation.Status = change.Status
+	conversation.UpdatedAt = time.Now()
+	r.conversations[conversation.ID] = conversation
+
+	change.ChangedAt = conversation.UpdatedAt
+	r.statusChanges[conversation.ID] = append(r.statusChanges[conversation.ID], change)
+	return true, nil
+}
+
+func (r *conversationRepository) StatusChanges(ctx context.Context, conversationID uuid.UUID) ([]backend.ConversationStatusChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.statusChanges[conversationID]), nil
+}
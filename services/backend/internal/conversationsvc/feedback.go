@@ -0,0 +1,92 @@
+package conversationsvc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/maintenance"
+)
+
+const defaultFeedbackWindow = 30 * 24 * time.Hour
+
+func (s *Service) handleFeedback(ctx context.Context, event domain.FeedbackEvent) error {
+	if s.maintenance.ReadOnly() {
+		return maintenance.ErrReadOnly
+	}
+	if event.Rating != domain.FeedbackRatingPositive && event.Rating != domain.FeedbackRatingNegative {
+		return fmt.Errorf("unknown feedback rating %q", event.Rating)
+	}
+
+	_, err := s.feedbackRepository.SaveFeedback(ctx, domain.Feedback{
+		MessageID: event.MessageID,
+		TeamID:    event.TeamID,
+		UserID:    event.UserID,
+		Rating:    event.Rating,
+		Comment:   event.Comment,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save feedback: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) FeedbackSummary(ctx context.Context, query backend.FeedbackSummaryQuery) (backend.FeedbackSummary, error) {
+	bucket := query.Bucket
+	if bucket == "" {
+		bucket = backend.FeedbackBucketDay
+	}
+	if bucket != backend.FeedbackBucketDay && bucket != backend.FeedbackBucketWeek && bucket != backend.FeedbackBucketMonth {
+		return backend.FeedbackSummary{}, fmt.Errorf("%w: bucket must be day, week or month", domain.ErrInvalidFeedbackQuery)
+	}
+
+	until := query.Until
+	if until.IsZero() {
+		until = time.Now()
+	}
+	since := query.Since
+	if since.IsZero() {
+		since = until.Add(-defaultFeedbackWindow)
+	}
+	if !since.Before(until) {
+		return backend.FeedbackSummary{}, fmt.Errorf("%w: since must be before until", domain.ErrInvalidFeedbackQuery)
+	}
+
+	workspaces, err := s.organizationWorkspaces(ctx, query.OrganizationID)
+	if err != nil {
+		return backend.FeedbackSummary{}, err
+	}
+
+	summary := backend.FeedbackSummary{Buckets: []backend.FeedbackSummaryBucket{}}
+	if len(workspaces) == 0 {
+		return summary, nil
+	}
+
+	counts, err := s.feedbackRepository.FeedbackCounts(ctx, workspaces, string(bucket), since, until)
+	if err != nil {
+		return backend.FeedbackSummary{}, fmt.Errorf("failed to count feedback: %w", err)
+	}
+
+	for _, count := range counts {
+		summary.Positive += count.Positive
+		summary.Negative += count.Negative
+		summary.Buckets = append(summary.Buckets, backend.FeedbackSummaryBucket{
+			Start:            count.BucketStart,
+			Positive:         count.Positive,
+			Negative:         count.Negative,
+			SatisfactionRate: satisfactionRate(count.Positive, count.Negative),
+		})
+	}
+	summary.SatisfactionRate = satisfactionRate(summary.Positive, summary.Negative)
+
+	return summary, nil
+}
+
+func satisfactionRate(positive, negative int) float64 {
+	if positive+negative == 0 {
+		return 0
+	}
+	return float64(positive) / float64(positive+negative)
+}
@@ -0,0 +1,248 @@
+package conversationsvc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+)
+
+var errHandedOff = errors.New("handed off to a human")
+
+// handoffByAgent is recorded as the author of handoffs the agent asked for.
+const handoffByAgent = "agent"
+
+const (
+	maxHandoffProblemLength = 500
+	maxHandoffLinks         = 5
+)
+
+const (
+	nothingToEscalateReply = "There's no conversation with me in this thread to escalate."
+	alreadyEscalatedReply  = "This thread is already with a human. Say \"resume bot\" when you want me back."
+	notEscalatedReply      = "I'm already answering in this thread."
+	resumedReply           = "I'm back, and I'll take what was said while I was away into account."
+)
+
+var linkPattern = regexp.MustCompile(`https?://[^\s<>|]+`)
+
+func isEscalateCommand(text string) bool {
+	return strings.ToLower(strings.Trim(text, " \t\n.!")) == "escalate"
+}
+
+func isResumeCommand(text string) bool {
+	return strings.ToLower(strings.Trim(text, " \t\n.!")) == "resume bot"
+}
+
+func (s *Service) ConversationStatus(ctx context.Context, query backend.ConversationStatusQuery) (backend.ConversationStatusHistory, error) {
+	conversation, err := s.conversationRepository.Conversation(ctx, query.ConversationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return backend.ConversationStatusHistory{}, httperrors.NotFound("conversation not found")
+		}
+		return backend.ConversationStatusHistory{}, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	changes, err := s.conversationRepository.StatusChanges(ctx, query.ConversationID)
+	if err != nil {
+		return backend.ConversationStatusHistory{}, fmt.Errorf("failed to list conversation status changes: %w", err)
+	}
+	return backend.ConversationStatusHistory{Status: conversation.Status, Changes: changes}, nil
+}
+
+func (s *Service) escalateThread(ctx context.Context, thread domain.SlackThread) error {
+	conversation, err := s.conversationRepository.GetConversationByThread(ctx, thread.TeamID, thread.Channel, thread.ThreadTS)
+	if errors.Is(err, sql.ErrNoRows) {
+		if err := s.slackGateway.ReplyMessage(ctx, thread, nothingToEscalateReply); err != nil {
+			return fmt.Errorf("failed to reply to escalation request: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	escalated, err := s.escalate(ctx, conversation, thread.Sender.ID, "requested in the thread")
+	if err != nil {
+		return err
+	}
+	if !escalated {
+		if err := s.slackGateway.ReplyMessage(ctx, thread, alreadyEscalatedReply); err != nil {
+			return fmt.Errorf("failed to reply to escalation request: %w", err)
+		}
+	}
+	return nil
+}
+
+// escalate hands the conversation off to a human: the agent stops answering
+// in it, and a summary of the conversation is posted for the organization's
+// on-call group. It reports false when the conversation was already escalated.
+func (s *Service) escalate(ctx context.Context, conversation domain.Conversation, changedBy, reason string) (bool, error) {
+	changed, err := s.conversationRepository.SetStatus(ctx, backend.ConversationStatusChange{
+		ConversationID: conversation.ID,
+		Status:         backend.ConversationStatusEscalated,
+		ChangedBy:      changedBy,
+		Reason:         reason,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to escalate conversation: %w", err)
+	}
+	if !changed {
+		return false, nil
+	}
+	slog.Info("Handing conversation off to a human", "conversation_id", conversation.ID, "changed_by", changedBy)
+	s.turns.cancel(conversation.ChannelID, conversation.ThreadTS, "", errHandedOff)
+
+	history, err := s.conversationRepository.GetConversationHistory(ctx, conversation.ID)
+	if err != nil {
+		return true, fmt.Errorf("failed to get conversation history: %w", err)
+	}
+	steps, err := s.conversationRepository.Steps(ctx, conversation.ID, time.Time{})
+	if err != nil {
+		return true, fmt.Errorf("failed to list conversation steps: %w", err)
+	}
+
+	thread := domain.SlackThread{
+		TeamID:   conversation.TeamID,
+		Channel:  conversation.ChannelID,
+		ThreadTS: conversation.ThreadTS,
+	}
+	summary := handoffSummary(history, steps, s.onCallGroup(ctx, conversation.TeamID))
+	if err := s.slackGateway.ReplyMessage(ctx, thread, summary); err != nil {
+		return true, fmt.Errorf("failed to post handoff summary: %w", err)
+	}
+	return true, nil
+}
+
+func (s *Service) resumeThread(ctx context.Context, thread domain.SlackThread) error {
+	conversation, err := s.conversationRepository.GetConversationByThread(ctx, thread.TeamID, thread.Channel, thread.ThreadTS)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	reply := notEscalatedReply
+	if err == nil {
+		resumed, err := s.conversationRepository.SetStatus(ctx, backend.ConversationStatusChange{
+			ConversationID: conversation.ID,
+			Status:         backend.ConversationStatusActive,
+			ChangedBy:      thread.Sender.ID,
+			Reason:         "resumed in the thread",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to resume conversation: %w", err)
+		}
+		if resumed {
+			slog.Info("Agent resumed in conversation", "conversation_id", conversation.ID, "changed_by", thread.Sender.ID)
+			reply = resumedReply
+		}
+	}
+
+	if err := s.slackGateway.ReplyMessage(ctx, thread, reply); err != nil {
+		return fmt.Errorf("failed to reply to resume request: %w", err)
+	}
+	return nil
+}
+
+// holdEscalatedMessage stores a message posted in an escalated thread without
+// sending it to the agent, which sees it in the conversation's history once
+// the thread is resumed. It reports whether the thread was escalated.
+func (s *Service) holdEscalatedMessage(ctx context.Context, command domain.UserCommand) (bool, error) {
+	conversation, err := s.conversationRepository.GetConversationByThread(ctx, command.Thread.TeamID, command.Thread.Channel, command.Thread.ThreadTS)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get conversation: %w", err)
+	}
+	if conversation.Status != backend.ConversationStatusEscalated {
+		return false, nil
+	}
+
+	text, redactions := s.redactor.Redact(ctx, s.teamOrganization(ctx, conversation.TeamID), command.Thread.Message)
+	_, err = s.conversationRepository.StoreMessage(ctx, conversation.ID, domain.Message{
+		ConversationID: conversation.ID,
+		SlackMessageTS: fmt.Sprintf("%d", time.Now().UnixNano()),
+		Sender:         command.Thread.Sender,
+		MessageText:    text,
+		SlackEventID:   command.EventID,
+		ClientMsgID:    command.ClientMsgID,
+	})
+	if errors.Is(err, domain.ErrDuplicateMessage) {
+		return true, nil
+	}
+	if err != nil {
+		return true, fmt.Errorf("failed to store message: %w", err)
+	}
+	s.recordRedactions(ctx, conversation.ID, redactions)
+	return true, nil
+}
+
+// onCallGroup returns the Slack user group paged when a conversation in the
+// workspace is handed off, or "" when its organization has none.
+func (s *Service) onCallGroup(ctx context.Context, teamID string) string {
+	organizationID, err := s.integrationRepository.BusinessIDByProviderProjectID(ctx, backend.ConnectorTypeSlack, teamID)
+	if err != nil {
+		slog.Warn("No organization to page for handoff", "team_id", teamID, "error", err)
+		return ""
+	}
+	group := s.notifications.OnCallGroups[organizationID.String()]
+	if group == "" {
+		slog.Warn("No on-call group for handoff", "organizationID", organizationID)
+	}
+	return group
+}
+
+// handoffSummary tells whoever picks up the thread what the user asked, which
+// tools the agent already used and the links shared so far, and pages group
+// when it is set.
+func handoffSummary(history []domain.Message, steps []backend.ConversationStep, group string) string {
+	problem := "_unknown_"
+	for _, message := range history {
+		if !message.IsBotMessage {
+			problem = strings.Join(strings.Fields(message.MessageText), " ")
+			break
+		}
+	}
+	if runes := []rune(problem); len(runes) > maxHandoffProblemLength {
+		problem = string(runes[:maxHandoffProblemLength]) + "…"
+	}
+
+	tried := summarizeTools(steps)
+	if tried == "" {
+		tried = "_nothing yet_"
+	}
+
+	var links []string
+	seen := make(map[string]bool)
+	for _, message := range history {
+		for _, link := range linkPattern.FindAllString(message.MessageText, -1) {
+			link = strings.TrimRight(link, ".,;:)")
+			if seen[link] || len(links) == maxHandoffLinks {
+				continue
+			}
+			seen[link] = true
+			links = append(links, link)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("Handing this thread over to a human.\n")
+	fmt.Fprintf(&b, "*Problem:* %s\n", problem)
+	fmt.Fprintf(&b, "*Tried:* %s\n", tried)
+	if len(links) > 0 {
+		fmt.Fprintf(&b, "*Links:* %s\n", strings.Join(links, ", "))
+	}
+	if group != "" {
+		fmt.Fprintf(&b, "<!subteam^%s> ", group)
+	}
+	b.WriteString("Can someone take a look? I'll stay quiet here until someone says \"resume bot\".")
+	return b.String()
+}
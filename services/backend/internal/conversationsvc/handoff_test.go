@@ -0,0 +1,44 @@
+package conversationsvc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+)
+
+func TestHandoffSummary(t *testing.T) {
+	history := []domain.Message{
+		{MessageText: "why is\npayments down? see https://grafana.example.com/d/payments."},
+		{MessageText: "The pod is OOMKilled, logs at <https://logs.example.com/q/1|logs>", IsBotMessage: true},
+		{MessageText: "same dashboard: https://grafana.example.com/d/payments"},
+	}
+	steps := []backend.ConversationStep{{Tool: "kubectl"}, {Tool: "kubectl"}}
+
+	got := handoffSummary(history, steps, "S123")
+	for _, want := range []string{
+		"*Problem:* why is payments down? see https://grafana.example.com/d/payments.\n",
+		"*Tried:* kubectl ×2\n",
+		"*Links:* https://grafana.example.com/d/payments, https://logs.example.com/q/1\n",
+		"<!subteam^S123> ",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("handoffSummary() = %q, want it to contain %q", got, want)
+		}
+	}
+
+	empty := handoffSummary(nil, nil, "")
+	if !strings.Contains(empty, "*Tried:* _nothing yet_") || strings.Contains(empty, "*Links:*") || strings.Contains(empty, "subteam") {
+		t.Errorf("handoffSummary() without history = %q", empty)
+	}
+}
+
+func TestHandoffCommands(t *testing.T) {
+	if !isEscalateCommand(" Escalate! ") || isEscalateCommand("please escalate this") {
+		t.Error("isEscalateCommand() should only match a bare \"escalate\"")
+	}
+	if !isResumeCommand("resume bot.") || isResumeCommand("resume") {
+		t.Error("isResumeCommand() should only match \"resume bot\"")
+	}
+}
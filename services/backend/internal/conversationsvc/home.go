@@ -0,0 +1,113 @@
+package conversationsvc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
+)
+
+const homeRecentConversations = 5
+
+// homeViewers remembers who opened the App Home tab since startup so their view
+// can be republished when an integration changes. Everyone else gets a fresh
+// view the next time they open the tab.
+type homeViewers struct {
+	mu    sync.Mutex
+	users map[string]map[string]struct{}
+}
+
+func (h *homeViewers) add(teamID, userID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.users == nil {
+		h.users = make(map[string]map[string]struct{})
+	}
+	if h.users[teamID] == nil {
+		h.users[teamID] = make(map[string]struct{})
+	}
+	h.users[teamID][userID] = struct{}{}
+}
+
+func (h *homeViewers) list(teamID string) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	users := make([]string, 0, len(h.users[teamID]))
+	for userID := range h.users[teamID] {
+		users = append(users, userID)
+	}
+	return users
+}
+
+func (s *Service) handleHomeOpened(ctx context.Context, event domain.HomeOpened) error {
+	s.homeViewers.add(event.TeamID, event.UserID)
+	return s.publishHome(ctx, event.TeamID, event.UserID)
+}
+
+func (s *Service) publishHome(ctx context.Context, teamID, userID string) error {
+	view := domain.HomeView{TeamID: teamID, UserID: userID}
+
+	organizationID, err := s.integrationRepository.BusinessIDByProviderProjectID(ctx, backend.ConnectorTypeSlack, teamID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return fmt.Errorf("failed to find organization for team: %w", err)
+	default:
+		view.Linked = true
+		if s.integrations != nil {
+			view.Integrations, err = s.integrations.Integrations(ctx, backend.IntegrationsQuery{OrganizationID: organizationID})
+			if err != nil {
+				return fmt.Errorf("failed to list integrations: %w", err)
+			}
+		}
+	}
+
+	view.Conversations, err = s.historyRepository.RecentConversations(ctx, teamID, userID, homeRecentConversations)
+	if err != nil {
+		return fmt.Errorf("failed to get recent conversations: %w", err)
+	}
+
+	if err := s.slackGateway.PublishHome(ctx, view); err != nil {
+		return fmt.Errorf("failed to publish home: %w", err)
+	}
+	return nil
+}
+
+// IntegrationStatusChanged republishes the App Home of every user in the
+// organization's Slack workspaces who has opened it, and asks the organization
+// to reconnect integrations that need reauthorization.
+func (s *Service) IntegrationStatusChanged(ctx context.Context, integration backend.Integration) {
+	ctx = tracing.Detach(ctx)
+	go func() {
+		if integration.Status == backend.IntegrationStatusNeedsReauthorization {
+			s.notifyReauthorization(ctx, integration)
+		}
+
+		workspaces, err := s.integrationRepository.Integrations(ctx, integration.OrganizationID)
+		if err != nil {
+			slog.Error("Failed to find workspaces for home refresh", "error", err, "organizationID", integration.OrganizationID)
+			return
+		}
+
+		for _, workspace := range workspaces {
+			if workspace.ConnectorType != backend.ConnectorTypeSlack {
+				continue
+			}
+			for _, userID := range s.homeViewers.list(workspace.ProviderProjectID) {
+				if err := s.publishHome(ctx, workspace.ProviderProjectID, userID); err != nil {
+					slog.Error("Failed to refresh home", "error", err, "teamID", workspace.ProviderProjectID, "userID", userID)
+				}
+			}
+		}
+	}()
+}
+
+var _ backend.IntegrationStatusListener = (*Service)(nil)
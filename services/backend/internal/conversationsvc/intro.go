@@ -0,0 +1,86 @@
+package conversationsvc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+)
+
+const (
+	defaultIntroMessage  = "Hi, I'm InfraGPT! Mention {bot} with a question about your infrastructure and I'll dig into it in a thread."
+	defaultIntroCooldown = 24 * time.Hour
+)
+
+type IntroConfig struct {
+	Disabled bool `mapstructure:"disabled"`
+	// Message opens the intro; "{bot}" is replaced with a mention of the bot.
+	Message string `mapstructure:"message"`
+	// CooldownHours suppresses a repeat intro in a channel the bot rejoins
+	// within that many hours.
+	CooldownHours int `mapstructure:"cooldown_hours"`
+}
+
+func (c IntroConfig) message() string {
+	if c.Message == "" {
+		return defaultIntroMessage
+	}
+	return c.Message
+}
+
+func (c IntroConfig) cooldown() time.Duration {
+	if c.CooldownHours <= 0 {
+		return defaultIntroCooldown
+	}
+	return time.Duration(c.CooldownHours) * time.Hour
+}
+
+func (s *Service) handleBotJoinedChannel(ctx context.Context, event domain.BotJoinedChannel) error {
+	if s.intro.Disabled || s.maintenance.ReadOnly() {
+		return nil
+	}
+
+	claimed, err := s.channelRepository.ClaimChannelIntro(ctx, event.TeamID, event.ChannelID, time.Now().Add(-s.intro.cooldown()))
+	if err != nil {
+		return fmt.Errorf("failed to claim channel intro: %w", err)
+	}
+	if !claimed {
+		slog.Info("Skipping channel intro posted recently", "teamID", event.TeamID, "channelID", event.ChannelID)
+		return nil
+	}
+
+	intro := domain.ChannelIntro{
+		TeamID:    event.TeamID,
+		ChannelID: event.ChannelID,
+		BotUserID: event.BotUserID,
+		Message:   s.intro.message(),
+	}
+
+	organizationID, err := s.integrationRepository.BusinessIDByProviderProjectID(ctx, backend.ConnectorTypeSlack, event.TeamID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return fmt.Errorf("failed to find organization for team: %w", err)
+	default:
+		intro.Linked = true
+		if s.integrations != nil {
+			intro.Integrations, err = s.integrations.Integrations(ctx, backend.IntegrationsQuery{OrganizationID: organizationID})
+			if err != nil {
+				return fmt.Errorf("failed to list integrations: %w", err)
+			}
+		}
+		if s.modelSelectionEnabled(ctx, event.TeamID) {
+			intro.Models = s.models.Allowed
+		}
+	}
+
+	if err := s.slackGateway.PostChannelIntro(ctx, intro); err != nil {
+		return fmt.Errorf("failed to post channel intro: %w", err)
+	}
+	return nil
+}
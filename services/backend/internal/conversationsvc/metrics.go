@@ -0,0 +1,29 @@
+package conversationsvc
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const instrumentationName = "github.com/73ai/infragpt/services/backend/internal/conversationsvc"
+
+var (
+	deduplicatedMessages metric.Int64Counter
+	agentTurns           metric.Int64Counter
+	unreadableReplies    metric.Int64Counter
+)
+
+func init() {
+	deduplicatedMessages, _ = otel.Meter(instrumentationName).Int64Counter(
+		"conversation.messages.deduplicated",
+		metric.WithDescription("Number of Slack messages skipped because they were already processed"),
+	)
+	agentTurns, _ = otel.Meter(instrumentationName).Int64Counter(
+		"conversation.turns",
+		metric.WithDescription("Number of agent turns by outcome: completed, failed or cancelled"),
+	)
+	unreadableReplies, _ = otel.Meter(instrumentationName).Int64Counter(
+		"conversation.replies.unreadable",
+		metric.WithDescription("Number of agent replies with a response envelope that could not be read, posted as plain answers"),
+	)
+}
@@ -0,0 +1,74 @@
+package conversationsvc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+)
+
+const modelFlag = "--model"
+
+type ModelConfig struct {
+	// Default is recorded on messages that do not request a model. It is
+	// not sent to the agent, which picks its own default for them.
+	Default string   `mapstructure:"default"`
+	Allowed []string `mapstructure:"allowed"`
+}
+
+// resolve returns the model to record for the requested one, rejecting models
+// outside the allow-list.
+func (c ModelConfig) resolve(requested string) (string, error) {
+	if requested == "" {
+		return c.Default, nil
+	}
+	if !slices.Contains(c.Allowed, requested) {
+		if len(c.Allowed) == 0 {
+			return "", fmt.Errorf("%w %q: model selection is not enabled", domain.ErrUnknownModel, requested)
+		}
+		return "", fmt.Errorf("%w %q, available models: %s", domain.ErrUnknownModel, requested, strings.Join(c.Allowed, ", "))
+	}
+	return requested, nil
+}
+
+// parseModelFlag extracts a leading "--model <name>" or "--model=<name>" from
+// a message and returns the requested model and the remaining text.
+func parseModelFlag(text string) (model string, rest string) {
+	trimmed := strings.TrimSpace(text)
+	if !strings.HasPrefix(trimmed, modelFlag) {
+		return "", text
+	}
+
+	remainder := trimmed[len(modelFlag):]
+	switch {
+	case strings.HasPrefix(remainder, "="):
+		remainder = remainder[1:]
+	case strings.HasPrefix(remainder, " "), strings.HasPrefix(remainder, "\t"):
+		remainder = strings.TrimLeft(remainder, " \t")
+	default:
+		return "", text
+	}
+
+	model, rest, _ = strings.Cut(remainder, " ")
+	return strings.TrimSpace(model), strings.TrimSpace(rest)
+}
+
+// modelSelectionEnabled reports whether the organization behind a Slack
+// workspace has backend.FeatureFlagModelSelection turned on.
+func (s *Service) modelSelectionEnabled(ctx context.Context, teamID string) bool {
+	if s.featureFlags == nil {
+		return false
+	}
+
+	businessID, err := s.integrationRepository.BusinessIDByProviderProjectID(ctx, backend.ConnectorTypeSlack, teamID)
+	if err != nil {
+		slog.Info("no organization found for slack workspace, model selection disabled", "team_id", teamID, "error", err)
+		return false
+	}
+
+	return s.featureFlags.Enabled(ctx, businessID, backend.FeatureFlagModelSelection)
+}
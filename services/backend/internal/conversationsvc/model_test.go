@@ -0,0 +1,45 @@
+package conversationsvc
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+)
+
+func TestModelSelection(t *testing.T) {
+	models := ModelConfig{Default: "gpt-4o", Allowed: []string{"gpt-4o", "gpt-4o-mini"}}
+
+	tests := []struct {
+		name      string
+		text      string
+		wantModel string
+		wantText  string
+		wantErr   error
+	}{
+		{name: "no flag uses default", text: "why is the api slow?", wantModel: "gpt-4o", wantText: "why is the api slow?"},
+		{name: "flag with space", text: "--model gpt-4o-mini why is the api slow?", wantModel: "gpt-4o-mini", wantText: "why is the api slow?"},
+		{name: "flag with equals", text: "--model=gpt-4o-mini why?", wantModel: "gpt-4o-mini", wantText: "why?"},
+		{name: "flag not at start is ignored", text: "use --model gpt-4o-mini", wantModel: "gpt-4o", wantText: "use --model gpt-4o-mini"},
+		{name: "unknown model", text: "--model o9 why?", wantErr: domain.ErrUnknownModel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested, text := parseModelFlag(tt.text)
+			model, err := models.resolve(requested)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("resolve() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if model != tt.wantModel {
+				t.Errorf("model = %q, want %q", model, tt.wantModel)
+			}
+			if text != tt.wantText {
+				t.Errorf("text = %q, want %q", text, tt.wantText)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package conversationsvc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/google/uuid"
+)
+
+type NotificationConfig struct {
+	// Channels maps organization IDs to the Slack channel that receives the
+	// organization's alerts.
+	Channels map[string]string `mapstructure:"channels"`
+	// OnCallGroups maps organization IDs to the Slack user group paged when a
+	// conversation is handed off to a human.
+	OnCallGroups map[string]string `mapstructure:"oncall_groups"`
+}
+
+// CredentialAccessThresholdExceeded posts the alert to the organization's
+// notification channel in each of its Slack workspaces.
+func (s *Service) CredentialAccessThresholdExceeded(ctx context.Context, alert backend.CredentialAccessAlert) {
+	message := fmt.Sprintf("The credentials of the %s integration (%s) were read %d times in the last hour, above the alert threshold of %d. Review its credential access history if this was not expected.",
+		alert.Integration.ConnectorType, alert.Integration.ID, alert.Accesses, alert.Threshold)
+	s.notifyOrganization(ctx, alert.Integration.OrganizationID, "credential access alert", message)
+}
+
+// notifyReauthorization asks the organization to reconnect an integration
+// whose authorization no longer works.
+func (s *Service) notifyReauthorization(ctx context.Context, integration backend.Integration) {
+	message := fmt.Sprintf("The %s integration (%s) needs to be reauthorized: its authorization was revoked or no longer validates. Reconnect it from the dashboard to restore access.",
+		integration.ConnectorType, integration.ID)
+	s.notifyOrganization(ctx, integration.OrganizationID, "reauthorization notice", message)
+}
+
+// notifyOrganization posts message to the organization's notification channel
+// in each of its Slack workspaces.
+func (s *Service) notifyOrganization(ctx context.Context, organizationID uuid.UUID, kind, message string) {
+	channel := s.notifications.Channels[organizationID.String()]
+	if channel == "" {
+		slog.Warn("No notification channel for "+kind, "organizationID", organizationID)
+		return
+	}
+
+	workspaces, err := s.integrationRepository.Integrations(ctx, organizationID)
+	if err != nil {
+		slog.Error("Failed to find workspaces for "+kind, "error", err, "organizationID", organizationID)
+		return
+	}
+
+	for _, workspace := range workspaces {
+		if workspace.ConnectorType != backend.ConnectorTypeSlack {
+			continue
+		}
+		thread := domain.SlackThread{TeamID: workspace.ProviderProjectID, Channel: channel}
+		if err := s.slackGateway.ReplyMessage(ctx, thread, message); err != nil {
+			slog.Error("Failed to post "+kind, "error", err, "teamID", workspace.ProviderProjectID, "channel", channel)
+		}
+	}
+}
+
+var _ backend.CredentialAccessAlertListener = (*Service)(nil)
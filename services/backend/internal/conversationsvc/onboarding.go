@@ -0,0 +1,90 @@
+package conversationsvc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/google/uuid"
+)
+
+var onboardingLabels = map[backend.OnboardingStep]string{
+	backend.OnboardingStepConnectSlack:      "connecting Slack",
+	backend.OnboardingStepConnectGitHub:     "connecting GitHub",
+	backend.OnboardingStepConnectCloud:      "connecting a cloud account",
+	backend.OnboardingStepInviteTeammates:   "inviting teammates",
+	backend.OnboardingStepFirstConversation: "the first conversation",
+}
+
+// onboardingGuidance tells users how to finish each onboarding step.
+var onboardingGuidance = map[backend.OnboardingStep]string{
+	backend.OnboardingStepConnectSlack:      "add InfraGPT to your Slack workspace",
+	backend.OnboardingStepConnectGitHub:     "connect GitHub from the dashboard so I can read your repositories",
+	backend.OnboardingStepConnectCloud:      "connect your cloud account from the dashboard so I can look into your infrastructure",
+	backend.OnboardingStepInviteTeammates:   "invite your teammates from the dashboard",
+	backend.OnboardingStepFirstConversation: "mention me in a channel with a question about your infrastructure",
+}
+
+var _ backend.OnboardingReporter = (*Service)(nil)
+var _ backend.OnboardingStepListener = (*Service)(nil)
+
+// CompletedOnboardingSteps reports Slack as connected once a workspace is
+// linked to the organization, and the first conversation once one was held.
+func (s *Service) CompletedOnboardingSteps(ctx context.Context, organizationID uuid.UUID) ([]backend.OnboardingStep, error) {
+	activity, err := s.organizationDataRepository.OrganizationActivity(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization activity: %w", err)
+	}
+
+	var steps []backend.OnboardingStep
+	if activity.Workspaces > 0 {
+		steps = append(steps, backend.OnboardingStepConnectSlack)
+	}
+	if activity.Conversations > 0 {
+		steps = append(steps, backend.OnboardingStepFirstConversation)
+	}
+	return steps, nil
+}
+
+// OnboardingStepCompleted posts the completed step to the organization's
+// notification channel.
+func (s *Service) OnboardingStepCompleted(ctx context.Context, completion backend.OnboardingStepCompletion) {
+	message := fmt.Sprintf("InfraGPT setup: %s is done.", onboardingLabels[completion.Step])
+	s.notifyOrganization(ctx, completion.OrganizationID, "onboarding notice", message)
+}
+
+// onboardingReply points an organization's first mention at its next
+// onboarding step while connecting GitHub or a cloud is still pending, since
+// the agent cannot help without them. It returns false when the message should
+// go to the agent.
+func (s *Service) onboardingReply(ctx context.Context, thread domain.SlackThread) (string, bool) {
+	if s.onboarding == nil {
+		return "", false
+	}
+
+	organizationID, err := s.integrationRepository.BusinessIDByProviderProjectID(ctx, backend.ConnectorTypeSlack, thread.TeamID)
+	if err != nil {
+		return "", false
+	}
+
+	onboarding, err := s.onboarding.Onboarding(ctx, backend.OnboardingQuery{OrganizationID: organizationID})
+	if err != nil {
+		slog.Error("Failed to get onboarding, continuing without it", "organization_id", organizationID, "error", err)
+		return "", false
+	}
+	if onboarding.Status(backend.OnboardingStepFirstConversation) != backend.OnboardingStepStatusPending {
+		return "", false
+	}
+	if onboarding.Status(backend.OnboardingStepConnectGitHub) != backend.OnboardingStepStatusPending &&
+		onboarding.Status(backend.OnboardingStepConnectCloud) != backend.OnboardingStepStatusPending {
+		return "", false
+	}
+
+	next, ok := onboarding.NextStep()
+	if !ok {
+		return "", false
+	}
+	return "Thanks for trying InfraGPT! I can't look into your infrastructure until setup is further along. Next, " + onboardingGuidance[next] + ".", true
+}
@@ -0,0 +1,27 @@
+package conversationsvc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/73ai/infragpt/services/backend"
+)
+
+var _ backend.OrganizationDataOwner = (*Service)(nil)
+
+func (s *Service) DeleteOrganizationData(ctx context.Context, cmd backend.DeleteOrganizationCommand) ([]backend.DeletedResource, error) {
+	data, err := s.organizationDataRepository.DeleteOrganizationData(ctx, cmd.OrganizationID, cmd.DryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete slack data: %w", err)
+	}
+
+	return []backend.DeletedResource{
+		{Type: "slack_workspaces", Count: data.Workspaces},
+		{Type: "conversations", Count: data.Conversations},
+		{Type: "channels", Count: data.Channels},
+		{Type: "channel_contexts", Count: data.ChannelContexts},
+		{Type: "channel_intros", Count: data.ChannelIntros},
+		{Type: "slack_tokens", Count: data.SlackTokens},
+		{Type: "slack_user_mappings", Count: data.SlackUserMappings},
+	}, nil
+}
@@ -0,0 +1,54 @@
+package conversationsvc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/google/uuid"
+)
+
+// checkQuotas returns the organization behind a Slack workspace, or an error
+// wrapping backend.ErrQuotaExceeded when it has used up a quota. Workspaces
+// without an organization are not tracked, and failed quota lookups let the
+// request through.
+func (s *Service) checkQuotas(ctx context.Context, teamID string) (uuid.UUID, error) {
+	organizationID, ok := s.quotaOrganization(ctx, teamID)
+	if !ok {
+		return uuid.Nil, nil
+	}
+
+	if err := s.quotas.CheckQuotas(ctx, organizationID); err != nil {
+		if errors.Is(err, backend.ErrQuotaExceeded) {
+			return organizationID, err
+		}
+		slog.Error("Failed to check quotas, allowing request", "organization_id", organizationID, "error", err)
+	}
+	return organizationID, nil
+}
+
+// recordTokens counts the LLM tokens the agent reported using against the
+// organization's quota.
+func (s *Service) recordTokens(ctx context.Context, organizationID uuid.UUID, tokens int64) {
+	if s.quotas == nil || organizationID == uuid.Nil || tokens <= 0 {
+		return
+	}
+	s.quotas.RecordUsage(ctx, organizationID, backend.QuotaMetricLLMTokens, tokens)
+}
+
+func (s *Service) quotaOrganization(ctx context.Context, teamID string) (uuid.UUID, bool) {
+	if s.quotas == nil {
+		return uuid.Nil, false
+	}
+	organizationID, err := s.integrationRepository.BusinessIDByProviderProjectID(ctx, backend.ConnectorTypeSlack, teamID)
+	if err != nil {
+		return uuid.Nil, false
+	}
+	return organizationID, true
+}
+
+func quotaExceededReply(err error) string {
+	return "InfraGPT can't take on more requests right now: " + strings.TrimPrefix(err.Error(), backend.ErrQuotaExceeded.Error()+": ")
+}
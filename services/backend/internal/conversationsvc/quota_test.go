@@ -0,0 +1,61 @@
+package conversationsvc
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domaintest"
+	"github.com/google/uuid"
+)
+
+// quotaRecorder allows every request and adds up recorded usage per metric.
+type quotaRecorder struct {
+	usage map[backend.QuotaMetric]int64
+}
+
+func (q *quotaRecorder) CheckQuotas(ctx context.Context, organizationID uuid.UUID) error {
+	return nil
+}
+
+func (q *quotaRecorder) RecordUsage(ctx context.Context, organizationID uuid.UUID, metric backend.QuotaMetric, amount int64) {
+	q.usage[metric] += amount
+}
+
+func TestHandleUserCommandRecordsReportedTokens(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	quotas := &quotaRecorder{usage: map[backend.QuotaMetric]int64{}}
+	svc := &Service{
+		integrationRepository:  workspaceRepository{organizations: map[string]uuid.UUID{"T1": orgID}},
+		conversationRepository: domaintest.NewConversationRepository(),
+		channelRepository:      channelRepository{},
+		userMappingRepository: &userMappingRepository{mappings: map[string]backend.SlackUserMapping{
+			"T1/U1": {TeamID: "T1", SlackUserID: "U1", OrganizationID: orgID, UserID: uuid.New()},
+		}},
+		agentService: &agentService{tokensUsed: 1234},
+		models:       ModelConfig{Default: "gpt-4o"},
+		quotas:       quotas,
+	}
+
+	thread := domain.SlackThread{
+		Message:  "why is the api slow? " + strings.Repeat("x", 10000),
+		Sender:   domain.SlackUser{ID: "U1"},
+		Channel:  "C1",
+		ThreadTS: "1700000000.000100",
+		TeamID:   "T1",
+	}
+	command := domain.UserCommand{Thread: thread, MessageTS: thread.ThreadTS, MessageType: domain.MessageTypeAppMention, EventID: "Ev1"}
+	if err := svc.handleUserCommand(ctx, command); err != nil {
+		t.Fatalf("handleUserCommand() error = %v", err)
+	}
+
+	if got := quotas.usage[backend.QuotaMetricLLMTokens]; got != 1234 {
+		t.Errorf("recorded %d LLM tokens, want the 1234 the agent reported", got)
+	}
+	if got := quotas.usage[backend.QuotaMetricRequests]; got != 1 {
+		t.Errorf("recorded %d requests, want 1", got)
+	}
+}
@@ -0,0 +1,49 @@
+package conversationsvc
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/redact"
+	"github.com/google/uuid"
+)
+
+// redactMessages strips secrets from text, and from past messages in place,
+// before they are stored and sent to the agent. Past messages are redacted
+// again so history stored before redaction existed does not leak, but only
+// the redactions in text are returned since the rest were counted before.
+func (s *Service) redactMessages(ctx context.Context, teamID, text string, pastMessages []domain.Message) (string, redact.Redactions) {
+	organizationID := s.teamOrganization(ctx, teamID)
+
+	for i := range pastMessages {
+		pastMessages[i].MessageText, _ = s.redactor.Redact(ctx, organizationID, pastMessages[i].MessageText)
+	}
+	return s.redactor.Redact(ctx, organizationID, text)
+}
+
+// teamOrganization returns the organization linked to a Slack workspace, or
+// uuid.Nil so only the built-in detectors apply.
+func (s *Service) teamOrganization(ctx context.Context, teamID string) uuid.UUID {
+	if s.redactor == nil {
+		return uuid.Nil
+	}
+	organizationID, err := s.integrationRepository.BusinessIDByProviderProjectID(ctx, backend.ConnectorTypeSlack, teamID)
+	if err != nil {
+		return uuid.Nil
+	}
+	return organizationID
+}
+
+func (s *Service) recordRedactions(ctx context.Context, conversationID uuid.UUID, redactions redact.Redactions) {
+	total := redactions.Total()
+	if total == 0 {
+		return
+	}
+
+	slog.Info("Redacted secrets from conversation", "conversation_id", conversationID, "count", total, "types", redactions)
+	if err := s.conversationRepository.AddRedactions(ctx, conversationID, total); err != nil {
+		slog.Error("Failed to record conversation redactions", "conversation_id", conversationID, "error", err)
+	}
+}
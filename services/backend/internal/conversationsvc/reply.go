@@ -0,0 +1,77 @@
+package conversationsvc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	planFooter          = "_Nothing in this plan has been run. Reply in this thread to approve it or ask for changes._"
+	clarificationFooter = "_Reply in this thread and I'll pick up from there._"
+)
+
+// renderReply formats an agent reply for Slack by its kind. Replies whose
+// envelope cannot be read are posted as the agent sent them.
+func (s *Service) renderReply(ctx context.Context, conversationID uuid.UUID, message string) string {
+	reply, err := s.agentService.ParseReply(message)
+	if err != nil {
+		slog.Warn("Posting unreadable agent reply as an answer", "conversation_id", conversationID, "error", err)
+		unreadableReplies.Add(ctx, 1)
+		return message
+	}
+
+	switch reply.Kind {
+	case domain.ReplyKindPlan:
+		return renderPlan(reply)
+	case domain.ReplyKindNeedsClarification:
+		return renderClarification(reply)
+	default:
+		return reply.Text
+	}
+}
+
+func renderPlan(reply domain.AgentReply) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*Proposed plan* · risk: *%s*\n", reply.Plan.RiskLevel)
+	if reply.Text != "" {
+		fmt.Fprintf(&b, "%s\n", reply.Text)
+	}
+
+	b.WriteString("\n*Steps*\n")
+	for i, step := range reply.Plan.Steps {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, step.Description)
+		if step.Command != "" {
+			fmt.Fprintf(&b, "    `%s`\n", step.Command)
+		}
+	}
+	if len(reply.Plan.AffectedResources) > 0 {
+		b.WriteString("\n*Affected resources*\n")
+		for _, resource := range reply.Plan.AffectedResources {
+			fmt.Fprintf(&b, "• %s\n", resource)
+		}
+	}
+	if reply.Plan.RollbackNotes != "" {
+		fmt.Fprintf(&b, "\n*Rollback:* %s\n", reply.Plan.RollbackNotes)
+	}
+
+	b.WriteString("\n" + planFooter)
+	return b.String()
+}
+
+func renderClarification(reply domain.AgentReply) string {
+	var b strings.Builder
+	if reply.Text != "" {
+		fmt.Fprintf(&b, "%s\n", reply.Text)
+	}
+	for _, question := range reply.Questions {
+		fmt.Fprintf(&b, "• %s\n", question)
+	}
+
+	b.WriteString("\n" + clarificationFooter)
+	return b.String()
+}
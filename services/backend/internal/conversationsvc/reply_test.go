@@ -0,0 +1,86 @@
+package conversationsvc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/google/uuid"
+)
+
+type fakeReplyParser struct {
+	domain.AgentService
+	reply domain.AgentReply
+	err   error
+}
+
+func (f fakeReplyParser) ParseReply(string) (domain.AgentReply, error) {
+	return f.reply, f.err
+}
+
+func TestRenderReply(t *testing.T) {
+	plan := domain.AgentReply{
+		Kind: domain.ReplyKindPlan,
+		Text: "The API is CPU throttled.",
+		Plan: &domain.Plan{
+			Steps: []domain.PlanStep{
+				{Description: "Raise the CPU limit", Command: "kubectl set resources deployment/api --limits=cpu=2"},
+				{Description: "Watch the rollout"},
+			},
+			AffectedResources: []string{"deployment/api in prod"},
+			RiskLevel:         "medium",
+			RollbackNotes:     "kubectl rollout undo deployment/api",
+		},
+	}
+
+	tests := []struct {
+		name  string
+		agent fakeReplyParser
+		want  []string
+	}{
+		{
+			name:  "answer",
+			agent: fakeReplyParser{reply: domain.AgentReply{Kind: domain.ReplyKindAnswer, Text: "All green."}},
+			want:  []string{"All green."},
+		},
+		{
+			name:  "plan",
+			agent: fakeReplyParser{reply: plan},
+			want: []string{
+				"*Proposed plan* · risk: *medium*\nThe API is CPU throttled.\n",
+				"1. Raise the CPU limit\n    `kubectl set resources deployment/api --limits=cpu=2`\n2. Watch the rollout\n",
+				"*Affected resources*\n• deployment/api in prod\n",
+				"*Rollback:* kubectl rollout undo deployment/api\n",
+				planFooter,
+			},
+		},
+		{
+			name: "clarification",
+			agent: fakeReplyParser{reply: domain.AgentReply{
+				Kind:      domain.ReplyKindNeedsClarification,
+				Text:      "Which environment?",
+				Questions: []string{"prod or staging?"},
+			}},
+			want: []string{"Which environment?\n• prod or staging?\n", clarificationFooter},
+		},
+		{
+			name:  "unreadable envelope",
+			agent: fakeReplyParser{err: errors.New("invalid agent response envelope")},
+			want:  []string{`{"envelope_version": 1, "kind": "plan"}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{agentService: tt.agent}
+			got := s.renderReply(context.Background(), uuid.New(), `{"envelope_version": 1, "kind": "plan"}`)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("renderReply() = %q, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package conversationsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
+	"github.com/google/uuid"
+)
+
+// triggerSender is recorded as the author of messages started by repository
+// triggers rather than by a Slack user.
+var triggerSender = domain.SlackUser{ID: "repository-trigger", Name: "Repository trigger", Username: "repository-trigger"}
+
+// RepositoryEventTriggered starts an agent conversation about the event in the
+// trigger's channel. It returns immediately; the webhook that delivered the
+// event is not held open for the agent turn.
+func (s *Service) RepositoryEventTriggered(ctx context.Context, match backend.RepositoryTriggerMatch) {
+	go func() {
+		if err := s.startTriggeredConversation(tracing.Detach(ctx), match); err != nil {
+			slog.Error("Failed to start conversation for repository event",
+				"error", err,
+				"trigger_id", match.Trigger.ID,
+				"repository", match.Event.Repository,
+				"channel", match.Trigger.Channel)
+		}
+	}()
+}
+
+func (s *Service) startTriggeredConversation(ctx context.Context, match backend.RepositoryTriggerMatch) error {
+	if s.maintenance.ReadOnly() {
+		slog.Info("Skipping repository event while read-only", "trigger_id", match.Trigger.ID)
+		return nil
+	}
+
+	organizationID := match.Trigger.OrganizationID
+	if s.quotas != nil {
+		err := s.quotas.CheckQuotas(ctx, organizationID)
+		if errors.Is(err, backend.ErrQuotaExceeded) {
+			slog.Info("Skipping repository event over quota", "trigger_id", match.Trigger.ID, "organization_id", organizationID, "reason", err)
+			return nil
+		}
+		if err != nil {
+			slog.Error("Failed to check quotas, allowing repository event", "organization_id", organizationID, "error", err)
+		}
+	}
+
+	// Titles and commit messages can carry secrets; redact the summary like
+	// anything else posted to Slack.
+	summary, _ := s.redactor.Redact(ctx, organizationID, repositoryEventSummary(match.Event))
+	thread, err := s.postThread(ctx, organizationID, match.Trigger.Channel, summary)
+	if err != nil {
+		return err
+	}
+
+	conversation, err := s.conversationRepository.CreateConversation(ctx, thread.TeamID, thread.Channel, thread.ThreadTS)
+	if err != nil {
+		return fmt.Errorf("failed to create conversation: %w", err)
+	}
+
+	messageText, redactions := s.redactMessages(ctx, conversation.TeamID, repositoryEventPrompt(match.Event), nil)
+	message, err := s.conversationRepository.StoreMessage(ctx, conversation.ID, domain.Message{
+		ConversationID: conversation.ID,
+		SlackMessageTS: thread.ThreadTS,
+		Sender:         triggerSender,
+		MessageText:    messageText,
+		Model:          s.models.Default,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store message: %w", err)
+	}
+	s.recordRedactions(ctx, conversation.ID, redactions)
+
+	return s.runThreadTurn(ctx, thread, conversation, message, "", organizationID, uuid.Nil)
+}
+
+// repositoryEventSummary is the thread's root message in Slack.
+func repositoryEventSummary(event backend.RepositoryEvent) string {
+	switch event.Type {
+	case backend.RepositoryEventPullRequest:
+		return fmt.Sprintf("Pull request #%d %s in %s by %s: <%s|%s>",
+			event.PullRequestNumber, event.Action, event.Repository, event.Sender, event.URL, event.Title)
+	default:
+		return fmt.Sprintf("%s pushed to %s in %s: <%s|%s>",
+			event.Sender, event.Branch, event.Repository, event.URL, event.Title)
+	}
+}
+
+// repositoryEventPrompt asks the agent to look into the event.
+func repositoryEventPrompt(event backend.RepositoryEvent) string {
+	var b strings.Builder
+	switch event.Type {
+	case backend.RepositoryEventPullRequest:
+		fmt.Fprintf(&b, "Pull request #%d in %s was %s by %s.\nTitle: %s\nBranch: %s\nHead commit: %s\nURL: %s\n",
+			event.PullRequestNumber, event.Repository, event.Action, event.Sender, event.Title, event.Branch, event.CommitSHA, event.URL)
+	default:
+		fmt.Fprintf(&b, "%s pushed to %s in %s.\nHead commit: %s (%s)\nCompare: %s\n",
+			event.Sender, event.Branch, event.Repository, event.CommitSHA, event.Title, event.URL)
+	}
+	if len(event.ChangedFiles) > 0 {
+		b.WriteString("Changed files:\n")
+		for _, file := range event.ChangedFiles {
+			fmt.Fprintf(&b, "- %s\n", file)
+		}
+	}
+	b.WriteString("\nReview the change for infrastructure impact and summarize anything the team should know.")
+	return b.String()
+}
+
+var _ backend.RepositoryEventListener = (*Service)(nil)
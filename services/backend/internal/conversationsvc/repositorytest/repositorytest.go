@@ -0,0 +1,551 @@
+// Package repositorytest holds contract tests that every implementation of the
+// conversation service repositories must pass.
+package repositorytest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/google/uuid"
+)
+
+type fixture interface {
+	ConversationRepository() domain.ConversationRepository
+	ChannelRepository() domain.ChannelRepository
+	FeedbackRepository() domain.FeedbackRepository
+	UserMappingRepository() domain.UserMappingRepository
+	// Reset removes all stored data so each test starts from an empty store.
+	Reset(t *testing.T)
+}
+
+func Ensure(t *testing.T, f fixture) {
+	t.Run("ConversationRepository", func(t *testing.T) {
+		t.Run("creates and finds a conversation", func(t *testing.T) {
+			f.Reset(t)
+			ctx := context.Background()
+			repo := f.ConversationRepository()
+
+			created, err := repo.CreateConversation(ctx, "T1", "C1", "1700000000.000100")
+			if err != nil {
+				t.Fatalf("CreateConversation() error = %v", err)
+			}
+			if created.ID == uuid.Nil {
+				t.Fatal("CreateConversation() returned a nil ID")
+			}
+
+			byThread, err := repo.GetConversationByThread(ctx, "T1", "C1", "1700000000.000100")
+			if err != nil {
+				t.Fatalf("GetConversationByThread() error = %v", err)
+			}
+			if byThread.ID != created.ID {
+				t.Errorf("GetConversationByThread() = %s, want %s", byThread.ID, created.ID)
+			}
+
+			byID, err := repo.Conversation(ctx, created.ID)
+			if err != nil {
+				t.Fatalf("Conversation() error = %v", err)
+			}
+			if byID.TeamID != "T1" || byID.ChannelID != "C1" || byID.ThreadTS != "1700000000.000100" {
+				t.Errorf("Conversation() = %+v", byID)
+			}
+		})
+
+		t.Run("reports missing conversations as sql.ErrNoRows", func(t *testing.T) {
+			f.Reset(t)
+			ctx := context.Background()
+			repo := f.ConversationRepository()
+
+			if _, err := repo.GetConversationByThread(ctx, "T1", "C1", "missing"); !errors.Is(err, sql.ErrNoRows) {
+				t.Errorf("GetConversationByThread() error = %v, want %v", err, sql.ErrNoRows)
+			}
+			if _, err := repo.Conversation(ctx, uuid.New()); !errors.Is(err, sql.ErrNoRows) {
+				t.Errorf("Conversation() error = %v, want %v", err, sql.ErrNoRows)
+			}
+		})
+
+		t.Run("rejects a second conversation for the same thread", func(t *testing.T) {
+			f.Reset(t)
+			ctx := context.Background()
+			repo := f.ConversationRepository()
+
+			if _, err := repo.CreateConversation(ctx, "T1", "C1", "1700000000.000100"); err != nil {
+				t.Fatalf("CreateConversation() error = %v", err)
+			}
+			if _, err := repo.CreateConversation(ctx, "T1", "C1", "1700000000.000100"); err == nil {
+				t.Error("CreateConversation() error = nil, want unique constraint violation")
+			}
+		})
+
+		t.Run("stores messages and returns history in order", func(t *testing.T) {
+			f.Reset(t)
+			ctx := context.Background()
+			repo := f.ConversationRepository()
+
+			conversation, err := repo.CreateConversation(ctx, "T1", "C1", "1700000000.000100")
+			if err != nil {
+				t.Fatalf("CreateConversation() error = %v", err)
+			}
+
+			first := newMessage(conversation.ID, "1", "how do I restart the api?")
+			first.Model = "gpt-4o-mini"
+			second := newMessage(conversation.ID, "2", "run the deploy job")
+			second.IsBotMessage = true
+
+			for _, message := range []domain.Message{first, second} {
+				stored, err := repo.StoreMessage(ctx, conversation.ID, message)
+				if err != nil {
+					t.Fatalf("StoreMessage() error = %v", err)
+				}
+				if stored.ID == uuid.Nil {
+					t.Error("StoreMessage() returned a nil ID")
+				}
+			}
+
+			history, err := repo.GetConversationHistory(ctx, conversation.ID)
+			if err != nil {
+				t.Fatalf("GetConversationHistory() error = %v", err)
+			}
+			if len(history) != 2 {
+				t.Fatalf("GetConversationHistory() returned %d messages, want 2", len(history))
+			}
+			if history[0].MessageText != first.MessageText || history[0].Model != "gpt-4o-mini" {
+				t.Errorf("history[0] = %+v, want %+v", history[0], first)
+			}
+			if !history[1].IsBotMessage || history[1].Model != "" {
+				t.Errorf("history[1] = %+v, want a bot message without model", history[1])
+			}
+		})
+
+		t.Run("finds a message by slack timestamp", func(t *testing.T) {
+			f.Reset(t)
+			ctx := context.Background()
+			repo := f.ConversationRepository()
+
+			conversation, err := repo.CreateConversation(ctx, "T1", "C1", "1700000000.000100")
+			if err != nil {
+				t.Fatalf("CreateConversation() error = %v", err)
+			}
+			message := newMessage(conversation.ID, "1700000000.000200", "hello")
+			if _, err := repo.StoreMessage(ctx, conversation.ID, message); err != nil {
+				t.Fatalf("StoreMessage() error = %v", err)
+			}
+
+			got, err := repo.MessageBySlackTS(ctx, conversation.ID, message.Sender.ID, message.SlackMessageTS)
+			if err != nil {
+				t.Fatalf("MessageBySlackTS() error = %v", err)
+			}
+			if got.MessageText != "hello" || got.Sender.Email != message.Sender.Email {
+				t.Errorf("MessageBySlackTS() = %+v", got)
+			}
+
+			_, err = repo.MessageBySlackTS(ctx, conversation.ID, "someone-else", message.SlackMessageTS)
+			if !errors.Is(err, sql.ErrNoRows) {
+				t.Errorf("MessageBySlackTS() for another sender error = %v, want %v", err, sql.ErrNoRows)
+			}
+		})
+
+		t.Run("rejects duplicate and orphaned messages", func(t *testing.T) {
+			f.Reset(t)
+			ctx := context.Background()
+			repo := f.ConversationRepository()
+
+			conversation, err := repo.CreateConversation(ctx, "T1", "C1", "1700000000.000100")
+			if err != nil {
+				t.Fatalf("CreateConversation() error = %v", err)
+			}
+			message := newMessage(conversation.ID, "1", "hello")
+			if _, err := repo.StoreMessage(ctx, conversation.ID, message); err != nil {
+				t.Fatalf("StoreMessage() error = %v", err)
+			}
+			if _, err := repo.StoreMessage(ctx, conversation.ID, message); err == nil {
+				t.Error("StoreMessage() with duplicate timestamp error = nil, want unique constraint violation")
+			}
+			if _, err := repo.StoreMessage(ctx, uuid.New(), newMessage(uuid.Nil, "2", "hello")); err == nil {
+				t.Error("StoreMessage() for unknown conversation error = nil, want foreign key violation")
+			}
+		})
+
+		t.Run("deduplicates retried deliveries", func(t *testing.T) {
+			f.Reset(t)
+			ctx := context.Background()
+			repo := f.ConversationRepository()
+
+			conversation, err := repo.CreateConversation(ctx, "T1", "C1", "1700000000.000100")
+			if err != nil {
+				t.Fatalf("CreateConversation() error = %v", err)
+			}
+			message := newMessage(conversation.ID, "1", "hello")
+			message.SlackEventID = "Ev1"
+			message.ClientMsgID = "client-1"
+			if _, err := repo.StoreMessage(ctx, conversation.ID, message); err != nil {
+				t.Fatalf("StoreMessage() error = %v", err)
+			}
+
+			retry := newMessage(conversation.ID, "2", "hello")
+			retry.SlackEventID = "Ev1"
+			if _, err := repo.StoreMessage(ctx, conversation.ID, retry); !errors.Is(err, domain.ErrDuplicateMessage) {
+				t.Errorf("StoreMessage() with same event id error = %v, want %v", err, domain.ErrDuplicateMessage)
+			}
+
+			// app_mention and message events for one user message share client_msg_id.
+			mention := newMessage(conversation.ID, "3", "hello")
+			mention.SlackEventID = "Ev2"
+			mention.ClientMsgID = "client-1"
+			if _, err := repo.StoreMessage(ctx, conversation.ID, mention); !errors.Is(err, domain.ErrDuplicateMessage) {
+				t.Errorf("StoreMessage() with same client_msg_id error = %v, want %v", err, domain.ErrDuplicateMessage)
+			}
+		})
+
+		t.Run("adds redactions to a conversation", func(t *testing.T) {
+			f.Reset(t)
+			ctx := context.Background()
+			repo := f.ConversationRepository()
+
+			conversation, err := repo.CreateConversation(ctx, "T1", "C1", "1700000000.000100")
+			if err != nil {
+				t.Fatalf("CreateConversation() error = %v", err)
+			}
+			for range 2 {
+				if err := repo.AddRedactions(ctx, conversation.ID, 3); err != nil {
+					t.Fatalf("AddRedactions() error = %v", err)
+				}
+			}
+			if err := repo.AddRedactions(ctx, uuid.New(), 1); err == nil {
+				t.Error("AddRedactions() for unknown conversation error = nil, want foreign key violation")
+			}
+		})
+
+		t.Run("records status changes once per transition", func(t *testing.T) {
+			f.Reset(t)
+			ctx := context.Background()
+			repo := f.ConversationRepository()
+
+			conversation, err := repo.CreateConversation(ctx, "T1", "C1", "1700000000.000100")
+			if err != nil {
+				t.Fatalf("CreateConversation() error = %v", err)
+			}
+			if conversation.Status != backend.ConversationStatusActive {
+				t.Errorf("CreateConversation() status = %q, want %q", conversation.Status, backend.ConversationStatusActive)
+			}
+
+			transitions := []struct {
+				status backend.ConversationStatus
+				want   bool
+			}{
+				{backend.ConversationStatusEscalated, true},
+				{backend.ConversationStatusEscalated, false},
+				{backend.ConversationStatusActive, true},
+			}
+			for _, tt := range transitions {
+				changed, err := repo.SetStatus(ctx, backend.ConversationStatusChange{
+					ConversationID: conversation.ID,
+					Status:         tt.status,
+					ChangedBy:      "U1",
+					Reason:         "test",
+				})
+				if err != nil {
+					t.Fatalf("SetStatus(%q) error = %v", tt.status, err)
+				}
+				if changed != tt.want {
+					t.Errorf("SetStatus(%q) = %v, want %v", tt.status, changed, tt.want)
+				}
+			}
+
+			found, err := repo.Conversation(ctx, conversation.ID)
+			if err != nil {
+				t.Fatalf("Conversation() error = %v", err)
+			}
+			if found.Status != backend.ConversationStatusActive {
+				t.Errorf("Conversation() status = %q, want %q", found.Status, backend.ConversationStatusActive)
+			}
+
+			changes, err := repo.StatusChanges(ctx, conversation.ID)
+			if err != nil {
+				t.Fatalf("StatusChanges() error = %v", err)
+			}
+			if len(changes) != 2 || changes[0].Status != backend.ConversationStatusEscalated || changes[1].Status != backend.ConversationStatusActive {
+				t.Errorf("StatusChanges() = %+v, want escalated then active", changes)
+			}
+			if len(changes) > 0 && (changes[0].ChangedBy != "U1" || changes[0].Reason != "test") {
+				t.Errorf("StatusChanges()[0] = %+v, want changed by U1 for test", changes[0])
+			}
+		})
+
+		t.Run("lists a user's recent conversations", func(t *testing.T) {
+			f.Reset(t)
+			ctx := context.Background()
+			repo := f.ConversationRepository()
+
+			older, err := repo.CreateConversation(ctx, "T1", "C1", "1700000000.000100")
+			if err != nil {
+				t.Fatalf("CreateConversation() error = %v", err)
+			}
+			newer, err := repo.CreateConversation(ctx, "T1", "C2", "1700000000.000200")
+			if err != nil {
+				t.Fatalf("CreateConversation() error = %v", err)
+			}
+			otherTeam, err := repo.CreateConversation(ctx, "T2", "C1", "1700000000.000300")
+			if err != nil {
+				t.Fatalf("CreateConversation() error = %v", err)
+			}
+			for i, conversation := range []domain.Conversation{older, newer, otherTeam} {
+				if _, err := repo.StoreMessage(ctx, conversation.ID, newMessage(conversation.ID, fmt.Sprint(i), "hello")); err != nil {
+					t.Fatalf("StoreMessage() error = %v", err)
+				}
+			}
+
+			recent, err := repo.RecentConversations(ctx, "T1", "U1", 5)
+			if err != nil {
+				t.Fatalf("RecentConversations() error = %v", err)
+			}
+			if len(recent) != 2 || recent[0].ID != newer.ID || recent[1].ID != older.ID {
+				t.Errorf("RecentConversations() = %+v, want newest conversation in T1 first", recent)
+			}
+
+			limited, err := repo.RecentConversations(ctx, "T1", "U1", 1)
+			if err != nil {
+				t.Fatalf("RecentConversations() error = %v", err)
+			}
+			if len(limited) != 1 {
+				t.Errorf("RecentConversations() with limit 1 returned %d conversations", len(limited))
+			}
+
+			none, err := repo.RecentConversations(ctx, "T1", "someone-else", 5)
+			if err != nil {
+				t.Fatalf("RecentConversations() error = %v", err)
+			}
+			if len(none) != 0 {
+				t.Errorf("RecentConversations() for another user = %+v, want none", none)
+			}
+		})
+	})
+
+	t.Run("ChannelRepository", func(t *testing.T) {
+		t.Run("sets, replaces and clears a channel context", func(t *testing.T) {
+			f.Reset(t)
+			ctx := context.Background()
+			repo := f.ChannelRepository()
+
+			empty, err := repo.ChannelContext(ctx, "T1", "C1")
+			if err != nil {
+				t.Fatalf("ChannelContext() error = %v", err)
+			}
+			if len(empty.Repositories) != 0 || empty.ChannelID != "C1" {
+				t.Errorf("ChannelContext() before set = %+v, want an empty context for C1", empty)
+			}
+
+			if err := repo.AddChannel(ctx, "T1", "C1", "payments-oncall"); err != nil {
+				t.Fatalf("AddChannel() error = %v", err)
+			}
+			err = repo.SetChannelContext(ctx, backend.ChannelContext{TeamID: "T1", ChannelID: "C1", Repositories: []string{"acme/payments"}, GCPProjects: []string{"acme-prod"}})
+			if err != nil {
+				t.Fatalf("SetChannelContext() error = %v", err)
+			}
+			err = repo.SetChannelContext(ctx, backend.ChannelContext{TeamID: "T1", ChannelID: "C1", KubernetesNamespaces: []string{"payments"}})
+			if err != nil {
+				t.Fatalf("SetChannelContext() replace error = %v", err)
+			}
+
+			got, err := repo.ChannelContext(ctx, "T1", "C1")
+			if err != nil {
+				t.Fatalf("ChannelContext() error = %v", err)
+			}
+			if len(got.Repositories) != 0 || len(got.GCPProjects) != 0 || fmt.Sprint(got.KubernetesNamespaces) != "[payments]" {
+				t.Errorf("ChannelContext() = %+v, want only the payments namespace", got)
+			}
+
+			listed, err := repo.ChannelContexts(ctx, "T1")
+			if err != nil {
+				t.Fatalf("ChannelContexts() error = %v", err)
+			}
+			if len(listed) != 1 || listed[0].ChannelName != "payments-oncall" {
+				t.Errorf("ChannelContexts() = %+v, want the named channel", listed)
+			}
+
+			if err := repo.SetChannelContext(ctx, backend.ChannelContext{TeamID: "T1", ChannelID: "C1"}); err != nil {
+				t.Fatalf("SetChannelContext() clear error = %v", err)
+			}
+			listed, err = repo.ChannelContexts(ctx, "T1")
+			if err != nil {
+				t.Fatalf("ChannelContexts() error = %v", err)
+			}
+			if len(listed) != 0 {
+				t.Errorf("ChannelContexts() after clear = %+v, want none", listed)
+			}
+		})
+
+		t.Run("claims a channel intro once per cooldown", func(t *testing.T) {
+			f.Reset(t)
+			ctx := context.Background()
+			repo := f.ChannelRepository()
+
+			claimed, err := repo.ClaimChannelIntro(ctx, "T1", "C1", time.Now().Add(-time.Hour))
+			if err != nil {
+				t.Fatalf("ClaimChannelIntro() error = %v", err)
+			}
+			if !claimed {
+				t.Error("ClaimChannelIntro() first claim = false, want true")
+			}
+
+			claimed, err = repo.ClaimChannelIntro(ctx, "T1", "C1", time.Now().Add(-time.Hour))
+			if err != nil {
+				t.Fatalf("ClaimChannelIntro() error = %v", err)
+			}
+			if claimed {
+				t.Error("ClaimChannelIntro() within cooldown = true, want false")
+			}
+
+			claimed, err = repo.ClaimChannelIntro(ctx, "T1", "C2", time.Now().Add(-time.Hour))
+			if err != nil {
+				t.Fatalf("ClaimChannelIntro() error = %v", err)
+			}
+			if !claimed {
+				t.Error("ClaimChannelIntro() other channel = false, want true")
+			}
+
+			claimed, err = repo.ClaimChannelIntro(ctx, "T1", "C1", time.Now().Add(time.Minute))
+			if err != nil {
+				t.Fatalf("ClaimChannelIntro() error = %v", err)
+			}
+			if !claimed {
+				t.Error("ClaimChannelIntro() after cooldown = false, want true")
+			}
+		})
+	})
+
+	t.Run("FeedbackRepository", func(t *testing.T) {
+		t.Run("links feedback to the reply and its invocation and updates it in place", func(t *testing.T) {
+			f.Reset(t)
+			ctx := context.Background()
+			conversations := f.ConversationRepository()
+			repo := f.FeedbackRepository()
+
+			conversation, err := conversations.CreateConversation(ctx, "T1", "C1", "1700000000.000100")
+			if err != nil {
+				t.Fatalf("CreateConversation() error = %v", err)
+			}
+			question, err := conversations.StoreMessage(ctx, conversation.ID, newMessage(conversation.ID, "1", "why is checkout down?"))
+			if err != nil {
+				t.Fatalf("StoreMessage() error = %v", err)
+			}
+			reply := newMessage(conversation.ID, "2", "the payments pod is crash looping")
+			reply.Sender = domain.SlackUser{ID: "bot"}
+			reply.IsBotMessage = true
+			reply, err = conversations.StoreMessage(ctx, conversation.ID, reply)
+			if err != nil {
+				t.Fatalf("StoreMessage() error = %v", err)
+			}
+
+			first, err := repo.SaveFeedback(ctx, domain.Feedback{MessageID: reply.ID, TeamID: "T1", UserID: "U1", Rating: domain.FeedbackRatingNegative, Comment: "missed the root cause"})
+			if err != nil {
+				t.Fatalf("SaveFeedback() error = %v", err)
+			}
+			if first.ConversationID != conversation.ID || first.InvocationMessageID != question.ID {
+				t.Errorf("SaveFeedback() = %+v, want conversation %s and invocation %s", first, conversation.ID, question.ID)
+			}
+
+			second, err := repo.SaveFeedback(ctx, domain.Feedback{MessageID: reply.ID, TeamID: "T1", UserID: "U1", Rating: domain.FeedbackRatingPositive})
+			if err != nil {
+				t.Fatalf("SaveFeedback() again error = %v", err)
+			}
+			if second.ID != first.ID || second.Rating != domain.FeedbackRatingPositive || second.Comment != "missed the root cause" {
+				t.Errorf("SaveFeedback() again = %+v, want the same row with the new rating and the kept comment", second)
+			}
+
+			if _, err := repo.SaveFeedback(ctx, domain.Feedback{MessageID: question.ID, TeamID: "T1", UserID: "U1", Rating: domain.FeedbackRatingPositive}); !errors.Is(err, domain.ErrFeedbackMessageNotFound) {
+				t.Errorf("SaveFeedback() on a user message error = %v, want ErrFeedbackMessageNotFound", err)
+			}
+			if _, err := repo.SaveFeedback(ctx, domain.Feedback{MessageID: reply.ID, TeamID: "T2", UserID: "U1", Rating: domain.FeedbackRatingPositive}); !errors.Is(err, domain.ErrFeedbackMessageNotFound) {
+				t.Errorf("SaveFeedback() from another workspace error = %v, want ErrFeedbackMessageNotFound", err)
+			}
+
+			if _, err := repo.SaveFeedback(ctx, domain.Feedback{MessageID: reply.ID, TeamID: "T1", UserID: "U2", Rating: domain.FeedbackRatingNegative}); err != nil {
+				t.Fatalf("SaveFeedback() second user error = %v", err)
+			}
+
+			counts, err := repo.FeedbackCounts(ctx, []string{"T1"}, "day", time.Now().Add(-time.Hour), time.Now().Add(time.Hour))
+			if err != nil {
+				t.Fatalf("FeedbackCounts() error = %v", err)
+			}
+			if len(counts) != 1 || counts[0].Positive != 1 || counts[0].Negative != 1 {
+				t.Errorf("FeedbackCounts() = %+v, want one bucket with one rating each", counts)
+			}
+		})
+	})
+
+	t.Run("UserMappingRepository", func(t *testing.T) {
+		t.Run("saves, overrides, lists and deletes slack user mappings", func(t *testing.T) {
+			f.Reset(t)
+			ctx := context.Background()
+			repo := f.UserMappingRepository()
+			orgID := uuid.New()
+
+			if _, err := repo.SlackUserMapping(ctx, "T1", "U1"); !errors.Is(err, sql.ErrNoRows) {
+				t.Fatalf("SlackUserMapping() before save error = %v, want sql.ErrNoRows", err)
+			}
+
+			byEmail := uuid.New()
+			_, err := repo.SaveSlackUserMapping(ctx, backend.SlackUserMapping{TeamID: "T1", SlackUserID: "U1", OrganizationID: orgID, UserID: byEmail, Source: backend.SlackUserMappingSourceEmail})
+			if err != nil {
+				t.Fatalf("SaveSlackUserMapping() error = %v", err)
+			}
+			manual := uuid.New()
+			saved, err := repo.SaveSlackUserMapping(ctx, backend.SlackUserMapping{TeamID: "T1", SlackUserID: "U1", OrganizationID: orgID, UserID: manual, Source: backend.SlackUserMappingSourceManual})
+			if err != nil {
+				t.Fatalf("SaveSlackUserMapping() override error = %v", err)
+			}
+			if saved.UserID != manual || saved.Source != backend.SlackUserMappingSourceManual {
+				t.Errorf("SaveSlackUserMapping() override = %+v, want the manual mapping", saved)
+			}
+
+			got, err := repo.SlackUserMapping(ctx, "T1", "U1")
+			if err != nil {
+				t.Fatalf("SlackUserMapping() error = %v", err)
+			}
+			if got.UserID != manual || got.OrganizationID != orgID {
+				t.Errorf("SlackUserMapping() = %+v, want the manual mapping", got)
+			}
+
+			if _, err := repo.SaveSlackUserMapping(ctx, backend.SlackUserMapping{TeamID: "T2", SlackUserID: "U1", OrganizationID: uuid.New(), UserID: uuid.New(), Source: backend.SlackUserMappingSourceEmail}); err != nil {
+				t.Fatalf("SaveSlackUserMapping() other organization error = %v", err)
+			}
+			listed, err := repo.SlackUserMappings(ctx, orgID)
+			if err != nil {
+				t.Fatalf("SlackUserMappings() error = %v", err)
+			}
+			if len(listed) != 1 || listed[0].TeamID != "T1" {
+				t.Errorf("SlackUserMappings() = %+v, want only the organization's mapping", listed)
+			}
+
+			if err := repo.DeleteSlackUserMapping(ctx, uuid.New(), "T1", "U1"); !errors.Is(err, domain.ErrSlackUserMappingNotFound) {
+				t.Errorf("DeleteSlackUserMapping() from another organization error = %v, want ErrSlackUserMappingNotFound", err)
+			}
+			if err := repo.DeleteSlackUserMapping(ctx, orgID, "T1", "U1"); err != nil {
+				t.Fatalf("DeleteSlackUserMapping() error = %v", err)
+			}
+			if _, err := repo.SlackUserMapping(ctx, "T1", "U1"); !errors.Is(err, sql.ErrNoRows) {
+				t.Errorf("SlackUserMapping() after delete error = %v, want sql.ErrNoRows", err)
+			}
+		})
+	})
+}
+
+func newMessage(conversationID uuid.UUID, slackTS, text string) domain.Message {
+	return domain.Message{
+		ConversationID: conversationID,
+		SlackMessageTS: slackTS,
+		Sender: domain.SlackUser{
+			ID:       "U1",
+			Email:    "jane@example.com",
+			Name:     "Jane",
+			Username: "jane",
+		},
+		MessageText: text,
+	}
+}
@@ -10,15 +10,33 @@ import (
 
 	"github.com/73ai/infragpt/services/backend"
 	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/maintenance"
+	"github.com/73ai/infragpt/services/backend/internal/generic/redact"
 	"github.com/google/uuid"
 )
 
 type Service struct {
-	slackGateway           domain.SlackGateway
-	integrationRepository  domain.IntegrationRepository
-	conversationRepository domain.ConversationRepository
-	channelRepository      domain.ChannelRepository
-	agentService           domain.AgentService
+	slackGateway               domain.SlackGateway
+	integrationRepository      domain.IntegrationRepository
+	conversationRepository     domain.ConversationRepository
+	historyRepository          domain.ConversationRepository
+	channelRepository          domain.ChannelRepository
+	feedbackRepository         domain.FeedbackRepository
+	userMappingRepository      domain.UserMappingRepository
+	organizationDataRepository domain.OrganizationDataRepository
+	agentService               domain.AgentService
+	models                     ModelConfig
+	intro                      IntroConfig
+	featureFlags               backend.FeatureFlags
+	maintenance                *maintenance.Mode
+	integrations               backend.IntegrationService
+	identity                   backend.IdentityService
+	redactor                   *redact.Redactor
+	notifications              NotificationConfig
+	quotas                     backend.Quotas
+	onboarding                 backend.OnboardingService
+	homeViewers                homeViewers
+	turns                      turns
 }
 
 func (s *Service) Integrations(ctx context.Context, query backend.IntegrationsQuery) ([]backend.Integration, error) {
@@ -60,8 +78,10 @@ func (s *Service) CompleteSlackIntegration(ctx context.Context, command backend.
 
 var _ backend.ConversationService = (*Service)(nil)
 
+const readOnlyReply = "InfraGPT is briefly read-only for maintenance and can't pick up new requests right now. Please try again in a few minutes."
+
 func (s *Service) SendReply(ctx context.Context, command backend.SendReplyCommand) error {
-	slog.Info("Sending reply to Slack", "conversationID", command.ConversationID, "message", command.Message)
+	slog.Info("Sending reply to Slack", "conversationID", command.ConversationID)
 	conversationID, err := uuid.Parse(command.ConversationID)
 	if err != nil {
 		return fmt.Errorf("invalid conversation ID: %w", err)
@@ -71,6 +91,13 @@ func (s *Service) SendReply(ctx context.Context, command backend.SendReplyComman
 	if err != nil {
 		return fmt.Errorf("failed to get conversation: %w", err)
 	}
+	if conversation.Status == backend.ConversationStatusEscalated {
+		slog.Info("Dropping agent reply in escalated conversation", "conversationID", conversationID)
+		return nil
+	}
+
+	reply, redactions := s.redactor.Redact(ctx, s.teamOrganization(ctx, conversation.TeamID), s.renderReply(ctx, conversationID, command.Message))
+	s.recordRedactions(ctx, conversationID, redactions)
 
 	thread := domain.SlackThread{
 		Message:  "",
@@ -79,11 +106,6 @@ func (s *Service) SendReply(ctx context.Context, command backend.SendReplyComman
 		TeamID:   conversation.TeamID,
 	}
 
-	err = s.slackGateway.ReplyMessage(ctx, thread, command.Message)
-	if err != nil {
-		return fmt.Errorf("failed to send reply: %w", err)
-	}
-
 	botMessage := domain.Message{
 		ConversationID: conversationID,
 		SlackMessageTS: fmt.Sprintf("%d", time.Now().UnixNano()),
@@ -92,20 +114,45 @@ func (s *Service) SendReply(ctx context.Context, command backend.SendReplyComman
 			Username: "bot",
 			Name:     "Backend Bot",
 		},
-		MessageText:  command.Message,
+		MessageText:  reply,
 		IsBotMessage: true,
 	}
 
-	_, err = s.conversationRepository.StoreMessage(ctx, conversationID, botMessage)
+	// The reply is stored first so its feedback buttons can reference it.
+	stored, err := s.conversationRepository.StoreMessage(ctx, conversationID, botMessage)
 	if err != nil {
 		slog.Error("Failed to store bot message", "error", err)
+		if err := s.slackGateway.ReplyMessage(ctx, thread, reply); err != nil {
+			return fmt.Errorf("failed to send reply: %w", err)
+		}
 		return fmt.Errorf("failed to store bot message: %w", err)
 	}
 
+	steps, err := s.turnSteps(ctx, conversation)
+	if err != nil {
+		slog.Error("Failed to get the steps of the turn", "conversation_id", conversation.ID, "error", err)
+	}
+	if footer := s.stepFooter(ctx, conversation, steps); footer != "" {
+		reply += "\n\n" + footer
+	}
+	if err := s.slackGateway.ReplyWithFeedback(ctx, thread, reply, stored.ID); err != nil {
+		return fmt.Errorf("failed to send reply: %w", err)
+	}
+	if hasReadOnlyStep(steps) {
+		if err := s.slackGateway.ReplyReadOnlyIntegration(ctx, thread); err != nil {
+			return fmt.Errorf("failed to send read-only notice: %w", err)
+		}
+	}
+
 	return nil
 }
 
 func (s *Service) SubscribeSlackNotifications(ctx context.Context) error {
+	s.slackGateway.OnHomeOpened(s.handleHomeOpened)
+	s.slackGateway.OnSlashCommand(s.handleSlashCommand)
+	s.slackGateway.OnFeedback(s.handleFeedback)
+	s.slackGateway.OnBotJoinedChannel(s.handleBotJoinedChannel)
+	s.slackGateway.OnMessageDeleted(s.handleMessageDeleted)
 	if err := s.slackGateway.SubscribeAllMessages(ctx, s.handleUserCommand); err != nil {
 		return fmt.Errorf("failed to subscribe to all messages: %w", err)
 	}
@@ -116,10 +163,82 @@ func (s *Service) SubscribeSlackNotifications(ctx context.Context) error {
 func (s *Service) handleUserCommand(ctx context.Context, command domain.UserCommand) error {
 	slog.Info("Received user command", "type", command.MessageType, "channel", command.Thread.Channel, "user", command.Thread.Sender.Username)
 
+	if command.InReply && isStopCommand(command.Thread.Message) {
+		return s.stopThread(ctx, command.Thread)
+	}
+
+	if s.maintenance.ReadOnly() {
+		if err := s.slackGateway.ReplyMessage(ctx, command.Thread, readOnlyReply); err != nil {
+			return fmt.Errorf("failed to reply with read-only notice: %w", err)
+		}
+		return nil
+	}
+
+	if command.InReply && isEscalateCommand(command.Thread.Message) {
+		return s.escalateThread(ctx, command.Thread)
+	}
+	if command.InReply && isResumeCommand(command.Thread.Message) {
+		return s.resumeThread(ctx, command.Thread)
+	}
+	if command.InReply {
+		held, err := s.holdEscalatedMessage(ctx, command)
+		if err != nil {
+			return fmt.Errorf("failed to hold message in escalated thread: %w", err)
+		}
+		if held {
+			return nil
+		}
+	}
+
+	userID, err := s.resolveUser(ctx, command.Thread)
+	if errors.Is(err, domain.ErrSlackUserNotMapped) {
+		slog.Info("Rejected message from unmapped Slack user", "team_id", command.Thread.TeamID, "slack_user_id", command.Thread.Sender.ID)
+		if err := s.slackGateway.ReplyUnmappedUser(ctx, command.Thread); err != nil {
+			return fmt.Errorf("failed to reply to unmapped user: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to resolve slack user: %w", err)
+	}
+
+	if !command.InReply {
+		if reply, ok := s.onboardingReply(ctx, command.Thread); ok {
+			if err := s.slackGateway.ReplyMessage(ctx, command.Thread, reply); err != nil {
+				return fmt.Errorf("failed to reply with onboarding step: %w", err)
+			}
+			return nil
+		}
+	}
+
+	requestedModel, messageText := parseModelFlag(command.Thread.Message)
+	if requestedModel != "" && !s.modelSelectionEnabled(ctx, command.Thread.TeamID) {
+		if err := s.slackGateway.ReplyMessage(ctx, command.Thread, domain.ErrModelSelectionDisabled.Error()); err != nil {
+			return fmt.Errorf("failed to reply with model error: %w", err)
+		}
+		return nil
+	}
+
+	model, err := s.models.resolve(requestedModel)
+	if err != nil {
+		slog.Info("Rejected message with unknown model", "model", requestedModel, "channel", command.Thread.Channel)
+		if err := s.slackGateway.ReplyMessage(ctx, command.Thread, err.Error()); err != nil {
+			return fmt.Errorf("failed to reply with model error: %w", err)
+		}
+		return nil
+	}
+
+	organizationID, err := s.checkQuotas(ctx, command.Thread.TeamID)
+	if errors.Is(err, backend.ErrQuotaExceeded) {
+		if err := s.slackGateway.ReplyMessage(ctx, command.Thread, quotaExceededReply(err)); err != nil {
+			return fmt.Errorf("failed to reply with quota notice: %w", err)
+		}
+		return nil
+	}
+
 	var pastMessages []domain.Message
 
 	var conversation domain.Conversation
-	var err error
 	conversation, err = s.conversationRepository.GetConversationByThread(ctx, command.Thread.TeamID, command.Thread.Channel, command.Thread.ThreadTS)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
 		slog.Error("Failed to get conversation", "error", err)
@@ -140,12 +259,17 @@ func (s *Service) handleUserCommand(ctx context.Context, command domain.UserComm
 		}
 	}
 
+	messageText, redactions := s.redactMessages(ctx, conversation.TeamID, messageText, pastMessages)
+
 	message := domain.Message{
 		ConversationID: conversation.ID,
 		SlackMessageTS: fmt.Sprintf("%d", time.Now().UnixNano()),
 		Sender:         command.Thread.Sender,
-		MessageText:    command.Thread.Message,
+		MessageText:    messageText,
 		IsBotMessage:   false,
+		Model:          model,
+		SlackEventID:   command.EventID,
+		ClientMsgID:    command.ClientMsgID,
 	}
 
 	_, err = s.conversationRepository.MessageBySlackTS(ctx, conversation.ID, command.Thread.Sender.ID, command.MessageTS)
@@ -159,20 +283,66 @@ func (s *Service) handleUserCommand(ctx context.Context, command domain.UserComm
 	}
 
 	_, err = s.conversationRepository.StoreMessage(ctx, conversation.ID, message)
+	if errors.Is(err, domain.ErrDuplicateMessage) {
+		slog.Info("Skipping already processed message", "event_id", command.EventID, "client_msg_id", command.ClientMsgID)
+		deduplicatedMessages.Add(ctx, 1)
+		return nil
+	}
 	if err != nil {
 		slog.Error("Failed to store message", "error", err)
 		return fmt.Errorf("failed to store message: %w", err)
 	}
+	s.recordRedactions(ctx, conversation.ID, redactions)
+
+	channelContext, err := s.channelRepository.ChannelContext(ctx, conversation.TeamID, conversation.ChannelID)
+	if err != nil {
+		slog.Error("Failed to get channel context, continuing without it", "error", err, "channel", conversation.ChannelID)
+	}
 
 	agentRequest := domain.AgentRequest{
-		Conversation: conversation,
-		Message:      message,
-		PastMessages: pastMessages,
+		Conversation:   conversation,
+		Message:        message,
+		PastMessages:   pastMessages,
+		ChannelContext: channelContext,
+		UserID:         userID,
+		Model:          requestedModel,
+	}
+
+	if organizationID != uuid.Nil {
+		s.quotas.RecordUsage(ctx, organizationID, backend.QuotaMetricRequests, 1)
 	}
 
-	_, err = s.agentService.ProcessMessage(ctx, agentRequest)
+	startedAt := time.Now()
+	turnCtx, finishTurn := s.turns.start(ctx, command.Thread.Channel, command.Thread.ThreadTS, command.MessageTS)
+	response, err := s.agentService.ProcessMessage(turnCtx, agentRequest)
+	if cause := finishTurn(); cause != nil {
+		slog.Info("Agent turn cancelled", "conversation_id", conversation.ID, "reason", cause)
+		recordTurn(ctx, turnCancelled)
+		if errors.Is(cause, errHandedOff) {
+			return nil
+		}
+		if err := s.slackGateway.ReplyMessage(ctx, command.Thread, turnCancelledReply(cause)); err != nil {
+			return fmt.Errorf("failed to confirm cancellation: %w", err)
+		}
+		return nil
+	}
 	if err != nil {
 		slog.Error("Failed to process message with agent service", "error", err)
+		recordTurn(ctx, turnFailed)
+		return nil
+	}
+	s.recordTokens(ctx, organizationID, response.TokensUsed)
+	if response.Success {
+		recordTurn(ctx, turnCompleted)
+	} else {
+		recordTurn(ctx, turnFailed)
+	}
+	s.recordReportedTools(ctx, conversation.ID, response.ToolsUsed, startedAt)
+
+	if response.Handoff {
+		if _, err := s.escalate(ctx, conversation, handoffByAgent, "requested by the agent"); err != nil {
+			return fmt.Errorf("failed to hand off conversation: %w", err)
+		}
 	}
 
 	return nil
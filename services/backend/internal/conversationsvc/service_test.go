@@ -0,0 +1,152 @@
+package conversationsvc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domaintest"
+	"github.com/google/uuid"
+)
+
+type agentService struct {
+	domain.AgentService
+	requests   []domain.AgentRequest
+	tokensUsed int64
+}
+
+func (s *agentService) ProcessMessage(ctx context.Context, request domain.AgentRequest) (domain.AgentResponse, error) {
+	s.requests = append(s.requests, request)
+	return domain.AgentResponse{Success: true, TokensUsed: s.tokensUsed}, nil
+}
+
+type channelRepository struct {
+	domain.ChannelRepository
+}
+
+func (channelRepository) ChannelContext(ctx context.Context, teamID, channelID string) (backend.ChannelContext, error) {
+	return backend.ChannelContext{}, nil
+}
+
+func TestHandleUserCommandSkipsSlackRetries(t *testing.T) {
+	ctx := context.Background()
+	conversations := domaintest.NewConversationRepository()
+	agent := &agentService{}
+	svc := &Service{
+		integrationRepository:  workspaceRepository{},
+		conversationRepository: conversations,
+		channelRepository:      channelRepository{},
+		userMappingRepository:  &userMappingRepository{mappings: map[string]backend.SlackUserMapping{}},
+		agentService:           agent,
+		models:                 ModelConfig{Default: "gpt-4o"},
+	}
+
+	thread := domain.SlackThread{
+		Message:  "why is the api slow?",
+		Sender:   domain.SlackUser{ID: "U1"},
+		Channel:  "C1",
+		ThreadTS: "1700000000.000100",
+		TeamID:   "T1",
+	}
+	deliveries := []struct {
+		name    string
+		command domain.UserCommand
+	}{
+		{
+			name:    "first delivery",
+			command: domain.UserCommand{Thread: thread, MessageTS: thread.ThreadTS, MessageType: domain.MessageTypeAppMention, EventID: "Ev1", ClientMsgID: "c1"},
+		},
+		{
+			name:    "retry of the same event",
+			command: domain.UserCommand{Thread: thread, MessageTS: thread.ThreadTS, MessageType: domain.MessageTypeAppMention, EventID: "Ev1", ClientMsgID: "c1"},
+		},
+		{
+			name:    "same message delivered as another event",
+			command: domain.UserCommand{Thread: thread, MessageTS: thread.ThreadTS, MessageType: domain.MessageTypeChannel, EventID: "Ev2", ClientMsgID: "c1"},
+		},
+	}
+	for _, d := range deliveries {
+		if err := svc.handleUserCommand(ctx, d.command); err != nil {
+			t.Fatalf("handleUserCommand() for %s error = %v", d.name, err)
+		}
+	}
+
+	if len(agent.requests) != 1 {
+		t.Fatalf("agent called %d times, want once for the first delivery", len(agent.requests))
+	}
+	conversation, err := conversations.GetConversationByThread(ctx, thread.TeamID, thread.Channel, thread.ThreadTS)
+	if err != nil {
+		t.Fatalf("GetConversationByThread() error = %v", err)
+	}
+	messages, err := conversations.GetConversationHistory(ctx, conversation.ID)
+	if err != nil {
+		t.Fatalf("GetConversationHistory() error = %v", err)
+	}
+	if len(messages) != 1 || messages[0].SlackEventID != "Ev1" {
+		t.Errorf("stored messages = %+v, want only the first delivery", messages)
+	}
+	if got := agent.requests[0].Message.SlackEventID; got != "Ev1" {
+		t.Errorf("agent answered event %q, want the first delivery", got)
+	}
+}
+
+type featureFlags map[backend.FeatureFlag]bool
+
+func (f featureFlags) Enabled(ctx context.Context, organizationID uuid.UUID, flag backend.FeatureFlag) bool {
+	return f[flag]
+}
+
+func (f featureFlags) Value(ctx context.Context, organizationID uuid.UUID, flag backend.FeatureFlag) string {
+	return ""
+}
+
+func TestHandleUserCommandModel(t *testing.T) {
+	ctx := context.Background()
+	conversations := domaintest.NewConversationRepository()
+	agent := &agentService{}
+	orgID := uuid.New()
+	svc := &Service{
+		integrationRepository:  workspaceRepository{organizations: map[string]uuid.UUID{"T1": orgID}},
+		conversationRepository: conversations,
+		channelRepository:      channelRepository{},
+		userMappingRepository: &userMappingRepository{mappings: map[string]backend.SlackUserMapping{
+			"T1/U1": {TeamID: "T1", SlackUserID: "U1", OrganizationID: orgID, UserID: uuid.New()},
+		}},
+		agentService: agent,
+		models:       ModelConfig{Default: "gpt-4o", Allowed: []string{"gpt-4o", "gpt-4o-mini"}},
+		featureFlags: featureFlags{backend.FeatureFlagModelSelection: true},
+	}
+
+	for i, text := range []string{"why is the api slow?", "--model gpt-4o-mini and now?"} {
+		thread := domain.SlackThread{Message: text, Sender: domain.SlackUser{ID: "U1"}, Channel: "C1", ThreadTS: fmt.Sprintf("1700000000.00010%d", i), TeamID: "T1"}
+		command := domain.UserCommand{Thread: thread, MessageTS: thread.ThreadTS, MessageType: domain.MessageTypeAppMention, EventID: fmt.Sprintf("Ev%d", i)}
+		if err := svc.handleUserCommand(ctx, command); err != nil {
+			t.Fatalf("handleUserCommand(%q) error = %v", text, err)
+		}
+	}
+
+	if len(agent.requests) != 2 {
+		t.Fatalf("agent called %d times, want 2", len(agent.requests))
+	}
+	tests := []struct {
+		name          string
+		request       domain.AgentRequest
+		wantRequested string
+		wantRecorded  string
+	}{
+		{name: "no model requested", request: agent.requests[0], wantRequested: "", wantRecorded: "gpt-4o"},
+		{name: "model requested", request: agent.requests[1], wantRequested: "gpt-4o-mini", wantRecorded: "gpt-4o-mini"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.request.Model != tt.wantRequested {
+				t.Errorf("model sent to the agent = %q, want %q", tt.request.Model, tt.wantRequested)
+			}
+			if tt.request.Message.Model != tt.wantRecorded {
+				t.Errorf("recorded model = %q, want %q", tt.request.Message.Model, tt.wantRecorded)
+			}
+		})
+	}
+}
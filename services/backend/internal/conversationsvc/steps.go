@@ -0,0 +1,204 @@
+package conversationsvc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/google/uuid"
+)
+
+// maxStepResultLength caps the output kept for each step, which is enough to
+// see why a command failed without storing whole log dumps.
+const maxStepResultLength = 4096
+
+var _ backend.ConversationStepListener = (*Service)(nil)
+
+func (s *Service) ConversationSteps(ctx context.Context, query backend.ConversationStepsQuery) ([]backend.ConversationStep, error) {
+	if _, err := s.historyRepository.Conversation(ctx, query.ConversationID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, httperrors.NotFound("conversation not found")
+		}
+		return nil, fmt.Errorf("failed to get conversation: %w", err)
+	}
+
+	steps, err := s.historyRepository.Steps(ctx, query.ConversationID, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conversation steps: %w", err)
+	}
+	return steps, nil
+}
+
+// ConversationHistory returns the conversation's messages, oldest first, with
+// each agent turn's steps attached to the user message that started it.
+func (s *Service) ConversationHistory(ctx context.Context, query backend.ConversationHistoryQuery) ([]backend.ConversationMessage, error) {
+	if _, err := s.organizationConversation(ctx, query.ConversationID, query.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	history, err := s.historyRepository.GetConversationHistory(ctx, query.ConversationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversation history: %w", err)
+	}
+	steps, err := s.historyRepository.Steps(ctx, query.ConversationID, time.Time{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conversation steps: %w", err)
+	}
+	return turnHistory(history, steps), nil
+}
+
+// turnHistory attaches each step to the latest user message created at or
+// before the step started. Steps older than every user message go to the
+// first one so none are lost.
+func turnHistory(history []domain.Message, steps []backend.ConversationStep) []backend.ConversationMessage {
+	messages := make([]backend.ConversationMessage, len(history))
+	for i, m := range history {
+		messages[i] = backend.ConversationMessage{
+			ID:         m.ID,
+			SenderID:   m.Sender.ID,
+			SenderName: m.Sender.Name,
+			Text:       m.MessageText,
+			IsBot:      m.IsBotMessage,
+			CreatedAt:  m.CreatedAt,
+		}
+	}
+	slices.SortStableFunc(messages, func(a, b backend.ConversationMessage) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	for _, step := range steps {
+		turn := -1
+		for i, m := range messages {
+			if m.IsBot {
+				continue
+			}
+			if turn == -1 || !m.CreatedAt.After(step.StartedAt) {
+				turn = i
+			}
+			if m.CreatedAt.After(step.StartedAt) {
+				break
+			}
+		}
+		if turn >= 0 {
+			messages[turn].Steps = append(messages[turn].Steps, step)
+		}
+	}
+	return messages
+}
+
+// ConversationStepTaken stores a step with its output redacted the same way as
+// the conversation's messages. Arguments are also redacted by flag name, as
+// the logs are.
+func (s *Service) ConversationStepTaken(ctx context.Context, step backend.ConversationStep) {
+	conversation, err := s.conversationRepository.Conversation(ctx, step.ConversationID)
+	if err != nil {
+		slog.Error("Failed to get conversation for step", "conversation_id", step.ConversationID, "error", err)
+		return
+	}
+
+	organizationID := s.teamOrganization(ctx, conversation.TeamID)
+	step.Arguments, _ = s.redactor.RedactArguments(ctx, organizationID, slices.Clone(step.Arguments))
+	step.Result, _ = s.redactor.Redact(ctx, organizationID, step.Result)
+	if len(step.Result) > maxStepResultLength {
+		step.Result = strings.ToValidUTF8(step.Result[:maxStepResultLength], "")
+		step.ResultTruncated = true
+	}
+
+	if err := s.conversationRepository.StoreStep(ctx, step); err != nil {
+		slog.Error("Failed to store conversation step", "conversation_id", step.ConversationID, "tool", step.Tool, "error", err)
+	}
+}
+
+// recordReportedTools stores the tools the agent said it used for a message.
+// The agent only reports their names, so nothing else is known about them.
+func (s *Service) recordReportedTools(ctx context.Context, conversationID uuid.UUID, tools []string, startedAt time.Time) {
+	duration := time.Since(startedAt)
+	for _, tool := range tools {
+		s.ConversationStepTaken(ctx, backend.ConversationStep{
+			ID:             uuid.New(),
+			ConversationID: conversationID,
+			Tool:           tool,
+			Duration:       duration,
+			Outcome:        backend.ConversationStepReported,
+			StartedAt:      startedAt,
+		})
+	}
+}
+
+// stepFooter summarizes the tools used since the latest user message, e.g.
+// "_What I did: kubectl ×2, gcloud_", for organizations with
+// backend.FeatureFlagToolFooter turned on. It is empty when there is nothing
+// to show.
+func (s *Service) stepFooter(ctx context.Context, conversation domain.Conversation, steps []backend.ConversationStep) string {
+	if s.featureFlags == nil {
+		return ""
+	}
+	businessID, err := s.integrationRepository.BusinessIDByProviderProjectID(ctx, backend.ConnectorTypeSlack, conversation.TeamID)
+	if err != nil || !s.featureFlags.Enabled(ctx, businessID, backend.FeatureFlagToolFooter) {
+		return ""
+	}
+	return formatStepFooter(steps)
+}
+
+// turnSteps returns the steps taken since the latest user message.
+func (s *Service) turnSteps(ctx context.Context, conversation domain.Conversation) ([]backend.ConversationStep, error) {
+	history, err := s.conversationRepository.GetConversationHistory(ctx, conversation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversation history: %w", err)
+	}
+	var since time.Time
+	for _, message := range history {
+		if !message.IsBotMessage && message.CreatedAt.After(since) {
+			since = message.CreatedAt
+		}
+	}
+
+	steps, err := s.conversationRepository.Steps(ctx, conversation.ID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conversation steps: %w", err)
+	}
+	return steps, nil
+}
+
+func hasReadOnlyStep(steps []backend.ConversationStep) bool {
+	return slices.ContainsFunc(steps, func(step backend.ConversationStep) bool {
+		return step.Outcome == backend.ConversationStepReadOnly
+	})
+}
+
+func formatStepFooter(steps []backend.ConversationStep) string {
+	if len(steps) == 0 {
+		return ""
+	}
+	return "_What I did: " + summarizeTools(steps) + "_"
+}
+
+// summarizeTools lists the tools used in first-use order, counting repeats, as
+// in "kubectl ×2, gcloud".
+func summarizeTools(steps []backend.ConversationStep) string {
+	var tools []string
+	counts := make(map[string]int)
+	for _, step := range steps {
+		if counts[step.Tool] == 0 {
+			tools = append(tools, step.Tool)
+		}
+		counts[step.Tool]++
+	}
+
+	parts := make([]string, len(tools))
+	for i, tool := range tools {
+		parts[i] = tool
+		if counts[tool] > 1 {
+			parts[i] = fmt.Sprintf("%s ×%d", tool, counts[tool])
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,65 @@
+package conversationsvc
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+)
+
+func TestFormatStepFooter(t *testing.T) {
+	tests := []struct {
+		name  string
+		tools []string
+		want  string
+	}{
+		{name: "no steps", want: ""},
+		{name: "single tool", tools: []string{"gcloud"}, want: "_What I did: gcloud_"},
+		{name: "repeated tools are counted in first-use order", tools: []string{"kubectl", "gcloud", "kubectl"}, want: "_What I did: kubectl ×2, gcloud_"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var steps []backend.ConversationStep
+			for _, tool := range tt.tools {
+				steps = append(steps, backend.ConversationStep{Tool: tool})
+			}
+			if got := formatStepFooter(steps); got != tt.want {
+				t.Errorf("formatStepFooter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTurnHistory(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+
+	history := []domain.Message{
+		{MessageText: "why is payments down?", CreatedAt: at(0)},
+		{MessageText: "the pod is OOMKilled", IsBotMessage: true, CreatedAt: at(20)},
+		{MessageText: "raise the limit", CreatedAt: at(30)},
+		{MessageText: "done", IsBotMessage: true, CreatedAt: at(50)},
+	}
+	steps := []backend.ConversationStep{
+		{Tool: "kubectl", StartedAt: at(5)},
+		{Tool: "gcloud", StartedAt: at(10)},
+		{Tool: "kubectl", StartedAt: at(35)},
+	}
+
+	messages := turnHistory(history, steps)
+	var got [][]string
+	for _, m := range messages {
+		var tools []string
+		for _, step := range m.Steps {
+			tools = append(tools, step.Tool)
+		}
+		got = append(got, tools)
+	}
+	want := [][]string{{"kubectl", "gcloud"}, nil, {"kubectl"}, nil}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("turnHistory() steps per message = %v, want %v", got, want)
+	}
+}
@@ -2,14 +2,22 @@ package agent
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"time"
 
 	agent "github.com/73ai/infragpt/services/agent/src/client/go"
 	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// handoffAgentType is the agent type of responses in which the agent asks for
+// a human to take over.
+const handoffAgentType = "handoff"
+
 // Client wraps the agent gRPC client to implement domain.AgentService
 type Client struct {
 	agentClient *agent.Client
@@ -29,6 +37,12 @@ func NewClient(config *agent.Config) (*Client, error) {
 
 // ProcessMessage implements domain.AgentService interface
 func (c *Client) ProcessMessage(ctx context.Context, request domain.AgentRequest) (domain.AgentResponse, error) {
+	ctx, span := tracing.Start(ctx, "agent.process_message",
+		attribute.String("conversation.id", request.Conversation.ID.String()),
+		attribute.String("slack.channel_id", request.Conversation.ChannelID),
+		attribute.String("llm.model", request.Model))
+	defer span.End()
+
 	// Convert domain models to agent protobuf models
 	agentReq, err := c.convertToAgentRequest(request)
 	if err != nil {
@@ -40,7 +54,11 @@ func (c *Client) ProcessMessage(ctx context.Context, request domain.AgentRequest
 
 	// Call the Python agent service
 	resp, err := c.agentClient.ProcessMessage(ctx, agentReq)
+	if err != nil && ctx.Err() != nil {
+		return domain.AgentResponse{}, fmt.Errorf("agent request cancelled: %w", context.Cause(ctx))
+	}
 	if err != nil {
+		span.RecordError(err)
 		log.Printf("Agent service error: %v", err)
 		return domain.AgentResponse{
 			Success:      false,
@@ -53,9 +71,45 @@ func (c *Client) ProcessMessage(ctx context.Context, request domain.AgentRequest
 		ResponseText: resp.ResponseText,
 		Success:      resp.Success,
 		ErrorMessage: resp.ErrorMessage,
+		ToolsUsed:    resp.ToolsUsed,
+		TokensUsed:   resp.TokensUsed,
+		Handoff:      resp.AgentType == handoffAgentType,
 	}, nil
 }
 
+// ParseReply implements domain.AgentService interface
+func (c *Client) ParseReply(reply string) (domain.AgentReply, error) {
+	envelope, err := agent.ParseEnvelope(reply)
+	if err != nil {
+		return domain.AgentReply{}, err
+	}
+
+	parsed := domain.AgentReply{
+		Kind:      domain.ReplyKind(envelope.Kind),
+		Text:      envelope.Text,
+		Questions: envelope.Questions,
+	}
+	if envelope.Plan != nil {
+		parsed.Plan = &domain.Plan{
+			AffectedResources: envelope.Plan.AffectedResources,
+			RiskLevel:         string(envelope.Plan.RiskLevel),
+			RollbackNotes:     envelope.Plan.RollbackNotes,
+		}
+		for _, step := range envelope.Plan.Steps {
+			parsed.Plan.Steps = append(parsed.Plan.Steps, domain.PlanStep{
+				Description: step.Description,
+				Command:     step.Command,
+			})
+		}
+	}
+	return parsed, nil
+}
+
+// Ready waits until the agent service is reachable, or ctx is done.
+func (c *Client) Ready(ctx context.Context) error {
+	return c.agentClient.Ready(ctx)
+}
+
 // Close closes the connection to the agent service
 func (c *Client) Close() error {
 	if c.agentClient != nil {
@@ -83,11 +137,36 @@ func (c *Client) convertToAgentRequest(req domain.AgentRequest) (agent.AgentRequ
 		})
 	}
 
+	contextFields := make(map[string]any)
+	if len(req.ChannelContext.Repositories) > 0 {
+		contextFields["repositories"] = req.ChannelContext.Repositories
+	}
+	if len(req.ChannelContext.KubernetesNamespaces) > 0 {
+		contextFields["kubernetes_namespaces"] = req.ChannelContext.KubernetesNamespaces
+	}
+	if len(req.ChannelContext.GCPProjects) > 0 {
+		contextFields["gcp_projects"] = req.ChannelContext.GCPProjects
+	}
+	if req.UserID != uuid.Nil {
+		contextFields["user_id"] = req.UserID.String()
+	}
+
+	var requestContext string
+	if len(contextFields) > 0 {
+		encoded, err := json.Marshal(contextFields)
+		if err != nil {
+			return agent.AgentRequest{}, fmt.Errorf("failed to encode request context: %w", err)
+		}
+		requestContext = string(encoded)
+	}
+
 	return agent.AgentRequest{
 		ConversationId: req.Message.ConversationID.String(),
 		CurrentMessage: req.Message.MessageText,
 		PastMessages:   pastMessages,
+		Context:        requestContext,
 		UserId:         req.Message.Sender.Name,
 		ChannelId:      req.Conversation.ChannelID,
+		Model:          req.Model,
 	}, nil
 }
@@ -7,11 +7,13 @@ import (
 
 	"github.com/73ai/infragpt/services/backend"
 	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
 	"github.com/google/uuid"
 )
 
 type BackendDB struct {
-	db *sql.DB
+	db     *sql.DB
+	readDB *sql.DB
 	Querier
 }
 
@@ -19,6 +21,27 @@ func (i *BackendDB) DB() *sql.DB {
 	return i.db
 }
 
+// ReadDB returns the read replica, or the primary when no replica is
+// configured.
+func (i *BackendDB) ReadDB() *sql.DB {
+	if i.readDB == nil {
+		return i.db
+	}
+	return i.readDB
+}
+
+// ReadReplica returns repositories that query the read replica. Replicas lag
+// the primary, so use it only for reads that need not see the caller's own
+// writes.
+func (i *BackendDB) ReadReplica() *BackendDB {
+	readDB := i.ReadDB()
+	return &BackendDB{
+		db:      readDB,
+		readDB:  readDB,
+		Querier: New(pgretry.Wrap(readDB)),
+	}
+}
+
 var _ domain.WorkSpaceTokenRepository = (*BackendDB)(nil)
 var _ domain.IntegrationRepository = (*BackendDB)(nil)
 var _ domain.ConversationRepository = (*BackendDB)(nil)
@@ -44,6 +67,17 @@ func (i BackendDB) GetToken(ctx context.Context, teamID string) (string, error)
 	return token, nil
 }
 
+func (i BackendDB) LinkWorkspace(ctx context.Context, teamID, enterpriseID string) error {
+	err := i.linkSlackWorkspace(ctx, linkSlackWorkspaceParams{
+		TeamID:       teamID,
+		EnterpriseID: enterpriseID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to link slack workspace: %w", err)
+	}
+	return nil
+}
+
 func (i BackendDB) Integrations(ctx context.Context, businessID uuid.UUID) ([]domain.Integration, error) {
 	is, err := i.integrations(ctx, businessID)
 	if err != nil {
@@ -65,6 +99,17 @@ func (i BackendDB) Integrations(ctx context.Context, businessID uuid.UUID) ([]do
 	return integrations, nil
 }
 
+func (i BackendDB) BusinessIDByProviderProjectID(ctx context.Context, provider backend.ConnectorType, providerProjectID string) (uuid.UUID, error) {
+	businessID, err := i.businessIDByProviderProjectID(ctx, businessIDByProviderProjectIDParams{
+		Provider:          string(provider),
+		ProviderProjectID: providerProjectID,
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to get business id: %w", err)
+	}
+	return businessID, nil
+}
+
 func (i BackendDB) SaveIntegration(ctx context.Context, integration domain.Integration) error {
 	bid := uuid.MustParse(integration.BusinessID)
 	err := i.saveIntegration(ctx, saveIntegrationParams{
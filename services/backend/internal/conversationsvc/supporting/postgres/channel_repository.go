@@ -3,8 +3,11 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/73ai/infragpt/services/backend"
 	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
 )
 
@@ -74,4 +77,97 @@ func (db *BackendDB) IsChannelMonitored(ctx context.Context, teamID, channelID s
 	return isMonitored, nil
 }
 
+func (db *BackendDB) SetChannelContext(ctx context.Context, channelContext backend.ChannelContext) error {
+	if len(channelContext.Repositories) == 0 && len(channelContext.KubernetesNamespaces) == 0 && len(channelContext.GCPProjects) == 0 {
+		err := db.Querier.DeleteChannelContext(ctx, DeleteChannelContextParams{
+			TeamID:    channelContext.TeamID,
+			ChannelID: channelContext.ChannelID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to delete channel context: %w", err)
+		}
+		return nil
+	}
+
+	err := db.Querier.SetChannelContext(ctx, SetChannelContextParams{
+		TeamID:               channelContext.TeamID,
+		ChannelID:            channelContext.ChannelID,
+		Repositories:         nonNil(channelContext.Repositories),
+		KubernetesNamespaces: nonNil(channelContext.KubernetesNamespaces),
+		GcpProjects:          nonNil(channelContext.GCPProjects),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set channel context: %w", err)
+	}
+
+	return nil
+}
+
+func (db *BackendDB) ChannelContext(ctx context.Context, teamID, channelID string) (backend.ChannelContext, error) {
+	dbContext, err := db.Querier.ChannelContext(ctx, ChannelContextParams{
+		TeamID:    teamID,
+		ChannelID: channelID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return backend.ChannelContext{TeamID: teamID, ChannelID: channelID}, nil
+		}
+		return backend.ChannelContext{}, fmt.Errorf("failed to get channel context: %w", err)
+	}
+
+	return backend.ChannelContext{
+		TeamID:               dbContext.TeamID,
+		ChannelID:            dbContext.ChannelID,
+		Repositories:         dbContext.Repositories,
+		KubernetesNamespaces: dbContext.KubernetesNamespaces,
+		GCPProjects:          dbContext.GcpProjects,
+		UpdatedAt:            dbContext.UpdatedAt,
+	}, nil
+}
+
+func (db *BackendDB) ChannelContexts(ctx context.Context, teamID string) ([]backend.ChannelContext, error) {
+	rows, err := db.Querier.ChannelContextsByTeam(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channel contexts: %w", err)
+	}
+
+	contexts := make([]backend.ChannelContext, len(rows))
+	for i, row := range rows {
+		contexts[i] = backend.ChannelContext{
+			TeamID:               row.TeamID,
+			ChannelID:            row.ChannelID,
+			ChannelName:          row.ChannelName.String,
+			Repositories:         row.Repositories,
+			KubernetesNamespaces: row.KubernetesNamespaces,
+			GCPProjects:          row.GcpProjects,
+			UpdatedAt:            row.UpdatedAt,
+		}
+	}
+
+	return contexts, nil
+}
+
+// nonNil keeps NOT NULL array columns from receiving a NULL.
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
 var _ domain.ChannelRepository = (*BackendDB)(nil)
+
+func (db *BackendDB) ClaimChannelIntro(ctx context.Context, teamID, channelID string, postedBefore time.Time) (bool, error) {
+	_, err := db.Querier.ClaimChannelIntro(ctx, ClaimChannelIntroParams{
+		TeamID:       teamID,
+		ChannelID:    channelID,
+		PostedBefore: postedBefore,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim channel intro: %w", err)
+	}
+	return true, nil
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
 	"github.com/73ai/infragpt/services/backend/internal/generic/postgresconfig"
 	_ "github.com/lib/pq"
 )
@@ -14,9 +15,14 @@ func (c Config) New() (*BackendDB, error) {
 	if err != nil {
 		return nil, err
 	}
+	readDB, err := c.InitReadReplica(db)
+	if err != nil {
+		return nil, err
+	}
 
 	return &BackendDB{
 		db:      db,
-		Querier: New(db),
+		readDB:  readDB,
+		Querier: New(pgretry.Wrap(db)),
 	}, nil
 }
@@ -30,8 +30,26 @@ func (q *Queries) AddChannel(ctx context.Context, arg AddChannelParams) error {
 	return err
 }
 
+const addConversationRedactions = `-- name: AddConversationRedactions :exec
+INSERT INTO conversation_redactions (conversation_id, redaction_count)
+VALUES ($1, $2)
+ON CONFLICT (conversation_id) DO UPDATE
+SET redaction_count = conversation_redactions.redaction_count + EXCLUDED.redaction_count,
+    updated_at = NOW()
+`
+
+type AddConversationRedactionsParams struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	RedactionCount int32     `json:"redaction_count"`
+}
+
+func (q *Queries) AddConversationRedactions(ctx context.Context, arg AddConversationRedactionsParams) error {
+	_, err := q.exec(ctx, q.addConversationRedactionsStmt, addConversationRedactions, arg.ConversationID, arg.RedactionCount)
+	return err
+}
+
 const conversation = `-- name: Conversation :one
-SELECT conversation_id, team_id, channel_id, thread_ts, created_at, updated_at from conversations
+SELECT conversation_id, team_id, channel_id, thread_ts, created_at, updated_at, status from conversations
 WHERE conversation_id = $1
 `
 
@@ -45,14 +63,38 @@ func (q *Queries) Conversation(ctx context.Context, conversationID uuid.UUID) (C
 		&i.ThreadTs,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
 	)
 	return i, err
 }
 
+const countRetriedMessages = `-- name: CountRetriedMessages :one
+SELECT COUNT(*) FROM messages m
+WHERE NOT m.is_bot_message
+  AND m.slack_event_id IS NULL
+  AND m.client_msg_id IS NULL
+  AND EXISTS (
+    SELECT 1 FROM messages earlier
+    WHERE earlier.conversation_id = m.conversation_id
+      AND earlier.sender_user_id = m.sender_user_id
+      AND earlier.message_text = m.message_text
+      AND NOT earlier.is_bot_message
+      AND (m.created_at, m.message_id) > (earlier.created_at, earlier.message_id)
+      AND m.created_at - earlier.created_at < $1::int * INTERVAL '1 second'
+  )
+`
+
+func (q *Queries) CountRetriedMessages(ctx context.Context, windowSeconds int32) (int64, error) {
+	row := q.queryRow(ctx, q.countRetriedMessagesStmt, countRetriedMessages, windowSeconds)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createConversation = `-- name: CreateConversation :one
 INSERT INTO conversations (team_id, channel_id, thread_ts)
 VALUES ($1, $2, $3)
-RETURNING conversation_id, team_id, channel_id, thread_ts, created_at, updated_at
+RETURNING conversation_id, team_id, channel_id, thread_ts, created_at, updated_at, status
 `
 
 type CreateConversationParams struct {
@@ -71,12 +113,37 @@ func (q *Queries) CreateConversation(ctx context.Context, arg CreateConversation
 		&i.ThreadTs,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
 	)
 	return i, err
 }
 
+const deleteRetriedMessages = `-- name: DeleteRetriedMessages :execrows
+DELETE FROM messages m
+WHERE NOT m.is_bot_message
+  AND m.slack_event_id IS NULL
+  AND m.client_msg_id IS NULL
+  AND EXISTS (
+    SELECT 1 FROM messages earlier
+    WHERE earlier.conversation_id = m.conversation_id
+      AND earlier.sender_user_id = m.sender_user_id
+      AND earlier.message_text = m.message_text
+      AND NOT earlier.is_bot_message
+      AND (m.created_at, m.message_id) > (earlier.created_at, earlier.message_id)
+      AND m.created_at - earlier.created_at < $1::int * INTERVAL '1 second'
+  )
+`
+
+func (q *Queries) DeleteRetriedMessages(ctx context.Context, windowSeconds int32) (int64, error) {
+	result, err := q.exec(ctx, q.deleteRetriedMessagesStmt, deleteRetriedMessages, windowSeconds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getConversationByThread = `-- name: GetConversationByThread :one
-SELECT conversation_id, team_id, channel_id, thread_ts, created_at, updated_at
+SELECT conversation_id, team_id, channel_id, thread_ts, created_at, updated_at, status
 FROM conversations
 WHERE team_id = $1 AND channel_id = $2 AND thread_ts = $3
 `
@@ -97,12 +164,13 @@ func (q *Queries) GetConversationByThread(ctx context.Context, arg GetConversati
 		&i.ThreadTs,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Status,
 	)
 	return i, err
 }
 
 const getConversationHistory = `-- name: GetConversationHistory :many
-SELECT message_id, conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message, created_at
+SELECT message_id, conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message, created_at, model, slack_event_id, client_msg_id
 FROM messages
 WHERE conversation_id = $1
 ORDER BY created_at ASC
@@ -128,6 +196,9 @@ func (q *Queries) GetConversationHistory(ctx context.Context, conversationID uui
 			&i.MessageText,
 			&i.IsBotMessage,
 			&i.CreatedAt,
+			&i.Model,
+			&i.SlackEventID,
+			&i.ClientMsgID,
 		); err != nil {
 			return nil, err
 		}
@@ -143,7 +214,7 @@ func (q *Queries) GetConversationHistory(ctx context.Context, conversationID uui
 }
 
 const getConversationHistoryDesc = `-- name: GetConversationHistoryDesc :many
-SELECT message_id, conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message, created_at
+SELECT message_id, conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message, created_at, model, slack_event_id, client_msg_id
 FROM messages
 WHERE conversation_id = $1
 ORDER BY created_at DESC
@@ -175,6 +246,9 @@ func (q *Queries) GetConversationHistoryDesc(ctx context.Context, arg GetConvers
 			&i.MessageText,
 			&i.IsBotMessage,
 			&i.CreatedAt,
+			&i.Model,
+			&i.SlackEventID,
+			&i.ClientMsgID,
 		); err != nil {
 			return nil, err
 		}
@@ -243,7 +317,7 @@ func (q *Queries) IsChannelMonitored(ctx context.Context, arg IsChannelMonitored
 }
 
 const messageBySlackTS = `-- name: MessageBySlackTS :one
-SELECT message_id, conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message, created_at
+SELECT message_id, conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message, created_at, model, slack_event_id, client_msg_id
 FROM messages
 WHERE conversation_id = $1 AND slack_message_ts = $2 AND sender_user_id = $3
 `
@@ -268,10 +342,60 @@ func (q *Queries) MessageBySlackTS(ctx context.Context, arg MessageBySlackTSPara
 		&i.MessageText,
 		&i.IsBotMessage,
 		&i.CreatedAt,
+		&i.Model,
+		&i.SlackEventID,
+		&i.ClientMsgID,
 	)
 	return i, err
 }
 
+const recentConversationsByParticipant = `-- name: RecentConversationsByParticipant :many
+SELECT c.conversation_id, c.team_id, c.channel_id, c.thread_ts, c.created_at, c.updated_at, c.status
+FROM conversations c
+JOIN messages m ON m.conversation_id = c.conversation_id
+WHERE c.team_id = $1 AND m.sender_user_id = $2
+GROUP BY c.conversation_id
+ORDER BY MAX(m.created_at) DESC
+LIMIT $3
+`
+
+type RecentConversationsByParticipantParams struct {
+	TeamID       string `json:"team_id"`
+	SenderUserID string `json:"sender_user_id"`
+	Limit        int32  `json:"limit"`
+}
+
+func (q *Queries) RecentConversationsByParticipant(ctx context.Context, arg RecentConversationsByParticipantParams) ([]Conversation, error) {
+	rows, err := q.query(ctx, q.recentConversationsByParticipantStmt, recentConversationsByParticipant, arg.TeamID, arg.SenderUserID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Conversation
+	for rows.Next() {
+		var i Conversation
+		if err := rows.Scan(
+			&i.ConversationID,
+			&i.TeamID,
+			&i.ChannelID,
+			&i.ThreadTs,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const setChannelMonitoring = `-- name: SetChannelMonitoring :exec
 UPDATE channels
 SET is_monitored = $3
@@ -290,9 +414,10 @@ func (q *Queries) SetChannelMonitoring(ctx context.Context, arg SetChannelMonito
 }
 
 const storeMessage = `-- name: StoreMessage :one
-INSERT INTO messages (conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING message_id, conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message, created_at
+INSERT INTO messages (conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message, model, slack_event_id, client_msg_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT DO NOTHING
+RETURNING message_id, conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message, created_at, model, slack_event_id, client_msg_id
 `
 
 type StoreMessageParams struct {
@@ -304,6 +429,9 @@ type StoreMessageParams struct {
 	SenderName     sql.NullString `json:"sender_name"`
 	MessageText    string         `json:"message_text"`
 	IsBotMessage   bool           `json:"is_bot_message"`
+	Model          sql.NullString `json:"model"`
+	SlackEventID   sql.NullString `json:"slack_event_id"`
+	ClientMsgID    sql.NullString `json:"client_msg_id"`
 }
 
 func (q *Queries) StoreMessage(ctx context.Context, arg StoreMessageParams) (Message, error) {
@@ -316,6 +444,9 @@ func (q *Queries) StoreMessage(ctx context.Context, arg StoreMessageParams) (Mes
 		arg.SenderName,
 		arg.MessageText,
 		arg.IsBotMessage,
+		arg.Model,
+		arg.SlackEventID,
+		arg.ClientMsgID,
 	)
 	var i Message
 	err := row.Scan(
@@ -329,6 +460,9 @@ func (q *Queries) StoreMessage(ctx context.Context, arg StoreMessageParams) (Mes
 		&i.MessageText,
 		&i.IsBotMessage,
 		&i.CreatedAt,
+		&i.Model,
+		&i.SlackEventID,
+		&i.ClientMsgID,
 	)
 	return i, err
 }
@@ -5,7 +5,9 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/73ai/infragpt/services/backend"
 	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
 	"github.com/google/uuid"
 )
@@ -25,11 +27,37 @@ func (db *BackendDB) GetConversationByThread(ctx context.Context, teamID, channe
 		TeamID:    dbConversation.TeamID,
 		ChannelID: dbConversation.ChannelID,
 		ThreadTS:  dbConversation.ThreadTs,
+		Status:    backend.ConversationStatus(dbConversation.Status),
 		CreatedAt: dbConversation.CreatedAt,
 		UpdatedAt: dbConversation.UpdatedAt,
 	}, nil
 }
 
+func (db *BackendDB) RecentConversations(ctx context.Context, teamID, userID string, limit int) ([]domain.Conversation, error) {
+	dbConversations, err := db.Querier.RecentConversationsByParticipant(ctx, RecentConversationsByParticipantParams{
+		TeamID:       teamID,
+		SenderUserID: userID,
+		Limit:        int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent conversations: %w", err)
+	}
+
+	conversations := make([]domain.Conversation, len(dbConversations))
+	for i, c := range dbConversations {
+		conversations[i] = domain.Conversation{
+			ID:        c.ConversationID,
+			TeamID:    c.TeamID,
+			ChannelID: c.ChannelID,
+			ThreadTS:  c.ThreadTs,
+			Status:    backend.ConversationStatus(c.Status),
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+		}
+	}
+	return conversations, nil
+}
+
 func (db *BackendDB) CreateConversation(ctx context.Context, teamID, channelID, threadTS string) (domain.Conversation, error) {
 	dbConversation, err := db.Querier.CreateConversation(ctx, CreateConversationParams{
 		TeamID:    teamID,
@@ -45,6 +73,7 @@ func (db *BackendDB) CreateConversation(ctx context.Context, teamID, channelID,
 		TeamID:    dbConversation.TeamID,
 		ChannelID: dbConversation.ChannelID,
 		ThreadTS:  dbConversation.ThreadTs,
+		Status:    backend.ConversationStatus(dbConversation.Status),
 		CreatedAt: dbConversation.CreatedAt,
 		UpdatedAt: dbConversation.UpdatedAt,
 	}, nil
@@ -72,7 +101,13 @@ func (db *BackendDB) StoreMessage(ctx context.Context, conversationID uuid.UUID,
 		SenderName:     senderName,
 		MessageText:    message.MessageText,
 		IsBotMessage:   message.IsBotMessage,
+		Model:          sql.NullString{String: message.Model, Valid: message.Model != ""},
+		SlackEventID:   sql.NullString{String: message.SlackEventID, Valid: message.SlackEventID != ""},
+		ClientMsgID:    sql.NullString{String: message.ClientMsgID, Valid: message.ClientMsgID != ""},
 	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Message{}, domain.ErrDuplicateMessage
+	}
 	if err != nil {
 		return domain.Message{}, fmt.Errorf("failed to store message: %w", err)
 	}
@@ -89,6 +124,9 @@ func (db *BackendDB) StoreMessage(ctx context.Context, conversationID uuid.UUID,
 		},
 		MessageText:  dbMessage.MessageText,
 		IsBotMessage: dbMessage.IsBotMessage,
+		Model:        dbMessage.Model.String,
+		SlackEventID: dbMessage.SlackEventID.String,
+		ClientMsgID:  dbMessage.ClientMsgID.String,
 		CreatedAt:    dbMessage.CreatedAt,
 	}, nil
 }
@@ -113,6 +151,7 @@ func (db *BackendDB) GetConversationHistory(ctx context.Context, conversationID
 			},
 			MessageText:  dbMsg.MessageText,
 			IsBotMessage: dbMsg.IsBotMessage,
+			Model:        dbMsg.Model.String,
 			CreatedAt:    dbMsg.CreatedAt,
 		}
 	}
@@ -145,6 +184,9 @@ func (db *BackendDB) MessageBySlackTS(ctx context.Context, conversationID uuid.U
 		},
 		MessageText:  dbMessage.MessageText,
 		IsBotMessage: dbMessage.IsBotMessage,
+		Model:        dbMessage.Model.String,
+		SlackEventID: dbMessage.SlackEventID.String,
+		ClientMsgID:  dbMessage.ClientMsgID.String,
 		CreatedAt:    dbMessage.CreatedAt,
 	}, nil
 }
@@ -163,9 +205,43 @@ func (db *BackendDB) Conversation(ctx context.Context, conversationID uuid.UUID)
 		TeamID:    dbConversation.TeamID,
 		ChannelID: dbConversation.ChannelID,
 		ThreadTS:  dbConversation.ThreadTs,
+		Status:    backend.ConversationStatus(dbConversation.Status),
 		CreatedAt: dbConversation.CreatedAt,
 		UpdatedAt: dbConversation.UpdatedAt,
 	}, nil
 }
 
+func (db *BackendDB) AddRedactions(ctx context.Context, conversationID uuid.UUID, count int) error {
+	err := db.Querier.AddConversationRedactions(ctx, AddConversationRedactionsParams{
+		ConversationID: conversationID,
+		RedactionCount: int32(count),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add conversation redactions: %w", err)
+	}
+	return nil
+}
+
+// RemoveRetriedMessages removes user messages that Slack retries stored more
+// than once before messages carried Slack event IDs: a later copy of the same
+// text from the same sender in the same conversation within window of an
+// earlier one. Messages with an event or client message ID are deduplicated
+// on insert and never removed. With dryRun set the copies are only counted.
+func (db *BackendDB) RemoveRetriedMessages(ctx context.Context, window time.Duration, dryRun bool) (int64, error) {
+	windowSeconds := int32(window / time.Second)
+	if dryRun {
+		count, err := db.Querier.CountRetriedMessages(ctx, windowSeconds)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count retried messages: %w", err)
+		}
+		return count, nil
+	}
+
+	removed, err := db.Querier.DeleteRetriedMessages(ctx, windowSeconds)
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove retried messages: %w", err)
+	}
+	return removed, nil
+}
+
 var _ domain.ConversationRepository = (*BackendDB)(nil)
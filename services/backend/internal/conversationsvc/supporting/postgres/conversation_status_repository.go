@@ -0,0 +1,41 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/google/uuid"
+)
+
+func (db *BackendDB) SetStatus(ctx context.Context, change backend.ConversationStatusChange) (bool, error) {
+	changed, err := db.Querier.SetConversationStatus(ctx, SetConversationStatusParams{
+		ConversationID: change.ConversationID,
+		Status:         string(change.Status),
+		ChangedBy:      change.ChangedBy,
+		Reason:         change.Reason,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to set conversation status: %w", err)
+	}
+	return changed > 0, nil
+}
+
+func (db *BackendDB) StatusChanges(ctx context.Context, conversationID uuid.UUID) ([]backend.ConversationStatusChange, error) {
+	rows, err := db.Querier.ConversationStatusChanges(ctx, conversationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversation status changes: %w", err)
+	}
+
+	changes := make([]backend.ConversationStatusChange, len(rows))
+	for i, row := range rows {
+		changes[i] = backend.ConversationStatusChange{
+			ConversationID: row.ConversationID,
+			Status:         backend.ConversationStatus(row.Status),
+			ChangedBy:      row.ChangedBy,
+			Reason:         row.Reason,
+			ChangedAt:      row.ChangedAt,
+		}
+	}
+	return changes, nil
+}
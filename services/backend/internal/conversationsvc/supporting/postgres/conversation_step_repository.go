@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/google/uuid"
+)
+
+func (db *BackendDB) StoreStep(ctx context.Context, step backend.ConversationStep) error {
+	err := db.Querier.StoreConversationStep(ctx, StoreConversationStepParams{
+		StepID:          step.ID,
+		ConversationID:  step.ConversationID,
+		Tool:            step.Tool,
+		Arguments:       step.Arguments,
+		DurationMs:      step.Duration.Milliseconds(),
+		Outcome:         string(step.Outcome),
+		Result:          step.Result,
+		ResultTruncated: step.ResultTruncated,
+		StartedAt:       step.StartedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store conversation step: %w", err)
+	}
+	return nil
+}
+
+func (db *BackendDB) Steps(ctx context.Context, conversationID uuid.UUID, since time.Time) ([]backend.ConversationStep, error) {
+	rows, err := db.Querier.ConversationSteps(ctx, ConversationStepsParams{
+		ConversationID: conversationID,
+		StartedAt:      since,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get conversation steps: %w", err)
+	}
+
+	steps := make([]backend.ConversationStep, len(rows))
+	for i, row := range rows {
+		steps[i] = backend.ConversationStep{
+			ID:              row.StepID,
+			ConversationID:  row.ConversationID,
+			Tool:            row.Tool,
+			Arguments:       row.Arguments,
+			Duration:        time.Duration(row.DurationMs) * time.Millisecond,
+			Outcome:         backend.ConversationStepOutcome(row.Outcome),
+			Result:          row.Result,
+			ResultTruncated: row.ResultTruncated,
+			StartedAt:       row.StartedAt,
+		}
+	}
+	return steps, nil
+}
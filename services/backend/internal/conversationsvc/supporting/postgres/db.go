@@ -27,12 +27,72 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.addChannelStmt, err = db.PrepareContext(ctx, addChannel); err != nil {
 		return nil, fmt.Errorf("error preparing query AddChannel: %w", err)
 	}
+	if q.addConversationRedactionsStmt, err = db.PrepareContext(ctx, addConversationRedactions); err != nil {
+		return nil, fmt.Errorf("error preparing query AddConversationRedactions: %w", err)
+	}
+	if q.channelContextStmt, err = db.PrepareContext(ctx, channelContext); err != nil {
+		return nil, fmt.Errorf("error preparing query ChannelContext: %w", err)
+	}
+	if q.channelContextsByTeamStmt, err = db.PrepareContext(ctx, channelContextsByTeam); err != nil {
+		return nil, fmt.Errorf("error preparing query ChannelContextsByTeam: %w", err)
+	}
+	if q.claimChannelIntroStmt, err = db.PrepareContext(ctx, claimChannelIntro); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimChannelIntro: %w", err)
+	}
 	if q.conversationStmt, err = db.PrepareContext(ctx, conversation); err != nil {
 		return nil, fmt.Errorf("error preparing query Conversation: %w", err)
 	}
+	if q.conversationStatusChangesStmt, err = db.PrepareContext(ctx, conversationStatusChanges); err != nil {
+		return nil, fmt.Errorf("error preparing query ConversationStatusChanges: %w", err)
+	}
+	if q.conversationStepsStmt, err = db.PrepareContext(ctx, conversationSteps); err != nil {
+		return nil, fmt.Errorf("error preparing query ConversationSteps: %w", err)
+	}
+	if q.countConversationsByTeamsStmt, err = db.PrepareContext(ctx, countConversationsByTeams); err != nil {
+		return nil, fmt.Errorf("error preparing query CountConversationsByTeams: %w", err)
+	}
+	if q.countRetriedMessagesStmt, err = db.PrepareContext(ctx, countRetriedMessages); err != nil {
+		return nil, fmt.Errorf("error preparing query CountRetriedMessages: %w", err)
+	}
 	if q.createConversationStmt, err = db.PrepareContext(ctx, createConversation); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateConversation: %w", err)
 	}
+	if q.deleteChannelContextStmt, err = db.PrepareContext(ctx, deleteChannelContext); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteChannelContext: %w", err)
+	}
+	if q.deleteChannelContextsByTeamsStmt, err = db.PrepareContext(ctx, deleteChannelContextsByTeams); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteChannelContextsByTeams: %w", err)
+	}
+	if q.deleteChannelIntrosByTeamsStmt, err = db.PrepareContext(ctx, deleteChannelIntrosByTeams); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteChannelIntrosByTeams: %w", err)
+	}
+	if q.deleteChannelsByTeamsStmt, err = db.PrepareContext(ctx, deleteChannelsByTeams); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteChannelsByTeams: %w", err)
+	}
+	if q.deleteConversationsByTeamsStmt, err = db.PrepareContext(ctx, deleteConversationsByTeams); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteConversationsByTeams: %w", err)
+	}
+	if q.deleteOrganizationWorkspacesStmt, err = db.PrepareContext(ctx, deleteOrganizationWorkspaces); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteOrganizationWorkspaces: %w", err)
+	}
+	if q.deleteRetriedMessagesStmt, err = db.PrepareContext(ctx, deleteRetriedMessages); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteRetriedMessages: %w", err)
+	}
+	if q.deleteSlackTokensByTeamsStmt, err = db.PrepareContext(ctx, deleteSlackTokensByTeams); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSlackTokensByTeams: %w", err)
+	}
+	if q.deleteSlackUserMappingStmt, err = db.PrepareContext(ctx, deleteSlackUserMapping); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSlackUserMapping: %w", err)
+	}
+	if q.deleteSlackUserMappingsByOrganizationStmt, err = db.PrepareContext(ctx, deleteSlackUserMappingsByOrganization); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSlackUserMappingsByOrganization: %w", err)
+	}
+	if q.deleteSlackWorkspacesByTeamsStmt, err = db.PrepareContext(ctx, deleteSlackWorkspacesByTeams); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSlackWorkspacesByTeams: %w", err)
+	}
+	if q.feedbackCountsStmt, err = db.PrepareContext(ctx, feedbackCounts); err != nil {
+		return nil, fmt.Errorf("error preparing query FeedbackCounts: %w", err)
+	}
 	if q.getConversationByThreadStmt, err = db.PrepareContext(ctx, getConversationByThread); err != nil {
 		return nil, fmt.Errorf("error preparing query GetConversationByThread: %w", err)
 	}
@@ -51,18 +111,51 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.messageBySlackTSStmt, err = db.PrepareContext(ctx, messageBySlackTS); err != nil {
 		return nil, fmt.Errorf("error preparing query MessageBySlackTS: %w", err)
 	}
+	if q.organizationWorkspaceIDsStmt, err = db.PrepareContext(ctx, organizationWorkspaceIDs); err != nil {
+		return nil, fmt.Errorf("error preparing query OrganizationWorkspaceIDs: %w", err)
+	}
+	if q.recentConversationsByParticipantStmt, err = db.PrepareContext(ctx, recentConversationsByParticipant); err != nil {
+		return nil, fmt.Errorf("error preparing query RecentConversationsByParticipant: %w", err)
+	}
+	if q.saveFeedbackStmt, err = db.PrepareContext(ctx, saveFeedback); err != nil {
+		return nil, fmt.Errorf("error preparing query SaveFeedback: %w", err)
+	}
+	if q.saveSlackUserMappingStmt, err = db.PrepareContext(ctx, saveSlackUserMapping); err != nil {
+		return nil, fmt.Errorf("error preparing query SaveSlackUserMapping: %w", err)
+	}
+	if q.setChannelContextStmt, err = db.PrepareContext(ctx, setChannelContext); err != nil {
+		return nil, fmt.Errorf("error preparing query SetChannelContext: %w", err)
+	}
 	if q.setChannelMonitoringStmt, err = db.PrepareContext(ctx, setChannelMonitoring); err != nil {
 		return nil, fmt.Errorf("error preparing query SetChannelMonitoring: %w", err)
 	}
+	if q.setConversationStatusStmt, err = db.PrepareContext(ctx, setConversationStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query SetConversationStatus: %w", err)
+	}
+	if q.slackUserMappingStmt, err = db.PrepareContext(ctx, slackUserMapping); err != nil {
+		return nil, fmt.Errorf("error preparing query SlackUserMapping: %w", err)
+	}
+	if q.slackUserMappingsByOrganizationStmt, err = db.PrepareContext(ctx, slackUserMappingsByOrganization); err != nil {
+		return nil, fmt.Errorf("error preparing query SlackUserMappingsByOrganization: %w", err)
+	}
+	if q.storeConversationStepStmt, err = db.PrepareContext(ctx, storeConversationStep); err != nil {
+		return nil, fmt.Errorf("error preparing query StoreConversationStep: %w", err)
+	}
 	if q.storeMessageStmt, err = db.PrepareContext(ctx, storeMessage); err != nil {
 		return nil, fmt.Errorf("error preparing query StoreMessage: %w", err)
 	}
 	if q.updateConversationTimestampStmt, err = db.PrepareContext(ctx, updateConversationTimestamp); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateConversationTimestamp: %w", err)
 	}
+	if q.businessIDByProviderProjectIDStmt, err = db.PrepareContext(ctx, businessIDByProviderProjectID); err != nil {
+		return nil, fmt.Errorf("error preparing query businessIDByProviderProjectID: %w", err)
+	}
 	if q.integrationsStmt, err = db.PrepareContext(ctx, integrations); err != nil {
 		return nil, fmt.Errorf("error preparing query integrations: %w", err)
 	}
+	if q.linkSlackWorkspaceStmt, err = db.PrepareContext(ctx, linkSlackWorkspace); err != nil {
+		return nil, fmt.Errorf("error preparing query linkSlackWorkspace: %w", err)
+	}
 	if q.saveIntegrationStmt, err = db.PrepareContext(ctx, saveIntegration); err != nil {
 		return nil, fmt.Errorf("error preparing query saveIntegration: %w", err)
 	}
@@ -82,16 +175,116 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing addChannelStmt: %w", cerr)
 		}
 	}
+	if q.addConversationRedactionsStmt != nil {
+		if cerr := q.addConversationRedactionsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addConversationRedactionsStmt: %w", cerr)
+		}
+	}
+	if q.channelContextStmt != nil {
+		if cerr := q.channelContextStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing channelContextStmt: %w", cerr)
+		}
+	}
+	if q.channelContextsByTeamStmt != nil {
+		if cerr := q.channelContextsByTeamStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing channelContextsByTeamStmt: %w", cerr)
+		}
+	}
+	if q.claimChannelIntroStmt != nil {
+		if cerr := q.claimChannelIntroStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimChannelIntroStmt: %w", cerr)
+		}
+	}
 	if q.conversationStmt != nil {
 		if cerr := q.conversationStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing conversationStmt: %w", cerr)
 		}
 	}
+	if q.conversationStatusChangesStmt != nil {
+		if cerr := q.conversationStatusChangesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing conversationStatusChangesStmt: %w", cerr)
+		}
+	}
+	if q.conversationStepsStmt != nil {
+		if cerr := q.conversationStepsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing conversationStepsStmt: %w", cerr)
+		}
+	}
+	if q.countConversationsByTeamsStmt != nil {
+		if cerr := q.countConversationsByTeamsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countConversationsByTeamsStmt: %w", cerr)
+		}
+	}
+	if q.countRetriedMessagesStmt != nil {
+		if cerr := q.countRetriedMessagesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countRetriedMessagesStmt: %w", cerr)
+		}
+	}
 	if q.createConversationStmt != nil {
 		if cerr := q.createConversationStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createConversationStmt: %w", cerr)
 		}
 	}
+	if q.deleteChannelContextStmt != nil {
+		if cerr := q.deleteChannelContextStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteChannelContextStmt: %w", cerr)
+		}
+	}
+	if q.deleteChannelContextsByTeamsStmt != nil {
+		if cerr := q.deleteChannelContextsByTeamsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteChannelContextsByTeamsStmt: %w", cerr)
+		}
+	}
+	if q.deleteChannelIntrosByTeamsStmt != nil {
+		if cerr := q.deleteChannelIntrosByTeamsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteChannelIntrosByTeamsStmt: %w", cerr)
+		}
+	}
+	if q.deleteChannelsByTeamsStmt != nil {
+		if cerr := q.deleteChannelsByTeamsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteChannelsByTeamsStmt: %w", cerr)
+		}
+	}
+	if q.deleteConversationsByTeamsStmt != nil {
+		if cerr := q.deleteConversationsByTeamsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteConversationsByTeamsStmt: %w", cerr)
+		}
+	}
+	if q.deleteOrganizationWorkspacesStmt != nil {
+		if cerr := q.deleteOrganizationWorkspacesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteOrganizationWorkspacesStmt: %w", cerr)
+		}
+	}
+	if q.deleteRetriedMessagesStmt != nil {
+		if cerr := q.deleteRetriedMessagesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteRetriedMessagesStmt: %w", cerr)
+		}
+	}
+	if q.deleteSlackTokensByTeamsStmt != nil {
+		if cerr := q.deleteSlackTokensByTeamsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSlackTokensByTeamsStmt: %w", cerr)
+		}
+	}
+	if q.deleteSlackUserMappingStmt != nil {
+		if cerr := q.deleteSlackUserMappingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSlackUserMappingStmt: %w", cerr)
+		}
+	}
+	if q.deleteSlackUserMappingsByOrganizationStmt != nil {
+		if cerr := q.deleteSlackUserMappingsByOrganizationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSlackUserMappingsByOrganizationStmt: %w", cerr)
+		}
+	}
+	if q.deleteSlackWorkspacesByTeamsStmt != nil {
+		if cerr := q.deleteSlackWorkspacesByTeamsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSlackWorkspacesByTeamsStmt: %w", cerr)
+		}
+	}
+	if q.feedbackCountsStmt != nil {
+		if cerr := q.feedbackCountsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing feedbackCountsStmt: %w", cerr)
+		}
+	}
 	if q.getConversationByThreadStmt != nil {
 		if cerr := q.getConversationByThreadStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getConversationByThreadStmt: %w", cerr)
@@ -122,11 +315,56 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing messageBySlackTSStmt: %w", cerr)
 		}
 	}
+	if q.organizationWorkspaceIDsStmt != nil {
+		if cerr := q.organizationWorkspaceIDsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing organizationWorkspaceIDsStmt: %w", cerr)
+		}
+	}
+	if q.recentConversationsByParticipantStmt != nil {
+		if cerr := q.recentConversationsByParticipantStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recentConversationsByParticipantStmt: %w", cerr)
+		}
+	}
+	if q.saveFeedbackStmt != nil {
+		if cerr := q.saveFeedbackStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing saveFeedbackStmt: %w", cerr)
+		}
+	}
+	if q.saveSlackUserMappingStmt != nil {
+		if cerr := q.saveSlackUserMappingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing saveSlackUserMappingStmt: %w", cerr)
+		}
+	}
+	if q.setChannelContextStmt != nil {
+		if cerr := q.setChannelContextStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setChannelContextStmt: %w", cerr)
+		}
+	}
 	if q.setChannelMonitoringStmt != nil {
 		if cerr := q.setChannelMonitoringStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing setChannelMonitoringStmt: %w", cerr)
 		}
 	}
+	if q.setConversationStatusStmt != nil {
+		if cerr := q.setConversationStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setConversationStatusStmt: %w", cerr)
+		}
+	}
+	if q.slackUserMappingStmt != nil {
+		if cerr := q.slackUserMappingStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing slackUserMappingStmt: %w", cerr)
+		}
+	}
+	if q.slackUserMappingsByOrganizationStmt != nil {
+		if cerr := q.slackUserMappingsByOrganizationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing slackUserMappingsByOrganizationStmt: %w", cerr)
+		}
+	}
+	if q.storeConversationStepStmt != nil {
+		if cerr := q.storeConversationStepStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing storeConversationStepStmt: %w", cerr)
+		}
+	}
 	if q.storeMessageStmt != nil {
 		if cerr := q.storeMessageStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing storeMessageStmt: %w", cerr)
@@ -137,11 +375,21 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateConversationTimestampStmt: %w", cerr)
 		}
 	}
+	if q.businessIDByProviderProjectIDStmt != nil {
+		if cerr := q.businessIDByProviderProjectIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing businessIDByProviderProjectIDStmt: %w", cerr)
+		}
+	}
 	if q.integrationsStmt != nil {
 		if cerr := q.integrationsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing integrationsStmt: %w", cerr)
 		}
 	}
+	if q.linkSlackWorkspaceStmt != nil {
+		if cerr := q.linkSlackWorkspaceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing linkSlackWorkspaceStmt: %w", cerr)
+		}
+	}
 	if q.saveIntegrationStmt != nil {
 		if cerr := q.saveIntegrationStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing saveIntegrationStmt: %w", cerr)
@@ -194,45 +442,107 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                              DBTX
-	tx                              *sql.Tx
-	addChannelStmt                  *sql.Stmt
-	conversationStmt                *sql.Stmt
-	createConversationStmt          *sql.Stmt
-	getConversationByThreadStmt     *sql.Stmt
-	getConversationHistoryStmt      *sql.Stmt
-	getConversationHistoryDescStmt  *sql.Stmt
-	getMonitoredChannelsStmt        *sql.Stmt
-	isChannelMonitoredStmt          *sql.Stmt
-	messageBySlackTSStmt            *sql.Stmt
-	setChannelMonitoringStmt        *sql.Stmt
-	storeMessageStmt                *sql.Stmt
-	updateConversationTimestampStmt *sql.Stmt
-	integrationsStmt                *sql.Stmt
-	saveIntegrationStmt             *sql.Stmt
-	saveSlackTokenStmt              *sql.Stmt
-	slackTokenStmt                  *sql.Stmt
+	db                                        DBTX
+	tx                                        *sql.Tx
+	addChannelStmt                            *sql.Stmt
+	addConversationRedactionsStmt             *sql.Stmt
+	channelContextStmt                        *sql.Stmt
+	channelContextsByTeamStmt                 *sql.Stmt
+	claimChannelIntroStmt                     *sql.Stmt
+	conversationStmt                          *sql.Stmt
+	conversationStatusChangesStmt             *sql.Stmt
+	conversationStepsStmt                     *sql.Stmt
+	countConversationsByTeamsStmt             *sql.Stmt
+	countRetriedMessagesStmt                  *sql.Stmt
+	createConversationStmt                    *sql.Stmt
+	deleteChannelContextStmt                  *sql.Stmt
+	deleteChannelContextsByTeamsStmt          *sql.Stmt
+	deleteChannelIntrosByTeamsStmt            *sql.Stmt
+	deleteChannelsByTeamsStmt                 *sql.Stmt
+	deleteConversationsByTeamsStmt            *sql.Stmt
+	deleteOrganizationWorkspacesStmt          *sql.Stmt
+	deleteRetriedMessagesStmt                 *sql.Stmt
+	deleteSlackTokensByTeamsStmt              *sql.Stmt
+	deleteSlackUserMappingStmt                *sql.Stmt
+	deleteSlackUserMappingsByOrganizationStmt *sql.Stmt
+	deleteSlackWorkspacesByTeamsStmt          *sql.Stmt
+	feedbackCountsStmt                        *sql.Stmt
+	getConversationByThreadStmt               *sql.Stmt
+	getConversationHistoryStmt                *sql.Stmt
+	getConversationHistoryDescStmt            *sql.Stmt
+	getMonitoredChannelsStmt                  *sql.Stmt
+	isChannelMonitoredStmt                    *sql.Stmt
+	messageBySlackTSStmt                      *sql.Stmt
+	organizationWorkspaceIDsStmt              *sql.Stmt
+	recentConversationsByParticipantStmt      *sql.Stmt
+	saveFeedbackStmt                          *sql.Stmt
+	saveSlackUserMappingStmt                  *sql.Stmt
+	setChannelContextStmt                     *sql.Stmt
+	setChannelMonitoringStmt                  *sql.Stmt
+	setConversationStatusStmt                 *sql.Stmt
+	slackUserMappingStmt                      *sql.Stmt
+	slackUserMappingsByOrganizationStmt       *sql.Stmt
+	storeConversationStepStmt                 *sql.Stmt
+	storeMessageStmt                          *sql.Stmt
+	updateConversationTimestampStmt           *sql.Stmt
+	businessIDByProviderProjectIDStmt         *sql.Stmt
+	integrationsStmt                          *sql.Stmt
+	linkSlackWorkspaceStmt                    *sql.Stmt
+	saveIntegrationStmt                       *sql.Stmt
+	saveSlackTokenStmt                        *sql.Stmt
+	slackTokenStmt                            *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                              tx,
-		tx:                              tx,
-		addChannelStmt:                  q.addChannelStmt,
-		conversationStmt:                q.conversationStmt,
-		createConversationStmt:          q.createConversationStmt,
-		getConversationByThreadStmt:     q.getConversationByThreadStmt,
-		getConversationHistoryStmt:      q.getConversationHistoryStmt,
-		getConversationHistoryDescStmt:  q.getConversationHistoryDescStmt,
-		getMonitoredChannelsStmt:        q.getMonitoredChannelsStmt,
-		isChannelMonitoredStmt:          q.isChannelMonitoredStmt,
-		messageBySlackTSStmt:            q.messageBySlackTSStmt,
-		setChannelMonitoringStmt:        q.setChannelMonitoringStmt,
-		storeMessageStmt:                q.storeMessageStmt,
-		updateConversationTimestampStmt: q.updateConversationTimestampStmt,
-		integrationsStmt:                q.integrationsStmt,
-		saveIntegrationStmt:             q.saveIntegrationStmt,
-		saveSlackTokenStmt:              q.saveSlackTokenStmt,
-		slackTokenStmt:                  q.slackTokenStmt,
+		db:                                        tx,
+		tx:                                        tx,
+		addChannelStmt:                            q.addChannelStmt,
+		addConversationRedactionsStmt:             q.addConversationRedactionsStmt,
+		channelContextStmt:                        q.channelContextStmt,
+		channelContextsByTeamStmt:                 q.channelContextsByTeamStmt,
+		claimChannelIntroStmt:                     q.claimChannelIntroStmt,
+		conversationStmt:                          q.conversationStmt,
+		conversationStatusChangesStmt:             q.conversationStatusChangesStmt,
+		conversationStepsStmt:                     q.conversationStepsStmt,
+		countConversationsByTeamsStmt:             q.countConversationsByTeamsStmt,
+		countRetriedMessagesStmt:                  q.countRetriedMessagesStmt,
+		createConversationStmt:                    q.createConversationStmt,
+		deleteChannelContextStmt:                  q.deleteChannelContextStmt,
+		deleteChannelContextsByTeamsStmt:          q.deleteChannelContextsByTeamsStmt,
+		deleteChannelIntrosByTeamsStmt:            q.deleteChannelIntrosByTeamsStmt,
+		deleteChannelsByTeamsStmt:                 q.deleteChannelsByTeamsStmt,
+		deleteConversationsByTeamsStmt:            q.deleteConversationsByTeamsStmt,
+		deleteOrganizationWorkspacesStmt:          q.deleteOrganizationWorkspacesStmt,
+		deleteRetriedMessagesStmt:                 q.deleteRetriedMessagesStmt,
+		deleteSlackTokensByTeamsStmt:              q.deleteSlackTokensByTeamsStmt,
+		deleteSlackUserMappingStmt:                q.deleteSlackUserMappingStmt,
+		deleteSlackUserMappingsByOrganizationStmt: q.deleteSlackUserMappingsByOrganizationStmt,
+		deleteSlackWorkspacesByTeamsStmt:          q.deleteSlackWorkspacesByTeamsStmt,
+		feedbackCountsStmt:                        q.feedbackCountsStmt,
+		getConversationByThreadStmt:               q.getConversationByThreadStmt,
+		getConversationHistoryStmt:                q.getConversationHistoryStmt,
+		getConversationHistoryDescStmt:            q.getConversationHistoryDescStmt,
+		getMonitoredChannelsStmt:                  q.getMonitoredChannelsStmt,
+		isChannelMonitoredStmt:                    q.isChannelMonitoredStmt,
+		messageBySlackTSStmt:                      q.messageBySlackTSStmt,
+		organizationWorkspaceIDsStmt:              q.organizationWorkspaceIDsStmt,
+		recentConversationsByParticipantStmt:      q.recentConversationsByParticipantStmt,
+		saveFeedbackStmt:                          q.saveFeedbackStmt,
+		saveSlackUserMappingStmt:                  q.saveSlackUserMappingStmt,
+		setChannelContextStmt:                     q.setChannelContextStmt,
+		setChannelMonitoringStmt:                  q.setChannelMonitoringStmt,
+		setConversationStatusStmt:                 q.setConversationStatusStmt,
+		slackUserMappingStmt:                      q.slackUserMappingStmt,
+		slackUserMappingsByOrganizationStmt:       q.slackUserMappingsByOrganizationStmt,
+		storeConversationStepStmt:                 q.storeConversationStepStmt,
+		storeMessageStmt:                          q.storeMessageStmt,
+		updateConversationTimestampStmt:           q.updateConversationTimestampStmt,
+		businessIDByProviderProjectIDStmt:         q.businessIDByProviderProjectIDStmt,
+		integrationsStmt:                          q.integrationsStmt,
+		linkSlackWorkspaceStmt:                    q.linkSlackWorkspaceStmt,
+		saveIntegrationStmt:                       q.saveIntegrationStmt,
+		saveSlackTokenStmt:                        q.saveSlackTokenStmt,
+		slackTokenStmt:                            q.slackTokenStmt,
 	}
 }
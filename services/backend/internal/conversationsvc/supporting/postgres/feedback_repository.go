@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+)
+
+var _ domain.FeedbackRepository = (*BackendDB)(nil)
+
+func (db *BackendDB) SaveFeedback(ctx context.Context, feedback domain.Feedback) (domain.Feedback, error) {
+	saved, err := db.Querier.SaveFeedback(ctx, SaveFeedbackParams{
+		UserID:    feedback.UserID,
+		Rating:    string(feedback.Rating),
+		Comment:   feedback.Comment,
+		MessageID: feedback.MessageID,
+		TeamID:    feedback.TeamID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Feedback{}, domain.ErrFeedbackMessageNotFound
+	}
+	if err != nil {
+		return domain.Feedback{}, fmt.Errorf("failed to save feedback: %w", err)
+	}
+
+	return domain.Feedback{
+		ID:                  saved.FeedbackID,
+		MessageID:           saved.MessageID,
+		ConversationID:      saved.ConversationID,
+		InvocationMessageID: saved.InvocationMessageID.UUID,
+		TeamID:              saved.TeamID,
+		UserID:              saved.UserID,
+		Rating:              domain.FeedbackRating(saved.Rating),
+		Comment:             saved.Comment,
+		CreatedAt:           saved.CreatedAt,
+		UpdatedAt:           saved.UpdatedAt,
+	}, nil
+}
+
+func (db *BackendDB) FeedbackCounts(ctx context.Context, teamIDs []string, bucket string, since, until time.Time) ([]domain.FeedbackCount, error) {
+	rows, err := db.Querier.FeedbackCounts(ctx, FeedbackCountsParams{
+		Bucket:  bucket,
+		TeamIds: teamIDs,
+		Since:   since,
+		Until:   until,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count feedback: %w", err)
+	}
+
+	counts := make([]domain.FeedbackCount, len(rows))
+	for i, row := range rows {
+		counts[i] = domain.FeedbackCount{
+			BucketStart: row.BucketStart.UTC(),
+			Positive:    int(row.Positive),
+			Negative:    int(row.Negative),
+		}
+	}
+	return counts, nil
+}
@@ -19,6 +19,21 @@ type Channel struct {
 	CreatedAt   time.Time      `json:"created_at"`
 }
 
+type ChannelContext struct {
+	TeamID               string    `json:"team_id"`
+	ChannelID            string    `json:"channel_id"`
+	Repositories         []string  `json:"repositories"`
+	KubernetesNamespaces []string  `json:"kubernetes_namespaces"`
+	GcpProjects          []string  `json:"gcp_projects"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+type ChannelIntro struct {
+	TeamID    string    `json:"team_id"`
+	ChannelID string    `json:"channel_id"`
+	PostedAt  time.Time `json:"posted_at"`
+}
+
 type Conversation struct {
 	ConversationID uuid.UUID `json:"conversation_id"`
 	TeamID         string    `json:"team_id"`
@@ -26,6 +41,34 @@ type Conversation struct {
 	ThreadTs       string    `json:"thread_ts"`
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
+	Status         string    `json:"status"`
+}
+
+type ConversationRedaction struct {
+	ConversationID uuid.UUID `json:"conversation_id"`
+	RedactionCount int32     `json:"redaction_count"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type ConversationStatusChange struct {
+	ChangeID       uuid.UUID `json:"change_id"`
+	ConversationID uuid.UUID `json:"conversation_id"`
+	Status         string    `json:"status"`
+	ChangedBy      string    `json:"changed_by"`
+	Reason         string    `json:"reason"`
+	ChangedAt      time.Time `json:"changed_at"`
+}
+
+type ConversationStep struct {
+	StepID          uuid.UUID `json:"step_id"`
+	ConversationID  uuid.UUID `json:"conversation_id"`
+	Tool            string    `json:"tool"`
+	Arguments       []string  `json:"arguments"`
+	DurationMs      int64     `json:"duration_ms"`
+	Outcome         string    `json:"outcome"`
+	Result          string    `json:"result"`
+	ResultTruncated bool      `json:"result_truncated"`
+	StartedAt       time.Time `json:"started_at"`
 }
 
 type Integration struct {
@@ -49,6 +92,22 @@ type Message struct {
 	MessageText    string         `json:"message_text"`
 	IsBotMessage   bool           `json:"is_bot_message"`
 	CreatedAt      time.Time      `json:"created_at"`
+	Model          sql.NullString `json:"model"`
+	SlackEventID   sql.NullString `json:"slack_event_id"`
+	ClientMsgID    sql.NullString `json:"client_msg_id"`
+}
+
+type MessageFeedback struct {
+	FeedbackID          uuid.UUID     `json:"feedback_id"`
+	MessageID           uuid.UUID     `json:"message_id"`
+	ConversationID      uuid.UUID     `json:"conversation_id"`
+	InvocationMessageID uuid.NullUUID `json:"invocation_message_id"`
+	TeamID              string        `json:"team_id"`
+	UserID              string        `json:"user_id"`
+	Rating              string        `json:"rating"`
+	Comment             string        `json:"comment"`
+	CreatedAt           time.Time     `json:"created_at"`
+	UpdatedAt           time.Time     `json:"updated_at"`
 }
 
 type SlackToken struct {
@@ -59,3 +118,19 @@ type SlackToken struct {
 	ExpiredAt sql.NullTime `json:"expired_at"`
 	CreatedAt time.Time    `json:"created_at"`
 }
+
+type SlackUserMapping struct {
+	TeamID         string    `json:"team_id"`
+	SlackUserID    string    `json:"slack_user_id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	Source         string    `json:"source"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+type SlackWorkspace struct {
+	TeamID       string    `json:"team_id"`
+	EnterpriseID string    `json:"enterprise_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
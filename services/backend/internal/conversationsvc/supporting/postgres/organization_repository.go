@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/google/uuid"
+)
+
+var _ domain.OrganizationDataRepository = (*BackendDB)(nil)
+
+func (i BackendDB) DeleteOrganizationData(ctx context.Context, organizationID uuid.UUID, dryRun bool) (domain.OrganizationData, error) {
+	tx, err := i.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.OrganizationData{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := New(tx)
+
+	teamIDs, err := qtx.OrganizationWorkspaceIDs(ctx, organizationID)
+	if err != nil {
+		return domain.OrganizationData{}, fmt.Errorf("failed to get organization workspaces: %w", err)
+	}
+
+	data := domain.OrganizationData{Workspaces: len(teamIDs)}
+	deletes := []struct {
+		resource string
+		count    *int
+		delete   func() (int64, error)
+	}{
+		{"conversations", &data.Conversations, func() (int64, error) { return qtx.DeleteConversationsByTeams(ctx, teamIDs) }},
+		{"channel contexts", &data.ChannelContexts, func() (int64, error) { return qtx.DeleteChannelContextsByTeams(ctx, teamIDs) }},
+		{"channel intros", &data.ChannelIntros, func() (int64, error) { return qtx.DeleteChannelIntrosByTeams(ctx, teamIDs) }},
+		{"channels", &data.Channels, func() (int64, error) { return qtx.DeleteChannelsByTeams(ctx, teamIDs) }},
+		{"slack user mappings", &data.SlackUserMappings, func() (int64, error) { return qtx.DeleteSlackUserMappingsByOrganization(ctx, organizationID) }},
+		{"slack tokens", &data.SlackTokens, func() (int64, error) { return qtx.DeleteSlackTokensByTeams(ctx, teamIDs) }},
+	}
+	for _, d := range deletes {
+		n, err := d.delete()
+		if err != nil {
+			return domain.OrganizationData{}, fmt.Errorf("failed to delete %s: %w", d.resource, err)
+		}
+		*d.count = int(n)
+	}
+
+	// The workspace links go last: they map the Slack teams to the organization.
+	if _, err := qtx.DeleteSlackWorkspacesByTeams(ctx, teamIDs); err != nil {
+		return domain.OrganizationData{}, fmt.Errorf("failed to delete enterprise workspaces: %w", err)
+	}
+	if _, err := qtx.DeleteOrganizationWorkspaces(ctx, organizationID); err != nil {
+		return domain.OrganizationData{}, fmt.Errorf("failed to delete workspaces: %w", err)
+	}
+
+	if dryRun {
+		return data, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return domain.OrganizationData{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return data, nil
+}
+
+func (i BackendDB) OrganizationActivity(ctx context.Context, organizationID uuid.UUID) (domain.OrganizationActivity, error) {
+	teamIDs, err := i.OrganizationWorkspaceIDs(ctx, organizationID)
+	if err != nil {
+		return domain.OrganizationActivity{}, fmt.Errorf("failed to get organization workspaces: %w", err)
+	}
+	if len(teamIDs) == 0 {
+		return domain.OrganizationActivity{}, nil
+	}
+
+	conversations, err := i.CountConversationsByTeams(ctx, teamIDs)
+	if err != nil {
+		return domain.OrganizationActivity{}, fmt.Errorf("failed to count conversations: %w", err)
+	}
+	return domain.OrganizationActivity{Workspaces: len(teamIDs), Conversations: int(conversations)}, nil
+}
@@ -6,24 +6,56 @@ package postgres
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
 	AddChannel(ctx context.Context, arg AddChannelParams) error
+	AddConversationRedactions(ctx context.Context, arg AddConversationRedactionsParams) error
+	ChannelContext(ctx context.Context, arg ChannelContextParams) (ChannelContext, error)
+	ChannelContextsByTeam(ctx context.Context, teamID string) ([]ChannelContextsByTeamRow, error)
+	ClaimChannelIntro(ctx context.Context, arg ClaimChannelIntroParams) (time.Time, error)
 	Conversation(ctx context.Context, conversationID uuid.UUID) (Conversation, error)
+	ConversationStatusChanges(ctx context.Context, conversationID uuid.UUID) ([]ConversationStatusChange, error)
+	ConversationSteps(ctx context.Context, arg ConversationStepsParams) ([]ConversationStep, error)
+	CountConversationsByTeams(ctx context.Context, teamIds []string) (int64, error)
+	CountRetriedMessages(ctx context.Context, windowSeconds int32) (int64, error)
 	CreateConversation(ctx context.Context, arg CreateConversationParams) (Conversation, error)
+	DeleteChannelContext(ctx context.Context, arg DeleteChannelContextParams) error
+	DeleteChannelContextsByTeams(ctx context.Context, teamIds []string) (int64, error)
+	DeleteChannelIntrosByTeams(ctx context.Context, teamIds []string) (int64, error)
+	DeleteChannelsByTeams(ctx context.Context, teamIds []string) (int64, error)
+	DeleteConversationsByTeams(ctx context.Context, teamIds []string) (int64, error)
+	DeleteOrganizationWorkspaces(ctx context.Context, businessID uuid.UUID) (int64, error)
+	DeleteRetriedMessages(ctx context.Context, windowSeconds int32) (int64, error)
+	DeleteSlackTokensByTeams(ctx context.Context, teamIds []string) (int64, error)
+	DeleteSlackUserMapping(ctx context.Context, arg DeleteSlackUserMappingParams) (int64, error)
+	DeleteSlackUserMappingsByOrganization(ctx context.Context, organizationID uuid.UUID) (int64, error)
+	DeleteSlackWorkspacesByTeams(ctx context.Context, teamIds []string) (int64, error)
+	FeedbackCounts(ctx context.Context, arg FeedbackCountsParams) ([]FeedbackCountsRow, error)
 	GetConversationByThread(ctx context.Context, arg GetConversationByThreadParams) (Conversation, error)
 	GetConversationHistory(ctx context.Context, conversationID uuid.UUID) ([]Message, error)
 	GetConversationHistoryDesc(ctx context.Context, arg GetConversationHistoryDescParams) ([]Message, error)
 	GetMonitoredChannels(ctx context.Context, teamID string) ([]Channel, error)
 	IsChannelMonitored(ctx context.Context, arg IsChannelMonitoredParams) (bool, error)
 	MessageBySlackTS(ctx context.Context, arg MessageBySlackTSParams) (Message, error)
+	OrganizationWorkspaceIDs(ctx context.Context, businessID uuid.UUID) ([]string, error)
+	RecentConversationsByParticipant(ctx context.Context, arg RecentConversationsByParticipantParams) ([]Conversation, error)
+	SaveFeedback(ctx context.Context, arg SaveFeedbackParams) (MessageFeedback, error)
+	SaveSlackUserMapping(ctx context.Context, arg SaveSlackUserMappingParams) (SlackUserMapping, error)
+	SetChannelContext(ctx context.Context, arg SetChannelContextParams) error
 	SetChannelMonitoring(ctx context.Context, arg SetChannelMonitoringParams) error
+	SetConversationStatus(ctx context.Context, arg SetConversationStatusParams) (int64, error)
+	SlackUserMapping(ctx context.Context, arg SlackUserMappingParams) (SlackUserMapping, error)
+	SlackUserMappingsByOrganization(ctx context.Context, organizationID uuid.UUID) ([]SlackUserMapping, error)
+	StoreConversationStep(ctx context.Context, arg StoreConversationStepParams) error
 	StoreMessage(ctx context.Context, arg StoreMessageParams) (Message, error)
 	UpdateConversationTimestamp(ctx context.Context, conversationID uuid.UUID) error
+	businessIDByProviderProjectID(ctx context.Context, arg businessIDByProviderProjectIDParams) (uuid.UUID, error)
 	integrations(ctx context.Context, businessID uuid.UUID) ([]Integration, error)
+	linkSlackWorkspace(ctx context.Context, arg linkSlackWorkspaceParams) error
 	saveIntegration(ctx context.Context, arg saveIntegrationParams) error
 	saveSlackToken(ctx context.Context, arg saveSlackTokenParams) error
 	slackToken(ctx context.Context, teamID string) (string, error)
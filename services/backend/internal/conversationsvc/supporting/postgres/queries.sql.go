@@ -11,6 +11,25 @@ import (
 	"github.com/google/uuid"
 )
 
+const businessIDByProviderProjectID = `-- name: businessIDByProviderProjectID :one
+SELECT business_id FROM integration
+WHERE provider = $1 and active='t'
+  and (provider_project_id = $2 or provider_project_id = (SELECT enterprise_id FROM slack_workspaces WHERE team_id = $2))
+ORDER BY provider_project_id = $2 DESC, created_at DESC LIMIT 1
+`
+
+type businessIDByProviderProjectIDParams struct {
+	Provider          string `json:"provider"`
+	ProviderProjectID string `json:"provider_project_id"`
+}
+
+func (q *Queries) businessIDByProviderProjectID(ctx context.Context, arg businessIDByProviderProjectIDParams) (uuid.UUID, error) {
+	row := q.queryRow(ctx, q.businessIDByProviderProjectIDStmt, businessIDByProviderProjectID, arg.Provider, arg.ProviderProjectID)
+	var business_id uuid.UUID
+	err := row.Scan(&business_id)
+	return business_id, err
+}
+
 const integrations = `-- name: integrations :many
 SELECT id, provider, status, business_id, provider_project_id, active, created_at FROM integration WHERE business_id = $1 and active='t'
 `
@@ -46,6 +65,21 @@ func (q *Queries) integrations(ctx context.Context, businessID uuid.UUID) ([]Int
 	return items, nil
 }
 
+const linkSlackWorkspace = `-- name: linkSlackWorkspace :exec
+INSERT INTO slack_workspaces (team_id, enterprise_id) VALUES ($1, $2)
+ON CONFLICT (team_id) DO UPDATE SET enterprise_id = EXCLUDED.enterprise_id
+`
+
+type linkSlackWorkspaceParams struct {
+	TeamID       string `json:"team_id"`
+	EnterpriseID string `json:"enterprise_id"`
+}
+
+func (q *Queries) linkSlackWorkspace(ctx context.Context, arg linkSlackWorkspaceParams) error {
+	_, err := q.exec(ctx, q.linkSlackWorkspaceStmt, linkSlackWorkspace, arg.TeamID, arg.EnterpriseID)
+	return err
+}
+
 const saveIntegration = `-- name: saveIntegration :exec
 INSERT INTO integration (id, provider, status, business_id, provider_project_id) VALUES ($1, $2, $3, $4, $5)
 `
@@ -85,7 +119,10 @@ func (q *Queries) saveSlackToken(ctx context.Context, arg saveSlackTokenParams)
 }
 
 const slackToken = `-- name: slackToken :one
-SELECT token FROM slack_token WHERE team_id = $1 and expired='f'
+SELECT token FROM slack_token
+WHERE expired='f' AND (team_id = $1 OR team_id = (SELECT enterprise_id FROM slack_workspaces WHERE slack_workspaces.team_id = $1))
+ORDER BY team_id = $1 DESC, created_at DESC
+LIMIT 1
 `
 
 func (q *Queries) slackToken(ctx context.Context, teamID string) (string, error) {
@@ -0,0 +1,32 @@
+-- name: SetChannelContext :exec
+INSERT INTO channel_contexts (team_id, channel_id, repositories, kubernetes_namespaces, gcp_projects, updated_at)
+VALUES ($1, $2, $3, $4, $5, NOW())
+ON CONFLICT (team_id, channel_id)
+DO UPDATE SET repositories = EXCLUDED.repositories,
+    kubernetes_namespaces = EXCLUDED.kubernetes_namespaces,
+    gcp_projects = EXCLUDED.gcp_projects,
+    updated_at = NOW();
+
+-- name: DeleteChannelContext :exec
+DELETE FROM channel_contexts
+WHERE team_id = $1 AND channel_id = $2;
+
+-- name: ChannelContext :one
+SELECT team_id, channel_id, repositories, kubernetes_namespaces, gcp_projects, updated_at
+FROM channel_contexts
+WHERE team_id = $1 AND channel_id = $2;
+
+-- name: ChannelContextsByTeam :many
+SELECT cc.team_id, cc.channel_id, c.channel_name, cc.repositories, cc.kubernetes_namespaces, cc.gcp_projects, cc.updated_at
+FROM channel_contexts cc
+LEFT JOIN channels c ON c.team_id = cc.team_id AND c.channel_id = cc.channel_id
+WHERE cc.team_id = $1
+ORDER BY cc.channel_id;
+
+-- name: ClaimChannelIntro :one
+INSERT INTO channel_intros (team_id, channel_id, posted_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (team_id, channel_id)
+DO UPDATE SET posted_at = NOW()
+WHERE channel_intros.posted_at < sqlc.arg(posted_before)
+RETURNING posted_at;
@@ -2,10 +2,10 @@
 -- name: CreateConversation :one
 INSERT INTO conversations (team_id, channel_id, thread_ts)
 VALUES ($1, $2, $3)
-RETURNING conversation_id, team_id, channel_id, thread_ts, created_at, updated_at;
+RETURNING conversation_id, team_id, channel_id, thread_ts, created_at, updated_at, status;
 
 -- name: GetConversationByThread :one
-SELECT conversation_id, team_id, channel_id, thread_ts, created_at, updated_at
+SELECT conversation_id, team_id, channel_id, thread_ts, created_at, updated_at, status
 FROM conversations
 WHERE team_id = $1 AND channel_id = $2 AND thread_ts = $3;
 
@@ -15,23 +15,24 @@ SET updated_at = NOW()
 WHERE conversation_id = $1;
 
 -- name: StoreMessage :one
-INSERT INTO messages (conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-RETURNING message_id, conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message, created_at;
+INSERT INTO messages (conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message, model, slack_event_id, client_msg_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT DO NOTHING
+RETURNING message_id, conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message, created_at, model, slack_event_id, client_msg_id;
 
 -- name: MessageBySlackTS :one
-SELECT message_id, conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message, created_at
+SELECT message_id, conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message, created_at, model, slack_event_id, client_msg_id
 FROM messages
 WHERE conversation_id = $1 AND slack_message_ts = $2 AND sender_user_id = $3;
 
 -- name: GetConversationHistory :many
-SELECT message_id, conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message, created_at
+SELECT message_id, conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message, created_at, model, slack_event_id, client_msg_id
 FROM messages
 WHERE conversation_id = $1
 ORDER BY created_at ASC;
 
 -- name: GetConversationHistoryDesc :many
-SELECT message_id, conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message, created_at
+SELECT message_id, conversation_id, slack_message_ts, sender_user_id, sender_username, sender_email, sender_name, message_text, is_bot_message, created_at, model, slack_event_id, client_msg_id
 FROM messages
 WHERE conversation_id = $1
 ORDER BY created_at DESC
@@ -60,4 +61,50 @@ WHERE team_id = $1 AND channel_id = $2;
 
 -- name: Conversation :one
 SELECT * from conversations
-WHERE conversation_id = $1;
\ No newline at end of file
+WHERE conversation_id = $1;
+
+-- name: RecentConversationsByParticipant :many
+SELECT c.conversation_id, c.team_id, c.channel_id, c.thread_ts, c.created_at, c.updated_at, c.status
+FROM conversations c
+JOIN messages m ON m.conversation_id = c.conversation_id
+WHERE c.team_id = $1 AND m.sender_user_id = $2
+GROUP BY c.conversation_id
+ORDER BY MAX(m.created_at) DESC
+LIMIT $3;
+
+-- name: AddConversationRedactions :exec
+INSERT INTO conversation_redactions (conversation_id, redaction_count)
+VALUES ($1, $2)
+ON CONFLICT (conversation_id) DO UPDATE
+SET redaction_count = conversation_redactions.redaction_count + EXCLUDED.redaction_count,
+    updated_at = NOW();
+
+-- name: CountRetriedMessages :one
+SELECT COUNT(*) FROM messages m
+WHERE NOT m.is_bot_message
+  AND m.slack_event_id IS NULL
+  AND m.client_msg_id IS NULL
+  AND EXISTS (
+    SELECT 1 FROM messages earlier
+    WHERE earlier.conversation_id = m.conversation_id
+      AND earlier.sender_user_id = m.sender_user_id
+      AND earlier.message_text = m.message_text
+      AND NOT earlier.is_bot_message
+      AND (m.created_at, m.message_id) > (earlier.created_at, earlier.message_id)
+      AND m.created_at - earlier.created_at < @window_seconds::int * INTERVAL '1 second'
+  );
+
+-- name: DeleteRetriedMessages :execrows
+DELETE FROM messages m
+WHERE NOT m.is_bot_message
+  AND m.slack_event_id IS NULL
+  AND m.client_msg_id IS NULL
+  AND EXISTS (
+    SELECT 1 FROM messages earlier
+    WHERE earlier.conversation_id = m.conversation_id
+      AND earlier.sender_user_id = m.sender_user_id
+      AND earlier.message_text = m.message_text
+      AND NOT earlier.is_bot_message
+      AND (m.created_at, m.message_id) > (earlier.created_at, earlier.message_id)
+      AND m.created_at - earlier.created_at < @window_seconds::int * INTERVAL '1 second'
+  );
@@ -0,0 +1,16 @@
+-- name: SetConversationStatus :execrows
+WITH changed AS (
+    UPDATE conversations
+    SET status = $2, updated_at = NOW()
+    WHERE conversation_id = $1 AND status <> $2
+    RETURNING conversation_id
+)
+INSERT INTO conversation_status_changes (conversation_id, status, changed_by, reason)
+SELECT conversation_id, $2, $3, $4
+FROM changed;
+
+-- name: ConversationStatusChanges :many
+SELECT change_id, conversation_id, status, changed_by, reason, changed_at
+FROM conversation_status_changes
+WHERE conversation_id = $1
+ORDER BY changed_at, change_id;
@@ -0,0 +1,10 @@
+-- name: StoreConversationStep :exec
+INSERT INTO conversation_steps (step_id, conversation_id, tool, arguments, duration_ms, outcome, result, result_truncated, started_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (step_id) DO NOTHING;
+
+-- name: ConversationSteps :many
+SELECT step_id, conversation_id, tool, arguments, duration_ms, outcome, result, result_truncated, started_at
+FROM conversation_steps
+WHERE conversation_id = $1 AND started_at >= $2
+ORDER BY started_at, step_id;
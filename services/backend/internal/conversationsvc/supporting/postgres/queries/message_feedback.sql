@@ -0,0 +1,27 @@
+-- name: SaveFeedback :one
+INSERT INTO message_feedback (message_id, conversation_id, invocation_message_id, team_id, user_id, rating, comment)
+SELECT m.message_id, m.conversation_id,
+    (SELECT u.message_id FROM messages u
+     WHERE u.conversation_id = m.conversation_id AND NOT u.is_bot_message AND u.created_at <= m.created_at
+     ORDER BY u.created_at DESC
+     LIMIT 1),
+    c.team_id, sqlc.arg(user_id), sqlc.arg(rating), sqlc.arg(comment)
+FROM messages m
+JOIN conversations c ON c.conversation_id = m.conversation_id
+WHERE m.message_id = sqlc.arg(message_id) AND m.is_bot_message AND c.team_id = sqlc.arg(team_id)
+ON CONFLICT (message_id, user_id) DO UPDATE
+SET rating = EXCLUDED.rating,
+    comment = CASE WHEN EXCLUDED.comment = '' THEN message_feedback.comment ELSE EXCLUDED.comment END,
+    updated_at = NOW()
+RETURNING feedback_id, message_id, conversation_id, invocation_message_id, team_id, user_id, rating, comment, created_at, updated_at;
+
+-- name: FeedbackCounts :many
+SELECT date_trunc(sqlc.arg(bucket)::text, created_at)::timestamptz AS bucket_start,
+    COUNT(*) FILTER (WHERE rating = 'positive') AS positive,
+    COUNT(*) FILTER (WHERE rating = 'negative') AS negative
+FROM message_feedback
+WHERE team_id = ANY(sqlc.arg(team_ids)::text[])
+  AND created_at >= sqlc.arg(since)
+  AND created_at < sqlc.arg(until)
+GROUP BY bucket_start
+ORDER BY bucket_start;
@@ -0,0 +1,36 @@
+-- name: OrganizationWorkspaceIDs :many
+SELECT provider_project_id FROM integration
+WHERE business_id = $1 AND provider = 'slack'
+UNION
+SELECT slack_workspaces.team_id FROM slack_workspaces
+JOIN integration ON integration.provider_project_id = slack_workspaces.enterprise_id
+WHERE integration.business_id = $1 AND integration.provider = 'slack'
+ORDER BY provider_project_id;
+
+-- name: CountConversationsByTeams :one
+SELECT COUNT(*) FROM conversations WHERE team_id = ANY(sqlc.arg(team_ids)::text[]);
+
+-- name: DeleteConversationsByTeams :execrows
+DELETE FROM conversations WHERE team_id = ANY(sqlc.arg(team_ids)::text[]);
+
+-- name: DeleteChannelsByTeams :execrows
+DELETE FROM channels WHERE team_id = ANY(sqlc.arg(team_ids)::text[]);
+
+-- name: DeleteChannelContextsByTeams :execrows
+DELETE FROM channel_contexts WHERE team_id = ANY(sqlc.arg(team_ids)::text[]);
+
+-- name: DeleteChannelIntrosByTeams :execrows
+DELETE FROM channel_intros WHERE team_id = ANY(sqlc.arg(team_ids)::text[]);
+
+-- name: DeleteSlackTokensByTeams :execrows
+DELETE FROM slack_token WHERE team_id = ANY(sqlc.arg(team_ids)::text[]);
+
+-- name: DeleteSlackWorkspacesByTeams :execrows
+DELETE FROM slack_workspaces
+WHERE team_id = ANY(sqlc.arg(team_ids)::text[]) OR enterprise_id = ANY(sqlc.arg(team_ids)::text[]);
+
+-- name: DeleteSlackUserMappingsByOrganization :execrows
+DELETE FROM slack_user_mappings WHERE organization_id = $1;
+
+-- name: DeleteOrganizationWorkspaces :execrows
+DELETE FROM integration WHERE business_id = $1 AND provider = 'slack';
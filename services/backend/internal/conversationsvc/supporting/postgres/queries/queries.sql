@@ -1,5 +1,8 @@
 -- name: slackToken :one
-SELECT token FROM slack_token WHERE team_id = $1 and expired='f';
+SELECT token FROM slack_token
+WHERE expired='f' AND (team_id = $1 OR team_id = (SELECT enterprise_id FROM slack_workspaces WHERE slack_workspaces.team_id = $1))
+ORDER BY team_id = $1 DESC, created_at DESC
+LIMIT 1;
 
 -- name: saveSlackToken :exec
 INSERT INTO slack_token (token_id, team_id, token) VALUES ($1, $2, $3);
@@ -7,5 +10,15 @@ INSERT INTO slack_token (token_id, team_id, token) VALUES ($1, $2, $3);
 -- name: integrations :many
 SELECT * FROM integration WHERE business_id = $1 and active='t';
 
+-- name: businessIDByProviderProjectID :one
+SELECT business_id FROM integration
+WHERE provider = $1 and active='t'
+  and (provider_project_id = $2 or provider_project_id = (SELECT enterprise_id FROM slack_workspaces WHERE team_id = $2))
+ORDER BY provider_project_id = $2 DESC, created_at DESC LIMIT 1;
+
 -- name: saveIntegration :exec
-INSERT INTO integration (id, provider, status, business_id, provider_project_id) VALUES ($1, $2, $3, $4, $5);
\ No newline at end of file
+INSERT INTO integration (id, provider, status, business_id, provider_project_id) VALUES ($1, $2, $3, $4, $5);
+
+-- name: linkSlackWorkspace :exec
+INSERT INTO slack_workspaces (team_id, enterprise_id) VALUES ($1, $2)
+ON CONFLICT (team_id) DO UPDATE SET enterprise_id = EXCLUDED.enterprise_id;
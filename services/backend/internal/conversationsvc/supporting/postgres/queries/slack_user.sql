@@ -0,0 +1,21 @@
+-- name: SlackUserMapping :one
+SELECT team_id, slack_user_id, organization_id, user_id, source, created_at, updated_at
+FROM slack_user_mappings
+WHERE team_id = $1 AND slack_user_id = $2;
+
+-- name: SlackUserMappingsByOrganization :many
+SELECT team_id, slack_user_id, organization_id, user_id, source, created_at, updated_at
+FROM slack_user_mappings
+WHERE organization_id = $1
+ORDER BY team_id, slack_user_id;
+
+-- name: SaveSlackUserMapping :one
+INSERT INTO slack_user_mappings (team_id, slack_user_id, organization_id, user_id, source)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (team_id, slack_user_id)
+DO UPDATE SET organization_id = EXCLUDED.organization_id, user_id = EXCLUDED.user_id, source = EXCLUDED.source, updated_at = NOW()
+RETURNING team_id, slack_user_id, organization_id, user_id, source, created_at, updated_at;
+
+-- name: DeleteSlackUserMapping :execrows
+DELETE FROM slack_user_mappings
+WHERE team_id = $1 AND slack_user_id = $2 AND organization_id = $3;
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/repositorytest"
+	"github.com/73ai/infragpt/services/backend/internal/generic/postgrestest"
+	"github.com/google/uuid"
+)
+
+func TestMain(m *testing.M) {
+	postgrestest.Main(m)
+}
+
+type fixture struct {
+	db *BackendDB
+}
+
+func (f fixture) ConversationRepository() domain.ConversationRepository {
+	return f.db
+}
+
+func (f fixture) ChannelRepository() domain.ChannelRepository {
+	return f.db
+}
+
+func (f fixture) FeedbackRepository() domain.FeedbackRepository {
+	return f.db
+}
+
+func (f fixture) UserMappingRepository() domain.UserMappingRepository {
+	return f.db
+}
+
+func (f fixture) Reset(t *testing.T) {
+	postgrestest.Truncate(t, f.db.DB(), "conversations", "conversation_redactions", "conversation_status_changes", "messages", "channels", "channel_contexts", "message_feedback", "channel_intros", "slack_user_mappings")
+}
+
+func TestRepositories(t *testing.T) {
+	db := postgrestest.DB(t)
+	repositorytest.Ensure(t, fixture{db: &BackendDB{db: db, Querier: New(db)}})
+}
+
+func TestRemoveRetriedMessages(t *testing.T) {
+	db := postgrestest.DB(t)
+	repo := &BackendDB{db: db, Querier: New(db)}
+	fixture{db: repo}.Reset(t)
+	ctx := context.Background()
+
+	var conversationID uuid.UUID
+	err := db.QueryRowContext(ctx, `INSERT INTO conversations (team_id, channel_id, thread_ts) VALUES ('T1', 'C1', '1.0') RETURNING conversation_id`).Scan(&conversationID)
+	if err != nil {
+		t.Fatalf("failed to create conversation: %v", err)
+	}
+
+	first := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	messages := []struct {
+		ts, sender, text string
+		bot              bool
+		eventID          sql.NullString
+		at               time.Time
+	}{
+		{ts: "1.1", sender: "U1", text: "restart api", at: first},
+		// Slack retried the event a minute later.
+		{ts: "1.2", sender: "U1", text: "restart api", at: first.Add(time.Minute)},
+		// The user asked again well after any retry.
+		{ts: "1.3", sender: "U1", text: "restart api", at: first.Add(time.Hour)},
+		// A different sender saying the same thing.
+		{ts: "1.4", sender: "U2", text: "restart api", at: first.Add(time.Minute)},
+		// The agent repeating itself.
+		{ts: "1.5", sender: "B1", text: "Restarted.", bot: true, at: first},
+		{ts: "1.6", sender: "B1", text: "Restarted.", bot: true, at: first.Add(time.Minute)},
+		// Deduplicated on insert by its event ID.
+		{ts: "1.7", sender: "U1", text: "restart api", eventID: sql.NullString{String: "Ev1", Valid: true}, at: first.Add(2 * time.Minute)},
+	}
+	for _, m := range messages {
+		_, err := db.ExecContext(ctx, `INSERT INTO messages (conversation_id, slack_message_ts, sender_user_id, message_text, is_bot_message, slack_event_id, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			conversationID, m.ts, m.sender, m.text, m.bot, m.eventID, m.at)
+		if err != nil {
+			t.Fatalf("failed to store message %s: %v", m.ts, err)
+		}
+	}
+
+	count, err := repo.RemoveRetriedMessages(ctx, 6*time.Minute, true)
+	if err != nil || count != 1 {
+		t.Fatalf("RemoveRetriedMessages() dry run = (%d, %v), want (1, nil)", count, err)
+	}
+	removed, err := repo.RemoveRetriedMessages(ctx, 6*time.Minute, false)
+	if err != nil || removed != 1 {
+		t.Fatalf("RemoveRetriedMessages() = (%d, %v), want (1, nil)", removed, err)
+	}
+
+	var remaining []string
+	rows, err := db.QueryContext(ctx, `SELECT slack_message_ts FROM messages ORDER BY slack_message_ts`)
+	if err != nil {
+		t.Fatalf("failed to list messages: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var ts string
+		if err := rows.Scan(&ts); err != nil {
+			t.Fatalf("failed to scan message: %v", err)
+		}
+		remaining = append(remaining, ts)
+	}
+	if want := []string{"1.1", "1.3", "1.4", "1.5", "1.6", "1.7"}; !slices.Equal(remaining, want) {
+		t.Errorf("remaining messages = %v, want %v", remaining, want)
+	}
+}
@@ -8,4 +8,23 @@ CREATE TABLE channels (
     PRIMARY KEY (team_id, channel_id)
 );
 
-CREATE INDEX idx_channels_team_monitored ON channels(team_id, is_monitored);
\ No newline at end of file
+CREATE INDEX idx_channels_team_monitored ON channels(team_id, is_monitored);
+
+-- Default context bindings injected into conversations started in a channel
+CREATE TABLE channel_contexts (
+    team_id VARCHAR(36) NOT NULL,
+    channel_id VARCHAR(36) NOT NULL,
+    repositories TEXT[] NOT NULL DEFAULT '{}',
+    kubernetes_namespaces TEXT[] NOT NULL DEFAULT '{}',
+    gcp_projects TEXT[] NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (team_id, channel_id)
+);
+
+-- When the bot last introduced itself in a channel, to avoid repeating it
+CREATE TABLE channel_intros (
+    team_id VARCHAR(36) NOT NULL,
+    channel_id VARCHAR(36) NOT NULL,
+    posted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (team_id, channel_id)
+);
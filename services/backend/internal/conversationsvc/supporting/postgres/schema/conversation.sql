@@ -6,8 +6,28 @@ CREATE TABLE conversations (
     thread_ts VARCHAR(36) NOT NULL, -- Slack thread timestamp (unique per channel)
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    status VARCHAR(16) NOT NULL DEFAULT 'active', -- active or escalated
     UNIQUE(team_id, channel_id, thread_ts)
 );
 
 CREATE INDEX idx_conversations_team_channel ON conversations(team_id, channel_id);
-CREATE INDEX idx_conversations_thread_ts ON conversations(team_id, channel_id, thread_ts);
\ No newline at end of file
+CREATE INDEX idx_conversations_thread_ts ON conversations(team_id, channel_id, thread_ts);
+
+-- Secrets redacted from a conversation before it was sent to the agent or Slack
+CREATE TABLE conversation_redactions (
+    conversation_id UUID PRIMARY KEY REFERENCES conversations(conversation_id) ON DELETE CASCADE,
+    redaction_count INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Conversation status changes - handoffs to a human and back to the agent
+CREATE TABLE conversation_status_changes (
+    change_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    conversation_id UUID NOT NULL REFERENCES conversations(conversation_id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL,
+    changed_by VARCHAR(36) NOT NULL, -- Slack user ID, or "agent"
+    reason TEXT NOT NULL DEFAULT '',
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_conversation_status_changes_conversation ON conversation_status_changes(conversation_id, changed_at);
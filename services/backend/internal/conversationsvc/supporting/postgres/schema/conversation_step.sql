@@ -0,0 +1,14 @@
+-- Conversation steps table - tools the agent used while answering, in order
+CREATE TABLE conversation_steps (
+    step_id UUID PRIMARY KEY,
+    conversation_id UUID NOT NULL REFERENCES conversations(conversation_id) ON DELETE CASCADE,
+    tool VARCHAR(64) NOT NULL,
+    arguments TEXT[] NOT NULL DEFAULT '{}', -- Redacted before they are stored
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    outcome VARCHAR(16) NOT NULL,
+    result TEXT NOT NULL DEFAULT '',
+    result_truncated BOOLEAN NOT NULL DEFAULT FALSE,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_conversation_steps_conversation_started ON conversation_steps(conversation_id, started_at);
@@ -10,8 +10,13 @@ CREATE TABLE messages (
     message_text TEXT NOT NULL,
     is_bot_message BOOLEAN NOT NULL DEFAULT FALSE,
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    model VARCHAR(100), -- LLM model used to answer the message, NULL for the agent default
+    slack_event_id VARCHAR(64), -- Slack Events API event_id, NULL for bot messages
+    client_msg_id VARCHAR(64), -- Slack client_msg_id, shared by events for the same user message
     UNIQUE(conversation_id, slack_message_ts)
 );
 
 CREATE INDEX idx_messages_conversation_id ON messages(conversation_id);
-CREATE INDEX idx_messages_created_at ON messages(conversation_id, created_at DESC);
\ No newline at end of file
+CREATE INDEX idx_messages_created_at ON messages(conversation_id, created_at DESC);
+CREATE UNIQUE INDEX idx_messages_slack_event_id ON messages(slack_event_id) WHERE slack_event_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_messages_client_msg_id ON messages(conversation_id, client_msg_id) WHERE client_msg_id IS NOT NULL;
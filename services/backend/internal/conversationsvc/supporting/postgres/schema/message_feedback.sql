@@ -0,0 +1,16 @@
+-- Message feedback table - thumbs up/down and comments on agent replies
+CREATE TABLE message_feedback (
+    feedback_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    message_id UUID NOT NULL REFERENCES messages(message_id) ON DELETE CASCADE, -- The bot reply being rated
+    conversation_id UUID NOT NULL REFERENCES conversations(conversation_id) ON DELETE CASCADE,
+    invocation_message_id UUID REFERENCES messages(message_id) ON DELETE SET NULL, -- The user message that invoked the agent
+    team_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    rating VARCHAR(16) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE(message_id, user_id)
+);
+
+CREATE INDEX idx_message_feedback_team_created ON message_feedback(team_id, created_at);
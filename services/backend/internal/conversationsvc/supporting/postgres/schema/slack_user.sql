@@ -0,0 +1,13 @@
+-- Slack user mappings - links a Slack user to an InfraGPT user in the workspace's organization
+CREATE TABLE slack_user_mappings (
+    team_id VARCHAR(36) NOT NULL,
+    slack_user_id VARCHAR(36) NOT NULL,
+    organization_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    source VARCHAR(16) NOT NULL, -- 'email' when matched automatically, 'manual' when set by an admin
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (team_id, slack_user_id)
+);
+
+CREATE INDEX idx_slack_user_mappings_organization ON slack_user_mappings(organization_id);
@@ -0,0 +1,9 @@
+-- Workspaces of an Enterprise Grid organization installed org-wide share the
+-- enterprise's bot token, which is stored under the enterprise ID.
+CREATE TABLE slack_workspaces (
+    team_id VARCHAR(36) PRIMARY KEY,
+    enterprise_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_slack_workspaces_enterprise ON slack_workspaces(enterprise_id);
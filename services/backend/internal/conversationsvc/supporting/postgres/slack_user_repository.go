@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/google/uuid"
+)
+
+var _ domain.UserMappingRepository = (*BackendDB)(nil)
+
+func (db *BackendDB) SlackUserMapping(ctx context.Context, teamID, slackUserID string) (backend.SlackUserMapping, error) {
+	mapping, err := db.Querier.SlackUserMapping(ctx, SlackUserMappingParams{
+		TeamID:      teamID,
+		SlackUserID: slackUserID,
+	})
+	if err != nil {
+		return backend.SlackUserMapping{}, err
+	}
+	return toSlackUserMapping(mapping), nil
+}
+
+func (db *BackendDB) SlackUserMappings(ctx context.Context, organizationID uuid.UUID) ([]backend.SlackUserMapping, error) {
+	rows, err := db.Querier.SlackUserMappingsByOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list slack user mappings: %w", err)
+	}
+
+	mappings := make([]backend.SlackUserMapping, len(rows))
+	for i, row := range rows {
+		mappings[i] = toSlackUserMapping(row)
+	}
+	return mappings, nil
+}
+
+func (db *BackendDB) SaveSlackUserMapping(ctx context.Context, mapping backend.SlackUserMapping) (backend.SlackUserMapping, error) {
+	saved, err := db.Querier.SaveSlackUserMapping(ctx, SaveSlackUserMappingParams{
+		TeamID:         mapping.TeamID,
+		SlackUserID:    mapping.SlackUserID,
+		OrganizationID: mapping.OrganizationID,
+		UserID:         mapping.UserID,
+		Source:         string(mapping.Source),
+	})
+	if err != nil {
+		return backend.SlackUserMapping{}, fmt.Errorf("failed to save slack user mapping: %w", err)
+	}
+	return toSlackUserMapping(saved), nil
+}
+
+func (db *BackendDB) DeleteSlackUserMapping(ctx context.Context, organizationID uuid.UUID, teamID, slackUserID string) error {
+	deleted, err := db.Querier.DeleteSlackUserMapping(ctx, DeleteSlackUserMappingParams{
+		TeamID:         teamID,
+		SlackUserID:    slackUserID,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete slack user mapping: %w", err)
+	}
+	if deleted == 0 {
+		return domain.ErrSlackUserMappingNotFound
+	}
+	return nil
+}
+
+func toSlackUserMapping(mapping SlackUserMapping) backend.SlackUserMapping {
+	return backend.SlackUserMapping{
+		TeamID:         mapping.TeamID,
+		SlackUserID:    mapping.SlackUserID,
+		OrganizationID: mapping.OrganizationID,
+		UserID:         mapping.UserID,
+		Source:         backend.SlackUserMappingSource(mapping.Source),
+		UpdatedAt:      mapping.UpdatedAt,
+	}
+}
@@ -17,9 +17,9 @@ func (s *Slack) handleAppMention(ctx context.Context, teamID string, event *slac
 		return fmt.Errorf("error getting team token for team_id:%s err:%w", teamID, err)
 	}
 
-	teamClient := slack.New(teamToken)
+	teamClient := slack.New(teamToken, slack.OptionHTTPClient(httpClient))
 
-	channelInfo, err := teamClient.GetConversationInfo(&slack.GetConversationInfoInput{
+	channelInfo, err := teamClient.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
 		ChannelID: event.Channel,
 	})
 	if err != nil {
@@ -38,7 +38,7 @@ func (s *Slack) handleAppMention(ctx context.Context, teamID string, event *slac
 		}
 	}
 
-	at, err := teamClient.AuthTest()
+	at, err := teamClient.AuthTestContext(ctx)
 	if err != nil {
 		return fmt.Errorf("error authenticating team: %w", err)
 	}
@@ -48,12 +48,12 @@ func (s *Slack) handleAppMention(ctx context.Context, teamID string, event *slac
 	// Extract text without the bot mention
 	text := strings.TrimSpace(strings.Replace(event.Text, fmt.Sprintf("<@%s>", botUserID), "", -1))
 
-	if err := teamClient.AddReaction("eyes", slack.NewRefToMessage(event.Channel, event.TimeStamp)); err != nil {
+	if err := teamClient.AddReactionContext(ctx, "eyes", slack.NewRefToMessage(event.Channel, event.TimeStamp)); err != nil {
 		slog.Error("Error adding reaction to app mention", "error", err, "channelID", event.Channel, "timestamp", event.TimeStamp)
 	}
 
 	// Get requester info
-	requesterInfo, err := teamClient.GetUserInfo(event.User)
+	requesterInfo, err := teamClient.GetUserInfoContext(ctx, event.User)
 	requesterName := ""
 	requesterUsername := ""
 	requesterEmail := ""
@@ -96,5 +96,7 @@ func (s *Slack) handleAppMention(ctx context.Context, teamID string, event *slac
 		MessageTS:   event.TimeStamp,
 	}
 
-	return handler(ctx, command)
+	return whileThinking(ctx, teamClient, event.Channel, event.TimeStamp, func() error {
+		return handler(ctx, command)
+	})
 }
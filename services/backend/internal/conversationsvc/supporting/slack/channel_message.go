@@ -14,6 +14,10 @@ import (
 func (s *Slack) handleChannelMessage(ctx context.Context, teamID string, event *slackevents.MessageEvent, handler func(context.Context, domain.UserCommand) error) error {
 	slog.Info("Handling channel message event", "teamID", teamID, "channelID", event.Channel, "user", event.User, "text", event.Text, "bot", event.BotID, "subType", event.SubType, "threadTS", event.ThreadTimeStamp,
 		"e", event)
+	if event.SubType == "message_deleted" {
+		return s.handleMessageDeleted(ctx, teamID, event)
+	}
+
 	// NOTE: This is a workaround for the bot user ID that is used in testing datadog bot.
 	if event.BotID != "B090TCWJFDW" {
 		if event.BotID != "" {
@@ -30,7 +34,7 @@ func (s *Slack) handleChannelMessage(ctx context.Context, teamID string, event *
 		return fmt.Errorf("error getting team token for team_id:%s err:%w", teamID, err)
 	}
 
-	teamClient := slack.New(teamToken)
+	teamClient := slack.New(teamToken, slack.OptionHTTPClient(httpClient))
 
 	isMonitored, err := s.channelRepository.IsChannelMonitored(ctx, teamID, event.Channel)
 	if err != nil {
@@ -41,7 +45,7 @@ func (s *Slack) handleChannelMessage(ctx context.Context, teamID string, event *
 		return nil
 	}
 
-	channelInfo, err := teamClient.GetConversationInfo(&slack.GetConversationInfoInput{
+	channelInfo, err := teamClient.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
 		ChannelID: event.Channel,
 	})
 	if err != nil {
@@ -63,7 +67,7 @@ func (s *Slack) handleChannelMessage(ctx context.Context, teamID string, event *
 
 	isMonitored = true
 
-	at, err := teamClient.AuthTest()
+	at, err := teamClient.AuthTestContext(ctx)
 	if err != nil {
 		return fmt.Errorf("error authenticating team: %w", err)
 	}
@@ -118,11 +122,11 @@ func (s *Slack) handleChannelMessage(ctx context.Context, teamID string, event *
 		text = strings.TrimSpace(b.String())
 	}
 
-	if err := teamClient.AddReaction("eyes", slack.NewRefToMessage(event.Channel, event.TimeStamp)); err != nil {
+	if err := teamClient.AddReactionContext(ctx, "eyes", slack.NewRefToMessage(event.Channel, event.TimeStamp)); err != nil {
 		slog.Error("Error adding reaction to app mention", "error", err, "channelID", event.Channel, "timestamp", event.TimeStamp)
 	}
 
-	requesterInfo, err := teamClient.GetUserInfo(event.User)
+	requesterInfo, err := teamClient.GetUserInfoContext(ctx, event.User)
 	requesterName := ""
 	requesterUsername := ""
 	requesterEmail := ""
@@ -168,5 +172,7 @@ func (s *Slack) handleChannelMessage(ctx context.Context, teamID string, event *
 		MessageTS:   event.TimeStamp,
 	}
 
-	return handler(ctx, command)
+	return whileThinking(ctx, teamClient, event.Channel, event.TimeStamp, func() error {
+		return handler(ctx, command)
+	})
 }
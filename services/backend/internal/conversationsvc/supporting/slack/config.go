@@ -2,7 +2,9 @@ package slack
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
 	"github.com/slack-go/slack"
@@ -10,9 +12,13 @@ import (
 )
 
 type Config struct {
-	ClientID                 string                          `mapstructure:"client_id"`
-	ClientSecret             string                          `mapstructure:"client_secret"`
-	AppToken                 string                          `mapstructure:"app_token"`
+	ClientID     string `mapstructure:"client_id"`
+	ClientSecret string `mapstructure:"client_secret"`
+	AppToken     string `mapstructure:"app_token"`
+	DashboardURL string `mapstructure:"dashboard_url"`
+	// MaxSnippetBytes caps the message text the "Analyze with InfraGPT"
+	// shortcut sends to the agent.
+	MaxSnippetBytes          int                             `mapstructure:"max_snippet_bytes"`
 	WorkSpaceTokenRepository domain.WorkSpaceTokenRepository `mapstructure:"-"`
 	ChannelRepository        domain.ChannelRepository        `mapstructure:"-"`
 }
@@ -34,6 +40,11 @@ func (c Config) New(ctx context.Context) (*Slack, error) {
 		return nil, fmt.Errorf("client secret is required")
 	}
 
+	maxSnippetBytes := c.MaxSnippetBytes
+	if maxSnippetBytes <= 0 {
+		maxSnippetBytes = defaultMaxSnippetBytes
+	}
+
 	return &Slack{
 		clientID:          c.ClientID,
 		clientSecret:      c.ClientSecret,
@@ -41,5 +52,36 @@ func (c Config) New(ctx context.Context) (*Slack, error) {
 		socketClient:      socketClient,
 		tokenRepository:   c.WorkSpaceTokenRepository,
 		channelRepository: c.ChannelRepository,
+		dashboardURL:      c.DashboardURL,
+		maxSnippetBytes:   maxSnippetBytes,
 	}, nil
 }
+
+// Check validates the app-level token by asking Slack for a Socket Mode URL,
+// and the OAuth client credentials by exchanging a code Slack cannot know,
+// which it only reports as invalid once the credentials are accepted.
+func (c Config) Check(ctx context.Context) error {
+	if !strings.HasPrefix(c.AppToken, "xapp-") {
+		return errors.New("app_token must be an app-level token starting with xapp-; generate one with the connections:write scope under Basic Information in the Slack app settings")
+	}
+	client := slack.New("", slack.OptionAppLevelToken(c.AppToken), slack.OptionHTTPClient(httpClient))
+	if _, _, err := client.StartSocketModeContext(ctx); err != nil {
+		var slackErr slack.SlackErrorResponse
+		if errors.As(err, &slackErr) {
+			return fmt.Errorf("slack rejected app_token with %q; check it belongs to this app and has the connections:write scope, and that Socket Mode is enabled", slackErr.Err)
+		}
+		return fmt.Errorf("failed to reach Slack with app_token: %w", err)
+	}
+
+	_, err := slack.GetOAuthV2ResponseContext(ctx, httpClient, c.ClientID, c.ClientSecret, "preflight", "")
+	var slackErr slack.SlackErrorResponse
+	switch {
+	case errors.As(err, &slackErr) && slackErr.Err == "invalid_code":
+		return nil
+	case errors.As(err, &slackErr):
+		return fmt.Errorf("slack rejected client_id and client_secret with %q; copy both from Basic Information in the settings of the app app_token belongs to", slackErr.Err)
+	case err != nil:
+		return fmt.Errorf("failed to reach Slack with the OAuth client credentials: %w", err)
+	}
+	return errors.New("slack accepted a made-up OAuth code; client_id and client_secret could not be verified")
+}
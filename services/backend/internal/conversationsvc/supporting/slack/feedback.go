@@ -0,0 +1,281 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
+	"github.com/google/uuid"
+	"github.com/slack-go/slack"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	feedbackBlockID       = "agent_feedback"
+	feedbackStatusBlockID = "agent_feedback_status"
+
+	actionFeedbackPositive = "feedback_positive"
+	actionFeedbackNegative = "feedback_negative"
+	actionFeedbackComment  = "feedback_comment"
+
+	feedbackModalCallbackID = "feedback_modal"
+	feedbackRatingBlockID   = "feedback_rating"
+	feedbackCommentBlockID  = "feedback_comment"
+	feedbackRatingActionID  = "rating"
+	feedbackCommentActionID = "comment"
+)
+
+// feedbackModalMetadata is carried in the modal's private_metadata so the
+// submission can be tied back to the rated reply.
+type feedbackModalMetadata struct {
+	MessageID string `json:"message_id"`
+	Channel   string `json:"channel"`
+	MessageTS string `json:"message_ts"`
+	ThreadTS  string `json:"thread_ts"`
+}
+
+func (s *Slack) OnFeedback(handler func(ctx context.Context, event domain.FeedbackEvent) error) {
+	s.feedback = handler
+}
+
+func (s *Slack) ReplyWithFeedback(ctx context.Context, t domain.SlackThread, message string, messageID uuid.UUID) error {
+	return s.reply(ctx, t, message, feedbackBlocks(messageID, "")...)
+}
+
+// feedbackBlocks renders the rating buttons under an agent reply. Once a rating
+// is recorded its button is highlighted and a short note confirms it.
+func feedbackBlocks(messageID uuid.UUID, recorded domain.FeedbackRating) []slack.Block {
+	value := messageID.String()
+	positive := slack.NewButtonBlockElement(actionFeedbackPositive, value, emojiText(":thumbsup: Helpful"))
+	negative := slack.NewButtonBlockElement(actionFeedbackNegative, value, emojiText(":thumbsdown: Not helpful"))
+	comment := slack.NewButtonBlockElement(actionFeedbackComment, value, plainText("Tell us more"))
+
+	switch recorded {
+	case domain.FeedbackRatingPositive:
+		positive.Style = slack.StylePrimary
+	case domain.FeedbackRatingNegative:
+		negative.Style = slack.StylePrimary
+	}
+
+	blocks := []slack.Block{slack.NewActionBlock(feedbackBlockID, positive, negative, comment)}
+	if recorded != "" {
+		blocks = append(blocks, slack.NewContextBlock(feedbackStatusBlockID,
+			slack.NewTextBlockObject(slack.MarkdownType, "Thanks, your feedback was recorded: "+ratingLabel(recorded), false, false)))
+	}
+	return blocks
+}
+
+func ratingLabel(rating domain.FeedbackRating) string {
+	if rating == domain.FeedbackRatingPositive {
+		return ":thumbsup: helpful"
+	}
+	return ":thumbsdown: not helpful"
+}
+
+func emojiText(text string) *slack.TextBlockObject {
+	return slack.NewTextBlockObject(slack.PlainTextType, text, true, false)
+}
+
+// withFeedbackStatus swaps the feedback blocks of a posted reply for ones that
+// show the recorded rating, leaving the reply's text untouched.
+func withFeedbackStatus(blocks []slack.Block, messageID uuid.UUID, rating domain.FeedbackRating) []slack.Block {
+	updated := make([]slack.Block, 0, len(blocks)+1)
+	for _, block := range blocks {
+		if block.ID() != feedbackBlockID && block.ID() != feedbackStatusBlockID {
+			updated = append(updated, block)
+		}
+	}
+	return append(updated, feedbackBlocks(messageID, rating)...)
+}
+
+func (s *Slack) handleBlockActions(ctx context.Context, callback slack.InteractionCallback) error {
+	for _, action := range callback.ActionCallback.BlockActions {
+		var rating domain.FeedbackRating
+		switch action.ActionID {
+		case actionFeedbackPositive:
+			rating = domain.FeedbackRatingPositive
+		case actionFeedbackNegative:
+			rating = domain.FeedbackRatingNegative
+		case actionFeedbackComment:
+		default:
+			continue
+		}
+
+		messageID, err := uuid.Parse(action.Value)
+		if err != nil {
+			return fmt.Errorf("invalid feedback message id %q: %w", action.Value, err)
+		}
+
+		if rating == "" {
+			if err := s.openFeedbackModal(ctx, callback, messageID); err != nil {
+				return fmt.Errorf("failed to open feedback modal: %w", err)
+			}
+			continue
+		}
+
+		if err := s.recordFeedback(ctx, domain.FeedbackEvent{
+			TeamID:    callback.Team.ID,
+			UserID:    callback.User.ID,
+			MessageID: messageID,
+			Rating:    rating,
+		}); err != nil {
+			return err
+		}
+
+		if err := s.updateFeedbackStatus(ctx, callback.Team.ID, callback.Channel.ID, callback.Message, messageID, rating); err != nil {
+			return fmt.Errorf("failed to show recorded feedback: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Slack) openFeedbackModal(ctx context.Context, callback slack.InteractionCallback, messageID uuid.UUID) error {
+	metadata, err := json.Marshal(feedbackModalMetadata{
+		MessageID: messageID.String(),
+		Channel:   callback.Channel.ID,
+		MessageTS: callback.Message.Timestamp,
+		ThreadTS:  callback.Message.ThreadTimestamp,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode modal metadata: %w", err)
+	}
+
+	teamClient, err := s.teamClient(ctx, callback.Team.ID)
+	if err != nil {
+		return err
+	}
+
+	_, err = teamClient.OpenViewContext(ctx, callback.TriggerID, feedbackModal(string(metadata)))
+	return err
+}
+
+func feedbackModal(metadata string) slack.ModalViewRequest {
+	rating := slack.NewRadioButtonsBlockElement(feedbackRatingActionID,
+		slack.NewOptionBlockObject(string(domain.FeedbackRatingPositive), emojiText(":thumbsup: Helpful"), nil),
+		slack.NewOptionBlockObject(string(domain.FeedbackRatingNegative), emojiText(":thumbsdown: Not helpful"), nil),
+	)
+
+	commentInput := slack.NewPlainTextInputBlockElement(plainText("What was missing, wrong or especially useful?"), feedbackCommentActionID)
+	commentInput.Multiline = true
+	commentInput.MaxLength = 2000
+	comment := slack.NewInputBlock(feedbackCommentBlockID, plainText("Tell us more"), nil, commentInput)
+	comment.Optional = true
+
+	return slack.ModalViewRequest{
+		Type:            slack.VTModal,
+		CallbackID:      feedbackModalCallbackID,
+		Title:           plainText("Feedback"),
+		Submit:          plainText("Send"),
+		Close:           plainText("Cancel"),
+		PrivateMetadata: metadata,
+		Blocks: slack.Blocks{BlockSet: []slack.Block{
+			slack.NewInputBlock(feedbackRatingBlockID, plainText("Was this reply helpful?"), nil, rating),
+			comment,
+		}},
+	}
+}
+
+func (s *Slack) handleViewSubmission(ctx context.Context, callback slack.InteractionCallback) error {
+	if callback.View.CallbackID != feedbackModalCallbackID || callback.View.State == nil {
+		return nil
+	}
+
+	var metadata feedbackModalMetadata
+	if err := json.Unmarshal([]byte(callback.View.PrivateMetadata), &metadata); err != nil {
+		return fmt.Errorf("invalid feedback modal metadata: %w", err)
+	}
+	messageID, err := uuid.Parse(metadata.MessageID)
+	if err != nil {
+		return fmt.Errorf("invalid feedback message id %q: %w", metadata.MessageID, err)
+	}
+
+	values := callback.View.State.Values
+	rating := domain.FeedbackRating(values[feedbackRatingBlockID][feedbackRatingActionID].SelectedOption.Value)
+	if err := s.recordFeedback(ctx, domain.FeedbackEvent{
+		TeamID:    callback.Team.ID,
+		UserID:    callback.User.ID,
+		MessageID: messageID,
+		Rating:    rating,
+		Comment:   strings.TrimSpace(values[feedbackCommentBlockID][feedbackCommentActionID].Value),
+	}); err != nil {
+		return err
+	}
+
+	message, err := s.postedMessage(ctx, callback.Team.ID, metadata)
+	if err != nil {
+		return fmt.Errorf("failed to find rated reply: %w", err)
+	}
+	if err := s.updateFeedbackStatus(ctx, callback.Team.ID, metadata.Channel, message, messageID, rating); err != nil {
+		return fmt.Errorf("failed to show recorded feedback: %w", err)
+	}
+	return nil
+}
+
+func (s *Slack) recordFeedback(ctx context.Context, event domain.FeedbackEvent) (err error) {
+	ctx, span := tracing.Start(ctx, "slack.feedback",
+		attribute.String("slack.team_id", event.TeamID),
+		attribute.String("feedback.rating", string(event.Rating)))
+	defer func() { tracing.End(span, err) }()
+
+	if s.feedback == nil {
+		return nil
+	}
+	if err := s.feedback(ctx, event); err != nil {
+		return fmt.Errorf("failed to record feedback: %w", err)
+	}
+	return nil
+}
+
+// postedMessage fetches a reply the modal was opened from; modal submissions
+// do not carry the message itself.
+func (s *Slack) postedMessage(ctx context.Context, teamID string, metadata feedbackModalMetadata) (slack.Message, error) {
+	teamClient, err := s.teamClient(ctx, teamID)
+	if err != nil {
+		return slack.Message{}, err
+	}
+
+	threadTS := metadata.ThreadTS
+	if threadTS == "" {
+		threadTS = metadata.MessageTS
+	}
+	messages, _, _, err := teamClient.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: metadata.Channel,
+		Timestamp: threadTS,
+		Oldest:    metadata.MessageTS,
+		Latest:    metadata.MessageTS,
+		Inclusive: true,
+	})
+	if err != nil {
+		return slack.Message{}, fmt.Errorf("failed to get thread replies: %w", err)
+	}
+	for _, message := range messages {
+		if message.Timestamp == metadata.MessageTS {
+			return message, nil
+		}
+	}
+	return slack.Message{}, fmt.Errorf("message %s not found in thread %s", metadata.MessageTS, threadTS)
+}
+
+func (s *Slack) updateFeedbackStatus(ctx context.Context, teamID, channelID string, message slack.Message, messageID uuid.UUID, rating domain.FeedbackRating) error {
+	teamClient, err := s.teamClient(ctx, teamID)
+	if err != nil {
+		return err
+	}
+
+	_, _, _, err = teamClient.UpdateMessageContext(ctx, channelID, message.Timestamp,
+		slack.MsgOptionText(message.Text, false),
+		slack.MsgOptionBlocks(withFeedbackStatus(message.Blocks.BlockSet, messageID, rating)...),
+	)
+	return err
+}
+
+func (s *Slack) teamClient(ctx context.Context, teamID string) (*slack.Client, error) {
+	teamToken, err := s.tokenRepository.GetToken(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team token: %w", err)
+	}
+	return slack.New(teamToken, slack.OptionHTTPClient(httpClient)), nil
+}
@@ -0,0 +1,162 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
+	"github.com/google/uuid"
+	"github.com/slack-go/slack"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (s *Slack) OnHomeOpened(handler func(ctx context.Context, event domain.HomeOpened) error) {
+	s.homeOpened = handler
+}
+
+func (s *Slack) PublishHome(ctx context.Context, view domain.HomeView) (err error) {
+	ctx, span := tracing.Start(ctx, "slack.publish_home",
+		attribute.String("slack.team_id", view.TeamID))
+	defer func() { tracing.End(span, err) }()
+
+	teamToken, err := s.tokenRepository.GetToken(ctx, view.TeamID)
+	if err != nil {
+		return fmt.Errorf("failed to get team token: %w", err)
+	}
+	teamClient := slack.New(teamToken, slack.OptionHTTPClient(httpClient))
+
+	permalinks := make(map[uuid.UUID]string, len(view.Conversations))
+	for _, c := range view.Conversations {
+		permalink, err := teamClient.GetPermalinkContext(ctx, &slack.PermalinkParameters{Channel: c.ChannelID, Ts: c.ThreadTS})
+		if err != nil {
+			slog.Error("Error getting conversation permalink", "error", err, "channelID", c.ChannelID, "threadTS", c.ThreadTS)
+			continue
+		}
+		permalinks[c.ID] = permalink
+	}
+
+	appID, _ := s.appID.Load().(string)
+	_, err = teamClient.PublishViewContext(ctx, view.UserID, slack.HomeTabViewRequest{
+		Type:   slack.VTHomeTab,
+		Blocks: slack.Blocks{BlockSet: homeBlocks(view, appID, s.dashboardURL, permalinks)},
+	}, "")
+	if err != nil {
+		return fmt.Errorf("failed to publish home view: %w", err)
+	}
+	return nil
+}
+
+func homeBlocks(view domain.HomeView, appID, dashboardURL string, permalinks map[uuid.UUID]string) []slack.Block {
+	blocks := []slack.Block{
+		slack.NewHeaderBlock(plainText("InfraGPT")),
+	}
+
+	if !view.Linked {
+		blocks = append(blocks, markdownSection("This workspace isn't connected to an InfraGPT organization yet. Finish setup in the dashboard to connect your infrastructure."))
+		if dashboardURL != "" {
+			blocks = append(blocks, slack.NewActionBlock("home_setup", linkButton("open_dashboard", "Finish setup", dashboardURL)))
+		}
+		return blocks
+	}
+
+	blocks = append(blocks, slack.NewHeaderBlock(plainText("Integrations")))
+	if len(view.Integrations) == 0 {
+		blocks = append(blocks, markdownSection("No integrations are connected yet. Connect GitHub, GCP and more so InfraGPT can look into your infrastructure."))
+		if dashboardURL != "" {
+			blocks = append(blocks, slack.NewActionBlock("home_setup", linkButton("connect_integration", "Connect an integration", dashboardURL)))
+		}
+	} else {
+		var lines []string
+		for _, integration := range view.Integrations {
+			lines = append(lines, fmt.Sprintf("• *%s*  %s", connectorName(integration.ConnectorType), statusBadge(integration.Status)))
+		}
+		blocks = append(blocks, markdownSection(strings.Join(lines, "\n")))
+	}
+
+	blocks = append(blocks, slack.NewDividerBlock(), slack.NewHeaderBlock(plainText("Recent conversations")))
+	if len(view.Conversations) == 0 {
+		blocks = append(blocks, markdownSection("You haven't asked InfraGPT anything yet. Mention the bot in a channel to start a conversation."))
+	} else {
+		var lines []string
+		for _, c := range view.Conversations {
+			line := fmt.Sprintf("• <#%s>", c.ChannelID)
+			if permalink, ok := permalinks[c.ID]; ok {
+				line += fmt.Sprintf(" · <%s|View thread>", permalink)
+			}
+			line += fmt.Sprintf(" · <!date^%d^{date_short_pretty} {time}|%s>", c.UpdatedAt.Unix(), c.UpdatedAt.Format("Jan 2 15:04"))
+			lines = append(lines, line)
+		}
+		blocks = append(blocks, markdownSection(strings.Join(lines, "\n")))
+	}
+
+	var buttons []slack.BlockElement
+	if appID != "" {
+		newConversation := "https://slack.com/app_redirect?" + url.Values{"app": {appID}, "team": {view.TeamID}}.Encode()
+		buttons = append(buttons, linkButton("new_conversation", "Start a new conversation", newConversation))
+	}
+	if dashboardURL != "" {
+		buttons = append(buttons, linkButton("open_dashboard", "Open dashboard", dashboardURL))
+	}
+	if len(buttons) > 0 {
+		blocks = append(blocks, slack.NewDividerBlock(), slack.NewActionBlock("home_actions", buttons...))
+	}
+
+	return blocks
+}
+
+func statusBadge(status backend.IntegrationStatus) string {
+	switch status {
+	case backend.IntegrationStatusActive:
+		return ":large_green_circle: Active"
+	case backend.IntegrationStatusPending, backend.IntegrationStatusNotStarted:
+		return ":large_blue_circle: Pending"
+	case backend.IntegrationStatusSuspended:
+		return ":large_yellow_circle: Suspended"
+	case backend.IntegrationStatusNeedsReauthorization:
+		return ":red_circle: Needs reauthorization"
+	default:
+		return ":white_circle: " + strings.ReplaceAll(string(status), "_", " ")
+	}
+}
+
+func connectorName(connectorType backend.ConnectorType) string {
+	switch connectorType {
+	case backend.ConnectorTypeGithub:
+		return "GitHub"
+	case backend.ConnectorTypeGCP:
+		return "GCP"
+	case backend.ConnectorTypeAWS:
+		return "AWS"
+	case backend.ConnectorTypePagerDuty:
+		return "PagerDuty"
+	case backend.ConnectorTypeObjectStore:
+		return "Object storage"
+	case backend.ConnectorTypeAzureDevOps:
+		return "Azure DevOps"
+	default:
+		name := string(connectorType)
+		if name == "" {
+			return name
+		}
+		return strings.ToUpper(name[:1]) + name[1:]
+	}
+}
+
+func plainText(text string) *slack.TextBlockObject {
+	return slack.NewTextBlockObject(slack.PlainTextType, text, false, false)
+}
+
+func markdownSection(text string) *slack.SectionBlock {
+	return slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil)
+}
+
+func linkButton(actionID, text, link string) *slack.ButtonBlockElement {
+	button := slack.NewButtonBlockElement(actionID, "", plainText(text))
+	button.URL = link
+	return button
+}
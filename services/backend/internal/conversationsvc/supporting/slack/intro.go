@@ -0,0 +1,104 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
+	"github.com/slack-go/slack"
+	"github.com/slack-go/slack/slackevents"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+func (s *Slack) OnBotJoinedChannel(handler func(ctx context.Context, event domain.BotJoinedChannel) error) {
+	s.botJoined = handler
+}
+
+// handleMemberJoinedChannel passes on joins of the bot itself; other members
+// joining a channel are ignored.
+func (s *Slack) handleMemberJoinedChannel(ctx context.Context, teamID string, event *slackevents.MemberJoinedChannelEvent) error {
+	if s.botJoined == nil {
+		return nil
+	}
+
+	teamClient, err := s.teamClient(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	at, err := teamClient.AuthTestContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get bot user ID: %w", err)
+	}
+	if event.User != at.UserID {
+		return nil
+	}
+
+	return s.botJoined(ctx, domain.BotJoinedChannel{
+		TeamID:    teamID,
+		ChannelID: event.Channel,
+		BotUserID: at.UserID,
+	})
+}
+
+func (s *Slack) PostChannelIntro(ctx context.Context, intro domain.ChannelIntro) (err error) {
+	ctx, span := tracing.Start(ctx, "slack.post_channel_intro",
+		attribute.String("slack.team_id", intro.TeamID),
+		attribute.String("slack.channel_id", intro.ChannelID))
+	defer func() { tracing.End(span, err) }()
+
+	teamClient, err := s.teamClient(ctx, intro.TeamID)
+	if err != nil {
+		return err
+	}
+
+	blocks := introBlocks(intro, s.dashboardURL)
+	_, _, err = teamClient.PostMessageContext(ctx, intro.ChannelID,
+		slack.MsgOptionText(introText(intro), false),
+		slack.MsgOptionBlocks(blocks...),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to post channel intro: %w", err)
+	}
+	return nil
+}
+
+func introText(intro domain.ChannelIntro) string {
+	return strings.ReplaceAll(intro.Message, "{bot}", fmt.Sprintf("<@%s>", intro.BotUserID))
+}
+
+func introBlocks(intro domain.ChannelIntro, dashboardURL string) []slack.Block {
+	mention := fmt.Sprintf("<@%s>", intro.BotUserID)
+	blocks := []slack.Block{markdownSection(introText(intro))}
+
+	usage := []string{
+		fmt.Sprintf("• `%s why are the payments pods restarting?` starts a thread; reply in it to follow up", mention),
+	}
+	if len(intro.Models) > 0 {
+		usage = append(usage, fmt.Sprintf("• Add `--model <name>` to pick a model: %s", "`"+strings.Join(intro.Models, "`, `")+"`"))
+	}
+	usage = append(usage, "• `/infragpt context` sets the repositories and projects this channel is about")
+	blocks = append(blocks, markdownSection("*How to use me*\n"+strings.Join(usage, "\n")))
+
+	switch {
+	case !intro.Linked:
+		blocks = append(blocks, markdownSection("This workspace isn't connected to an InfraGPT organization yet, so I can't look at your infrastructure."))
+		if dashboardURL != "" {
+			blocks = append(blocks, slack.NewActionBlock("intro_setup", linkButton("open_dashboard", "Finish setup", dashboardURL)))
+		}
+	case len(intro.Integrations) == 0:
+		blocks = append(blocks, markdownSection("No integrations are connected yet. Connect GitHub, GCP and more so I can look into your infrastructure."))
+		if dashboardURL != "" {
+			blocks = append(blocks, slack.NewActionBlock("intro_setup", linkButton("connect_integration", "Connect an integration", dashboardURL)))
+		}
+	default:
+		var lines []string
+		for _, integration := range intro.Integrations {
+			lines = append(lines, fmt.Sprintf("• *%s*  %s", connectorName(integration.ConnectorType), statusBadge(integration.Status)))
+		}
+		blocks = append(blocks, markdownSection("*Connected integrations*\n"+strings.Join(lines, "\n")))
+	}
+
+	return blocks
+}
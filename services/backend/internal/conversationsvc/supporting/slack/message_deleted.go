@@ -0,0 +1,29 @@
+package slack
+
+import (
+	"context"
+
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/slack-go/slack/slackevents"
+)
+
+func (s *Slack) OnMessageDeleted(handler func(ctx context.Context, event domain.MessageDeleted) error) {
+	s.messageDeleted = handler
+}
+
+func (s *Slack) handleMessageDeleted(ctx context.Context, teamID string, event *slackevents.MessageEvent) error {
+	if s.messageDeleted == nil || event.DeletedTimeStamp == "" {
+		return nil
+	}
+
+	threadTS := event.DeletedTimeStamp
+	if event.PreviousMessage != nil && event.PreviousMessage.ThreadTimeStamp != "" {
+		threadTS = event.PreviousMessage.ThreadTimeStamp
+	}
+	return s.messageDeleted(ctx, domain.MessageDeleted{
+		TeamID:    teamID,
+		Channel:   event.Channel,
+		ThreadTS:  threadTS,
+		MessageTS: event.DeletedTimeStamp,
+	})
+}
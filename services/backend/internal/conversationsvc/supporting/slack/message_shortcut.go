@@ -0,0 +1,92 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
+	"github.com/slack-go/slack"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// analyzeShortcutCallbackID is the callback ID of the "Analyze with InfraGPT"
+// message shortcut configured on the Slack app.
+const analyzeShortcutCallbackID = "analyze_with_infragpt"
+
+const defaultMaxSnippetBytes = 8000
+
+// analyzePrompt asks the agent to analyze a message's text, fencing it as code
+// unless it already contains a code block.
+func analyzePrompt(text string) string {
+	if strings.Contains(text, "```") {
+		return "Analyze this snippet:\n" + text
+	}
+	return "Analyze this snippet:\n```\n" + text + "\n```"
+}
+
+// handleMessageShortcut sends the text of the message the shortcut was used on
+// to the agent, as if the user had asked about it in the message's thread.
+func (s *Slack) handleMessageShortcut(ctx context.Context, callback slack.InteractionCallback, handler func(context.Context, domain.UserCommand) error) (err error) {
+	if callback.CallbackID != analyzeShortcutCallbackID {
+		return nil
+	}
+
+	ctx, span := tracing.Start(ctx, "slack.message_shortcut",
+		attribute.String("slack.team_id", callback.Team.ID),
+		attribute.String("slack.channel_id", callback.Channel.ID))
+	defer func() { tracing.End(span, err) }()
+
+	teamToken, err := s.tokenRepository.GetToken(ctx, callback.Team.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get team token: %w", err)
+	}
+	teamClient := slack.New(teamToken, slack.OptionHTTPClient(httpClient))
+
+	text := strings.TrimSpace(callback.Message.Text)
+	var refusal string
+	switch {
+	case text == "":
+		refusal = "That message has no text to analyze."
+	case len(text) > s.maxSnippetBytes:
+		refusal = fmt.Sprintf("That message is too long to analyze. Snippets are limited to %d characters.", s.maxSnippetBytes)
+	}
+	if refusal != "" {
+		if _, err := teamClient.PostEphemeralContext(ctx, callback.Channel.ID, callback.User.ID, slack.MsgOptionText(refusal, false)); err != nil {
+			return fmt.Errorf("failed to post shortcut refusal: %w", err)
+		}
+		return nil
+	}
+
+	sender := domain.SlackUser{ID: callback.User.ID, Username: callback.User.Name}
+	if info, err := teamClient.GetUserInfoContext(ctx, callback.User.ID); err == nil {
+		sender.Name = info.RealName
+		sender.Username = info.Name
+		sender.Email = info.Profile.Email
+	} else {
+		slog.Error("Error getting shortcut user info", "error", err, "userID", callback.User.ID)
+	}
+
+	threadTS := callback.Message.ThreadTimestamp
+	if threadTS == "" {
+		threadTS = callback.Message.Timestamp
+	}
+	command := domain.UserCommand{
+		Thread: domain.SlackThread{
+			TeamID:   callback.Team.ID,
+			Channel:  callback.Channel.ID,
+			ThreadTS: threadTS,
+			Sender:   sender,
+			Message:  analyzePrompt(text),
+		},
+		InReply:     callback.Message.ThreadTimestamp != "",
+		MessageType: domain.MessageTypeShortcut,
+		MessageTS:   callback.ActionTs,
+	}
+
+	return whileThinking(ctx, teamClient, callback.Channel.ID, callback.Message.Timestamp, func() error {
+		return handler(ctx, command)
+	})
+}
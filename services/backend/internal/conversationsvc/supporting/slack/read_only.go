@@ -0,0 +1,18 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+)
+
+func (s *Slack) ReplyReadOnlyIntegration(ctx context.Context, t domain.SlackThread) error {
+	message := "This integration is read-only, so I couldn't make that change."
+	if s.dashboardURL != "" {
+		message = fmt.Sprintf("This integration is read-only; an admin can enable write access here: <%s|%s>", s.dashboardURL, s.dashboardURL)
+	} else {
+		message += " An admin can enable write access in the InfraGPT dashboard."
+	}
+	return s.reply(ctx, t, message)
+}
@@ -0,0 +1,188 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	// maxSectionTextLength is Slack's limit on a section block's text.
+	maxSectionTextLength = 3000
+	// maxMessageTextLength is Slack's limit on a message's text. Longer
+	// replies are posted as numbered messages in the thread.
+	maxMessageTextLength = 40000
+	// partLabelLength leaves room for the "(12/12)" prefix of a numbered
+	// message's text.
+	partLabelLength = 16
+
+	codeFence = "```"
+)
+
+// replyChunks splits a Slack-formatted reply into pieces that each fit in a
+// section block. A code block cut by a split is closed at the end of one piece
+// and reopened at the start of the next so both render as code.
+func replyChunks(text string) []string {
+	return splitLines(text, maxSectionTextLength)
+}
+
+// splitLines splits text between lines into pieces of at most maxLength
+// bytes, hard wrapping lines that are longer on their own.
+func splitLines(text string, maxLength int) []string {
+	// Leave room for the fences added when a split falls inside a code block.
+	limit := maxLength - 2*(len(codeFence)+1)
+
+	var chunks []string
+	var current strings.Builder
+	inCodeFence := false
+
+	// pending reports whether current holds more than a reopened fence.
+	pending := func() bool {
+		return current.Len() > 0 && !(inCodeFence && current.String() == codeFence+"\n")
+	}
+	flush := func() {
+		if !pending() {
+			return
+		}
+		chunk := current.String()
+		if inCodeFence {
+			chunk += "\n" + codeFence
+		}
+		chunks = append(chunks, chunk)
+		current.Reset()
+		if inCodeFence {
+			current.WriteString(codeFence + "\n")
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		for len(line) > limit {
+			flush()
+			cut := limit
+			for !utf8.RuneStart(line[cut]) {
+				cut--
+			}
+			current.WriteString(line[:cut])
+			flush()
+			line = line[cut:]
+		}
+
+		if pending() && current.Len()+1+len(line) > limit {
+			flush()
+		}
+		if current.Len() > 0 && !strings.HasSuffix(current.String(), "\n") {
+			current.WriteString("\n")
+		}
+		current.WriteString(line)
+
+		if strings.HasPrefix(strings.TrimLeft(line, " \t"), codeFence) {
+			inCodeFence = !inCodeFence
+		}
+	}
+	if inCodeFence {
+		// An unterminated fence would otherwise swallow the rest of the reply.
+		inCodeFence = false
+		current.WriteString("\n" + codeFence)
+	}
+	flush()
+
+	return chunks
+}
+
+// replyParts splits a Slack-formatted reply into the messages it is posted as,
+// each within Slack's text limit. Splits fall between paragraphs and never
+// inside a code block, unless a single paragraph or code block is too long for
+// one message and has to be split between lines.
+func replyParts(text string) []string {
+	limit := maxMessageTextLength - partLabelLength
+
+	var parts []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			parts = append(parts, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, paragraph := range paragraphs(text) {
+		if current.Len() > 0 && current.Len()+2+len(paragraph) > limit {
+			flush()
+		}
+		if len(paragraph) > limit {
+			pieces := splitLines(paragraph, limit)
+			parts = append(parts, pieces[:len(pieces)-1]...)
+			paragraph = pieces[len(pieces)-1]
+		}
+		if current.Len() > 0 {
+			current.WriteString("\n\n")
+		}
+		current.WriteString(paragraph)
+	}
+	flush()
+
+	return parts
+}
+
+// paragraphs splits text on blank lines, keeping each code block whole.
+func paragraphs(text string) []string {
+	var paragraphs []string
+	var current []string
+	inCodeFence := false
+	for _, line := range strings.Split(text, "\n") {
+		if !inCodeFence && strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				paragraphs = append(paragraphs, strings.Join(current, "\n"))
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line)
+		if strings.HasPrefix(strings.TrimLeft(line, " \t"), codeFence) {
+			inCodeFence = !inCodeFence
+		}
+	}
+	if len(current) > 0 {
+		paragraphs = append(paragraphs, strings.Join(current, "\n"))
+	}
+	return paragraphs
+}
+
+// replyMessages renders a reply as Block Kit sections, one message per part
+// of the reply. Messages of a multi-part reply are numbered like "(1/3)" and
+// keep their text as the notification fallback. Trailing blocks go at the end
+// of the last message, or in a message of their own when there is no text.
+func replyMessages(text string, trailing ...slack.Block) [][]slack.MsgOption {
+	parts := replyParts(text)
+
+	var messages [][]slack.MsgOption
+	for i, part := range parts {
+		chunks := replyChunks(part)
+		blocks := make([]slack.Block, 0, len(chunks)+1+len(trailing))
+		if len(parts) > 1 {
+			label := fmt.Sprintf("(%d/%d)", i+1, len(parts))
+			blocks = append(blocks, slack.NewContextBlock("", slack.NewTextBlockObject(slack.MarkdownType, label, false, false)))
+			part = label + " " + part
+		}
+		for _, chunk := range chunks {
+			blocks = append(blocks, markdownSection(chunk))
+		}
+		if i == len(parts)-1 {
+			blocks = append(blocks, trailing...)
+			trailing = nil
+		}
+		messages = append(messages, []slack.MsgOption{
+			slack.MsgOptionText(part, false),
+			slack.MsgOptionBlocks(blocks...),
+		})
+	}
+	if len(trailing) > 0 {
+		messages = append(messages, []slack.MsgOption{
+			slack.MsgOptionText("Was this reply helpful?", false),
+			slack.MsgOptionBlocks(trailing...),
+		})
+	}
+	return messages
+}
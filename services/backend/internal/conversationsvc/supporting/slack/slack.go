@@ -6,10 +6,15 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
 	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/socketmode"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -117,6 +122,8 @@ func transformBoldOutsideCode(line string, boldRegex *regexp.Regexp) string {
 	return strings.Join(parts, "`")
 }
 
+var httpClient = tracing.HTTPClient(30 * time.Second)
+
 type Slack struct {
 	clientID          string
 	clientSecret      string
@@ -124,6 +131,17 @@ type Slack struct {
 	socketClient      *socketmode.Client
 	tokenRepository   domain.WorkSpaceTokenRepository
 	channelRepository domain.ChannelRepository
+	dashboardURL      string
+	maxSnippetBytes   int
+	homeOpened        func(ctx context.Context, event domain.HomeOpened) error
+	slashCommand      func(ctx context.Context, command domain.SlashCommand) (string, error)
+	feedback          func(ctx context.Context, event domain.FeedbackEvent) error
+	botJoined         func(ctx context.Context, event domain.BotJoinedChannel) error
+	messageDeleted    func(ctx context.Context, event domain.MessageDeleted) error
+	// appID is learned from incoming events and used to link to the bot's DM.
+	appID atomic.Value
+	// linkedWorkspaces caches the team IDs already linked to their enterprise.
+	linkedWorkspaces sync.Map
 }
 
 // TODO: Advanced token security via token rotation
@@ -139,12 +157,19 @@ func (s *Slack) CompleteAuthentication(ctx context.Context, code string) (string
 		return "", fmt.Errorf("failed to get oauth v2 response: %w", err)
 	}
 
+	// An org-wide Enterprise Grid install has no team; its token serves every
+	// workspace of the enterprise and is stored under the enterprise ID.
+	teamID := oauthV2Response.Team.ID
+	if oauthV2Response.IsEnterpriseInstall {
+		teamID = oauthV2Response.Enterprise.ID
+	}
+
 	// TODO: store refresh token and handle token refresh
-	if err := s.tokenRepository.SaveToken(ctx, oauthV2Response.Team.ID, oauthV2Response.AccessToken); err != nil {
+	if err := s.tokenRepository.SaveToken(ctx, teamID, oauthV2Response.AccessToken); err != nil {
 		return "", fmt.Errorf("failed to save token: %w", err)
 	}
 
-	return oauthV2Response.Team.ID, nil
+	return teamID, nil
 }
 
 func (s *Slack) SubscribeAllMessages(ctx context.Context, f func(ctx context.Context, command domain.UserCommand) error) error {
@@ -156,7 +181,7 @@ func (s *Slack) SubscribeAllMessages(ctx context.Context, f func(ctx context.Con
 		return nil
 	})
 	g.Go(func() error {
-		if err := s.socketClient.Run(); err != nil {
+		if err := s.socketClient.RunContext(ctx); err != nil {
 			return fmt.Errorf("failed to run socket client: %w", err)
 		}
 		return nil
@@ -166,22 +191,49 @@ func (s *Slack) SubscribeAllMessages(ctx context.Context, f func(ctx context.Con
 }
 
 func (s *Slack) ReplyMessage(ctx context.Context, t domain.SlackThread, message string) error {
-	teamToken, err := s.tokenRepository.GetToken(ctx, t.TeamID)
+	return s.reply(ctx, t, message)
+}
+
+func (s *Slack) StartThread(ctx context.Context, t domain.SlackThread, message string) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, "slack.start_thread",
+		attribute.String("slack.team_id", t.TeamID),
+		attribute.String("slack.channel_id", t.Channel))
+	defer func() { tracing.End(span, err) }()
+
+	teamClient, err := s.teamClient(ctx, t.TeamID)
 	if err != nil {
-		return fmt.Errorf("failed to get team token: %w", err)
+		return "", err
+	}
+
+	_, threadTS, err := teamClient.PostMessageContext(ctx, t.Channel, slack.MsgOptionText(transformMarkdownToSlack(message), false))
+	if err != nil {
+		return "", fmt.Errorf("failed to post message: %w", err)
+	}
+	return threadTS, nil
+}
+
+// reply posts message to the thread, with trailing blocks such as feedback
+// buttons appended after the message text.
+func (s *Slack) reply(ctx context.Context, t domain.SlackThread, message string, trailing ...slack.Block) (err error) {
+	ctx, span := tracing.Start(ctx, "slack.reply_message",
+		attribute.String("slack.team_id", t.TeamID),
+		attribute.String("slack.channel_id", t.Channel),
+		attribute.String("slack.thread_ts", t.ThreadTS))
+	defer func() { tracing.End(span, err) }()
+
+	teamClient, err := s.teamClient(ctx, t.TeamID)
+	if err != nil {
+		return err
 	}
-	teamClient := slack.New(teamToken)
 
 	// Transform markdown to Slack format
 	slackFormattedMessage := transformMarkdownToSlack(message)
 
-	_, _, err = teamClient.PostMessage(
-		t.Channel,
-		slack.MsgOptionText(slackFormattedMessage, false),
-		slack.MsgOptionTS(t.ThreadTS),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to post message: %w", err)
+	for _, options := range replyMessages(slackFormattedMessage, trailing...) {
+		_, _, err = teamClient.PostMessageContext(ctx, t.Channel, append(options, slack.MsgOptionTS(t.ThreadTS))...)
+		if err != nil {
+			return fmt.Errorf("failed to post message: %w", err)
+		}
 	}
 
 	return nil
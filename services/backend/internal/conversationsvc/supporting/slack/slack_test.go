@@ -1,9 +1,18 @@
 package slack
 
 import (
+	"encoding/json"
+	"fmt"
 	"regexp"
+	"slices"
 	"strings"
 	"testing"
+	"unicode/utf8"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/google/uuid"
+	"github.com/slack-go/slack"
 )
 
 func TestTransformMarkdownToSlack(t *testing.T) {
@@ -166,3 +175,186 @@ func TestTransformMarkdownToSlack_Performance(t *testing.T) {
 		t.Errorf("Fast-path failed: got %q, want %q", result, expected)
 	}
 }
+
+func TestReplyChunks(t *testing.T) {
+	t.Run("short reply is one chunk", func(t *testing.T) {
+		got := replyChunks("*Summary*\nAll pods are healthy.")
+		if len(got) != 1 || got[0] != "*Summary*\nAll pods are healthy." {
+			t.Errorf("replyChunks() = %q", got)
+		}
+	})
+
+	t.Run("long code block is split with balanced fences", func(t *testing.T) {
+		var code []string
+		for i := range 300 {
+			code = append(code, fmt.Sprintf("line %03d: kubectl get pods -n payments", i))
+		}
+		text := "Here is the diff:\n```\n" + strings.Join(code, "\n") + "\n```\nDone."
+
+		chunks := replyChunks(text)
+		if len(chunks) < 2 {
+			t.Fatalf("replyChunks() = %d chunks, want the reply split", len(chunks))
+		}
+		for i, chunk := range chunks {
+			if len(chunk) > maxSectionTextLength {
+				t.Errorf("chunk %d is %d bytes, over the section limit", i, len(chunk))
+			}
+			if n := strings.Count(chunk, "```"); n%2 != 0 {
+				t.Errorf("chunk %d has %d code fences, want them balanced:\n%s", i, n, chunk)
+			}
+		}
+		if !strings.HasSuffix(chunks[len(chunks)-1], "```\nDone.") {
+			t.Errorf("last chunk = %q, want the text after the code block", chunks[len(chunks)-1])
+		}
+	})
+
+	t.Run("overlong line is hard wrapped", func(t *testing.T) {
+		chunks := replyChunks(strings.Repeat("é", maxSectionTextLength))
+		if len(chunks) != 3 {
+			t.Fatalf("replyChunks() = %d chunks, want 3", len(chunks))
+		}
+		for i, chunk := range chunks {
+			if !utf8.ValidString(chunk) || len(chunk) > maxSectionTextLength {
+				t.Errorf("chunk %d is invalid or too long (%d bytes)", i, len(chunk))
+			}
+		}
+	})
+}
+
+func TestReplyMessages(t *testing.T) {
+	var analysis, codeBlocks []string
+	for i := 0; len(strings.Join(analysis, "\n\n")) < 118000; i++ {
+		if i%12 == 11 {
+			var code []string
+			for j := range 40 {
+				code = append(code, fmt.Sprintf("%02d-%02d kubectl describe pod payments-api-%d -n payments", i, j, j))
+			}
+			block := "```\n" + strings.Join(code, "\n") + "\n```"
+			codeBlocks = append(codeBlocks, block)
+			analysis = append(analysis, block)
+			continue
+		}
+		analysis = append(analysis, fmt.Sprintf("*Finding %d*\n", i)+strings.Repeat("The pod restarted after its memory limit was exceeded. ", 18))
+	}
+	text := strings.Join(analysis, "\n\n")
+
+	messages := replyMessages(text, feedbackBlocks(uuid.New(), "")...)
+	if len(messages) != 3 {
+		t.Fatalf("replyMessages() = %d messages, want 3", len(messages))
+	}
+	for i, options := range messages {
+		_, values, err := slack.UnsafeApplyMsgOptions("", "C1", "", options...)
+		if err != nil {
+			t.Fatalf("message %d: UnsafeApplyMsgOptions() error = %v", i, err)
+		}
+		text := values.Get("text")
+		if len(text) > maxMessageTextLength {
+			t.Errorf("message %d text is %d bytes, over the message limit", i, len(text))
+		}
+		if label := fmt.Sprintf("(%d/3) ", i+1); !strings.HasPrefix(text, label) {
+			t.Errorf("message %d text starts with %q, want %q", i, text[:10], label)
+		}
+		if n := strings.Count(text, "```"); n%2 != 0 {
+			t.Errorf("message %d has %d code fences, want them balanced", i, n)
+		}
+
+		var blocks slack.Blocks
+		if err := json.Unmarshal([]byte(values.Get("blocks")), &blocks); err != nil {
+			t.Fatalf("message %d: invalid blocks: %v", i, err)
+		}
+		if len(blocks.BlockSet) > 50 {
+			t.Errorf("message %d has %d blocks, over Slack's limit", i, len(blocks.BlockSet))
+		}
+		for _, block := range blocks.BlockSet {
+			if section, ok := block.(*slack.SectionBlock); ok && len(section.Text.Text) > maxSectionTextLength {
+				t.Errorf("message %d has a %d byte section, over the section limit", i, len(section.Text.Text))
+			}
+		}
+		hasFeedback := slices.ContainsFunc(blocks.BlockSet, func(b slack.Block) bool { return b.ID() == feedbackBlockID })
+		if hasFeedback != (i == 2) {
+			t.Errorf("message %d has feedback buttons = %v, want them only on the last message", i, hasFeedback)
+		}
+	}
+
+	for _, block := range codeBlocks {
+		whole := slices.ContainsFunc(messages, func(options []slack.MsgOption) bool {
+			_, values, _ := slack.UnsafeApplyMsgOptions("", "C1", "", options...)
+			return strings.Contains(values.Get("text"), block)
+		})
+		if !whole {
+			t.Errorf("code block %q was split across messages", block[:20])
+		}
+	}
+}
+
+func TestWithFeedbackStatus(t *testing.T) {
+	messageID := uuid.New()
+	posted := append([]slack.Block{markdownSection("The payments pod is crash looping.")}, feedbackBlocks(messageID, "")...)
+
+	rated := withFeedbackStatus(posted, messageID, domain.FeedbackRatingPositive)
+	rerated := withFeedbackStatus(rated, messageID, domain.FeedbackRatingNegative)
+
+	if len(rerated) != 3 {
+		t.Fatalf("withFeedbackStatus() = %d blocks, want the reply, the buttons and one status note", len(rerated))
+	}
+	if _, ok := rerated[0].(*slack.SectionBlock); !ok {
+		t.Errorf("first block = %T, want the reply text kept", rerated[0])
+	}
+
+	actions, ok := rerated[1].(*slack.ActionBlock)
+	if !ok {
+		t.Fatalf("second block = %T, want the feedback buttons", rerated[1])
+	}
+	for _, element := range actions.Elements.ElementSet {
+		button := element.(*slack.ButtonBlockElement)
+		if button.Value != messageID.String() {
+			t.Errorf("button %s value = %q, want the message id", button.ActionID, button.Value)
+		}
+		if highlighted := button.Style == slack.StylePrimary; highlighted != (button.ActionID == actionFeedbackNegative) {
+			t.Errorf("button %s highlighted = %v, want only the recorded rating highlighted", button.ActionID, highlighted)
+		}
+	}
+	if rerated[2].ID() != feedbackStatusBlockID {
+		t.Errorf("last block = %s, want the status note", rerated[2].ID())
+	}
+}
+
+func TestIntroBlocks(t *testing.T) {
+	intro := domain.ChannelIntro{
+		BotUserID: "U123",
+		Message:   "Hi! Mention {bot} to ask me something.",
+		Linked:    true,
+		Integrations: []backend.Integration{
+			{ConnectorType: backend.ConnectorTypeGithub, Status: backend.IntegrationStatusActive},
+		},
+		Models: []string{"gpt-4o", "gpt-4o-mini"},
+	}
+
+	var texts []string
+	for _, block := range introBlocks(intro, "https://app.example.com") {
+		if section, ok := block.(*slack.SectionBlock); ok {
+			texts = append(texts, section.Text.Text)
+		}
+	}
+	rendered := strings.Join(texts, "\n")
+
+	for _, want := range []string{"Mention <@U123> to ask", "`--model <name>`", "`gpt-4o-mini`", "`/infragpt context`", "*GitHub*"} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("introBlocks() = %q, want it to contain %q", rendered, want)
+		}
+	}
+	if strings.Contains(rendered, "{bot}") {
+		t.Errorf("introBlocks() = %q, want the {bot} placeholder replaced", rendered)
+	}
+}
+
+func TestAnalyzePrompt(t *testing.T) {
+	if got, want := analyzePrompt("resource \"aws_s3_bucket\" \"logs\" {}"), "Analyze this snippet:\n```\nresource \"aws_s3_bucket\" \"logs\" {}\n```"; got != want {
+		t.Errorf("analyzePrompt() = %q, want %q", got, want)
+	}
+
+	fenced := "why does this fail?\n```\nkubectl apply -f deploy.yaml\n```"
+	if got := analyzePrompt(fenced); got != "Analyze this snippet:\n"+fenced {
+		t.Errorf("analyzePrompt() = %q, want the fenced message unchanged", got)
+	}
+}
@@ -0,0 +1,54 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
+	"github.com/slack-go/slack"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const slashCommandFailedReply = "Something went wrong handling that command. Please try again."
+
+func (s *Slack) OnSlashCommand(handler func(ctx context.Context, command domain.SlashCommand) (string, error)) {
+	s.slashCommand = handler
+}
+
+func (s *Slack) handleSlashCommand(ctx context.Context, command slack.SlashCommand) (err error) {
+	ctx, span := tracing.Start(ctx, "slack.slash_command",
+		attribute.String("slack.team_id", command.TeamID),
+		attribute.String("slack.channel_id", command.ChannelID),
+		attribute.String("slack.command", command.Command))
+	defer func() { tracing.End(span, err) }()
+
+	if s.slashCommand == nil {
+		return nil
+	}
+
+	reply, handlerErr := s.slashCommand(ctx, domain.SlashCommand{
+		TeamID:    command.TeamID,
+		ChannelID: command.ChannelID,
+		UserID:    command.UserID,
+		Command:   command.Command,
+		Text:      command.Text,
+	})
+	if handlerErr != nil {
+		reply = slashCommandFailedReply
+	}
+
+	teamToken, err := s.tokenRepository.GetToken(ctx, command.TeamID)
+	if err != nil {
+		return fmt.Errorf("failed to get team token: %w", err)
+	}
+	teamClient := slack.New(teamToken, slack.OptionHTTPClient(httpClient))
+	if _, err := teamClient.PostEphemeralContext(ctx, command.ChannelID, command.UserID, slack.MsgOptionText(reply, false)); err != nil {
+		return fmt.Errorf("failed to post slash command reply: %w", err)
+	}
+
+	if handlerErr != nil {
+		return fmt.Errorf("failed to handle %s command: %w", command.Command, handlerErr)
+	}
+	return nil
+}
@@ -2,15 +2,25 @@ package slack
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"sync"
 
 	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
+	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/slackevents"
 	"github.com/slack-go/slack/socketmode"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 func (s *Slack) subscribe(ctx context.Context, handler func(context.Context, domain.UserCommand) error) error {
+	// Events are handled concurrently so a message such as "stop" is seen
+	// while an earlier message in the thread is still with the agent.
+	var inFlight sync.WaitGroup
+	defer inFlight.Wait()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -31,10 +41,41 @@ func (s *Slack) subscribe(ctx context.Context, handler func(context.Context, dom
 					slog.Error("Failed to cast event data to EventsAPIEvent", "msg", event.Data)
 					continue
 				}
-				err := s.handleEventAPI(ctx, payload, handler)
-				if err != nil {
-					slog.Error("Failed to handle event API:", "error", err)
+				s.linkWorkspace(ctx, payload.TeamID, payload.EnterpriseID)
+				inFlight.Add(1)
+				go func() {
+					defer inFlight.Done()
+					if err := s.handleEventAPI(ctx, payload, handler); err != nil {
+						slog.Error("Failed to handle event API:", "error", err)
+					}
+				}()
+			case socketmode.EventTypeSlashCommand:
+				s.socketClient.Ack(*event.Request)
+				command, ok := event.Data.(slack.SlashCommand)
+				if !ok {
+					slog.Error("Failed to cast event data to SlashCommand", "msg", event.Data)
+					continue
+				}
+				s.linkWorkspace(ctx, command.TeamID, command.EnterpriseID)
+				if err := s.handleSlashCommand(ctx, command); err != nil {
+					slog.Error("Failed to handle slash command", "error", err)
+				}
+			case socketmode.EventTypeInteractive:
+				// Home tab link buttons need nothing beyond the ack.
+				s.socketClient.Ack(*event.Request)
+				callback, ok := event.Data.(slack.InteractionCallback)
+				if !ok {
+					slog.Error("Failed to cast event data to InteractionCallback", "msg", event.Data)
+					continue
 				}
+				s.linkWorkspace(ctx, callback.Team.ID, callback.Enterprise.ID)
+				inFlight.Add(1)
+				go func() {
+					defer inFlight.Done()
+					if err := s.handleInteraction(ctx, callback, handler); err != nil {
+						slog.Error("Failed to handle interaction", "type", callback.Type, "error", err)
+					}
+				}()
 			default:
 				slog.Info("Unhandled event type: %s with data:",
 					"type", event.Type, "data", event.Data)
@@ -43,21 +84,59 @@ func (s *Slack) subscribe(ctx context.Context, handler func(context.Context, dom
 	}
 }
 
-func (s *Slack) handleEventAPI(ctx context.Context, event slackevents.EventsAPIEvent, handler func(context.Context, domain.UserCommand) error) error {
+func (s *Slack) handleInteraction(ctx context.Context, callback slack.InteractionCallback, handler func(context.Context, domain.UserCommand) error) error {
+	switch callback.Type {
+	case slack.InteractionTypeBlockActions:
+		return s.handleBlockActions(ctx, callback)
+	case slack.InteractionTypeViewSubmission:
+		return s.handleViewSubmission(ctx, callback)
+	case slack.InteractionTypeMessageAction:
+		return s.handleMessageShortcut(ctx, callback, handler)
+	default:
+		return nil
+	}
+}
+
+func (s *Slack) handleEventAPI(ctx context.Context, event slackevents.EventsAPIEvent, handler func(context.Context, domain.UserCommand) error) (err error) {
 	teamID := event.TeamID
+	ctx, span := tracing.Start(ctx, "slack.event",
+		attribute.String("slack.team_id", teamID),
+		attribute.String("slack.event_type", event.InnerEvent.Type))
+	defer func() { tracing.End(span, err) }()
+
+	if event.APIAppID != "" {
+		s.appID.Store(event.APIAppID)
+	}
+
 	switch event.Type {
 	case slackevents.CallbackEvent:
+		handler = withDeliveryIDs(event, handler)
 		switch ev := event.InnerEvent.Data.(type) {
 		case *slackevents.AppMentionEvent:
+			span.SetAttributes(attribute.String("slack.channel_id", ev.Channel))
 			err := s.handleAppMention(ctx, teamID, ev, handler)
 			if err != nil {
 				return fmt.Errorf("failed to handle app mention: %w", err)
 			}
 		case *slackevents.MessageEvent:
+			span.SetAttributes(attribute.String("slack.channel_id", ev.Channel))
 			err := s.handleChannelMessage(ctx, teamID, ev, handler)
 			if err != nil {
 				return fmt.Errorf("failed to handle channel message: %w", err)
 			}
+		case *slackevents.AppHomeOpenedEvent:
+			if ev.Tab != "home" || s.homeOpened == nil {
+				return nil
+			}
+			err := s.homeOpened(ctx, domain.HomeOpened{TeamID: teamID, UserID: ev.User})
+			if err != nil {
+				return fmt.Errorf("failed to handle app home opened: %w", err)
+			}
+		case *slackevents.MemberJoinedChannelEvent:
+			span.SetAttributes(attribute.String("slack.channel_id", ev.Channel))
+			if err := s.handleMemberJoinedChannel(ctx, teamID, ev); err != nil {
+				return fmt.Errorf("failed to handle member joined channel: %w", err)
+			}
 		default:
 			slog.Info("Unhandled callback event:", "event", ev)
 		}
@@ -69,3 +148,25 @@ func (s *Slack) handleEventAPI(ctx context.Context, event slackevents.EventsAPIE
 
 	return nil
 }
+
+// withDeliveryIDs stamps commands with the Slack event_id and client_msg_id so
+// retried deliveries of the same event can be recognised downstream.
+func withDeliveryIDs(event slackevents.EventsAPIEvent, handler func(context.Context, domain.UserCommand) error) func(context.Context, domain.UserCommand) error {
+	callback, ok := event.Data.(*slackevents.EventsAPICallbackEvent)
+	if !ok {
+		return handler
+	}
+
+	var inner struct {
+		ClientMsgID string `json:"client_msg_id"`
+	}
+	if callback.InnerEvent != nil {
+		_ = json.Unmarshal(*callback.InnerEvent, &inner)
+	}
+
+	return func(ctx context.Context, command domain.UserCommand) error {
+		command.EventID = callback.EventID
+		command.ClientMsgID = inner.ClientMsgID
+		return handler(ctx, command)
+	}
+}
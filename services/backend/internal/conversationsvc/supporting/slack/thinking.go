@@ -0,0 +1,33 @@
+package slack
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const thinkingReaction = "hourglass_flowing_sand"
+
+// whileThinking marks the user's message with an hourglass reaction while f runs
+// and removes it once f returns, whether it succeeded, failed or panicked.
+func whileThinking(ctx context.Context, client *slack.Client, channel, timestamp string, f func() error) error {
+	ref := slack.NewRefToMessage(channel, timestamp)
+	if err := client.AddReactionContext(ctx, thinkingReaction, ref); err != nil {
+		// Another event for the same message may already own the indicator.
+		slog.Error("Error adding thinking reaction", "error", err, "channelID", channel, "timestamp", timestamp)
+		return f()
+	}
+
+	defer func() {
+		// Clean up even when ctx was cancelled while the agent was working.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		defer cancel()
+		if err := client.RemoveReactionContext(ctx, thinkingReaction, ref); err != nil {
+			slog.Error("Error removing thinking reaction", "error", err, "channelID", channel, "timestamp", timestamp)
+		}
+	}()
+
+	return f()
+}
@@ -0,0 +1,18 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+)
+
+func (s *Slack) ReplyUnmappedUser(ctx context.Context, t domain.SlackThread) error {
+	message := "I couldn't match your Slack account to an InfraGPT user in this workspace's organization, " +
+		"so I can't act on your behalf yet. Sign in to InfraGPT with the same email as your Slack profile"
+	if s.dashboardURL != "" {
+		message += fmt.Sprintf(" at <%s|the dashboard>", s.dashboardURL)
+	}
+	message += ", or ask an admin to link your account."
+	return s.reply(ctx, t, message)
+}
@@ -0,0 +1,24 @@
+package slack
+
+import (
+	"context"
+	"log/slog"
+)
+
+// linkWorkspace records the Enterprise Grid organization of a workspace the
+// first time one of its events arrives, so the enterprise-wide bot token of an
+// org-wide install can be found by the workspace's team ID.
+func (s *Slack) linkWorkspace(ctx context.Context, teamID, enterpriseID string) {
+	if teamID == "" || enterpriseID == "" || teamID == enterpriseID {
+		return
+	}
+	if linked, ok := s.linkedWorkspaces.Load(teamID); ok && linked == enterpriseID {
+		return
+	}
+
+	if err := s.tokenRepository.LinkWorkspace(ctx, teamID, enterpriseID); err != nil {
+		slog.Error("Failed to link Slack workspace to its enterprise", "teamID", teamID, "enterpriseID", enterpriseID, "error", err)
+		return
+	}
+	s.linkedWorkspaces.Store(teamID, enterpriseID)
+}
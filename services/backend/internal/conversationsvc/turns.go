@@ -0,0 +1,131 @@
+package conversationsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	errStoppedByUser  = errors.New("stopped by user")
+	errMessageDeleted = errors.New("triggering message deleted")
+)
+
+const (
+	turnCompleted = "completed"
+	turnFailed    = "failed"
+	turnCancelled = "cancelled"
+)
+
+const nothingToStopReply = "There's nothing running in this thread to stop."
+
+type turnKey struct {
+	channel  string
+	threadTS string
+}
+
+type turn struct {
+	messageTS string
+	cancel    context.CancelCauseFunc
+}
+
+// turns tracks the agent calls in flight per thread so a "stop" reply or a
+// deleted message can cancel them.
+type turns struct {
+	mu       sync.Mutex
+	inFlight map[turnKey][]*turn
+}
+
+// start registers a turn answering messageTS. The returned function removes
+// it again and reports why the turn was cancelled, or nil if it was not.
+func (t *turns) start(ctx context.Context, channel, threadTS, messageTS string) (context.Context, func() error) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	key := turnKey{channel: channel, threadTS: threadTS}
+	current := &turn{messageTS: messageTS, cancel: cancel}
+
+	t.mu.Lock()
+	if t.inFlight == nil {
+		t.inFlight = make(map[turnKey][]*turn)
+	}
+	t.inFlight[key] = append(t.inFlight[key], current)
+	t.mu.Unlock()
+
+	return ctx, func() error {
+		t.mu.Lock()
+		t.inFlight[key] = slices.DeleteFunc(t.inFlight[key], func(other *turn) bool { return other == current })
+		if len(t.inFlight[key]) == 0 {
+			delete(t.inFlight, key)
+		}
+		t.mu.Unlock()
+
+		cause := context.Cause(ctx)
+		cancel(nil)
+		if errors.Is(cause, errStoppedByUser) || errors.Is(cause, errMessageDeleted) || errors.Is(cause, errHandedOff) {
+			return cause
+		}
+		return nil
+	}
+}
+
+// cancel stops the turns in a thread, or only the one answering messageTS when
+// it is set, and reports how many were stopped.
+func (t *turns) cancel(channel, threadTS, messageTS string, cause error) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var stopped int
+	for _, current := range t.inFlight[turnKey{channel: channel, threadTS: threadTS}] {
+		if messageTS != "" && current.messageTS != messageTS {
+			continue
+		}
+		current.cancel(cause)
+		stopped++
+	}
+	return stopped
+}
+
+func isStopCommand(text string) bool {
+	switch strings.ToLower(strings.Trim(text, " \t\n.!")) {
+	case "stop", "cancel":
+		return true
+	}
+	return false
+}
+
+func (s *Service) stopThread(ctx context.Context, thread domain.SlackThread) error {
+	if s.turns.cancel(thread.Channel, thread.ThreadTS, "", errStoppedByUser) > 0 {
+		slog.Info("Stopping agent turns on request", "channel", thread.Channel, "thread_ts", thread.ThreadTS, "user", thread.Sender.ID)
+		return nil
+	}
+	if err := s.slackGateway.ReplyMessage(ctx, thread, nothingToStopReply); err != nil {
+		return fmt.Errorf("failed to reply to stop request: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) handleMessageDeleted(ctx context.Context, event domain.MessageDeleted) error {
+	if s.turns.cancel(event.Channel, event.ThreadTS, event.MessageTS, errMessageDeleted) > 0 {
+		slog.Info("Stopping agent turn for deleted message", "channel", event.Channel, "message_ts", event.MessageTS)
+	}
+	return nil
+}
+
+// turnCancelledReply confirms in the thread that the agent stopped working.
+func turnCancelledReply(cause error) string {
+	if errors.Is(cause, errMessageDeleted) {
+		return "Stopped, since the message I was answering was deleted."
+	}
+	return "Stopped. I won't finish that request."
+}
+
+func recordTurn(ctx context.Context, outcome string) {
+	agentTurns.Add(ctx, 1, metric.WithAttributes(attribute.String("outcome", outcome)))
+}
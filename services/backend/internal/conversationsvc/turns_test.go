@@ -0,0 +1,57 @@
+package conversationsvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTurns(t *testing.T) {
+	var registry turns
+	ctx := context.Background()
+
+	first, finishFirst := registry.start(ctx, "C1", "100.1", "100.1")
+	second, finishSecond := registry.start(ctx, "C1", "100.1", "100.2")
+	other, finishOther := registry.start(ctx, "C1", "200.1", "200.1")
+
+	if got := registry.cancel("C1", "100.1", "100.2", errMessageDeleted); got != 1 {
+		t.Fatalf("cancel() for a deleted message stopped %d turns, want 1", got)
+	}
+	if first.Err() != nil {
+		t.Error("deleting one message cancelled another turn in the thread")
+	}
+	if cause := finishSecond(); !errors.Is(cause, errMessageDeleted) || second.Err() == nil {
+		t.Errorf("finish() after deletion = %v, want errMessageDeleted", cause)
+	}
+
+	if got := registry.cancel("C1", "100.1", "", errStoppedByUser); got != 1 {
+		t.Fatalf("cancel() for the thread stopped %d turns, want 1", got)
+	}
+	if cause := finishFirst(); !errors.Is(cause, errStoppedByUser) {
+		t.Errorf("finish() after stop = %v, want errStoppedByUser", cause)
+	}
+
+	if other.Err() != nil {
+		t.Error("stopping one thread cancelled a turn in another")
+	}
+	if cause := finishOther(); cause != nil {
+		t.Errorf("finish() of a completed turn = %v, want nil", cause)
+	}
+	if got := registry.cancel("C1", "200.1", "", errStoppedByUser); got != 0 {
+		t.Errorf("cancel() after the turn finished stopped %d turns, want 0", got)
+	}
+}
+
+func TestIsStopCommand(t *testing.T) {
+	for text, want := range map[string]bool{
+		"stop":            true,
+		" Cancel! ":       true,
+		"STOP.":           true,
+		"stop the deploy": false,
+		"":                false,
+	} {
+		if got := isStopCommand(text); got != want {
+			t.Errorf("isStopCommand(%q) = %v, want %v", text, got, want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package conversationsvc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/google/uuid"
+)
+
+// resolveUser returns the InfraGPT user a Slack user acts as, matching them by
+// email on their first message and remembering the match. It returns uuid.Nil
+// when the workspace is not linked to an organization yet, and
+// domain.ErrSlackUserNotMapped when no member of the organization matches.
+func (s *Service) resolveUser(ctx context.Context, thread domain.SlackThread) (uuid.UUID, error) {
+	mapping, err := s.userMappingRepository.SlackUserMapping(ctx, thread.TeamID, thread.Sender.ID)
+	if err == nil {
+		return mapping.UserID, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, fmt.Errorf("failed to get slack user mapping: %w", err)
+	}
+
+	organizationID, err := s.integrationRepository.BusinessIDByProviderProjectID(ctx, backend.ConnectorTypeSlack, thread.TeamID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, nil
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to find organization for team: %w", err)
+	}
+
+	if thread.Sender.Email == "" {
+		return uuid.Nil, domain.ErrSlackUserNotMapped
+	}
+	user, err := s.identity.OrganizationUser(ctx, backend.OrganizationUserQuery{
+		OrganizationID: organizationID,
+		Email:          thread.Sender.Email,
+	})
+	if errors.Is(err, backend.ErrUserNotFound) {
+		return uuid.Nil, domain.ErrSlackUserNotMapped
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to find organization user: %w", err)
+	}
+
+	mapping, err = s.userMappingRepository.SaveSlackUserMapping(ctx, backend.SlackUserMapping{
+		TeamID:         thread.TeamID,
+		SlackUserID:    thread.Sender.ID,
+		OrganizationID: organizationID,
+		UserID:         user.ID,
+		Source:         backend.SlackUserMappingSourceEmail,
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	slog.Info("Mapped Slack user by email", "team_id", thread.TeamID, "slack_user_id", thread.Sender.ID, "user_id", user.ID)
+	return mapping.UserID, nil
+}
+
+func (s *Service) SlackUserMappings(ctx context.Context, query backend.SlackUserMappingsQuery) ([]backend.SlackUserMapping, error) {
+	return s.userMappingRepository.SlackUserMappings(ctx, query.OrganizationID)
+}
+
+func (s *Service) MapSlackUser(ctx context.Context, cmd backend.MapSlackUserCommand) (backend.SlackUserMapping, error) {
+	if cmd.SlackUserID == "" {
+		return backend.SlackUserMapping{}, fmt.Errorf("%w: slack user is required", domain.ErrInvalidSlackUserMapping)
+	}
+	if cmd.UserID == uuid.Nil {
+		return backend.SlackUserMapping{}, fmt.Errorf("%w: user is required", domain.ErrInvalidSlackUserMapping)
+	}
+
+	teamID, err := s.organizationWorkspace(ctx, cmd.OrganizationID, cmd.TeamID)
+	if err != nil {
+		return backend.SlackUserMapping{}, err
+	}
+
+	_, err = s.identity.OrganizationUser(ctx, backend.OrganizationUserQuery{
+		OrganizationID: cmd.OrganizationID,
+		UserID:         cmd.UserID,
+	})
+	if errors.Is(err, backend.ErrUserNotFound) {
+		return backend.SlackUserMapping{}, fmt.Errorf("%w: user is not a member of the organization", domain.ErrInvalidSlackUserMapping)
+	}
+	if err != nil {
+		return backend.SlackUserMapping{}, fmt.Errorf("failed to find organization user: %w", err)
+	}
+
+	return s.userMappingRepository.SaveSlackUserMapping(ctx, backend.SlackUserMapping{
+		TeamID:         teamID,
+		SlackUserID:    cmd.SlackUserID,
+		OrganizationID: cmd.OrganizationID,
+		UserID:         cmd.UserID,
+		Source:         backend.SlackUserMappingSourceManual,
+	})
+}
+
+func (s *Service) UnmapSlackUser(ctx context.Context, cmd backend.UnmapSlackUserCommand) error {
+	teamID, err := s.organizationWorkspace(ctx, cmd.OrganizationID, cmd.TeamID)
+	if err != nil {
+		return err
+	}
+	return s.userMappingRepository.DeleteSlackUserMapping(ctx, cmd.OrganizationID, teamID, cmd.SlackUserID)
+}
@@ -0,0 +1,107 @@
+package conversationsvc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/google/uuid"
+)
+
+type workspaceRepository struct {
+	domain.IntegrationRepository
+	organizations map[string]uuid.UUID
+}
+
+func (r workspaceRepository) BusinessIDByProviderProjectID(ctx context.Context, provider backend.ConnectorType, teamID string) (uuid.UUID, error) {
+	organizationID, ok := r.organizations[teamID]
+	if !ok {
+		return uuid.Nil, sql.ErrNoRows
+	}
+	return organizationID, nil
+}
+
+type identityService struct {
+	backend.IdentityService
+	members map[uuid.UUID][]backend.User
+	lookups int
+}
+
+func (s *identityService) OrganizationUser(ctx context.Context, query backend.OrganizationUserQuery) (backend.User, error) {
+	s.lookups++
+	for _, user := range s.members[query.OrganizationID] {
+		if user.ID == query.UserID || (query.UserID == uuid.Nil && strings.EqualFold(user.Email, query.Email)) {
+			return user, nil
+		}
+	}
+	return backend.User{}, backend.ErrUserNotFound
+}
+
+type userMappingRepository struct {
+	domain.UserMappingRepository
+	mappings map[string]backend.SlackUserMapping
+}
+
+func (r *userMappingRepository) SlackUserMapping(ctx context.Context, teamID, slackUserID string) (backend.SlackUserMapping, error) {
+	mapping, ok := r.mappings[teamID+"/"+slackUserID]
+	if !ok {
+		return backend.SlackUserMapping{}, sql.ErrNoRows
+	}
+	return mapping, nil
+}
+
+func (r *userMappingRepository) SaveSlackUserMapping(ctx context.Context, mapping backend.SlackUserMapping) (backend.SlackUserMapping, error) {
+	r.mappings[mapping.TeamID+"/"+mapping.SlackUserID] = mapping
+	return mapping, nil
+}
+
+func TestResolveUser(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	jane := backend.User{ID: uuid.New(), Email: "jane@acme.com"}
+
+	identity := &identityService{members: map[uuid.UUID][]backend.User{orgID: {jane}}}
+	mappings := &userMappingRepository{mappings: map[string]backend.SlackUserMapping{}}
+	svc := &Service{
+		integrationRepository: workspaceRepository{organizations: map[string]uuid.UUID{"T1": orgID}},
+		userMappingRepository: mappings,
+		identity:              identity,
+	}
+
+	thread := domain.SlackThread{TeamID: "T1", Sender: domain.SlackUser{ID: "U1", Email: "Jane@acme.com"}}
+	for range 2 {
+		userID, err := svc.resolveUser(ctx, thread)
+		if err != nil {
+			t.Fatalf("resolveUser() error = %v", err)
+		}
+		if userID != jane.ID {
+			t.Errorf("resolveUser() = %s, want the member matched by email", userID)
+		}
+	}
+	if identity.lookups != 1 {
+		t.Errorf("identity lookups = %d, want the mapping reused after the first message", identity.lookups)
+	}
+	if mapping := mappings.mappings["T1/U1"]; mapping.Source != backend.SlackUserMappingSourceEmail || mapping.OrganizationID != orgID {
+		t.Errorf("stored mapping = %+v, want an email mapping in the organization", mapping)
+	}
+
+	stranger := domain.SlackThread{TeamID: "T1", Sender: domain.SlackUser{ID: "U2", Email: "someone@else.com"}}
+	if _, err := svc.resolveUser(ctx, stranger); !errors.Is(err, domain.ErrSlackUserNotMapped) {
+		t.Errorf("resolveUser() for a non-member error = %v, want ErrSlackUserNotMapped", err)
+	}
+
+	noEmail := domain.SlackThread{TeamID: "T1", Sender: domain.SlackUser{ID: "U3"}}
+	if _, err := svc.resolveUser(ctx, noEmail); !errors.Is(err, domain.ErrSlackUserNotMapped) {
+		t.Errorf("resolveUser() without an email error = %v, want ErrSlackUserNotMapped", err)
+	}
+
+	unlinked := domain.SlackThread{TeamID: "T9", Sender: domain.SlackUser{ID: "U1", Email: "jane@acme.com"}}
+	userID, err := svc.resolveUser(ctx, unlinked)
+	if err != nil || userID != uuid.Nil {
+		t.Errorf("resolveUser() in an unlinked workspace = %s, %v, want no user and no error", userID, err)
+	}
+}
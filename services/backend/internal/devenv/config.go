@@ -0,0 +1,89 @@
+// Package devenv wires the backend for local development: repositories are
+// kept in memory, GitHub is simulated and demo data is seeded at startup, so
+// no Postgres, Clerk, Slack app or GitHub App is needed.
+package devenv
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/identityapi"
+	"github.com/73ai/infragpt/services/backend/integrationapi"
+	conversationdomain "github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	conversationdomaintest "github.com/73ai/infragpt/services/backend/internal/conversationsvc/domaintest"
+	"github.com/73ai/infragpt/services/backend/internal/identitysvc"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domaintest"
+)
+
+const defaultCallbackURL = "http://localhost:3000/integrations/github/callback"
+
+type Config struct {
+	Enabled bool `mapstructure:"enabled"`
+	// CallbackURL is where the simulated GitHub App installation redirects,
+	// with installation_id and state, like the app's setup URL.
+	CallbackURL string `mapstructure:"callback_url"`
+}
+
+// Environment is a backend running on in-memory repositories.
+type Environment struct {
+	Integrations  backend.IntegrationService
+	Identity      backend.IdentityService
+	Conversations conversationdomain.ConversationRepository
+	Seed          Seed
+	// Handler serves the identity, integration and webhook endpoints. Requests
+	// are not authenticated.
+	Handler http.Handler
+}
+
+func (c Config) New(ctx context.Context) (*Environment, error) {
+	callbackURL := c.CallbackURL
+	if callbackURL == "" {
+		callbackURL = defaultCallbackURL
+	}
+
+	integrations := domaintest.NewIntegrationRepository()
+	credentials := domaintest.NewCredentialRepository(integrations)
+	integrationService := integrationsvc.NewService(integrationsvc.ServiceConfig{
+		IntegrationRepository:       integrations,
+		CredentialRepository:        credentials,
+		ActivityRepository:          domaintest.NewActivityRepository(),
+		IntegrationDataRepository:   domaintest.NewIntegrationDataRepository(integrations, credentials),
+		CredentialAccessRepository:  domaintest.NewCredentialAccessRepository(),
+		SyncJobRepository:           domaintest.NewSyncJobRepository(),
+		RepositoryTriggerRepository: domaintest.NewRepositoryTriggerRepository(),
+		Connectors: map[backend.ConnectorType]domain.Connector{
+			backend.ConnectorTypeGithub: newGithubConnector(callbackURL, integrations),
+		},
+	})
+
+	identityService := identitysvc.Config{}.NewInMemory(seedIdentityEvents...)
+	if err := identityService.Subscribe(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create demo organization: %w", err)
+	}
+
+	env := &Environment{
+		Integrations:  integrationService,
+		Identity:      identityService,
+		Conversations: conversationdomaintest.NewConversationRepository(),
+	}
+
+	seed, err := env.seed(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seed demo data: %w", err)
+	}
+	env.Seed = seed
+
+	unauthenticated := func(h http.Handler) http.Handler { return h }
+	identityHandler := identityapi.NewHandler(env.Identity, unauthenticated)
+	mux := http.NewServeMux()
+	mux.Handle("/identity/", identityHandler)
+	mux.Handle("/integrations/", integrationapi.NewHandler(integrationService, unauthenticated))
+	integrationService.RegisterWebhookRoutes(mux)
+	env.Handler = mux
+
+	return env, nil
+}
@@ -0,0 +1,121 @@
+package devenv_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/73ai/infragpt/services/backend/internal/devenv"
+)
+
+type integration struct {
+	ID            string `json:"id"`
+	ConnectorType string `json:"connector_type"`
+	Status        string `json:"status"`
+	BotID         string `json:"bot_id"`
+}
+
+func post(t *testing.T, server *httptest.Server, path string, header http.Header, body any, out any) {
+	t.Helper()
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("failed to marshal %s request: %v", path, err)
+	}
+	req, err := http.NewRequest(http.MethodPost, server.URL+path, bytes.NewReader(payload))
+	if err != nil {
+		t.Fatalf("failed to create %s request: %v", path, err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("POST %s error = %v", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("POST %s status = %d, want 200", path, resp.StatusCode)
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("failed to decode %s response: %v", path, err)
+		}
+	}
+}
+
+func TestDevelopmentEnvironment(t *testing.T) {
+	ctx := context.Background()
+	const callbackURL = "http://console.test/integrations/github/callback"
+
+	env, err := devenv.Config{Enabled: true, CallbackURL: callbackURL}.New(ctx)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	server := httptest.NewServer(env.Handler)
+	t.Cleanup(server.Close)
+
+	organizationID := env.Seed.OrganizationID.String()
+	list := func() []integration {
+		var resp struct {
+			Integrations []integration `json:"integrations"`
+		}
+		post(t, server, "/integrations/list/", nil, map[string]string{"organization_id": organizationID}, &resp)
+		return resp.Integrations
+	}
+
+	seeded := list()
+	if len(seeded) != 1 || seeded[0].ID != env.Seed.IntegrationID.String() || seeded[0].Status != "active" {
+		t.Fatalf("seeded integrations = %+v, want the active demo GitHub integration", seeded)
+	}
+	history, err := env.Conversations.GetConversationHistory(ctx, env.Seed.ConversationID)
+	if err != nil || len(history) != 2 {
+		t.Fatalf("demo conversation history = %d messages, error = %v, want 2", len(history), err)
+	}
+
+	post(t, server, "/integrations/revoke/", nil, map[string]string{
+		"integration_id":  env.Seed.IntegrationID.String(),
+		"organization_id": organizationID,
+	}, nil)
+
+	var intent struct {
+		Type string `json:"type"`
+		URL  string `json:"url"`
+	}
+	post(t, server, "/integrations/initiate/", nil, map[string]string{
+		"organization_id": organizationID,
+		"user_id":         env.Seed.UserID.String(),
+		"connector_type":  "github",
+	}, &intent)
+	if intent.Type != "installation" || !strings.HasPrefix(intent.URL, callbackURL+"?") {
+		t.Fatalf("initiate = %+v, want an installation redirecting to %s", intent, callbackURL)
+	}
+	callback, err := url.Parse(intent.URL)
+	if err != nil {
+		t.Fatalf("failed to parse authorization URL: %v", err)
+	}
+
+	var authorized integration
+	post(t, server, "/integrations/authorize/", nil, map[string]string{
+		"connector_type":  "github",
+		"state":           callback.Query().Get("state"),
+		"installation_id": callback.Query().Get("installation_id"),
+	}, &authorized)
+	if authorized.Status != "active" || authorized.BotID != callback.Query().Get("installation_id") {
+		t.Fatalf("authorize = %+v, want an active integration for installation %s", authorized, callback.Query().Get("installation_id"))
+	}
+
+	post(t, server, "/webhooks/github", http.Header{"X-Github-Event": {"installation"}}, map[string]any{
+		"action":       "deleted",
+		"installation": map[string]any{"id": json.Number(authorized.BotID)},
+	}, nil)
+	if got := list(); len(got) != 1 || got[0].ID != authorized.ID || got[0].Status != "inactive" {
+		t.Errorf("integrations after uninstall = %+v, want %s inactive", got, authorized.ID)
+	}
+}
@@ -0,0 +1,208 @@
+package devenv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/github"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+// demoRepositories are the repositories every simulated installation can
+// access.
+var demoRepositories = []string{"acme/infra", "acme/payments", "acme/web"}
+
+// githubConnector simulates a GitHub App. Authorizing skips GitHub and sends
+// the browser straight to the callback with a new installation, and webhooks
+// posted to /webhooks/github are accepted without a signature.
+type githubConnector struct {
+	callbackURL   string
+	integrations  domain.IntegrationRepository
+	installations atomic.Int64
+}
+
+func newGithubConnector(callbackURL string, integrations domain.IntegrationRepository) *githubConnector {
+	g := &githubConnector{callbackURL: callbackURL, integrations: integrations}
+	g.installations.Store(1000)
+	return g
+}
+
+func (g *githubConnector) InitiateAuthorization(organizationID string, userID string) (backend.IntegrationAuthorizationIntent, error) {
+	params := url.Values{}
+	params.Set("installation_id", strconv.FormatInt(g.installations.Add(1), 10))
+	params.Set("setup_action", "install")
+	params.Set("state", organizationID+":"+userID)
+
+	return backend.IntegrationAuthorizationIntent{
+		Type: backend.AuthorizationTypeInstallation,
+		URL:  g.callbackURL + "?" + params.Encode(),
+	}, nil
+}
+
+func (g *githubConnector) ParseState(state string) (organizationID uuid.UUID, userID uuid.UUID, err error) {
+	orgPart, userPart, ok := strings.Cut(state, ":")
+	if !ok {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid state format", backend.ErrInvalidState)
+	}
+	if organizationID, err = uuid.Parse(orgPart); err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid organization ID in state: %w", backend.ErrInvalidState, err)
+	}
+	if userID, err = uuid.Parse(userPart); err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid user ID in state: %w", backend.ErrInvalidState, err)
+	}
+	return organizationID, userID, nil
+}
+
+func (g *githubConnector) CompleteAuthorization(authData backend.AuthorizationData) (backend.Credentials, error) {
+	if authData.InstallationID == "" {
+		return backend.Credentials{}, fmt.Errorf("installation ID is required for GitHub App")
+	}
+	if _, _, err := g.ParseState(authData.State); err != nil {
+		return backend.Credentials{}, err
+	}
+
+	return backend.Credentials{
+		Type: backend.CredentialTypeToken,
+		Data: map[string]string{"installation_id": authData.InstallationID},
+		OrganizationInfo: &backend.OrganizationInfo{
+			ExternalID: "acme-" + authData.InstallationID,
+			Name:       "acme",
+			Metadata:   map[string]string{"github_account_type": "Organization"},
+		},
+	}, nil
+}
+
+func (g *githubConnector) ValidateCredentials(creds backend.Credentials) error {
+	if creds.Data["installation_id"] == "" {
+		return fmt.Errorf("installation ID not found in credentials")
+	}
+	return nil
+}
+
+func (g *githubConnector) RefreshCredentials(creds backend.Credentials) (backend.Credentials, error) {
+	return creds, nil
+}
+
+func (g *githubConnector) RevokeCredentials(creds backend.Credentials) error {
+	return nil
+}
+
+func (g *githubConnector) Permissions(creds backend.Credentials) (map[string]string, error) {
+	return map[string]string{"contents": "read", "metadata": "read", "pull_requests": "read"}, nil
+}
+
+func (g *githubConnector) ConfigureWebhooks(integrationID string, creds backend.Credentials) error {
+	return nil
+}
+
+func (g *githubConnector) ValidateWebhookSignature(payload []byte, signature string, secret string) error {
+	return nil
+}
+
+// Subscribe returns at once; webhooks arrive through RegisterRoutes.
+func (g *githubConnector) Subscribe(ctx context.Context, handler func(ctx context.Context, event any) error) error {
+	return nil
+}
+
+func (g *githubConnector) RegisterRoutes(mux *http.ServeMux, handler func(ctx context.Context, event any) error) bool {
+	mux.HandleFunc("POST /webhooks/github", func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+
+		event := github.WebhookEvent{
+			EventType:  github.EventType(r.Header.Get("X-GitHub-Event")),
+			RawPayload: payload,
+			CreatedAt:  time.Now(),
+		}
+		if installation, ok := payload["installation"].(map[string]any); ok {
+			if id, ok := installation["id"].(float64); ok {
+				event.InstallationID = strconv.FormatFloat(id, 'f', 0, 64)
+			}
+		}
+		if action, ok := payload["action"].(string); ok {
+			event.Action = action
+			event.InstallationAction = action
+		}
+
+		if err := handler(r.Context(), event); err != nil {
+			slog.Error("error handling simulated GitHub webhook event", "event_type", event.EventType, "error", err)
+			http.Error(w, "Failed to handle event", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	return true
+}
+
+// ProcessEvent applies installation events to the integration's status the
+// way the real connector does.
+func (g *githubConnector) ProcessEvent(ctx context.Context, event any) error {
+	webhookEvent, ok := event.(github.WebhookEvent)
+	if !ok {
+		return fmt.Errorf("invalid event type: expected WebhookEvent")
+	}
+	if webhookEvent.EventType != github.EventTypeInstallation {
+		slog.Debug("ignoring simulated GitHub event", "event_type", webhookEvent.EventType)
+		return nil
+	}
+
+	var status backend.IntegrationStatus
+	switch webhookEvent.InstallationAction {
+	case "deleted":
+		status = backend.IntegrationStatusInactive
+	case "suspend":
+		status = backend.IntegrationStatusSuspended
+	case "unsuspend":
+		status = backend.IntegrationStatusActive
+	default:
+		return nil
+	}
+
+	integration, err := g.integrations.FindByBotIDAndType(ctx, webhookEvent.InstallationID, backend.ConnectorTypeGithub)
+	if err != nil {
+		return fmt.Errorf("failed to find integration for installation %s: %w", webhookEvent.InstallationID, err)
+	}
+	return g.integrations.UpdateStatus(ctx, integration.ID, status)
+}
+
+func (g *githubConnector) Sync(ctx context.Context, integration backend.Integration, params map[string]string) (backend.SyncResult, error) {
+	return backend.SyncResult{}, nil
+}
+
+func (g *githubConnector) Repositories(ctx context.Context, integration backend.Integration) ([]backend.SyncedRepository, error) {
+	repositories := make([]backend.SyncedRepository, len(demoRepositories))
+	for i, fullName := range demoRepositories {
+		owner, name, _ := strings.Cut(fullName, "/")
+		repositories[i] = backend.SyncedRepository{
+			ID:            int64(i + 1),
+			Name:          name,
+			FullName:      fullName,
+			URL:           "https://github.com/" + owner + "/" + name,
+			Private:       true,
+			DefaultBranch: "main",
+			Permissions:   backend.RepositoryPermissions{Pull: true},
+			Enabled:       true,
+			LastSyncedAt:  integration.CreatedAt,
+		}
+	}
+	return repositories, nil
+}
+
+// PullRequestFiles reports every pull request as changing the Terraform root
+// module, so path-filtered repository triggers can be tried out.
+func (g *githubConnector) PullRequestFiles(ctx context.Context, integration backend.Integration, repository string, number int) ([]string, error) {
+	return []string{"terraform/main.tf"}, nil
+}
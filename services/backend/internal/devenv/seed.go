@@ -0,0 +1,108 @@
+package devenv
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/73ai/infragpt/services/backend"
+	conversationdomain "github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	demoClerkUserID = "user_dev"
+	demoClerkOrgID  = "org_dev"
+	demoTeamID      = "T0DEV"
+	demoChannelID   = "C0DEV"
+	demoThreadTS    = "1700000000.000100"
+)
+
+// seedIdentityEvents are applied as if Clerk had sent them when a developer
+// signed up and created an organization.
+var seedIdentityEvents = []any{
+	backend.UserCreatedEvent{
+		ClerkUserID: demoClerkUserID,
+		Email:       "dev@acme.example",
+		FirstName:   "Dev",
+		LastName:    "User",
+	},
+	backend.OrganizationCreatedEvent{
+		ClerkOrgID:      demoClerkOrgID,
+		Name:            "Acme",
+		Slug:            "acme",
+		CreatedByUserID: demoClerkUserID,
+	},
+}
+
+// Seed identifies the demo data created at startup.
+type Seed struct {
+	ClerkUserID    string
+	ClerkOrgID     string
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	IntegrationID  uuid.UUID
+	ConversationID uuid.UUID
+}
+
+// seed finds the demo organization and user, connects GitHub through the same
+// initiate and authorize calls the console makes, and stores a sample
+// conversation.
+func (e *Environment) seed(ctx context.Context) (Seed, error) {
+	profile, err := e.Identity.Profile(ctx, backend.ProfileQuery{ClerkUserID: demoClerkUserID, ClerkOrgID: demoClerkOrgID})
+	if err != nil {
+		return Seed{}, fmt.Errorf("failed to find demo organization: %w", err)
+	}
+
+	intent, err := e.Integrations.NewIntegration(ctx, backend.NewIntegrationCommand{
+		OrganizationID: profile.OrganizationID,
+		UserID:         profile.UserID,
+		ConnectorType:  backend.ConnectorTypeGithub,
+	})
+	if err != nil {
+		return Seed{}, fmt.Errorf("failed to initiate demo integration: %w", err)
+	}
+	callback, err := url.Parse(intent.URL)
+	if err != nil {
+		return Seed{}, fmt.Errorf("failed to parse authorization URL: %w", err)
+	}
+	integration, err := e.Integrations.AuthorizeIntegration(ctx, backend.AuthorizeIntegrationCommand{
+		ConnectorType:  backend.ConnectorTypeGithub,
+		State:          callback.Query().Get("state"),
+		InstallationID: callback.Query().Get("installation_id"),
+	})
+	if err != nil {
+		return Seed{}, fmt.Errorf("failed to authorize demo integration: %w", err)
+	}
+
+	conversation, err := e.Conversations.CreateConversation(ctx, demoTeamID, demoChannelID, demoThreadTS)
+	if err != nil {
+		return Seed{}, fmt.Errorf("failed to create demo conversation: %w", err)
+	}
+	for _, message := range []conversationdomain.Message{
+		{
+			SlackMessageTS: demoThreadTS,
+			Sender:         conversationdomain.SlackUser{ID: "U0DEV", Name: "Dev User", Username: "dev"},
+			MessageText:    "Which repositories does the payments service deploy from?",
+		},
+		{
+			SlackMessageTS: "1700000005.000200",
+			Sender:         conversationdomain.SlackUser{ID: "B0DEV", Username: "infragpt"},
+			MessageText:    "The payments service is built from acme/payments and its infrastructure lives in acme/infra.",
+			IsBotMessage:   true,
+		},
+	} {
+		if _, err := e.Conversations.StoreMessage(ctx, conversation.ID, message); err != nil {
+			return Seed{}, fmt.Errorf("failed to store demo message: %w", err)
+		}
+	}
+
+	return Seed{
+		ClerkUserID:    demoClerkUserID,
+		ClerkOrgID:     demoClerkOrgID,
+		OrganizationID: profile.OrganizationID,
+		UserID:         profile.UserID,
+		IntegrationID:  integration.ID,
+		ConversationID: conversation.ID,
+	}, nil
+}
@@ -7,12 +7,25 @@ import (
 )
 
 type Config struct {
-	Database *sql.DB `mapstructure:"-"`
+	Database *sql.DB       `mapstructure:"-"`
+	History  HistoryConfig `mapstructure:"history"`
+	// VerificationURL is returned to the CLI as the page to enter the user
+	// code on. Point it at the backend's /device/verify to use the
+	// server-rendered page instead of the console. Defaults to
+	// DefaultVerificationURL.
+	VerificationURL string `mapstructure:"verification_url"`
 }
 
 func (c Config) New() *Service {
 	deviceCodeRepo := postgres.NewDeviceCodeRepository(c.Database)
 	deviceTokenRepo := postgres.NewDeviceTokenRepository(c.Database)
+	apiKeyRepo := postgres.NewAPIKeyRepository(c.Database)
+	historyRepo := postgres.NewHistoryRepository(c.Database)
+	organizationRepo := postgres.NewOrganizationDataRepository(c.Database)
 
-	return NewService(deviceCodeRepo, deviceTokenRepo)
+	svc := NewService(deviceCodeRepo, deviceTokenRepo, apiKeyRepo, historyRepo, organizationRepo, c.History)
+	if c.VerificationURL != "" {
+		svc.verificationURL = c.VerificationURL
+	}
+	return svc
 }
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey authenticates calls to the public API on behalf of an organization.
+// Only a hash of the key is stored; the key itself is shown once, when it is
+// issued.
+type APIKey struct {
+	ID   uuid.UUID
+	Name string
+	// Prefix is the start of the key, kept so members can tell keys apart.
+	Prefix         string
+	Hash           string
+	OrganizationID uuid.UUID
+	// UserID is the member who issued the key; calls made with it are
+	// attributed to them.
+	UserID    uuid.UUID
+	CreatedAt time.Time
+	RevokedAt *time.Time
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key APIKey) error
+	GetByHash(ctx context.Context, hash string) (*APIKey, error)
+	ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]APIKey, error)
+	// Revoke returns ErrAPIKeyNotFound when the organization has no key with
+	// the ID. Revoking a revoked key keeps its original revocation time.
+	Revoke(ctx context.Context, organizationID, id uuid.UUID) error
+}
@@ -28,6 +28,8 @@ type DeviceCode struct {
 }
 
 type DeviceCodeRepository interface {
+	// Create returns ErrUserCodeTaken when another pending or authorized code
+	// already uses the same user code.
 	Create(ctx context.Context, code DeviceCode) error
 	GetByUserCode(ctx context.Context, userCode string) (*DeviceCode, error)
 	GetByDeviceCode(ctx context.Context, deviceCode string) (*DeviceCode, error)
@@ -3,12 +3,18 @@ package domain
 import "errors"
 
 var (
-	ErrDeviceCodeNotFound  = errors.New("device code not found")
-	ErrDeviceCodeExpired   = errors.New("device code expired")
-	ErrDeviceCodeUsed      = errors.New("device code already used")
-	ErrDeviceTokenNotFound = errors.New("device token not found")
-	ErrDeviceTokenRevoked  = errors.New("device token revoked")
-	ErrDeviceTokenExpired  = errors.New("device token expired")
-	ErrInvalidUserCode     = errors.New("invalid user code")
+	ErrDeviceCodeNotFound   = errors.New("device code not found")
+	ErrDeviceCodeExpired    = errors.New("device code expired")
+	ErrDeviceCodeUsed       = errors.New("device code already used")
+	ErrDeviceTokenNotFound  = errors.New("device token not found")
+	ErrDeviceTokenRevoked   = errors.New("device token revoked")
+	ErrDeviceTokenExpired   = errors.New("device token expired")
+	ErrInvalidUserCode      = errors.New("invalid user code")
 	ErrAuthorizationPending = errors.New("authorization pending")
+	ErrUserCodeTaken        = errors.New("user code already in use")
+	ErrInvalidHistoryEntry  = errors.New("invalid history entry")
+	ErrInvalidHistoryCursor = errors.New("invalid history cursor")
+	ErrAPIKeyNotFound       = errors.New("API key not found")
+	ErrAPIKeyRevoked        = errors.New("API key revoked")
+	ErrInvalidAPIKeyName    = errors.New("API key name must be 1 to 255 characters")
 )
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HistoryEntry is a command the CLI ran, synced so history follows the user
+// across machines.
+type HistoryEntry struct {
+	// EntryID is the client-generated ULID that makes pushes idempotent.
+	EntryID        string
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+	DeviceID       uuid.UUID
+	Command        string
+	ExitCode       *int
+	ConversationID string
+	ExecutedAt     time.Time
+	// Cursor orders entries by arrival and is what pulls page on.
+	Cursor int64
+}
+
+type HistoryQuery struct {
+	UserID uuid.UUID
+	// DeviceID limits the query to one device; uuid.Nil returns every device.
+	DeviceID uuid.UUID
+	After    int64
+	Limit    int
+}
+
+type HistoryRepository interface {
+	// Append stores entries, skipping any EntryID the user already pushed, and
+	// returns how many were new.
+	Append(ctx context.Context, entries []HistoryEntry) (int, error)
+	List(ctx context.Context, query HistoryQuery) ([]HistoryEntry, error)
+	// Evict deletes the user's oldest entries until their commands fit in
+	// maxBytes and returns how many were deleted.
+	Evict(ctx context.Context, userID uuid.UUID, maxBytes int64) (int, error)
+}
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationData counts the device records stored for an organization.
+type OrganizationData struct {
+	DeviceCodes    int
+	DeviceTokens   int
+	HistoryEntries int
+	APIKeys        int
+}
+
+type OrganizationDataRepository interface {
+	// DeleteOrganizationData deletes the organization's device records in one
+	// transaction. With dryRun the transaction is rolled back, so the counts
+	// report what would be deleted.
+	DeleteOrganizationData(ctx context.Context, organizationID uuid.UUID, dryRun bool) (OrganizationData, error)
+}
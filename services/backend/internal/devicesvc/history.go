@@ -0,0 +1,163 @@
+package devicesvc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc/domain"
+)
+
+const (
+	MaxHistoryBatch        = 500
+	MaxHistoryCommandBytes = 16 * 1024
+	DefaultHistoryPullSize = 200
+	MaxHistoryPullSize     = 1000
+
+	defaultHistoryMaxBytesPerUser = 10 * 1024 * 1024
+)
+
+// ulidCharset is Crockford's base32, which ULIDs are encoded in.
+const ulidCharset = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+type HistoryConfig struct {
+	// MaxBytesPerUser caps the command text stored per user; the oldest
+	// entries are evicted once a push goes over it.
+	MaxBytesPerUser int64 `mapstructure:"max_bytes_per_user"`
+}
+
+func (c HistoryConfig) maxBytesPerUser() int64 {
+	if c.MaxBytesPerUser > 0 {
+		return c.MaxBytesPerUser
+	}
+	return defaultHistoryMaxBytesPerUser
+}
+
+type PushHistoryResult struct {
+	Accepted   int
+	Duplicates int
+	Evicted    int
+}
+
+// PushHistory stores a batch of the device's history. Entries whose ID the user
+// already pushed, from any device, are ignored so clients can safely retry.
+func (s *Service) PushHistory(ctx context.Context, device ValidateTokenResult, entries []domain.HistoryEntry) (PushHistoryResult, error) {
+	if len(entries) > MaxHistoryBatch {
+		return PushHistoryResult{}, fmt.Errorf("%w: at most %d entries per push", domain.ErrInvalidHistoryEntry, MaxHistoryBatch)
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+		entry.EntryID = strings.ToUpper(entry.EntryID)
+		if err := validateHistoryEntry(*entry); err != nil {
+			return PushHistoryResult{}, fmt.Errorf("%w: entry %d: %s", domain.ErrInvalidHistoryEntry, i, err)
+		}
+		entry.OrganizationID = device.OrganizationID
+		entry.UserID = device.UserID
+		entry.DeviceID = device.DeviceID
+	}
+
+	accepted, err := s.historyRepo.Append(ctx, entries)
+	if err != nil {
+		return PushHistoryResult{}, fmt.Errorf("failed to store history: %w", err)
+	}
+
+	evicted, err := s.historyRepo.Evict(ctx, device.UserID, s.history.maxBytesPerUser())
+	if err != nil {
+		return PushHistoryResult{}, fmt.Errorf("failed to enforce history quota: %w", err)
+	}
+
+	return PushHistoryResult{
+		Accepted:   accepted,
+		Duplicates: len(entries) - accepted,
+		Evicted:    evicted,
+	}, nil
+}
+
+type PullHistoryQuery struct {
+	// Cursor is the NextCursor of the previous pull; zero starts from the beginning.
+	Cursor int64
+	// DeviceOnly limits the pull to the calling device's own history.
+	DeviceOnly bool
+	Limit      int
+}
+
+type PullHistoryResult struct {
+	Entries    []domain.HistoryEntry
+	NextCursor int64
+	HasMore    bool
+}
+
+// PullHistory returns the user's history pushed after the cursor, oldest first.
+func (s *Service) PullHistory(ctx context.Context, device ValidateTokenResult, query PullHistoryQuery) (PullHistoryResult, error) {
+	if query.Cursor < 0 {
+		return PullHistoryResult{}, domain.ErrInvalidHistoryCursor
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultHistoryPullSize
+	}
+	limit = min(limit, MaxHistoryPullSize)
+
+	historyQuery := domain.HistoryQuery{
+		UserID: device.UserID,
+		After:  query.Cursor,
+		Limit:  limit + 1,
+	}
+	if query.DeviceOnly {
+		historyQuery.DeviceID = device.DeviceID
+	}
+
+	entries, err := s.historyRepo.List(ctx, historyQuery)
+	if err != nil {
+		return PullHistoryResult{}, fmt.Errorf("failed to list history: %w", err)
+	}
+
+	result := PullHistoryResult{NextCursor: query.Cursor}
+	if len(entries) > limit {
+		entries = entries[:limit]
+		result.HasMore = true
+	}
+	if len(entries) > 0 {
+		result.NextCursor = entries[len(entries)-1].Cursor
+	}
+	result.Entries = entries
+	return result, nil
+}
+
+func validateHistoryEntry(entry domain.HistoryEntry) error {
+	if !isULID(entry.EntryID) {
+		return fmt.Errorf("id %q is not a ULID", entry.EntryID)
+	}
+	if strings.TrimSpace(entry.Command) == "" {
+		return fmt.Errorf("command is required")
+	}
+	if len(entry.Command) > MaxHistoryCommandBytes {
+		return fmt.Errorf("command is longer than %d bytes", MaxHistoryCommandBytes)
+	}
+	if entry.ExecutedAt.IsZero() {
+		return fmt.Errorf("timestamp is required")
+	}
+	if entry.ExecutedAt.After(time.Now().Add(24 * time.Hour)) {
+		return fmt.Errorf("timestamp is in the future")
+	}
+	if len(entry.ConversationID) > 64 {
+		return fmt.Errorf("conversation_id is longer than 64 characters")
+	}
+	return nil
+}
+
+func isULID(id string) bool {
+	// The first character only carries three bits of the 48-bit timestamp.
+	if len(id) != 26 || id[0] > '7' {
+		return false
+	}
+	for _, c := range id {
+		if !strings.ContainsRune(ulidCharset, c) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,24 @@
+package devicesvc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/73ai/infragpt/services/backend"
+)
+
+var _ backend.OrganizationDataOwner = (*Service)(nil)
+
+func (s *Service) DeleteOrganizationData(ctx context.Context, cmd backend.DeleteOrganizationCommand) ([]backend.DeletedResource, error) {
+	data, err := s.organizationRepo.DeleteOrganizationData(ctx, cmd.OrganizationID, cmd.DryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete device data: %w", err)
+	}
+
+	return []backend.DeletedResource{
+		{Type: "device_tokens", Count: data.DeviceTokens},
+		{Type: "device_codes", Count: data.DeviceCodes},
+		{Type: "device_history", Count: data.HistoryEntries},
+		{Type: "api_keys", Count: data.APIKeys},
+	}, nil
+}
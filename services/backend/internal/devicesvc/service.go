@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -20,20 +21,45 @@ const (
 	UserCodeLength     = 8
 	AccessTokenLength  = 32
 	RefreshTokenLength = 32
+	// maxUserCodeAttempts bounds retries when a generated user code collides
+	// with one that is still active.
+	maxUserCodeAttempts = 5
+	// DefaultVerificationURL is the console page where users enter their code.
+	// Relative URLs are resolved against the console by the CLI.
+	DefaultVerificationURL = "/cli/verify"
 )
 
+// userCodeCharset leaves out characters that are easy to misread, such as O/0 and I/1.
+const userCodeCharset = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
 type Service struct {
-	deviceCodeRepo  domain.DeviceCodeRepository
-	deviceTokenRepo domain.DeviceTokenRepository
+	deviceCodeRepo   domain.DeviceCodeRepository
+	deviceTokenRepo  domain.DeviceTokenRepository
+	apiKeyRepo       domain.APIKeyRepository
+	historyRepo      domain.HistoryRepository
+	organizationRepo domain.OrganizationDataRepository
+	history          HistoryConfig
+	verificationURL  string
+	newUserCode      func() (string, error)
 }
 
 func NewService(
 	deviceCodeRepo domain.DeviceCodeRepository,
 	deviceTokenRepo domain.DeviceTokenRepository,
+	apiKeyRepo domain.APIKeyRepository,
+	historyRepo domain.HistoryRepository,
+	organizationRepo domain.OrganizationDataRepository,
+	history HistoryConfig,
 ) *Service {
 	return &Service{
-		deviceCodeRepo:  deviceCodeRepo,
-		deviceTokenRepo: deviceTokenRepo,
+		deviceCodeRepo:   deviceCodeRepo,
+		deviceTokenRepo:  deviceTokenRepo,
+		apiKeyRepo:       apiKeyRepo,
+		historyRepo:      historyRepo,
+		organizationRepo: organizationRepo,
+		history:          history,
+		verificationURL:  DefaultVerificationURL,
+		newUserCode:      generateUserCode,
 	}
 }
 
@@ -51,29 +77,34 @@ func (s *Service) InitiateDeviceFlow(ctx context.Context) (InitiateDeviceFlowRes
 		return InitiateDeviceFlowResult{}, fmt.Errorf("failed to generate device code: %w", err)
 	}
 
-	userCode, err := generateUserCode()
-	if err != nil {
-		return InitiateDeviceFlowResult{}, fmt.Errorf("failed to generate user code: %w", err)
-	}
-
 	now := time.Now()
 	code := domain.DeviceCode{
 		ID:         uuid.New(),
 		DeviceCode: deviceCode,
-		UserCode:   userCode,
 		Status:     domain.DeviceCodeStatusPending,
 		ExpiresAt:  now.Add(DeviceCodeExpiry),
 		CreatedAt:  now,
 	}
 
-	if err := s.deviceCodeRepo.Create(ctx, code); err != nil {
-		return InitiateDeviceFlowResult{}, fmt.Errorf("failed to create device code: %w", err)
+	for attempt := 1; ; attempt++ {
+		code.UserCode, err = s.newUserCode()
+		if err != nil {
+			return InitiateDeviceFlowResult{}, fmt.Errorf("failed to generate user code: %w", err)
+		}
+
+		err = s.deviceCodeRepo.Create(ctx, code)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, domain.ErrUserCodeTaken) || attempt == maxUserCodeAttempts {
+			return InitiateDeviceFlowResult{}, fmt.Errorf("failed to create device code: %w", err)
+		}
 	}
 
 	return InitiateDeviceFlowResult{
 		DeviceCode:      deviceCode,
-		UserCode:        userCode,
-		VerificationURL: "/cli/verify",
+		UserCode:        code.UserCode,
+		VerificationURL: s.verificationURL,
 		ExpiresIn:       int(DeviceCodeExpiry.Seconds()),
 		Interval:        5,
 	}, nil
@@ -149,18 +180,28 @@ func (s *Service) PollDeviceFlow(ctx context.Context, deviceCode string) (PollDe
 	}, nil
 }
 
-func (s *Service) AuthorizeDevice(ctx context.Context, userCode string, organizationID, userID uuid.UUID) error {
+// CheckUserCode reports whether userCode can still be authorized. It returns
+// ErrDeviceCodeNotFound, ErrDeviceCodeExpired or ErrDeviceCodeUsed when it
+// cannot.
+func (s *Service) CheckUserCode(ctx context.Context, userCode string) error {
 	code, err := s.deviceCodeRepo.GetByUserCode(ctx, userCode)
 	if err != nil {
 		return err
 	}
 
+	if code.Status == domain.DeviceCodeStatusExpired || code.ExpiresAt.Before(time.Now()) {
+		return domain.ErrDeviceCodeExpired
+	}
 	if code.Status != domain.DeviceCodeStatusPending {
-		if code.Status == domain.DeviceCodeStatusExpired || code.ExpiresAt.Before(time.Now()) {
-			return domain.ErrDeviceCodeExpired
-		}
 		return domain.ErrDeviceCodeUsed
 	}
+	return nil
+}
+
+func (s *Service) AuthorizeDevice(ctx context.Context, userCode string, organizationID, userID uuid.UUID) error {
+	if err := s.CheckUserCode(ctx, userCode); err != nil {
+		return err
+	}
 
 	return s.deviceCodeRepo.Authorize(ctx, userCode, organizationID, userID)
 }
@@ -168,6 +209,8 @@ func (s *Service) AuthorizeDevice(ctx context.Context, userCode string, organiza
 type ValidateTokenResult struct {
 	OrganizationID uuid.UUID
 	UserID         uuid.UUID
+	// DeviceID identifies the CLI install; it survives token refreshes.
+	DeviceID uuid.UUID
 }
 
 func (s *Service) ValidateToken(ctx context.Context, accessToken string) (ValidateTokenResult, error) {
@@ -187,6 +230,7 @@ func (s *Service) ValidateToken(ctx context.Context, accessToken string) (Valida
 	return ValidateTokenResult{
 		OrganizationID: token.OrganizationID,
 		UserID:         token.UserID,
+		DeviceID:       token.ID,
 	}, nil
 }
 
@@ -246,14 +290,14 @@ func generateSecureToken(length int) (string, error) {
 }
 
 func generateUserCode() (string, error) {
-	const charset = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
 	code := make([]byte, UserCodeLength)
 	for i := range code {
 		b := make([]byte, 1)
 		if _, err := rand.Read(b); err != nil {
 			return "", err
 		}
-		code[i] = charset[int(b[0])%len(charset)]
+		// The charset has 32 characters, so every byte value maps evenly.
+		code[i] = userCodeCharset[int(b[0])%len(userCodeCharset)]
 	}
 	formatted := string(code[:4]) + "-" + string(code[4:])
 	return strings.ToUpper(formatted), nil
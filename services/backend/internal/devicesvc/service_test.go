@@ -0,0 +1,273 @@
+package devicesvc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc/domain"
+	"github.com/google/uuid"
+)
+
+// memoryDeviceCodeRepository enforces the active user code uniqueness of the
+// postgres schema.
+type memoryDeviceCodeRepository struct {
+	domain.DeviceCodeRepository
+	codes []domain.DeviceCode
+}
+
+func (m *memoryDeviceCodeRepository) Create(ctx context.Context, code domain.DeviceCode) error {
+	for _, existing := range m.codes {
+		active := existing.Status == domain.DeviceCodeStatusPending || existing.Status == domain.DeviceCodeStatusAuthorized
+		if active && existing.UserCode == code.UserCode {
+			return domain.ErrUserCodeTaken
+		}
+	}
+	m.codes = append(m.codes, code)
+	return nil
+}
+
+func TestInitiateDeviceFlowRetriesUserCodeCollision(t *testing.T) {
+	repo := &memoryDeviceCodeRepository{codes: []domain.DeviceCode{
+		{UserCode: "ABCD-EFGH", Status: domain.DeviceCodeStatusPending},
+	}}
+	s := NewService(repo, nil, nil, nil, nil, HistoryConfig{})
+
+	generated := 0
+	s.newUserCode = func() (string, error) {
+		generated++
+		if generated == 1 {
+			return "ABCD-EFGH", nil
+		}
+		return generateUserCode()
+	}
+
+	result, err := s.InitiateDeviceFlow(context.Background())
+	if err != nil {
+		t.Fatalf("InitiateDeviceFlow() error = %v", err)
+	}
+	if generated != 2 {
+		t.Errorf("generated %d user codes, want a retry after the collision", generated)
+	}
+	if result.UserCode == "ABCD-EFGH" {
+		t.Errorf("InitiateDeviceFlow() user code = %s, want one distinct from the active code", result.UserCode)
+	}
+	if len(repo.codes) != 2 || repo.codes[1].UserCode != result.UserCode {
+		t.Errorf("stored codes = %+v, want the retried code stored", repo.codes)
+	}
+}
+
+func TestInitiateDeviceFlowGivesUpAfterRepeatedCollisions(t *testing.T) {
+	repo := &memoryDeviceCodeRepository{codes: []domain.DeviceCode{
+		{UserCode: "ABCD-EFGH", Status: domain.DeviceCodeStatusAuthorized},
+	}}
+	s := NewService(repo, nil, nil, nil, nil, HistoryConfig{})
+	s.newUserCode = func() (string, error) { return "ABCD-EFGH", nil }
+
+	if _, err := s.InitiateDeviceFlow(context.Background()); !errors.Is(err, domain.ErrUserCodeTaken) {
+		t.Errorf("InitiateDeviceFlow() error = %v, want ErrUserCodeTaken", err)
+	}
+}
+
+func TestGenerateUserCode(t *testing.T) {
+	code, err := generateUserCode()
+	if err != nil {
+		t.Fatalf("generateUserCode() error = %v", err)
+	}
+	if len(code) != UserCodeLength+1 || code[4] != '-' {
+		t.Fatalf("generateUserCode() = %q, want XXXX-XXXX", code)
+	}
+	for _, c := range strings.Replace(code, "-", "", 1) {
+		if !strings.ContainsRune(userCodeCharset, c) {
+			t.Errorf("generateUserCode() = %q, contains %q outside the charset", code, c)
+		}
+	}
+}
+
+// memoryHistoryRepository deduplicates on the user and entry ID like the
+// postgres unique constraint.
+type memoryHistoryRepository struct {
+	entries []domain.HistoryEntry
+}
+
+func (m *memoryHistoryRepository) Append(ctx context.Context, entries []domain.HistoryEntry) (int, error) {
+	inserted := 0
+	for _, entry := range entries {
+		if slices.ContainsFunc(m.entries, func(e domain.HistoryEntry) bool {
+			return e.UserID == entry.UserID && e.EntryID == entry.EntryID
+		}) {
+			continue
+		}
+		entry.Cursor = int64(len(m.entries) + 1)
+		m.entries = append(m.entries, entry)
+		inserted++
+	}
+	return inserted, nil
+}
+
+func (m *memoryHistoryRepository) List(ctx context.Context, query domain.HistoryQuery) ([]domain.HistoryEntry, error) {
+	var entries []domain.HistoryEntry
+	for _, e := range m.entries {
+		if e.UserID == query.UserID && e.Cursor > query.After && (query.DeviceID == uuid.Nil || e.DeviceID == query.DeviceID) {
+			entries = append(entries, e)
+		}
+	}
+	return entries[:min(len(entries), query.Limit)], nil
+}
+
+func (m *memoryHistoryRepository) Evict(ctx context.Context, userID uuid.UUID, maxBytes int64) (int, error) {
+	return 0, nil
+}
+
+func TestHistorySync(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryHistoryRepository{}
+	s := NewService(nil, nil, nil, repo, nil, HistoryConfig{})
+
+	userID := uuid.New()
+	laptop := ValidateTokenResult{OrganizationID: uuid.New(), UserID: userID, DeviceID: uuid.New()}
+	desktop := ValidateTokenResult{OrganizationID: laptop.OrganizationID, UserID: userID, DeviceID: uuid.New()}
+
+	entry := func(id, command string) domain.HistoryEntry {
+		return domain.HistoryEntry{EntryID: id, Command: command, ExecutedAt: time.Now()}
+	}
+
+	result, err := s.PushHistory(ctx, laptop, []domain.HistoryEntry{
+		entry("01HZY3M8G1X9Q7R2T5V6W8Y0ZA", "kubectl get pods"),
+		entry("01hzy3m8g1x9q7r2t5v6w8y0zb", "terraform plan"),
+	})
+	if err != nil {
+		t.Fatalf("PushHistory() error = %v", err)
+	}
+	if result.Accepted != 2 || result.Duplicates != 0 {
+		t.Errorf("PushHistory() = %+v, want 2 accepted", result)
+	}
+
+	result, err = s.PushHistory(ctx, desktop, []domain.HistoryEntry{
+		entry("01HZY3M8G1X9Q7R2T5V6W8Y0ZB", "terraform plan"),
+		entry("01HZY3M8G1X9Q7R2T5V6W8Y0ZC", "gcloud auth list"),
+	})
+	if err != nil {
+		t.Fatalf("PushHistory() retry error = %v", err)
+	}
+	if result.Accepted != 1 || result.Duplicates != 1 {
+		t.Errorf("PushHistory() retry = %+v, want 1 accepted and 1 duplicate", result)
+	}
+
+	_, err = s.PushHistory(ctx, laptop, []domain.HistoryEntry{entry("not-a-ulid", "ls")})
+	if !errors.Is(err, domain.ErrInvalidHistoryEntry) {
+		t.Errorf("PushHistory() with a bad id error = %v, want %v", err, domain.ErrInvalidHistoryEntry)
+	}
+
+	page, err := s.PullHistory(ctx, desktop, PullHistoryQuery{Limit: 2})
+	if err != nil {
+		t.Fatalf("PullHistory() error = %v", err)
+	}
+	if len(page.Entries) != 2 || !page.HasMore || page.NextCursor != 2 {
+		t.Fatalf("PullHistory() = %+v, want the first two entries and more to come", page)
+	}
+	page, err = s.PullHistory(ctx, desktop, PullHistoryQuery{Cursor: page.NextCursor, Limit: 2})
+	if err != nil {
+		t.Fatalf("PullHistory() second page error = %v", err)
+	}
+	if len(page.Entries) != 1 || page.HasMore || page.Entries[0].Command != "gcloud auth list" {
+		t.Errorf("PullHistory() second page = %+v, want the last entry", page)
+	}
+
+	own, err := s.PullHistory(ctx, desktop, PullHistoryQuery{DeviceOnly: true})
+	if err != nil {
+		t.Fatalf("PullHistory() device only error = %v", err)
+	}
+	if len(own.Entries) != 1 || own.Entries[0].DeviceID != desktop.DeviceID {
+		t.Errorf("PullHistory() device only = %+v, want the desktop's entry", own.Entries)
+	}
+}
+
+// memoryAPIKeyRepository scopes revocation to the organization like the
+// postgres query.
+type memoryAPIKeyRepository struct {
+	keys []domain.APIKey
+}
+
+func (m *memoryAPIKeyRepository) Create(ctx context.Context, key domain.APIKey) error {
+	m.keys = append(m.keys, key)
+	return nil
+}
+
+func (m *memoryAPIKeyRepository) GetByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	for _, key := range m.keys {
+		if key.Hash == hash {
+			return &key, nil
+		}
+	}
+	return nil, domain.ErrAPIKeyNotFound
+}
+
+func (m *memoryAPIKeyRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]domain.APIKey, error) {
+	var keys []domain.APIKey
+	for _, key := range m.keys {
+		if key.OrganizationID == organizationID {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (m *memoryAPIKeyRepository) Revoke(ctx context.Context, organizationID, id uuid.UUID) error {
+	for i, key := range m.keys {
+		if key.ID == id && key.OrganizationID == organizationID {
+			if key.RevokedAt == nil {
+				now := time.Now()
+				m.keys[i].RevokedAt = &now
+			}
+			return nil
+		}
+	}
+	return domain.ErrAPIKeyNotFound
+}
+
+func TestAPIKeys(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryAPIKeyRepository{}
+	s := NewService(nil, nil, repo, nil, nil, HistoryConfig{})
+	organizationID, userID := uuid.New(), uuid.New()
+
+	if _, err := s.IssueAPIKey(ctx, organizationID, userID, "  "); !errors.Is(err, domain.ErrInvalidAPIKeyName) {
+		t.Errorf("IssueAPIKey() without a name error = %v, want %v", err, domain.ErrInvalidAPIKeyName)
+	}
+
+	issued, err := s.IssueAPIKey(ctx, organizationID, userID, "deploy bot")
+	if err != nil {
+		t.Fatalf("IssueAPIKey() error = %v", err)
+	}
+	if !strings.HasPrefix(issued.Key, APIKeyPrefix) || !strings.HasPrefix(issued.Key, issued.APIKey.Prefix) {
+		t.Errorf("IssueAPIKey() key = %q, prefix = %q", issued.Key, issued.APIKey.Prefix)
+	}
+	if stored := repo.keys[0]; stored.Hash == issued.Key || strings.Contains(stored.Hash, issued.Key) {
+		t.Errorf("stored key = %+v, want only a hash of the key", stored)
+	}
+
+	got, err := s.ValidateAPIKey(ctx, issued.Key)
+	if err != nil {
+		t.Fatalf("ValidateAPIKey() error = %v", err)
+	}
+	if want := (ValidateAPIKeyResult{OrganizationID: organizationID, UserID: userID, KeyID: issued.APIKey.ID}); got != want {
+		t.Errorf("ValidateAPIKey() = %+v, want %+v", got, want)
+	}
+	if _, err := s.ValidateAPIKey(ctx, APIKeyPrefix+"unknown"); !errors.Is(err, domain.ErrAPIKeyNotFound) {
+		t.Errorf("ValidateAPIKey() of an unknown key error = %v, want %v", err, domain.ErrAPIKeyNotFound)
+	}
+
+	if err := s.RevokeAPIKey(ctx, uuid.New(), issued.APIKey.ID); !errors.Is(err, domain.ErrAPIKeyNotFound) {
+		t.Errorf("RevokeAPIKey() from another organization error = %v, want %v", err, domain.ErrAPIKeyNotFound)
+	}
+	if err := s.RevokeAPIKey(ctx, organizationID, issued.APIKey.ID); err != nil {
+		t.Fatalf("RevokeAPIKey() error = %v", err)
+	}
+	if _, err := s.ValidateAPIKey(ctx, issued.Key); !errors.Is(err, domain.ErrAPIKeyRevoked) {
+		t.Errorf("ValidateAPIKey() after revoking error = %v, want %v", err, domain.ErrAPIKeyRevoked)
+	}
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
+	"github.com/google/uuid"
+)
+
+type apiKeyRepository struct {
+	queries *Queries
+}
+
+func NewAPIKeyRepository(sqlDB *sql.DB) domain.APIKeyRepository {
+	return &apiKeyRepository{
+		queries: New(pgretry.Wrap(sqlDB)),
+	}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key domain.APIKey) error {
+	return r.queries.CreateAPIKey(ctx, CreateAPIKeyParams{
+		ID:             key.ID,
+		Name:           key.Name,
+		KeyPrefix:      key.Prefix,
+		KeyHash:        key.Hash,
+		OrganizationID: key.OrganizationID,
+		UserID:         key.UserID,
+		CreatedAt:      key.CreatedAt,
+	})
+}
+
+func (r *apiKeyRepository) GetByHash(ctx context.Context, hash string) (*domain.APIKey, error) {
+	dbKey, err := r.queries.GetAPIKeyByHash(ctx, hash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	key := r.mapToDomain(dbKey)
+	return &key, nil
+}
+
+func (r *apiKeyRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]domain.APIKey, error) {
+	dbKeys, err := r.queries.ListAPIKeysByOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]domain.APIKey, len(dbKeys))
+	for i, dbKey := range dbKeys {
+		keys[i] = r.mapToDomain(dbKey)
+	}
+	return keys, nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, organizationID, id uuid.UUID) error {
+	revoked, err := r.queries.RevokeAPIKey(ctx, RevokeAPIKeyParams{
+		ID:             id,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return err
+	}
+	if revoked == 0 {
+		return domain.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) mapToDomain(dbKey ApiKey) domain.APIKey {
+	key := domain.APIKey{
+		ID:             dbKey.ID,
+		Name:           dbKey.Name,
+		Prefix:         dbKey.KeyPrefix,
+		Hash:           dbKey.KeyHash,
+		OrganizationID: dbKey.OrganizationID,
+		UserID:         dbKey.UserID,
+		CreatedAt:      dbKey.CreatedAt,
+	}
+
+	if dbKey.RevokedAt.Valid {
+		key.RevokedAt = &dbKey.RevokedAt.Time
+	}
+
+	return key
+}
@@ -27,15 +27,36 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.authorizeDeviceCodeStmt, err = db.PrepareContext(ctx, authorizeDeviceCode); err != nil {
 		return nil, fmt.Errorf("error preparing query AuthorizeDeviceCode: %w", err)
 	}
+	if q.createAPIKeyStmt, err = db.PrepareContext(ctx, createAPIKey); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateAPIKey: %w", err)
+	}
 	if q.createDeviceCodeStmt, err = db.PrepareContext(ctx, createDeviceCode); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateDeviceCode: %w", err)
 	}
 	if q.createDeviceTokenStmt, err = db.PrepareContext(ctx, createDeviceToken); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateDeviceToken: %w", err)
 	}
+	if q.deleteAPIKeysByOrganizationStmt, err = db.PrepareContext(ctx, deleteAPIKeysByOrganization); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteAPIKeysByOrganization: %w", err)
+	}
+	if q.deleteDeviceCodesByOrganizationStmt, err = db.PrepareContext(ctx, deleteDeviceCodesByOrganization); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteDeviceCodesByOrganization: %w", err)
+	}
+	if q.deleteDeviceHistoryByOrganizationStmt, err = db.PrepareContext(ctx, deleteDeviceHistoryByOrganization); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteDeviceHistoryByOrganization: %w", err)
+	}
+	if q.deleteDeviceTokensByOrganizationStmt, err = db.PrepareContext(ctx, deleteDeviceTokensByOrganization); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteDeviceTokensByOrganization: %w", err)
+	}
 	if q.deleteExpiredDeviceCodesStmt, err = db.PrepareContext(ctx, deleteExpiredDeviceCodes); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteExpiredDeviceCodes: %w", err)
 	}
+	if q.evictDeviceHistoryStmt, err = db.PrepareContext(ctx, evictDeviceHistory); err != nil {
+		return nil, fmt.Errorf("error preparing query EvictDeviceHistory: %w", err)
+	}
+	if q.getAPIKeyByHashStmt, err = db.PrepareContext(ctx, getAPIKeyByHash); err != nil {
+		return nil, fmt.Errorf("error preparing query GetAPIKeyByHash: %w", err)
+	}
 	if q.getDeviceCodeByDeviceCodeStmt, err = db.PrepareContext(ctx, getDeviceCodeByDeviceCode); err != nil {
 		return nil, fmt.Errorf("error preparing query GetDeviceCodeByDeviceCode: %w", err)
 	}
@@ -48,9 +69,21 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getDeviceTokenByRefreshTokenStmt, err = db.PrepareContext(ctx, getDeviceTokenByRefreshToken); err != nil {
 		return nil, fmt.Errorf("error preparing query GetDeviceTokenByRefreshToken: %w", err)
 	}
+	if q.insertDeviceHistoryEntryStmt, err = db.PrepareContext(ctx, insertDeviceHistoryEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertDeviceHistoryEntry: %w", err)
+	}
+	if q.listAPIKeysByOrganizationStmt, err = db.PrepareContext(ctx, listAPIKeysByOrganization); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAPIKeysByOrganization: %w", err)
+	}
+	if q.listDeviceHistoryStmt, err = db.PrepareContext(ctx, listDeviceHistory); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDeviceHistory: %w", err)
+	}
 	if q.markDeviceCodeAsUsedStmt, err = db.PrepareContext(ctx, markDeviceCodeAsUsed); err != nil {
 		return nil, fmt.Errorf("error preparing query MarkDeviceCodeAsUsed: %w", err)
 	}
+	if q.revokeAPIKeyStmt, err = db.PrepareContext(ctx, revokeAPIKey); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeAPIKey: %w", err)
+	}
 	if q.revokeAllDeviceTokensForUserStmt, err = db.PrepareContext(ctx, revokeAllDeviceTokensForUser); err != nil {
 		return nil, fmt.Errorf("error preparing query RevokeAllDeviceTokensForUser: %w", err)
 	}
@@ -70,6 +103,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing authorizeDeviceCodeStmt: %w", cerr)
 		}
 	}
+	if q.createAPIKeyStmt != nil {
+		if cerr := q.createAPIKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createAPIKeyStmt: %w", cerr)
+		}
+	}
 	if q.createDeviceCodeStmt != nil {
 		if cerr := q.createDeviceCodeStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createDeviceCodeStmt: %w", cerr)
@@ -80,11 +118,41 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createDeviceTokenStmt: %w", cerr)
 		}
 	}
+	if q.deleteAPIKeysByOrganizationStmt != nil {
+		if cerr := q.deleteAPIKeysByOrganizationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteAPIKeysByOrganizationStmt: %w", cerr)
+		}
+	}
+	if q.deleteDeviceCodesByOrganizationStmt != nil {
+		if cerr := q.deleteDeviceCodesByOrganizationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteDeviceCodesByOrganizationStmt: %w", cerr)
+		}
+	}
+	if q.deleteDeviceHistoryByOrganizationStmt != nil {
+		if cerr := q.deleteDeviceHistoryByOrganizationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteDeviceHistoryByOrganizationStmt: %w", cerr)
+		}
+	}
+	if q.deleteDeviceTokensByOrganizationStmt != nil {
+		if cerr := q.deleteDeviceTokensByOrganizationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteDeviceTokensByOrganizationStmt: %w", cerr)
+		}
+	}
 	if q.deleteExpiredDeviceCodesStmt != nil {
 		if cerr := q.deleteExpiredDeviceCodesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteExpiredDeviceCodesStmt: %w", cerr)
 		}
 	}
+	if q.evictDeviceHistoryStmt != nil {
+		if cerr := q.evictDeviceHistoryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing evictDeviceHistoryStmt: %w", cerr)
+		}
+	}
+	if q.getAPIKeyByHashStmt != nil {
+		if cerr := q.getAPIKeyByHashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getAPIKeyByHashStmt: %w", cerr)
+		}
+	}
 	if q.getDeviceCodeByDeviceCodeStmt != nil {
 		if cerr := q.getDeviceCodeByDeviceCodeStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getDeviceCodeByDeviceCodeStmt: %w", cerr)
@@ -105,11 +173,31 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getDeviceTokenByRefreshTokenStmt: %w", cerr)
 		}
 	}
+	if q.insertDeviceHistoryEntryStmt != nil {
+		if cerr := q.insertDeviceHistoryEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertDeviceHistoryEntryStmt: %w", cerr)
+		}
+	}
+	if q.listAPIKeysByOrganizationStmt != nil {
+		if cerr := q.listAPIKeysByOrganizationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAPIKeysByOrganizationStmt: %w", cerr)
+		}
+	}
+	if q.listDeviceHistoryStmt != nil {
+		if cerr := q.listDeviceHistoryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDeviceHistoryStmt: %w", cerr)
+		}
+	}
 	if q.markDeviceCodeAsUsedStmt != nil {
 		if cerr := q.markDeviceCodeAsUsedStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing markDeviceCodeAsUsedStmt: %w", cerr)
 		}
 	}
+	if q.revokeAPIKeyStmt != nil {
+		if cerr := q.revokeAPIKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeAPIKeyStmt: %w", cerr)
+		}
+	}
 	if q.revokeAllDeviceTokensForUserStmt != nil {
 		if cerr := q.revokeAllDeviceTokensForUserStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing revokeAllDeviceTokensForUserStmt: %w", cerr)
@@ -162,37 +250,59 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                               DBTX
-	tx                               *sql.Tx
-	authorizeDeviceCodeStmt          *sql.Stmt
-	createDeviceCodeStmt             *sql.Stmt
-	createDeviceTokenStmt            *sql.Stmt
-	deleteExpiredDeviceCodesStmt     *sql.Stmt
-	getDeviceCodeByDeviceCodeStmt    *sql.Stmt
-	getDeviceCodeByUserCodeStmt      *sql.Stmt
-	getDeviceTokenByAccessTokenStmt  *sql.Stmt
-	getDeviceTokenByRefreshTokenStmt *sql.Stmt
-	markDeviceCodeAsUsedStmt         *sql.Stmt
-	revokeAllDeviceTokensForUserStmt *sql.Stmt
-	revokeDeviceTokenStmt            *sql.Stmt
-	updateDeviceTokensStmt           *sql.Stmt
+	db                                    DBTX
+	tx                                    *sql.Tx
+	authorizeDeviceCodeStmt               *sql.Stmt
+	createAPIKeyStmt                      *sql.Stmt
+	createDeviceCodeStmt                  *sql.Stmt
+	createDeviceTokenStmt                 *sql.Stmt
+	deleteAPIKeysByOrganizationStmt       *sql.Stmt
+	deleteDeviceCodesByOrganizationStmt   *sql.Stmt
+	deleteDeviceHistoryByOrganizationStmt *sql.Stmt
+	deleteDeviceTokensByOrganizationStmt  *sql.Stmt
+	deleteExpiredDeviceCodesStmt          *sql.Stmt
+	evictDeviceHistoryStmt                *sql.Stmt
+	getAPIKeyByHashStmt                   *sql.Stmt
+	getDeviceCodeByDeviceCodeStmt         *sql.Stmt
+	getDeviceCodeByUserCodeStmt           *sql.Stmt
+	getDeviceTokenByAccessTokenStmt       *sql.Stmt
+	getDeviceTokenByRefreshTokenStmt      *sql.Stmt
+	insertDeviceHistoryEntryStmt          *sql.Stmt
+	listAPIKeysByOrganizationStmt         *sql.Stmt
+	listDeviceHistoryStmt                 *sql.Stmt
+	markDeviceCodeAsUsedStmt              *sql.Stmt
+	revokeAPIKeyStmt                      *sql.Stmt
+	revokeAllDeviceTokensForUserStmt      *sql.Stmt
+	revokeDeviceTokenStmt                 *sql.Stmt
+	updateDeviceTokensStmt                *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                               tx,
-		tx:                               tx,
-		authorizeDeviceCodeStmt:          q.authorizeDeviceCodeStmt,
-		createDeviceCodeStmt:             q.createDeviceCodeStmt,
-		createDeviceTokenStmt:            q.createDeviceTokenStmt,
-		deleteExpiredDeviceCodesStmt:     q.deleteExpiredDeviceCodesStmt,
-		getDeviceCodeByDeviceCodeStmt:    q.getDeviceCodeByDeviceCodeStmt,
-		getDeviceCodeByUserCodeStmt:      q.getDeviceCodeByUserCodeStmt,
-		getDeviceTokenByAccessTokenStmt:  q.getDeviceTokenByAccessTokenStmt,
-		getDeviceTokenByRefreshTokenStmt: q.getDeviceTokenByRefreshTokenStmt,
-		markDeviceCodeAsUsedStmt:         q.markDeviceCodeAsUsedStmt,
-		revokeAllDeviceTokensForUserStmt: q.revokeAllDeviceTokensForUserStmt,
-		revokeDeviceTokenStmt:            q.revokeDeviceTokenStmt,
-		updateDeviceTokensStmt:           q.updateDeviceTokensStmt,
+		db:                                    tx,
+		tx:                                    tx,
+		authorizeDeviceCodeStmt:               q.authorizeDeviceCodeStmt,
+		createAPIKeyStmt:                      q.createAPIKeyStmt,
+		createDeviceCodeStmt:                  q.createDeviceCodeStmt,
+		createDeviceTokenStmt:                 q.createDeviceTokenStmt,
+		deleteAPIKeysByOrganizationStmt:       q.deleteAPIKeysByOrganizationStmt,
+		deleteDeviceCodesByOrganizationStmt:   q.deleteDeviceCodesByOrganizationStmt,
+		deleteDeviceHistoryByOrganizationStmt: q.deleteDeviceHistoryByOrganizationStmt,
+		deleteDeviceTokensByOrganizationStmt:  q.deleteDeviceTokensByOrganizationStmt,
+		deleteExpiredDeviceCodesStmt:          q.deleteExpiredDeviceCodesStmt,
+		evictDeviceHistoryStmt:                q.evictDeviceHistoryStmt,
+		getAPIKeyByHashStmt:                   q.getAPIKeyByHashStmt,
+		getDeviceCodeByDeviceCodeStmt:         q.getDeviceCodeByDeviceCodeStmt,
+		getDeviceCodeByUserCodeStmt:           q.getDeviceCodeByUserCodeStmt,
+		getDeviceTokenByAccessTokenStmt:       q.getDeviceTokenByAccessTokenStmt,
+		getDeviceTokenByRefreshTokenStmt:      q.getDeviceTokenByRefreshTokenStmt,
+		insertDeviceHistoryEntryStmt:          q.insertDeviceHistoryEntryStmt,
+		listAPIKeysByOrganizationStmt:         q.listAPIKeysByOrganizationStmt,
+		listDeviceHistoryStmt:                 q.listDeviceHistoryStmt,
+		markDeviceCodeAsUsedStmt:              q.markDeviceCodeAsUsedStmt,
+		revokeAPIKeyStmt:                      q.revokeAPIKeyStmt,
+		revokeAllDeviceTokensForUserStmt:      q.revokeAllDeviceTokensForUserStmt,
+		revokeDeviceTokenStmt:                 q.revokeDeviceTokenStmt,
+		updateDeviceTokensStmt:                q.updateDeviceTokensStmt,
 	}
 }
@@ -55,6 +55,18 @@ func (q *Queries) CreateDeviceCode(ctx context.Context, arg CreateDeviceCodePara
 	return err
 }
 
+const deleteDeviceCodesByOrganization = `-- name: DeleteDeviceCodesByOrganization :execrows
+DELETE FROM device_codes WHERE organization_id = $1
+`
+
+func (q *Queries) DeleteDeviceCodesByOrganization(ctx context.Context, organizationID uuid.NullUUID) (int64, error) {
+	result, err := q.exec(ctx, q.deleteDeviceCodesByOrganizationStmt, deleteDeviceCodesByOrganization, organizationID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteExpiredDeviceCodes = `-- name: DeleteExpiredDeviceCodes :exec
 DELETE FROM device_codes
 WHERE expires_at < NOW()
@@ -91,6 +103,8 @@ const getDeviceCodeByUserCode = `-- name: GetDeviceCodeByUserCode :one
 SELECT id, device_code, user_code, status, organization_id, user_id, expires_at, created_at
 FROM device_codes
 WHERE user_code = $1
+ORDER BY created_at DESC
+LIMIT 1
 `
 
 func (q *Queries) GetDeviceCodeByUserCode(ctx context.Context, userCode string) (DeviceCode, error) {
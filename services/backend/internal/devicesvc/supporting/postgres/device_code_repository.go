@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/73ai/infragpt/services/backend/internal/devicesvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type deviceCodeRepository struct {
@@ -16,12 +18,15 @@ type deviceCodeRepository struct {
 
 func NewDeviceCodeRepository(sqlDB *sql.DB) domain.DeviceCodeRepository {
 	return &deviceCodeRepository{
-		queries: New(sqlDB),
+		queries: New(pgretry.Wrap(sqlDB)),
 	}
 }
 
+// activeUserCodeIndex keeps user codes unique among pending and authorized codes.
+const activeUserCodeIndex = "idx_device_codes_active_user_code"
+
 func (r *deviceCodeRepository) Create(ctx context.Context, code domain.DeviceCode) error {
-	return r.queries.CreateDeviceCode(ctx, CreateDeviceCodeParams{
+	err := r.queries.CreateDeviceCode(ctx, CreateDeviceCodeParams{
 		ID:         code.ID,
 		DeviceCode: code.DeviceCode,
 		UserCode:   code.UserCode,
@@ -29,6 +34,14 @@ func (r *deviceCodeRepository) Create(ctx context.Context, code domain.DeviceCod
 		ExpiresAt:  code.ExpiresAt,
 		CreatedAt:  code.CreatedAt,
 	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" && pqErr.Constraint == activeUserCodeIndex {
+			return domain.ErrUserCodeTaken
+		}
+		return err
+	}
+
+	return nil
 }
 
 func (r *deviceCodeRepository) GetByUserCode(ctx context.Context, userCode string) (*domain.DeviceCode, error) {
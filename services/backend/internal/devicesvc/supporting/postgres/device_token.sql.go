@@ -43,6 +43,18 @@ func (q *Queries) CreateDeviceToken(ctx context.Context, arg CreateDeviceTokenPa
 	return err
 }
 
+const deleteDeviceTokensByOrganization = `-- name: DeleteDeviceTokensByOrganization :execrows
+DELETE FROM device_tokens WHERE organization_id = $1
+`
+
+func (q *Queries) DeleteDeviceTokensByOrganization(ctx context.Context, organizationID uuid.UUID) (int64, error) {
+	result, err := q.exec(ctx, q.deleteDeviceTokensByOrganizationStmt, deleteDeviceTokensByOrganization, organizationID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getDeviceTokenByAccessToken = `-- name: GetDeviceTokenByAccessToken :one
 SELECT id, access_token, refresh_token, organization_id, user_id, device_name, expires_at, created_at, revoked_at
 FROM device_tokens
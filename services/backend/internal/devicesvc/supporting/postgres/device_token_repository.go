@@ -6,6 +6,7 @@ import (
 	"errors"
 
 	"github.com/73ai/infragpt/services/backend/internal/devicesvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
 	"github.com/google/uuid"
 )
 
@@ -15,7 +16,7 @@ type deviceTokenRepository struct {
 
 func NewDeviceTokenRepository(sqlDB *sql.DB) domain.DeviceTokenRepository {
 	return &deviceTokenRepository{
-		queries: New(sqlDB),
+		queries: New(pgretry.Wrap(sqlDB)),
 	}
 }
 
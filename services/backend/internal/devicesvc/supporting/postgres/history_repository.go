@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
+	"github.com/google/uuid"
+)
+
+type historyRepository struct {
+	queries *Queries
+}
+
+func NewHistoryRepository(sqlDB *sql.DB) domain.HistoryRepository {
+	return &historyRepository{
+		queries: New(pgretry.Wrap(sqlDB)),
+	}
+}
+
+func (r *historyRepository) Append(ctx context.Context, entries []domain.HistoryEntry) (int, error) {
+	inserted := 0
+	for _, entry := range entries {
+		var exitCode sql.NullInt32
+		if entry.ExitCode != nil {
+			exitCode = sql.NullInt32{Int32: int32(*entry.ExitCode), Valid: true}
+		}
+
+		rows, err := r.queries.InsertDeviceHistoryEntry(ctx, InsertDeviceHistoryEntryParams{
+			EntryID:        entry.EntryID,
+			OrganizationID: entry.OrganizationID,
+			UserID:         entry.UserID,
+			DeviceID:       entry.DeviceID,
+			Command:        entry.Command,
+			ExitCode:       exitCode,
+			ConversationID: sql.NullString{String: entry.ConversationID, Valid: entry.ConversationID != ""},
+			ExecutedAt:     entry.ExecutedAt,
+		})
+		if err != nil {
+			return inserted, fmt.Errorf("failed to insert history entry %s: %w", entry.EntryID, err)
+		}
+		inserted += int(rows)
+	}
+	return inserted, nil
+}
+
+func (r *historyRepository) List(ctx context.Context, query domain.HistoryQuery) ([]domain.HistoryEntry, error) {
+	rows, err := r.queries.ListDeviceHistory(ctx, ListDeviceHistoryParams{
+		UserID:     query.UserID,
+		AfterSeq:   query.After,
+		DeviceID:   uuid.NullUUID{UUID: query.DeviceID, Valid: query.DeviceID != uuid.Nil},
+		MaxEntries: int32(query.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list history: %w", err)
+	}
+
+	entries := make([]domain.HistoryEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = domain.HistoryEntry{
+			EntryID:        row.EntryID,
+			OrganizationID: row.OrganizationID,
+			UserID:         row.UserID,
+			DeviceID:       row.DeviceID,
+			Command:        row.Command,
+			ConversationID: row.ConversationID.String,
+			ExecutedAt:     row.ExecutedAt,
+			Cursor:         row.Seq,
+		}
+		if row.ExitCode.Valid {
+			exitCode := int(row.ExitCode.Int32)
+			entries[i].ExitCode = &exitCode
+		}
+	}
+	return entries, nil
+}
+
+func (r *historyRepository) Evict(ctx context.Context, userID uuid.UUID, maxBytes int64) (int, error) {
+	rows, err := r.queries.EvictDeviceHistory(ctx, EvictDeviceHistoryParams{
+		UserID:   userID,
+		MaxBytes: maxBytes,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to evict history: %w", err)
+	}
+	return int(rows), nil
+}
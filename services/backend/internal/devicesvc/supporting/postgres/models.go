@@ -11,6 +11,17 @@ import (
 	"github.com/google/uuid"
 )
 
+type ApiKey struct {
+	ID             uuid.UUID    `json:"id"`
+	Name           string       `json:"name"`
+	KeyPrefix      string       `json:"key_prefix"`
+	KeyHash        string       `json:"key_hash"`
+	OrganizationID uuid.UUID    `json:"organization_id"`
+	UserID         uuid.UUID    `json:"user_id"`
+	CreatedAt      time.Time    `json:"created_at"`
+	RevokedAt      sql.NullTime `json:"revoked_at"`
+}
+
 type DeviceCode struct {
 	ID             uuid.UUID     `json:"id"`
 	DeviceCode     string        `json:"device_code"`
@@ -22,6 +33,19 @@ type DeviceCode struct {
 	CreatedAt      time.Time     `json:"created_at"`
 }
 
+type DeviceHistory struct {
+	Seq            int64          `json:"seq"`
+	EntryID        string         `json:"entry_id"`
+	OrganizationID uuid.UUID      `json:"organization_id"`
+	UserID         uuid.UUID      `json:"user_id"`
+	DeviceID       uuid.UUID      `json:"device_id"`
+	Command        string         `json:"command"`
+	ExitCode       sql.NullInt32  `json:"exit_code"`
+	ConversationID sql.NullString `json:"conversation_id"`
+	ExecutedAt     time.Time      `json:"executed_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
 type DeviceToken struct {
 	ID             uuid.UUID      `json:"id"`
 	AccessToken    string         `json:"access_token"`
@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
+	"github.com/google/uuid"
+)
+
+type organizationDataRepository struct {
+	db      *sql.DB
+	queries *Queries
+}
+
+func NewOrganizationDataRepository(sqlDB *sql.DB) domain.OrganizationDataRepository {
+	return &organizationDataRepository{
+		db:      sqlDB,
+		queries: New(pgretry.Wrap(sqlDB)),
+	}
+}
+
+func (r *organizationDataRepository) DeleteOrganizationData(ctx context.Context, organizationID uuid.UUID, dryRun bool) (domain.OrganizationData, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.OrganizationData{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := r.queries.WithTx(tx)
+
+	history, err := qtx.DeleteDeviceHistoryByOrganization(ctx, organizationID)
+	if err != nil {
+		return domain.OrganizationData{}, fmt.Errorf("failed to delete device history: %w", err)
+	}
+	tokens, err := qtx.DeleteDeviceTokensByOrganization(ctx, organizationID)
+	if err != nil {
+		return domain.OrganizationData{}, fmt.Errorf("failed to delete device tokens: %w", err)
+	}
+	apiKeys, err := qtx.DeleteAPIKeysByOrganization(ctx, organizationID)
+	if err != nil {
+		return domain.OrganizationData{}, fmt.Errorf("failed to delete API keys: %w", err)
+	}
+	codes, err := qtx.DeleteDeviceCodesByOrganization(ctx, uuid.NullUUID{UUID: organizationID, Valid: true})
+	if err != nil {
+		return domain.OrganizationData{}, fmt.Errorf("failed to delete device codes: %w", err)
+	}
+
+	data := domain.OrganizationData{
+		DeviceCodes:    int(codes),
+		DeviceTokens:   int(tokens),
+		HistoryEntries: int(history),
+		APIKeys:        int(apiKeys),
+	}
+	if dryRun {
+		return data, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return domain.OrganizationData{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return data, nil
+}
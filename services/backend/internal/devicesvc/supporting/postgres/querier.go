@@ -12,14 +12,25 @@ import (
 
 type Querier interface {
 	AuthorizeDeviceCode(ctx context.Context, arg AuthorizeDeviceCodeParams) error
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) error
 	CreateDeviceCode(ctx context.Context, arg CreateDeviceCodeParams) error
 	CreateDeviceToken(ctx context.Context, arg CreateDeviceTokenParams) error
+	DeleteAPIKeysByOrganization(ctx context.Context, organizationID uuid.UUID) (int64, error)
+	DeleteDeviceCodesByOrganization(ctx context.Context, organizationID uuid.NullUUID) (int64, error)
+	DeleteDeviceHistoryByOrganization(ctx context.Context, organizationID uuid.UUID) (int64, error)
+	DeleteDeviceTokensByOrganization(ctx context.Context, organizationID uuid.UUID) (int64, error)
 	DeleteExpiredDeviceCodes(ctx context.Context) error
+	EvictDeviceHistory(ctx context.Context, arg EvictDeviceHistoryParams) (int64, error)
+	GetAPIKeyByHash(ctx context.Context, keyHash string) (ApiKey, error)
 	GetDeviceCodeByDeviceCode(ctx context.Context, deviceCode string) (DeviceCode, error)
 	GetDeviceCodeByUserCode(ctx context.Context, userCode string) (DeviceCode, error)
 	GetDeviceTokenByAccessToken(ctx context.Context, accessToken string) (DeviceToken, error)
 	GetDeviceTokenByRefreshToken(ctx context.Context, refreshToken string) (DeviceToken, error)
+	InsertDeviceHistoryEntry(ctx context.Context, arg InsertDeviceHistoryEntryParams) (int64, error)
+	ListAPIKeysByOrganization(ctx context.Context, organizationID uuid.UUID) ([]ApiKey, error)
+	ListDeviceHistory(ctx context.Context, arg ListDeviceHistoryParams) ([]DeviceHistory, error)
 	MarkDeviceCodeAsUsed(ctx context.Context, deviceCode string) error
+	RevokeAPIKey(ctx context.Context, arg RevokeAPIKeyParams) (int64, error)
 	RevokeAllDeviceTokensForUser(ctx context.Context, userID uuid.UUID) error
 	RevokeDeviceToken(ctx context.Context, accessToken string) error
 	UpdateDeviceTokens(ctx context.Context, arg UpdateDeviceTokensParams) error
@@ -0,0 +1,22 @@
+-- name: CreateAPIKey :exec
+INSERT INTO api_keys (id, name, key_prefix, key_hash, organization_id, user_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetAPIKeyByHash :one
+SELECT id, name, key_prefix, key_hash, organization_id, user_id, created_at, revoked_at
+FROM api_keys
+WHERE key_hash = $1;
+
+-- name: ListAPIKeysByOrganization :many
+SELECT id, name, key_prefix, key_hash, organization_id, user_id, created_at, revoked_at
+FROM api_keys
+WHERE organization_id = $1
+ORDER BY created_at DESC;
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_keys
+SET revoked_at = COALESCE(revoked_at, NOW())
+WHERE id = $1 AND organization_id = $2;
+
+-- name: DeleteAPIKeysByOrganization :execrows
+DELETE FROM api_keys WHERE organization_id = $1;
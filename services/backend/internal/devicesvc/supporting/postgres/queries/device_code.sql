@@ -5,7 +5,9 @@ VALUES ($1, $2, $3, $4, $5, $6);
 -- name: GetDeviceCodeByUserCode :one
 SELECT id, device_code, user_code, status, organization_id, user_id, expires_at, created_at
 FROM device_codes
-WHERE user_code = $1;
+WHERE user_code = $1
+ORDER BY created_at DESC
+LIMIT 1;
 
 -- name: GetDeviceCodeByDeviceCode :one
 SELECT id, device_code, user_code, status, organization_id, user_id, expires_at, created_at
@@ -25,3 +27,6 @@ WHERE device_code = $1 AND status = 'authorized';
 -- name: DeleteExpiredDeviceCodes :exec
 DELETE FROM device_codes
 WHERE expires_at < NOW();
+
+-- name: DeleteDeviceCodesByOrganization :execrows
+DELETE FROM device_codes WHERE organization_id = $1;
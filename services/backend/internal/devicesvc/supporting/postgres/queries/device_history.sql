@@ -0,0 +1,28 @@
+-- name: InsertDeviceHistoryEntry :execrows
+INSERT INTO device_history (entry_id, organization_id, user_id, device_id, command, exit_code, conversation_id, executed_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+ON CONFLICT (user_id, entry_id) DO NOTHING;
+
+-- name: ListDeviceHistory :many
+SELECT seq, entry_id, organization_id, user_id, device_id, command, exit_code, conversation_id, executed_at, created_at
+FROM device_history
+WHERE user_id = sqlc.arg(user_id)
+  AND seq > sqlc.arg(after_seq)
+  AND (sqlc.narg(device_id)::uuid IS NULL OR device_id = sqlc.narg(device_id)::uuid)
+ORDER BY seq
+LIMIT sqlc.arg(max_entries);
+
+-- name: EvictDeviceHistory :execrows
+DELETE FROM device_history
+WHERE seq IN (
+    SELECT ranked.seq
+    FROM (
+        SELECT seq, SUM(octet_length(command)) OVER (ORDER BY executed_at DESC, seq DESC) AS total_bytes
+        FROM device_history
+        WHERE user_id = sqlc.arg(user_id)
+    ) ranked
+    WHERE ranked.total_bytes > sqlc.arg(max_bytes)::bigint
+);
+
+-- name: DeleteDeviceHistoryByOrganization :execrows
+DELETE FROM device_history WHERE organization_id = $1;
@@ -26,3 +26,6 @@ WHERE user_id = $1 AND revoked_at IS NULL;
 UPDATE device_tokens
 SET access_token = $2, refresh_token = $3, expires_at = $4
 WHERE refresh_token = $1 AND revoked_at IS NULL;
+
+-- name: DeleteDeviceTokensByOrganization :execrows
+DELETE FROM device_tokens WHERE organization_id = $1;
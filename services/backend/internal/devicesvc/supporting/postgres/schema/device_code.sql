@@ -1,7 +1,7 @@
 CREATE TABLE device_codes (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
     device_code TEXT UNIQUE NOT NULL,
-    user_code VARCHAR(10) UNIQUE NOT NULL,
+    user_code VARCHAR(10) NOT NULL,
     status VARCHAR(20) NOT NULL DEFAULT 'pending',
     organization_id UUID,
     user_id UUID,
@@ -9,6 +9,8 @@ CREATE TABLE device_codes (
     created_at TIMESTAMP NOT NULL DEFAULT NOW()
 );
 
-CREATE INDEX idx_device_codes_user_code ON device_codes (user_code);
+-- A user code is only unique while it can still be authorized or exchanged.
+CREATE UNIQUE INDEX idx_device_codes_active_user_code ON device_codes (user_code)
+    WHERE status IN ('pending', 'authorized');
 CREATE INDEX idx_device_codes_device_code ON device_codes (device_code);
 CREATE INDEX idx_device_codes_expires_at ON device_codes (expires_at);
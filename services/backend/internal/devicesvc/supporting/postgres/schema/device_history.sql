@@ -0,0 +1,17 @@
+CREATE TABLE device_history (
+    seq BIGSERIAL PRIMARY KEY,
+    entry_id VARCHAR(26) NOT NULL,
+    organization_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    device_id UUID NOT NULL,
+    command TEXT NOT NULL,
+    exit_code INTEGER,
+    conversation_id VARCHAR(64),
+    executed_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    UNIQUE (user_id, entry_id)
+);
+
+CREATE INDEX idx_device_history_user_seq ON device_history (user_id, seq);
+CREATE INDEX idx_device_history_user_device_seq ON device_history (user_id, device_id, seq);
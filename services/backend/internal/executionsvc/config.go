@@ -0,0 +1,60 @@
+package executionsvc
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/executionsvc/supporting/docker"
+	"github.com/73ai/infragpt/services/backend/internal/executionsvc/supporting/postgres"
+)
+
+const (
+	defaultMaxTimeout     = 60 * time.Second
+	defaultMaxAuditOutput = 4096
+)
+
+type Config struct {
+	// Image runs every command; execution is disabled when empty.
+	Image             string `mapstructure:"image"`
+	MaxTimeoutSeconds int    `mapstructure:"max_timeout_seconds"`
+	// MaxAuditOutputBytes caps how much of a command's output is kept in the audit log.
+	MaxAuditOutputBytes int `mapstructure:"max_audit_output_bytes"`
+	// AllowedCommands maps binaries to their allowed subcommands, replacing the defaults.
+	AllowedCommands map[string][]string `mapstructure:"allowed_commands"`
+	DockerBinary    string              `mapstructure:"docker_binary"`
+	Memory          string              `mapstructure:"memory"`
+	CPUs            string              `mapstructure:"cpus"`
+
+	Database     *sql.DB                    `mapstructure:"-"`
+	Integrations backend.IntegrationService `mapstructure:"-"`
+	// StepListener is told about commands run on behalf of a conversation.
+	StepListener backend.ConversationStepListener `mapstructure:"-"`
+}
+
+func (c Config) New() backend.ExecutionService {
+	maxTimeout := defaultMaxTimeout
+	if c.MaxTimeoutSeconds > 0 {
+		maxTimeout = time.Duration(c.MaxTimeoutSeconds) * time.Second
+	}
+
+	maxAuditOutput := defaultMaxAuditOutput
+	if c.MaxAuditOutputBytes > 0 {
+		maxAuditOutput = c.MaxAuditOutputBytes
+	}
+
+	return &service{
+		policy: newPolicy(c.AllowedCommands, maxTimeout),
+		runner: &docker.Runner{
+			Binary: c.DockerBinary,
+			Memory: c.Memory,
+			CPUs:   c.CPUs,
+		},
+		audit:          postgres.NewAuditRepository(c.Database),
+		integrations:   c.Integrations,
+		steps:          c.StepListener,
+		image:          c.Image,
+		maxAuditOutput: maxAuditOutput,
+		now:            time.Now,
+	}
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Decision string
+
+const (
+	DecisionAllowed Decision = "allowed"
+	DecisionDenied  Decision = "denied"
+)
+
+// AuditEntry records a command request, the policy decision and, for commands
+// that ran, the exit code and the start of their output.
+type AuditEntry struct {
+	ID              uuid.UUID
+	OrganizationID  uuid.UUID
+	IntegrationID   uuid.UUID
+	ConversationID  string
+	ToolCallID      string
+	Binary          string
+	Args            []string
+	Decision        Decision
+	Rule            string
+	Reason          string
+	ExitCode        *int
+	TimedOut        bool
+	Output          string
+	OutputTruncated bool
+	StartedAt       time.Time
+	FinishedAt      time.Time
+}
+
+type AuditRepository interface {
+	Record(ctx context.Context, entry AuditEntry) error
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "errors"
+
+var (
+	ErrExecutionDisabled       = errors.New("command execution is not configured")
+	ErrUnsupportedIntegration  = errors.New("integration credentials cannot be mounted for command execution")
+	ErrIntegrationNotAvailable = errors.New("integration is not active")
+)
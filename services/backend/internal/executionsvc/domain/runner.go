@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/73ai/infragpt/services/backend"
+)
+
+// RunSpec describes a single command run in a short-lived container.
+type RunSpec struct {
+	Image  string
+	Binary string
+	Args   []string
+	Env    map[string]string
+	// Files are mounted read-only under CredentialsDir, keyed by file name.
+	Files map[string][]byte
+}
+
+// CredentialsDir is where RunSpec.Files appear inside the container.
+const CredentialsDir = "/credentials"
+
+// Runner executes commands in isolation. Run returns the command's exit code;
+// it stops the command when ctx is done and returns ctx's error.
+type Runner interface {
+	Run(ctx context.Context, spec RunSpec, output func(backend.CommandOutput) error) (int, error)
+}
@@ -0,0 +1,214 @@
+package executionsvc
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+)
+
+const (
+	RuleBinaryNotAllowed     = "binary_not_allowed"
+	RuleSubcommandNotAllowed = "subcommand_not_allowed"
+	RuleShellMetacharacters  = "shell_metacharacters"
+	RuleFlagNotAllowed       = "flag_not_allowed"
+	RuleResourceNotAllowed   = "resource_not_allowed"
+	RuleTimeoutRequired      = "timeout_required"
+	RuleTimeoutTooLong       = "timeout_too_long"
+	// RuleGrantMissing and RuleWriteNotGranted deny commands on integrations
+	// the organization has not granted read or write access over.
+	RuleGrantMissing    = "grant_missing"
+	RuleWriteNotGranted = "write_not_granted"
+)
+
+// defaultAllowedCommands maps each binary to the read-only subcommands the
+// agent may run. A subcommand matches when the arguments start with its words.
+var defaultAllowedCommands = map[string][]string{
+	"kubectl": {
+		"get",
+		"describe",
+		"logs",
+		"top",
+		"explain",
+		"version",
+		"api-resources",
+		"api-versions",
+		"auth can-i",
+		"rollout status",
+		"rollout history",
+	},
+	"gcloud": {
+		"container clusters list",
+		"container clusters describe",
+		"container node-pools list",
+		"container node-pools describe",
+		"compute instances list",
+		"compute instances describe",
+		"compute zones list",
+		"projects describe",
+		"iam service-accounts list",
+	},
+}
+
+// deniedFlags would let a command escape the mounted credentials or act as
+// another identity.
+var deniedFlags = []string{
+	"--kubeconfig",
+	"--token",
+	"--as",
+	"--as-group",
+	"--as-uid",
+	"--server",
+	"--certificate-authority",
+	"--client-certificate",
+	"--client-key",
+	"--insecure-skip-tls-verify",
+	"--impersonate-service-account",
+	"--access-token-file",
+	"--account",
+	"--configuration",
+}
+
+// deniedShorthands are the single-letter forms of deniedFlags: -s for --server.
+// pflag accepts them with the value attached, as in -shttps://example.com.
+const deniedShorthands = "s"
+
+// booleanShorthands may take no value, so pflag reads the letter after them
+// in a group such as -As as another flag.
+const booleanShorthands = "AfhipqRw"
+
+// deniedResources are kubectl resources whose contents must not reach the
+// agent or the command audit log, whatever subcommands are allowed.
+var deniedResources = map[string][]string{
+	"kubectl": {"secret", "secrets"},
+}
+
+const shellMetacharacters = ";&|`$<>()\\\n\r\x00"
+
+type policyDecision struct {
+	Allowed bool
+	Rule    string
+	Reason  string
+}
+
+type policy struct {
+	commands   map[string][][]string
+	maxTimeout time.Duration
+}
+
+func newPolicy(allowed map[string][]string, maxTimeout time.Duration) policy {
+	if len(allowed) == 0 {
+		allowed = defaultAllowedCommands
+	}
+
+	commands := make(map[string][][]string, len(allowed))
+	for binary, subcommands := range allowed {
+		for _, subcommand := range subcommands {
+			commands[binary] = append(commands[binary], strings.Fields(subcommand))
+		}
+	}
+
+	return policy{commands: commands, maxTimeout: maxTimeout}
+}
+
+func (p policy) check(cmd backend.ExecuteCommandCommand) policyDecision {
+	subcommands, ok := p.commands[cmd.Binary]
+	if !ok {
+		return deny(RuleBinaryNotAllowed, fmt.Sprintf("%q is not an allowed binary", cmd.Binary))
+	}
+
+	for _, arg := range cmd.Args {
+		if strings.ContainsAny(arg, shellMetacharacters) {
+			return deny(RuleShellMetacharacters, fmt.Sprintf("argument %q contains shell metacharacters", arg))
+		}
+	}
+
+	if !matchesSubcommand(subcommands, cmd.Args) {
+		return deny(RuleSubcommandNotAllowed, fmt.Sprintf("%q is not an allowed %s subcommand", strings.Join(cmd.Args, " "), cmd.Binary))
+	}
+
+	for _, arg := range cmd.Args {
+		flag, _, _ := strings.Cut(arg, "=")
+		if slices.Contains(deniedFlags, flag) {
+			return deny(RuleFlagNotAllowed, fmt.Sprintf("flag %s is not allowed", flag))
+		}
+		if shorthand, ok := deniedShorthand(arg); ok {
+			return deny(RuleFlagNotAllowed, fmt.Sprintf("flag %s is not allowed", shorthand))
+		}
+	}
+
+	for _, arg := range cmd.Args {
+		if resource, ok := deniedResource(deniedResources[cmd.Binary], arg); ok {
+			return deny(RuleResourceNotAllowed, fmt.Sprintf("%s cannot be read", resource))
+		}
+	}
+
+	if cmd.Timeout <= 0 {
+		return deny(RuleTimeoutRequired, "a timeout is required")
+	}
+	if cmd.Timeout > p.maxTimeout {
+		return deny(RuleTimeoutTooLong, fmt.Sprintf("timeout %s exceeds the maximum of %s", cmd.Timeout, p.maxTimeout))
+	}
+
+	return policyDecision{Allowed: true}
+}
+
+// deniedShorthand reports whether arg is a group of single-letter flags that
+// sets one of deniedShorthands, with or without its value attached.
+func deniedShorthand(arg string) (string, bool) {
+	if !strings.HasPrefix(arg, "-") || strings.HasPrefix(arg, "--") {
+		return "", false
+	}
+	for _, letter := range arg[1:] {
+		if strings.ContainsRune(deniedShorthands, letter) {
+			return "-" + string(letter), true
+		}
+		if !strings.ContainsRune(booleanShorthands, letter) {
+			// The rest of the group is this flag's value.
+			return "", false
+		}
+	}
+	return "", false
+}
+
+// deniedResource reports whether arg names one of resources, alone or in
+// forms such as "secrets,pods", "secret/db-password" or "secrets.v1".
+func deniedResource(resources []string, arg string) (string, bool) {
+	if strings.HasPrefix(arg, "-") {
+		return "", false
+	}
+	for _, part := range strings.Split(arg, ",") {
+		kind, _, _ := strings.Cut(part, "/")
+		kind, _, _ = strings.Cut(kind, ".")
+		kind = strings.ToLower(kind)
+		if slices.Contains(resources, kind) {
+			return kind, true
+		}
+	}
+	return "", false
+}
+
+func matchesSubcommand(subcommands [][]string, args []string) bool {
+	return slices.ContainsFunc(subcommands, func(words []string) bool {
+		return len(args) >= len(words) && slices.Equal(args[:len(words)], words)
+	})
+}
+
+// readOnlyCommands are the default allowed commands, which only read.
+var readOnlyCommands = newPolicy(defaultAllowedCommands, 0).commands
+
+// requiredGrant returns the grant the command's integration needs: read_infra
+// for the default read-only commands, and write_infra for anything else
+// allowed through Config.AllowedCommands.
+func requiredGrant(cmd backend.ExecuteCommandCommand) backend.IntegrationGrant {
+	if matchesSubcommand(readOnlyCommands[cmd.Binary], cmd.Args) {
+		return backend.IntegrationGrantReadInfra
+	}
+	return backend.IntegrationGrantWriteInfra
+}
+
+func deny(rule, reason string) policyDecision {
+	return policyDecision{Rule: rule, Reason: reason}
+}
@@ -0,0 +1,236 @@
+package executionsvc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/executionsvc/domain"
+	"github.com/google/uuid"
+)
+
+const accessTokenFile = "access_token"
+
+// gcpScopes are the OAuth scopes minted for each grant. userinfo.email lets
+// GKE identify the service account for kubectl.
+var gcpScopes = map[backend.IntegrationGrant][]string{
+	backend.IntegrationGrantReadInfra: {
+		"https://www.googleapis.com/auth/cloud-platform.read-only",
+		"https://www.googleapis.com/auth/userinfo.email",
+	},
+	backend.IntegrationGrantWriteInfra: {
+		"https://www.googleapis.com/auth/cloud-platform",
+		"https://www.googleapis.com/auth/userinfo.email",
+	},
+}
+
+type service struct {
+	policy         policy
+	runner         domain.Runner
+	audit          domain.AuditRepository
+	integrations   backend.IntegrationService
+	steps          backend.ConversationStepListener
+	image          string
+	maxAuditOutput int
+	now            func() time.Time
+}
+
+var _ backend.ExecutionService = (*service)(nil)
+
+func (s *service) ExecuteCommand(ctx context.Context, cmd backend.ExecuteCommandCommand, output func(backend.CommandOutput) error) (backend.CommandResult, error) {
+	if s.image == "" {
+		return backend.CommandResult{}, domain.ErrExecutionDisabled
+	}
+
+	entry := domain.AuditEntry{
+		ID:             uuid.New(),
+		OrganizationID: cmd.OrganizationID,
+		IntegrationID:  cmd.IntegrationID,
+		ConversationID: cmd.ConversationID,
+		ToolCallID:     cmd.ToolCallID,
+		Binary:         cmd.Binary,
+		Args:           cmd.Args,
+		StartedAt:      s.now(),
+	}
+
+	decision := s.policy.check(cmd)
+	if !decision.Allowed {
+		return s.deny(ctx, entry, decision), nil
+	}
+
+	if cmd.IntegrationID != uuid.Nil {
+		decision, err := s.checkGrant(ctx, cmd)
+		if err != nil {
+			return backend.CommandResult{}, err
+		}
+		if !decision.Allowed {
+			return s.deny(ctx, entry, decision), nil
+		}
+	}
+
+	spec, err := s.runSpec(ctx, cmd)
+	if err != nil {
+		return backend.CommandResult{}, err
+	}
+
+	entry.Decision = domain.DecisionAllowed
+	captured := &truncatingBuffer{limit: s.maxAuditOutput}
+
+	runCtx, cancel := context.WithTimeout(ctx, cmd.Timeout)
+	defer cancel()
+
+	exitCode, runErr := s.runner.Run(runCtx, spec, func(out backend.CommandOutput) error {
+		captured.append(out.Data)
+		return output(out)
+	})
+
+	entry.FinishedAt = s.now()
+	entry.Output = captured.String()
+	entry.OutputTruncated = captured.truncated
+
+	result := backend.CommandResult{Allowed: true, ExitCode: exitCode}
+	if runErr != nil {
+		if !errors.Is(runErr, context.DeadlineExceeded) || ctx.Err() != nil {
+			entry.Reason = runErr.Error()
+			s.record(ctx, entry)
+			return backend.CommandResult{}, fmt.Errorf("failed to run command: %w", runErr)
+		}
+		result.TimedOut = true
+		entry.TimedOut = true
+	} else {
+		entry.ExitCode = &exitCode
+	}
+
+	s.record(ctx, entry)
+	return result, nil
+}
+
+func (s *service) deny(ctx context.Context, entry domain.AuditEntry, decision policyDecision) backend.CommandResult {
+	entry.Decision = domain.DecisionDenied
+	entry.Rule = decision.Rule
+	entry.Reason = decision.Reason
+	entry.FinishedAt = entry.StartedAt
+	s.record(ctx, entry)
+
+	return backend.CommandResult{DeniedRule: decision.Rule, Reason: decision.Reason}
+}
+
+// checkGrant asks the integration service whether the organization granted
+// the access the command needs over its integration.
+func (s *service) checkGrant(ctx context.Context, cmd backend.ExecuteCommandCommand) (policyDecision, error) {
+	grant := requiredGrant(cmd)
+	err := s.integrations.CheckGrant(ctx, backend.CheckGrantQuery{
+		IntegrationID:  cmd.IntegrationID,
+		OrganizationID: cmd.OrganizationID,
+		Grant:          grant,
+	})
+	switch {
+	case errors.Is(err, backend.ErrGrantMissing) && grant == backend.IntegrationGrantWriteInfra:
+		return deny(RuleWriteNotGranted, "this integration is read-only, so commands that change infrastructure are not allowed"), nil
+	case errors.Is(err, backend.ErrGrantMissing):
+		return deny(RuleGrantMissing, fmt.Sprintf("%s is not granted over this integration", grant)), nil
+	case err != nil:
+		return policyDecision{}, fmt.Errorf("failed to check integration grant: %w", err)
+	}
+	return policyDecision{Allowed: true}, nil
+}
+
+func (s *service) runSpec(ctx context.Context, cmd backend.ExecuteCommandCommand) (domain.RunSpec, error) {
+	spec := domain.RunSpec{
+		Image:  s.image,
+		Binary: cmd.Binary,
+		Args:   cmd.Args,
+		Env:    map[string]string{},
+		Files:  map[string][]byte{},
+	}
+	if cmd.IntegrationID == uuid.Nil {
+		return spec, nil
+	}
+
+	integration, err := s.integrations.Integration(ctx, backend.IntegrationQuery{
+		IntegrationID:  cmd.IntegrationID,
+		OrganizationID: cmd.OrganizationID,
+	})
+	if err != nil {
+		return domain.RunSpec{}, fmt.Errorf("failed to get integration: %w", err)
+	}
+	if integration.Status != backend.IntegrationStatusActive {
+		return domain.RunSpec{}, domain.ErrIntegrationNotAvailable
+	}
+	if integration.ConnectorType != backend.ConnectorTypeGCP {
+		return domain.RunSpec{}, domain.ErrUnsupportedIntegration
+	}
+
+	ctx = backend.WithCredentialAccessor(ctx, backend.CredentialAccessor{
+		Type:   backend.CredentialAccessorService,
+		ID:     "executionsvc",
+		Reason: "command_execution",
+	})
+	credentials, err := s.integrations.MintCredentials(ctx, backend.MintCredentialsCommand{
+		IntegrationID:  cmd.IntegrationID,
+		OrganizationID: cmd.OrganizationID,
+		ConversationID: cmd.ConversationID,
+		ToolCallID:     cmd.ToolCallID,
+		Scope:          backend.CredentialScope{OAuthScopes: gcpScopes[requiredGrant(cmd)]},
+		MinValidity:    cmd.Timeout,
+	})
+	if errors.Is(err, backend.ErrCredentialMintingUnsupported) {
+		return domain.RunSpec{}, domain.ErrUnsupportedIntegration
+	}
+	if err != nil {
+		return domain.RunSpec{}, fmt.Errorf("failed to mint integration credentials: %w", err)
+	}
+
+	accessToken := credentials.Data["access_token"]
+	if accessToken == "" {
+		return domain.RunSpec{}, domain.ErrUnsupportedIntegration
+	}
+
+	spec.Files[accessTokenFile] = []byte(accessToken)
+	spec.Env["CLOUDSDK_AUTH_ACCESS_TOKEN_FILE"] = domain.CredentialsDir + "/" + accessTokenFile
+	spec.Env["CLOUDSDK_CORE_PROJECT"] = credentials.Data["project_id"]
+	if name := integration.Metadata["gke_cluster_name"]; name != "" {
+		spec.Env["GKE_CLUSTER_NAME"] = name
+		location := integration.Metadata["gke_cluster_zone"]
+		if location == "" {
+			location = integration.Metadata["gke_cluster_region"]
+		}
+		spec.Env["GKE_CLUSTER_LOCATION"] = location
+	}
+
+	return spec, nil
+}
+
+func (s *service) record(ctx context.Context, entry domain.AuditEntry) {
+	if err := s.audit.Record(context.WithoutCancel(ctx), entry); err != nil {
+		slog.Error("failed to record command audit entry",
+			"organization_id", entry.OrganizationID,
+			"binary", entry.Binary,
+			"decision", entry.Decision,
+			"error", err)
+	}
+	s.notifyStep(ctx, entry)
+}
+
+// truncatingBuffer keeps the first limit bytes appended to it.
+type truncatingBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *truncatingBuffer) append(p []byte) {
+	if remaining := b.limit - b.buf.Len(); len(p) > remaining {
+		p = p[:max(remaining, 0)]
+		b.truncated = true
+	}
+	b.buf.Write(p)
+}
+
+func (b *truncatingBuffer) String() string {
+	return b.buf.String()
+}
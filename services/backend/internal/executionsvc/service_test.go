@@ -0,0 +1,274 @@
+package executionsvc
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/executionsvc/domain"
+	"github.com/google/uuid"
+)
+
+type memoryAuditRepository struct {
+	entries []domain.AuditEntry
+}
+
+func (m *memoryAuditRepository) Record(ctx context.Context, entry domain.AuditEntry) error {
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+type fakeRunner struct {
+	spec     domain.RunSpec
+	output   []backend.CommandOutput
+	exitCode int
+	block    bool
+}
+
+func (f *fakeRunner) Run(ctx context.Context, spec domain.RunSpec, output func(backend.CommandOutput) error) (int, error) {
+	f.spec = spec
+	for _, out := range f.output {
+		if err := output(out); err != nil {
+			return -1, err
+		}
+	}
+	if f.block {
+		<-ctx.Done()
+		return -1, ctx.Err()
+	}
+	return f.exitCode, nil
+}
+
+type fakeIntegrations struct {
+	backend.IntegrationService
+	integration backend.Integration
+	minted      backend.MintedCredentials
+	mintCmd     backend.MintCredentialsCommand
+}
+
+func (f *fakeIntegrations) Integration(ctx context.Context, query backend.IntegrationQuery) (backend.Integration, error) {
+	return f.integration, nil
+}
+
+func (f *fakeIntegrations) CheckGrant(ctx context.Context, query backend.CheckGrantQuery) error {
+	if !f.integration.Granted(query.Grant) {
+		return backend.ErrGrantMissing
+	}
+	return nil
+}
+
+func (f *fakeIntegrations) MintCredentials(ctx context.Context, cmd backend.MintCredentialsCommand) (backend.MintedCredentials, error) {
+	f.mintCmd = cmd
+	return f.minted, nil
+}
+
+func TestPolicy(t *testing.T) {
+	p := newPolicy(nil, time.Minute)
+
+	tests := []struct {
+		name string
+		cmd  backend.ExecuteCommandCommand
+		rule string
+	}{
+		{"allowed", backend.ExecuteCommandCommand{Binary: "kubectl", Args: []string{"get", "pods", "-n", "default"}, Timeout: 10 * time.Second}, ""},
+		{"multi-word subcommand", backend.ExecuteCommandCommand{Binary: "gcloud", Args: []string{"container", "clusters", "list", "--format=json"}, Timeout: 10 * time.Second}, ""},
+		{"jsonpath braces", backend.ExecuteCommandCommand{Binary: "kubectl", Args: []string{"get", "pods", "-o", "jsonpath={.items[*].metadata.name}"}, Timeout: 10 * time.Second}, ""},
+		{"unknown binary", backend.ExecuteCommandCommand{Binary: "bash", Args: []string{"-c", "id"}, Timeout: 10 * time.Second}, RuleBinaryNotAllowed},
+		{"mutating subcommand", backend.ExecuteCommandCommand{Binary: "kubectl", Args: []string{"delete", "pod", "web"}, Timeout: 10 * time.Second}, RuleSubcommandNotAllowed},
+		{"partial subcommand", backend.ExecuteCommandCommand{Binary: "kubectl", Args: []string{"rollout", "restart", "deploy/web"}, Timeout: 10 * time.Second}, RuleSubcommandNotAllowed},
+		{"metacharacters", backend.ExecuteCommandCommand{Binary: "kubectl", Args: []string{"get", "pods;", "rm", "-rf"}, Timeout: 10 * time.Second}, RuleShellMetacharacters},
+		{"impersonation", backend.ExecuteCommandCommand{Binary: "kubectl", Args: []string{"get", "secrets", "--as=system:admin"}, Timeout: 10 * time.Second}, RuleFlagNotAllowed},
+		{"server shorthand", backend.ExecuteCommandCommand{Binary: "kubectl", Args: []string{"get", "pods", "-s", "https://evil.example.com"}, Timeout: 10 * time.Second}, RuleFlagNotAllowed},
+		{"server shorthand with attached value", backend.ExecuteCommandCommand{Binary: "kubectl", Args: []string{"get", "pods", "-shttps://evil.example.com"}, Timeout: 10 * time.Second}, RuleFlagNotAllowed},
+		{"server shorthand in a group", backend.ExecuteCommandCommand{Binary: "kubectl", Args: []string{"get", "pods", "-Ashttps://evil.example.com"}, Timeout: 10 * time.Second}, RuleFlagNotAllowed},
+		{"shorthand value containing s", backend.ExecuteCommandCommand{Binary: "kubectl", Args: []string{"get", "pods", "-lapp=sidecars"}, Timeout: 10 * time.Second}, ""},
+		{"secrets", backend.ExecuteCommandCommand{Binary: "kubectl", Args: []string{"get", "secrets", "-o", "yaml"}, Timeout: 10 * time.Second}, RuleResourceNotAllowed},
+		{"named secret", backend.ExecuteCommandCommand{Binary: "kubectl", Args: []string{"describe", "secret/db-password"}, Timeout: 10 * time.Second}, RuleResourceNotAllowed},
+		{"secrets among resources", backend.ExecuteCommandCommand{Binary: "kubectl", Args: []string{"get", "pods,Secrets.v1", "-A"}, Timeout: 10 * time.Second}, RuleResourceNotAllowed},
+		{"missing timeout", backend.ExecuteCommandCommand{Binary: "kubectl", Args: []string{"get", "pods"}}, RuleTimeoutRequired},
+		{"timeout too long", backend.ExecuteCommandCommand{Binary: "kubectl", Args: []string{"get", "pods"}, Timeout: time.Hour}, RuleTimeoutTooLong},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision := p.check(tt.cmd)
+			if decision.Allowed != (tt.rule == "") || decision.Rule != tt.rule {
+				t.Errorf("check() = %+v, want rule %q", decision, tt.rule)
+			}
+		})
+	}
+}
+
+func TestExecuteCommand(t *testing.T) {
+	ctx := context.Background()
+	org := uuid.New()
+
+	newService := func(runner domain.Runner, integrations backend.IntegrationService) (*service, *memoryAuditRepository) {
+		audit := &memoryAuditRepository{}
+		return &service{
+			policy:         newPolicy(nil, time.Minute),
+			runner:         runner,
+			audit:          audit,
+			integrations:   integrations,
+			image:          "infragpt/tools",
+			maxAuditOutput: 8,
+			now:            time.Now,
+		}, audit
+	}
+
+	t.Run("denied commands return the rule and are audited", func(t *testing.T) {
+		runner := &fakeRunner{}
+		svc, audit := newService(runner, nil)
+
+		result, err := svc.ExecuteCommand(ctx, backend.ExecuteCommandCommand{
+			OrganizationID: org,
+			Binary:         "kubectl",
+			Args:           []string{"delete", "pod", "web"},
+			Timeout:        time.Second,
+		}, func(backend.CommandOutput) error { return nil })
+		if err != nil {
+			t.Fatalf("ExecuteCommand() error = %v", err)
+		}
+		if result.Allowed || result.DeniedRule != RuleSubcommandNotAllowed {
+			t.Errorf("result = %+v, want denied by %s", result, RuleSubcommandNotAllowed)
+		}
+		if runner.spec.Binary != "" {
+			t.Error("denied command was run")
+		}
+		if len(audit.entries) != 1 || audit.entries[0].Decision != domain.DecisionDenied || audit.entries[0].Rule != RuleSubcommandNotAllowed {
+			t.Errorf("audit entries = %+v, want one denial", audit.entries)
+		}
+	})
+
+	t.Run("allowed commands stream output and audit it truncated", func(t *testing.T) {
+		runner := &fakeRunner{
+			output: []backend.CommandOutput{
+				{Stream: backend.OutputStreamStdout, Data: []byte("NAME   READY\n")},
+				{Stream: backend.OutputStreamStderr, Data: []byte("warning\n")},
+			},
+			exitCode: 1,
+		}
+		svc, audit := newService(runner, nil)
+
+		var streamed []backend.CommandOutput
+		result, err := svc.ExecuteCommand(ctx, backend.ExecuteCommandCommand{
+			OrganizationID: org,
+			Binary:         "kubectl",
+			Args:           []string{"get", "pods"},
+			Timeout:        time.Second,
+		}, func(out backend.CommandOutput) error {
+			streamed = append(streamed, out)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("ExecuteCommand() error = %v", err)
+		}
+		if !result.Allowed || result.ExitCode != 1 {
+			t.Errorf("result = %+v, want allowed with exit code 1", result)
+		}
+		if len(streamed) != 2 || streamed[1].Stream != backend.OutputStreamStderr {
+			t.Errorf("streamed = %+v, want stdout then stderr", streamed)
+		}
+
+		entry := audit.entries[0]
+		if entry.Decision != domain.DecisionAllowed || entry.ExitCode == nil || *entry.ExitCode != 1 {
+			t.Errorf("audit entry = %+v, want allowed with exit code 1", entry)
+		}
+		if entry.Output != "NAME   R" || !entry.OutputTruncated {
+			t.Errorf("audit output = %q (truncated %v), want first 8 bytes", entry.Output, entry.OutputTruncated)
+		}
+	})
+
+	t.Run("timeouts are reported in the result", func(t *testing.T) {
+		svc, audit := newService(&fakeRunner{block: true}, nil)
+
+		result, err := svc.ExecuteCommand(ctx, backend.ExecuteCommandCommand{
+			OrganizationID: org,
+			Binary:         "kubectl",
+			Args:           []string{"logs", "web"},
+			Timeout:        10 * time.Millisecond,
+		}, func(backend.CommandOutput) error { return nil })
+		if err != nil {
+			t.Fatalf("ExecuteCommand() error = %v", err)
+		}
+		if !result.TimedOut || !audit.entries[0].TimedOut {
+			t.Errorf("result = %+v, want timed out", result)
+		}
+	})
+
+	t.Run("minted gcp credentials are mounted", func(t *testing.T) {
+		runner := &fakeRunner{}
+		integrations := &fakeIntegrations{
+			integration: backend.Integration{
+				ConnectorType: backend.ConnectorTypeGCP,
+				Status:        backend.IntegrationStatusActive,
+				Metadata:      map[string]string{"gke_cluster_name": "prod", "gke_cluster_region": "us-central1"},
+				Grants:        []backend.IntegrationGrant{backend.IntegrationGrantReadInfra},
+			},
+			minted: backend.MintedCredentials{
+				Data: map[string]string{"access_token": "ya29.minted", "project_id": "acme"},
+			},
+		}
+		svc, _ := newService(runner, integrations)
+
+		_, err := svc.ExecuteCommand(ctx, backend.ExecuteCommandCommand{
+			OrganizationID: org,
+			IntegrationID:  uuid.New(),
+			ConversationID: "conv-1",
+			ToolCallID:     "call-1",
+			Binary:         "gcloud",
+			Args:           []string{"container", "clusters", "list"},
+			Timeout:        time.Second,
+		}, func(backend.CommandOutput) error { return nil })
+		if err != nil {
+			t.Fatalf("ExecuteCommand() error = %v", err)
+		}
+
+		if string(runner.spec.Files[accessTokenFile]) != "ya29.minted" {
+			t.Error("minted access token not mounted")
+		}
+		if got := integrations.mintCmd; got.ConversationID != "conv-1" || got.ToolCallID != "call-1" || got.MinValidity != time.Second {
+			t.Errorf("mint command = %+v, want conversation, tool call and timeout", got)
+		}
+		if !slices.Equal(integrations.mintCmd.Scope.OAuthScopes, gcpScopes[backend.IntegrationGrantReadInfra]) {
+			t.Errorf("scopes = %v, want read-only scopes", integrations.mintCmd.Scope.OAuthScopes)
+		}
+		if runner.spec.Env["CLOUDSDK_CORE_PROJECT"] != "acme" || runner.spec.Env["GKE_CLUSTER_LOCATION"] != "us-central1" {
+			t.Errorf("env = %v, want project and cluster location", runner.spec.Env)
+		}
+	})
+
+	t.Run("commands beyond the read-only defaults need write access", func(t *testing.T) {
+		runner := &fakeRunner{}
+		svc, audit := newService(runner, &fakeIntegrations{
+			integration: backend.Integration{
+				ConnectorType: backend.ConnectorTypeGCP,
+				Status:        backend.IntegrationStatusActive,
+				Grants:        []backend.IntegrationGrant{backend.IntegrationGrantReadInfra},
+			},
+		})
+		svc.policy = newPolicy(map[string][]string{"kubectl": {"get", "rollout restart"}}, time.Minute)
+
+		result, err := svc.ExecuteCommand(ctx, backend.ExecuteCommandCommand{
+			OrganizationID: org,
+			IntegrationID:  uuid.New(),
+			Binary:         "kubectl",
+			Args:           []string{"rollout", "restart", "deploy/web"},
+			Timeout:        time.Second,
+		}, func(backend.CommandOutput) error { return nil })
+		if err != nil {
+			t.Fatalf("ExecuteCommand() error = %v", err)
+		}
+		if result.Allowed || result.DeniedRule != RuleWriteNotGranted {
+			t.Errorf("result = %+v, want denied by %s", result, RuleWriteNotGranted)
+		}
+		if runner.spec.Binary != "" {
+			t.Error("command was run without write access")
+		}
+		if len(audit.entries) != 1 || stepOutcome(audit.entries[0]) != backend.ConversationStepReadOnly {
+			t.Errorf("audit entries = %+v, want one read-only denial", audit.entries)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package executionsvc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/executionsvc/domain"
+	"github.com/google/uuid"
+)
+
+// StepNotifier fans conversation steps out to listeners added after the
+// execution service is built, like integrationsvc.StatusNotifier.
+type StepNotifier struct {
+	mu        sync.RWMutex
+	listeners []backend.ConversationStepListener
+}
+
+func (n *StepNotifier) Listen(listener backend.ConversationStepListener) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.listeners = append(n.listeners, listener)
+}
+
+func (n *StepNotifier) ConversationStepTaken(ctx context.Context, step backend.ConversationStep) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	for _, listener := range n.listeners {
+		listener.ConversationStepTaken(ctx, step)
+	}
+}
+
+// notifyStep reports commands run on behalf of a conversation as one of its
+// steps.
+func (s *service) notifyStep(ctx context.Context, entry domain.AuditEntry) {
+	if s.steps == nil {
+		return
+	}
+	conversationID, err := uuid.Parse(entry.ConversationID)
+	if err != nil {
+		return
+	}
+
+	result := entry.Output
+	if result == "" {
+		result = entry.Reason
+	}
+	s.steps.ConversationStepTaken(context.WithoutCancel(ctx), backend.ConversationStep{
+		ID:              entry.ID,
+		ConversationID:  conversationID,
+		Tool:            entry.Binary,
+		Arguments:       entry.Args,
+		Duration:        entry.FinishedAt.Sub(entry.StartedAt),
+		Outcome:         stepOutcome(entry),
+		Result:          result,
+		ResultTruncated: entry.OutputTruncated,
+		StartedAt:       entry.StartedAt,
+	})
+}
+
+func stepOutcome(entry domain.AuditEntry) backend.ConversationStepOutcome {
+	switch {
+	case entry.Decision == domain.DecisionDenied && entry.Rule == RuleWriteNotGranted:
+		return backend.ConversationStepReadOnly
+	case entry.Decision == domain.DecisionDenied:
+		return backend.ConversationStepDenied
+	case entry.TimedOut:
+		return backend.ConversationStepTimedOut
+	case entry.ExitCode != nil && *entry.ExitCode == 0:
+		return backend.ConversationStepSucceeded
+	default:
+		return backend.ConversationStepFailed
+	}
+}
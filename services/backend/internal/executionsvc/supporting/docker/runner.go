@@ -0,0 +1,167 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/executionsvc/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultMemory = "256m"
+	defaultCPUs   = "0.5"
+	pidsLimit     = "128"
+)
+
+type Runner struct {
+	// Binary is the docker CLI, resolved from PATH when empty.
+	Binary string
+	Memory string
+	CPUs   string
+}
+
+var _ domain.Runner = (*Runner)(nil)
+
+func (r *Runner) Run(ctx context.Context, spec domain.RunSpec, output func(backend.CommandOutput) error) (int, error) {
+	credentialsDir, err := os.MkdirTemp("", "infragpt-exec-")
+	if err != nil {
+		return -1, fmt.Errorf("failed to create credentials directory: %w", err)
+	}
+	defer os.RemoveAll(credentialsDir)
+
+	// The container runs as nobody, which must be able to read the mount.
+	if err := os.Chmod(credentialsDir, 0o755); err != nil {
+		return -1, fmt.Errorf("failed to set credentials directory permissions: %w", err)
+	}
+
+	for name, data := range spec.Files {
+		if err := os.WriteFile(filepath.Join(credentialsDir, filepath.Base(name)), data, 0o444); err != nil {
+			return -1, fmt.Errorf("failed to write credentials file: %w", err)
+		}
+	}
+
+	name := "infragpt-exec-" + uuid.NewString()
+	cmd := exec.Command(r.binary(), r.runArgs(name, credentialsDir, spec)...)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return -1, fmt.Errorf("failed to open stdout: %w", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return -1, fmt.Errorf("failed to open stderr: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return -1, fmt.Errorf("failed to start container: %w", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			// Killing the docker client does not stop the container.
+			if err := exec.Command(r.binary(), "rm", "-f", name).Run(); err != nil {
+				slog.Error("failed to remove command container", "container", name, "error", err)
+			}
+		case <-done:
+		}
+	}()
+
+	var (
+		mu        sync.Mutex
+		outputErr error
+		wg        sync.WaitGroup
+	)
+	forward := func(stream backend.OutputStream, reader io.Reader) {
+		defer wg.Done()
+		buf := make([]byte, 4096)
+		for {
+			n, err := reader.Read(buf)
+			if n > 0 {
+				mu.Lock()
+				if outputErr == nil {
+					outputErr = output(backend.CommandOutput{Stream: stream, Data: append([]byte(nil), buf[:n]...)})
+				}
+				mu.Unlock()
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+	wg.Add(2)
+	go forward(backend.OutputStreamStdout, stdout)
+	go forward(backend.OutputStreamStderr, stderr)
+	wg.Wait()
+
+	waitErr := cmd.Wait()
+	close(done)
+
+	if ctx.Err() != nil {
+		return -1, ctx.Err()
+	}
+	if outputErr != nil {
+		return -1, fmt.Errorf("failed to stream output: %w", outputErr)
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(waitErr, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if waitErr != nil {
+		return -1, fmt.Errorf("failed to run container: %w", waitErr)
+	}
+	return 0, nil
+}
+
+func (r *Runner) runArgs(name, credentialsDir string, spec domain.RunSpec) []string {
+	args := []string{
+		"run", "--rm",
+		"--name", name,
+		"--read-only",
+		"--cap-drop=ALL",
+		"--security-opt", "no-new-privileges",
+		"--user", "65534:65534",
+		"--memory", valueOr(r.Memory, defaultMemory),
+		"--cpus", valueOr(r.CPUs, defaultCPUs),
+		"--pids-limit", pidsLimit,
+		"--tmpfs", "/tmp:rw,size=64m",
+		"--env", "HOME=/tmp",
+		"--volume", credentialsDir + ":" + domain.CredentialsDir + ":ro",
+		"--entrypoint", spec.Binary,
+	}
+
+	keys := make([]string, 0, len(spec.Env))
+	for key := range spec.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		args = append(args, "--env", key+"="+spec.Env[key])
+	}
+
+	args = append(args, spec.Image)
+	return append(args, spec.Args...)
+}
+
+func (r *Runner) binary() string {
+	return valueOr(r.Binary, "docker")
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/73ai/infragpt/services/backend/internal/executionsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
+	"github.com/google/uuid"
+)
+
+type auditRepository struct {
+	queries *Queries
+}
+
+func NewAuditRepository(sqlDB *sql.DB) domain.AuditRepository {
+	return &auditRepository{
+		queries: New(pgretry.Wrap(sqlDB)),
+	}
+}
+
+func (r *auditRepository) Record(ctx context.Context, entry domain.AuditEntry) error {
+	params := StoreCommandAuditParams{
+		ID:             entry.ID,
+		OrganizationID: entry.OrganizationID,
+		IntegrationID:  uuid.NullUUID{UUID: entry.IntegrationID, Valid: entry.IntegrationID != uuid.Nil},
+		ConversationID: entry.ConversationID,
+		BinaryName:     entry.Binary,
+		Args:           entry.Args,
+		Decision:       string(entry.Decision),
+		Rule:           entry.Rule,
+		Reason:         entry.Reason,
+		TimedOut:       entry.TimedOut,
+		// Postgres text rejects NUL bytes and invalid UTF-8, which truncated
+		// command output can contain.
+		Output:          strings.ToValidUTF8(strings.ReplaceAll(entry.Output, "\x00", ""), "\uFFFD"),
+		OutputTruncated: entry.OutputTruncated,
+		StartedAt:       entry.StartedAt,
+		FinishedAt:      entry.FinishedAt,
+		ToolCallID:      entry.ToolCallID,
+	}
+	if params.Args == nil {
+		params.Args = []string{}
+	}
+	if entry.ExitCode != nil {
+		params.ExitCode = sql.NullInt32{Int32: int32(*entry.ExitCode), Valid: true}
+	}
+
+	if err := r.queries.StoreCommandAudit(ctx, params); err != nil {
+		return fmt.Errorf("failed to store command audit entry: %w", err)
+	}
+	return nil
+}
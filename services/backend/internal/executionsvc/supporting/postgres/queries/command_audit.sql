@@ -0,0 +1,6 @@
+-- name: StoreCommandAudit :exec
+INSERT INTO command_audit_log (
+    id, organization_id, integration_id, conversation_id, binary_name, args,
+    decision, rule, reason, exit_code, timed_out, output, output_truncated,
+    started_at, finished_at, tool_call_id
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16);
@@ -0,0 +1,20 @@
+CREATE TABLE command_audit_log (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL,
+    integration_id UUID,
+    conversation_id VARCHAR(255) NOT NULL DEFAULT '',
+    binary_name VARCHAR(64) NOT NULL,
+    args TEXT[] NOT NULL DEFAULT '{}',
+    decision VARCHAR(16) NOT NULL,
+    rule VARCHAR(64) NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    exit_code INTEGER,
+    timed_out BOOLEAN NOT NULL DEFAULT FALSE,
+    output TEXT NOT NULL DEFAULT '',
+    output_truncated BOOLEAN NOT NULL DEFAULT FALSE,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    tool_call_id VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_command_audit_log_org_started ON command_audit_log (organization_id, started_at DESC);
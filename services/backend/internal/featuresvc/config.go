@@ -0,0 +1,28 @@
+package featuresvc
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend/internal/featuresvc/supporting/postgres"
+)
+
+const defaultCacheTTL = 30 * time.Second
+
+type Config struct {
+	Database *sql.DB `mapstructure:"-"`
+	// Defaults apply to every organization without its own override.
+	Defaults        map[string]string `mapstructure:"defaults"`
+	CacheTTLSeconds int               `mapstructure:"cache_ttl_seconds"`
+	// AdminToken guards the flag admin endpoints; they are disabled when empty.
+	AdminToken string `mapstructure:"admin_token"`
+}
+
+func (c Config) New() *Service {
+	ttl := defaultCacheTTL
+	if c.CacheTTLSeconds > 0 {
+		ttl = time.Duration(c.CacheTTLSeconds) * time.Second
+	}
+
+	return NewService(postgres.NewFeatureFlagRepository(c.Database), c.Defaults, ttl)
+}
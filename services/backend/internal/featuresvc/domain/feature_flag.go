@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrFeatureFlagNotFound = errors.New("feature flag not found")
+	ErrInvalidFeatureFlag  = errors.New("invalid feature flag")
+)
+
+type FeatureFlagRepository interface {
+	Set(ctx context.Context, organizationID uuid.UUID, key string, value string) error
+	Unset(ctx context.Context, organizationID uuid.UUID, key string) error
+	ListByOrganization(ctx context.Context, organizationID uuid.UUID) (map[string]string, error)
+}
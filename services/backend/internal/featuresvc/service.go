@@ -0,0 +1,131 @@
+package featuresvc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/featuresvc/domain"
+	"github.com/google/uuid"
+)
+
+const maxFlagValueLength = 255
+
+var flagKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,99}$`)
+
+type cachedFlags struct {
+	flags     map[string]string
+	fetchedAt time.Time
+}
+
+type Service struct {
+	repository domain.FeatureFlagRepository
+	defaults   map[string]string
+	ttl        time.Duration
+	now        func() time.Time
+
+	mu    sync.RWMutex
+	cache map[uuid.UUID]cachedFlags
+}
+
+var _ backend.FeatureFlagService = (*Service)(nil)
+
+func NewService(repository domain.FeatureFlagRepository, defaults map[string]string, ttl time.Duration) *Service {
+	return &Service{
+		repository: repository,
+		defaults:   maps.Clone(defaults),
+		ttl:        ttl,
+		now:        time.Now,
+		cache:      make(map[uuid.UUID]cachedFlags),
+	}
+}
+
+func (s *Service) Enabled(ctx context.Context, organizationID uuid.UUID, flag backend.FeatureFlag) bool {
+	enabled, err := strconv.ParseBool(s.Value(ctx, organizationID, flag))
+	return err == nil && enabled
+}
+
+func (s *Service) Value(ctx context.Context, organizationID uuid.UUID, flag backend.FeatureFlag) string {
+	flags, err := s.organizationFlags(ctx, organizationID)
+	if err != nil {
+		slog.Error("failed to load feature flags, treating as off", "organization_id", organizationID, "flag", flag, "error", err)
+		return ""
+	}
+	return flags[string(flag)]
+}
+
+func (s *Service) OrganizationFeatureFlags(ctx context.Context, query backend.OrganizationFeatureFlagsQuery) (map[backend.FeatureFlag]string, error) {
+	flags, err := s.organizationFlags(ctx, query.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[backend.FeatureFlag]string, len(flags))
+	for key, value := range flags {
+		result[backend.FeatureFlag(key)] = value
+	}
+	return result, nil
+}
+
+func (s *Service) SetFeatureFlag(ctx context.Context, cmd backend.SetFeatureFlagCommand) error {
+	if !flagKeyPattern.MatchString(string(cmd.Flag)) {
+		return fmt.Errorf("%w: %q must be lowercase snake_case", domain.ErrInvalidFeatureFlag, cmd.Flag)
+	}
+	if len(cmd.Value) > maxFlagValueLength {
+		return fmt.Errorf("%w: value longer than %d characters", domain.ErrInvalidFeatureFlag, maxFlagValueLength)
+	}
+
+	if err := s.repository.Set(ctx, cmd.OrganizationID, string(cmd.Flag), cmd.Value); err != nil {
+		return fmt.Errorf("failed to set feature flag: %w", err)
+	}
+	s.invalidate(cmd.OrganizationID)
+	return nil
+}
+
+func (s *Service) UnsetFeatureFlag(ctx context.Context, cmd backend.UnsetFeatureFlagCommand) error {
+	if err := s.repository.Unset(ctx, cmd.OrganizationID, string(cmd.Flag)); err != nil {
+		return fmt.Errorf("failed to unset feature flag: %w", err)
+	}
+	s.invalidate(cmd.OrganizationID)
+	return nil
+}
+
+// organizationFlags returns the defaults merged with the organization's
+// overrides, served from cache for up to ttl.
+func (s *Service) organizationFlags(ctx context.Context, organizationID uuid.UUID) (map[string]string, error) {
+	s.mu.RLock()
+	cached, ok := s.cache[organizationID]
+	s.mu.RUnlock()
+	if ok && s.now().Sub(cached.fetchedAt) < s.ttl {
+		return cached.flags, nil
+	}
+
+	overrides, err := s.repository.ListByOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feature flags: %w", err)
+	}
+
+	flags := maps.Clone(s.defaults)
+	if flags == nil {
+		flags = make(map[string]string, len(overrides))
+	}
+	maps.Copy(flags, overrides)
+
+	s.mu.Lock()
+	s.cache[organizationID] = cachedFlags{flags: flags, fetchedAt: s.now()}
+	s.mu.Unlock()
+
+	return flags, nil
+}
+
+func (s *Service) invalidate(organizationID uuid.UUID) {
+	s.mu.Lock()
+	delete(s.cache, organizationID)
+	s.mu.Unlock()
+}
@@ -0,0 +1,111 @@
+package featuresvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/featuresvc/domain"
+	"github.com/google/uuid"
+)
+
+type memoryFlagRepository struct {
+	flags map[uuid.UUID]map[string]string
+	loads int
+}
+
+func (m *memoryFlagRepository) Set(ctx context.Context, organizationID uuid.UUID, key string, value string) error {
+	if m.flags[organizationID] == nil {
+		m.flags[organizationID] = make(map[string]string)
+	}
+	m.flags[organizationID][key] = value
+	return nil
+}
+
+func (m *memoryFlagRepository) Unset(ctx context.Context, organizationID uuid.UUID, key string) error {
+	if _, ok := m.flags[organizationID][key]; !ok {
+		return domain.ErrFeatureFlagNotFound
+	}
+	delete(m.flags[organizationID], key)
+	return nil
+}
+
+func (m *memoryFlagRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID) (map[string]string, error) {
+	m.loads++
+	flags := make(map[string]string)
+	for k, v := range m.flags[organizationID] {
+		flags[k] = v
+	}
+	return flags, nil
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryFlagRepository{flags: make(map[uuid.UUID]map[string]string)}
+	svc := NewService(repo, map[string]string{"streaming": "true"}, time.Minute)
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	org := uuid.New()
+
+	if svc.Enabled(ctx, org, backend.FeatureFlagModelSelection) {
+		t.Error("unknown flag enabled, want off by default")
+	}
+	if !svc.Enabled(ctx, org, "streaming") {
+		t.Error("streaming disabled, want config default")
+	}
+
+	err := svc.SetFeatureFlag(ctx, backend.SetFeatureFlagCommand{OrganizationID: org, Flag: backend.FeatureFlagModelSelection, Value: "true"})
+	if err != nil {
+		t.Fatalf("SetFeatureFlag() error = %v", err)
+	}
+	err = svc.SetFeatureFlag(ctx, backend.SetFeatureFlagCommand{OrganizationID: org, Flag: "streaming", Value: "false"})
+	if err != nil {
+		t.Fatalf("SetFeatureFlag() error = %v", err)
+	}
+	if !svc.Enabled(ctx, org, backend.FeatureFlagModelSelection) {
+		t.Error("model_selection disabled after set")
+	}
+	if svc.Enabled(ctx, org, "streaming") {
+		t.Error("streaming enabled, want organization override")
+	}
+	if svc.Enabled(ctx, uuid.New(), backend.FeatureFlagModelSelection) {
+		t.Error("override leaked to another organization")
+	}
+
+	loads := repo.loads
+	svc.Value(ctx, org, "streaming")
+	if repo.loads != loads {
+		t.Errorf("repository loaded %d times within ttl, want cached", repo.loads-loads)
+	}
+
+	// Writes from another instance are picked up once the cache expires.
+	repo.flags[org]["tier"] = "enterprise"
+	if got := svc.Value(ctx, org, "tier"); got != "" {
+		t.Errorf("Value() = %q before ttl, want cached empty value", got)
+	}
+	now = now.Add(2 * time.Minute)
+	if got := svc.Value(ctx, org, "tier"); got != "enterprise" {
+		t.Errorf("Value() = %q after ttl, want enterprise", got)
+	}
+
+	if err := svc.UnsetFeatureFlag(ctx, backend.UnsetFeatureFlagCommand{OrganizationID: org, Flag: "streaming"}); err != nil {
+		t.Fatalf("UnsetFeatureFlag() error = %v", err)
+	}
+	if !svc.Enabled(ctx, org, "streaming") {
+		t.Error("streaming disabled after unset, want config default")
+	}
+
+	err = svc.UnsetFeatureFlag(ctx, backend.UnsetFeatureFlagCommand{OrganizationID: org, Flag: "streaming"})
+	if !errors.Is(err, domain.ErrFeatureFlagNotFound) {
+		t.Errorf("UnsetFeatureFlag() error = %v, want ErrFeatureFlagNotFound", err)
+	}
+
+	err = svc.SetFeatureFlag(ctx, backend.SetFeatureFlagCommand{OrganizationID: org, Flag: "Bad Flag", Value: "true"})
+	if !errors.Is(err, domain.ErrInvalidFeatureFlag) {
+		t.Errorf("SetFeatureFlag() error = %v, want ErrInvalidFeatureFlag", err)
+	}
+}
@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/73ai/infragpt/services/backend/internal/featuresvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
+	"github.com/google/uuid"
+)
+
+type featureFlagRepository struct {
+	queries *Queries
+}
+
+func NewFeatureFlagRepository(sqlDB *sql.DB) domain.FeatureFlagRepository {
+	return &featureFlagRepository{
+		queries: New(pgretry.Wrap(sqlDB)),
+	}
+}
+
+func (r *featureFlagRepository) Set(ctx context.Context, organizationID uuid.UUID, key string, value string) error {
+	err := r.queries.UpsertFeatureFlag(ctx, UpsertFeatureFlagParams{
+		OrganizationID: organizationID,
+		FlagKey:        key,
+		FlagValue:      value,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set feature flag: %w", err)
+	}
+	return nil
+}
+
+func (r *featureFlagRepository) Unset(ctx context.Context, organizationID uuid.UUID, key string) error {
+	deleted, err := r.queries.DeleteFeatureFlag(ctx, DeleteFeatureFlagParams{
+		OrganizationID: organizationID,
+		FlagKey:        key,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unset feature flag: %w", err)
+	}
+	if deleted == 0 {
+		return domain.ErrFeatureFlagNotFound
+	}
+	return nil
+}
+
+func (r *featureFlagRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID) (map[string]string, error) {
+	rows, err := r.queries.FindFeatureFlagsByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feature flags: %w", err)
+	}
+
+	flags := make(map[string]string, len(rows))
+	for _, row := range rows {
+		flags[row.FlagKey] = row.FlagValue
+	}
+	return flags, nil
+}
@@ -0,0 +1,15 @@
+-- name: UpsertFeatureFlag :exec
+INSERT INTO organization_feature_flags (organization_id, flag_key, flag_value)
+VALUES ($1, $2, $3)
+ON CONFLICT (organization_id, flag_key)
+DO UPDATE SET flag_value = EXCLUDED.flag_value, updated_at = NOW();
+
+-- name: DeleteFeatureFlag :execrows
+DELETE FROM organization_feature_flags
+WHERE organization_id = $1 AND flag_key = $2;
+
+-- name: FindFeatureFlagsByOrganizationID :many
+SELECT organization_id, flag_key, flag_value, created_at, updated_at
+FROM organization_feature_flags
+WHERE organization_id = $1
+ORDER BY flag_key;
@@ -0,0 +1,8 @@
+CREATE TABLE organization_feature_flags (
+    organization_id UUID NOT NULL,
+    flag_key VARCHAR(100) NOT NULL,
+    flag_value VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, flag_key)
+);
@@ -0,0 +1,133 @@
+// Package envconfig lets environment variables override values decoded from
+// config.yaml, so secrets can be injected without mounting files.
+package envconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+// Override sets raw[key] for every leaf of target's mapstructure layout whose
+// environment variable is set, and returns the overridden keys in dot notation.
+// The variable for database.password under prefix INFRAGPT is
+// INFRAGPT_DATABASE_PASSWORD. Maps cannot be overridden because their keys are
+// not known up front.
+func Override(raw map[string]any, target any, prefix string, lookup func(string) (string, bool)) []string {
+	var overridden []string
+	walk(reflect.TypeOf(target), nil, func(path []string) {
+		value, ok := lookup(prefix + "_" + strings.ToUpper(strings.Join(path, "_")))
+		if !ok {
+			return
+		}
+		set(raw, path, value)
+		overridden = append(overridden, strings.Join(path, "."))
+	})
+	return overridden
+}
+
+func walk(t reflect.Type, path []string, leaf func([]string)) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fieldPath := append(append([]string(nil), path...), name)
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		switch {
+		case fieldType.Kind() == reflect.Struct && fieldType != reflect.TypeOf(time.Time{}):
+			walk(fieldType, fieldPath, leaf)
+		case isScalar(fieldType.Kind()), fieldType.Kind() == reflect.Slice && isScalar(fieldType.Elem().Kind()):
+			leaf(fieldPath)
+		}
+	}
+}
+
+func isScalar(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+func set(raw map[string]any, path []string, value string) {
+	for _, key := range path[:len(path)-1] {
+		next, ok := raw[key].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			raw[key] = next
+		}
+		raw = next
+	}
+	raw[path[len(path)-1]] = value
+}
+
+// DecodeHook converts the strings Override sets into the field's type:
+// numbers, booleans, durations and comma-separated lists.
+func DecodeHook() mapstructure.DecodeHookFuncType {
+	return func(from reflect.Type, to reflect.Type, data any) (any, error) {
+		s, ok := data.(string)
+		if !ok || from.Kind() != reflect.String {
+			return data, nil
+		}
+
+		switch {
+		case to == reflect.TypeOf(time.Duration(0)):
+			return time.ParseDuration(s)
+		case to.Kind() == reflect.Slice && to.Elem().Kind() != reflect.Uint8:
+			if s == "" {
+				return []string{}, nil
+			}
+			values := strings.Split(s, ",")
+			for i := range values {
+				values[i] = strings.TrimSpace(values[i])
+			}
+			return values, nil
+		}
+
+		switch to.Kind() {
+		case reflect.Bool:
+			return parse(strconv.ParseBool(s))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return parse(strconv.ParseInt(s, 10, 64))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return parse(strconv.ParseUint(s, 10, 64))
+		case reflect.Float32, reflect.Float64:
+			return parse(strconv.ParseFloat(s, 64))
+		}
+		return data, nil
+	}
+}
+
+func parse[T any](value T, err error) (any, error) {
+	if err != nil {
+		return nil, fmt.Errorf("invalid value: %w", err)
+	}
+	return value, nil
+}
@@ -0,0 +1,66 @@
+package envconfig
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+func TestOverride(t *testing.T) {
+	type database struct {
+		Host     string `mapstructure:"host"`
+		Port     int    `mapstructure:"port"`
+		Password string `mapstructure:"password"`
+	}
+	type config struct {
+		Database database          `mapstructure:"database"`
+		HttpLog  bool              `mapstructure:"http_log"`
+		Timeout  time.Duration     `mapstructure:"timeout"`
+		Allowed  []string          `mapstructure:"allowed"`
+		Defaults map[string]string `mapstructure:"defaults"`
+		Internal any               `mapstructure:"-"`
+	}
+
+	env := map[string]string{
+		"INFRAGPT_DATABASE_PORT":     "6543",
+		"INFRAGPT_DATABASE_PASSWORD": "from-env",
+		"INFRAGPT_HTTP_LOG":          "false",
+		"INFRAGPT_TIMEOUT":           "90s",
+		"INFRAGPT_ALLOWED":           "gpt-4o, gpt-4o-mini",
+	}
+	raw := map[string]any{
+		"database": map[string]any{"host": "db.internal", "port": 5432, "password": "from-yaml"},
+		"http_log": true,
+	}
+
+	var c config
+	overridden := Override(raw, &c, "INFRAGPT", func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	})
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{DecodeHook: DecodeHook(), Result: &c})
+	if err != nil {
+		t.Fatalf("NewDecoder() error = %v", err)
+	}
+	if err := decoder.Decode(raw); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	want := config{
+		Database: database{Host: "db.internal", Port: 6543, Password: "from-env"},
+		Timeout:  90 * time.Second,
+		Allowed:  []string{"gpt-4o", "gpt-4o-mini"},
+	}
+	if c.Database != want.Database || c.HttpLog || c.Timeout != want.Timeout || !slices.Equal(c.Allowed, want.Allowed) {
+		t.Errorf("decoded config = %+v, want %+v", c, want)
+	}
+
+	slices.Sort(overridden)
+	wantKeys := []string{"allowed", "database.password", "database.port", "http_log", "timeout"}
+	if !slices.Equal(overridden, wantKeys) {
+		t.Errorf("Override() = %v, want %v", overridden, wantKeys)
+	}
+}
@@ -2,13 +2,27 @@ package httperrors
 
 import (
 	"errors"
-	"strings"
+	"net/http"
 )
 
+const (
+	CodeValidation   = "validation_error"
+	CodeNotFound     = "not_found"
+	CodeConflict     = "conflict"
+	CodeUnauthorized = "unauthorized"
+	CodeRateLimited  = "rate_limited"
+	CodeMaintenance  = "maintenance"
+	CodeUnavailable  = "unavailable"
+	CodeInternal     = "internal_error"
+)
+
+const internalErrorMessage = "internal server error"
+
 type Error struct {
 	Code       string   `json:"code"`
 	Message    string   `json:"message"`
 	Fields     []string `json:"fields"`
+	RequestID  string   `json:"request_id,omitempty"`
 	HttpStatus int      `json:"-"`
 }
 
@@ -16,35 +30,64 @@ func (e Error) Error() string {
 	return e.Message
 }
 
-// From converts an error to an Error using errors.As
+// Mapping ties a domain error to the status and code it is reported with.
+// The message sent to the client is Message, or the text of Target when empty,
+// never the text of the wrapping error.
+type Mapping struct {
+	Target     error
+	HttpStatus int
+	Code       string
+	Message    string
+}
+
+// From converts an error to an Error using errors.As.
+// Errors that are not an Error are reported as internal errors.
 func From(err error) Error {
+	if err == nil {
+		return Error{HttpStatus: http.StatusOK, Fields: []string{}}
+	}
+	return Classify(err)
+}
+
+// Classify converts err to an Error, consulting mappings for domain errors.
+// Anything unrecognised becomes an internal error with a generic message so
+// that implementation details never reach the client.
+func Classify(err error, mappings ...Mapping) Error {
 	var e Error
-	if ok := errors.As(err, &e); !ok {
-		httpStatus := 400
-		msg := ""
-		if err != nil {
-			msg = err.Error()
+	if errors.As(err, &e) {
+		if e.Fields == nil {
+			e.Fields = []string{}
 		}
-		if err == nil {
-			httpStatus = 200
-		}
-		if err != nil && (strings.Contains(strings.ToLower(msg), "internal") ||
-			strings.Contains(err.Error(), "panic") ||
-			strings.Contains(err.Error(), "nil pointer dereference") ||
-			strings.Contains(err.Error(), "goroutine")) {
-			httpStatus = 500
-		}
-		return Error{
-			HttpStatus: httpStatus,
-			Code:       "",
-			Fields:     []string{},
-			Message:    msg,
+		return e
+	}
+
+	for _, m := range mappings {
+		if errors.Is(err, m.Target) {
+			message := m.Message
+			if message == "" {
+				message = m.Target.Error()
+			}
+			return Error{
+				Code:       m.Code,
+				Message:    message,
+				Fields:     []string{},
+				HttpStatus: m.HttpStatus,
+			}
 		}
 	}
-	return e
+
+	return Error{
+		Code:       CodeInternal,
+		Message:    internalErrorMessage,
+		Fields:     []string{},
+		HttpStatus: http.StatusInternalServerError,
+	}
 }
 
 func New(httpStatus int, code string, message string, fields []string) error {
+	if fields == nil {
+		fields = []string{}
+	}
 	return Error{
 		Code:       code,
 		Message:    message,
@@ -53,6 +96,38 @@ func New(httpStatus int, code string, message string, fields []string) error {
 	}
 }
 
+func Validation(message string, fields ...string) error {
+	return New(http.StatusBadRequest, CodeValidation, message, fields)
+}
+
+func NotFound(message string) error {
+	return New(http.StatusNotFound, CodeNotFound, message, nil)
+}
+
+func Conflict(message string) error {
+	return New(http.StatusConflict, CodeConflict, message, nil)
+}
+
+func Unauthorized(message string) error {
+	return New(http.StatusUnauthorized, CodeUnauthorized, message, nil)
+}
+
+func RateLimited(message string) error {
+	return New(http.StatusTooManyRequests, CodeRateLimited, message, nil)
+}
+
+func Maintenance(message string) error {
+	return New(http.StatusServiceUnavailable, CodeMaintenance, message, nil)
+}
+
+func Unavailable(message string) error {
+	return New(http.StatusServiceUnavailable, CodeUnavailable, message, nil)
+}
+
+func Internal() error {
+	return New(http.StatusInternalServerError, CodeInternal, internalErrorMessage, nil)
+}
+
 func (e Error) Is(target error) bool {
 	var err Error
 	if ok := errors.As(target, &err); !ok {
@@ -0,0 +1,44 @@
+package httperrors
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID returns the request ID supplied by the caller or a freshly generated one.
+func RequestID(r *http.Request) string {
+	if id := r.Header.Get(RequestIDHeader); id != "" {
+		return id
+	}
+	return uuid.NewString()
+}
+
+// Write classifies err, logs the underlying cause with the request ID and
+// writes the JSON error envelope to w.
+func Write(w http.ResponseWriter, r *http.Request, err error, mappings ...Mapping) {
+	httpError := Classify(err, mappings...)
+	httpError.RequestID = RequestID(r)
+
+	attrs := []any{
+		"path", r.URL.Path,
+		"request_id", httpError.RequestID,
+		"status", httpError.HttpStatus,
+		"code", httpError.Code,
+		"err", err,
+	}
+	if httpError.HttpStatus >= http.StatusInternalServerError {
+		slog.Error("http request failed", attrs...)
+	} else {
+		slog.Info("http request rejected", attrs...)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set(RequestIDHeader, httpError.RequestID)
+	w.WriteHeader(httpError.HttpStatus)
+	_ = json.NewEncoder(w).Encode(httpError)
+}
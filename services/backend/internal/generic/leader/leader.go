@@ -0,0 +1,183 @@
+// Package leader runs work on a single replica at a time. Replicas campaign
+// for a named lease in a shared store; the holder runs the work and renews the
+// lease, and another replica takes over once a lease it stopped renewing
+// expires.
+package leader
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const defaultLeaseSeconds = 5
+
+// LeaseStore grants a named lease to one holder at a time.
+type LeaseStore interface {
+	// Acquire takes the lease for holder, or renews it if holder already has
+	// it, until ttl from now. It reports false while another holder's lease
+	// has not expired.
+	Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error)
+	// Release gives the lease up if holder has it, so another replica can take
+	// over without waiting for it to expire.
+	Release(ctx context.Context, name, holder string) error
+}
+
+type Config struct {
+	// LeaseSeconds is how long a lease lasts without renewal, and so bounds
+	// how long a failover takes after the leader dies.
+	LeaseSeconds int `mapstructure:"lease_seconds"`
+
+	Name  string     `mapstructure:"-"`
+	Store LeaseStore `mapstructure:"-"`
+	// Holder identifies this replica. It defaults to the hostname and a
+	// random suffix.
+	Holder string `mapstructure:"-"`
+}
+
+func (c Config) New() *Elector {
+	lease := time.Duration(c.LeaseSeconds) * time.Second
+	if lease <= 0 {
+		lease = defaultLeaseSeconds * time.Second
+	}
+
+	holder := c.Holder
+	if holder == "" {
+		hostname, _ := os.Hostname()
+		holder = fmt.Sprintf("%s-%s", hostname, uuid.NewString()[:8])
+	}
+
+	return &Elector{
+		name:   c.Name,
+		holder: holder,
+		store:  c.Store,
+		lease:  lease,
+		renew:  lease / 3,
+	}
+}
+
+// Elector campaigns for a lease on behalf of this replica.
+type Elector struct {
+	name   string
+	holder string
+	store  LeaseStore
+	lease  time.Duration
+	renew  time.Duration
+}
+
+// Run campaigns for the lease until ctx is done and calls fn whenever this
+// replica holds it. fn's context is canceled as soon as the lease cannot be
+// renewed, before it expires for the other replicas. An error from fn while
+// it still holds the lease is returned.
+func (e *Elector) Run(ctx context.Context, fn func(ctx context.Context) error) error {
+	for {
+		start := time.Now()
+		acquired, err := e.acquire(ctx)
+		if err != nil && ctx.Err() == nil {
+			slog.Warn("leader: failed to acquire lease", "lease", e.name, "holder", e.holder, "error", err)
+		}
+		if acquired {
+			if err := e.lead(ctx, start, fn); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(e.renew):
+		}
+	}
+}
+
+func (e *Elector) lead(ctx context.Context, start time.Time, fn func(ctx context.Context) error) error {
+	slog.Info("leader: acquired lease", "lease", e.name, "holder", e.holder)
+	e.transition(ctx, true)
+
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- fn(leaderCtx) }()
+
+	expiry := time.NewTimer(e.lease - time.Since(start))
+	defer expiry.Stop()
+	renewal := time.NewTicker(e.renew)
+	defer renewal.Stop()
+
+	lost := func(reason string, err error) {
+		slog.Warn("leader: lost lease", "lease", e.name, "holder", e.holder, "reason", reason, "error", err)
+		cancel()
+		<-done
+		e.transition(ctx, false)
+	}
+
+	for {
+		select {
+		case err := <-done:
+			e.release(ctx)
+			e.transition(ctx, false)
+			if err != nil && ctx.Err() == nil {
+				return err
+			}
+			return nil
+		case <-ctx.Done():
+			cancel()
+			<-done
+			e.release(ctx)
+			e.transition(ctx, false)
+			return nil
+		case <-expiry.C:
+			lost("lease expired before it could be renewed", nil)
+			return nil
+		case <-renewal.C:
+			start := time.Now()
+			acquired, err := e.acquire(ctx)
+			if err != nil || !acquired {
+				lost("renewal failed", err)
+				return nil
+			}
+			expiry.Reset(e.lease - time.Since(start))
+		}
+	}
+}
+
+// acquire gives the store until the next renewal to answer, so a hung store
+// cannot keep the lease past its expiry.
+func (e *Elector) acquire(ctx context.Context) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.renew)
+	defer cancel()
+	return e.store.Acquire(ctx, e.name, e.holder, e.lease)
+}
+
+func (e *Elector) release(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), e.renew)
+	defer cancel()
+	if err := e.store.Release(ctx, e.name, e.holder); err != nil {
+		slog.Warn("leader: failed to release lease", "lease", e.name, "holder", e.holder, "error", err)
+		return
+	}
+	slog.Info("leader: released lease", "lease", e.name, "holder", e.holder)
+}
+
+func (e *Elector) transition(ctx context.Context, leading bool) {
+	attrs := metric.WithAttributes(attribute.String("lease", e.name), attribute.Bool("leading", leading))
+	transitions.Add(context.WithoutCancel(ctx), 1, attrs)
+}
+
+const instrumentationName = "github.com/73ai/infragpt/services/backend/internal/generic/leader"
+
+var transitions metric.Int64Counter
+
+func init() {
+	transitions, _ = otel.Meter(instrumentationName).Int64Counter(
+		"leader.transitions",
+		metric.WithDescription("Number of times this replica gained or lost a lease"),
+	)
+}
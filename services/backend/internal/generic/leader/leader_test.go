@@ -0,0 +1,188 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeLeaseStore struct {
+	mu          sync.Mutex
+	holder      string
+	expiresAt   time.Time
+	partitioned map[string]bool
+}
+
+func (s *fakeLeaseStore) Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.partitioned[holder] {
+		return false, errors.New("connection refused")
+	}
+	if s.holder != holder && time.Now().Before(s.expiresAt) {
+		return false, nil
+	}
+	s.holder = holder
+	s.expiresAt = time.Now().Add(ttl)
+	return true, nil
+}
+
+func (s *fakeLeaseStore) Release(ctx context.Context, name, holder string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.partitioned[holder] {
+		return errors.New("connection refused")
+	}
+	if s.holder == holder {
+		s.holder = ""
+	}
+	return nil
+}
+
+func (s *fakeLeaseStore) partition(holder string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.partitioned[holder] = true
+}
+
+// fakeSlack delivers each event to every connected subscriber, like a Socket
+// Mode connection per replica, and holds events back while none is connected.
+type fakeSlack struct {
+	mu          sync.Mutex
+	subscribers map[string]func(event int)
+	pending     []int
+}
+
+func (s *fakeSlack) connect(holder string, handle func(event int)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.subscribers[holder] = handle
+	for _, event := range s.pending {
+		handle(event)
+	}
+	s.pending = nil
+}
+
+func (s *fakeSlack) disconnect(holder string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subscribers, holder)
+}
+
+func (s *fakeSlack) publish(event int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.subscribers) == 0 {
+		s.pending = append(s.pending, event)
+		return
+	}
+	for _, handle := range s.subscribers {
+		handle(event)
+	}
+}
+
+func TestFailover(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := &fakeLeaseStore{partitioned: make(map[string]bool)}
+	slack := &fakeSlack{subscribers: make(map[string]func(int))}
+
+	var mu sync.Mutex
+	processed := make(map[int][]string)
+	leading := ""
+	record := func(holder string, event int) {
+		mu.Lock()
+		defer mu.Unlock()
+		processed[event] = append(processed[event], holder)
+	}
+	leader := func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		return leading
+	}
+
+	var wg sync.WaitGroup
+	for _, holder := range []string{"replica-a", "replica-b"} {
+		elector := &Elector{name: "slack", holder: holder, store: store, lease: 150 * time.Millisecond, renew: 50 * time.Millisecond}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = elector.Run(ctx, func(ctx context.Context) error {
+				mu.Lock()
+				leading = holder
+				mu.Unlock()
+
+				slack.connect(holder, func(event int) { record(holder, event) })
+				defer slack.disconnect(holder)
+				<-ctx.Done()
+				return ctx.Err()
+			})
+		}()
+	}
+
+	waitFor := func(what string, cond func() bool) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for !cond() {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %s", what)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	waitFor("a leader", func() bool { return leader() != "" })
+	first := leader()
+
+	const events = 60
+	for event := range events {
+		if event == 10 {
+			store.partition(first)
+		}
+		slack.publish(event)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	waitFor("all events", func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(processed) == events
+	})
+	cancel()
+	wg.Wait()
+
+	second := leader()
+	if second == first {
+		t.Fatalf("leader = %s after its store was partitioned, want the other replica", second)
+	}
+	for event := range events {
+		holders := processed[event]
+		if len(holders) != 1 {
+			t.Errorf("event %d processed by %v, want exactly once", event, holders)
+		}
+	}
+	if got := processed[events-1]; len(got) == 1 && got[0] != second {
+		t.Errorf("last event processed by %s, want the new leader %s", got[0], second)
+	}
+}
+
+func TestRunReturnsErrorWhileLeading(t *testing.T) {
+	store := &fakeLeaseStore{partitioned: make(map[string]bool)}
+	elector := &Elector{name: "slack", holder: "replica-a", store: store, lease: 150 * time.Millisecond, renew: 50 * time.Millisecond}
+
+	want := errors.New("socket closed")
+	err := elector.Run(context.Background(), func(ctx context.Context) error { return want })
+	if !errors.Is(err, want) {
+		t.Fatalf("Run() error = %v, want %v", err, want)
+	}
+	if store.holder != "" {
+		t.Errorf("lease held by %q after Run returned, want released", store.holder)
+	}
+}
@@ -0,0 +1,47 @@
+package leader
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PostgresStore keeps leases in the leader_leases table. Expiry is checked
+// against the database clock, so replicas do not need synchronized clocks.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+const acquireLease = `INSERT INTO leader_leases (name, holder, expires_at)
+VALUES ($1, $2, NOW() + $3 * INTERVAL '1 millisecond')
+ON CONFLICT (name) DO UPDATE
+SET holder = EXCLUDED.holder, expires_at = EXCLUDED.expires_at
+WHERE leader_leases.holder = EXCLUDED.holder OR leader_leases.expires_at < NOW()
+RETURNING holder`
+
+func (s *PostgresStore) Acquire(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	var got string
+	err := s.db.QueryRowContext(ctx, acquireLease, name, holder, ttl.Milliseconds()).Scan(&got)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire lease %s: %w", name, err)
+	}
+	return true, nil
+}
+
+const releaseLease = `DELETE FROM leader_leases WHERE name = $1 AND holder = $2`
+
+func (s *PostgresStore) Release(ctx context.Context, name, holder string) error {
+	if _, err := s.db.ExecContext(ctx, releaseLease, name, holder); err != nil {
+		return fmt.Errorf("failed to release lease %s: %w", name, err)
+	}
+	return nil
+}
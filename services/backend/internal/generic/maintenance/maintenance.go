@@ -0,0 +1,88 @@
+package maintenance
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+)
+
+const defaultRetryAfter = 5 * time.Minute
+
+// ErrReadOnly is reported for writes rejected while read-only mode is on.
+var ErrReadOnly = httperrors.Maintenance("InfraGPT is in read-only mode for maintenance, please retry shortly")
+
+type Config struct {
+	ReadOnly          bool `mapstructure:"read_only"`
+	RetryAfterSeconds int  `mapstructure:"retry_after_seconds"`
+}
+
+func (c Config) New() *Mode {
+	retryAfter := time.Duration(c.RetryAfterSeconds) * time.Second
+	if retryAfter <= 0 {
+		retryAfter = defaultRetryAfter
+	}
+
+	m := &Mode{retryAfter: retryAfter}
+	m.readOnly.Store(c.ReadOnly)
+	if c.ReadOnly {
+		slog.Warn("maintenance: starting in read-only mode")
+	}
+	return m
+}
+
+// Mode holds the process-wide read-only switch. It can be flipped at runtime,
+// so leaving read-only mode does not need a restart. A nil Mode is never
+// read-only.
+type Mode struct {
+	readOnly   atomic.Bool
+	retryAfter time.Duration
+}
+
+func (m *Mode) ReadOnly() bool {
+	return m != nil && m.readOnly.Load()
+}
+
+func (m *Mode) SetReadOnly(readOnly bool) {
+	if m.readOnly.Swap(readOnly) != readOnly {
+		slog.Warn("maintenance: read-only mode changed", "read_only", readOnly)
+	}
+}
+
+func (m *Mode) RetryAfter() time.Duration {
+	return m.retryAfter
+}
+
+// Middleware rejects requests with 503 and a Retry-After header while in
+// read-only mode. Requests for one of readPaths are always let through; a path
+// ending in "/*" lets through everything below it.
+func (m *Mode) Middleware(readPaths ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !m.ReadOnly() || isRead(r, readPaths) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Retry-After", strconv.Itoa(int(m.retryAfter.Seconds())))
+			httperrors.Write(w, r, ErrReadOnly)
+		})
+	}
+}
+
+func isRead(r *http.Request, readPaths []string) bool {
+	if r.Method == http.MethodOptions {
+		return true
+	}
+	return slices.ContainsFunc(readPaths, func(path string) bool {
+		if prefix, ok := strings.CutSuffix(path, "*"); ok && strings.HasSuffix(prefix, "/") {
+			return strings.HasPrefix(r.URL.Path, prefix)
+		}
+		return path == r.URL.Path
+	})
+}
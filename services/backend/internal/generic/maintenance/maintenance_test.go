@@ -0,0 +1,47 @@
+package maintenance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMiddleware(t *testing.T) {
+	mode := Config{RetryAfterSeconds: 120}.New()
+	handler := mode.Middleware("/integrations/list/", "/webhooks/*")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, nil))
+		return rec
+	}
+
+	if rec := serve("/integrations/revoke/"); rec.Code != http.StatusOK {
+		t.Errorf("write status = %d before read-only, want 200", rec.Code)
+	}
+
+	mode.SetReadOnly(true)
+	rec := serve("/integrations/revoke/")
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("write status = %d in read-only mode, want 503", rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "120" {
+		t.Errorf("Retry-After = %q, want 120", got)
+	}
+	if rec := serve("/integrations/list/"); rec.Code != http.StatusOK {
+		t.Errorf("read status = %d in read-only mode, want 200", rec.Code)
+	}
+	if rec := serve("/webhooks/github"); rec.Code != http.StatusOK {
+		t.Errorf("webhook status = %d in read-only mode, want 200", rec.Code)
+	}
+	if rec := serve("/webhooks"); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d for the bare prefix in read-only mode, want 503", rec.Code)
+	}
+
+	mode.SetReadOnly(false)
+	if rec := serve("/integrations/revoke/"); rec.Code != http.StatusOK {
+		t.Errorf("write status = %d after leaving read-only, want 200", rec.Code)
+	}
+}
@@ -0,0 +1,113 @@
+package pgretry
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// breakerThreshold consecutive unreachable errors open the breaker.
+	breakerThreshold = 5
+	breakerCooldown  = 10 * time.Second
+)
+
+type breakerState int64
+
+const (
+	breakerClosed breakerState = iota
+	breakerHalfOpen
+	breakerOpen
+)
+
+// breaker opens after breakerThreshold consecutive unreachable errors and
+// rejects statements for breakerCooldown. It then lets a single probe through
+// and closes again once the database answers.
+type breaker struct {
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+	cooldown time.Duration
+}
+
+func newBreaker() *breaker {
+	return &breaker{cooldown: breakerCooldown}
+}
+
+func (b *breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+// record counts err against the breaker. Errors from a reachable database,
+// such as constraint violations, count as successes.
+func (b *breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if unreachable(err) {
+		b.failures++
+		if b.state == breakerHalfOpen || b.failures >= breakerThreshold {
+			if b.state != breakerOpen {
+				slog.Warn("pgretry: database unreachable, circuit breaker open", "failures", b.failures, "error", err)
+			}
+			b.state = breakerOpen
+			b.openedAt = time.Now()
+		}
+		return
+	}
+
+	if b.state != breakerClosed {
+		slog.Info("pgretry: database reachable again, circuit breaker closed")
+	}
+	b.state = breakerClosed
+	b.failures = 0
+}
+
+func (b *breaker) current() breakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+const instrumentationName = "github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
+
+var retries metric.Int64Counter
+
+func init() {
+	meter := otel.Meter(instrumentationName)
+	retries, _ = meter.Int64Counter(
+		"postgres.retries",
+		metric.WithDescription("Number of Postgres statements retried after a transient error"),
+	)
+	_, _ = meter.Int64ObservableGauge(
+		"postgres.circuit_breaker.state",
+		metric.WithDescription("Postgres circuit breaker state: 0 closed, 1 half open, 2 open"),
+		metric.WithInt64Callback(func(ctx context.Context, o metric.Int64Observer) error {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, d := range wrapped {
+				o.Observe(int64(d.breaker.current()))
+			}
+			return nil
+		}),
+	)
+}
@@ -0,0 +1,195 @@
+// Package pgretry retries transient Postgres errors for sqlc queries and
+// fails fast through a circuit breaker while the database is unreachable.
+//
+// Only statements run outside a transaction go through it; a failed
+// transaction has to be retried as a whole by its caller.
+package pgretry
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/lib/pq"
+)
+
+const (
+	maxAttempts = 3
+	baseBackoff = 50 * time.Millisecond
+)
+
+// ErrUnavailable is returned without querying while the breaker is open.
+var ErrUnavailable = httperrors.Unavailable("the database is temporarily unavailable, please retry shortly")
+
+var (
+	mu      sync.Mutex
+	wrapped = make(map[*sql.DB]*DB)
+)
+
+// DB implements the sqlc DBTX interface on top of a *sql.DB.
+type DB struct {
+	db      *sql.DB
+	breaker *breaker
+}
+
+// Wrap returns the retrying DB for db. Repositories sharing a *sql.DB share
+// its breaker, so one outage trips it for all of them.
+func Wrap(db *sql.DB) *DB {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if d, ok := wrapped[db]; ok {
+		return d
+	}
+	d := &DB{db: db, breaker: newBreaker()}
+	wrapped[db] = d
+	return d
+}
+
+func (d *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	var result sql.Result
+	err := d.do(ctx, func() (err error) {
+		result, err = d.db.ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+func (d *DB) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	var stmt *sql.Stmt
+	err := d.do(ctx, func() (err error) {
+		stmt, err = d.db.PrepareContext(ctx, query)
+		return err
+	})
+	return stmt, err
+}
+
+func (d *DB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := d.do(ctx, func() (err error) {
+		rows, err = d.db.QueryContext(ctx, query, args...)
+		return err
+	})
+	return rows, err
+}
+
+func (d *DB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	var row *sql.Row
+	_ = d.do(ctx, func() error {
+		row = d.db.QueryRowContext(ctx, query, args...)
+		return row.Err()
+	})
+	if row == nil {
+		// The breaker was open. Only database/sql can build a *sql.Row that
+		// carries an error, so borrow one from a database that never connects.
+		return unavailableDB.QueryRowContext(ctx, query, args...)
+	}
+	return row
+}
+
+func (d *DB) do(ctx context.Context, op func() error) error {
+	for attempt := 1; ; attempt++ {
+		if !d.breaker.allow() {
+			return ErrUnavailable
+		}
+
+		err := op()
+		d.breaker.record(err)
+		if err == nil || attempt == maxAttempts || !Retryable(err) {
+			return err
+		}
+
+		retries.Add(ctx, 1)
+		backoff := baseBackoff << (attempt - 1)
+		backoff += rand.N(backoff)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// Retryable reports whether running the statement again is safe and may
+// succeed: serialization failures and deadlocks, which roll the statement
+// back, and connections that failed before the statement was sent. A
+// connection lost mid-statement is not retryable, because the server may
+// already have applied a write. Constraint violations and other query errors
+// are not retryable either.
+func Retryable(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "40001", "40P01":
+			return true
+		}
+	}
+	return notSent(err)
+}
+
+// notSent reports whether err means the statement never reached the
+// database: the connection was refused or rejected, or database/sql reported
+// the pooled connection bad before using it.
+func notSent(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "57P03", "53300", "08001", "08004":
+			return true
+		}
+		return false
+	}
+	return errors.Is(err, driver.ErrBadConn) || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// unreachable reports whether err means the database could not be reached,
+// as opposed to the database rejecting the statement.
+func unreachable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "57P01", "57P02", "57P03", "53300":
+			return true
+		}
+		return pqErr.Code.Class() == "08"
+	}
+
+	var netErr net.Error
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.As(err, &netErr)
+}
+
+// unavailableDB never connects, so its rows report ErrUnavailable.
+var unavailableDB = sql.OpenDB(unavailableConnector{})
+
+type unavailableConnector struct{}
+
+func (unavailableConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, ErrUnavailable
+}
+
+func (unavailableConnector) Driver() driver.Driver {
+	return unavailableDriver{}
+}
+
+type unavailableDriver struct{}
+
+func (unavailableDriver) Open(string) (driver.Conn, error) {
+	return nil, ErrUnavailable
+}
@@ -0,0 +1,141 @@
+package pgretry
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"serialization failure", &pq.Error{Code: "40001"}, true},
+		{"deadlock", &pq.Error{Code: "40P01"}, true},
+		{"starting up", &pq.Error{Code: "57P03"}, true},
+		{"too many connections", &pq.Error{Code: "53300"}, true},
+		{"connection refused", &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, true},
+		{"bad connection", driver.ErrBadConn, true},
+		{"admin shutdown", &pq.Error{Code: "57P01"}, false},
+		{"connection failure", &pq.Error{Code: "08006"}, false},
+		{"connection reset", fmt.Errorf("failed to get integration: %w", syscall.ECONNRESET), false},
+		{"connection closed", io.ErrUnexpectedEOF, false},
+		{"network timeout", &net.OpError{Op: "read", Err: os.ErrDeadlineExceeded}, false},
+		{"unique violation", &pq.Error{Code: "23505"}, false},
+		{"foreign key violation", &pq.Error{Code: "23503"}, false},
+		{"syntax error", &pq.Error{Code: "42601"}, false},
+		{"no rows", sql.ErrNoRows, false},
+		{"canceled", context.Canceled, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Retryable(tt.err); got != tt.want {
+				t.Errorf("Retryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDo(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("retries transient errors", func(t *testing.T) {
+		d := &DB{breaker: newBreaker()}
+		calls := 0
+		err := d.do(ctx, func() error {
+			calls++
+			if calls < 3 {
+				return &pq.Error{Code: "40001"}
+			}
+			return nil
+		})
+		if err != nil || calls != 3 {
+			t.Errorf("do() = %v after %d calls, want nil after 3", err, calls)
+		}
+	})
+
+	t.Run("does not retry constraint violations", func(t *testing.T) {
+		d := &DB{breaker: newBreaker()}
+		calls := 0
+		err := d.do(ctx, func() error {
+			calls++
+			return &pq.Error{Code: "23505"}
+		})
+		if err == nil || calls != 1 {
+			t.Errorf("do() = %v after %d calls, want the error after 1", err, calls)
+		}
+	})
+
+	t.Run("does not retry statements the server may have applied", func(t *testing.T) {
+		d := &DB{breaker: newBreaker()}
+		calls := 0
+		err := d.do(ctx, func() error {
+			calls++
+			return syscall.ECONNRESET
+		})
+		if err == nil || calls != 1 {
+			t.Errorf("do() = %v after %d calls, want the error after 1", err, calls)
+		}
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		d := &DB{breaker: newBreaker()}
+		calls := 0
+		err := d.do(ctx, func() error {
+			calls++
+			return &pq.Error{Code: "40001"}
+		})
+		if err == nil || calls != maxAttempts {
+			t.Errorf("do() = %v after %d calls, want the error after %d", err, calls, maxAttempts)
+		}
+	})
+
+	t.Run("fails fast while the breaker is open", func(t *testing.T) {
+		d := &DB{breaker: newBreaker()}
+		d.breaker.cooldown = 50 * time.Millisecond
+		down := func() error { return syscall.ECONNREFUSED }
+		for d.breaker.current() != breakerOpen {
+			_ = d.do(ctx, down)
+		}
+
+		calls := 0
+		err := d.do(ctx, func() error {
+			calls++
+			return nil
+		})
+		if !errors.Is(err, ErrUnavailable) || calls != 0 {
+			t.Fatalf("do() = %v after %d calls, want ErrUnavailable without calling", err, calls)
+		}
+
+		time.Sleep(d.breaker.cooldown)
+		if err := d.do(ctx, func() error { calls++; return nil }); err != nil || calls != 1 {
+			t.Fatalf("probe do() = %v after %d calls, want nil after 1", err, calls)
+		}
+		if d.breaker.current() != breakerClosed {
+			t.Errorf("breaker state = %d after a successful probe, want closed", d.breaker.current())
+		}
+	})
+}
+
+func TestQueryRowWhileOpen(t *testing.T) {
+	d := &DB{breaker: newBreaker()}
+	d.breaker.state = breakerOpen
+	d.breaker.openedAt = time.Now()
+
+	var n int
+	if err := d.QueryRowContext(context.Background(), "SELECT 1").Scan(&n); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("Scan() error = %v, want ErrUnavailable", err)
+	}
+}
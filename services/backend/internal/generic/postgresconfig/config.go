@@ -3,6 +3,8 @@ package postgresconfig
 import (
 	"database/sql"
 	"fmt"
+
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
 )
 
 type Config struct {
@@ -11,6 +13,8 @@ type Config struct {
 	DBName   string `mapstructure:"db_name"`
 	User     string `mapstructure:"user"`
 	Password string `mapstructure:"password"`
+	// ReadReplicaDSN optionally points read-only queries at a replica.
+	ReadReplicaDSN string `mapstructure:"read_replica_dsn"`
 }
 
 func (c Config) connStr() string {
@@ -18,10 +22,25 @@ func (c Config) connStr() string {
 }
 
 func (c Config) Init() (*sql.DB, error) {
-	db, err := sql.Open("postgres", c.connStr())
+	db, err := tracing.OpenDB("postgres", c.connStr())
 	if err != nil {
 		return nil, err
 	}
 
 	return db, nil
 }
+
+// InitReadReplica opens the read replica, or returns primary when none is
+// configured.
+func (c Config) InitReadReplica(primary *sql.DB) (*sql.DB, error) {
+	if c.ReadReplicaDSN == "" {
+		return primary, nil
+	}
+
+	db, err := tracing.OpenDB("postgres", c.ReadReplicaDSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open read replica: %w", err)
+	}
+
+	return db, nil
+}
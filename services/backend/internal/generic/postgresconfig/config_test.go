@@ -0,0 +1,39 @@
+package postgresconfig
+
+import (
+	"testing"
+
+	_ "github.com/lib/pq"
+)
+
+func TestInitReadReplica(t *testing.T) {
+	c := Config{Host: "primary.internal", Port: 5432, DBName: "infragpt", User: "infragpt", Password: "x"}
+	primary, err := c.Init()
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	defer primary.Close()
+
+	t.Run("no replica", func(t *testing.T) {
+		db, err := c.InitReadReplica(primary)
+		if err != nil {
+			t.Fatalf("InitReadReplica() error = %v", err)
+		}
+		if db != primary {
+			t.Error("InitReadReplica() opened a database, want the primary without read_replica_dsn")
+		}
+	})
+
+	t.Run("replica", func(t *testing.T) {
+		c := c
+		c.ReadReplicaDSN = "host=replica.internal port=5432 user=infragpt password=x dbname=infragpt sslmode=disable"
+		db, err := c.InitReadReplica(primary)
+		if err != nil {
+			t.Fatalf("InitReadReplica() error = %v", err)
+		}
+		defer db.Close()
+		if db == primary {
+			t.Error("InitReadReplica() returned the primary, want the replica")
+		}
+	})
+}
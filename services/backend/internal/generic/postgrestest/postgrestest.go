@@ -0,0 +1,220 @@
+// Package postgrestest provides a disposable Postgres database for repository tests.
+//
+// Tests use POSTGRES_URL when it is set; otherwise a container is started with
+// Docker. Tests are skipped when neither is available.
+package postgrestest
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+)
+
+const (
+	urlEnv            = "POSTGRES_URL"
+	postgresImage     = "postgres"
+	postgresTag       = "16-alpine"
+	containerLifetime = 10 * time.Minute
+)
+
+var (
+	once     sync.Once
+	shared   *sql.DB
+	setupErr error
+	cleanup  []func()
+)
+
+// Main runs the package's tests and tears down the database afterwards.
+// Call it from TestMain.
+func Main(m *testing.M) {
+	code := m.Run()
+	for i := len(cleanup) - 1; i >= 0; i-- {
+		cleanup[i]()
+	}
+	os.Exit(code)
+}
+
+// DB returns a database with the service schemas and migrations applied.
+// The database is shared by all tests of a package; use Truncate to isolate them.
+func DB(t testing.TB) *sql.DB {
+	t.Helper()
+
+	once.Do(func() {
+		shared, setupErr = setup()
+	})
+	if setupErr != nil {
+		t.Skipf("postgres is not available: %v", setupErr)
+	}
+
+	return shared
+}
+
+// Truncate empties the given tables, or every table in the test schema when none are given.
+func Truncate(t testing.TB, db *sql.DB, tables ...string) {
+	t.Helper()
+
+	if len(tables) == 0 {
+		rows, err := db.Query(`SELECT tablename FROM pg_tables WHERE schemaname = current_schema()`)
+		if err != nil {
+			t.Fatalf("failed to list tables: %v", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var table string
+			if err := rows.Scan(&table); err != nil {
+				t.Fatalf("failed to scan table name: %v", err)
+			}
+			tables = append(tables, table)
+		}
+		if err := rows.Err(); err != nil {
+			t.Fatalf("failed to list tables: %v", err)
+		}
+	}
+
+	if _, err := db.Exec(fmt.Sprintf("TRUNCATE %s CASCADE", strings.Join(tables, ", "))); err != nil {
+		t.Fatalf("failed to truncate tables: %v", err)
+	}
+}
+
+func setup() (*sql.DB, error) {
+	url := os.Getenv(urlEnv)
+	if url == "" {
+		var err error
+		url, err = startContainer()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Each test binary works in its own schema so that packages can run in
+	// parallel against the same server.
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	admin, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		admin.Close()
+		return nil, fmt.Errorf("failed to create schema: %w", err)
+	}
+	cleanup = append(cleanup, func() {
+		_, _ = admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		admin.Close()
+	})
+
+	db, err := sql.Open("postgres", withSearchPath(url, schema))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	cleanup = append(cleanup, func() { db.Close() })
+
+	if err := migrate(db); err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func startContainer() (string, error) {
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to docker: %w", err)
+	}
+	if err := pool.Client.Ping(); err != nil {
+		return "", fmt.Errorf("failed to connect to docker: %w", err)
+	}
+
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: postgresImage,
+		Tag:        postgresTag,
+		Env: []string{
+			"POSTGRES_USER=test",
+			"POSTGRES_PASSWORD=test",
+			"POSTGRES_DB=test",
+		},
+	}, func(config *docker.HostConfig) {
+		config.AutoRemove = true
+		config.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to start postgres container: %w", err)
+	}
+	cleanup = append(cleanup, func() { _ = pool.Purge(resource) })
+
+	// Guards against leaked containers when the test binary is killed.
+	if err := resource.Expire(uint(containerLifetime.Seconds())); err != nil {
+		return "", fmt.Errorf("failed to set container expiry: %w", err)
+	}
+
+	url := fmt.Sprintf("postgres://test:test@%s/test?sslmode=disable", resource.GetHostPort("5432/tcp"))
+
+	pool.MaxWait = time.Minute
+	if err := pool.Retry(func() error {
+		db, err := sql.Open("postgres", url)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+		return db.Ping()
+	}); err != nil {
+		return "", fmt.Errorf("postgres container did not become ready: %w", err)
+	}
+
+	return url, nil
+}
+
+// migrate applies the conversation schema, which predates the migrations
+// directory, followed by every migration in order.
+func migrate(db *sql.DB) error {
+	root := moduleRoot()
+
+	dirs := []string{
+		filepath.Join(root, "internal", "conversationsvc", "supporting", "postgres", "schema"),
+		filepath.Join(root, "migrations"),
+	}
+
+	for _, dir := range dirs {
+		files, err := filepath.Glob(filepath.Join(dir, "*.sql"))
+		if err != nil {
+			return fmt.Errorf("failed to list schema files: %w", err)
+		}
+		sort.Strings(files)
+
+		for _, file := range files {
+			contents, err := os.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", file, err)
+			}
+			if _, err := db.Exec(string(contents)); err != nil {
+				return fmt.Errorf("failed to apply %s: %w", filepath.Base(file), err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func moduleRoot() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "..")
+}
+
+func withSearchPath(url, schema string) string {
+	separator := "?"
+	if strings.Contains(url, "?") {
+		separator = "&"
+	}
+	return url + separator + "search_path=" + schema
+}
@@ -0,0 +1,118 @@
+// Package preflight checks that the backend can reach the services it depends
+// on, both before it starts serving and afterwards for readiness probes.
+package preflight
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTimeout = 10 * time.Second
+	// defaultCacheTTL keeps readiness probes from calling Slack and GitHub on
+	// every poll, which would eat into their rate limits.
+	defaultCacheTTL = time.Minute
+)
+
+// Check verifies one dependency. Run returns an error that says what to fix.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+type Result struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+}
+
+type Config struct {
+	TimeoutSeconds int `mapstructure:"timeout_seconds"`
+	CacheSeconds   int `mapstructure:"cache_seconds"`
+}
+
+func (c Config) New(checks []Check) *Checker {
+	timeout := time.Duration(c.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	cacheTTL := time.Duration(c.CacheSeconds) * time.Second
+	if cacheTTL <= 0 {
+		cacheTTL = defaultCacheTTL
+	}
+	return &Checker{checks: checks, timeout: timeout, cacheTTL: cacheTTL, now: time.Now}
+}
+
+type Checker struct {
+	checks   []Check
+	timeout  time.Duration
+	cacheTTL time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	results   []Result
+	checkedAt time.Time
+}
+
+// Run runs every check concurrently, each with its own timeout, and returns
+// the results in the order the checks were given.
+func (c *Checker) Run(ctx context.Context) []Result {
+	results := make([]Result, len(c.checks))
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	c.results, c.checkedAt = results, c.now()
+	c.mu.Unlock()
+	return results
+}
+
+func (c *Checker) run(ctx context.Context, check Check) (result Result) {
+	result.Name = check.Name
+	defer func() {
+		if r := recover(); r != nil {
+			result.Passed, result.Message = false, fmt.Sprintf("check panicked: %v", r)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	if err := check.Run(ctx); err != nil {
+		result.Message = err.Error()
+		return result
+	}
+	result.Passed = true
+	return result
+}
+
+// Cached returns the results of the last run when they are recent enough,
+// and runs the checks again otherwise.
+func (c *Checker) Cached(ctx context.Context) []Result {
+	c.mu.Lock()
+	results, checkedAt := c.results, c.checkedAt
+	c.mu.Unlock()
+	if results != nil && c.now().Sub(checkedAt) < c.cacheTTL {
+		return results
+	}
+	return c.Run(ctx)
+}
+
+// Failed returns the results of the checks that did not pass.
+func Failed(results []Result) []Result {
+	var failed []Result
+	for _, r := range results {
+		if !r.Passed {
+			failed = append(failed, r)
+		}
+	}
+	return failed
+}
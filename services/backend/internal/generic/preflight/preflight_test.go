@@ -0,0 +1,53 @@
+package preflight
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestChecker(t *testing.T) {
+	runs := 0
+	checker := Config{TimeoutSeconds: 1, CacheSeconds: 60}.New([]Check{
+		{Name: "database", Run: func(ctx context.Context) error {
+			runs++
+			return nil
+		}},
+		{Name: "slack", Run: func(ctx context.Context) error {
+			return errors.New("app_token is invalid")
+		}},
+		{Name: "agent", Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+	})
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	checker.now = func() time.Time { return now }
+
+	results := checker.Run(context.Background())
+	want := []Result{
+		{Name: "database", Passed: true},
+		{Name: "slack", Message: "app_token is invalid"},
+		{Name: "agent", Message: context.DeadlineExceeded.Error()},
+	}
+	for i, r := range results {
+		if r != want[i] {
+			t.Errorf("Run()[%d] = %+v, want %+v", i, r, want[i])
+		}
+	}
+	if failed := Failed(results); len(failed) != 2 {
+		t.Errorf("Failed() returned %d results, want 2", len(failed))
+	}
+
+	now = now.Add(30 * time.Second)
+	checker.Cached(context.Background())
+	if runs != 1 {
+		t.Errorf("Cached() ran the checks again within the cache TTL")
+	}
+	now = now.Add(time.Minute)
+	checker.Cached(context.Background())
+	if runs != 2 {
+		t.Errorf("Cached() did not run the checks after the cache TTL")
+	}
+}
@@ -0,0 +1,41 @@
+package recovery
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+)
+
+// Middleware recovers panics raised by h, reports them and responds with a
+// 500 JSON error envelope carrying the request ID.
+func Middleware(component string) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+
+				httpError := httperrors.Classify(httperrors.Internal())
+				httpError.RequestID = httperrors.RequestID(r)
+
+				report(r.Context(), component, recovered,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"request_id", httpError.RequestID,
+				)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set(httperrors.RequestIDHeader, httpError.RequestID)
+				w.WriteHeader(httpError.HttpStatus)
+				_ = json.NewEncoder(w).Encode(httpError)
+			}()
+			h.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package recovery
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+)
+
+func TestMiddleware(t *testing.T) {
+	h := Middleware("test")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(httperrors.RequestIDHeader, "req-123")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+
+	var envelope httperrors.Error
+	if err := json.Unmarshal(rec.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+	if envelope.Code != httperrors.CodeInternal {
+		t.Errorf("code = %q, want %q", envelope.Code, httperrors.CodeInternal)
+	}
+	if envelope.RequestID != "req-123" {
+		t.Errorf("request_id = %q, want req-123", envelope.RequestID)
+	}
+}
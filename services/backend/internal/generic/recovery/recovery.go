@@ -0,0 +1,75 @@
+package recovery
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const instrumentationName = "github.com/73ai/infragpt/services/backend"
+
+type Config struct {
+	SentryDSN   string `mapstructure:"sentry_dsn"`
+	Environment string `mapstructure:"environment"`
+}
+
+var (
+	sentryEnabled bool
+	panicCounter  metric.Int64Counter
+)
+
+func init() {
+	panicCounter, _ = otel.Meter(instrumentationName).Int64Counter(
+		"backend.panics",
+		metric.WithDescription("Number of panics recovered while serving requests"),
+	)
+}
+
+// New configures panic reporting. Sentry is only used when a DSN is configured;
+// flush waits for buffered reports to be delivered.
+func (c Config) New() (flush func(), err error) {
+	if c.SentryDSN == "" {
+		return func() {}, nil
+	}
+
+	if err := sentry.Init(sentry.ClientOptions{
+		Dsn:         c.SentryDSN,
+		Environment: c.Environment,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to initialize sentry: %w", err)
+	}
+	sentryEnabled = true
+
+	return func() { sentry.Flush(2 * time.Second) }, nil
+}
+
+// report logs a recovered panic with its stack trace, counts it and forwards
+// it to Sentry when configured.
+func report(ctx context.Context, component string, recovered any, attrs ...any) {
+	stack := string(debug.Stack())
+
+	slog.ErrorContext(ctx, "recovered from panic",
+		append([]any{"component", component, "panic", fmt.Sprint(recovered), "stack", stack}, attrs...)...)
+
+	if panicCounter != nil {
+		panicCounter.Add(ctx, 1, metric.WithAttributes(attribute.String("component", component)))
+	}
+
+	if sentryEnabled {
+		hub := sentry.CurrentHub().Clone()
+		hub.ConfigureScope(func(scope *sentry.Scope) {
+			scope.SetTag("component", component)
+			for i := 0; i+1 < len(attrs); i += 2 {
+				scope.SetExtra(fmt.Sprint(attrs[i]), attrs[i+1])
+			}
+		})
+		hub.RecoverWithContext(ctx, recovered)
+	}
+}
@@ -0,0 +1,231 @@
+// Package redact strips credentials from text before it leaves the backend
+// for the agent or Slack.
+package redact
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const instrumentationName = "github.com/73ai/infragpt/services/backend/internal/generic/redact"
+
+var redactionsCounter metric.Int64Counter
+
+func init() {
+	redactionsCounter, _ = otel.Meter(instrumentationName).Int64Counter(
+		"redact.redactions",
+		metric.WithDescription("Number of secrets replaced with placeholders, by type"),
+	)
+}
+
+// Pattern is a custom detector. Only the first capture group is replaced
+// when the expression has one, otherwise the whole match is.
+type Pattern struct {
+	Name  string `mapstructure:"name"`
+	Regex string `mapstructure:"regex"`
+}
+
+type Config struct {
+	Disabled bool `mapstructure:"disabled"`
+	// OrganizationPatterns adds detectors for a single organization, keyed by
+	// organization ID.
+	OrganizationPatterns map[string][]Pattern `mapstructure:"organization_patterns"`
+}
+
+func (c Config) New() (*Redactor, error) {
+	r := &Redactor{
+		disabled:      c.Disabled,
+		detectors:     builtinDetectors,
+		organizations: make(map[uuid.UUID][]detector),
+	}
+	for id, patterns := range c.OrganizationPatterns {
+		organizationID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid organization ID %q in redaction patterns: %w", id, err)
+		}
+		for _, p := range patterns {
+			if p.Name == "" {
+				return nil, fmt.Errorf("redaction pattern for organization %s has no name", id)
+			}
+			re, err := regexp.Compile(p.Regex)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compile redaction pattern %q: %w", p.Name, err)
+			}
+			r.organizations[organizationID] = append(r.organizations[organizationID], detector{name: p.Name, re: re})
+		}
+	}
+	return r, nil
+}
+
+// Redactor replaces secrets with typed placeholders such as
+// "[REDACTED:aws_access_key]". A nil Redactor redacts nothing.
+type Redactor struct {
+	disabled      bool
+	detectors     []detector
+	organizations map[uuid.UUID][]detector
+}
+
+// Redactions counts the secrets replaced in a text, by type.
+type Redactions map[string]int
+
+func (r Redactions) Total() int {
+	total := 0
+	for _, n := range r {
+		total += n
+	}
+	return total
+}
+
+// Redact returns text with the built-in detectors and the organization's
+// custom patterns applied. organizationID may be uuid.Nil.
+func (r *Redactor) Redact(ctx context.Context, organizationID uuid.UUID, text string) (string, Redactions) {
+	redactions := Redactions{}
+	if r == nil || r.disabled || text == "" {
+		return text, redactions
+	}
+
+	for _, d := range r.organizations[organizationID] {
+		text = d.apply(text, redactions)
+	}
+	for _, d := range r.detectors {
+		text = d.apply(text, redactions)
+	}
+
+	for name, n := range redactions {
+		redactionsCounter.Add(ctx, int64(n), metric.WithAttributes(attribute.String("type", name)))
+	}
+	return text, redactions
+}
+
+// SensitiveFieldNames are the field names whose values are masked in logs and
+// in the arguments of commands the agent runs.
+var SensitiveFieldNames = []string{"password", "token", "secret", "key", "credential", "auth"}
+
+// RedactArguments redacts each command argument like Redact does and also
+// replaces the value of any flag or assignment whose name contains one of
+// SensitiveFieldNames, as in "--password=hunter2", "--token hunter2" or
+// "API_KEY=hunter2", whatever the value looks like.
+func (r *Redactor) RedactArguments(ctx context.Context, organizationID uuid.UUID, args []string) ([]string, Redactions) {
+	redactions := Redactions{}
+	if r == nil || r.disabled || len(args) == 0 {
+		return args, redactions
+	}
+
+	redacted := make([]string, len(args))
+	byName := Redactions{}
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if name, value, ok := strings.Cut(arg, "="); ok && value != "" && !strings.ContainsAny(name, " \t") {
+			if field := sensitiveField(name); field != "" {
+				redacted[i] = name + "=[REDACTED:" + field + "]"
+				byName[field]++
+				continue
+			}
+		}
+		if field := sensitiveField(arg); field != "" && strings.HasPrefix(arg, "-") && !strings.Contains(arg, "=") &&
+			i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			redacted[i] = arg
+			redacted[i+1] = "[REDACTED:" + field + "]"
+			byName[field]++
+			i++
+			continue
+		}
+
+		var found Redactions
+		redacted[i], found = r.Redact(ctx, organizationID, arg)
+		for name, n := range found {
+			redactions[name] += n
+		}
+	}
+	for field, n := range byName {
+		redactions[field] += n
+		redactionsCounter.Add(ctx, int64(n), metric.WithAttributes(attribute.String("type", field)))
+	}
+	return redacted, redactions
+}
+
+// sensitiveField returns the first of SensitiveFieldNames contained in a flag
+// or variable name, or "" if there is none.
+func sensitiveField(name string) string {
+	name = strings.ToLower(strings.TrimLeft(name, "-"))
+	for _, field := range SensitiveFieldNames {
+		if strings.Contains(name, field) {
+			return field
+		}
+	}
+	return ""
+}
+
+type detector struct {
+	name string
+	re   *regexp.Regexp
+	// minEntropy skips matches whose secret looks like ordinary text, in bits
+	// per character. Zero accepts every match.
+	minEntropy float64
+}
+
+func (d detector) apply(text string, redactions Redactions) string {
+	matches := d.re.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+
+	placeholder := "[REDACTED:" + d.name + "]"
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		start, end := m[0], m[1]
+		if len(m) >= 4 && m[2] >= 0 {
+			start, end = m[2], m[3]
+		}
+		secret := text[start:end]
+		if secret == "" || strings.HasPrefix(secret, "[REDACTED:") || entropy(secret) < d.minEntropy {
+			continue
+		}
+		b.WriteString(text[last:start])
+		b.WriteString(placeholder)
+		last = end
+		redactions[d.name]++
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// entropy returns the Shannon entropy of s in bits per character.
+func entropy(s string) float64 {
+	counts := make(map[rune]int)
+	n := 0
+	for _, c := range s {
+		counts[c]++
+		n++
+	}
+	var h float64
+	for _, count := range counts {
+		p := float64(count) / float64(n)
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+// builtinDetectors run in order, so the specific formats claim their matches
+// before the generic assignment and bearer detectors see them.
+var builtinDetectors = []detector{
+	{name: "private_key", re: regexp.MustCompile(`-----BEGIN [A-Z0-9 ]*PRIVATE KEY( BLOCK)?-----[\s\S]*?-----END [A-Z0-9 ]*PRIVATE KEY( BLOCK)?-----`)},
+	{name: "aws_access_key", re: regexp.MustCompile(`\b(?:AKIA|ASIA|AGPA|AIDA|AROA|ANPA|ANVA|AIPA)[0-9A-Z]{16}\b`)},
+	{name: "aws_secret_key", re: regexp.MustCompile(`(?i)aws_?secret_?(?:access_?)?key["']?\s*[:=]\s*["']?([A-Za-z0-9/+=]{40})\b`), minEntropy: 3.5},
+	{name: "gcp_api_key", re: regexp.MustCompile(`\bAIza[0-9A-Za-z_\-]{35}\b`)},
+	{name: "github_token", re: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,255}|github_pat_[A-Za-z0-9_]{22,255})\b`)},
+	{name: "slack_token", re: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{name: "jwt", re: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{name: "connection_string", re: regexp.MustCompile(`\b[a-zA-Z][a-zA-Z0-9+.-]*://[^\s:/@\[\]]+:([^\s@/\[\]]+)@`)},
+	{name: "bearer_token", re: regexp.MustCompile(`(?i)\bbearer\s+([A-Za-z0-9\-._~+/]{20,}=*)`), minEntropy: 3},
+	{name: "secret", re: regexp.MustCompile(`(?i)(?:secret|password|passwd|token|api_?key|access_?key)["']?\s*[:=]\s*["']?([^\s"',;]{16,})`), minEntropy: 3.5},
+}
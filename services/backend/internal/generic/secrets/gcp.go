@@ -0,0 +1,47 @@
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"sync"
+
+	secretmanager "google.golang.org/api/secretmanager/v1"
+)
+
+// gcpProvider reads secrets from Google Cloud Secret Manager with the
+// application default credentials. References name a secret version, or the
+// latest version when it is left out:
+// secret://gcp/projects/<project>/secrets/<secret>[/versions/<version>].
+type gcpProvider struct {
+	once    sync.Once
+	service *secretmanager.Service
+	err     error
+}
+
+func (p *gcpProvider) GetSecret(ctx context.Context, name string) ([]byte, error) {
+	p.once.Do(func() {
+		p.service, p.err = secretmanager.NewService(context.WithoutCancel(ctx))
+	})
+	if p.err != nil {
+		return nil, fmt.Errorf("failed to create secret manager client: %w", p.err)
+	}
+
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+	version, err := p.service.Projects.Secrets.Versions.Access(name).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to access secret version: %w", err)
+	}
+	if version.Payload == nil {
+		return nil, fmt.Errorf("secret version %s has no payload", name)
+	}
+
+	value, err := base64.StdEncoding.DecodeString(version.Payload.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode secret payload: %w", err)
+	}
+	return value, nil
+}
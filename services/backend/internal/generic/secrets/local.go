@@ -0,0 +1,30 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// envProvider reads secrets from environment variables.
+type envProvider struct{}
+
+func (envProvider) GetSecret(ctx context.Context, name string) ([]byte, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return nil, fmt.Errorf("environment variable %s is not set", name)
+	}
+	return []byte(value), nil
+}
+
+// fileProvider reads secrets from files, such as mounted Kubernetes or Docker
+// secrets. Absolute paths start with a second slash: secret://file//run/x.
+type fileProvider struct{}
+
+func (fileProvider) GetSecret(ctx context.Context, path string) ([]byte, error) {
+	value, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret file: %w", err)
+	}
+	return value, nil
+}
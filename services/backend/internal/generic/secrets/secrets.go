@@ -0,0 +1,86 @@
+// Package secrets resolves secret:// references in config values through
+// pluggable secret backends, so secrets can live in a secret manager rather
+// than in config.yaml or the environment.
+//
+// A reference names its backend and the secret within it:
+//
+//	secret://env/GITHUB_PRIVATE_KEY
+//	secret://file//run/secrets/database_password
+//	secret://gcp/projects/acme/secrets/github-webhook-secret/versions/latest
+//
+// The backend resolves references once at startup, so a rotated secret is
+// picked up on the next restart.
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const scheme = "secret://"
+
+// SecretProvider reads secrets from one backend. ref is the part of the
+// reference after the backend name.
+type SecretProvider interface {
+	GetSecret(ctx context.Context, ref string) ([]byte, error)
+}
+
+type Config struct {
+	// Providers adds or replaces backends by name, e.g. a Vault provider.
+	Providers map[string]SecretProvider `mapstructure:"-"`
+}
+
+func (c Config) New() *Resolver {
+	providers := map[string]SecretProvider{
+		"env":  envProvider{},
+		"file": fileProvider{},
+		"gcp":  &gcpProvider{},
+	}
+	for name, provider := range c.Providers {
+		providers[name] = provider
+	}
+
+	return &Resolver{providers: providers}
+}
+
+// Resolver reads secret:// references from their backend on every call.
+type Resolver struct {
+	providers map[string]SecretProvider
+}
+
+// IsRef reports whether value is a secret:// reference.
+func IsRef(value string) bool {
+	return strings.HasPrefix(value, scheme)
+}
+
+// GetSecret returns the secret a secret:// reference points to.
+func (r *Resolver) GetSecret(ctx context.Context, ref string) ([]byte, error) {
+	backend, path, ok := strings.Cut(strings.TrimPrefix(ref, scheme), "/")
+	if !IsRef(ref) || !ok || path == "" {
+		return nil, fmt.Errorf("invalid secret reference %q: want secret://<backend>/<name>", ref)
+	}
+	provider, ok := r.providers[backend]
+	if !ok {
+		return nil, fmt.Errorf("invalid secret reference %q: unknown backend %q", ref, backend)
+	}
+
+	value, err := provider.GetSecret(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secret %s: %w", ref, err)
+	}
+	return value, nil
+}
+
+// Resolve returns value unchanged unless it is a secret:// reference, in which
+// case it returns the secret without trailing newlines.
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	if !IsRef(value) {
+		return value, nil
+	}
+	secret, err := r.GetSecret(ctx, value)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(secret), "\r\n"), nil
+}
@@ -0,0 +1,51 @@
+package secrets
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type vaultProvider struct{}
+
+func (vaultProvider) GetSecret(ctx context.Context, ref string) ([]byte, error) {
+	return []byte("value-of-" + ref), nil
+}
+
+func TestResolve(t *testing.T) {
+	ctx := context.Background()
+
+	t.Setenv("SECRETS_TEST_TOKEN", "from-env")
+	path := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(path, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	resolver := Config{Providers: map[string]SecretProvider{"vault": vaultProvider{}}}.New()
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "plain-value", want: "plain-value"},
+		{value: "secret://env/SECRETS_TEST_TOKEN", want: "from-env"},
+		{value: "secret://file/" + path, want: "from-file"},
+		{value: "secret://vault/database/password", want: "value-of-database/password"},
+	}
+	for _, tt := range tests {
+		got, err := resolver.Resolve(ctx, tt.value)
+		if err != nil {
+			t.Errorf("Resolve(%q) error = %v", tt.value, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Resolve(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+
+	for _, value := range []string{"secret://aws/db", "secret://env", "secret://env/SECRETS_TEST_UNSET"} {
+		if _, err := resolver.Resolve(ctx, value); err == nil {
+			t.Errorf("Resolve(%q) error = nil, want an error", value)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package tracing
+
+import (
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+)
+
+// Middleware creates a server span for every request and extracts the incoming trace context.
+func Middleware(operation string) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return otelhttp.NewHandler(h, operation, otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method + " " + r.URL.Path
+		}))
+	}
+}
+
+// HTTPClient returns a client whose requests are recorded as client spans.
+func HTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: otelhttp.NewTransport(http.DefaultTransport),
+	}
+}
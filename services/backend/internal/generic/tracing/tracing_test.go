@@ -0,0 +1,95 @@
+package tracing
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/grpc"
+)
+
+// collector is an OTLP metrics endpoint that remembers the names of the
+// metrics it receives.
+type collector struct {
+	collectormetrics.UnimplementedMetricsServiceServer
+
+	mu    sync.Mutex
+	names map[string]bool
+}
+
+func (c *collector) Export(_ context.Context, req *collectormetrics.ExportMetricsServiceRequest) (*collectormetrics.ExportMetricsServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, rm := range req.GetResourceMetrics() {
+		for _, sm := range rm.GetScopeMetrics() {
+			for _, m := range sm.GetMetrics() {
+				c.names[m.GetName()] = true
+			}
+		}
+	}
+	return &collectormetrics.ExportMetricsServiceResponse{}, nil
+}
+
+func (c *collector) received(name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.names[name]
+}
+
+func TestNewExportsMetrics(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	c := &collector{names: make(map[string]bool)}
+	server := grpc.NewServer()
+	collectormetrics.RegisterMetricsServiceServer(server, c)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	// Packages create their counters in init, before the provider is installed.
+	counter, err := otel.Meter(instrumentationName).Int64Counter("tracing.test.recorded")
+	if err != nil {
+		t.Fatalf("create counter: %v", err)
+	}
+
+	ctx := context.Background()
+	shutdown, err := Config{Endpoint: lis.Addr().String(), Insecure: true}.New(ctx)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	counter.Add(ctx, 1)
+	if err := shutdown(ctx); err != nil {
+		t.Fatalf("shutdown() error = %v", err)
+	}
+
+	if !c.received("tracing.test.recorded") {
+		t.Error("recorded metric was not exported")
+	}
+}
+
+func TestDetach(t *testing.T) {
+	span := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx, cancel := context.WithCancel(trace.ContextWithSpanContext(context.Background(), span))
+	detached := Detach(ctx)
+	cancel()
+
+	if err := detached.Err(); err != nil {
+		t.Errorf("detached context error = %v, want it to outlive its parent", err)
+	}
+	if got := trace.SpanContextFromContext(detached); !got.Equal(span) {
+		t.Errorf("detached span context = %v, want %v", got, span)
+	}
+	if trace.SpanFromContext(detached).IsRecording() {
+		t.Error("detached context carries the parent's recording span")
+	}
+}
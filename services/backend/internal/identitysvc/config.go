@@ -1,8 +1,11 @@
 package identitysvc
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
 
+	"github.com/73ai/infragpt/services/backend/internal/identitysvc/domaintest"
 	"github.com/73ai/infragpt/services/backend/internal/identitysvc/supporting/clerk"
 
 	"github.com/73ai/infragpt/services/backend/internal/identitysvc/supporting/postgres"
@@ -25,3 +28,26 @@ func (c Config) New(db *sql.DB) *service {
 		authService:      c.Clerk.NewAuthService(),
 	}
 }
+
+// NewInMemory returns a service backed by in-memory repositories for local
+// development. Subscribe applies events, such as backend.UserCreatedEvent,
+// as if Clerk had delivered them, and returns once they are applied.
+func (c Config) NewInMemory(events ...any) *service {
+	return &service{
+		userRepo:         domaintest.NewUserRepository(),
+		organizationRepo: domaintest.NewOrganizationRepository(),
+		memberRepo:       domaintest.NewMemberRepository(),
+		authService:      replayedEvents(events),
+	}
+}
+
+type replayedEvents []any
+
+func (e replayedEvents) Subscribe(ctx context.Context, handler func(ctx context.Context, event any) error) error {
+	for _, event := range e {
+		if err := handler(ctx, event); err != nil {
+			return fmt.Errorf("failed to apply %T: %w", event, err)
+		}
+	}
+	return nil
+}
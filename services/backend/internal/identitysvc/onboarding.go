@@ -0,0 +1,25 @@
+package identitysvc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/google/uuid"
+)
+
+var _ backend.OnboardingReporter = (*service)(nil)
+
+// CompletedOnboardingSteps reports teammates as invited once the organization
+// has a member besides the one who created it.
+func (s *service) CompletedOnboardingSteps(ctx context.Context, organizationID uuid.UUID) ([]backend.OnboardingStep, error) {
+	members, err := s.memberRepo.MembersByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization members: %w", err)
+	}
+
+	if len(members) > 1 {
+		return []backend.OnboardingStep{backend.OnboardingStepInviteTeammates}, nil
+	}
+	return nil, nil
+}
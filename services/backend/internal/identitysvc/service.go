@@ -3,6 +3,7 @@ package identitysvc
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/73ai/infragpt/services/backend"
 	"github.com/73ai/infragpt/services/backend/internal/identitysvc/domain"
@@ -220,3 +221,40 @@ func (s *service) Profile(ctx context.Context, query backend.ProfileQuery) (back
 		UserID:         user.ID,
 	}, nil
 }
+
+func (s *service) OrganizationUser(ctx context.Context, query backend.OrganizationUserQuery) (backend.User, error) {
+	if query.UserID == uuid.Nil && query.Email == "" {
+		return backend.User{}, backend.ErrUserNotFound
+	}
+
+	members, err := s.memberRepo.MembersByOrganizationID(ctx, query.OrganizationID)
+	if err != nil {
+		return backend.User{}, fmt.Errorf("failed to list organization members: %w", err)
+	}
+
+	for _, member := range members {
+		if query.UserID != uuid.Nil && member.UserID != query.UserID {
+			continue
+		}
+
+		user, err := s.userRepo.UserByClerkID(ctx, member.ClerkUserID)
+		if err != nil {
+			return backend.User{}, fmt.Errorf("user not found: %w", err)
+		}
+		if query.UserID == uuid.Nil && !strings.EqualFold(user.Email, query.Email) {
+			continue
+		}
+
+		return backend.User{
+			ID:          user.ID,
+			ClerkUserID: user.ClerkUserID,
+			Email:       user.Email,
+			FirstName:   user.FirstName,
+			LastName:    user.LastName,
+			CreatedAt:   user.CreatedAt,
+			UpdatedAt:   user.UpdatedAt,
+		}, nil
+	}
+
+	return backend.User{}, backend.ErrUserNotFound
+}
@@ -3,6 +3,7 @@ package clerk
 import (
 	"context"
 	"net/http"
+	"strings"
 
 	clerkapi "github.com/clerk/clerk-sdk-go/v2"
 	clerkhttp "github.com/clerk/clerk-sdk-go/v2/http"
@@ -38,3 +39,24 @@ func (c Config) NewAuthMiddleware() func(http.Handler) http.Handler {
 
 	return clerkhttp.WithHeaderAuthorization()
 }
+
+// sessionCookie is where Clerk's frontend keeps the session token on the
+// console's domain.
+const sessionCookie = "__session"
+
+// NewSessionAuthMiddleware authenticates pages opened in the browser, which
+// send the session cookie rather than an Authorization header. The backend
+// must be served on the console's domain for the cookie to reach it.
+func (c Config) NewSessionAuthMiddleware() func(http.Handler) http.Handler {
+	clerkapi.SetKey(c.SecretKey)
+
+	return clerkhttp.WithHeaderAuthorization(clerkhttp.AuthorizationJWTExtractor(func(r *http.Request) string {
+		if authorization := strings.TrimSpace(r.Header.Get("Authorization")); authorization != "" {
+			return strings.TrimPrefix(authorization, "Bearer ")
+		}
+		if cookie, err := r.Cookie(sessionCookie); err == nil {
+			return cookie.Value
+		}
+		return ""
+	}))
+}
@@ -1,6 +1,16 @@
 package clerk
 
-import "github.com/73ai/infragpt/services/backend/internal/identitysvc/domain"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/73ai/infragpt/services/backend/internal/identitysvc/domain"
+	clerkapi "github.com/clerk/clerk-sdk-go/v2"
+	"github.com/clerk/clerk-sdk-go/v2/jwks"
+)
 
 type Config struct {
 	Port          int    `mapstructure:"port"`
@@ -15,3 +25,24 @@ func (c Config) NewAuthService() domain.AuthService {
 		webhookSecret: c.WebhookSecret,
 	}
 }
+
+// Check validates secret_key by fetching the instance's JSON Web Key Set,
+// which the auth middleware needs to verify session tokens.
+func (c Config) Check(ctx context.Context) error {
+	if c.SecretKey == "" {
+		return errors.New("secret_key is empty")
+	}
+	if !strings.HasPrefix(c.SecretKey, "sk_") {
+		return errors.New("secret_key does not start with sk_; use the instance's secret key, not its publishable key")
+	}
+
+	client := jwks.NewClient(&clerkapi.ClientConfig{BackendConfig: clerkapi.BackendConfig{Key: &c.SecretKey}})
+	if _, err := client.Get(ctx, &jwks.GetParams{}); err != nil {
+		var apiErr *clerkapi.APIErrorResponse
+		if errors.As(err, &apiErr) && (apiErr.HTTPStatusCode == http.StatusUnauthorized || apiErr.HTTPStatusCode == http.StatusForbidden) {
+			return fmt.Errorf("Clerk rejected secret_key with status %d; copy the secret key from the API keys page of the instance's Clerk dashboard", apiErr.HTTPStatusCode)
+		}
+		return fmt.Errorf("failed to reach the Clerk API: %w", err)
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/73ai/infragpt/services/backend/internal/generic/recovery"
 	svix "github.com/svix/svix-webhooks/go"
 
 	"github.com/73ai/infragpt/services/backend"
@@ -69,7 +70,7 @@ func (c webhookServerConfig) startWebhookServer(ctx context.Context) error {
 	httpServer := &http.Server{
 		Addr:        fmt.Sprintf(":%d", c.port),
 		BaseContext: func(net.Listener) context.Context { return ctx },
-		Handler:     panicMiddleware(webhookValidationMiddleware(wh, h)),
+		Handler:     recovery.Middleware("clerk.webhook")(webhookValidationMiddleware(wh, h)),
 	}
 
 	return httpServer.ListenAndServe()
@@ -289,18 +290,6 @@ func ApiHandlerFunc[T any, R any](handler func(context.Context, T) (R, error)) f
 	}
 }
 
-func panicMiddleware(h http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		defer func() {
-			if r := recover(); r != nil {
-				slog.Error("clerk: panic while handling http request", "recover", r)
-				w.WriteHeader(http.StatusInternalServerError)
-			}
-		}()
-		h.ServeHTTP(w, r)
-	})
-}
-
 func webhookValidationMiddleware(webhook *svix.Webhook, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		body, err := io.ReadAll(r.Body)
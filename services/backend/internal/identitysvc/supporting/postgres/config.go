@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
 	"github.com/73ai/infragpt/services/backend/internal/generic/postgresconfig"
 	_ "github.com/lib/pq"
 )
@@ -17,6 +18,6 @@ func (c Config) New() (*IdentityDB, error) {
 
 	return &IdentityDB{
 		db:      db,
-		Querier: New(db),
+		Querier: New(pgretry.Wrap(db)),
 	}, nil
 }
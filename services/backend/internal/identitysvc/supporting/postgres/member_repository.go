@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
 	"github.com/73ai/infragpt/services/backend/internal/identitysvc/domain"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -15,7 +16,7 @@ type memberRepository struct {
 
 func NewMemberRepository(sqlDB *sql.DB) domain.MemberRepository {
 	return &memberRepository{
-		queries: New(sqlDB),
+		queries: New(pgretry.Wrap(sqlDB)),
 	}
 }
 
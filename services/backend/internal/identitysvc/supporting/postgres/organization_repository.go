@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
 	"github.com/73ai/infragpt/services/backend/internal/identitysvc/domain"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
@@ -19,7 +20,7 @@ type organizationRepository struct {
 func NewOrganizationRepository(sqlDB *sql.DB) domain.OrganizationRepository {
 	return &organizationRepository{
 		db:      sqlDB,
-		queries: New(sqlDB),
+		queries: New(pgretry.Wrap(sqlDB)),
 	}
 }
 
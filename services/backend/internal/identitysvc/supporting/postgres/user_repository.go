@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
 	"github.com/73ai/infragpt/services/backend/internal/identitysvc/domain"
 	"github.com/lib/pq"
 )
@@ -14,7 +15,7 @@ type userRepository struct {
 
 func NewUserRepository(sqlDB *sql.DB) domain.UserRepository {
 	return &userRepository{
-		queries: New(sqlDB),
+		queries: New(pgretry.Wrap(sqlDB)),
 	}
 }
 
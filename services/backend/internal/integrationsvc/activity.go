@@ -0,0 +1,176 @@
+package integrationsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultActivityPageSize = 50
+	maxActivityPageSize     = 200
+)
+
+// permissionMetadataPrefix marks the integration metadata where connectors,
+// such as GitHub on a new_permissions_accepted webhook, keep granted
+// permissions.
+const permissionMetadataPrefix = "permission_"
+
+func (s *service) IntegrationActivity(ctx context.Context, query backend.IntegrationActivityQuery) (backend.IntegrationActivityPage, error) {
+	_, err := s.Integration(ctx, backend.IntegrationQuery{
+		IntegrationID:  query.IntegrationID,
+		OrganizationID: query.OrganizationID,
+	})
+	// A revoked integration's history stays available to its organization,
+	// which the repository enforces by listing only that organization's entries.
+	deleted := errors.Is(err, backend.ErrIntegrationNotFound)
+	if err != nil && !deleted {
+		return backend.IntegrationActivityPage{}, err
+	}
+
+	if s.activityRepository == nil {
+		if deleted {
+			return backend.IntegrationActivityPage{}, err
+		}
+		return backend.IntegrationActivityPage{}, nil
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultActivityPageSize
+	}
+	query.Limit = min(query.Limit, maxActivityPageSize)
+	query.Offset = max(query.Offset, 0)
+
+	page, err := s.activityRepository.Activities(ctx, query)
+	if err != nil {
+		return backend.IntegrationActivityPage{}, fmt.Errorf("failed to list integration activity: %w", err)
+	}
+	if deleted && page.Total == 0 {
+		return backend.IntegrationActivityPage{}, backend.ErrIntegrationNotFound
+	}
+	return page, nil
+}
+
+func (s *service) recordActivity(ctx context.Context, integration backend.Integration, activityType backend.IntegrationActivityType, details map[string]string) {
+	domain.RecordActivity(ctx, s.activityRepository, backend.IntegrationActivity{
+		IntegrationID:  integration.ID,
+		OrganizationID: integration.OrganizationID,
+		Type:           activityType,
+		Details:        details,
+	})
+}
+
+// recordingIntegrationRepository adds status transitions made through any path,
+// including connectors handling webhooks, to the activity feed.
+type recordingIntegrationRepository struct {
+	domain.IntegrationRepository
+	recorder domain.ActivityRecorder
+}
+
+func (r recordingIntegrationRepository) Update(ctx context.Context, integration backend.Integration) error {
+	before, findErr := r.IntegrationRepository.FindByID(ctx, integration.ID)
+	if err := r.IntegrationRepository.Update(ctx, integration); err != nil {
+		return err
+	}
+	if findErr == nil {
+		r.recordStatusChange(ctx, before, integration.Status)
+	}
+	return nil
+}
+
+func (r recordingIntegrationRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status backend.IntegrationStatus) error {
+	before, findErr := r.IntegrationRepository.FindByID(ctx, id)
+	if err := r.IntegrationRepository.UpdateStatus(ctx, id, status); err != nil {
+		return err
+	}
+	if findErr == nil {
+		r.recordStatusChange(ctx, before, status)
+	}
+	return nil
+}
+
+func (r recordingIntegrationRepository) UpdateMetadata(ctx context.Context, id uuid.UUID, metadata map[string]string) error {
+	before, findErr := r.IntegrationRepository.FindByID(ctx, id)
+	if err := r.IntegrationRepository.UpdateMetadata(ctx, id, metadata); err != nil {
+		return err
+	}
+	if findErr == nil {
+		r.recordPermissionsChange(ctx, before, metadata)
+	}
+	return nil
+}
+
+// recordPermissionsChange records the connector permissions, kept in metadata
+// under permissionMetadataPrefix, that the update changed. Removed permissions
+// are recorded as "none".
+func (r recordingIntegrationRepository) recordPermissionsChange(ctx context.Context, before backend.Integration, metadata map[string]string) {
+	changed := make(map[string]string)
+	for key := range maps.Keys(before.Metadata) {
+		if _, kept := metadata[key]; !kept && strings.HasPrefix(key, permissionMetadataPrefix) {
+			changed[strings.TrimPrefix(key, permissionMetadataPrefix)] = "none"
+		}
+	}
+	for key, access := range metadata {
+		if strings.HasPrefix(key, permissionMetadataPrefix) && before.Metadata[key] != access {
+			changed[strings.TrimPrefix(key, permissionMetadataPrefix)] = access
+		}
+	}
+	if len(changed) == 0 {
+		return
+	}
+	domain.RecordActivity(ctx, r.recorder, backend.IntegrationActivity{
+		IntegrationID:  before.ID,
+		OrganizationID: before.OrganizationID,
+		Type:           backend.IntegrationActivityPermissionsChanged,
+		Details:        changed,
+	})
+}
+
+func (r recordingIntegrationRepository) recordStatusChange(ctx context.Context, before backend.Integration, status backend.IntegrationStatus) {
+	if before.Status == status {
+		return
+	}
+	domain.RecordActivity(ctx, r.recorder, backend.IntegrationActivity{
+		IntegrationID:  before.ID,
+		OrganizationID: before.OrganizationID,
+		Type:           backend.IntegrationActivityStatusChanged,
+		Details:        map[string]string{"from": string(before.Status), "to": string(status)},
+	})
+}
+
+// recordingCredentialRepository adds credential refreshes to the activity feed.
+type recordingCredentialRepository struct {
+	domain.CredentialRepository
+	integrations domain.IntegrationRepository
+	recorder     domain.ActivityRecorder
+}
+
+func (r recordingCredentialRepository) Update(ctx context.Context, cred domain.IntegrationCredential) error {
+	if err := r.CredentialRepository.Update(ctx, cred); err != nil {
+		return err
+	}
+
+	integration, err := r.integrations.FindByID(ctx, cred.IntegrationID)
+	if err != nil {
+		return nil
+	}
+
+	details := map[string]string{"credential_type": string(cred.CredentialType)}
+	if cred.ExpiresAt != nil {
+		details["expires_at"] = cred.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	domain.RecordActivity(ctx, r.recorder, backend.IntegrationActivity{
+		IntegrationID:  integration.ID,
+		OrganizationID: integration.OrganizationID,
+		Type:           backend.IntegrationActivityCredentialRefreshed,
+		Details:        details,
+	})
+	return nil
+}
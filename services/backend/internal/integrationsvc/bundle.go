@@ -0,0 +1,303 @@
+package integrationsvc
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/github"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	bundleVersion          = 1
+	bundleKDF              = "pbkdf2-sha256"
+	bundleKDFIterations    = 600_000
+	minBundlePassphraseLen = 12
+)
+
+// bundleEnvelope is the on-disk format: an AES-256-GCM encrypted bundle with
+// the key derived from the operator's passphrase.
+type bundleEnvelope struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+type bundle struct {
+	Version        int                 `json:"version"`
+	OrganizationID uuid.UUID           `json:"organization_id"`
+	ExportedAt     time.Time           `json:"exported_at"`
+	Integrations   []bundleIntegration `json:"integrations"`
+}
+
+type bundleIntegration struct {
+	UserID                  uuid.UUID                 `json:"user_id"`
+	ConnectorType           backend.ConnectorType     `json:"connector_type"`
+	Status                  backend.IntegrationStatus `json:"status"`
+	BotID                   string                    `json:"bot_id,omitempty"`
+	ConnectorUserID         string                    `json:"connector_user_id,omitempty"`
+	ConnectorOrganizationID string                    `json:"connector_organization_id,omitempty"`
+	Metadata                map[string]string         `json:"metadata,omitempty"`
+	Credential              *bundleCredential         `json:"credential,omitempty"`
+}
+
+type bundleCredential struct {
+	Type      backend.CredentialType `json:"type"`
+	Data      map[string]string      `json:"data"`
+	ExpiresAt *time.Time             `json:"expires_at,omitempty"`
+}
+
+func (s *service) ExportIntegrations(ctx context.Context, query backend.ExportIntegrationsQuery) ([]byte, error) {
+	if len(query.Passphrase) < minBundlePassphraseLen {
+		return nil, fmt.Errorf("%w: must be at least %d characters", domain.ErrInvalidBundlePassphrase, minBundlePassphraseLen)
+	}
+
+	integrations, err := s.integrationRepository.FindByOrganization(ctx, query.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find integrations: %w", err)
+	}
+
+	b := bundle{
+		Version:        bundleVersion,
+		OrganizationID: query.OrganizationID,
+		ExportedAt:     time.Now(),
+	}
+	for _, integration := range integrations {
+		if integration.Status == backend.IntegrationStatusDeleted {
+			continue
+		}
+
+		entry := bundleIntegration{
+			UserID:                  integration.UserID,
+			ConnectorType:           integration.ConnectorType,
+			Status:                  integration.Status,
+			BotID:                   integration.BotID,
+			ConnectorUserID:         integration.ConnectorUserID,
+			ConnectorOrganizationID: integration.ConnectorOrganizationID,
+			Metadata:                integration.Metadata,
+		}
+
+		credential, err := s.credentialRepository.FindByIntegration(withCredentialAccessReason(ctx, "export"), integration.ID)
+		if err != nil {
+			slog.Warn("exporting integration without credentials", "integration_id", integration.ID, "connector_type", integration.ConnectorType, "error", err)
+		} else {
+			entry.Credential = &bundleCredential{
+				Type:      credential.CredentialType,
+				Data:      credential.Data,
+				ExpiresAt: credential.ExpiresAt,
+			}
+		}
+
+		b.Integrations = append(b.Integrations, entry)
+	}
+
+	plaintext, err := json.Marshal(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bundle: %w", err)
+	}
+
+	envelope, err := sealBundle(plaintext, query.Passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt bundle: %w", err)
+	}
+
+	return json.Marshal(envelope)
+}
+
+func (s *service) ImportIntegrations(ctx context.Context, cmd backend.ImportIntegrationsCommand) (backend.ImportIntegrationsResult, error) {
+	var envelope bundleEnvelope
+	if err := json.Unmarshal(cmd.Bundle, &envelope); err != nil {
+		return backend.ImportIntegrationsResult{}, fmt.Errorf("%w: %v", domain.ErrInvalidBundle, err)
+	}
+
+	plaintext, err := openBundle(envelope, cmd.Passphrase)
+	if err != nil {
+		return backend.ImportIntegrationsResult{}, err
+	}
+
+	var b bundle
+	if err := json.Unmarshal(plaintext, &b); err != nil {
+		return backend.ImportIntegrationsResult{}, fmt.Errorf("%w: %v", domain.ErrInvalidBundle, err)
+	}
+	if b.Version != bundleVersion {
+		return backend.ImportIntegrationsResult{}, fmt.Errorf("%w: unsupported version %d", domain.ErrInvalidBundle, b.Version)
+	}
+
+	var result backend.ImportIntegrationsResult
+	for _, entry := range b.Integrations {
+		integration := backend.Integration{
+			OrganizationID:          cmd.OrganizationID,
+			UserID:                  entry.UserID,
+			ConnectorType:           entry.ConnectorType,
+			Status:                  entry.Status,
+			BotID:                   entry.BotID,
+			ConnectorUserID:         entry.ConnectorUserID,
+			ConnectorOrganizationID: entry.ConnectorOrganizationID,
+			Metadata:                entry.Metadata,
+		}
+
+		connector, exists := s.connectors[entry.ConnectorType]
+		if !exists {
+			slog.Warn("skipping integration for unsupported connector", "connector_type", entry.ConnectorType)
+			result.Skipped = append(result.Skipped, integration)
+			continue
+		}
+
+		existing, err := s.integrationRepository.FindByOrganizationAndType(ctx, cmd.OrganizationID, entry.ConnectorType)
+		if err != nil {
+			return result, fmt.Errorf("failed to check existing integrations: %w", err)
+		}
+		if len(existing) > 0 {
+			result.Skipped = append(result.Skipped, integration)
+			continue
+		}
+
+		restored, ok, err := s.importIntegration(ctx, connector, integration, entry.Credential)
+		if err != nil {
+			return result, fmt.Errorf("failed to import %s integration: %w", entry.ConnectorType, err)
+		}
+		if ok {
+			result.Restored = append(result.Restored, restored)
+		} else {
+			result.NeedsReauthorization = append(result.NeedsReauthorization, restored)
+		}
+	}
+
+	return result, nil
+}
+
+// importIntegration stores one bundle entry. ok is false when its credentials
+// no longer work, in which case the integration is stored as needing
+// reauthorization.
+func (s *service) importIntegration(ctx context.Context, connector domain.Connector, integration backend.Integration, credential *bundleCredential) (backend.Integration, bool, error) {
+	// Installation details can change while the bundle sits on disk, so
+	// installation-based connectors look them up again instead of trusting it.
+	if claimer, ok := connector.(github.GitHubConnector); ok && integration.BotID != "" {
+		claimed, err := claimer.ClaimInstallation(ctx, integration.BotID, integration.OrganizationID, integration.UserID)
+		if err == nil {
+			return *claimed, true, nil
+		}
+		slog.Warn("failed to reclaim installation from bundle", "connector_type", integration.ConnectorType, "installation_id", integration.BotID, "error", err)
+		credential = nil
+	}
+
+	valid := false
+	var validationErr error
+	if credential != nil {
+		validationErr = connector.ValidateCredentials(backend.Credentials{
+			Type:      credential.Type,
+			Data:      credential.Data,
+			ExpiresAt: credential.ExpiresAt,
+		})
+		if validationErr != nil {
+			slog.Warn("imported credentials failed validation", "connector_type", integration.ConnectorType, "error", validationErr)
+		}
+		valid = validationErr == nil
+	}
+
+	now := time.Now()
+	integration.ID = uuid.New()
+	integration.CreatedAt = now
+	integration.UpdatedAt = now
+	if !valid {
+		integration.Status = backend.IntegrationStatusNeedsReauthorization
+	}
+	if integration.Metadata == nil {
+		integration.Metadata = make(map[string]string)
+	}
+
+	if err := s.integrationRepository.Store(ctx, integration); err != nil {
+		return backend.Integration{}, false, fmt.Errorf("failed to store integration: %w", err)
+	}
+	if validationErr != nil {
+		s.recordActivity(ctx, integration, backend.IntegrationActivityValidationFailed, map[string]string{"source": "import", "error": validationErr.Error()})
+	}
+
+	if credential != nil {
+		err := s.credentialRepository.Store(ctx, domain.IntegrationCredential{
+			ID:              uuid.New(),
+			IntegrationID:   integration.ID,
+			CredentialType:  credential.Type,
+			Data:            credential.Data,
+			ExpiresAt:       credential.ExpiresAt,
+			EncryptionKeyID: "v1",
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		})
+		if err != nil {
+			return backend.Integration{}, false, fmt.Errorf("failed to store credentials: %w", err)
+		}
+	}
+
+	return integration, valid, nil
+}
+
+func sealBundle(plaintext []byte, passphrase string) (bundleEnvelope, error) {
+	envelope := bundleEnvelope{
+		Version:    bundleVersion,
+		KDF:        bundleKDF,
+		Iterations: bundleKDFIterations,
+		Salt:       make([]byte, 16),
+	}
+	if _, err := rand.Read(envelope.Salt); err != nil {
+		return bundleEnvelope{}, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	gcm, err := bundleCipher(passphrase, envelope.Salt, envelope.Iterations)
+	if err != nil {
+		return bundleEnvelope{}, err
+	}
+
+	envelope.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(envelope.Nonce); err != nil {
+		return bundleEnvelope{}, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	envelope.Ciphertext = gcm.Seal(nil, envelope.Nonce, plaintext, nil)
+	return envelope, nil
+}
+
+func openBundle(envelope bundleEnvelope, passphrase string) ([]byte, error) {
+	if envelope.Version != bundleVersion || envelope.KDF != bundleKDF || envelope.Iterations <= 0 {
+		return nil, fmt.Errorf("%w: unsupported envelope version %d (%s)", domain.ErrInvalidBundle, envelope.Version, envelope.KDF)
+	}
+
+	gcm, err := bundleCipher(passphrase, envelope.Salt, envelope.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	if len(envelope.Nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("%w: malformed nonce", domain.ErrInvalidBundle)
+	}
+
+	plaintext, err := gcm.Open(nil, envelope.Nonce, envelope.Ciphertext, nil)
+	if err != nil {
+		return nil, domain.ErrInvalidBundlePassphrase
+	}
+	return plaintext, nil
+}
+
+func bundleCipher(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive bundle key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,142 @@
+package integrationsvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domaintest"
+	"github.com/google/uuid"
+)
+
+// tokenConnector accepts credentials whose token is in valid.
+type tokenConnector struct {
+	domain.Connector
+	valid map[string]bool
+}
+
+func (c tokenConnector) ValidateCredentials(creds backend.Credentials) error {
+	if !c.valid[creds.Data["token"]] {
+		return errors.New("token revoked")
+	}
+	return nil
+}
+
+func TestIntegrationBundle(t *testing.T) {
+	ctx := context.Background()
+	const passphrase = "correct horse battery staple"
+
+	newService := func() (*service, domain.IntegrationRepository, domain.CredentialRepository) {
+		integrations := domaintest.NewIntegrationRepository()
+		credentials := domaintest.NewCredentialRepository(integrations)
+		svc := NewService(ServiceConfig{
+			IntegrationRepository: integrations,
+			CredentialRepository:  credentials,
+			Connectors: map[backend.ConnectorType]domain.Connector{
+				backend.ConnectorTypeSlack:   tokenConnector{valid: map[string]bool{"xoxb-live": true}},
+				backend.ConnectorTypeDatadog: tokenConnector{valid: map[string]bool{}},
+			},
+		})
+		return svc.(*service), integrations, credentials
+	}
+
+	source, integrations, credentials := newService()
+	orgID := uuid.New()
+	for connectorType, token := range map[backend.ConnectorType]string{
+		backend.ConnectorTypeSlack:   "xoxb-live",
+		backend.ConnectorTypeDatadog: "dd-revoked",
+	} {
+		integration := backend.Integration{
+			ID:             uuid.New(),
+			OrganizationID: orgID,
+			UserID:         uuid.New(),
+			ConnectorType:  connectorType,
+			Status:         backend.IntegrationStatusActive,
+			Metadata:       map[string]string{"team": "acme"},
+		}
+		if err := integrations.Store(ctx, integration); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+		err := credentials.Store(ctx, domain.IntegrationCredential{
+			ID:             uuid.New(),
+			IntegrationID:  integration.ID,
+			CredentialType: backend.CredentialTypeToken,
+			Data:           map[string]string{"token": token},
+		})
+		if err != nil {
+			t.Fatalf("Store() credential error = %v", err)
+		}
+	}
+
+	bundle, err := source.ExportIntegrations(ctx, backend.ExportIntegrationsQuery{OrganizationID: orgID, Passphrase: passphrase})
+	if err != nil {
+		t.Fatalf("ExportIntegrations() error = %v", err)
+	}
+
+	t.Run("rejects short passphrase", func(t *testing.T) {
+		_, err := source.ExportIntegrations(ctx, backend.ExportIntegrationsQuery{OrganizationID: orgID, Passphrase: "short"})
+		if !errors.Is(err, domain.ErrInvalidBundlePassphrase) {
+			t.Errorf("ExportIntegrations() error = %v, want %v", err, domain.ErrInvalidBundlePassphrase)
+		}
+	})
+
+	t.Run("rejects wrong passphrase", func(t *testing.T) {
+		target, _, _ := newService()
+		_, err := target.ImportIntegrations(ctx, backend.ImportIntegrationsCommand{OrganizationID: uuid.New(), Bundle: bundle, Passphrase: "not the passphrase"})
+		if !errors.Is(err, domain.ErrInvalidBundlePassphrase) {
+			t.Errorf("ImportIntegrations() error = %v, want %v", err, domain.ErrInvalidBundlePassphrase)
+		}
+	})
+
+	t.Run("restores into another organization", func(t *testing.T) {
+		target, targetIntegrations, targetCredentials := newService()
+		targetOrgID := uuid.New()
+
+		result, err := target.ImportIntegrations(ctx, backend.ImportIntegrationsCommand{OrganizationID: targetOrgID, Bundle: bundle, Passphrase: passphrase})
+		if err != nil {
+			t.Fatalf("ImportIntegrations() error = %v", err)
+		}
+		if len(result.Restored) != 1 || result.Restored[0].ConnectorType != backend.ConnectorTypeSlack {
+			t.Errorf("Restored = %+v, want the slack integration", result.Restored)
+		}
+		if len(result.NeedsReauthorization) != 1 || result.NeedsReauthorization[0].ConnectorType != backend.ConnectorTypeDatadog {
+			t.Errorf("NeedsReauthorization = %+v, want the datadog integration", result.NeedsReauthorization)
+		}
+
+		restored, err := targetIntegrations.FindByOrganization(ctx, targetOrgID)
+		if err != nil {
+			t.Fatalf("FindByOrganization() error = %v", err)
+		}
+		for _, integration := range restored {
+			want := backend.IntegrationStatusActive
+			if integration.ConnectorType == backend.ConnectorTypeDatadog {
+				want = backend.IntegrationStatusNeedsReauthorization
+			}
+			if integration.Status != want {
+				t.Errorf("%s Status = %v, want %v", integration.ConnectorType, integration.Status, want)
+			}
+			if integration.Metadata["team"] != "acme" {
+				t.Errorf("%s Metadata = %v, want metadata restored", integration.ConnectorType, integration.Metadata)
+			}
+		}
+
+		slack := result.Restored[0]
+		cred, err := targetCredentials.FindByIntegration(ctx, slack.ID)
+		if err != nil {
+			t.Fatalf("FindByIntegration() error = %v", err)
+		}
+		if cred.Data["token"] != "xoxb-live" {
+			t.Errorf("token = %q, want xoxb-live", cred.Data["token"])
+		}
+
+		again, err := target.ImportIntegrations(ctx, backend.ImportIntegrationsCommand{OrganizationID: targetOrgID, Bundle: bundle, Passphrase: passphrase})
+		if err != nil {
+			t.Fatalf("ImportIntegrations() again error = %v", err)
+		}
+		if len(again.Skipped) != 2 {
+			t.Errorf("Skipped %d integrations on re-import, want 2", len(again.Skipped))
+		}
+	})
+}
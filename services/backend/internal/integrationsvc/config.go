@@ -2,54 +2,184 @@ package integrationsvc
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
 
 	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/azuredevops"
 	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/gcp"
 	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/github"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/objectstore"
 	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/slack"
 	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
 	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/supporting/postgres"
 )
 
 type Config struct {
-	Database *sql.DB       `mapstructure:"-"`
-	Slack    slack.Config  `mapstructure:"slack"`
-	GitHub   github.Config `mapstructure:"github"`
-	GCP      gcp.Config    `mapstructure:"gcp"`
+	Database    *sql.DB            `mapstructure:"-"`
+	Slack       slack.Config       `mapstructure:"slack"`
+	GitHub      github.Config      `mapstructure:"github"`
+	GCP         gcp.Config         `mapstructure:"gcp"`
+	ObjectStore objectstore.Config `mapstructure:"objectstore"`
+	AzureDevOps azuredevops.Config `mapstructure:"azure_devops"`
+
+	// ReadDatabase, such as a read replica, serves integration listings. The
+	// primary Database is used when it is nil.
+	ReadDatabase *sql.DB `mapstructure:"-"`
+
+	FeatureFlags      backend.FeatureFlags    `mapstructure:"-"`
+	FlaggedConnectors []backend.ConnectorType `mapstructure:"flagged_connectors"`
+
+	Sync             SyncConfig             `mapstructure:"sync"`
+	CredentialAccess CredentialAccessConfig `mapstructure:"credential_access"`
+
+	StatusListener                backend.IntegrationStatusListener     `mapstructure:"-"`
+	CredentialAccessAlertListener backend.CredentialAccessAlertListener `mapstructure:"-"`
+	RepositoryEventListener       backend.RepositoryEventListener       `mapstructure:"-"`
 }
 
 func (c Config) New() (backend.IntegrationService, error) {
-	integrationRepository := postgres.NewIntegrationRepository(c.Database)
+	activityRepository := postgres.NewActivityRepository(c.Database)
 
-	credentialRepository, err := postgres.NewCredentialRepository(c.Database)
+	var integrationRepository domain.IntegrationRepository = postgres.NewIntegrationRepository(c.Database)
+	integrationRepository = recordingIntegrationRepository{integrationRepository, activityRepository}
+	if c.StatusListener != nil {
+		integrationRepository = notifyingIntegrationRepository{integrationRepository, c.StatusListener}
+	}
+
+	postgresCredentialRepository, err := postgres.NewCredentialRepository(c.Database)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create credential repository: %w", err)
 	}
+	credentialAccessRepository := postgres.NewCredentialAccessRepository(c.Database)
+	credentialRepository := auditingCredentialRepository{
+		CredentialRepository: recordingCredentialRepository{postgresCredentialRepository, integrationRepository, activityRepository},
+		integrations:         integrationRepository,
+		accesses:             credentialAccessRepository,
+		listener:             c.CredentialAccessAlertListener,
+		threshold:            c.CredentialAccess.AlertThresholdPerHour,
+		alerts:               newCredentialAccessAlerts(),
+	}
+
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
 
 	connectors := make(map[backend.ConnectorType]domain.Connector)
 
-	if c.Slack.ClientID != "" && c.Slack.BotToken != "" {
+	if c.slackEnabled() {
 		connectors[backend.ConnectorTypeSlack] = c.Slack.New()
 	}
 
-	if c.GitHub.AppID != "" {
+	if c.githubEnabled() {
 		c.GitHub.GitHubRepositoryRepo = postgres.NewGitHubRepositoryRepository(c.Database)
 		c.GitHub.IntegrationRepository = integrationRepository
 		c.GitHub.CredentialRepository = credentialRepository
+		c.GitHub.ActivityRecorder = activityRepository
+		c.GitHub.InstallationLocker = postgres.NewInstallationLocker(c.Database)
 
 		connectors[backend.ConnectorTypeGithub] = c.GitHub.New()
 	}
 
+	if c.azureDevOpsEnabled() {
+		c.AzureDevOps.AzureDevOpsRepositoryRepo = postgres.NewAzureDevOpsRepositoryRepository(c.Database)
+		c.AzureDevOps.IntegrationRepository = integrationRepository
+		c.AzureDevOps.CredentialRepository = credentialRepository
+		c.AzureDevOps.ActivityRecorder = activityRepository
+
+		connectors[backend.ConnectorTypeAzureDevOps] = c.AzureDevOps.New()
+	}
+
 	c.GCP.IntegrationRepository = integrationRepository
 	c.GCP.CredentialRepository = credentialRepository
 	connectors[backend.ConnectorTypeGCP] = c.GCP.New()
+	connectors[backend.ConnectorTypeObjectStore] = c.ObjectStore.New()
+
+	logConnectors(connectors)
+
+	var readIntegrationRepository domain.IntegrationRepository
+	if c.ReadDatabase != nil {
+		readIntegrationRepository = postgres.NewIntegrationRepository(c.ReadDatabase)
+	}
 
 	serviceConfig := ServiceConfig{
-		IntegrationRepository: integrationRepository,
-		CredentialRepository:  credentialRepository,
-		Connectors:            connectors,
+		IntegrationRepository:       integrationRepository,
+		ReadIntegrationRepository:   readIntegrationRepository,
+		CredentialRepository:        credentialRepository,
+		ActivityRepository:          activityRepository,
+		IntegrationDataRepository:   postgres.NewIntegrationDataRepository(c.Database),
+		CredentialAccessRepository:  credentialAccessRepository,
+		Connectors:                  connectors,
+		FeatureFlags:                c.FeatureFlags,
+		FlaggedConnectors:           c.FlaggedConnectors,
+		SyncSchedules:               c.Sync.schedules(slices.Collect(maps.Keys(connectors))),
+		SyncJobRepository:           postgres.NewSyncJobRepository(c.Database),
+		SyncJobPolicy:               c.Sync.jobPolicy(),
+		RepositoryTriggerRepository: postgres.NewRepositoryTriggerRepository(c.Database),
+		RepositoryEventListener:     c.RepositoryEventListener,
 	}
 
 	return NewService(serviceConfig), nil
 }
+
+func (c Config) slackEnabled() bool {
+	return c.Slack.ClientID != "" && c.Slack.BotToken != ""
+}
+
+func (c Config) githubEnabled() bool {
+	return c.GitHub.AppID != ""
+}
+
+// githubConfigured reports whether any GitHub App setting is present, so a
+// config missing app_id is reported instead of silently disabling GitHub.
+func (c Config) githubConfigured() bool {
+	g := c.GitHub
+	return g.AppID != "" || g.AppName != "" || g.PrivateKey != "" || g.WebhookSecret != "" || g.RedirectURL != ""
+}
+
+func (c Config) azureDevOpsEnabled() bool {
+	return c.AzureDevOps.WebhookBaseURL != ""
+}
+
+// validate reports every problem across the configured connectors so a bad
+// config.yaml fails at startup rather than on the first request.
+func (c Config) validate() error {
+	var report strings.Builder
+	add := func(connector string, err error) {
+		if err == nil {
+			return
+		}
+		for _, line := range strings.Split(err.Error(), "\n") {
+			fmt.Fprintf(&report, "\n  integrations.%s: %s", connector, line)
+		}
+	}
+
+	if c.slackEnabled() {
+		add("slack", c.Slack.Validate())
+	}
+	if c.githubConfigured() {
+		add("github", c.GitHub.Validate())
+	}
+	if c.azureDevOpsEnabled() {
+		add("azure_devops", c.AzureDevOps.Validate())
+	}
+
+	if report.Len() > 0 {
+		return errors.New("invalid integration configuration:" + report.String())
+	}
+	return nil
+}
+
+func logConnectors(connectors map[backend.ConnectorType]domain.Connector) {
+	for _, connectorType := range slices.Sorted(maps.Keys(connectors)) {
+		var capabilities []string
+		if _, ok := connectors[connectorType].(domain.SyncStatusReporter); ok {
+			capabilities = append(capabilities, "sync_status")
+		}
+		slog.Info("integration connector enabled", "connector", connectorType, "capabilities", capabilities)
+	}
+}
@@ -0,0 +1,25 @@
+package integrationsvc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/github"
+)
+
+func TestConfigValidate(t *testing.T) {
+	if err := (Config{}).validate(); err != nil {
+		t.Fatalf("validate() error = %v for a config without connectors", err)
+	}
+
+	c := Config{GitHub: github.Config{AppName: "infragpt", RedirectURL: "https://app.example.com/callback"}}
+	err := c.validate()
+	if err == nil {
+		t.Fatal("validate() error = nil for a GitHub config without app_id")
+	}
+	for _, want := range []string{"integrations.github: missing app_id", "integrations.github: missing private_key"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("validate() error = %q, want it to mention %q", err, want)
+		}
+	}
+}
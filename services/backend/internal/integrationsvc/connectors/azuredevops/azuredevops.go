@@ -0,0 +1,365 @@
+package azuredevops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const apiVersion = "7.1"
+
+var errInvalidToken = errors.New("personal access token was rejected by Azure DevOps")
+
+type azureDevOpsConnector struct {
+	config     Config
+	client     *http.Client
+	apiBaseURL string
+}
+
+func (a *azureDevOpsConnector) InitiateAuthorization(organizationID string, userID string) (backend.IntegrationAuthorizationIntent, error) {
+	return backend.IntegrationAuthorizationIntent{
+		Type: backend.AuthorizationTypeAPIKey,
+		URL:  "azure-devops-pat",
+	}, nil
+}
+
+func (a *azureDevOpsConnector) ParseState(state string) (organizationID uuid.UUID, userID uuid.UUID, err error) {
+	parts := strings.Split(state, ":")
+	if len(parts) != 2 {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid state format", backend.ErrInvalidState)
+	}
+
+	orgID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid organization ID in state: %w", backend.ErrInvalidState, err)
+	}
+
+	uID, err := uuid.Parse(parts[1])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid user ID in state: %w", backend.ErrInvalidState, err)
+	}
+
+	return orgID, uID, nil
+}
+
+// CompleteAuthorization expects the code to be a JSON object with the Azure
+// DevOps organization and a personal access token with Code (Read) scope.
+func (a *azureDevOpsConnector) CompleteAuthorization(authData backend.AuthorizationData) (backend.Credentials, error) {
+	var code struct {
+		Organization        string `json:"organization"`
+		PersonalAccessToken string `json:"personal_access_token"`
+	}
+	if err := json.Unmarshal([]byte(authData.Code), &code); err != nil {
+		return backend.Credentials{}, fmt.Errorf("invalid JSON format")
+	}
+
+	creds := backend.Credentials{
+		Type: backend.CredentialTypeToken,
+		Data: map[string]string{
+			"organization":          code.Organization,
+			"personal_access_token": code.PersonalAccessToken,
+		},
+		OrganizationInfo: &backend.OrganizationInfo{
+			ExternalID: code.Organization,
+			Name:       code.Organization,
+			Metadata: map[string]string{
+				"azure_devops_organization": code.Organization,
+			},
+		},
+	}
+
+	if err := a.ValidateCredentials(creds); err != nil {
+		return backend.Credentials{}, err
+	}
+	return creds, nil
+}
+
+func (a *azureDevOpsConnector) ValidateCredentials(creds backend.Credentials) error {
+	account, err := accountFromCredentials(creds)
+	if err != nil {
+		return err
+	}
+
+	if _, err := a.listProjects(context.Background(), account); err != nil {
+		return fmt.Errorf("credential validation failed: %w", err)
+	}
+	return nil
+}
+
+// Permissions returns an empty map: Azure DevOps does not expose the scopes
+// of a personal access token.
+func (a *azureDevOpsConnector) Permissions(creds backend.Credentials) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+// RefreshCredentials returns the credentials unchanged; personal access tokens
+// are renewed by the user in Azure DevOps.
+func (a *azureDevOpsConnector) RefreshCredentials(creds backend.Credentials) (backend.Credentials, error) {
+	return creds, nil
+}
+
+// RevokeCredentials removes the service hook subscriptions created for the
+// integration. The personal access token itself can only be revoked by its
+// owner.
+func (a *azureDevOpsConnector) RevokeCredentials(creds backend.Credentials) error {
+	account, err := accountFromCredentials(creds)
+	if err != nil {
+		return err
+	}
+
+	for _, subscriptionID := range strings.Split(creds.Data["service_hook_subscriptions"], ",") {
+		if subscriptionID == "" {
+			continue
+		}
+		if err := a.deleteSubscription(context.Background(), account, subscriptionID); err != nil {
+			return err
+		}
+	}
+
+	slog.Info("Azure DevOps credentials revoked", "organization", account.organization)
+	return nil
+}
+
+func (a *azureDevOpsConnector) Sync(ctx context.Context, integration backend.Integration, params map[string]string) (backend.SyncResult, error) {
+	current, err := a.config.IntegrationRepository.FindByID(ctx, integration.ID)
+	if err != nil {
+		return backend.SyncResult{}, fmt.Errorf("failed to find integration: %w", err)
+	}
+	if err := current.CheckCredentialsUsable(); err != nil {
+		return backend.SyncResult{}, err
+	}
+
+	account, err := a.integrationAccount(ctx, integration.ID)
+	if err != nil {
+		return backend.SyncResult{}, err
+	}
+
+	repositories, err := a.listRepositories(ctx, account)
+	if err != nil {
+		return backend.SyncResult{}, fmt.Errorf("failed to fetch repositories: %w", err)
+	}
+
+	slog.Info("fetched repositories from Azure DevOps",
+		"integration_id", integration.ID,
+		"repository_count", len(repositories))
+
+	stored, err := a.config.AzureDevOpsRepositoryRepo.ListByIntegrationID(ctx, integration.ID)
+	if err != nil {
+		return backend.SyncResult{}, fmt.Errorf("failed to list stored repositories: %w", err)
+	}
+	previous := make(map[uuid.UUID]AzureDevOpsRepository, len(stored))
+	for _, repo := range stored {
+		previous[repo.RepositoryID] = repo
+	}
+
+	result := a.storeRepositories(ctx, integration.ID, repositories, previous)
+
+	if err := a.config.AzureDevOpsRepositoryRepo.UpdateLastSyncTime(ctx, integration.ID, time.Now()); err != nil {
+		slog.Error("failed to update last sync time", "integration_id", integration.ID, "error", err)
+	}
+
+	return result, nil
+}
+
+// storeRepositories upserts repositories and counts them against previous,
+// the integration's repositories before the sync keyed by repository ID.
+func (a *azureDevOpsConnector) storeRepositories(ctx context.Context, integrationID uuid.UUID, repositories []Repository, previous map[uuid.UUID]AzureDevOpsRepository) backend.SyncResult {
+	var result backend.SyncResult
+	for _, repo := range repositories {
+		stored, err := repo.toStored(integrationID)
+		if err == nil {
+			err = a.config.AzureDevOpsRepositoryRepo.Store(ctx, stored)
+		}
+		if err != nil {
+			slog.Error("failed to store repository",
+				"integration_id", integrationID,
+				"repository_id", repo.ID,
+				"repository_name", repo.Name,
+				"error", err)
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", repo.Name, err))
+			continue
+		}
+
+		before, existed := previous[stored.RepositoryID]
+		switch {
+		case !existed:
+			result.Added++
+		case before.RepositoryFullName != stored.RepositoryFullName || before.RepositoryURL != stored.RepositoryURL ||
+			before.IsPrivate != stored.IsPrivate || before.DefaultBranch != stored.DefaultBranch:
+			result.Updated++
+		}
+	}
+	return result
+}
+
+func (a *azureDevOpsConnector) SyncStatus(ctx context.Context, integration backend.Integration) (backend.IntegrationSyncStatus, error) {
+	repositories, err := a.config.AzureDevOpsRepositoryRepo.ListByIntegrationID(ctx, integration.ID)
+	if err != nil {
+		return backend.IntegrationSyncStatus{}, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	var lastSyncedAt *time.Time
+	for _, repo := range repositories {
+		if !repo.LastSyncedAt.IsZero() && (lastSyncedAt == nil || repo.LastSyncedAt.After(*lastSyncedAt)) {
+			syncedAt := repo.LastSyncedAt
+			lastSyncedAt = &syncedAt
+		}
+	}
+
+	// The token reads every repository it can list, so all of them are
+	// accessible.
+	total := len(repositories)
+	return backend.IntegrationSyncStatus{
+		LastSyncedAt:              lastSyncedAt,
+		RepositoryCount:           &total,
+		AccessibleRepositoryCount: &total,
+	}, nil
+}
+
+func (a *azureDevOpsConnector) Repositories(ctx context.Context, integration backend.Integration) ([]backend.SyncedRepository, error) {
+	repositories, err := a.config.AzureDevOpsRepositoryRepo.ListByIntegrationID(ctx, integration.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	synced := make([]backend.SyncedRepository, len(repositories))
+	for i, repo := range repositories {
+		synced[i] = backend.SyncedRepository{
+			ID:            repo.ID,
+			Name:          repo.RepositoryName,
+			FullName:      repo.RepositoryFullName,
+			URL:           repo.RepositoryURL,
+			Private:       repo.IsPrivate,
+			DefaultBranch: repo.DefaultBranch,
+			Permissions:   backend.RepositoryPermissions{Pull: true},
+			Enabled:       repo.Enabled,
+			LastSyncedAt:  repo.LastSyncedAt,
+		}
+	}
+	return synced, nil
+}
+
+func (a *azureDevOpsConnector) SetRepositoriesEnabled(ctx context.Context, integration backend.Integration, repositoryIDs []int64, enabled bool) (int, error) {
+	updated, err := a.config.AzureDevOpsRepositoryRepo.SetEnabled(ctx, integration.ID, repositoryIDs, enabled)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update repositories: %w", err)
+	}
+	return updated, nil
+}
+
+// account is an Azure DevOps organization and the token used to call it.
+type account struct {
+	organization string
+	token        string
+}
+
+func accountFromCredentials(creds backend.Credentials) (account, error) {
+	organization := creds.Data["organization"]
+	if organization == "" {
+		return account{}, fmt.Errorf("organization not found in credentials")
+	}
+	token := creds.Data["personal_access_token"]
+	if token == "" {
+		return account{}, fmt.Errorf("personal access token not found in credentials")
+	}
+	return account{organization: organization, token: token}, nil
+}
+
+func (a *azureDevOpsConnector) integrationAccount(ctx context.Context, integrationID uuid.UUID) (account, error) {
+	credential, err := a.config.CredentialRepository.FindByIntegration(ctx, integrationID)
+	if err != nil {
+		return account{}, fmt.Errorf("failed to retrieve credentials: %w", err)
+	}
+	return accountFromCredentials(backend.Credentials{Type: credential.CredentialType, Data: credential.Data})
+}
+
+func (a *azureDevOpsConnector) listProjects(ctx context.Context, acc account) (_ []Project, err error) {
+	ctx, span := tracing.Start(ctx, "azuredevops.list_projects", attribute.String("azuredevops.organization", acc.organization))
+	defer func() { tracing.End(span, err) }()
+
+	var response struct {
+		Value []Project `json:"value"`
+	}
+	if err := a.do(ctx, acc, http.MethodGet, "_apis/projects", nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Value, nil
+}
+
+// listRepositories lists the repositories of every project in the
+// organization. The endpoint is not paginated.
+func (a *azureDevOpsConnector) listRepositories(ctx context.Context, acc account) (_ []Repository, err error) {
+	ctx, span := tracing.Start(ctx, "azuredevops.list_repositories", attribute.String("azuredevops.organization", acc.organization))
+	defer func() { tracing.End(span, err) }()
+
+	var response struct {
+		Value []Repository `json:"value"`
+	}
+	if err := a.do(ctx, acc, http.MethodGet, "_apis/git/repositories", nil, &response); err != nil {
+		return nil, err
+	}
+	return response.Value, nil
+}
+
+// do calls an organization-level REST endpoint, decoding the JSON response
+// into out when it is not nil.
+func (a *azureDevOpsConnector) do(ctx context.Context, acc account, method, path string, body, out any) error {
+	endpoint := fmt.Sprintf("%s/%s/%s?api-version=%s", a.apiBaseURL, url.PathEscape(acc.organization), path, apiVersion)
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth("", acc.token)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call Azure DevOps: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Azure DevOps answers a rejected token with a 203 sign-in page rather
+	// than a 401.
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusNonAuthoritativeInfo:
+		return errInvalidToken
+	case resp.StatusCode == http.StatusNotFound && method == http.MethodDelete:
+		return nil
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return fmt.Errorf("Azure DevOps API error: status %d", resp.StatusCode)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode Azure DevOps response: %w", err)
+	}
+	return nil
+}
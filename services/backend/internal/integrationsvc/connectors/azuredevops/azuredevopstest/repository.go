@@ -0,0 +1,103 @@
+package azuredevopstest
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/azuredevops"
+	"github.com/google/uuid"
+)
+
+type repositoryKey struct {
+	integrationID uuid.UUID
+	repositoryID  uuid.UUID
+}
+
+type repositoryStore struct {
+	mu           sync.RWMutex
+	nextID       int64
+	repositories map[repositoryKey]azuredevops.AzureDevOpsRepository
+}
+
+// NewRepositoryStore returns an in-memory azuredevops.AzureDevOpsRepositoryRepository
+// that upserts on (integration, repository) like the Postgres implementation.
+func NewRepositoryStore() azuredevops.AzureDevOpsRepositoryRepository {
+	return &repositoryStore{
+		repositories: make(map[repositoryKey]azuredevops.AzureDevOpsRepository),
+	}
+}
+
+func (s *repositoryStore) Store(ctx context.Context, repo azuredevops.AzureDevOpsRepository) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := repositoryKey{repo.IntegrationID, repo.RepositoryID}
+	if existing, ok := s.repositories[key]; ok {
+		repo.ID = existing.ID
+		repo.CreatedAt = existing.CreatedAt
+		repo.Enabled = existing.Enabled
+	} else {
+		s.nextID++
+		repo.ID = s.nextID
+		repo.Enabled = true
+	}
+	s.repositories[key] = repo
+	return nil
+}
+
+func (s *repositoryStore) ListByIntegrationID(ctx context.Context, integrationID uuid.UUID) ([]azuredevops.AzureDevOpsRepository, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var repos []azuredevops.AzureDevOpsRepository
+	for key, repo := range s.repositories {
+		if key.integrationID == integrationID {
+			repos = append(repos, repo)
+		}
+	}
+	slices.SortFunc(repos, func(a, b azuredevops.AzureDevOpsRepository) int {
+		return strings.Compare(a.RepositoryFullName, b.RepositoryFullName)
+	})
+	return repos, nil
+}
+
+func (s *repositoryStore) SetEnabled(ctx context.Context, integrationID uuid.UUID, ids []int64, enabled bool) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	updated := 0
+	for key, repo := range s.repositories {
+		if key.integrationID == integrationID && slices.Contains(ids, repo.ID) {
+			repo.Enabled = enabled
+			s.repositories[key] = repo
+			updated++
+		}
+	}
+	return updated, nil
+}
+
+func (s *repositoryStore) BulkDelete(ctx context.Context, integrationID uuid.UUID, repositoryIDs []uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, repositoryID := range repositoryIDs {
+		delete(s.repositories, repositoryKey{integrationID, repositoryID})
+	}
+	return nil
+}
+
+func (s *repositoryStore) UpdateLastSyncTime(ctx context.Context, integrationID uuid.UUID, syncTime time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, repo := range s.repositories {
+		if key.integrationID == integrationID {
+			repo.LastSyncedAt = syncTime
+			s.repositories[key] = repo
+		}
+	}
+	return nil
+}
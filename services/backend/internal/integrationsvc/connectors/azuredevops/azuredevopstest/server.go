@@ -0,0 +1,166 @@
+// Package azuredevopstest provides an in-process fake of the Azure DevOps REST
+// API and an in-memory repository store for testing the Azure DevOps connector.
+package azuredevopstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/azuredevops"
+)
+
+// Subscription is a service hook subscription created through the fake.
+type Subscription struct {
+	ID             string
+	EventType      string
+	ProjectID      string
+	ConsumerInputs map[string]string
+}
+
+// Server fakes the subset of the Azure DevOps REST API used by the connector
+// for a single organization. Requests must authenticate with Token.
+type Server struct {
+	*httptest.Server
+
+	Organization string
+	Token        string
+
+	mu            sync.Mutex
+	projects      []azuredevops.Project
+	repositories  []azuredevops.Repository
+	subscriptions map[string]Subscription
+	nextID        int
+}
+
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	s := &Server{
+		Organization:  "contoso",
+		Token:         "test-personal-access-token",
+		subscriptions: make(map[string]Subscription),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{org}/_apis/projects", s.listProjects)
+	mux.HandleFunc("GET /{org}/_apis/git/repositories", s.listRepositories)
+	mux.HandleFunc("POST /{org}/_apis/hooks/subscriptions", s.createSubscription)
+	mux.HandleFunc("DELETE /{org}/_apis/hooks/subscriptions/{id}", s.deleteSubscription)
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// AddProject registers a project and its repositories.
+func (s *Server) AddProject(project azuredevops.Project, repositories ...azuredevops.Repository) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.projects = append(s.projects, project)
+	for _, repo := range repositories {
+		repo.Project = project
+		s.repositories = append(s.repositories, repo)
+	}
+}
+
+// Subscriptions returns the service hook subscriptions that have not been deleted.
+func (s *Server) Subscriptions() []Subscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subscriptions := make([]Subscription, 0, len(s.subscriptions))
+	for _, subscription := range s.subscriptions {
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions
+}
+
+func (s *Server) listProjects(w http.ResponseWriter, r *http.Request) {
+	if !s.authenticate(w, r) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]any{"count": len(s.projects), "value": s.projects})
+}
+
+func (s *Server) listRepositories(w http.ResponseWriter, r *http.Request) {
+	if !s.authenticate(w, r) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]any{"count": len(s.repositories), "value": s.repositories})
+}
+
+func (s *Server) createSubscription(w http.ResponseWriter, r *http.Request) {
+	if !s.authenticate(w, r) {
+		return
+	}
+
+	var request struct {
+		EventType       string            `json:"eventType"`
+		PublisherInputs map[string]string `json:"publisherInputs"`
+		ConsumerInputs  map[string]string `json:"consumerInputs"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"message": err.Error()})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	subscription := Subscription{
+		ID:             "subscription-" + strconv.Itoa(s.nextID),
+		EventType:      request.EventType,
+		ProjectID:      request.PublisherInputs["projectId"],
+		ConsumerInputs: request.ConsumerInputs,
+	}
+	s.subscriptions[subscription.ID] = subscription
+	writeJSON(w, http.StatusOK, map[string]string{"id": subscription.ID})
+}
+
+func (s *Server) deleteSubscription(w http.ResponseWriter, r *http.Request) {
+	if !s.authenticate(w, r) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := s.subscriptions[id]; !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "subscription not found"})
+		return
+	}
+	delete(s.subscriptions, id)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authenticate mimics Azure DevOps answering a bad token with a 203 sign-in
+// page.
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) bool {
+	_, token, ok := r.BasicAuth()
+	if !ok || token != s.Token || r.PathValue("org") != s.Organization {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusNonAuthoritativeInfo)
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
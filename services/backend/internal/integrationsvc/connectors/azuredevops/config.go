@@ -0,0 +1,63 @@
+package azuredevops
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+)
+
+const defaultAPIBaseURL = "https://dev.azure.com"
+
+type Config struct {
+	// WebhookBaseURL is the public URL of the backend that service hooks
+	// deliver to; the connector is disabled without it.
+	WebhookBaseURL string `mapstructure:"webhook_base_url"`
+	// WebhookSecret derives the per-integration password service hooks send,
+	// since Azure DevOps cannot sign its payloads.
+	WebhookSecret string `mapstructure:"webhook_secret"`
+	// APIBaseURL defaults to Azure DevOps Services; set it for Azure DevOps
+	// Server or tests.
+	APIBaseURL string `mapstructure:"api_base_url"`
+
+	AzureDevOpsRepositoryRepo AzureDevOpsRepositoryRepository
+	IntegrationRepository     domain.IntegrationRepository
+	CredentialRepository      domain.CredentialRepository
+	ActivityRecorder          domain.ActivityRecorder
+}
+
+// minWebhookSecretLength matches the GitHub connector's requirement.
+const minWebhookSecretLength = 16
+
+func (c Config) Validate() error {
+	var errs []error
+	if c.WebhookBaseURL == "" {
+		errs = append(errs, errors.New("missing webhook_base_url"))
+	}
+	if c.WebhookSecret == "" {
+		errs = append(errs, errors.New("missing webhook_secret"))
+	} else if len(c.WebhookSecret) < minWebhookSecretLength {
+		errs = append(errs, fmt.Errorf("webhook_secret must be at least %d characters", minWebhookSecretLength))
+	}
+	return errors.Join(errs...)
+}
+
+func (c Config) New() domain.Connector {
+	if err := c.Validate(); err != nil {
+		panic(fmt.Sprintf("invalid azure devops config: %v", err))
+	}
+
+	apiBaseURL := strings.TrimSuffix(c.APIBaseURL, "/")
+	if apiBaseURL == "" {
+		apiBaseURL = defaultAPIBaseURL
+	}
+
+	return &azureDevOpsConnector{
+		config:     c,
+		client:     tracing.HTTPClient(30 * time.Second),
+		apiBaseURL: apiBaseURL,
+	}
+}
@@ -0,0 +1,232 @@
+package azuredevops_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/azuredevops"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/azuredevops/azuredevopstest"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domaintest"
+	"github.com/google/uuid"
+)
+
+const (
+	projectID    = "6ce954b1-ce1f-45d1-b94d-e6bf2464ba2c"
+	apiRepoID    = "5febef5a-833d-4e14-b9c0-14cb638f91e6"
+	webRepoID    = "3411ebc1-d5aa-464f-9615-0b527bc66719"
+	workerRepoID = "8c1a52f3-1b8d-4b0c-9f59-8cfc0f2d6a1e"
+)
+
+type harness struct {
+	server       *azuredevopstest.Server
+	connector    domain.Connector
+	integrations domain.IntegrationRepository
+	credentials  domain.CredentialRepository
+}
+
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+
+	h := &harness{
+		server:       azuredevopstest.NewServer(t),
+		integrations: domaintest.NewIntegrationRepository(),
+	}
+	h.credentials = domaintest.NewCredentialRepository(h.integrations)
+	h.connector = azuredevops.Config{
+		WebhookBaseURL:            "https://app.example.com",
+		WebhookSecret:             "test-webhook-secret",
+		APIBaseURL:                h.server.URL,
+		AzureDevOpsRepositoryRepo: azuredevopstest.NewRepositoryStore(),
+		IntegrationRepository:     h.integrations,
+		CredentialRepository:      h.credentials,
+	}.New()
+
+	h.server.AddProject(azuredevops.Project{ID: projectID, Name: "Platform", Visibility: "private"},
+		azuredevops.Repository{ID: apiRepoID, Name: "api", DefaultBranch: "refs/heads/main"},
+		azuredevops.Repository{ID: webRepoID, Name: "web", DefaultBranch: "refs/heads/develop"},
+	)
+
+	return h
+}
+
+func (h *harness) authorize(t *testing.T, token string) (backend.Credentials, error) {
+	t.Helper()
+
+	code, err := json.Marshal(map[string]string{
+		"organization":          h.server.Organization,
+		"personal_access_token": token,
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal code: %v", err)
+	}
+	return h.connector.CompleteAuthorization(backend.AuthorizationData{Code: string(code)})
+}
+
+// connect authorizes, stores the integration and its credential like the
+// service does, and configures service hooks.
+func (h *harness) connect(t *testing.T) backend.Integration {
+	t.Helper()
+	ctx := context.Background()
+
+	creds, err := h.authorize(t, h.server.Token)
+	if err != nil {
+		t.Fatalf("CompleteAuthorization() error = %v", err)
+	}
+
+	integration := backend.Integration{
+		ID:                      uuid.New(),
+		OrganizationID:          uuid.New(),
+		UserID:                  uuid.New(),
+		ConnectorType:           backend.ConnectorTypeAzureDevOps,
+		Status:                  backend.IntegrationStatusActive,
+		ConnectorOrganizationID: creds.OrganizationInfo.ExternalID,
+		CreatedAt:               time.Now(),
+		UpdatedAt:               time.Now(),
+	}
+	if err := h.integrations.Store(ctx, integration); err != nil {
+		t.Fatalf("failed to store integration: %v", err)
+	}
+	if err := h.credentials.Store(ctx, domain.IntegrationCredential{
+		ID:             uuid.New(),
+		IntegrationID:  integration.ID,
+		CredentialType: creds.Type,
+		Data:           creds.Data,
+	}); err != nil {
+		t.Fatalf("failed to store credential: %v", err)
+	}
+
+	if err := h.connector.ConfigureWebhooks(integration.ID.String(), creds); err != nil {
+		t.Fatalf("ConfigureWebhooks() error = %v", err)
+	}
+	return integration
+}
+
+func (h *harness) deliver(t *testing.T, integrationID uuid.UUID, password string, payload map[string]any) int {
+	t.Helper()
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("failed to marshal payload: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	if !h.connector.(domain.WebhookRouter).RegisterRoutes(mux, h.connector.ProcessEvent) {
+		t.Fatal("RegisterRoutes() = false, want webhooks on the main server")
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/azure-devops?integration_id="+integrationID.String(), strings.NewReader(string(body)))
+	req.SetBasicAuth("infragpt", password)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func (h *harness) fullNames(t *testing.T, integration backend.Integration) []string {
+	t.Helper()
+
+	repos, err := h.connector.(domain.RepositoryLister).Repositories(context.Background(), integration)
+	if err != nil {
+		t.Fatalf("Repositories() error = %v", err)
+	}
+	names := make([]string, len(repos))
+	for i, repo := range repos {
+		names[i] = repo.FullName
+	}
+	return names
+}
+
+func TestCompleteAuthorizationRejectsInvalidToken(t *testing.T) {
+	h := newHarness(t)
+
+	if _, err := h.authorize(t, "wrong-token"); err == nil {
+		t.Fatal("CompleteAuthorization() error = nil, want rejected token")
+	}
+}
+
+func TestConnector(t *testing.T) {
+	ctx := context.Background()
+	h := newHarness(t)
+	integration := h.connect(t)
+
+	subscriptions := h.server.Subscriptions()
+	if len(subscriptions) != 3 {
+		t.Fatalf("got %d service hook subscriptions, want 3", len(subscriptions))
+	}
+	password := subscriptions[0].ConsumerInputs["basicAuthPassword"]
+	if password == "" {
+		t.Fatal("subscription has no basic auth password")
+	}
+	wantURL := "https://app.example.com/webhooks/azure-devops?integration_id=" + integration.ID.String()
+	if got := subscriptions[0].ConsumerInputs["url"]; got != wantURL {
+		t.Errorf("subscription url = %q, want %q", got, wantURL)
+	}
+
+	result, err := h.connector.Sync(ctx, integration, nil)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if result.Added != 2 || result.Updated != 0 || len(result.Errors) != 0 {
+		t.Errorf("Sync() = %+v, want 2 added", result)
+	}
+	if got := h.fullNames(t, integration); strings.Join(got, ",") != "Platform/api,Platform/web" {
+		t.Fatalf("repositories after sync = %v, want [Platform/api Platform/web]", got)
+	}
+
+	t.Run("webhook with wrong password", func(t *testing.T) {
+		code := h.deliver(t, integration.ID, "wrong", map[string]any{
+			"eventType": "git.repo.deleted",
+			"resource":  map[string]any{"repositoryId": apiRepoID},
+		})
+		if code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want %d", code, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("repository created and deleted", func(t *testing.T) {
+		code := h.deliver(t, integration.ID, password, map[string]any{
+			"eventType": "git.repo.created",
+			"resource": map[string]any{
+				"repository": map[string]any{
+					"id":      workerRepoID,
+					"name":    "worker",
+					"project": map[string]any{"id": projectID, "name": "Platform"},
+				},
+			},
+		})
+		if code != http.StatusOK {
+			t.Fatalf("created status = %d, want %d", code, http.StatusOK)
+		}
+
+		code = h.deliver(t, integration.ID, password, map[string]any{
+			"eventType": "git.repo.deleted",
+			"resource":  map[string]any{"repositoryId": apiRepoID},
+		})
+		if code != http.StatusOK {
+			t.Fatalf("deleted status = %d, want %d", code, http.StatusOK)
+		}
+
+		if got := h.fullNames(t, integration); strings.Join(got, ",") != "Platform/web,Platform/worker" {
+			t.Errorf("repositories after webhooks = %v, want [Platform/web Platform/worker]", got)
+		}
+	})
+
+	t.Run("revocation removes service hooks", func(t *testing.T) {
+		credential, err := h.credentials.FindByIntegration(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("FindByIntegration() error = %v", err)
+		}
+		if err := h.connector.RevokeCredentials(backend.Credentials{Type: credential.CredentialType, Data: credential.Data}); err != nil {
+			t.Fatalf("RevokeCredentials() error = %v", err)
+		}
+		if got := len(h.server.Subscriptions()); got != 0 {
+			t.Errorf("got %d subscriptions after revocation, want 0", got)
+		}
+	})
+}
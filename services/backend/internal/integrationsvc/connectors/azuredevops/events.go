@@ -0,0 +1,71 @@
+package azuredevops
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventTypeRepositoryCreated EventType = "git.repo.created"
+	EventTypeRepositoryRenamed EventType = "git.repo.renamed"
+	EventTypeRepositoryDeleted EventType = "git.repo.deleted"
+)
+
+// handledEvents are the service hook events subscribed to for each project.
+var handledEvents = []EventType{EventTypeRepositoryCreated, EventTypeRepositoryRenamed, EventTypeRepositoryDeleted}
+
+type Project struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Visibility string `json:"visibility"`
+}
+
+type Repository struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Project       Project `json:"project"`
+	DefaultBranch string  `json:"defaultBranch"`
+	WebURL        string  `json:"webUrl"`
+}
+
+func (r Repository) toStored(integrationID uuid.UUID) (AzureDevOpsRepository, error) {
+	repositoryID, err := uuid.Parse(r.ID)
+	if err != nil {
+		return AzureDevOpsRepository{}, fmt.Errorf("invalid repository ID %q: %w", r.ID, err)
+	}
+	projectID, err := uuid.Parse(r.Project.ID)
+	if err != nil {
+		return AzureDevOpsRepository{}, fmt.Errorf("invalid project ID %q: %w", r.Project.ID, err)
+	}
+
+	now := time.Now()
+	return AzureDevOpsRepository{
+		IntegrationID:      integrationID,
+		RepositoryID:       repositoryID,
+		ProjectID:          projectID,
+		ProjectName:        r.Project.Name,
+		RepositoryName:     r.Name,
+		RepositoryFullName: r.Project.Name + "/" + r.Name,
+		RepositoryURL:      r.WebURL,
+		IsPrivate:          r.Project.Visibility != "public",
+		DefaultBranch:      strings.TrimPrefix(r.DefaultBranch, "refs/heads/"),
+		CreatedAt:          now,
+		UpdatedAt:          now,
+		LastSyncedAt:       now,
+	}, nil
+}
+
+// WebhookEvent is a service hook delivery for an integration's repositories.
+type WebhookEvent struct {
+	IntegrationID uuid.UUID
+	EventType     EventType
+	RepositoryID  uuid.UUID
+	// Repository is set for created and renamed repositories.
+	Repository *Repository
+	CreatedAt  time.Time
+}
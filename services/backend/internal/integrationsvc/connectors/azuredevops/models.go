@@ -0,0 +1,41 @@
+package azuredevops
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AzureDevOpsRepositoryRepository interface {
+	// Store inserts or updates a repository by its Azure DevOps ID. It leaves
+	// the flag of existing rows alone and enables new ones.
+	Store(ctx context.Context, repo AzureDevOpsRepository) error
+	ListByIntegrationID(ctx context.Context, integrationID uuid.UUID) ([]AzureDevOpsRepository, error)
+	// SetEnabled selects or deselects repositories by their row ID and returns
+	// how many were updated.
+	SetEnabled(ctx context.Context, integrationID uuid.UUID, ids []int64, enabled bool) (int, error)
+	BulkDelete(ctx context.Context, integrationID uuid.UUID, repositoryIDs []uuid.UUID) error
+	UpdateLastSyncTime(ctx context.Context, integrationID uuid.UUID, syncTime time.Time) error
+}
+
+type AzureDevOpsRepository struct {
+	// ID is a stable numeric ID assigned on first sync. Azure DevOps
+	// identifies repositories by GUID, and the rest of the system expects
+	// numeric repository IDs.
+	ID                 int64
+	IntegrationID      uuid.UUID
+	RepositoryID       uuid.UUID
+	ProjectID          uuid.UUID
+	ProjectName        string
+	RepositoryName     string
+	RepositoryFullName string
+	RepositoryURL      string
+	IsPrivate          bool
+	DefaultBranch      string
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	LastSyncedAt       time.Time
+	// Enabled is false for repositories deselected for agent access.
+	Enabled bool
+}
@@ -0,0 +1,272 @@
+package azuredevops
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/recovery"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	webhookPath = "/webhooks/azure-devops"
+	// webhookUsername is sent with the derived password; only the password
+	// is checked.
+	webhookUsername = "infragpt"
+)
+
+// ConfigureWebhooks subscribes the integration to repository events in every
+// project of the organization and remembers the subscriptions so they can be
+// removed on revocation. Projects created later are picked up by syncs but
+// send no events.
+func (a *azureDevOpsConnector) ConfigureWebhooks(integrationID string, creds backend.Credentials) error {
+	ctx := context.Background()
+	account, err := accountFromCredentials(creds)
+	if err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(integrationID)
+	if err != nil {
+		return fmt.Errorf("invalid integration ID: %w", err)
+	}
+
+	projects, err := a.listProjects(ctx, account)
+	if err != nil {
+		return fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	webhookURL := a.webhookURL(integrationID)
+	var subscriptionIDs []string
+	for _, project := range projects {
+		for _, eventType := range handledEvents {
+			subscriptionID, err := a.createSubscription(ctx, account, project.ID, eventType, webhookURL, a.computeSignature([]byte(integrationID), a.config.WebhookSecret))
+			if err != nil {
+				return fmt.Errorf("failed to subscribe to %s in project %s: %w", eventType, project.Name, err)
+			}
+			subscriptionIDs = append(subscriptionIDs, subscriptionID)
+		}
+	}
+
+	credential, err := a.config.CredentialRepository.FindByIntegration(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve credentials: %w", err)
+	}
+	credential.Data["service_hook_subscriptions"] = strings.Join(subscriptionIDs, ",")
+	credential.UpdatedAt = time.Now()
+	if err := a.config.CredentialRepository.Update(ctx, credential); err != nil {
+		return fmt.Errorf("failed to store service hook subscriptions: %w", err)
+	}
+
+	slog.Info("Azure DevOps service hooks configured",
+		"integration_id", integrationID,
+		"organization", account.organization,
+		"project_count", len(projects),
+		"webhook_url", webhookURL)
+	return nil
+}
+
+func (a *azureDevOpsConnector) createSubscription(ctx context.Context, acc account, projectID string, eventType EventType, webhookURL, password string) (string, error) {
+	request := map[string]any{
+		"publisherId":      "tfs",
+		"eventType":        eventType,
+		"resourceVersion":  "1.0",
+		"consumerId":       "webHooks",
+		"consumerActionId": "httpRequest",
+		"publisherInputs":  map[string]string{"projectId": projectID},
+		"consumerInputs": map[string]string{
+			"url":                   webhookURL,
+			"basicAuthUsername":     webhookUsername,
+			"basicAuthPassword":     password,
+			"resourceDetailsToSend": "all",
+		},
+	}
+
+	var response struct {
+		ID string `json:"id"`
+	}
+	if err := a.do(ctx, acc, http.MethodPost, "_apis/hooks/subscriptions", request, &response); err != nil {
+		return "", err
+	}
+	return response.ID, nil
+}
+
+// deleteSubscription removes a service hook subscription. One that is already
+// gone counts as deleted so that revocation can be retried.
+func (a *azureDevOpsConnector) deleteSubscription(ctx context.Context, acc account, subscriptionID string) error {
+	if err := a.do(ctx, acc, http.MethodDelete, "_apis/hooks/subscriptions/"+url.PathEscape(subscriptionID), nil, nil); err != nil {
+		return fmt.Errorf("failed to delete service hook subscription %s: %w", subscriptionID, err)
+	}
+	return nil
+}
+
+func (a *azureDevOpsConnector) webhookURL(integrationID string) string {
+	return fmt.Sprintf("%s%s?integration_id=%s", strings.TrimSuffix(a.config.WebhookBaseURL, "/"), webhookPath, url.QueryEscape(integrationID))
+}
+
+// ValidateWebhookSignature checks the basic auth password of a delivery.
+// Service hooks cannot sign their payload, so the password is an HMAC of the
+// integration ID, passed as payload, under the webhook secret.
+func (a *azureDevOpsConnector) ValidateWebhookSignature(payload []byte, signature string, secret string) error {
+	if secret == "" {
+		secret = a.config.WebhookSecret
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(a.computeSignature(payload, secret))) {
+		return fmt.Errorf("webhook signature validation failed")
+	}
+	return nil
+}
+
+func (a *azureDevOpsConnector) computeSignature(payload []byte, secret string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write(payload)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Subscribe waits for ctx to be done: webhooks are always served on the main
+// server through RegisterRoutes.
+func (a *azureDevOpsConnector) Subscribe(ctx context.Context, handler func(ctx context.Context, event any) error) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (a *azureDevOpsConnector) RegisterRoutes(mux *http.ServeMux, handler func(ctx context.Context, event any) error) bool {
+	mux.Handle(webhookPath, recovery.Middleware("azuredevops.webhook")(a.webhookHandler(handler)))
+	return true
+}
+
+func (a *azureDevOpsConnector) webhookHandler(handler func(ctx context.Context, event any) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		integrationID, err := uuid.Parse(r.URL.Query().Get("integration_id"))
+		if err != nil {
+			http.Error(w, "Invalid integration_id", http.StatusBadRequest)
+			return
+		}
+
+		_, password, ok := r.BasicAuth()
+		if !ok {
+			slog.Info("azuredevops: missing webhook credentials")
+			http.Error(w, "Missing webhook credentials", http.StatusUnauthorized)
+			return
+		}
+		if err := a.ValidateWebhookSignature([]byte(integrationID.String()), password, ""); err != nil {
+			slog.Info("azuredevops: webhook validation failed", "integration_id", integrationID, "error", err)
+			http.Error(w, "Invalid webhook credentials", http.StatusUnauthorized)
+			return
+		}
+
+		var payload struct {
+			EventType EventType `json:"eventType"`
+			Resource  struct {
+				Repository   *Repository `json:"repository"`
+				RepositoryID string      `json:"repositoryId"`
+			} `json:"resource"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+			return
+		}
+
+		if !slices.Contains(handledEvents, payload.EventType) {
+			slog.Debug("ignoring unhandled Azure DevOps event", "event_type", payload.EventType)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		repositoryID := payload.Resource.RepositoryID
+		if repositoryID == "" && payload.Resource.Repository != nil {
+			repositoryID = payload.Resource.Repository.ID
+		}
+		parsedRepositoryID, err := uuid.Parse(repositoryID)
+		if err != nil {
+			http.Error(w, "Invalid repository ID", http.StatusBadRequest)
+			return
+		}
+
+		event := WebhookEvent{
+			IntegrationID: integrationID,
+			EventType:     payload.EventType,
+			RepositoryID:  parsedRepositoryID,
+			Repository:    payload.Resource.Repository,
+			CreatedAt:     time.Now(),
+		}
+		if err := handler(r.Context(), event); err != nil {
+			slog.Error("error handling Azure DevOps webhook event", "event_type", payload.EventType, "error", err)
+			http.Error(w, "Failed to handle event", http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func (a *azureDevOpsConnector) ProcessEvent(ctx context.Context, event any) error {
+	webhookEvent, ok := event.(WebhookEvent)
+	if !ok {
+		return fmt.Errorf("invalid event type: expected WebhookEvent")
+	}
+
+	integration, err := a.config.IntegrationRepository.FindByID(ctx, webhookEvent.IntegrationID)
+	if errors.Is(err, backend.ErrIntegrationNotFound) || (err == nil && integration.ConnectorType != backend.ConnectorTypeAzureDevOps) {
+		slog.Info("ignoring Azure DevOps event for unknown integration", "integration_id", webhookEvent.IntegrationID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find integration: %w", err)
+	}
+	if err := integration.CheckCredentialsUsable(); err != nil {
+		slog.Info("skipping Azure DevOps event for unusable integration",
+			"integration_id", integration.ID,
+			"status", integration.Status,
+			"event_type", webhookEvent.EventType)
+		return nil
+	}
+
+	switch webhookEvent.EventType {
+	case EventTypeRepositoryCreated, EventTypeRepositoryRenamed:
+		if webhookEvent.Repository == nil {
+			return fmt.Errorf("%s event without repository", webhookEvent.EventType)
+		}
+		a.storeRepositories(ctx, integration.ID, []Repository{*webhookEvent.Repository}, nil)
+	case EventTypeRepositoryDeleted:
+		if err := a.config.AzureDevOpsRepositoryRepo.BulkDelete(ctx, integration.ID, []uuid.UUID{webhookEvent.RepositoryID}); err != nil {
+			return fmt.Errorf("failed to remove repository: %w", err)
+		}
+	default:
+		return nil
+	}
+
+	slog.Info("processed Azure DevOps repository event",
+		"integration_id", integration.ID,
+		"event_type", webhookEvent.EventType,
+		"repository_id", webhookEvent.RepositoryID)
+
+	domain.RecordActivity(ctx, a.config.ActivityRecorder, backend.IntegrationActivity{
+		IntegrationID:  integration.ID,
+		OrganizationID: integration.OrganizationID,
+		Type:           backend.IntegrationActivityWebhookProcessed,
+		Details: map[string]string{
+			"event_type": string(webhookEvent.EventType),
+		},
+	})
+	return nil
+}
@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/73ai/infragpt/services/backend"
 	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
 	"github.com/google/uuid"
 	"google.golang.org/api/cloudresourcemanager/v1"
+	"google.golang.org/api/iamcredentials/v1"
 	"google.golang.org/api/option"
 )
 
@@ -50,17 +52,17 @@ func (c *Connector) InitiateAuthorization(organizationID string, userID string)
 func (c *Connector) ParseState(state string) (organizationID uuid.UUID, userID uuid.UUID, err error) {
 	parts := strings.Split(state, ":")
 	if len(parts) != 2 {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid state format")
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid state format", backend.ErrInvalidState)
 	}
 
 	orgID, err := uuid.Parse(parts[0])
 	if err != nil {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid organization ID in state: %w", err)
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid organization ID in state: %w", backend.ErrInvalidState, err)
 	}
 
 	uID, err := uuid.Parse(parts[1])
 	if err != nil {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid user ID in state: %w", err)
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid user ID in state: %w", backend.ErrInvalidState, err)
 	}
 
 	return orgID, uID, nil
@@ -108,6 +110,12 @@ func (c *Connector) ValidateCredentials(creds backend.Credentials) error {
 	return nil
 }
 
+// Permissions returns an empty map: service accounts are granted IAM roles
+// rather than scopes.
+func (c *Connector) Permissions(creds backend.Credentials) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
 func ValidateServiceAccountWithViewer(jsonData []byte) (*ValidationResult, error) {
 	result := &ValidationResult{
 		Valid:  false,
@@ -205,6 +213,55 @@ func (c *Connector) RefreshCredentials(creds backend.Credentials) (backend.Crede
 	return creds, nil
 }
 
+// mintedTokenLifetime is how long impersonated access tokens stay valid.
+const mintedTokenLifetime = 15 * time.Minute
+
+// MintCredentials impersonates the stored service account into an access token
+// limited to scope.OAuthScopes. The service account must hold the Service
+// Account Token Creator role on itself.
+func (c *Connector) MintCredentials(ctx context.Context, creds backend.Credentials, scope backend.CredentialScope) (backend.MintedCredentials, error) {
+	saJSON, exists := creds.Data["service_account_json"]
+	if !exists {
+		return backend.MintedCredentials{}, fmt.Errorf("service account JSON not found in credentials")
+	}
+	if len(scope.OAuthScopes) == 0 {
+		return backend.MintedCredentials{}, fmt.Errorf("at least one OAuth scope is required")
+	}
+
+	var sa ServiceAccountKey
+	if err := json.Unmarshal([]byte(saJSON), &sa); err != nil {
+		return backend.MintedCredentials{}, fmt.Errorf("invalid service account JSON: %w", err)
+	}
+
+	service, err := iamcredentials.NewService(ctx, option.WithCredentialsJSON([]byte(saJSON)))
+	if err != nil {
+		return backend.MintedCredentials{}, fmt.Errorf("failed to create IAM credentials client: %w", err)
+	}
+
+	name := "projects/-/serviceAccounts/" + sa.ClientEmail
+	resp, err := service.Projects.ServiceAccounts.GenerateAccessToken(name, &iamcredentials.GenerateAccessTokenRequest{
+		Scope:    scope.OAuthScopes,
+		Lifetime: fmt.Sprintf("%ds", int(mintedTokenLifetime.Seconds())),
+	}).Context(ctx).Do()
+	if err != nil {
+		return backend.MintedCredentials{}, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, resp.ExpireTime)
+	if err != nil {
+		return backend.MintedCredentials{}, fmt.Errorf("invalid access token expiry %q: %w", resp.ExpireTime, err)
+	}
+
+	return backend.MintedCredentials{
+		Type: backend.CredentialTypeToken,
+		Data: map[string]string{
+			"access_token": resp.AccessToken,
+			"project_id":   sa.ProjectID,
+		},
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
 func (c *Connector) RevokeCredentials(creds backend.Credentials) error {
 	return nil
 }
@@ -226,10 +283,10 @@ func (c *Connector) ProcessEvent(ctx context.Context, event any) error {
 	return fmt.Errorf("event processing not supported for GCP connector")
 }
 
-func (c *Connector) Sync(ctx context.Context, integration backend.Integration, params map[string]string) error {
+func (c *Connector) Sync(ctx context.Context, integration backend.Integration, params map[string]string) (backend.SyncResult, error) {
 	credRecord, err := c.credentialRepository.FindByIntegration(ctx, integration.ID)
 	if err != nil {
-		return fmt.Errorf("failed to retrieve credentials: %w", err)
+		return backend.SyncResult{}, fmt.Errorf("failed to retrieve credentials: %w", err)
 	}
 
 	creds := backend.Credentials{
@@ -238,5 +295,5 @@ func (c *Connector) Sync(ctx context.Context, integration backend.Integration, p
 		ExpiresAt: credRecord.ExpiresAt,
 	}
 
-	return c.ValidateCredentials(creds)
+	return backend.SyncResult{}, c.ValidateCredentials(creds)
 }
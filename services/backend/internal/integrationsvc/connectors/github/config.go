@@ -1,15 +1,21 @@
 package github
 
 import (
-	"crypto/rsa"
+	"context"
+	"errors"
 	"fmt"
-	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
 	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
 	"github.com/golang-jwt/jwt/v4"
 )
 
+const defaultAPIBaseURL = "https://api.github.com"
+
 type Config struct {
 	AppID         string `mapstructure:"app_id"`
 	AppName       string `mapstructure:"app_name"`
@@ -17,41 +23,138 @@ type Config struct {
 	WebhookSecret string `mapstructure:"webhook_secret"`
 	RedirectURL   string `mapstructure:"redirect_url"`
 	WebhookPort   int    `mapstructure:"webhook_port"`
+	// APIBaseURL defaults to the public GitHub API; set it for GitHub Enterprise or tests.
+	APIBaseURL string `mapstructure:"api_base_url"`
+	// MaxConcurrentSyncs caps repository syncs running at once; further syncs
+	// queue until a slot frees up.
+	MaxConcurrentSyncs int `mapstructure:"max_concurrent_syncs"`
+	// JWTExpirySeconds is how long app JWTs stay valid, capped at GitHub's
+	// 10 minute maximum, which is also the default.
+	JWTExpirySeconds int `mapstructure:"jwt_expiry_seconds"`
+	// Profiling records the languages and marker files of repositories during
+	// sync. It costs two API calls per repository, so it is off by default.
+	Profiling ProfilingConfig `mapstructure:"profiling"`
 
 	GitHubRepositoryRepo  GitHubRepositoryRepository
 	IntegrationRepository domain.IntegrationRepository
 	CredentialRepository  domain.CredentialRepository
+	ActivityRecorder      domain.ActivityRecorder
+	// InstallationLocker serializes claims of an installation across
+	// replicas. Claims are only serialized within this process when it is nil.
+	InstallationLocker domain.InstallationLocker
 }
 
-func (c Config) New() domain.Connector {
+type ProfilingConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// MaxRepositoriesPerSync caps how many repositories one sync profiles;
+	// the rest are profiled by later syncs, least recently profiled first.
+	MaxRepositoriesPerSync int `mapstructure:"max_repositories_per_sync"`
+	// MaxRepositorySizeKB skips repositories larger than this, whose file
+	// listings GitHub truncates anyway.
+	MaxRepositorySizeKB int64 `mapstructure:"max_repository_size_kb"`
+}
+
+const (
+	defaultProfilesPerSync   = 20
+	defaultMaxProfiledSizeKB = 1024 * 1024
+)
+
+const (
+	maxJWTExpiry = 10 * time.Minute
+	// jwtClockSkew backdates iat so hosts whose clock runs ahead of GitHub's
+	// don't get "'iat' is in the future" errors.
+	jwtClockSkew = 60 * time.Second
+)
+
+// minWebhookSecretLength follows GitHub's advice to use a high-entropy secret.
+const minWebhookSecretLength = 16
+
+func (c Config) Validate() error {
+	var errs []error
 	if c.AppID == "" {
-		panic("missing app_id")
+		errs = append(errs, errors.New("missing app_id"))
+	} else if _, err := strconv.ParseInt(c.AppID, 10, 64); err != nil {
+		errs = append(errs, fmt.Errorf("app_id %q is not numeric, use the App ID from the app's settings page rather than its client ID", c.AppID))
 	}
 	if c.AppName == "" {
-		panic("missing app_name")
+		errs = append(errs, errors.New("missing app_name"))
 	}
 	if c.PrivateKey == "" {
-		panic("missing private_key")
+		errs = append(errs, errors.New("missing private_key"))
+	} else if _, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(c.PrivateKey)); err != nil {
+		errs = append(errs, fmt.Errorf("private_key is not a valid PEM encoded RSA key: %w", err))
 	}
 	if c.WebhookSecret == "" {
-		panic("missing webhook_secret")
+		errs = append(errs, errors.New("missing webhook_secret"))
+	} else if len(c.WebhookSecret) < minWebhookSecretLength {
+		errs = append(errs, fmt.Errorf("webhook_secret must be at least %d characters", minWebhookSecretLength))
 	}
 	if c.RedirectURL == "" {
-		panic("missing redirect_url")
+		errs = append(errs, errors.New("missing redirect_url"))
+	} else if u, err := url.Parse(c.RedirectURL); err != nil || u.Scheme == "" || u.Host == "" {
+		errs = append(errs, fmt.Errorf("redirect_url %q must be an absolute URL", c.RedirectURL))
+	}
+	if c.JWTExpirySeconds < 0 {
+		errs = append(errs, errors.New("jwt_expiry_seconds must not be negative"))
+	}
+	if c.Profiling.MaxRepositoriesPerSync < 0 || c.Profiling.MaxRepositorySizeKB < 0 {
+		errs = append(errs, errors.New("profiling limits must not be negative"))
 	}
+	return errors.Join(errs...)
+}
 
-	var privateKey *rsa.PrivateKey
-	var err error
-	privateKey, err = jwt.ParseRSAPrivateKeyFromPEM([]byte(c.PrivateKey))
+func (c Config) New() domain.Connector {
+	if err := c.Validate(); err != nil {
+		panic(fmt.Sprintf("invalid github config: %v", err))
+	}
+
+	privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(c.PrivateKey))
 	if err != nil {
 		panic(fmt.Sprintf("Failed to parse GitHub private key: %v", err))
 	}
 
+	apiBaseURL := strings.TrimSuffix(c.APIBaseURL, "/")
+	if apiBaseURL == "" {
+		apiBaseURL = defaultAPIBaseURL
+	}
+
+	jwtExpiry := time.Duration(c.JWTExpirySeconds) * time.Second
+	if jwtExpiry <= 0 || jwtExpiry > maxJWTExpiry {
+		jwtExpiry = maxJWTExpiry
+	}
+
+	profiling := c.Profiling
+	if profiling.MaxRepositoriesPerSync == 0 {
+		profiling.MaxRepositoriesPerSync = defaultProfilesPerSync
+	}
+	if profiling.MaxRepositorySizeKB == 0 {
+		profiling.MaxRepositorySizeKB = defaultMaxProfiledSizeKB
+	}
+
+	locker := c.InstallationLocker
+	if locker == nil {
+		locker = newLocalInstallationLocker()
+	}
+
 	connector := &githubConnector{
 		config:     c,
-		client:     &http.Client{Timeout: 30 * time.Second},
+		client:     tracing.HTTPClient(30 * time.Second),
 		privateKey: privateKey,
+		apiBaseURL: apiBaseURL,
+		jwtExpiry:  jwtExpiry,
+		syncs:      newSyncLimiter(c.MaxConcurrentSyncs),
+		profiling:  profiling,
+		locker:     locker,
 	}
 
 	return connector
 }
+
+// Check validates the config and that GitHub accepts JWTs signed with
+// private_key for app_id.
+func (c Config) Check(ctx context.Context) error {
+	if err := c.Validate(); err != nil {
+		return fmt.Errorf("invalid github config: %w", err)
+	}
+	return c.New().(*githubConnector).checkApp(ctx)
+}
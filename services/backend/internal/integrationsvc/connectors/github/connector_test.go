@@ -0,0 +1,861 @@
+package github_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/github"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/github/githubtest"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domaintest"
+	"github.com/google/uuid"
+)
+
+const webhookSecret = "test-webhook-secret"
+
+type harness struct {
+	server       *githubtest.Server
+	connector    domain.Connector
+	integrations domain.IntegrationRepository
+	credentials  domain.CredentialRepository
+	repositories github.GitHubRepositoryRepository
+}
+
+func newHarness(t *testing.T, options ...func(*github.Config)) *harness {
+	t.Helper()
+
+	h := &harness{
+		server:       githubtest.NewServer(t),
+		integrations: domaintest.NewIntegrationRepository(),
+		repositories: githubtest.NewRepositoryStore(),
+	}
+	h.credentials = domaintest.NewCredentialRepository(h.integrations)
+	config := github.Config{
+		AppID:                 h.server.AppID,
+		AppName:               "infragpt-test",
+		PrivateKey:            h.server.PrivateKeyPEM(),
+		WebhookSecret:         webhookSecret,
+		RedirectURL:           "https://app.example.com/integrations/github/callback",
+		APIBaseURL:            h.server.URL,
+		GitHubRepositoryRepo:  h.repositories,
+		IntegrationRepository: h.integrations,
+		CredentialRepository:  h.credentials,
+	}
+	for _, option := range options {
+		option(&config)
+	}
+	h.connector = config.New()
+
+	return h
+}
+
+func (h *harness) claim(t *testing.T, installationID int64, organizationID uuid.UUID) *backend.Integration {
+	t.Helper()
+
+	integration, err := h.connector.(github.GitHubConnector).ClaimInstallation(context.Background(), fmt.Sprint(installationID), organizationID, uuid.New())
+	if err != nil {
+		t.Fatalf("ClaimInstallation() error = %v", err)
+	}
+	return integration
+}
+
+func (h *harness) deliver(t *testing.T, req *http.Request) int {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	if !h.connector.(domain.WebhookRouter).RegisterRoutes(mux, h.connector.ProcessEvent) {
+		t.Fatal("RegisterRoutes() = false, want webhooks on the main server without a webhook port")
+	}
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func (h *harness) integration(t *testing.T, id uuid.UUID) backend.Integration {
+	t.Helper()
+
+	integration, err := h.integrations.FindByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	return integration
+}
+
+func installation(id int64, login string) github.Installation {
+	return github.Installation{
+		ID:                  id,
+		TargetType:          "Organization",
+		Account:             github.Account{ID: id * 10, Login: login, Type: "Organization"},
+		RepositorySelection: "selected",
+		Permissions:         map[string]string{"contents": "read", "metadata": "read"},
+	}
+}
+
+func repositories(login string, n int) []github.Repository {
+	repos := make([]github.Repository, n)
+	for i := range repos {
+		name := fmt.Sprintf("repo-%d", i+1)
+		repos[i] = github.Repository{
+			ID:            int64(1000 + i),
+			Name:          name,
+			FullName:      login + "/" + name,
+			HTMLURL:       "https://github.com/" + login + "/" + name,
+			DefaultBranch: "main",
+		}
+	}
+	return repos
+}
+
+func TestClaimInstallation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("new installation", func(t *testing.T) {
+		h := newHarness(t)
+		h.server.PageSize = 2
+		h.server.AddInstallation(installation(42, "acme"), repositories("acme", 5)...)
+
+		orgID := uuid.New()
+		integration := h.claim(t, 42, orgID)
+
+		if integration.Status != backend.IntegrationStatusActive {
+			t.Errorf("Status = %v, want active", integration.Status)
+		}
+		if integration.BotID != "42" || integration.ConnectorUserID != "acme" || integration.ConnectorOrganizationID != "420" {
+			t.Errorf("integration = %+v, want installation 42 for acme (420)", integration)
+		}
+		if got := integration.Metadata["github_app_id"]; got != h.server.AppID {
+			t.Errorf("Metadata[github_app_id] = %q, want %q", got, h.server.AppID)
+		}
+
+		cred, err := h.credentials.FindByIntegration(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("FindByIntegration() error = %v", err)
+		}
+		if cred.Data["access_token"] != "ghs_42_1" {
+			t.Errorf("access_token = %q, want the first minted token", cred.Data["access_token"])
+		}
+		if cred.ExpiresAt == nil {
+			t.Error("ExpiresAt = nil, want the token expiry")
+		}
+
+		repos, err := h.repositories.ListByIntegrationID(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("ListByIntegrationID() error = %v", err)
+		}
+		if len(repos) != 5 {
+			t.Errorf("synced %d repositories across pages, want 5", len(repos))
+		}
+	})
+
+	t.Run("reactivates inactive integration", func(t *testing.T) {
+		h := newHarness(t)
+		h.server.AddInstallation(installation(42, "acme"))
+
+		orgID := uuid.New()
+		first := h.claim(t, 42, orgID)
+		if err := h.integrations.UpdateStatus(ctx, first.ID, backend.IntegrationStatusInactive); err != nil {
+			t.Fatalf("UpdateStatus() error = %v", err)
+		}
+
+		second := h.claim(t, 42, orgID)
+		if second.ID != first.ID {
+			t.Errorf("ID = %v, want the existing integration %v", second.ID, first.ID)
+		}
+		if got := h.integration(t, first.ID).Status; got != backend.IntegrationStatusActive {
+			t.Errorf("Status = %v, want active", got)
+		}
+	})
+
+	t.Run("moves active integration to new installation", func(t *testing.T) {
+		h := newHarness(t)
+		h.server.AddInstallation(installation(42, "acme"))
+		h.server.AddInstallation(installation(43, "acme-new"))
+
+		orgID := uuid.New()
+		first := h.claim(t, 42, orgID)
+		second := h.claim(t, 43, orgID)
+
+		if second.ID != first.ID {
+			t.Errorf("ID = %v, want the existing integration %v", second.ID, first.ID)
+		}
+
+		stored := h.integration(t, first.ID)
+		if stored.BotID != "43" || stored.Metadata["github_account_login"] != "acme-new" {
+			t.Errorf("integration = %+v, want installation 43 for acme-new", stored)
+		}
+
+		all, err := h.integrations.FindByOrganizationAndType(ctx, orgID, backend.ConnectorTypeGithub)
+		if err != nil {
+			t.Fatalf("FindByOrganizationAndType() error = %v", err)
+		}
+		if len(all) != 1 {
+			t.Errorf("found %d integrations, want 1", len(all))
+		}
+	})
+
+	t.Run("concurrent claims share one integration", func(t *testing.T) {
+		h := newHarness(t)
+		h.server.AddInstallation(installation(42, "acme"), repositories("acme", 3)...)
+
+		orgID := uuid.New()
+		claimed := make(chan *backend.Integration, 2)
+		errs := make(chan error, 2)
+		for range 2 {
+			go func() {
+				integration, err := h.connector.(github.GitHubConnector).ClaimInstallation(ctx, "42", orgID, uuid.New())
+				claimed <- integration
+				errs <- err
+			}()
+		}
+
+		var ids []uuid.UUID
+		for range 2 {
+			if err := <-errs; err != nil {
+				t.Fatalf("ClaimInstallation() error = %v", err)
+			}
+			ids = append(ids, (<-claimed).ID)
+		}
+		if ids[0] != ids[1] {
+			t.Errorf("claims returned integrations %v and %v, want the same one", ids[0], ids[1])
+		}
+
+		all, err := h.integrations.FindByOrganizationAndType(ctx, orgID, backend.ConnectorTypeGithub)
+		if err != nil {
+			t.Fatalf("FindByOrganizationAndType() error = %v", err)
+		}
+		if len(all) != 1 {
+			t.Errorf("found %d integrations, want 1", len(all))
+		}
+	})
+
+	t.Run("unknown installation", func(t *testing.T) {
+		h := newHarness(t)
+
+		_, err := h.connector.(github.GitHubConnector).ClaimInstallation(ctx, "404", uuid.New(), uuid.New())
+		if err == nil {
+			t.Fatal("ClaimInstallation() error = nil, want error for unknown installation")
+		}
+	})
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	h := newHarness(t)
+	repos := repositories("acme", 3)
+	h.server.AddInstallation(installation(42, "acme"), repos[:2]...)
+	integration := h.claim(t, 42, uuid.New())
+
+	renamed := repos[1]
+	renamed.Name, renamed.FullName = "renamed", "acme/renamed"
+	h.server.AddInstallation(installation(42, "acme"), repos[0], renamed, repos[2])
+
+	result, err := h.connector.Sync(ctx, *integration, nil)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if result.Added != 1 || result.Updated != 1 || result.Removed != 0 || len(result.Errors) != 0 {
+		t.Errorf("Sync() = %+v, want 1 added and 1 updated", result)
+	}
+
+	result, err = h.connector.Sync(ctx, *integration, nil)
+	if err != nil {
+		t.Fatalf("second Sync() error = %v", err)
+	}
+	if result.Added != 0 || result.Updated != 0 {
+		t.Errorf("second Sync() = %+v, want nothing changed", result)
+	}
+
+	// The selection changes without an installation_repositories webhook.
+	h.server.AddInstallation(installation(42, "acme"), repos[0], repos[2])
+	result, err = h.connector.Sync(ctx, *integration, nil)
+	if err != nil {
+		t.Fatalf("Sync() after changing the selection error = %v", err)
+	}
+	if result.Added != 0 || result.Removed != 1 || len(result.Errors) != 0 {
+		t.Errorf("Sync() after changing the selection = %+v, want 1 removed", result)
+	}
+	stored, err := h.repositories.ListByIntegrationID(ctx, integration.ID)
+	if err != nil {
+		t.Fatalf("ListByIntegrationID() error = %v", err)
+	}
+	var names []string
+	for _, repo := range stored {
+		names = append(names, repo.RepositoryFullName)
+	}
+	slices.Sort(names)
+	if want := []string{"acme/repo-1", "acme/repo-3"}; !slices.Equal(names, want) {
+		t.Errorf("stored repositories = %v, want %v", names, want)
+	}
+}
+
+func TestSyncRepositoryPermissions(t *testing.T) {
+	ctx := context.Background()
+	h := newHarness(t)
+	inst := installation(42, "acme")
+	repos := repositories("acme", 2)
+	repos[0].Permissions = &github.RepositoryPermissions{Pull: true, Push: true}
+	repos[1].Permissions = &github.RepositoryPermissions{Pull: true}
+	h.server.AddInstallation(inst, repos...)
+	integration := h.claim(t, 42, uuid.New())
+
+	logs := captureLogs(t)
+	if _, err := h.connector.Sync(ctx, *integration, nil); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if strings.Contains(logs.String(), "repository permissions changed") {
+		t.Errorf("unchanged permissions reported as changed:\n%s", logs)
+	}
+	assertPermissions(t, h, integration.ID, map[string]github.RepositoryPermissions{
+		"acme/repo-1": {Pull: true, Push: true},
+		"acme/repo-2": {Pull: true},
+	})
+
+	repos[0].Permissions = &github.RepositoryPermissions{Pull: true}
+	repos[1].Permissions = &github.RepositoryPermissions{Pull: true, Push: true}
+	h.server.AddInstallation(inst, repos...)
+	logs.Reset()
+	if _, err := h.connector.Sync(ctx, *integration, nil); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	assertPermissions(t, h, integration.ID, map[string]github.RepositoryPermissions{
+		"acme/repo-1": {Pull: true},
+		"acme/repo-2": {Pull: true, Push: true},
+	})
+	for _, want := range []string{
+		`"changed_count":2`,
+		`{"repository_id":1000,"full_name":"acme/repo-1","lost":["push"]}`,
+		`{"repository_id":1001,"full_name":"acme/repo-2","gained":["push"]}`,
+		`"msg":"push access revoked, PR automation is disabled for these repositories"`,
+	} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("logs do not contain %s:\n%s", want, logs)
+		}
+	}
+}
+
+func assertPermissions(t *testing.T, h *harness, integrationID uuid.UUID, want map[string]github.RepositoryPermissions) {
+	t.Helper()
+
+	stored, err := h.repositories.ListByIntegrationID(context.Background(), integrationID)
+	if err != nil {
+		t.Fatalf("ListByIntegrationID() error = %v", err)
+	}
+	got := make(map[string]github.RepositoryPermissions, len(stored))
+	for _, repo := range stored {
+		got[repo.RepositoryFullName] = github.RepositoryPermissions{Admin: repo.PermissionAdmin, Push: repo.PermissionPush, Pull: repo.PermissionPull}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("stored permissions = %v, want %v", got, want)
+	}
+}
+
+// captureLogs records the default logger's output until the test ends.
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	return &logs
+}
+
+func TestRepositoryProfiles(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("off by default", func(t *testing.T) {
+		h := newHarness(t)
+		h.server.AddInstallation(installation(42, "acme"), repositories("acme", 2)...)
+		h.claim(t, 42, uuid.New())
+
+		if got := h.server.Profiled(); len(got) != 0 {
+			t.Errorf("profiled %v, want none", got)
+		}
+	})
+
+	t.Run("profiles a capped number of repositories per sync", func(t *testing.T) {
+		h := newHarness(t, func(c *github.Config) {
+			c.Profiling = github.ProfilingConfig{Enabled: true, MaxRepositoriesPerSync: 2, MaxRepositorySizeKB: 1000}
+		})
+		inst := installation(42, "acme")
+		repos := repositories("acme", 4)
+		repos[3].Size = 5000
+		h.server.AddInstallation(inst, repos...)
+		h.server.SetContents("acme/repo-1", map[string]int64{"Go": 9000, "HCL": 800}, true,
+			"Dockerfile", "charts/api/Chart.yaml", "go.mod", "infra/main.tf", "tools/go.mod",
+			"web/node_modules/left-pad/package.json", "web/package.json")
+
+		integration := h.claim(t, 42, uuid.New())
+		if got, want := h.server.Profiled(), []string{"acme/repo-1", "acme/repo-2"}; !slices.Equal(got, want) {
+			t.Fatalf("profiled %v on claim, want %v", got, want)
+		}
+
+		profile := profileOf(t, h, integration.ID, 1000)
+		if profile.PrimaryLanguage != "Go" || profile.Languages["HCL"] != 800 || !profile.TreeTruncated || profile.ProfiledAt.IsZero() {
+			t.Errorf("profile = %+v", profile)
+		}
+		wantMarkers := map[string][]string{
+			github.MarkerGo:        {"go.mod", "tools/go.mod"},
+			github.MarkerDocker:    {"Dockerfile"},
+			github.MarkerTerraform: {"infra/main.tf"},
+			github.MarkerHelm:      {"charts/api/Chart.yaml"},
+			github.MarkerNode:      {"web/package.json"},
+		}
+		if !reflect.DeepEqual(profile.Markers, wantMarkers) {
+			t.Errorf("Markers = %v, want %v", profile.Markers, wantMarkers)
+		}
+		if empty := profileOf(t, h, integration.ID, 1001); len(empty.Markers) != 0 || len(empty.Languages) != 0 {
+			t.Errorf("empty repository profile = %+v, want no languages or markers", empty)
+		}
+
+		if _, err := h.connector.Sync(ctx, *integration, nil); err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		if got, want := h.server.Profiled()[2:], []string{"acme/repo-3"}; !slices.Equal(got, want) {
+			t.Errorf("profiled %v on second sync, want %v and the oversized repo-4 skipped", got, want)
+		}
+
+		repos[0].PushedAt = time.Now().Add(time.Minute)
+		h.server.AddInstallation(inst, repos...)
+		if _, err := h.connector.Sync(ctx, *integration, nil); err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		if got, want := h.server.Profiled()[3:], []string{"acme/repo-1"}; !slices.Equal(got, want) {
+			t.Errorf("profiled %v after a push, want %v", got, want)
+		}
+
+		synced, err := h.connector.(domain.RepositoryLister).Repositories(ctx, *integration)
+		if err != nil {
+			t.Fatalf("Repositories() error = %v", err)
+		}
+		if synced[0].Profile == nil || synced[0].Profile.PrimaryLanguage != "Go" || synced[3].Profile != nil {
+			t.Errorf("Repositories() profiles = %+v, %+v", synced[0].Profile, synced[3].Profile)
+		}
+	})
+}
+
+func profileOf(t *testing.T, h *harness, integrationID uuid.UUID, repositoryID int64) github.RepositoryProfile {
+	t.Helper()
+
+	repo, err := h.repositories.GetByGitHubID(context.Background(), integrationID, repositoryID)
+	if err != nil {
+		t.Fatalf("GetByGitHubID() error = %v", err)
+	}
+	if repo.Profile == nil {
+		t.Fatalf("repository %d has no profile", repositoryID)
+	}
+	return *repo.Profile
+}
+
+func TestWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	installationEvent := func(action string, inst github.Installation) map[string]any {
+		return map[string]any{
+			"action":       action,
+			"installation": inst,
+			"sender":       map[string]any{"id": 1, "login": "octocat"},
+		}
+	}
+
+	t.Run("suspend and unsuspend", func(t *testing.T) {
+		h := newHarness(t)
+		inst := installation(42, "acme")
+		h.server.AddInstallation(inst)
+		integration := h.claim(t, 42, uuid.New())
+
+		if code := h.deliver(t, githubtest.NewWebhookRequest(t, webhookSecret, "installation", installationEvent("suspend", inst))); code != http.StatusOK {
+			t.Fatalf("suspend status = %d, want 200", code)
+		}
+		if got := h.integration(t, integration.ID).Status; got != backend.IntegrationStatusSuspended {
+			t.Errorf("Status = %v, want suspended", got)
+		}
+
+		if code := h.deliver(t, githubtest.NewWebhookRequest(t, webhookSecret, "installation", installationEvent("unsuspend", inst))); code != http.StatusOK {
+			t.Fatalf("unsuspend status = %d, want 200", code)
+		}
+		if got := h.integration(t, integration.ID).Status; got != backend.IntegrationStatusActive {
+			t.Errorf("Status = %v, want active", got)
+		}
+	})
+
+	t.Run("suspend cuts off stored and fresh tokens", func(t *testing.T) {
+		h := newHarness(t)
+		inst := installation(42, "acme")
+		h.server.AddInstallation(inst, repositories("acme", 1)...)
+		integration := h.claim(t, 42, uuid.New())
+
+		if code := h.deliver(t, githubtest.NewWebhookRequest(t, webhookSecret, "installation", installationEvent("suspend", inst))); code != http.StatusOK {
+			t.Fatalf("suspend status = %d, want 200", code)
+		}
+		credential, err := h.credentials.FindByIntegration(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("FindByIntegration() error = %v", err)
+		}
+		if token, ok := credential.Data["access_token"]; ok {
+			t.Errorf("access_token = %q after suspend, want it cleared", token)
+		}
+
+		issued := h.server.TokensIssued()
+		if _, err := h.connector.Sync(ctx, *integration, nil); !errors.Is(err, backend.ErrIntegrationSuspended) {
+			t.Errorf("Sync() error = %v, want ErrIntegrationSuspended", err)
+		}
+		if got := h.server.TokensIssued(); got != issued {
+			t.Errorf("TokensIssued() = %d after Sync(), want no new token for a suspended installation", got)
+		}
+
+		if code := h.deliver(t, githubtest.NewWebhookRequest(t, webhookSecret, "installation", installationEvent("unsuspend", inst))); code != http.StatusOK {
+			t.Fatalf("unsuspend status = %d, want 200", code)
+		}
+		credential, err = h.credentials.FindByIntegration(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("FindByIntegration() error = %v", err)
+		}
+		if credential.Data["access_token"] == "" {
+			t.Error("access_token is empty after unsuspend, want a renewed token")
+		}
+	})
+
+	t.Run("token refusals update the integration", func(t *testing.T) {
+		for _, tt := range []struct {
+			name   string
+			refuse func(s *githubtest.Server, id int64)
+			want   backend.IntegrationStatus
+		}{
+			{"suspended on GitHub", (*githubtest.Server).SuspendInstallation, backend.IntegrationStatusSuspended},
+			{"removed on GitHub", (*githubtest.Server).RemoveInstallation, backend.IntegrationStatusInactive},
+		} {
+			t.Run(tt.name, func(t *testing.T) {
+				h := newHarness(t)
+				inst := installation(42, "acme")
+				h.server.AddInstallation(inst)
+				integration := h.claim(t, 42, uuid.New())
+				tt.refuse(h.server, 42)
+
+				if code := h.deliver(t, githubtest.NewWebhookRequest(t, webhookSecret, "installation", installationEvent("unsuspend", inst))); code != http.StatusOK {
+					t.Fatalf("unsuspend status = %d, want 200 so GitHub stops redelivering", code)
+				}
+				if got := h.integration(t, integration.ID).Status; got != tt.want {
+					t.Errorf("Status = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("rejected app JWT is a typed error", func(t *testing.T) {
+		h := newHarness(t)
+		h.server.AddInstallation(installation(42, "acme"))
+		integration := h.claim(t, 42, uuid.New())
+
+		h.server.AppID = "54321"
+		if _, err := h.connector.Sync(ctx, *integration, nil); !errors.Is(err, github.ErrGitHubUnauthorized) {
+			t.Errorf("Sync() error = %v, want ErrGitHubUnauthorized", err)
+		}
+	})
+
+	t.Run("rejects invalid signature", func(t *testing.T) {
+		h := newHarness(t)
+		inst := installation(42, "acme")
+		h.server.AddInstallation(inst)
+		integration := h.claim(t, 42, uuid.New())
+
+		req := githubtest.NewWebhookRequest(t, "wrong-secret", "installation", installationEvent("suspend", inst))
+		if code := h.deliver(t, req); code != http.StatusUnauthorized {
+			t.Errorf("status = %d, want 401", code)
+		}
+		if got := h.integration(t, integration.ID).Status; got != backend.IntegrationStatusActive {
+			t.Errorf("Status = %v, want active", got)
+		}
+	})
+
+	t.Run("tolerates unexpected and extra fields", func(t *testing.T) {
+		h := newHarness(t)
+		h.server.AddInstallation(installation(42, "acme"))
+		integration := h.claim(t, 42, uuid.New())
+
+		payload := map[string]any{
+			"action": "suspend",
+			"installation": map[string]any{
+				"id":                   42,
+				"account":              map[string]any{"id": "420", "login": "acme", "type": "Organization"},
+				"created_at":           1700000000,
+				"permissions":          []string{"contents"},
+				"has_multiple_secrets": true,
+			},
+			"sender":       "octocat",
+			"enterprise":   map[string]any{"id": 7},
+			"requester":    nil,
+			"repositories": "all",
+		}
+		if code := h.deliver(t, githubtest.NewWebhookRequest(t, webhookSecret, "installation", payload)); code != http.StatusOK {
+			t.Fatalf("status = %d, want 200", code)
+		}
+		if got := h.integration(t, integration.ID).Status; got != backend.IntegrationStatusSuspended {
+			t.Errorf("Status = %v, want suspended", got)
+		}
+	})
+
+	t.Run("acknowledges truncated payloads", func(t *testing.T) {
+		h := newHarness(t)
+		h.server.AddInstallation(installation(42, "acme"))
+		integration := h.claim(t, 42, uuid.New())
+
+		payloads := map[string]map[string]any{
+			"no installation": {"action": "suspend"},
+			"no account":      {"action": "suspend", "installation": map[string]any{"id": 42}},
+			"string id":       {"action": "suspend", "installation": map[string]any{"id": "42", "account": map[string]any{"login": "acme"}}},
+			"no action":       {"installation": map[string]any{"id": 42, "account": map[string]any{"login": "acme"}}},
+		}
+		for name, payload := range payloads {
+			if code := h.deliver(t, githubtest.NewWebhookRequest(t, webhookSecret, "installation", payload)); code != http.StatusOK {
+				t.Errorf("%s: status = %d, want 200 so GitHub stops redelivering", name, code)
+			}
+		}
+		if got := h.integration(t, integration.ID).Status; got != backend.IntegrationStatusActive {
+			t.Errorf("Status = %v, want active", got)
+		}
+	})
+
+	t.Run("new permissions accepted", func(t *testing.T) {
+		h := newHarness(t)
+		inst := installation(42, "acme")
+		h.server.AddInstallation(inst)
+		integration := h.claim(t, 42, uuid.New())
+
+		inst.Permissions = map[string]string{"contents": "write", "metadata": "read"}
+		h.server.AddInstallation(inst, repositories("acme", 3)...)
+
+		if code := h.deliver(t, githubtest.NewWebhookRequest(t, webhookSecret, "installation", installationEvent("new_permissions_accepted", inst))); code != http.StatusOK {
+			t.Fatalf("status = %d, want 200", code)
+		}
+
+		stored := h.integration(t, integration.ID)
+		if got := stored.Metadata["permission_contents"]; got != "write" {
+			t.Errorf("Metadata[permission_contents] = %q, want write", got)
+		}
+		if got := stored.Metadata["github_installation_id"]; got != "42" {
+			t.Errorf("Metadata[github_installation_id] = %q, want existing metadata kept", got)
+		}
+
+		repos, err := h.repositories.ListByIntegrationID(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("ListByIntegrationID() error = %v", err)
+		}
+		if len(repos) != 3 {
+			t.Errorf("synced %d repositories, want 3", len(repos))
+		}
+	})
+
+	t.Run("authorization revoked", func(t *testing.T) {
+		h := newHarness(t)
+		personal := installation(42, "octocat")
+		h.server.AddInstallation(personal)
+		personalIntegration := h.claim(t, 42, uuid.New())
+		h.server.AddInstallation(installation(43, "acme"))
+		otherIntegration := h.claim(t, 43, uuid.New())
+
+		payload := map[string]any{
+			"action": "revoked",
+			"sender": map[string]any{"id": personal.Account.ID, "login": "octocat"},
+		}
+		if code := h.deliver(t, githubtest.NewWebhookRequest(t, webhookSecret, "github_app_authorization", payload)); code != http.StatusOK {
+			t.Fatalf("status = %d, want 200", code)
+		}
+
+		if got := h.integration(t, personalIntegration.ID).Status; got != backend.IntegrationStatusNeedsReauthorization {
+			t.Errorf("Status = %v, want needs_reauthorization", got)
+		}
+		if got := h.integration(t, otherIntegration.ID).Status; got != backend.IntegrationStatusActive {
+			t.Errorf("other Status = %v, want active", got)
+		}
+	})
+
+	t.Run("repositories removed tolerates missing repositories", func(t *testing.T) {
+		h := newHarness(t)
+		inst := installation(42, "acme")
+		repos := repositories("acme", 3)
+		h.server.AddInstallation(inst, repos...)
+		integration := h.claim(t, 42, uuid.New())
+
+		payload := map[string]any{
+			"action":               "removed",
+			"installation":         inst,
+			"repositories_removed": []github.Repository{repos[0], {ID: 9999, Name: "gone", FullName: "acme/gone"}},
+			"sender":               map[string]any{"id": 1, "login": "octocat"},
+		}
+		if code := h.deliver(t, githubtest.NewWebhookRequest(t, webhookSecret, "installation_repositories", payload)); code != http.StatusOK {
+			t.Fatalf("status = %d, want 200", code)
+		}
+
+		stored, err := h.repositories.ListByIntegrationID(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("ListByIntegrationID() error = %v", err)
+		}
+		if len(stored) != 2 {
+			t.Errorf("stored %d repositories, want 2", len(stored))
+		}
+	})
+
+	t.Run("ignores permissions for suspended installation", func(t *testing.T) {
+		h := newHarness(t)
+		inst := installation(42, "acme")
+		h.server.AddInstallation(inst)
+		integration := h.claim(t, 42, uuid.New())
+		if err := h.integrations.UpdateStatus(ctx, integration.ID, backend.IntegrationStatusSuspended); err != nil {
+			t.Fatalf("UpdateStatus() error = %v", err)
+		}
+		tokens := h.server.TokensIssued()
+
+		inst.Permissions = map[string]string{"contents": "write"}
+		if code := h.deliver(t, githubtest.NewWebhookRequest(t, webhookSecret, "installation", installationEvent("new_permissions_accepted", inst))); code != http.StatusOK {
+			t.Fatalf("status = %d, want 200", code)
+		}
+
+		if _, ok := h.integration(t, integration.ID).Metadata["permission_contents"]; ok {
+			t.Error("permissions recorded for suspended installation")
+		}
+		if got := h.server.TokensIssued(); got != tokens {
+			t.Errorf("TokensIssued() = %d, want %d (no sync while suspended)", got, tokens)
+		}
+	})
+}
+
+func TestPermissions(t *testing.T) {
+	h := newHarness(t)
+	h.server.AddInstallation(installation(42, "acme"))
+
+	permissions, err := h.connector.Permissions(backend.Credentials{Data: map[string]string{"installation_id": "42"}})
+	if err != nil {
+		t.Fatalf("Permissions() error = %v", err)
+	}
+	if len(permissions) != 2 || permissions["contents"] != "read" || permissions["metadata"] != "read" {
+		t.Errorf("Permissions() = %v, want contents and metadata read", permissions)
+	}
+
+	if _, err := h.connector.Permissions(backend.Credentials{Data: map[string]string{}}); err == nil {
+		t.Error("Permissions() without installation_id error = nil, want an error")
+	}
+}
+
+func TestMintCredentials(t *testing.T) {
+	ctx := context.Background()
+	h := newHarness(t)
+	h.server.AddInstallation(installation(42, "acme"), repositories("acme", 2)...)
+	integration := h.claim(t, 42, uuid.New())
+	creds := backend.Credentials{Data: map[string]string{"installation_id": "42"}}
+	minter := h.connector.(domain.CredentialMinter)
+
+	if got := h.server.TokenRequests(); len(got) == 0 || got[len(got)-1].Repositories != nil || got[len(got)-1].Permissions != nil {
+		t.Fatalf("sync TokenRequests() = %+v, want unscoped tokens", got)
+	}
+
+	minted, err := minter.MintCredentials(ctx, creds, backend.CredentialScope{
+		Repositories: []string{"acme/repo-2"},
+		Permissions:  map[string]string{"contents": "read"},
+	})
+	if err != nil {
+		t.Fatalf("MintCredentials() error = %v", err)
+	}
+	if minted.Data["access_token"] == "" || minted.ExpiresAt.IsZero() {
+		t.Errorf("MintCredentials() = %+v, want a token and expiry", minted)
+	}
+	requests := h.server.TokenRequests()
+	want := githubtest.TokenRequest{InstallationID: 42, Repositories: []string{"repo-2"}, Permissions: map[string]string{"contents": "read"}}
+	if got := requests[len(requests)-1]; !reflect.DeepEqual(got, want) {
+		t.Errorf("token request = %+v, want %+v", got, want)
+	}
+
+	for name, scope := range map[string]backend.CredentialScope{
+		"no repositories":           {},
+		"repository not owner/name": {Repositories: []string{"repo-1"}},
+		"permission not granted":    {Repositories: []string{"acme/repo-1"}, Permissions: map[string]string{"contents": "write"}},
+	} {
+		if _, err := minter.MintCredentials(ctx, creds, scope); err == nil {
+			t.Errorf("MintCredentials() with %s error = nil, want an error", name)
+		}
+	}
+
+	t.Run("pull request analysis uses a read-only token for the repository", func(t *testing.T) {
+		before := len(h.server.TokenRequests())
+		// The fake server does not serve pull requests; only the token matters.
+		_, _ = h.connector.(domain.PullRequestFileLister).PullRequestFiles(ctx, *integration, "acme/repo-1", 7)
+
+		requests := h.server.TokenRequests()[before:]
+		want := []githubtest.TokenRequest{{InstallationID: 42, Repositories: []string{"repo-1"}, Permissions: map[string]string{"contents": "read"}}}
+		if !reflect.DeepEqual(requests, want) {
+			t.Errorf("token requests = %+v, want %+v", requests, want)
+		}
+	})
+}
+
+func TestRevokeCredentials(t *testing.T) {
+	h := newHarness(t)
+	h.server.AddInstallation(installation(42, "acme"))
+
+	creds := backend.Credentials{Data: map[string]string{"installation_id": "42"}}
+	if err := h.connector.RevokeCredentials(creds); err != nil {
+		t.Fatalf("RevokeCredentials() error = %v", err)
+	}
+	if h.server.HasInstallation(42) {
+		t.Error("installation still registered after RevokeCredentials()")
+	}
+
+	if err := h.connector.RevokeCredentials(creds); err != nil {
+		t.Errorf("RevokeCredentials() of an uninstalled app error = %v, want nil so offboarding can resume", err)
+	}
+}
+
+func TestConfigCheck(t *testing.T) {
+	server := githubtest.NewServer(t)
+	config := github.Config{
+		AppID:         server.AppID,
+		AppName:       "infragpt-test",
+		PrivateKey:    server.PrivateKeyPEM(),
+		WebhookSecret: webhookSecret,
+		RedirectURL:   "https://app.example.com/integrations/github/callback",
+		APIBaseURL:    server.URL,
+	}
+
+	if err := config.Check(context.Background()); err != nil {
+		t.Errorf("Check() error = %v", err)
+	}
+
+	config.PrivateKey = githubtest.NewServer(t).PrivateKeyPEM()
+	if err := config.Check(context.Background()); !errors.Is(err, github.ErrGitHubUnauthorized) {
+		t.Errorf("Check() with another app's key error = %v, want %v", err, github.ErrGitHubUnauthorized)
+	}
+}
+
+func TestRegisterRoutesWithWebhookPort(t *testing.T) {
+	server := githubtest.NewServer(t)
+	integrations := domaintest.NewIntegrationRepository()
+	connector := github.Config{
+		AppID:                 server.AppID,
+		AppName:               "infragpt-test",
+		PrivateKey:            server.PrivateKeyPEM(),
+		WebhookSecret:         webhookSecret,
+		RedirectURL:           "https://app.example.com/integrations/github/callback",
+		WebhookPort:           8081,
+		APIBaseURL:            server.URL,
+		GitHubRepositoryRepo:  githubtest.NewRepositoryStore(),
+		IntegrationRepository: integrations,
+		CredentialRepository:  domaintest.NewCredentialRepository(integrations),
+	}.New()
+
+	mux := http.NewServeMux()
+	if connector.(domain.WebhookRouter).RegisterRoutes(mux, connector.ProcessEvent) {
+		t.Error("RegisterRoutes() = true, want the standalone webhook server to keep the route")
+	}
+}
@@ -8,9 +8,12 @@ const (
 	EventTypePush         EventType = "push"
 	EventTypePullRequest  EventType = "pull_request"
 	EventTypeInstallation EventType = "installation"
-	EventTypeIssues       EventType = "issues"
-	EventTypeRelease      EventType = "release"
-	EventTypeWorkflowRun  EventType = "workflow_run"
+	// EventTypeAppAuthorization is sent when a user revokes their
+	// authorization of the GitHub App.
+	EventTypeAppAuthorization EventType = "github_app_authorization"
+	EventTypeIssues           EventType = "issues"
+	EventTypeRelease          EventType = "release"
+	EventTypeWorkflowRun      EventType = "workflow_run"
 )
 
 type EventSubType string
@@ -61,6 +64,11 @@ type Repository struct {
 	UpdatedAt     time.Time `json:"updated_at"`
 	PushedAt      time.Time `json:"pushed_at"`
 	DefaultBranch string    `json:"default_branch"`
+	// Size is in kilobytes.
+	Size int64 `json:"size"`
+	// Permissions is the installation's access to the repository, as listed
+	// by installation/repositories; webhook payloads omit it.
+	Permissions *RepositoryPermissions `json:"permissions,omitempty"`
 }
 
 type Account struct {
@@ -100,3 +108,50 @@ type WebhookEvent struct {
 	RawPayload          map[string]any
 	CreatedAt           time.Time
 }
+
+// PushEvent holds the fields of a push payload that repository triggers use.
+type PushEvent struct {
+	Ref        string        `json:"ref"`
+	After      string        `json:"after"`
+	Deleted    bool          `json:"deleted"`
+	Compare    string        `json:"compare"`
+	Repository RepositoryRef `json:"repository"`
+	Sender     User          `json:"sender"`
+	HeadCommit *Commit       `json:"head_commit"`
+	// Commits lists at most 20 commits; larger pushes are cut off.
+	Commits []Commit `json:"commits"`
+}
+
+type Commit struct {
+	ID       string   `json:"id"`
+	Message  string   `json:"message"`
+	Added    []string `json:"added"`
+	Removed  []string `json:"removed"`
+	Modified []string `json:"modified"`
+}
+
+// PullRequestEvent holds the fields of a pull_request payload that repository
+// triggers use.
+type PullRequestEvent struct {
+	Action      string        `json:"action"`
+	Number      int           `json:"number"`
+	PullRequest PullRequest   `json:"pull_request"`
+	Repository  RepositoryRef `json:"repository"`
+	Sender      User          `json:"sender"`
+}
+
+type PullRequest struct {
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+	Head    struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	} `json:"head"`
+}
+
+// RepositoryRef is the repository of push and pull request payloads. Unlike
+// Repository it leaves out timestamps, which push payloads send as Unix times.
+type RepositoryRef struct {
+	ID       int64  `json:"id"`
+	FullName string `json:"full_name"`
+}
@@ -1,11 +1,14 @@
 package github
 
 import (
+	"bytes"
 	"context"
 	"crypto/rsa"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
@@ -14,11 +17,16 @@ import (
 	"time"
 
 	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
 	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// repositoriesPerPage is the largest page size GitHub allows for installation repositories.
+const repositoriesPerPage = 100
+
 type GitHubConnector interface {
 	ClaimInstallation(ctx context.Context, installationID string, organizationID, userID uuid.UUID) (*backend.Integration, error)
 }
@@ -27,6 +35,11 @@ type githubConnector struct {
 	config     Config
 	client     *http.Client
 	privateKey *rsa.PrivateKey
+	apiBaseURL string
+	jwtExpiry  time.Duration
+	syncs      *syncLimiter
+	profiling  ProfilingConfig
+	locker     domain.InstallationLocker
 }
 
 func (g *githubConnector) InitiateAuthorization(organizationID string, userID string) (backend.IntegrationAuthorizationIntent, error) {
@@ -64,38 +77,38 @@ func (g *githubConnector) ParseState(state string) (organizationID uuid.UUID, us
 
 	stateJSON, err := base64.URLEncoding.DecodeString(decodedState)
 	if err != nil {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid state format, failed to decode base64: %w", err)
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid state format, failed to decode base64: %w", backend.ErrInvalidState, err)
 	}
 
 	var stateData map[string]any
 	if err := json.Unmarshal(stateJSON, &stateData); err != nil {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid state format, failed to parse JSON: %w", err)
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid state format, failed to parse JSON: %w", backend.ErrInvalidState, err)
 	}
 
 	orgID, exists := stateData["organization_id"]
 	if !exists {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("organization_id not found in state")
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: organization_id not found in state", backend.ErrInvalidState)
 	}
 	orgIDStr, ok := orgID.(string)
 	if !ok {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("organization_id must be a string")
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: organization_id must be a string", backend.ErrInvalidState)
 	}
 	organizationID, err = uuid.Parse(orgIDStr)
 	if err != nil {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid organization_id format: %w", err)
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid organization_id format: %w", backend.ErrInvalidState, err)
 	}
 
 	uID, exists := stateData["user_id"]
 	if !exists {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("user_id not found in state")
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: user_id not found in state", backend.ErrInvalidState)
 	}
 	userIDStr, ok := uID.(string)
 	if !ok {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("user_id must be a string")
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: user_id must be a string", backend.ErrInvalidState)
 	}
 	userID, err = uuid.Parse(userIDStr)
 	if err != nil {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid user_id format: %w", err)
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid user_id format: %w", backend.ErrInvalidState, err)
 	}
 
 	return organizationID, userID, nil
@@ -146,7 +159,7 @@ func (g *githubConnector) ValidateCredentials(creds backend.Credentials) error {
 		return fmt.Errorf("failed to generate JWT: %w", err)
 	}
 
-	_, err = g.getInstallationDetails(jwt, installationID)
+	_, err = g.getInstallationDetails(context.Background(), jwt, installationID)
 	if err != nil {
 		return fmt.Errorf("installation validation failed: %w", err)
 	}
@@ -154,6 +167,28 @@ func (g *githubConnector) ValidateCredentials(creds backend.Credentials) error {
 	return nil
 }
 
+func (g *githubConnector) Permissions(creds backend.Credentials) (map[string]string, error) {
+	installationID, exists := creds.Data["installation_id"]
+	if !exists {
+		return nil, fmt.Errorf("installation ID not found in credentials")
+	}
+
+	jwt, err := g.generateJWT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate JWT: %w", err)
+	}
+
+	installation, err := g.getInstallationDetails(context.Background(), jwt, installationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installation details: %w", err)
+	}
+
+	if installation.Permissions == nil {
+		return map[string]string{}, nil
+	}
+	return installation.Permissions, nil
+}
+
 func (g *githubConnector) RefreshCredentials(creds backend.Credentials) (backend.Credentials, error) {
 	installationID, exists := creds.Data["installation_id"]
 	if !exists {
@@ -165,7 +200,7 @@ func (g *githubConnector) RefreshCredentials(creds backend.Credentials) (backend
 		return backend.Credentials{}, fmt.Errorf("failed to generate JWT: %w", err)
 	}
 
-	accessToken, err := g.getInstallationAccessToken(jwt, installationID)
+	accessToken, err := g.getInstallationAccessToken(context.Background(), jwt, installationID)
 	if err != nil {
 		return backend.Credentials{}, fmt.Errorf("failed to refresh access token: %w", err)
 	}
@@ -179,12 +214,42 @@ func (g *githubConnector) RefreshCredentials(creds backend.Credentials) (backend
 	return newCreds, nil
 }
 
+// MintCredentials mints an installation access token that can only reach
+// scope.Repositories, with scope.Permissions when given. GitHub expires it
+// after an hour.
+func (g *githubConnector) MintCredentials(ctx context.Context, creds backend.Credentials, scope backend.CredentialScope) (backend.MintedCredentials, error) {
+	installationID, exists := creds.Data["installation_id"]
+	if !exists {
+		return backend.MintedCredentials{}, fmt.Errorf("installation ID not found in credentials")
+	}
+
+	accessToken, err := g.scopedInstallationAccessToken(ctx, installationID, scope.Repositories, scope.Permissions)
+	if err != nil {
+		return backend.MintedCredentials{}, fmt.Errorf("failed to mint access token: %w", err)
+	}
+
+	return backend.MintedCredentials{
+		Type:      backend.CredentialTypeToken,
+		Data:      map[string]string{"access_token": accessToken.Token},
+		ExpiresAt: accessToken.ExpiresAt,
+	}, nil
+}
+
 func (g *githubConnector) RevokeCredentials(creds backend.Credentials) error {
 	installationID, exists := creds.Data["installation_id"]
 	if !exists {
 		return fmt.Errorf("installation ID not found in credentials")
 	}
 
+	jwt, err := g.generateJWT()
+	if err != nil {
+		return fmt.Errorf("failed to generate JWT: %w", err)
+	}
+
+	if err := g.deleteInstallation(context.Background(), jwt, installationID); err != nil {
+		return err
+	}
+
 	slog.Info("GitHub credentials revoked", "installation_id", installationID)
 	return nil
 }
@@ -213,8 +278,8 @@ func (g *githubConnector) generateJWT() (string, error) {
 
 	now := time.Now()
 	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
-		"iat": now.Unix(),
-		"exp": now.Add(10 * time.Minute).Unix(),
+		"iat": now.Add(-jwtClockSkew).Unix(),
+		"exp": now.Add(g.jwtExpiry).Unix(),
 		"iss": g.config.AppID,
 	})
 
@@ -226,10 +291,69 @@ func (g *githubConnector) generateJWT() (string, error) {
 	return tokenString, nil
 }
 
-func (g *githubConnector) getInstallationAccessToken(jwt string, installationID string) (*accessTokenResponse, error) {
-	url := fmt.Sprintf("https://api.github.com/app/installations/%s/access_tokens", installationID)
+// Errors returned when GitHub refuses to mint an installation access token.
+var (
+	// ErrGitHubUnauthorized means GitHub rejected the app JWT, which usually
+	// points at a wrong app_id or private_key rather than the installation.
+	ErrGitHubUnauthorized    = errors.New("GitHub rejected the app JWT")
+	ErrInstallationSuspended = errors.New("GitHub App installation is suspended")
+	ErrInstallationNotFound  = errors.New("GitHub App installation not found")
+)
+
+// getInstallationAccessToken mints a token with every permission and
+// repository of the installation, as syncs need.
+func (g *githubConnector) getInstallationAccessToken(ctx context.Context, jwt string, installationID string) (*accessTokenResponse, error) {
+	return g.createInstallationAccessToken(ctx, jwt, installationID, nil)
+}
+
+// scopedInstallationAccessToken mints a token that can only reach
+// repositories, given as "owner/name", with permissions. Nil permissions keep
+// the installation's.
+func (g *githubConnector) scopedInstallationAccessToken(ctx context.Context, installationID string, repositories []string, permissions map[string]string) (*accessTokenResponse, error) {
+	if len(repositories) == 0 {
+		return nil, fmt.Errorf("at least one repository is required")
+	}
+
+	// The token is scoped to repositories of the installation's account, which
+	// GitHub names without the owner.
+	names := make([]string, len(repositories))
+	for i, repository := range repositories {
+		_, name, found := strings.Cut(repository, "/")
+		if !found || name == "" {
+			return nil, fmt.Errorf("repository %q must be owner/name", repository)
+		}
+		names[i] = name
+	}
+
+	jwt, err := g.generateJWT()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate JWT: %w", err)
+	}
+
+	return g.createInstallationAccessToken(ctx, jwt, installationID, &accessTokenRequest{
+		Repositories: names,
+		Permissions:  permissions,
+	})
+}
+
+// createInstallationAccessToken mints a token for the installation, narrowed
+// by scope when it is not nil.
+func (g *githubConnector) createInstallationAccessToken(ctx context.Context, jwt string, installationID string, scope *accessTokenRequest) (_ *accessTokenResponse, err error) {
+	ctx, span := tracing.Start(ctx, "github.get_installation_access_token", attribute.String("github.installation_id", installationID))
+	defer func() { tracing.End(span, err) }()
+
+	url := fmt.Sprintf("%s/app/installations/%s/access_tokens", g.apiBaseURL, installationID)
+
+	var body io.Reader
+	if scope != nil {
+		payload, err := json.Marshal(scope)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal access token request: %w", err)
+		}
+		body = bytes.NewReader(payload)
+	}
 
-	req, err := http.NewRequest("POST", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -245,21 +369,82 @@ func (g *githubConnector) getInstallationAccessToken(jwt string, installationID
 	defer resp.Body.Close()
 
 	var response accessTokenResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode access token response: %w", err)
-	}
+	decodeErr := json.NewDecoder(resp.Body).Decode(&response)
 
-	if resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("GitHub API error: %s", response.Message)
+	switch resp.StatusCode {
+	case http.StatusCreated:
+	case http.StatusUnauthorized:
+		return nil, fmt.Errorf("%w: %s", ErrGitHubUnauthorized, response.Message)
+	case http.StatusForbidden:
+		return nil, fmt.Errorf("%w: installation %s: %s", ErrInstallationSuspended, installationID, response.Message)
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%w: installation %s", ErrInstallationNotFound, installationID)
+	default:
+		return nil, fmt.Errorf("GitHub API error: status %d: %s", resp.StatusCode, response.Message)
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("failed to decode access token response: %w", decodeErr)
 	}
 
 	return &response, nil
 }
 
-func (g *githubConnector) getInstallationDetails(jwt string, installationID string) (*installationResponse, error) {
-	url := fmt.Sprintf("https://api.github.com/app/installations/%s", installationID)
+// checkApp fetches the app the JWT authenticates as. GitHub refuses the JWT
+// when private_key was not generated for app_id.
+func (g *githubConnector) checkApp(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "github.check_app")
+	defer func() { tracing.End(span, err) }()
+
+	jwt, err := g.generateJWT()
+	if err != nil {
+		return fmt.Errorf("failed to generate JWT: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", g.apiBaseURL+"/app", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", jwt))
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach the GitHub API at %s: %w", g.apiBaseURL, err)
+	}
+	defer resp.Body.Close()
+
+	var app struct {
+		ID      int64  `json:"id"`
+		Slug    string `json:"slug"`
+		Message string `json:"message"`
+	}
+	decodeErr := json.NewDecoder(resp.Body).Decode(&app)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return fmt.Errorf("%w: %s; check that private_key was generated for app_id %s and that the host clock is correct",
+			ErrGitHubUnauthorized, app.Message, g.config.AppID)
+	default:
+		return fmt.Errorf("GitHub API error: status %d: %s", resp.StatusCode, app.Message)
+	}
+	if decodeErr != nil {
+		return fmt.Errorf("failed to decode app response: %w", decodeErr)
+	}
+	if strconv.FormatInt(app.ID, 10) != g.config.AppID {
+		return fmt.Errorf("private_key belongs to GitHub App %d (%s), not app_id %s", app.ID, app.Slug, g.config.AppID)
+	}
+	return nil
+}
+
+func (g *githubConnector) getInstallationDetails(ctx context.Context, jwt string, installationID string) (_ *installationResponse, err error) {
+	ctx, span := tracing.Start(ctx, "github.get_installation_details", attribute.String("github.installation_id", installationID))
+	defer func() { tracing.End(span, err) }()
+
+	url := fmt.Sprintf("%s/app/installations/%s", g.apiBaseURL, installationID)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -286,6 +471,37 @@ func (g *githubConnector) getInstallationDetails(jwt string, installationID stri
 	return &response, nil
 }
 
+// deleteInstallation uninstalls the app. An installation that is already gone
+// counts as deleted so that revocation can be retried.
+func (g *githubConnector) deleteInstallation(ctx context.Context, jwt string, installationID string) (err error) {
+	ctx, span := tracing.Start(ctx, "github.delete_installation", attribute.String("github.installation_id", installationID))
+	defer func() { tracing.End(span, err) }()
+
+	url := fmt.Sprintf("%s/app/installations/%s", g.apiBaseURL, installationID)
+
+	req, err := http.NewRequestWithContext(ctx, "DELETE", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", jwt))
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete installation: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("GitHub API error: status %d", resp.StatusCode)
+	}
+}
+
 func (g *githubConnector) formatPermissions(perms map[string]string) string {
 	var parts []string
 	for key, value := range perms {
@@ -304,7 +520,27 @@ func (g *githubConnector) buildWebhookURL() string {
 	return fmt.Sprintf("%s/webhooks/github", baseURL)
 }
 
+// ClaimInstallation holds the installation's lock while claiming it, so a
+// redirect processed twice at once returns the integration created by the
+// first claim instead of creating a second one.
 func (g *githubConnector) ClaimInstallation(ctx context.Context, installationID string, organizationID, userID uuid.UUID) (*backend.Integration, error) {
+	unlock, err := g.locker.LockInstallation(ctx, backend.ConnectorTypeGithub, installationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock installation %s: %w", installationID, err)
+	}
+	defer unlock()
+
+	integration, err := g.claimInstallation(ctx, installationID, organizationID, userID)
+	if errors.Is(err, backend.ErrIntegrationAlreadyExists) {
+		// Another replica without the lock stored it first.
+		if existing, findErr := g.config.IntegrationRepository.FindByBotIDAndType(ctx, installationID, backend.ConnectorTypeGithub); findErr == nil {
+			return &existing, nil
+		}
+	}
+	return integration, err
+}
+
+func (g *githubConnector) claimInstallation(ctx context.Context, installationID string, organizationID, userID uuid.UUID) (*backend.Integration, error) {
 	// First check if there's already an integration for this installation_id
 	existingIntegrationByBotID, err := g.config.IntegrationRepository.FindByBotIDAndType(ctx, installationID, backend.ConnectorTypeGithub)
 	if err == nil {
@@ -335,7 +571,7 @@ func (g *githubConnector) ClaimInstallation(ctx context.Context, installationID
 			return nil, fmt.Errorf("failed to generate JWT: %w", err)
 		}
 
-		installationDetails, err := g.getInstallationDetails(jwt, installationID)
+		installationDetails, err := g.getInstallationDetails(ctx, jwt, installationID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to get installation details from GitHub: %w", err)
 		}
@@ -377,7 +613,7 @@ func (g *githubConnector) ClaimInstallation(ctx context.Context, installationID
 		return nil, fmt.Errorf("failed to generate JWT: %w", err)
 	}
 
-	installationDetails, err := g.getInstallationDetails(jwt, installationID)
+	installationDetails, err := g.getInstallationDetails(ctx, jwt, installationID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get installation details from GitHub: %w", err)
 	}
@@ -406,7 +642,7 @@ func (g *githubConnector) ClaimInstallation(ctx context.Context, installationID
 	if err := g.config.IntegrationRepository.Store(ctx, *integration); err != nil {
 		return nil, fmt.Errorf("failed to store integration: %w", err)
 	}
-	accessToken, err := g.getInstallationAccessToken(jwt, installationID)
+	accessToken, err := g.getInstallationAccessToken(ctx, jwt, installationID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get access token: %w", err)
 	}
@@ -439,7 +675,7 @@ func (g *githubConnector) ClaimInstallation(ctx context.Context, installationID
 		return nil, fmt.Errorf("failed to store credentials: %w", err)
 	}
 
-	if err := g.syncRepositories(ctx, integration.ID, installationID); err != nil {
+	if _, err := g.syncRepositories(ctx, integration.ID, installationID); err != nil {
 		slog.Error("failed to sync repositories during installation claim",
 			"integration_id", integration.ID,
 			"installation_id", installationID,
@@ -449,30 +685,47 @@ func (g *githubConnector) ClaimInstallation(ctx context.Context, installationID
 	return integration, nil
 }
 
-func (g *githubConnector) syncRepositories(ctx context.Context, integrationID uuid.UUID, installationID string) error {
+func (g *githubConnector) syncRepositories(ctx context.Context, integrationID uuid.UUID, installationID string) (backend.SyncResult, error) {
+	release, err := g.syncs.acquire(ctx)
+	if err != nil {
+		return backend.SyncResult{}, fmt.Errorf("failed to wait for a sync slot: %w", err)
+	}
+	defer release()
+
 	slog.Info("syncing repositories",
 		"integration_id", integrationID,
 		"installation_id", installationID)
 
 	jwt, err := g.generateJWT()
 	if err != nil {
-		return fmt.Errorf("failed to generate JWT: %w", err)
+		return backend.SyncResult{}, fmt.Errorf("failed to generate JWT: %w", err)
 	}
 
-	accessToken, err := g.getInstallationAccessToken(jwt, installationID)
+	accessToken, err := g.getInstallationAccessToken(ctx, jwt, installationID)
 	if err != nil {
-		return fmt.Errorf("failed to get access token: %w", err)
+		return backend.SyncResult{}, fmt.Errorf("failed to get access token: %w", err)
 	}
-	repositories, err := g.fetchInstallationRepositories(accessToken.Token)
+	repositories, err := g.fetchInstallationRepositories(ctx, accessToken.Token)
 	if err != nil {
-		return fmt.Errorf("failed to fetch repositories: %w", err)
+		return backend.SyncResult{}, fmt.Errorf("failed to fetch repositories: %w", err)
 	}
 
 	slog.Info("fetched repositories from GitHub",
 		"integration_id", integrationID,
 		"repository_count", len(repositories))
 
+	stored, err := g.config.GitHubRepositoryRepo.ListByIntegrationID(ctx, integrationID)
+	if err != nil {
+		return backend.SyncResult{}, fmt.Errorf("failed to list stored repositories: %w", err)
+	}
+	previous := make(map[int64]GitHubRepository, len(stored))
+	for _, repo := range stored {
+		previous[repo.GitHubRepositoryID] = repo
+	}
+
+	var result backend.SyncResult
 	for _, repo := range repositories {
+		permissions := initialPermissions(repo)
 		githubRepo := GitHubRepository{
 			ID:                    uuid.New(),
 			IntegrationID:         integrationID,
@@ -482,9 +735,9 @@ func (g *githubConnector) syncRepositories(ctx context.Context, integrationID uu
 			RepositoryURL:         repo.HTMLURL,
 			IsPrivate:             repo.Private,
 			DefaultBranch:         repo.DefaultBranch,
-			PermissionAdmin:       false,
-			PermissionPush:        false,
-			PermissionPull:        true,
+			PermissionAdmin:       permissions.Admin,
+			PermissionPush:        permissions.Push,
+			PermissionPull:        permissions.Pull,
 			RepositoryDescription: repo.Description,
 			RepositoryLanguage:    repo.Language,
 			CreatedAt:             time.Now(),
@@ -501,15 +754,78 @@ func (g *githubConnector) syncRepositories(ctx context.Context, integrationID uu
 				"repository_id", repo.ID,
 				"repository_name", repo.FullName,
 				"error", err)
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", repo.FullName, err))
 			continue
 		}
+
+		before, existed := previous[repo.ID]
+		switch {
+		case !existed:
+			result.Added++
+		case repositoryChanged(before, githubRepo):
+			result.Updated++
+		}
+	}
+
+	// Repositories dropped from a selected installation are only announced by
+	// webhook, which may have been missed; prune whatever GitHub stopped listing.
+	fetched := make(map[int64]bool, len(repositories))
+	for _, repo := range repositories {
+		fetched[repo.ID] = true
+	}
+	var stale []int64
+	for _, repo := range stored {
+		if !fetched[repo.GitHubRepositoryID] {
+			stale = append(stale, repo.GitHubRepositoryID)
+		}
+	}
+	if len(stale) > 0 {
+		removed, err := g.removeRepositories(ctx, integrationID, stale)
+		if err != nil {
+			slog.Error("failed to remove inaccessible repositories", "integration_id", integrationID, "error", err)
+			result.Errors = append(result.Errors, fmt.Sprintf("removing %d inaccessible repositories: %v", len(stale), err))
+		}
+		result.Removed = removed
 	}
 
 	if err := g.config.GitHubRepositoryRepo.UpdateLastSyncTime(ctx, integrationID, time.Now()); err != nil {
 		slog.Error("failed to update last sync time", "integration_id", integrationID, "error", err)
 	}
 
-	return nil
+	if g.profiling.Enabled {
+		g.profileRepositories(ctx, integrationID, accessToken.Token, repositories, previous)
+	}
+
+	slog.Info("synced repositories",
+		"integration_id", integrationID,
+		"added", result.Added,
+		"removed", result.Removed,
+		"updated", result.Updated,
+		"errors", len(result.Errors))
+
+	return result, nil
+}
+
+// initialPermissions returns the permissions stored with a repository the
+// first time it is seen. Stored repositories keep theirs on upsert, so that
+// syncRepositoryPermissions can tell what changed.
+func initialPermissions(repo Repository) RepositoryPermissions {
+	if repo.Permissions != nil {
+		return *repo.Permissions
+	}
+	return RepositoryPermissions{Pull: true}
+}
+
+// repositoryChanged reports whether a sync changed the repository details
+// GitHub owns, ignoring sync times and the locally managed fields.
+func repositoryChanged(stored, fetched GitHubRepository) bool {
+	return stored.RepositoryName != fetched.RepositoryName ||
+		stored.RepositoryFullName != fetched.RepositoryFullName ||
+		stored.RepositoryURL != fetched.RepositoryURL ||
+		stored.IsPrivate != fetched.IsPrivate ||
+		stored.DefaultBranch != fetched.DefaultBranch ||
+		stored.RepositoryDescription != fetched.RepositoryDescription ||
+		stored.RepositoryLanguage != fetched.RepositoryLanguage
 }
 
 func (g *githubConnector) addRepositories(ctx context.Context, integrationID uuid.UUID, repositories []Repository) error {
@@ -518,6 +834,7 @@ func (g *githubConnector) addRepositories(ctx context.Context, integrationID uui
 		"repository_count", len(repositories))
 
 	for _, repo := range repositories {
+		permissions := initialPermissions(repo)
 		githubRepo := GitHubRepository{
 			ID:                    uuid.New(),
 			IntegrationID:         integrationID,
@@ -527,9 +844,9 @@ func (g *githubConnector) addRepositories(ctx context.Context, integrationID uui
 			RepositoryURL:         repo.HTMLURL,
 			IsPrivate:             repo.Private,
 			DefaultBranch:         repo.DefaultBranch,
-			PermissionAdmin:       false,
-			PermissionPush:        false,
-			PermissionPull:        true,
+			PermissionAdmin:       permissions.Admin,
+			PermissionPush:        permissions.Push,
+			PermissionPull:        permissions.Pull,
 			RepositoryDescription: repo.Description,
 			RepositoryLanguage:    repo.Language,
 			CreatedAt:             time.Now(),
@@ -553,48 +870,81 @@ func (g *githubConnector) addRepositories(ctx context.Context, integrationID uui
 	return nil
 }
 
-func (g *githubConnector) removeRepositories(ctx context.Context, integrationID uuid.UUID, repositoryIDs []int64) error {
+// removeRepositories deletes the stored repositories and returns how many
+// were still stored.
+func (g *githubConnector) removeRepositories(ctx context.Context, integrationID uuid.UUID, repositoryIDs []int64) (int, error) {
 	slog.Info("removing repositories",
 		"integration_id", integrationID,
 		"repository_count", len(repositoryIDs))
 
-	if err := g.config.GitHubRepositoryRepo.BulkDelete(ctx, integrationID, repositoryIDs); err != nil {
-		return fmt.Errorf("failed to bulk delete repositories: %w", err)
+	deletions, err := g.config.GitHubRepositoryRepo.BulkDelete(ctx, integrationID, repositoryIDs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to bulk delete repositories: %w", err)
 	}
 
-	return nil
+	var removed, missing []int64
+	for _, deletion := range deletions {
+		if deletion.Removed {
+			removed = append(removed, deletion.RepositoryID)
+		} else {
+			missing = append(missing, deletion.RepositoryID)
+		}
+	}
+	slog.Info("removed repositories",
+		"integration_id", integrationID,
+		"removed_repository_ids", removed,
+		"already_removed_repository_ids", missing)
+
+	return len(removed), nil
 }
 
-func (g *githubConnector) fetchInstallationRepositories(accessToken string) ([]Repository, error) {
-	req, err := http.NewRequest("GET", "https://api.github.com/installation/repositories", nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+func (g *githubConnector) fetchInstallationRepositories(ctx context.Context, accessToken string) (_ []Repository, err error) {
+	ctx, span := tracing.Start(ctx, "github.fetch_installation_repositories")
+	defer func() { tracing.End(span, err) }()
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
-	req.Header.Set("Accept", "application/vnd.github+json")
-	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	var repositories []Repository
+	for page := 1; ; page++ {
+		url := fmt.Sprintf("%s/installation/repositories?per_page=%d&page=%d", g.apiBaseURL, repositoriesPerPage, page)
+		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
 
-	resp, err := g.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch repositories: %w", err)
-	}
-	defer resp.Body.Close()
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("GitHub API error: status %d", resp.StatusCode)
-	}
+		resp, err := g.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch repositories: %w", err)
+		}
 
-	var response struct {
-		TotalCount   int          `json:"total_count"`
-		Repositories []Repository `json:"repositories"`
-	}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("GitHub API error: status %d", resp.StatusCode)
+		}
 
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return nil, fmt.Errorf("failed to decode repositories response: %w", err)
+		var response struct {
+			TotalCount   int          `json:"total_count"`
+			Repositories []Repository `json:"repositories"`
+		}
+
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode repositories response: %w", err)
+		}
+
+		repositories = append(repositories, response.Repositories...)
+		if len(response.Repositories) == 0 || len(repositories) >= response.TotalCount {
+			return repositories, nil
+		}
 	}
+}
 
-	return response.Repositories, nil
+type accessTokenRequest struct {
+	Repositories []string          `json:"repositories,omitempty"`
+	Permissions  map[string]string `json:"permissions,omitempty"`
 }
 
 type accessTokenResponse struct {
@@ -616,16 +966,97 @@ type accountResponse struct {
 	Type  string `json:"type"`
 }
 
-func (g *githubConnector) Sync(ctx context.Context, integration backend.Integration, params map[string]string) error {
-	if err := g.syncRepositoriesForIntegration(ctx, integration); err != nil {
-		return fmt.Errorf("failed to sync repositories: %w", err)
+func (g *githubConnector) Sync(ctx context.Context, integration backend.Integration, params map[string]string) (backend.SyncResult, error) {
+	// The integration may have been loaded before a suspend or delete webhook
+	// arrived, so its status is read again before GitHub is called.
+	current, err := g.config.IntegrationRepository.FindByID(ctx, integration.ID)
+	if err != nil {
+		return backend.SyncResult{}, fmt.Errorf("failed to find integration: %w", err)
+	}
+	if err := current.CheckCredentialsUsable(); err != nil {
+		return backend.SyncResult{}, err
+	}
+
+	result, err := g.syncRepositoriesForIntegration(ctx, integration)
+	if err != nil {
+		return backend.SyncResult{}, fmt.Errorf("failed to sync repositories: %w", err)
 	}
 
 	if err := g.syncRepositoryPermissions(ctx, integration); err != nil {
-		return fmt.Errorf("failed to sync repository permissions: %w", err)
+		return result, fmt.Errorf("failed to sync repository permissions: %w", err)
 	}
 
-	return nil
+	return result, nil
+}
+
+func (g *githubConnector) SyncStatus(ctx context.Context, integration backend.Integration) (backend.IntegrationSyncStatus, error) {
+	repositories, err := g.config.GitHubRepositoryRepo.ListByIntegrationID(ctx, integration.ID)
+	if err != nil {
+		return backend.IntegrationSyncStatus{}, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	var lastSyncedAt *time.Time
+	accessible := 0
+	for _, repo := range repositories {
+		if repo.PermissionPull {
+			accessible++
+		}
+		if !repo.LastSyncedAt.IsZero() && (lastSyncedAt == nil || repo.LastSyncedAt.After(*lastSyncedAt)) {
+			syncedAt := repo.LastSyncedAt
+			lastSyncedAt = &syncedAt
+		}
+	}
+
+	total := len(repositories)
+	return backend.IntegrationSyncStatus{
+		LastSyncedAt:              lastSyncedAt,
+		RepositoryCount:           &total,
+		AccessibleRepositoryCount: &accessible,
+	}, nil
+}
+
+func (g *githubConnector) Repositories(ctx context.Context, integration backend.Integration) ([]backend.SyncedRepository, error) {
+	repositories, err := g.config.GitHubRepositoryRepo.ListByIntegrationID(ctx, integration.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	synced := make([]backend.SyncedRepository, len(repositories))
+	for i, repo := range repositories {
+		synced[i] = backend.SyncedRepository{
+			ID:            repo.GitHubRepositoryID,
+			Name:          repo.RepositoryName,
+			FullName:      repo.RepositoryFullName,
+			URL:           repo.RepositoryURL,
+			Private:       repo.IsPrivate,
+			DefaultBranch: repo.DefaultBranch,
+			Permissions: backend.RepositoryPermissions{
+				Admin: repo.PermissionAdmin,
+				Push:  repo.PermissionPush,
+				Pull:  repo.PermissionPull,
+			},
+			Enabled:      repo.Enabled,
+			LastSyncedAt: repo.LastSyncedAt,
+		}
+		if repo.Profile != nil {
+			synced[i].Profile = &backend.RepositoryProfile{
+				Languages:       repo.Profile.Languages,
+				PrimaryLanguage: repo.Profile.PrimaryLanguage,
+				Markers:         repo.Profile.Markers,
+				TreeTruncated:   repo.Profile.TreeTruncated,
+				ProfiledAt:      repo.Profile.ProfiledAt,
+			}
+		}
+	}
+	return synced, nil
+}
+
+func (g *githubConnector) SetRepositoriesEnabled(ctx context.Context, integration backend.Integration, repositoryIDs []int64, enabled bool) (int, error) {
+	updated, err := g.config.GitHubRepositoryRepo.SetEnabled(ctx, integration.ID, repositoryIDs, enabled)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update repositories: %w", err)
+	}
+	return updated, nil
 }
 
 func (g *githubConnector) syncRepositoryPermissions(ctx context.Context, integration backend.Integration) error {
@@ -641,28 +1072,40 @@ func (g *githubConnector) syncRepositoryPermissions(ctx context.Context, integra
 		return fmt.Errorf("failed to generate JWT: %w", err)
 	}
 
-	accessToken, err := g.getInstallationAccessToken(jwt, installationID)
+	accessToken, err := g.getInstallationAccessToken(ctx, jwt, installationID)
 	if err != nil {
 		return fmt.Errorf("failed to get access token: %w", err)
 	}
 
-	installationDetails, err := g.getInstallationDetails(jwt, installationID)
+	installationDetails, err := g.getInstallationDetails(ctx, jwt, installationID)
 	if err != nil {
 		return fmt.Errorf("failed to get installation details: %w", err)
 	}
-	repositories, err := g.fetchInstallationRepositories(accessToken.Token)
+	repositories, err := g.fetchInstallationRepositories(ctx, accessToken.Token)
 	if err != nil {
 		return fmt.Errorf("failed to fetch repositories: %w", err)
 	}
 
-	defaultPermissions := RepositoryPermissions{
-		Admin: false,
-		Push:  true,
-		Pull:  true,
+	// Repositories GitHub lists without permissions keep their stored ones.
+	fetched := make(map[int64]RepositoryPermissions, len(repositories))
+	for _, repo := range repositories {
+		if repo.Permissions != nil {
+			fetched[repo.ID] = *repo.Permissions
+		}
 	}
 
+	stored, err := g.config.GitHubRepositoryRepo.ListByIntegrationID(ctx, integrationUUID)
+	if err != nil {
+		return fmt.Errorf("failed to list stored repositories: %w", err)
+	}
+	g.reportPermissionChanges(integration, diffRepositoryPermissions(stored, fetched))
+
 	for _, repo := range repositories {
-		if err := g.config.GitHubRepositoryRepo.UpdatePermissions(ctx, integrationUUID, repo.ID, defaultPermissions); err != nil {
+		permissions, ok := fetched[repo.ID]
+		if !ok {
+			continue
+		}
+		if err := g.config.GitHubRepositoryRepo.UpdatePermissions(ctx, integrationUUID, repo.ID, permissions); err != nil {
 			slog.Error("failed to update repository permissions",
 				"integration_id", integration.ID,
 				"repository_id", repo.ID,
@@ -681,6 +1124,32 @@ func (g *githubConnector) syncRepositoryPermissions(ctx context.Context, integra
 	return nil
 }
 
+func (g *githubConnector) reportPermissionChanges(integration backend.Integration, changes []PermissionChange) {
+	if len(changes) == 0 {
+		return
+	}
+
+	var pushRevoked []string
+	for _, change := range changes {
+		if change.LostPush() {
+			pushRevoked = append(pushRevoked, change.FullName)
+		}
+	}
+
+	slog.Info("repository permissions changed",
+		"integration_id", integration.ID,
+		"organization_id", integration.OrganizationID,
+		"changed_count", len(changes),
+		"changes", changes)
+
+	if len(pushRevoked) > 0 {
+		slog.Warn("push access revoked, PR automation is disabled for these repositories",
+			"integration_id", integration.ID,
+			"organization_id", integration.OrganizationID,
+			"repositories", pushRevoked)
+	}
+}
+
 func (g *githubConnector) syncInstallation(ctx context.Context, integration backend.Integration, params map[string]string) error {
 	installationID := params["installation_id"]
 	if installationID == "" {
@@ -690,15 +1159,16 @@ func (g *githubConnector) syncInstallation(ctx context.Context, integration back
 		return fmt.Errorf("installation_id is required for GitHub installation sync")
 	}
 
-	return g.syncRepositoriesForIntegration(ctx, integration)
+	_, err := g.syncRepositoriesForIntegration(ctx, integration)
+	return err
 }
 
-func (g *githubConnector) syncRepositoriesForIntegration(ctx context.Context, integration backend.Integration) error {
+func (g *githubConnector) syncRepositoriesForIntegration(ctx context.Context, integration backend.Integration) (backend.SyncResult, error) {
 	integrationUUID := integration.ID
 
 	installationID := integration.BotID
 	if installationID == "" {
-		return fmt.Errorf("installation ID not found in integration")
+		return backend.SyncResult{}, fmt.Errorf("installation ID not found in integration")
 	}
 
 	return g.syncRepositories(ctx, integrationUUID, installationID)
@@ -0,0 +1,230 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+type memoryRepositoryStore struct {
+	GitHubRepositoryRepository
+	repos []GitHubRepository
+}
+
+func (m *memoryRepositoryStore) Store(ctx context.Context, repo GitHubRepository) error {
+	m.repos = append(m.repos, repo)
+	return nil
+}
+
+func (m *memoryRepositoryStore) ListByIntegrationID(ctx context.Context, integrationID uuid.UUID) ([]GitHubRepository, error) {
+	var repos []GitHubRepository
+	for _, repo := range m.repos {
+		if repo.IntegrationID == integrationID {
+			repos = append(repos, repo)
+		}
+	}
+	return repos, nil
+}
+
+func TestSyncStatus(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryRepositoryStore{}
+	g := &githubConnector{config: Config{GitHubRepositoryRepo: store}}
+
+	integration := backend.Integration{ID: uuid.New()}
+	older := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	for _, repo := range []GitHubRepository{
+		{IntegrationID: integration.ID, GitHubRepositoryID: 1, PermissionPull: true, LastSyncedAt: older},
+		{IntegrationID: integration.ID, GitHubRepositoryID: 2, PermissionPull: true, LastSyncedAt: newer},
+		{IntegrationID: integration.ID, GitHubRepositoryID: 3, LastSyncedAt: older},
+		{IntegrationID: uuid.New(), GitHubRepositoryID: 4, PermissionPull: true, LastSyncedAt: newer.Add(time.Hour)},
+	} {
+		if err := store.Store(ctx, repo); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+	}
+
+	status, err := g.SyncStatus(ctx, integration)
+	if err != nil {
+		t.Fatalf("SyncStatus() error = %v", err)
+	}
+
+	if status.RepositoryCount == nil || *status.RepositoryCount != 3 {
+		t.Errorf("RepositoryCount = %v, want 3", status.RepositoryCount)
+	}
+	if status.AccessibleRepositoryCount == nil || *status.AccessibleRepositoryCount != 2 {
+		t.Errorf("AccessibleRepositoryCount = %v, want 2", status.AccessibleRepositoryCount)
+	}
+	if status.LastSyncedAt == nil || !status.LastSyncedAt.Equal(newer) {
+		t.Errorf("LastSyncedAt = %v, want %v", status.LastSyncedAt, newer)
+	}
+
+	empty, err := g.SyncStatus(ctx, backend.Integration{ID: uuid.New()})
+	if err != nil {
+		t.Fatalf("SyncStatus() error = %v", err)
+	}
+	if *empty.RepositoryCount != 0 || empty.LastSyncedAt != nil {
+		t.Errorf("empty status = %+v, want zero count and nil last sync", empty)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	valid := Config{
+		AppID:         "1",
+		AppName:       "infragpt",
+		PrivateKey:    string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		WebhookSecret: "0123456789abcdef",
+		RedirectURL:   "https://app.example.com/callback",
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	invalid := valid
+	invalid.AppName = ""
+	invalid.PrivateKey = "not a key"
+	invalid.WebhookSecret = "short"
+	invalid.AppID = "Iv1.8a61f9b3a7aba766"
+	invalid.RedirectURL = "/callback"
+
+	err = invalid.Validate()
+	if err == nil {
+		t.Fatal("Validate() error = nil, want error")
+	}
+	for _, want := range []string{"missing app_name", "private_key is not a valid PEM", "webhook_secret must be at least", "app_id \"Iv1.8a61f9b3a7aba766\" is not numeric", "must be an absolute URL"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() error = %q, want it to mention %q", err, want)
+		}
+	}
+}
+
+func TestGenerateJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	config := Config{
+		AppID:         "1",
+		AppName:       "infragpt",
+		PrivateKey:    string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		WebhookSecret: "0123456789abcdef",
+		RedirectURL:   "https://app.example.com/callback",
+	}
+
+	tests := []struct {
+		name          string
+		expirySeconds int
+		wantExpiry    time.Duration
+	}{
+		{name: "default", wantExpiry: 10 * time.Minute},
+		{name: "configured", expirySeconds: 300, wantExpiry: 5 * time.Minute},
+		{name: "capped", expirySeconds: 3600, wantExpiry: 10 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := config
+			c.JWTExpirySeconds = tt.expirySeconds
+			connector := c.New().(*githubConnector)
+
+			before := time.Now().Truncate(time.Second)
+			signed, err := connector.generateJWT()
+			if err != nil {
+				t.Fatalf("generateJWT() error = %v", err)
+			}
+			after := time.Now()
+
+			var claims jwt.RegisteredClaims
+			if _, err := jwt.ParseWithClaims(signed, &claims, func(*jwt.Token) (any, error) { return &key.PublicKey, nil }); err != nil {
+				t.Fatalf("ParseWithClaims() error = %v", err)
+			}
+			if claims.Issuer != "1" {
+				t.Errorf("iss = %q, want 1", claims.Issuer)
+			}
+			iat, exp := claims.IssuedAt.Time, claims.ExpiresAt.Time
+			if iat.Before(before.Add(-jwtClockSkew)) || iat.After(after.Add(-jwtClockSkew)) {
+				t.Errorf("iat = %s, want 60s before now (%s..%s)", iat, before, after)
+			}
+			if exp.Before(before.Add(tt.wantExpiry)) || exp.After(after.Add(tt.wantExpiry)) {
+				t.Errorf("exp = %s, want %s after now", exp, tt.wantExpiry)
+			}
+		})
+	}
+}
+
+func TestDiffRepositoryPermissions(t *testing.T) {
+	stored := []GitHubRepository{
+		{GitHubRepositoryID: 1, RepositoryFullName: "acme/api", PermissionPull: true, PermissionPush: true},
+		{GitHubRepositoryID: 2, RepositoryFullName: "acme/web", PermissionPull: true},
+		{GitHubRepositoryID: 3, RepositoryFullName: "acme/infra", PermissionPull: true, PermissionPush: true},
+		{GitHubRepositoryID: 4, RepositoryFullName: "acme/removed", PermissionPull: true},
+	}
+	fetched := map[int64]RepositoryPermissions{
+		1: {Pull: true},
+		2: {Pull: true, Push: true, Admin: true},
+		3: {Pull: true, Push: true},
+		5: {Pull: true},
+	}
+
+	changes := diffRepositoryPermissions(stored, fetched)
+	if len(changes) != 2 {
+		t.Fatalf("diffRepositoryPermissions() = %+v, want changes for acme/api and acme/web", changes)
+	}
+
+	api, web := changes[0], changes[1]
+	if api.FullName != "acme/api" || len(api.Gained) != 0 || strings.Join(api.Lost, ",") != "push" || !api.LostPush() {
+		t.Errorf("acme/api change = %+v, want push lost", api)
+	}
+	if web.FullName != "acme/web" || strings.Join(web.Gained, ",") != "admin,push" || len(web.Lost) != 0 || web.LostPush() {
+		t.Errorf("acme/web change = %+v, want admin and push gained", web)
+	}
+}
+
+func TestSyncLimiter(t *testing.T) {
+	limiter := newSyncLimiter(1)
+
+	release, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	queued := make(chan error, 1)
+	go func() {
+		_, err := limiter.acquire(ctx)
+		queued <- err
+	}()
+
+	select {
+	case err := <-queued:
+		t.Fatalf("acquire() beyond the limit returned %v, want it to wait", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	cancel()
+	if err := <-queued; !errors.Is(err, context.Canceled) {
+		t.Errorf("queued acquire() after cancel error = %v, want context.Canceled", err)
+	}
+
+	release()
+	next, err := limiter.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire() after release error = %v", err)
+	}
+	next()
+}
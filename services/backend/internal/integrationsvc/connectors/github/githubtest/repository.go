@@ -0,0 +1,159 @@
+package githubtest
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/github"
+	"github.com/google/uuid"
+)
+
+type repositoryKey struct {
+	integrationID uuid.UUID
+	repositoryID  int64
+}
+
+type repositoryStore struct {
+	mu           sync.RWMutex
+	repositories map[repositoryKey]github.GitHubRepository
+}
+
+// NewRepositoryStore returns an in-memory github.GitHubRepositoryRepository that
+// upserts on (integration, repository) like the Postgres implementation.
+func NewRepositoryStore() github.GitHubRepositoryRepository {
+	return &repositoryStore{
+		repositories: make(map[repositoryKey]github.GitHubRepository),
+	}
+}
+
+func (s *repositoryStore) Store(ctx context.Context, repo github.GitHubRepository) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := repositoryKey{repo.IntegrationID, repo.GitHubRepositoryID}
+	repo.Enabled = true
+	if existing, ok := s.repositories[key]; ok {
+		repo.ID = existing.ID
+		repo.CreatedAt = existing.CreatedAt
+		repo.GitHubCreatedAt = existing.GitHubCreatedAt
+		repo.Enabled = existing.Enabled
+		repo.Profile = existing.Profile
+		repo.PermissionAdmin = existing.PermissionAdmin
+		repo.PermissionPush = existing.PermissionPush
+		repo.PermissionPull = existing.PermissionPull
+	}
+	s.repositories[key] = repo
+	return nil
+}
+
+func (s *repositoryStore) ListByIntegrationID(ctx context.Context, integrationID uuid.UUID) ([]github.GitHubRepository, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var repos []github.GitHubRepository
+	for key, repo := range s.repositories {
+		if key.integrationID == integrationID {
+			repos = append(repos, repo)
+		}
+	}
+	slices.SortFunc(repos, func(a, b github.GitHubRepository) int {
+		return strings.Compare(a.RepositoryFullName, b.RepositoryFullName)
+	})
+	return repos, nil
+}
+
+func (s *repositoryStore) GetByGitHubID(ctx context.Context, integrationID uuid.UUID, repositoryID int64) (github.GitHubRepository, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.repositories[repositoryKey{integrationID, repositoryID}], nil
+}
+
+func (s *repositoryStore) DeleteByGitHubID(ctx context.Context, integrationID uuid.UUID, repositoryID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.repositories, repositoryKey{integrationID, repositoryID})
+	return nil
+}
+
+func (s *repositoryStore) UpdatePermissions(ctx context.Context, integrationID uuid.UUID, repositoryID int64, permissions github.RepositoryPermissions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := repositoryKey{integrationID, repositoryID}
+	repo, ok := s.repositories[key]
+	if !ok {
+		return nil
+	}
+	repo.PermissionAdmin = permissions.Admin
+	repo.PermissionPush = permissions.Push
+	repo.PermissionPull = permissions.Pull
+	repo.UpdatedAt = time.Now()
+	s.repositories[key] = repo
+	return nil
+}
+
+func (s *repositoryStore) SetEnabled(ctx context.Context, integrationID uuid.UUID, repositoryIDs []int64, enabled bool) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	updated := 0
+	for _, id := range repositoryIDs {
+		key := repositoryKey{integrationID, id}
+		repo, ok := s.repositories[key]
+		if !ok {
+			continue
+		}
+		repo.Enabled = enabled
+		repo.UpdatedAt = time.Now()
+		s.repositories[key] = repo
+		updated++
+	}
+	return updated, nil
+}
+
+func (s *repositoryStore) BulkDelete(ctx context.Context, integrationID uuid.UUID, repositoryIDs []int64) ([]github.RepositoryDeletion, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deletions []github.RepositoryDeletion
+	for _, id := range repositoryIDs {
+		key := repositoryKey{integrationID, id}
+		_, ok := s.repositories[key]
+		delete(s.repositories, key)
+		deletions = append(deletions, github.RepositoryDeletion{RepositoryID: id, Removed: ok})
+	}
+	return deletions, nil
+}
+
+func (s *repositoryStore) UpdateProfile(ctx context.Context, integrationID uuid.UUID, repositoryID int64, profile github.RepositoryProfile) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := repositoryKey{integrationID, repositoryID}
+	repo, ok := s.repositories[key]
+	if !ok {
+		return nil
+	}
+	repo.Profile = &profile
+	repo.UpdatedAt = time.Now()
+	s.repositories[key] = repo
+	return nil
+}
+
+func (s *repositoryStore) UpdateLastSyncTime(ctx context.Context, integrationID uuid.UUID, syncTime time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, repo := range s.repositories {
+		if key.integrationID == integrationID {
+			repo.LastSyncedAt = syncTime
+			s.repositories[key] = repo
+		}
+	}
+	return nil
+}
@@ -0,0 +1,426 @@
+// Package githubtest provides an in-process fake of the GitHub App API and
+// helpers for sending signed webhook deliveries to the GitHub connector.
+package githubtest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/github"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Server fakes the subset of the GitHub App API used by the connector.
+// App endpoints require a JWT signed with the key returned by PrivateKeyPEM;
+// installation endpoints require a token minted by the server.
+type Server struct {
+	*httptest.Server
+
+	AppID string
+	// PageSize caps the number of repositories returned per page regardless of
+	// the per_page the client asks for, so pagination can be exercised.
+	PageSize int
+
+	privateKey *rsa.PrivateKey
+
+	mu            sync.Mutex
+	installations map[int64]github.Installation
+	repositories  map[int64][]github.Repository
+	tokens        map[string]int64
+	tokenCount    int
+	suspended     map[int64]bool
+	contents      map[string]contents
+	profiled      []string
+	tokenRequests []TokenRequest
+}
+
+// TokenRequest is the scope the connector asked for when minting an
+// installation access token. Both fields are empty for unscoped tokens.
+type TokenRequest struct {
+	InstallationID int64
+	Repositories   []string          `json:"repositories"`
+	Permissions    map[string]string `json:"permissions"`
+}
+
+type contents struct {
+	languages map[string]int64
+	paths     []string
+	truncated bool
+}
+
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate app key: %v", err)
+	}
+
+	s := &Server{
+		AppID:         "12345",
+		PageSize:      100,
+		privateKey:    privateKey,
+		installations: make(map[int64]github.Installation),
+		repositories:  make(map[int64][]github.Repository),
+		tokens:        make(map[string]int64),
+		suspended:     make(map[int64]bool),
+		contents:      make(map[string]contents),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /app", s.app)
+	mux.HandleFunc("POST /app/installations/{id}/access_tokens", s.createAccessToken)
+	mux.HandleFunc("GET /app/installations/{id}", s.installation)
+	mux.HandleFunc("DELETE /app/installations/{id}", s.deleteInstallation)
+	mux.HandleFunc("GET /installation/repositories", s.installationRepositories)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/languages", s.repositoryLanguages)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/git/trees/{ref...}", s.repositoryTree)
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// PrivateKeyPEM returns the app private key in the format expected by the connector config.
+func (s *Server) PrivateKeyPEM() string {
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(s.privateKey),
+	}))
+}
+
+// AddInstallation registers an installation and the repositories it can access.
+func (s *Server) AddInstallation(installation github.Installation, repositories ...github.Repository) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.installations[installation.ID] = installation
+	s.repositories[installation.ID] = repositories
+}
+
+// SetContents sets the language breakdown and file paths of a repository's
+// default branch. Repositories without contents are empty.
+func (s *Server) SetContents(fullName string, languages map[string]int64, truncated bool, paths ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.contents[fullName] = contents{languages: languages, paths: paths, truncated: truncated}
+}
+
+// Profiled returns the full names of the repositories whose languages were
+// fetched, in order.
+func (s *Server) Profiled() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.profiled)
+}
+
+// TokenRequests returns the scope of every access token minted, in order.
+func (s *Server) TokenRequests() []TokenRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.tokenRequests)
+}
+
+// HasInstallation reports whether the installation is still registered.
+func (s *Server) HasInstallation(id int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.installations[id]
+	return ok
+}
+
+// SuspendInstallation makes GitHub refuse new access tokens for the
+// installation, as it does before the suspend webhook is delivered.
+func (s *Server) SuspendInstallation(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.suspended[id] = true
+}
+
+// RemoveInstallation uninstalls the app without notifying the connector.
+func (s *Server) RemoveInstallation(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.installations, id)
+	delete(s.repositories, id)
+	for token, installationID := range s.tokens {
+		if installationID == id {
+			delete(s.tokens, token)
+		}
+	}
+}
+
+// TokensIssued returns how many installation access tokens have been minted.
+func (s *Server) TokensIssued() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.tokenCount
+}
+
+func (s *Server) createAccessToken(w http.ResponseWriter, r *http.Request) {
+	if !s.authenticateApp(w, r) {
+		return
+	}
+
+	installation, ok := s.findInstallation(w, r)
+	if !ok {
+		return
+	}
+
+	request := TokenRequest{InstallationID: installation.ID}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"message": "Problems parsing JSON"})
+			return
+		}
+	}
+
+	s.mu.Lock()
+	if s.suspended[installation.ID] {
+		s.mu.Unlock()
+		writeJSON(w, http.StatusForbidden, map[string]string{"message": "This installation has been suspended"})
+		return
+	}
+	if message := s.checkTokenScope(installation, request); message != "" {
+		s.mu.Unlock()
+		writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"message": message})
+		return
+	}
+	s.tokenRequests = append(s.tokenRequests, request)
+	s.tokenCount++
+	token := fmt.Sprintf("ghs_%d_%d", installation.ID, s.tokenCount)
+	s.tokens[token] = installation.ID
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, map[string]any{
+		"token":       token,
+		"expires_at":  time.Now().Add(time.Hour).UTC().Format(time.RFC3339),
+		"permissions": installation.Permissions,
+	})
+}
+
+// checkTokenScope rejects scopes beyond the installation, as GitHub does. The
+// caller holds s.mu.
+func (s *Server) checkTokenScope(installation github.Installation, request TokenRequest) string {
+	for _, name := range request.Repositories {
+		if !slices.ContainsFunc(s.repositories[installation.ID], func(repo github.Repository) bool { return repo.Name == name }) {
+			return "There is at least one repository that does not exist or is not accessible to the parent installation."
+		}
+	}
+	for permission, access := range request.Permissions {
+		granted := installation.Permissions[permission]
+		if granted == "" || (access == "write" && granted != "write") {
+			return "The permissions requested are not granted to this installation."
+		}
+	}
+	return ""
+}
+
+func (s *Server) app(w http.ResponseWriter, r *http.Request) {
+	if !s.authenticateApp(w, r) {
+		return
+	}
+
+	id, _ := strconv.ParseInt(s.AppID, 10, 64)
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "slug": "infragpt-test"})
+}
+
+func (s *Server) installation(w http.ResponseWriter, r *http.Request) {
+	if !s.authenticateApp(w, r) {
+		return
+	}
+
+	installation, ok := s.findInstallation(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, installation)
+}
+
+func (s *Server) deleteInstallation(w http.ResponseWriter, r *http.Request) {
+	if !s.authenticateApp(w, r) {
+		return
+	}
+
+	installation, ok := s.findInstallation(w, r)
+	if !ok {
+		return
+	}
+
+	s.RemoveInstallation(installation.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) installationRepositories(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	token = strings.TrimPrefix(token, "token ")
+
+	s.mu.Lock()
+	installationID, ok := s.tokens[token]
+	repositories := s.repositories[installationID]
+	s.mu.Unlock()
+
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "Bad credentials"})
+		return
+	}
+
+	perPage := queryInt(r, "per_page", 30)
+	if perPage > s.PageSize {
+		perPage = s.PageSize
+	}
+	page := queryInt(r, "page", 1)
+
+	start := min((page-1)*perPage, len(repositories))
+	end := min(start+perPage, len(repositories))
+
+	if end < len(repositories) {
+		next := *r.URL
+		query := next.Query()
+		query.Set("page", strconv.Itoa(page+1))
+		next.RawQuery = query.Encode()
+		w.Header().Set("Link", fmt.Sprintf(`<%s%s>; rel="next"`, s.URL, next.RequestURI()))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"total_count":  len(repositories),
+		"repositories": repositories[start:end],
+	})
+}
+
+func (s *Server) repositoryLanguages(w http.ResponseWriter, r *http.Request) {
+	fullName, ok := s.findRepository(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	s.profiled = append(s.profiled, fullName)
+	languages := s.contents[fullName].languages
+	s.mu.Unlock()
+
+	if languages == nil {
+		languages = map[string]int64{}
+	}
+	writeJSON(w, http.StatusOK, languages)
+}
+
+func (s *Server) repositoryTree(w http.ResponseWriter, r *http.Request) {
+	fullName, ok := s.findRepository(w, r)
+	if !ok {
+		return
+	}
+
+	s.mu.Lock()
+	contents, ok := s.contents[fullName]
+	s.mu.Unlock()
+
+	if !ok {
+		writeJSON(w, http.StatusConflict, map[string]string{"message": "Git Repository is empty."})
+		return
+	}
+
+	tree := make([]map[string]string, len(contents.paths))
+	for i, path := range contents.paths {
+		tree[i] = map[string]string{"path": path, "type": "blob"}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"sha":       "0000000000000000000000000000000000000000",
+		"tree":      tree,
+		"truncated": contents.truncated,
+	})
+}
+
+// findRepository checks the installation token grants access to the
+// repository in the path and returns its full name.
+func (s *Server) findRepository(w http.ResponseWriter, r *http.Request) (string, bool) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	fullName := r.PathValue("owner") + "/" + r.PathValue("repo")
+
+	s.mu.Lock()
+	installationID, ok := s.tokens[token]
+	repositories := s.repositories[installationID]
+	s.mu.Unlock()
+
+	if !ok {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "Bad credentials"})
+		return "", false
+	}
+	if !slices.ContainsFunc(repositories, func(repo github.Repository) bool { return repo.FullName == fullName }) {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+		return "", false
+	}
+	return fullName, true
+}
+
+func (s *Server) authenticateApp(w http.ResponseWriter, r *http.Request) bool {
+	raw := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(raw, claims, func(token *jwt.Token) (any, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		return &s.privateKey.PublicKey, nil
+	})
+	if err != nil || claims["iss"] != s.AppID {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"message": "A JSON web token could not be decoded"})
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) findInstallation(w http.ResponseWriter, r *http.Request) (github.Installation, bool) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+		return github.Installation{}, false
+	}
+
+	s.mu.Lock()
+	installation, ok := s.installations[id]
+	s.mu.Unlock()
+
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+		return github.Installation{}, false
+	}
+
+	return installation, true
+}
+
+func queryInt(r *http.Request, key string, fallback int) int {
+	value, err := strconv.Atoi(r.URL.Query().Get(key))
+	if err != nil || value < 1 {
+		return fallback
+	}
+	return value
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
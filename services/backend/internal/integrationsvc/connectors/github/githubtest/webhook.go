@@ -0,0 +1,39 @@
+package githubtest
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+// Sign returns the X-Hub-Signature-256 header value GitHub sends for payload.
+func Sign(secret string, payload []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write(payload)
+	return "sha256=" + hex.EncodeToString(h.Sum(nil))
+}
+
+// NewWebhookRequest builds a signed webhook delivery of the given event type.
+func NewWebhookRequest(t testing.TB, secret string, eventType string, payload any) *http.Request {
+	t.Helper()
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("failed to marshal webhook payload: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/github", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", eventType)
+	req.Header.Set("X-GitHub-Delivery", uuid.NewString())
+	req.Header.Set("X-Hub-Signature-256", Sign(secret, body))
+
+	return req
+}
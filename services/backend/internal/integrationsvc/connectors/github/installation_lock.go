@@ -0,0 +1,37 @@
+package github
+
+import (
+	"context"
+	"sync"
+
+	"github.com/73ai/infragpt/services/backend"
+)
+
+// localInstallationLocker serializes installations within this process.
+type localInstallationLocker struct {
+	mu    sync.Mutex
+	locks map[string]chan struct{}
+}
+
+func newLocalInstallationLocker() *localInstallationLocker {
+	return &localInstallationLocker{locks: make(map[string]chan struct{})}
+}
+
+func (l *localInstallationLocker) LockInstallation(ctx context.Context, connectorType backend.ConnectorType, installationID string) (func(), error) {
+	key := string(connectorType) + ":" + installationID
+
+	l.mu.Lock()
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		l.locks[key] = lock
+	}
+	l.mu.Unlock()
+
+	select {
+	case lock <- struct{}{}:
+		return func() { <-lock }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -13,7 +13,16 @@ type GitHubRepositoryRepository interface {
 	GetByGitHubID(ctx context.Context, integrationID uuid.UUID, repositoryID int64) (GitHubRepository, error)
 	DeleteByGitHubID(ctx context.Context, integrationID uuid.UUID, repositoryID int64) error
 	UpdatePermissions(ctx context.Context, integrationID uuid.UUID, repositoryID int64, permissions RepositoryPermissions) error
-	BulkDelete(ctx context.Context, integrationID uuid.UUID, repositoryIDs []int64) error
+	// SetEnabled selects or deselects repositories for agent access and
+	// returns how many were updated. Store leaves the flag of existing rows
+	// alone and enables new ones.
+	SetEnabled(ctx context.Context, integrationID uuid.UUID, repositoryIDs []int64, enabled bool) (int, error)
+	// BulkDelete deletes repositories and reports per ID whether it was
+	// removed. Repositories that are already gone are not an error.
+	BulkDelete(ctx context.Context, integrationID uuid.UUID, repositoryIDs []int64) ([]RepositoryDeletion, error)
+	// UpdateProfile replaces the profile of a repository. Store leaves the
+	// profile of existing rows alone.
+	UpdateProfile(ctx context.Context, integrationID uuid.UUID, repositoryID int64, profile RepositoryProfile) error
 	UpdateLastSyncTime(ctx context.Context, integrationID uuid.UUID, syncTime time.Time) error
 }
 
@@ -37,10 +46,45 @@ type GitHubRepository struct {
 	GitHubCreatedAt       time.Time
 	GitHubUpdatedAt       time.Time
 	GitHubPushedAt        time.Time
+	// Enabled is false for repositories deselected for agent access.
+	Enabled bool
+	// Profile is nil until the repository is first profiled.
+	Profile *RepositoryProfile
+}
+
+// Marker kinds a RepositoryProfile records the paths of.
+const (
+	MarkerGo        = "go"
+	MarkerNode      = "node"
+	MarkerDocker    = "docker"
+	MarkerTerraform = "terraform"
+	MarkerHelm      = "helm"
+)
+
+// RepositoryProfile describes what a repository is built with, from GitHub's
+// language breakdown and the marker files in its default branch.
+type RepositoryProfile struct {
+	// Languages maps each language GitHub detected to its size in bytes.
+	Languages       map[string]int64 `json:"languages"`
+	PrimaryLanguage string           `json:"primary_language,omitempty"`
+	// Markers maps a marker kind, such as MarkerGo, to the paths of the files
+	// that revealed it.
+	Markers map[string][]string `json:"markers"`
+	// TreeTruncated is set when GitHub cut the file listing short, so markers
+	// deep in the repository may be missing.
+	TreeTruncated bool      `json:"tree_truncated,omitempty"`
+	ProfiledAt    time.Time `json:"profiled_at"`
+}
+
+// RepositoryDeletion is the result of deleting one repository in BulkDelete.
+type RepositoryDeletion struct {
+	RepositoryID int64
+	// Removed is false when the repository was already gone.
+	Removed bool
 }
 
 type RepositoryPermissions struct {
-	Admin bool
-	Push  bool
-	Pull  bool
+	Admin bool `json:"admin"`
+	Push  bool `json:"push"`
+	Pull  bool `json:"pull"`
 }
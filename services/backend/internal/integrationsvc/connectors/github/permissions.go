@@ -0,0 +1,56 @@
+package github
+
+// PermissionChange lists the access a repository gained or lost between two
+// permission syncs.
+type PermissionChange struct {
+	RepositoryID int64    `json:"repository_id"`
+	FullName     string   `json:"full_name"`
+	Gained       []string `json:"gained,omitempty"`
+	Lost         []string `json:"lost,omitempty"`
+}
+
+// LostPush reports whether push access was revoked, which disables PR automation.
+func (c PermissionChange) LostPush() bool {
+	for _, permission := range c.Lost {
+		if permission == "push" {
+			return true
+		}
+	}
+	return false
+}
+
+// diffRepositoryPermissions compares the stored permissions of each repository
+// with freshly fetched ones. Repositories without stored permissions are new
+// and are not reported as changed.
+func diffRepositoryPermissions(stored []GitHubRepository, fetched map[int64]RepositoryPermissions) []PermissionChange {
+	var changes []PermissionChange
+	for _, repo := range stored {
+		after, ok := fetched[repo.GitHubRepositoryID]
+		if !ok {
+			continue
+		}
+		before := RepositoryPermissions{Admin: repo.PermissionAdmin, Push: repo.PermissionPush, Pull: repo.PermissionPull}
+		if before == after {
+			continue
+		}
+
+		change := PermissionChange{RepositoryID: repo.GitHubRepositoryID, FullName: repo.RepositoryFullName}
+		for _, p := range []struct {
+			name          string
+			before, after bool
+		}{
+			{"admin", before.Admin, after.Admin},
+			{"push", before.Push, after.Push},
+			{"pull", before.Pull, after.Pull},
+		} {
+			switch {
+			case p.after && !p.before:
+				change.Gained = append(change.Gained, p.name)
+			case p.before && !p.after:
+				change.Lost = append(change.Lost, p.name)
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
@@ -0,0 +1,213 @@
+package github
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// maxMarkerPaths caps the paths recorded per marker kind; monorepos can have
+// hundreds of package.json files.
+const maxMarkerPaths = 20
+
+// errProfilingRateLimited stops profiling for the rest of a sync so the
+// remaining API budget is left for the agent.
+var errProfilingRateLimited = errors.New("GitHub API rate limit reached")
+
+// profileRepositories profiles the enabled repositories whose profile is
+// missing or older than their last push, up to the per-sync cap. Failures are
+// logged; the repositories are tried again by the next sync.
+func (g *githubConnector) profileRepositories(ctx context.Context, integrationID uuid.UUID, accessToken string, repositories []Repository, previous map[int64]GitHubRepository) {
+	type candidate struct {
+		repo       Repository
+		profiledAt time.Time
+	}
+	var candidates []candidate
+	skipped := 0
+	for _, repo := range repositories {
+		stored, existed := previous[repo.ID]
+		if existed && !stored.Enabled {
+			continue
+		}
+		if stored.Profile != nil && !stored.Profile.ProfiledAt.Before(repo.PushedAt) {
+			continue
+		}
+		if repo.Size > g.profiling.MaxRepositorySizeKB {
+			skipped++
+			continue
+		}
+		var profiledAt time.Time
+		if stored.Profile != nil {
+			profiledAt = stored.Profile.ProfiledAt
+		}
+		candidates = append(candidates, candidate{repo: repo, profiledAt: profiledAt})
+	}
+
+	// Never profiled repositories sort first, with their zero time.
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return a.profiledAt.Compare(b.profiledAt)
+	})
+	deferred := max(len(candidates)-g.profiling.MaxRepositoriesPerSync, 0)
+	candidates = candidates[:len(candidates)-deferred]
+
+	profiled := 0
+	for _, c := range candidates {
+		profile, err := g.fetchRepositoryProfile(ctx, accessToken, c.repo)
+		if errors.Is(err, errProfilingRateLimited) {
+			slog.Warn("stopped profiling repositories", "integration_id", integrationID, "error", err)
+			break
+		}
+		if err != nil {
+			slog.Error("failed to profile repository",
+				"integration_id", integrationID,
+				"repository_name", c.repo.FullName,
+				"error", err)
+			continue
+		}
+		if err := g.config.GitHubRepositoryRepo.UpdateProfile(ctx, integrationID, c.repo.ID, profile); err != nil {
+			slog.Error("failed to store repository profile",
+				"integration_id", integrationID,
+				"repository_name", c.repo.FullName,
+				"error", err)
+			continue
+		}
+		profiled++
+	}
+
+	slog.Info("profiled repositories",
+		"integration_id", integrationID,
+		"profiled", profiled,
+		"deferred", deferred,
+		"skipped_too_large", skipped)
+}
+
+func (g *githubConnector) fetchRepositoryProfile(ctx context.Context, accessToken string, repo Repository) (_ RepositoryProfile, err error) {
+	ctx, span := tracing.Start(ctx, "github.fetch_repository_profile", attribute.String("github.repository", repo.FullName))
+	defer func() { tracing.End(span, err) }()
+
+	profile := RepositoryProfile{
+		Languages:  map[string]int64{},
+		Markers:    map[string][]string{},
+		ProfiledAt: time.Now(),
+	}
+
+	languagesURL := fmt.Sprintf("%s/repos/%s/languages", g.apiBaseURL, repo.FullName)
+	if _, err := g.getRepositoryJSON(ctx, accessToken, languagesURL, &profile.Languages); err != nil {
+		return RepositoryProfile{}, fmt.Errorf("failed to fetch languages: %w", err)
+	}
+	profile.PrimaryLanguage = repo.Language
+	var primaryBytes int64
+	for language, bytes := range profile.Languages {
+		if bytes > primaryBytes || (bytes == primaryBytes && language < profile.PrimaryLanguage) {
+			profile.PrimaryLanguage, primaryBytes = language, bytes
+		}
+	}
+
+	if repo.DefaultBranch == "" {
+		return profile, nil
+	}
+	var tree struct {
+		Tree []struct {
+			Path string `json:"path"`
+			Type string `json:"type"`
+		} `json:"tree"`
+		Truncated bool `json:"truncated"`
+	}
+	treeURL := fmt.Sprintf("%s/repos/%s/git/trees/%s?recursive=1", g.apiBaseURL, repo.FullName, url.PathEscape(repo.DefaultBranch))
+	found, err := g.getRepositoryJSON(ctx, accessToken, treeURL, &tree)
+	if err != nil {
+		return RepositoryProfile{}, fmt.Errorf("failed to fetch file tree: %w", err)
+	}
+	if !found {
+		// Empty repositories have no tree to list.
+		return profile, nil
+	}
+
+	profile.TreeTruncated = tree.Truncated
+	for _, entry := range tree.Tree {
+		if entry.Type != "blob" {
+			continue
+		}
+		if kind := markerKind(entry.Path); kind != "" {
+			profile.Markers[kind] = append(profile.Markers[kind], entry.Path)
+		}
+	}
+	// Keep the shallowest paths, which describe the repository as a whole.
+	for kind, paths := range profile.Markers {
+		slices.SortFunc(paths, func(a, b string) int {
+			return cmp.Or(cmp.Compare(strings.Count(a, "/"), strings.Count(b, "/")), strings.Compare(a, b))
+		})
+		profile.Markers[kind] = paths[:min(len(paths), maxMarkerPaths)]
+	}
+	return profile, nil
+}
+
+// markerKind returns the marker kind a file reveals, or "" when it reveals none.
+func markerKind(filePath string) string {
+	if strings.Contains("/"+filePath, "/node_modules/") || strings.Contains("/"+filePath, "/vendor/") {
+		return ""
+	}
+	name := path.Base(filePath)
+	switch {
+	case name == "go.mod":
+		return MarkerGo
+	case name == "package.json":
+		return MarkerNode
+	case name == "Dockerfile", strings.HasPrefix(name, "Dockerfile."), strings.HasSuffix(name, ".Dockerfile"):
+		return MarkerDocker
+	case path.Ext(name) == ".tf":
+		return MarkerTerraform
+	case name == "Chart.yaml":
+		return MarkerHelm
+	}
+	return ""
+}
+
+// getRepositoryJSON decodes a repository API response into v. It reports
+// false for repositories or refs GitHub has nothing for, such as the tree of
+// an empty repository.
+func (g *githubConnector) getRepositoryJSON(ctx context.Context, accessToken, url string, v any) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+	case resp.StatusCode == http.StatusNotFound, resp.StatusCode == http.StatusConflict:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0":
+		return false, fmt.Errorf("%w: status %d", errProfilingRateLimited, resp.StatusCode)
+	default:
+		return false, fmt.Errorf("GitHub API error: status %d", resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return false, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,164 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// pullRequestTriggerActions are the pull request actions that change its code.
+var pullRequestTriggerActions = []string{"opened", "reopened", "synchronize"}
+
+// RepositoryEvent normalizes push and pull request events for repository
+// triggers. It reports false for other events, tag pushes, branch deletions
+// and pull request actions that leave the code alone, such as "closed".
+func (e WebhookEvent) RepositoryEvent() (backend.RepositoryEvent, bool) {
+	switch e.EventType {
+	case EventTypePush:
+		var push PushEvent
+		if err := decodePayload(e.RawPayload, &push); err != nil || push.Deleted || push.Repository.FullName == "" {
+			return backend.RepositoryEvent{}, false
+		}
+		branch, ok := strings.CutPrefix(push.Ref, "refs/heads/")
+		if !ok {
+			return backend.RepositoryEvent{}, false
+		}
+
+		event := backend.RepositoryEvent{
+			Type:         backend.RepositoryEventPush,
+			Repository:   push.Repository.FullName,
+			Branch:       branch,
+			CommitSHA:    push.After,
+			URL:          push.Compare,
+			Sender:       push.Sender.Login,
+			ChangedFiles: push.changedFiles(),
+		}
+		if push.HeadCommit != nil {
+			event.Title, _, _ = strings.Cut(push.HeadCommit.Message, "\n")
+		}
+		return event, true
+
+	case EventTypePullRequest:
+		var pr PullRequestEvent
+		if err := decodePayload(e.RawPayload, &pr); err != nil || pr.Repository.FullName == "" || pr.Number == 0 {
+			return backend.RepositoryEvent{}, false
+		}
+		if !slices.Contains(pullRequestTriggerActions, pr.Action) {
+			return backend.RepositoryEvent{}, false
+		}
+
+		return backend.RepositoryEvent{
+			Type:              backend.RepositoryEventPullRequest,
+			Repository:        pr.Repository.FullName,
+			Action:            pr.Action,
+			Branch:            pr.PullRequest.Head.Ref,
+			CommitSHA:         pr.PullRequest.Head.SHA,
+			PullRequestNumber: pr.Number,
+			Title:             pr.PullRequest.Title,
+			URL:               pr.PullRequest.HTMLURL,
+			Sender:            pr.Sender.Login,
+		}, true
+	}
+
+	return backend.RepositoryEvent{}, false
+}
+
+func (p PushEvent) changedFiles() []string {
+	var files []string
+	for _, commit := range p.Commits {
+		files = append(files, commit.Added...)
+		files = append(files, commit.Modified...)
+		files = append(files, commit.Removed...)
+	}
+	slices.Sort(files)
+	return slices.Compact(files)
+}
+
+func decodePayload(raw map[string]any, v any) error {
+	payload, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(payload, v)
+}
+
+const (
+	pullRequestFilesPerPage = 100
+	// maxPullRequestFilePages matches GitHub, which lists at most 3000 files.
+	maxPullRequestFilePages = 30
+)
+
+// readContentsPermissions narrows analysis tokens to reading repository
+// contents; syncs keep the installation's full token.
+var readContentsPermissions = map[string]string{"contents": "read"}
+
+// PullRequestFiles lists the paths a pull request changes, including the old
+// path of renamed files.
+func (g *githubConnector) PullRequestFiles(ctx context.Context, integration backend.Integration, repository string, number int) (_ []string, err error) {
+	ctx, span := tracing.Start(ctx, "github.pull_request_files",
+		attribute.String("github.repository", repository),
+		attribute.Int("github.pull_request", number))
+	defer func() { tracing.End(span, err) }()
+
+	if err := integration.CheckCredentialsUsable(); err != nil {
+		return nil, err
+	}
+	if integration.BotID == "" {
+		return nil, fmt.Errorf("installation ID not found in integration")
+	}
+
+	accessToken, err := g.scopedInstallationAccessToken(ctx, integration.BotID, []string{repository}, readContentsPermissions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	var files []string
+	for page := 1; page <= maxPullRequestFilePages; page++ {
+		url := fmt.Sprintf("%s/repos/%s/pulls/%d/files?per_page=%d&page=%d", g.apiBaseURL, repository, number, pullRequestFilesPerPage, page)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken.Token))
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+		resp, err := g.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch pull request files: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("GitHub API error: status %d", resp.StatusCode)
+		}
+
+		var pageFiles []struct {
+			Filename         string `json:"filename"`
+			PreviousFilename string `json:"previous_filename"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&pageFiles)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode pull request files: %w", err)
+		}
+
+		for _, file := range pageFiles {
+			files = append(files, file.Filename)
+			if file.PreviousFilename != "" {
+				files = append(files, file.PreviousFilename)
+			}
+		}
+		if len(pageFiles) < pullRequestFilesPerPage {
+			break
+		}
+	}
+
+	return files, nil
+}
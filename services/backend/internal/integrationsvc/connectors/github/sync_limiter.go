@@ -0,0 +1,59 @@
+package github
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"golang.org/x/sync/semaphore"
+)
+
+// defaultMaxConcurrentSyncs keeps a burst of installation webhooks well under
+// GitHub's secondary rate limits on concurrent requests.
+const defaultMaxConcurrentSyncs = 2
+
+const instrumentationName = "github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/github"
+
+var queuedSyncs, inFlightSyncs metric.Int64UpDownCounter
+
+func init() {
+	meter := otel.Meter(instrumentationName)
+	queuedSyncs, _ = meter.Int64UpDownCounter(
+		"github.syncs.queued",
+		metric.WithDescription("Number of repository syncs waiting for a free sync slot"),
+	)
+	inFlightSyncs, _ = meter.Int64UpDownCounter(
+		"github.syncs.in_flight",
+		metric.WithDescription("Number of repository syncs currently running"),
+	)
+}
+
+// syncLimiter bounds how many repository syncs run at once. Syncs beyond the
+// limit wait in line until a slot frees up or their context is cancelled.
+type syncLimiter struct {
+	slots *semaphore.Weighted
+}
+
+func newSyncLimiter(limit int) *syncLimiter {
+	if limit <= 0 {
+		limit = defaultMaxConcurrentSyncs
+	}
+	return &syncLimiter{slots: semaphore.NewWeighted(int64(limit))}
+}
+
+// acquire waits for a sync slot and returns the function that frees it. A sync
+// still waiting when ctx is cancelled leaves the queue with ctx's error.
+func (l *syncLimiter) acquire(ctx context.Context) (func(), error) {
+	queuedSyncs.Add(ctx, 1)
+	err := l.slots.Acquire(ctx, 1)
+	queuedSyncs.Add(context.WithoutCancel(ctx), -1)
+	if err != nil {
+		return nil, err
+	}
+
+	inFlightSyncs.Add(ctx, 1)
+	return func() {
+		inFlightSyncs.Add(context.WithoutCancel(ctx), -1)
+		l.slots.Release(1)
+	}, nil
+}
@@ -10,13 +10,17 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"net"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/recovery"
+	"github.com/73ai/infragpt/services/backend/internal/generic/tracing"
 	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
 	"github.com/google/uuid"
 )
@@ -47,32 +51,117 @@ func (g *githubConnector) ProcessEvent(ctx context.Context, event any) error {
 		return fmt.Errorf("invalid event type: expected WebhookEvent")
 	}
 
+	var err error
 	switch webhookEvent.EventType {
 	case EventTypeInstallation:
-		return g.handleInstallationEvent(ctx, webhookEvent)
+		err = g.handleInstallationEvent(ctx, webhookEvent)
 	case "installation_repositories":
-		return g.handleInstallationRepositoriesEvent(ctx, webhookEvent)
+		err = g.handleInstallationRepositoriesEvent(ctx, webhookEvent)
+	case EventTypeAppAuthorization:
+		err = g.handleAppAuthorizationEvent(ctx, webhookEvent)
 	default:
 		slog.Debug("ignoring non-installation event",
 			"event_type", webhookEvent.EventType,
 			"installation_id", webhookEvent.InstallationID)
 		return nil
 	}
+	if errors.Is(err, errMalformedEvent) {
+		slog.Warn("ignoring malformed GitHub webhook event",
+			"event_type", webhookEvent.EventType,
+			"installation_id", webhookEvent.InstallationID,
+			"error", err)
+		return nil
+	}
+	switch {
+	case errors.Is(err, ErrInstallationSuspended):
+		err = g.markInstallationUnusable(ctx, webhookEvent.InstallationID, backend.IntegrationStatusSuspended, err)
+	case errors.Is(err, ErrInstallationNotFound):
+		err = g.markInstallationUnusable(ctx, webhookEvent.InstallationID, backend.IntegrationStatusInactive, err)
+	case errors.Is(err, ErrGitHubUnauthorized):
+		slog.Error("GitHub rejected the app JWT, check the app_id and private_key configuration",
+			"event_type", webhookEvent.EventType,
+			"installation_id", webhookEvent.InstallationID,
+			"error", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	g.recordWebhookProcessed(ctx, webhookEvent)
+	return nil
+}
+
+// markInstallationUnusable records that GitHub refused a token for the
+// installation, so the event is acknowledged instead of redelivered.
+func (g *githubConnector) markInstallationUnusable(ctx context.Context, installationID string, status backend.IntegrationStatus, cause error) error {
+	slog.Warn("GitHub refused an installation token while handling a webhook event",
+		"installation_id", installationID,
+		"status", status,
+		"error", cause)
+
+	integration, err := g.config.IntegrationRepository.FindByBotIDAndType(ctx, installationID, backend.ConnectorTypeGithub)
+	if errors.Is(err, backend.ErrIntegrationNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find integration for installation %s: %w", installationID, err)
+	}
+	if err := g.config.IntegrationRepository.UpdateStatus(ctx, integration.ID, status); err != nil {
+		return fmt.Errorf("failed to update integration status for installation %s: %w", installationID, err)
+	}
+	if err := g.clearInstallationToken(ctx, integration.ID); err != nil {
+		return fmt.Errorf("failed to clear access token for installation %s: %w", installationID, err)
+	}
+	return nil
+}
+
+func (g *githubConnector) recordWebhookProcessed(ctx context.Context, event WebhookEvent) {
+	if g.config.ActivityRecorder == nil || event.InstallationID == "" {
+		return
+	}
+
+	integration, err := g.config.IntegrationRepository.FindByBotIDAndType(ctx, event.InstallationID, backend.ConnectorTypeGithub)
+	if err != nil {
+		return
+	}
+
+	domain.RecordActivity(ctx, g.config.ActivityRecorder, backend.IntegrationActivity{
+		IntegrationID:  integration.ID,
+		OrganizationID: integration.OrganizationID,
+		Type:           backend.IntegrationActivityWebhookProcessed,
+		Details: map[string]string{
+			"event_type": string(event.EventType),
+			"action":     event.InstallationAction,
+		},
+	})
 }
 
+// Subscribe serves webhooks on a port of their own. It is only used when
+// webhook_port is set; otherwise RegisterRoutes serves them on the main server.
 func (g *githubConnector) Subscribe(ctx context.Context, handler func(ctx context.Context, event any) error) error {
 	if g.config.WebhookPort == 0 {
 		return fmt.Errorf("github: webhook port is required for webhook server")
 	}
 
-	webhookConfig := webhookServerConfig{
+	return g.webhookServerConfig(handler).startWebhookServer(ctx)
+}
+
+func (g *githubConnector) RegisterRoutes(mux *http.ServeMux, handler func(ctx context.Context, event any) error) bool {
+	if g.config.WebhookPort != 0 {
+		return false
+	}
+
+	mux.Handle(webhookPath, g.webhookServerConfig(handler).handler())
+	return true
+}
+
+func (g *githubConnector) webhookServerConfig(handler func(ctx context.Context, event any) error) webhookServerConfig {
+	return webhookServerConfig{
 		port:                g.config.WebhookPort,
 		webhookSecret:       g.config.WebhookSecret,
 		callbackHandlerFunc: handler,
 		validateSignature:   g.ValidateWebhookSignature,
 	}
-
-	return webhookConfig.startWebhookServer(ctx)
 }
 
 func (g *githubConnector) handleInstallationEvent(ctx context.Context, event WebhookEvent) error {
@@ -104,21 +193,61 @@ func (g *githubConnector) handleInstallationEvent(ctx context.Context, event Web
 	}
 }
 
+// errMalformedEvent marks webhook payloads that lack the fields we act on.
+// They are acknowledged and dropped, since GitHub would otherwise redeliver
+// them forever.
+var errMalformedEvent = errors.New("malformed installation event")
+
+// parseInstallationEvent requires only the action, installation ID and
+// account. Other fields whose type does not match the model, for instance
+// after GitHub changes its schema, are logged and left empty.
 func (g *githubConnector) parseInstallationEvent(rawPayload map[string]any) (InstallationEvent, error) {
-	payloadBytes, err := json.Marshal(rawPayload)
-	if err != nil {
-		return InstallationEvent{}, fmt.Errorf("failed to marshal raw payload: %w", err)
+	action, _ := rawPayload["action"].(string)
+	installation, _ := rawPayload["installation"].(map[string]any)
+	installationID, _ := installation["id"].(float64)
+	account, _ := installation["account"].(map[string]any)
+	if action == "" || installationID <= 0 || account == nil {
+		return InstallationEvent{}, fmt.Errorf("%w: action, installation.id and installation.account are required", errMalformedEvent)
 	}
 
 	var installationEvent InstallationEvent
-	if err := json.Unmarshal(payloadBytes, &installationEvent); err != nil {
-		return InstallationEvent{}, fmt.Errorf("failed to unmarshal installation event: %w", err)
+	skipped := decodeFields(withoutKey(rawPayload, "installation"), &installationEvent, "")
+	skipped = append(skipped, decodeFields(withoutKey(installation, "account"), &installationEvent.Installation, "installation.")...)
+	skipped = append(skipped, decodeFields(account, &installationEvent.Installation.Account, "installation.account.")...)
+	if len(skipped) > 0 {
+		slog.Warn("skipped unexpected fields in GitHub installation event",
+			"action", action,
+			"installation_id", installationEvent.Installation.ID,
+			"fields", skipped)
 	}
 
 	installationEvent.RawPayload = rawPayload
 	return installationEvent, nil
 }
 
+// decodeFields decodes each key of raw into v on its own, so that one field
+// of an unexpected type does not fail the rest. It returns the skipped keys.
+func decodeFields(raw map[string]any, v any, prefix string) []string {
+	var skipped []string
+	for key, value := range raw {
+		field, err := json.Marshal(map[string]any{key: value})
+		if err == nil {
+			err = json.Unmarshal(field, v)
+		}
+		if err != nil {
+			skipped = append(skipped, prefix+key)
+		}
+	}
+	slices.Sort(skipped)
+	return skipped
+}
+
+func withoutKey(raw map[string]any, key string) map[string]any {
+	rest := maps.Clone(raw)
+	delete(rest, key)
+	return rest
+}
+
 func (g *githubConnector) handleInstallationCreated(ctx context.Context, event InstallationEvent) error {
 	slog.Info("GitHub App installation created",
 		"installation_id", event.Installation.ID,
@@ -142,7 +271,7 @@ func (g *githubConnector) handleInstallationDeleted(ctx context.Context, event I
 	installationIDStr := strconv.FormatInt(event.Installation.ID, 10)
 	integration, err := g.config.IntegrationRepository.FindByBotIDAndType(ctx, installationIDStr, backend.ConnectorTypeGithub)
 	if err != nil {
-		if errors.Is(err, domain.ErrIntegrationNotFound) {
+		if errors.Is(err, backend.ErrIntegrationNotFound) {
 			slog.Debug("integration not found for deleted installation",
 				"installation_id", event.Installation.ID)
 			return nil
@@ -152,6 +281,9 @@ func (g *githubConnector) handleInstallationDeleted(ctx context.Context, event I
 	if err := g.config.IntegrationRepository.UpdateStatus(ctx, integration.ID, backend.IntegrationStatusInactive); err != nil {
 		return fmt.Errorf("failed to update integration status for deleted installation %d: %w", event.Installation.ID, err)
 	}
+	if err := g.clearInstallationToken(ctx, integration.ID); err != nil {
+		return fmt.Errorf("failed to clear access token for deleted installation %d: %w", event.Installation.ID, err)
+	}
 
 	slog.Info("GitHub integration marked as inactive due to installation deletion",
 		"installation_id", event.Installation.ID,
@@ -170,7 +302,7 @@ func (g *githubConnector) handleInstallationSuspended(ctx context.Context, event
 	installationIDStr := strconv.FormatInt(event.Installation.ID, 10)
 	integration, err := g.config.IntegrationRepository.FindByBotIDAndType(ctx, installationIDStr, backend.ConnectorTypeGithub)
 	if err != nil {
-		if errors.Is(err, domain.ErrIntegrationNotFound) {
+		if errors.Is(err, backend.ErrIntegrationNotFound) {
 			slog.Debug("integration not found for suspended installation",
 				"installation_id", event.Installation.ID)
 			return nil
@@ -180,6 +312,9 @@ func (g *githubConnector) handleInstallationSuspended(ctx context.Context, event
 	if err := g.config.IntegrationRepository.UpdateStatus(ctx, integration.ID, backend.IntegrationStatusSuspended); err != nil {
 		return fmt.Errorf("failed to update integration status to suspended for installation %d: %w", event.Installation.ID, err)
 	}
+	if err := g.clearInstallationToken(ctx, integration.ID); err != nil {
+		return fmt.Errorf("failed to clear access token for suspended installation %d: %w", event.Installation.ID, err)
+	}
 
 	slog.Info("GitHub integration status updated to suspended",
 		"installation_id", event.Installation.ID,
@@ -197,7 +332,7 @@ func (g *githubConnector) handleInstallationUnsuspended(ctx context.Context, eve
 	installationIDStr := strconv.FormatInt(event.Installation.ID, 10)
 	integration, err := g.config.IntegrationRepository.FindByBotIDAndType(ctx, installationIDStr, backend.ConnectorTypeGithub)
 	if err != nil {
-		if errors.Is(err, domain.ErrIntegrationNotFound) {
+		if errors.Is(err, backend.ErrIntegrationNotFound) {
 			slog.Debug("integration not found for unsuspended installation",
 				"installation_id", event.Installation.ID)
 			return nil
@@ -208,6 +343,15 @@ func (g *githubConnector) handleInstallationUnsuspended(ctx context.Context, eve
 	if err := g.config.IntegrationRepository.UpdateStatus(ctx, integration.ID, backend.IntegrationStatusActive); err != nil {
 		return fmt.Errorf("failed to update integration status to active for installation %d: %w", event.Installation.ID, err)
 	}
+	if err := g.renewInstallationToken(ctx, integration.ID, installationIDStr); err != nil {
+		if errors.Is(err, ErrInstallationSuspended) || errors.Is(err, ErrInstallationNotFound) {
+			return err
+		}
+		slog.Error("failed to renew access token for unsuspended installation",
+			"installation_id", event.Installation.ID,
+			"integration_id", integration.ID,
+			"error", err)
+	}
 
 	slog.Info("GitHub integration status updated to active",
 		"installation_id", event.Installation.ID,
@@ -217,6 +361,61 @@ func (g *githubConnector) handleInstallationUnsuspended(ctx context.Context, eve
 	return nil
 }
 
+// clearInstallationToken drops the stored installation access token, which
+// would otherwise stay usable for up to an hour after GitHub cut off access.
+func (g *githubConnector) clearInstallationToken(ctx context.Context, integrationID uuid.UUID) error {
+	credential, err := g.config.CredentialRepository.FindByIntegration(ctx, integrationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrCredentialNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to find credentials: %w", err)
+	}
+	if _, ok := credential.Data["access_token"]; !ok {
+		return nil
+	}
+
+	now := time.Now()
+	credential.Data = maps.Clone(credential.Data)
+	delete(credential.Data, "access_token")
+	credential.ExpiresAt = &now
+	credential.UpdatedAt = now
+	if err := g.config.CredentialRepository.Update(ctx, credential); err != nil {
+		return fmt.Errorf("failed to update credentials: %w", err)
+	}
+	return nil
+}
+
+// renewInstallationToken stores a fresh installation access token, replacing
+// the one cleared when the installation was suspended.
+func (g *githubConnector) renewInstallationToken(ctx context.Context, integrationID uuid.UUID, installationID string) error {
+	credential, err := g.config.CredentialRepository.FindByIntegration(ctx, integrationID)
+	if err != nil {
+		return fmt.Errorf("failed to find credentials: %w", err)
+	}
+
+	jwt, err := g.generateJWT()
+	if err != nil {
+		return fmt.Errorf("failed to generate JWT: %w", err)
+	}
+	accessToken, err := g.getInstallationAccessToken(ctx, jwt, installationID)
+	if err != nil {
+		return fmt.Errorf("failed to get access token: %w", err)
+	}
+
+	credential.Data = maps.Clone(credential.Data)
+	credential.Data["access_token"] = accessToken.Token
+	credential.ExpiresAt = nil
+	if !accessToken.ExpiresAt.IsZero() {
+		credential.ExpiresAt = &accessToken.ExpiresAt
+	}
+	credential.UpdatedAt = time.Now()
+	if err := g.config.CredentialRepository.Update(ctx, credential); err != nil {
+		return fmt.Errorf("failed to update credentials: %w", err)
+	}
+	return nil
+}
+
 func (g *githubConnector) handlePermissionsUpdated(ctx context.Context, event InstallationEvent) error {
 	slog.Info("GitHub App permissions updated",
 		"installation_id", event.Installation.ID,
@@ -238,7 +437,7 @@ func (g *githubConnector) handlePermissionsUpdated(ctx context.Context, event In
 	installationIDStr := strconv.FormatInt(event.Installation.ID, 10)
 	integration, err := g.config.IntegrationRepository.FindByBotIDAndType(ctx, installationIDStr, backend.ConnectorTypeGithub)
 	if err != nil {
-		if errors.Is(err, domain.ErrIntegrationNotFound) {
+		if errors.Is(err, backend.ErrIntegrationNotFound) {
 			slog.Debug("integration not found for permissions update",
 				"installation_id", event.Installation.ID)
 			return nil
@@ -271,7 +470,7 @@ func (g *githubConnector) handlePermissionsUpdated(ctx context.Context, event In
 			"installation_id", event.Installation.ID,
 			"integration_id", integration.ID)
 
-		if err := g.syncRepositories(ctx, integrationUUID, installationIDStr); err != nil {
+		if _, err := g.syncRepositories(ctx, integrationUUID, installationIDStr); err != nil {
 			slog.Error("failed to sync repositories after permissions update",
 				"installation_id", event.Installation.ID,
 				"integration_id", integration.ID,
@@ -383,7 +582,7 @@ func (g *githubConnector) handleRepositoriesRemoved(ctx context.Context, event I
 		for _, repo := range event.RepositoriesRemoved {
 			repoIDs = append(repoIDs, repo.ID)
 		}
-		if err := g.removeRepositories(ctx, integrationID, repoIDs); err != nil {
+		if _, err := g.removeRepositories(ctx, integrationID, repoIDs); err != nil {
 			return fmt.Errorf("failed to remove repositories: %w", err)
 		}
 	}
@@ -391,11 +590,49 @@ func (g *githubConnector) handleRepositoriesRemoved(ctx context.Context, event I
 	return nil
 }
 
+// handleAppAuthorizationEvent handles a user revoking their authorization of
+// the app. Unlike an installation deletion the installation is still there,
+// so the integrations of the user's account are kept but need reauthorization.
+func (g *githubConnector) handleAppAuthorizationEvent(ctx context.Context, event WebhookEvent) error {
+	if event.Action != "revoked" {
+		slog.Debug("unhandled github_app_authorization action", "action", event.Action)
+		return nil
+	}
+	if event.SenderID <= 0 {
+		return fmt.Errorf("%w: sender.id is required", errMalformedEvent)
+	}
+
+	slog.Info("GitHub App authorization revoked",
+		"sender_id", event.SenderID,
+		"sender_login", event.SenderLogin)
+
+	integrations, err := g.config.IntegrationRepository.FindByConnectorOrganizationIDAndType(ctx, strconv.FormatInt(event.SenderID, 10), backend.ConnectorTypeGithub)
+	if err != nil {
+		return fmt.Errorf("failed to find integrations for revoked authorization of %s: %w", event.SenderLogin, err)
+	}
+
+	for _, integration := range integrations {
+		if integration.Status != backend.IntegrationStatusActive && integration.Status != backend.IntegrationStatusSuspended {
+			continue
+		}
+		if err := g.config.IntegrationRepository.UpdateStatus(ctx, integration.ID, backend.IntegrationStatusNeedsReauthorization); err != nil {
+			return fmt.Errorf("failed to mark integration %s as needing reauthorization: %w", integration.ID, err)
+		}
+
+		slog.Info("GitHub integration needs reauthorization after authorization was revoked",
+			"sender_login", event.SenderLogin,
+			"integration_id", integration.ID,
+			"organization_id", integration.OrganizationID)
+	}
+
+	return nil
+}
+
 func (g *githubConnector) isInstallationSuspended(ctx context.Context, installationID int64) (bool, error) {
 	installationIDStr := strconv.FormatInt(installationID, 10)
 	integration, err := g.config.IntegrationRepository.FindByBotIDAndType(ctx, installationIDStr, backend.ConnectorTypeGithub)
 	if err != nil {
-		if errors.Is(err, domain.ErrIntegrationNotFound) {
+		if errors.Is(err, backend.ErrIntegrationNotFound) {
 			return false, nil
 		}
 		return false, fmt.Errorf("failed to find integration by installation ID %d: %w", installationID, err)
@@ -407,7 +644,7 @@ func (g *githubConnector) isInstallationSuspended(ctx context.Context, installat
 func (g *githubConnector) findIntegrationIDByInstallationID(ctx context.Context, installationID string) (uuid.UUID, error) {
 	integration, err := g.config.IntegrationRepository.FindByBotIDAndType(ctx, installationID, backend.ConnectorTypeGithub)
 	if err != nil {
-		if errors.Is(err, domain.ErrIntegrationNotFound) {
+		if errors.Is(err, backend.ErrIntegrationNotFound) {
 			slog.Debug("integration not found for installation ID", "installation_id", installationID)
 			return uuid.Nil, nil
 		}
@@ -435,6 +672,13 @@ func convertUserToMap(user *User) map[string]any {
 	}
 }
 
+const webhookPath = "/webhooks/github"
+
+// handledEvents are the X-GitHub-Event types passed on to the event handler.
+// Others are acknowledged and dropped. Pushes and pull requests are matched
+// against repository triggers rather than processed by the connector.
+var handledEvents = []string{"installation", "installation_repositories", string(EventTypeAppAuthorization), string(EventTypePush), string(EventTypePullRequest)}
+
 // Webhook server configuration and implementation
 type webhookServerConfig struct {
 	port                int
@@ -444,27 +688,31 @@ type webhookServerConfig struct {
 }
 
 func (c webhookServerConfig) startWebhookServer(ctx context.Context) error {
-	h := &webhookHandler{
-		callbackHandlerFunc: c.callbackHandlerFunc,
-	}
-	h.init()
-
 	httpServer := &http.Server{
 		Addr:        fmt.Sprintf(":%d", c.port),
 		BaseContext: func(net.Listener) context.Context { return ctx },
-		Handler:     panicMiddleware(webhookValidationMiddleware(c.webhookSecret, c.validateSignature, h)),
+		Handler:     tracing.Middleware("github.webhook")(c.handler()),
 	}
 
 	return httpServer.ListenAndServe()
 }
 
+func (c webhookServerConfig) handler() http.Handler {
+	h := &webhookHandler{
+		callbackHandlerFunc: c.callbackHandlerFunc,
+	}
+	h.init()
+
+	return recovery.Middleware("github.webhook")(webhookValidationMiddleware(c.webhookSecret, c.validateSignature, h))
+}
+
 type webhookHandler struct {
 	http.ServeMux
 	callbackHandlerFunc func(ctx context.Context, event any) error
 }
 
 func (wh *webhookHandler) init() {
-	wh.HandleFunc("/webhooks/github", wh.handler())
+	wh.HandleFunc(webhookPath, wh.handler())
 }
 
 func (wh *webhookHandler) handler() func(w http.ResponseWriter, r *http.Request) {
@@ -479,7 +727,7 @@ func (wh *webhookHandler) handler() func(w http.ResponseWriter, r *http.Request)
 			return
 		}
 
-		if eventType != "installation" && eventType != "installation_repositories" {
+		if !slices.Contains(handledEvents, eventType) {
 			slog.Debug("ignoring non-installation event", "event_type", eventType)
 			w.WriteHeader(http.StatusOK)
 			_ = json.NewEncoder(w).Encode(response{})
@@ -568,18 +816,6 @@ func (wh *webhookHandler) convertToWebhookEvent(eventType string, rawPayload map
 	return event, nil
 }
 
-func panicMiddleware(h http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		defer func() {
-			if r := recover(); r != nil {
-				slog.Error("github: panic while handling http request", "recover", r)
-				w.WriteHeader(http.StatusInternalServerError)
-			}
-		}()
-		h.ServeHTTP(w, r)
-	})
-}
-
 func webhookValidationMiddleware(webhookSecret string, validateSignature func(payload []byte, signature string, secret string) error, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if webhookSecret == "" {
@@ -0,0 +1,9 @@
+package objectstore
+
+// Config holds the configuration for the object storage connector
+type Config struct{}
+
+// New creates a new object storage connector instance
+func (c Config) New() *Connector {
+	return &Connector{}
+}
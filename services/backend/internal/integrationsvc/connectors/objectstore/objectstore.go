@@ -0,0 +1,160 @@
+package objectstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/google/uuid"
+)
+
+// Settings is the JSON an operator submits as the authorization code when
+// connecting an S3-compatible bucket such as AWS S3 or MinIO.
+type Settings struct {
+	EndpointURL     string     `json:"endpoint_url"`
+	Bucket          string     `json:"bucket"`
+	Region          string     `json:"region"`
+	AccessKeyID     string     `json:"access_key_id"`
+	SecretAccessKey string     `json:"secret_access_key" masq:"sensitive"`
+	SessionToken    string     `json:"session_token,omitempty" masq:"sensitive"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+}
+
+func (s Settings) Validate() error {
+	var errs []error
+	if s.EndpointURL == "" {
+		errs = append(errs, errors.New("endpoint_url is required"))
+	} else if u, err := url.Parse(s.EndpointURL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		errs = append(errs, errors.New("endpoint_url must be an http or https URL"))
+	}
+	if s.Bucket == "" {
+		errs = append(errs, errors.New("bucket is required"))
+	}
+	if s.AccessKeyID == "" {
+		errs = append(errs, errors.New("access_key_id is required"))
+	}
+	if s.SecretAccessKey == "" {
+		errs = append(errs, errors.New("secret_access_key is required"))
+	}
+	if s.ExpiresAt != nil && !s.ExpiresAt.After(time.Now()) {
+		errs = append(errs, backend.ErrCredentialExpired)
+	}
+	return errors.Join(errs...)
+}
+
+type Connector struct{}
+
+func (c *Connector) InitiateAuthorization(organizationID string, userID string) (backend.IntegrationAuthorizationIntent, error) {
+	return backend.IntegrationAuthorizationIntent{
+		Type: backend.AuthorizationTypeAPIKey,
+		URL:  "objectstore-access-key",
+	}, nil
+}
+
+func (c *Connector) ParseState(state string) (organizationID uuid.UUID, userID uuid.UUID, err error) {
+	parts := strings.Split(state, ":")
+	if len(parts) != 2 {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid state format", backend.ErrInvalidState)
+	}
+
+	orgID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid organization ID in state: %w", backend.ErrInvalidState, err)
+	}
+
+	uID, err := uuid.Parse(parts[1])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid user ID in state: %w", backend.ErrInvalidState, err)
+	}
+
+	return orgID, uID, nil
+}
+
+// CompleteAuthorization stores the access keys as credentials and the
+// endpoint, bucket and region as integration metadata.
+func (c *Connector) CompleteAuthorization(authData backend.AuthorizationData) (backend.Credentials, error) {
+	if authData.Code == "" {
+		return backend.Credentials{}, fmt.Errorf("object store settings are required")
+	}
+
+	var settings Settings
+	if err := json.Unmarshal([]byte(authData.Code), &settings); err != nil {
+		return backend.Credentials{}, fmt.Errorf("invalid JSON format")
+	}
+	if err := settings.Validate(); err != nil {
+		return backend.Credentials{}, fmt.Errorf("invalid object store settings: %w", err)
+	}
+
+	data := map[string]string{
+		"access_key_id":     settings.AccessKeyID,
+		"secret_access_key": settings.SecretAccessKey,
+	}
+	if settings.SessionToken != "" {
+		data["session_token"] = settings.SessionToken
+	}
+
+	return backend.Credentials{
+		Type:      backend.CredentialTypeAccessKey,
+		Data:      data,
+		ExpiresAt: settings.ExpiresAt,
+		OrganizationInfo: &backend.OrganizationInfo{
+			ExternalID: settings.Bucket,
+			Name:       settings.Bucket,
+			Metadata: map[string]string{
+				"endpoint_url": settings.EndpointURL,
+				"bucket":       settings.Bucket,
+				"region":       settings.Region,
+			},
+		},
+	}, nil
+}
+
+func (c *Connector) ValidateCredentials(creds backend.Credentials) error {
+	if creds.Data["access_key_id"] == "" || creds.Data["secret_access_key"] == "" {
+		return fmt.Errorf("access_key_id and secret_access_key are required")
+	}
+	if creds.ExpiresAt != nil && !creds.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("object store %w", backend.ErrCredentialExpired)
+	}
+	return nil
+}
+
+func (c *Connector) RefreshCredentials(creds backend.Credentials) (backend.Credentials, error) {
+	return creds, nil
+}
+
+func (c *Connector) RevokeCredentials(creds backend.Credentials) error {
+	return nil
+}
+
+// Permissions returns an empty map: access is governed by the bucket policy
+// attached to the keys rather than scopes.
+func (c *Connector) Permissions(creds backend.Credentials) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+func (c *Connector) ConfigureWebhooks(integrationID string, creds backend.Credentials) error {
+	return nil
+}
+
+func (c *Connector) ValidateWebhookSignature(payload []byte, signature string, secret string) error {
+	return fmt.Errorf("webhooks not supported for object store connector")
+}
+
+func (c *Connector) Subscribe(ctx context.Context, handler func(ctx context.Context, event any) error) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (c *Connector) ProcessEvent(ctx context.Context, event any) error {
+	return fmt.Errorf("event processing not supported for object store connector")
+}
+
+func (c *Connector) Sync(ctx context.Context, integration backend.Integration, params map[string]string) (backend.SyncResult, error) {
+	return backend.SyncResult{}, nil
+}
@@ -0,0 +1,57 @@
+package objectstore
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+)
+
+func TestCompleteAuthorization(t *testing.T) {
+	c := Config{}.New()
+
+	t.Run("stores keys as credentials and the bucket as metadata", func(t *testing.T) {
+		creds, err := c.CompleteAuthorization(backend.AuthorizationData{
+			Code: `{"endpoint_url":"https://minio.internal:9000","bucket":"tf-state","region":"us-east-1","access_key_id":"AKIA","secret_access_key":"s3cr3t"}`,
+		})
+		if err != nil {
+			t.Fatalf("CompleteAuthorization() error = %v", err)
+		}
+		if creds.Type != backend.CredentialTypeAccessKey || creds.Data["access_key_id"] != "AKIA" || creds.Data["secret_access_key"] != "s3cr3t" {
+			t.Errorf("credentials = %+v", creds)
+		}
+		if _, ok := creds.Data["session_token"]; ok {
+			t.Error("session_token stored although none was given")
+		}
+		metadata := creds.OrganizationInfo.Metadata
+		if metadata["endpoint_url"] != "https://minio.internal:9000" || metadata["bucket"] != "tf-state" || metadata["region"] != "us-east-1" {
+			t.Errorf("metadata = %v", metadata)
+		}
+	})
+
+	t.Run("reports every missing field", func(t *testing.T) {
+		_, err := c.CompleteAuthorization(backend.AuthorizationData{Code: `{"endpoint_url":"minio.internal"}`})
+		if err == nil {
+			t.Fatal("CompleteAuthorization() error = nil")
+		}
+		for _, want := range []string{"endpoint_url must be", "bucket", "access_key_id", "secret_access_key"} {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("error %q does not mention %s", err, want)
+			}
+		}
+	})
+}
+
+func TestValidateCredentials(t *testing.T) {
+	c := Config{}.New()
+	past := time.Now().Add(-time.Minute)
+
+	err := c.ValidateCredentials(backend.Credentials{
+		Data:      map[string]string{"access_key_id": "AKIA", "secret_access_key": "s3cr3t"},
+		ExpiresAt: &past,
+	})
+	if err == nil {
+		t.Error("ValidateCredentials() accepted expired credentials")
+	}
+}
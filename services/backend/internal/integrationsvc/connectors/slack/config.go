@@ -1,7 +1,10 @@
 package slack
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
@@ -17,6 +20,28 @@ type Config struct {
 	AppToken      string   `mapstructure:"app_token"`
 }
 
+func (c Config) Validate() error {
+	var errs []error
+	if c.ClientID == "" {
+		errs = append(errs, errors.New("missing client_id"))
+	}
+	if c.ClientSecret == "" {
+		errs = append(errs, errors.New("missing client_secret"))
+	}
+	if c.RedirectURL == "" {
+		errs = append(errs, errors.New("missing redirect_url"))
+	}
+	if c.AppToken == "" {
+		errs = append(errs, errors.New("missing app_token"))
+	} else if !strings.HasPrefix(c.AppToken, "xapp-") {
+		errs = append(errs, errors.New("app_token must be an app-level token starting with xapp-"))
+	}
+	if c.BotToken != "" && !strings.HasPrefix(c.BotToken, "xoxb-") {
+		errs = append(errs, errors.New("bot_token must be a bot token starting with xoxb-"))
+	}
+	return errors.Join(errs...)
+}
+
 func (c Config) New() domain.Connector {
 	c.Scopes = []string{
 		"app_mentions:read",
@@ -32,17 +57,8 @@ func (c Config) New() domain.Connector {
 		"groups:history",
 	}
 
-	if c.RedirectURL == "" {
-		panic("missing redirect_url")
-	}
-	if c.AppToken == "" {
-		panic("missing app_token")
-	}
-	if c.ClientSecret == "" {
-		panic("missing client_secret")
-	}
-	if c.ClientID == "" {
-		panic("missing client_id")
+	if err := c.Validate(); err != nil {
+		panic(fmt.Sprintf("invalid slack config: %v", err))
 	}
 
 	return &slackConnector{
@@ -43,17 +43,17 @@ func (s *slackConnector) InitiateAuthorization(organizationID string, userID str
 func (s *slackConnector) ParseState(state string) (organizationID uuid.UUID, userID uuid.UUID, err error) {
 	parts := strings.Split(state, ":")
 	if len(parts) < 3 {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid state format, expected organizationID:userID:timestamp")
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid state format, expected organizationID:userID:timestamp", backend.ErrInvalidState)
 	}
 
 	organizationID, err = uuid.Parse(parts[0])
 	if err != nil {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid organization ID: %w", err)
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid organization ID: %w", backend.ErrInvalidState, err)
 	}
 
 	userID, err = uuid.Parse(parts[1])
 	if err != nil {
-		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid user ID: %w", err)
+		return uuid.Nil, uuid.Nil, fmt.Errorf("%w: invalid user ID: %w", backend.ErrInvalidState, err)
 	}
 
 	return organizationID, userID, nil
@@ -121,6 +121,17 @@ func (s *slackConnector) ValidateCredentials(creds backend.Credentials) error {
 	return nil
 }
 
+// Permissions returns the OAuth scopes granted to the bot token.
+func (s *slackConnector) Permissions(creds backend.Credentials) (map[string]string, error) {
+	permissions := make(map[string]string)
+	for _, scope := range strings.Split(creds.Data["scope"], ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			permissions[scope] = "granted"
+		}
+	}
+	return permissions, nil
+}
+
 func (s *slackConnector) RefreshCredentials(creds backend.Credentials) (backend.Credentials, error) {
 	return creds, fmt.Errorf("Slack OAuth2 tokens do not support refresh")
 }
@@ -151,6 +162,10 @@ func (s *slackConnector) RevokeCredentials(creds backend.Credentials) error {
 	}
 
 	if !response.OK {
+		// The token is already unusable, which is what revocation wants.
+		if response.Error == "invalid_auth" || response.Error == "token_revoked" {
+			return nil
+		}
 		return fmt.Errorf("failed to revoke credentials: %s", response.Error)
 	}
 
@@ -248,18 +263,18 @@ func (s *slackConnector) ProcessEvent(ctx context.Context, event any) error {
 	return nil
 }
 
-func (s *slackConnector) Sync(ctx context.Context, integration backend.Integration, params map[string]string) error {
+func (s *slackConnector) Sync(ctx context.Context, integration backend.Integration, params map[string]string) (backend.SyncResult, error) {
 	// Sync workspace information and validate credentials
 	if err := s.syncWorkspace(ctx, integration); err != nil {
-		return fmt.Errorf("failed to sync workspace: %w", err)
+		return backend.SyncResult{}, fmt.Errorf("failed to sync workspace: %w", err)
 	}
 
 	// Sync channels information
 	if err := s.syncChannels(ctx, integration); err != nil {
-		return fmt.Errorf("failed to sync channels: %w", err)
+		return backend.SyncResult{}, fmt.Errorf("failed to sync channels: %w", err)
 	}
 
-	return nil
+	return backend.SyncResult{}, nil
 }
 
 func (s *slackConnector) syncWorkspace(ctx context.Context, integration backend.Integration) error {
@@ -0,0 +1,175 @@
+package integrationsvc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+const credentialAccessAlertWindow = time.Hour
+
+type CredentialAccessConfig struct {
+	// AlertThresholdPerHour alerts the organization when an integration's
+	// credentials are read more often than this in an hour. Zero disables it.
+	AlertThresholdPerHour int `mapstructure:"alert_threshold_per_hour"`
+}
+
+func (s *service) CredentialAccessHistory(ctx context.Context, query backend.CredentialAccessQuery) (backend.CredentialAccessPage, error) {
+	if _, err := s.Integration(ctx, backend.IntegrationQuery{
+		IntegrationID:  query.IntegrationID,
+		OrganizationID: query.OrganizationID,
+	}); err != nil {
+		return backend.CredentialAccessPage{}, err
+	}
+
+	if s.credentialAccessRepository == nil {
+		return backend.CredentialAccessPage{}, nil
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultActivityPageSize
+	}
+	query.Limit = min(query.Limit, maxActivityPageSize)
+	query.Offset = max(query.Offset, 0)
+
+	page, err := s.credentialAccessRepository.Accesses(ctx, query)
+	if err != nil {
+		return backend.CredentialAccessPage{}, fmt.Errorf("failed to list credential access: %w", err)
+	}
+	return page, nil
+}
+
+// withCredentialAccessReason tags credential reads made by the service itself.
+// Reads already attributed to a caller, such as a device, keep that caller.
+func withCredentialAccessReason(ctx context.Context, reason string) context.Context {
+	if _, ok := backend.CredentialAccessorFromContext(ctx); ok {
+		return ctx
+	}
+	return backend.WithCredentialAccessor(ctx, backend.CredentialAccessor{
+		Type:   backend.CredentialAccessorService,
+		ID:     "integrationsvc",
+		Reason: reason,
+	})
+}
+
+// auditingCredentialRepository logs every credential read and alerts when an
+// integration's credentials are read more often than the threshold allows.
+type auditingCredentialRepository struct {
+	domain.CredentialRepository
+	integrations domain.IntegrationRepository
+	accesses     domain.CredentialAccessRepository
+	listener     backend.CredentialAccessAlertListener
+	threshold    int
+	alerts       *credentialAccessAlerts
+}
+
+func (r auditingCredentialRepository) FindByIntegration(ctx context.Context, integrationID uuid.UUID) (domain.IntegrationCredential, error) {
+	credential, err := r.CredentialRepository.FindByIntegration(ctx, integrationID)
+	if err != nil {
+		return credential, err
+	}
+
+	integration, err := r.integrations.FindByID(ctx, integrationID)
+	if err != nil {
+		slog.Error("failed to record credential access", "integration_id", integrationID, "error", err)
+		return credential, nil
+	}
+	r.record(ctx, integration, slices.Sorted(maps.Keys(credential.Data)))
+	return credential, nil
+}
+
+func (r auditingCredentialRepository) record(ctx context.Context, integration backend.Integration, fields []string) {
+	accessor, ok := backend.CredentialAccessorFromContext(ctx)
+	if !ok {
+		accessor = backend.CredentialAccessor{Type: backend.CredentialAccessorService, Reason: "internal"}
+	}
+
+	now := time.Now()
+	ctx = context.WithoutCancel(ctx)
+	err := r.accesses.RecordAccess(ctx, backend.CredentialAccess{
+		ID:             uuid.New(),
+		IntegrationID:  integration.ID,
+		OrganizationID: integration.OrganizationID,
+		Accessor:       accessor,
+		Fields:         fields,
+		AccessedAt:     now,
+	})
+	if err != nil {
+		slog.Error("failed to record credential access", "integration_id", integration.ID, "reason", accessor.Reason, "error", err)
+		return
+	}
+
+	if r.threshold <= 0 || r.listener == nil {
+		return
+	}
+	count, err := r.accesses.CountAccessesSince(ctx, integration.ID, now.Add(-credentialAccessAlertWindow))
+	if err != nil {
+		slog.Error("failed to count credential access", "integration_id", integration.ID, "error", err)
+		return
+	}
+	if count <= r.threshold || !r.alerts.claim(integration.ID, now) {
+		return
+	}
+
+	slog.Warn("integration credentials read unusually often",
+		"integration_id", integration.ID, "organization_id", integration.OrganizationID,
+		"accesses", count, "threshold", r.threshold)
+	r.listener.CredentialAccessThresholdExceeded(ctx, backend.CredentialAccessAlert{
+		Integration: integration,
+		Accesses:    count,
+		Threshold:   r.threshold,
+	})
+}
+
+// credentialAccessAlerts alerts at most once per window for each integration
+// so a burst of reads produces a single notification.
+type credentialAccessAlerts struct {
+	mu   sync.Mutex
+	last map[uuid.UUID]time.Time
+}
+
+func newCredentialAccessAlerts() *credentialAccessAlerts {
+	return &credentialAccessAlerts{last: make(map[uuid.UUID]time.Time)}
+}
+
+func (a *credentialAccessAlerts) claim(integrationID uuid.UUID, now time.Time) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if last, ok := a.last[integrationID]; ok && now.Sub(last) < credentialAccessAlertWindow {
+		return false
+	}
+	a.last[integrationID] = now
+	return true
+}
+
+// CredentialAccessAlertNotifier fans credential access alerts out to listeners
+// added after the integration service is built, like StatusNotifier.
+type CredentialAccessAlertNotifier struct {
+	mu        sync.RWMutex
+	listeners []backend.CredentialAccessAlertListener
+}
+
+func (n *CredentialAccessAlertNotifier) Listen(listener backend.CredentialAccessAlertListener) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.listeners = append(n.listeners, listener)
+}
+
+func (n *CredentialAccessAlertNotifier) CredentialAccessThresholdExceeded(ctx context.Context, alert backend.CredentialAccessAlert) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	for _, listener := range n.listeners {
+		listener.CredentialAccessThresholdExceeded(ctx, alert)
+	}
+}
@@ -0,0 +1,104 @@
+package integrationsvc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domaintest"
+	"github.com/google/uuid"
+)
+
+type alertRecorder struct {
+	alerts []backend.CredentialAccessAlert
+}
+
+func (r *alertRecorder) CredentialAccessThresholdExceeded(ctx context.Context, alert backend.CredentialAccessAlert) {
+	r.alerts = append(r.alerts, alert)
+}
+
+func TestCredentialAccess(t *testing.T) {
+	ctx := context.Background()
+
+	integrations := domaintest.NewIntegrationRepository()
+	accesses := domaintest.NewCredentialAccessRepository()
+	alerts := &alertRecorder{}
+	credentials := auditingCredentialRepository{
+		CredentialRepository: domaintest.NewCredentialRepository(integrations),
+		integrations:         integrations,
+		accesses:             accesses,
+		listener:             alerts,
+		threshold:            2,
+		alerts:               newCredentialAccessAlerts(),
+	}
+	svc := NewService(ServiceConfig{
+		IntegrationRepository:      integrations,
+		CredentialRepository:       credentials,
+		CredentialAccessRepository: accesses,
+		Connectors:                 map[backend.ConnectorType]domain.Connector{},
+	})
+
+	orgID := uuid.New()
+	integration := backend.Integration{ID: uuid.New(), OrganizationID: orgID, ConnectorType: backend.ConnectorTypeGCP, Status: backend.IntegrationStatusActive}
+	if err := integrations.Store(ctx, integration); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	err := credentials.Store(ctx, domain.IntegrationCredential{
+		ID:             uuid.New(),
+		IntegrationID:  integration.ID,
+		CredentialType: backend.CredentialTypeServiceAccount,
+		Data:           map[string]string{"service_account_json": "{}", "project_id": "acme"},
+	})
+	if err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	device := backend.CredentialAccessor{
+		Type:   backend.CredentialAccessorDevice,
+		ID:     uuid.NewString(),
+		UserID: uuid.NewString(),
+		IP:     "203.0.113.7",
+		Reason: "/device/credentials/gcp",
+	}
+	query := backend.IntegrationCredentialsQuery{IntegrationID: integration.ID, OrganizationID: orgID}
+	if _, err := svc.IntegrationCredentials(backend.WithCredentialAccessor(ctx, device), query); err != nil {
+		t.Fatalf("IntegrationCredentials() error = %v", err)
+	}
+	for range 3 {
+		if _, err := svc.IntegrationCredentials(ctx, query); err != nil {
+			t.Fatalf("IntegrationCredentials() error = %v", err)
+		}
+	}
+
+	page, err := svc.CredentialAccessHistory(ctx, backend.CredentialAccessQuery{IntegrationID: integration.ID, OrganizationID: orgID})
+	if err != nil {
+		t.Fatalf("CredentialAccessHistory() error = %v", err)
+	}
+	if page.Total != 4 {
+		t.Fatalf("CredentialAccessHistory() total = %d, want 4", page.Total)
+	}
+	first := page.Accesses[len(page.Accesses)-1]
+	if first.Accessor != device || !slices.Equal(first.Fields, []string{"project_id", "service_account_json"}) {
+		t.Errorf("first access = %+v, want the device reading both fields", first)
+	}
+	if latest := page.Accesses[0].Accessor; latest.Type != backend.CredentialAccessorService || latest.Reason != "credentials" {
+		t.Errorf("latest accessor = %+v, want the service tagged credentials", latest)
+	}
+
+	if len(alerts.alerts) != 1 {
+		t.Fatalf("alerts = %d, want a single alert for the burst", len(alerts.alerts))
+	}
+	if alert := alerts.alerts[0]; alert.Integration.ID != integration.ID || alert.Accesses != 3 || alert.Threshold != 2 {
+		t.Errorf("alert = %+v, want 3 accesses over a threshold of 2", alert)
+	}
+
+	t.Run("other organization", func(t *testing.T) {
+		_, err := svc.CredentialAccessHistory(ctx, backend.CredentialAccessQuery{IntegrationID: integration.ID, OrganizationID: uuid.New()})
+		if !errors.Is(err, backend.ErrIntegrationNotFound) {
+			t.Errorf("CredentialAccessHistory() error = %v, want ErrIntegrationNotFound", err)
+		}
+	})
+}
@@ -0,0 +1,168 @@
+package integrationsvc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+// minMintedValidity is the least time minted credentials must have left to be
+// handed out again, so a token never expires while the agent is using it.
+const minMintedValidity = time.Minute
+
+// MintCredentials is the credential broker: the agent only ever receives
+// credentials minted here, while the stored ones stay inside the service.
+func (s *service) MintCredentials(ctx context.Context, cmd backend.MintCredentialsCommand) (backend.MintedCredentials, error) {
+	integration, err := s.integrationRepository.FindByID(ctx, cmd.IntegrationID)
+	if err != nil {
+		return backend.MintedCredentials{}, fmt.Errorf("failed to find integration: %w", err)
+	}
+	if integration.OrganizationID != cmd.OrganizationID {
+		return backend.MintedCredentials{}, backend.ErrIntegrationNotFound
+	}
+	if err := integration.CheckCredentialsUsable(); err != nil {
+		return backend.MintedCredentials{}, err
+	}
+
+	connector, exists := s.connectors[integration.ConnectorType]
+	if !exists {
+		return backend.MintedCredentials{}, fmt.Errorf("%w: %s", backend.ErrUnsupportedConnector, integration.ConnectorType)
+	}
+	minter, ok := connector.(domain.CredentialMinter)
+	if !ok {
+		return backend.MintedCredentials{}, fmt.Errorf("%w: %s", backend.ErrCredentialMintingUnsupported, integration.ConnectorType)
+	}
+
+	validUntil := time.Now().Add(max(cmd.MinValidity, minMintedValidity))
+	minted, err := s.mintedCredentials.get(mintedCredentialKey(cmd), validUntil, func() (backend.MintedCredentials, error) {
+		readCtx := backend.WithCredentialAccessor(ctx, backend.CredentialAccessor{
+			Type:           backend.CredentialAccessorService,
+			ID:             "integrationsvc",
+			Reason:         "mint_credentials",
+			ConversationID: cmd.ConversationID,
+			ToolCallID:     cmd.ToolCallID,
+		})
+		credential, err := s.credentialRepository.FindByIntegration(readCtx, integration.ID)
+		if err != nil {
+			return backend.MintedCredentials{}, fmt.Errorf("failed to find credentials: %w", err)
+		}
+
+		minted, err := minter.MintCredentials(ctx, backend.Credentials{
+			Type:      credential.CredentialType,
+			Data:      credential.Data,
+			ExpiresAt: credential.ExpiresAt,
+		}, cmd.Scope)
+		if err != nil {
+			return backend.MintedCredentials{}, fmt.Errorf("failed to mint credentials: %w", err)
+		}
+		return minted, nil
+	})
+	if err != nil {
+		return backend.MintedCredentials{}, err
+	}
+
+	s.recordMintedCredentialUse(ctx, integration, cmd, minted)
+	return minted, nil
+}
+
+// recordMintedCredentialUse logs which conversation and tool call received
+// minted credentials, including ones reused from earlier in the conversation.
+func (s *service) recordMintedCredentialUse(ctx context.Context, integration backend.Integration, cmd backend.MintCredentialsCommand, minted backend.MintedCredentials) {
+	if s.credentialAccessRepository == nil {
+		return
+	}
+
+	accessor, ok := backend.CredentialAccessorFromContext(ctx)
+	if !ok {
+		accessor = backend.CredentialAccessor{Type: backend.CredentialAccessorService, Reason: "minted_credentials"}
+	}
+	accessor.ConversationID = cmd.ConversationID
+	accessor.ToolCallID = cmd.ToolCallID
+
+	err := s.credentialAccessRepository.RecordAccess(context.WithoutCancel(ctx), backend.CredentialAccess{
+		ID:             uuid.New(),
+		IntegrationID:  integration.ID,
+		OrganizationID: integration.OrganizationID,
+		Accessor:       accessor,
+		Fields:         slices.Sorted(maps.Keys(minted.Data)),
+		AccessedAt:     time.Now(),
+	})
+	if err != nil {
+		slog.Error("failed to record minted credential use",
+			"integration_id", integration.ID, "conversation_id", cmd.ConversationID, "tool_call_id", cmd.ToolCallID, "error", err)
+	}
+}
+
+// mintedCredentialKey identifies credentials that can be shared between tool
+// calls: the same integration and scope within one conversation. Credentials
+// minted outside a conversation are not shared.
+func mintedCredentialKey(cmd backend.MintCredentialsCommand) string {
+	if cmd.ConversationID == "" {
+		return ""
+	}
+	return strings.Join([]string{
+		cmd.IntegrationID.String(),
+		cmd.ConversationID,
+		strings.Join(slices.Sorted(slices.Values(cmd.Scope.OAuthScopes)), " "),
+		strings.Join(slices.Sorted(slices.Values(cmd.Scope.Repositories)), " "),
+	}, "\x00")
+}
+
+// mintedCredentialCache holds minted credentials until they expire so that a
+// conversation's tool calls share them and are given fresh ones transparently
+// once they run out.
+type mintedCredentialCache struct {
+	mu      sync.Mutex
+	entries map[string]backend.MintedCredentials
+}
+
+func newMintedCredentialCache() *mintedCredentialCache {
+	return &mintedCredentialCache{entries: make(map[string]backend.MintedCredentials)}
+}
+
+// get returns the credentials cached under key if they are valid until
+// validUntil, and otherwise mints and caches new ones. An empty key always
+// mints.
+func (c *mintedCredentialCache) get(key string, validUntil time.Time, mint func() (backend.MintedCredentials, error)) (backend.MintedCredentials, error) {
+	if key != "" {
+		c.mu.Lock()
+		cached, ok := c.entries[key]
+		c.mu.Unlock()
+		if ok && cached.ExpiresAt.After(validUntil) {
+			return cloneMinted(cached), nil
+		}
+	}
+
+	minted, err := mint()
+	if err != nil {
+		return backend.MintedCredentials{}, err
+	}
+	if key == "" {
+		return minted, nil
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range c.entries {
+		if !e.ExpiresAt.After(now) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cloneMinted(minted)
+	return minted, nil
+}
+
+func cloneMinted(minted backend.MintedCredentials) backend.MintedCredentials {
+	minted.Data = maps.Clone(minted.Data)
+	return minted
+}
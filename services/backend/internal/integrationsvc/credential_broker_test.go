@@ -0,0 +1,129 @@
+package integrationsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domaintest"
+	"github.com/google/uuid"
+)
+
+// fakeMinter mints numbered tokens that are valid for validity.
+type fakeMinter struct {
+	domain.Connector
+	validity time.Duration
+	minted   int
+}
+
+func (m *fakeMinter) MintCredentials(ctx context.Context, creds backend.Credentials, scope backend.CredentialScope) (backend.MintedCredentials, error) {
+	m.minted++
+	return backend.MintedCredentials{
+		Type:      backend.CredentialTypeToken,
+		Data:      map[string]string{"token": fmt.Sprintf("token-%d", m.minted)},
+		ExpiresAt: time.Now().Add(m.validity),
+	}, nil
+}
+
+func TestMintCredentials(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+
+	newService := func(t *testing.T, minter *fakeMinter) (backend.IntegrationService, backend.Integration) {
+		t.Helper()
+		integrations := domaintest.NewIntegrationRepository()
+		credentials := domaintest.NewCredentialRepository(integrations)
+		svc := NewService(ServiceConfig{
+			IntegrationRepository: integrations,
+			CredentialRepository:  credentials,
+			Connectors:            map[backend.ConnectorType]domain.Connector{backend.ConnectorTypeGithub: minter},
+		})
+
+		integration := backend.Integration{ID: uuid.New(), OrganizationID: orgID, ConnectorType: backend.ConnectorTypeGithub, Status: backend.IntegrationStatusActive}
+		if err := integrations.Store(ctx, integration); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+		err := credentials.Store(ctx, domain.IntegrationCredential{
+			ID:             uuid.New(),
+			IntegrationID:  integration.ID,
+			CredentialType: backend.CredentialTypeToken,
+			Data:           map[string]string{"installation_id": "42"},
+		})
+		if err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+		return svc, integration
+	}
+
+	mint := func(t *testing.T, svc backend.IntegrationService, cmd backend.MintCredentialsCommand) string {
+		t.Helper()
+		minted, err := svc.MintCredentials(ctx, cmd)
+		if err != nil {
+			t.Fatalf("MintCredentials() error = %v", err)
+		}
+		return minted.Data["token"]
+	}
+
+	t.Run("reuses credentials within a conversation", func(t *testing.T) {
+		minter := &fakeMinter{validity: time.Hour}
+		svc, integration := newService(t, minter)
+		cmd := backend.MintCredentialsCommand{IntegrationID: integration.ID, OrganizationID: orgID, ConversationID: "conv-1", ToolCallID: "call-1"}
+
+		first := mint(t, svc, cmd)
+		cmd.ToolCallID = "call-2"
+		if got := mint(t, svc, cmd); got != first {
+			t.Errorf("second tool call got %q, want the conversation's %q", got, first)
+		}
+		cmd.ConversationID = "conv-2"
+		if got := mint(t, svc, cmd); got == first {
+			t.Errorf("another conversation got %q, want newly minted credentials", got)
+		}
+		cmd.ConversationID = ""
+		if got := mint(t, svc, cmd); minter.minted != 3 {
+			t.Errorf("call outside a conversation got %q after %d mints, want it minted", got, minter.minted)
+		}
+	})
+
+	t.Run("mints again when cached credentials would expire too soon", func(t *testing.T) {
+		minter := &fakeMinter{validity: 10 * time.Minute}
+		svc, integration := newService(t, minter)
+		cmd := backend.MintCredentialsCommand{IntegrationID: integration.ID, OrganizationID: orgID, ConversationID: "conv-1", MinValidity: 5 * time.Minute}
+
+		first := mint(t, svc, cmd)
+		if got := mint(t, svc, cmd); got != first {
+			t.Errorf("got %q, want the cached %q while it is valid for MinValidity", got, first)
+		}
+		cmd.MinValidity = 15 * time.Minute
+		if got := mint(t, svc, cmd); got == first {
+			t.Errorf("got %q, want newly minted credentials once MinValidity outlasts the cached ones", got)
+		}
+
+		minter.validity = 30 * time.Second
+		cmd.ConversationID = "conv-2"
+		cmd.MinValidity = 0
+		short := mint(t, svc, cmd)
+		if got := mint(t, svc, cmd); got == short {
+			t.Errorf("got %q, want credentials expiring within %s minted again", got, minMintedValidity)
+		}
+	})
+
+	t.Run("rejects integrations of another organization", func(t *testing.T) {
+		minter := &fakeMinter{validity: time.Hour}
+		svc, integration := newService(t, minter)
+		cmd := backend.MintCredentialsCommand{IntegrationID: integration.ID, OrganizationID: orgID, ConversationID: "conv-1"}
+		mint(t, svc, cmd)
+
+		cmd.OrganizationID = uuid.New()
+		minted, err := svc.MintCredentials(ctx, cmd)
+		if !errors.Is(err, backend.ErrIntegrationNotFound) {
+			t.Errorf("MintCredentials() error = %v, want %v", err, backend.ErrIntegrationNotFound)
+		}
+		if minted.Data != nil || minter.minted != 1 {
+			t.Errorf("MintCredentials() = %+v after %d mints, want nothing handed out", minted, minter.minted)
+		}
+	})
+}
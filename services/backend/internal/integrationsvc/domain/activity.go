@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/google/uuid"
+)
+
+// ActivityRecorder appends entries to an integration's activity feed.
+type ActivityRecorder interface {
+	RecordActivity(ctx context.Context, activity backend.IntegrationActivity) error
+}
+
+type ActivityRepository interface {
+	ActivityRecorder
+	// Activities lists the entries recorded for the query's integration and
+	// organization, which remain after the integration is deleted.
+	Activities(ctx context.Context, query backend.IntegrationActivityQuery) (backend.IntegrationActivityPage, error)
+}
+
+// RecordActivity records activity on a best-effort basis: the feed is a support
+// aid, so failing to write it is logged rather than failing the operation.
+func RecordActivity(ctx context.Context, recorder ActivityRecorder, activity backend.IntegrationActivity) {
+	if recorder == nil {
+		return
+	}
+	if activity.ID == uuid.Nil {
+		activity.ID = uuid.New()
+	}
+	if activity.CreatedAt.IsZero() {
+		activity.CreatedAt = time.Now()
+	}
+	if err := recorder.RecordActivity(ctx, activity); err != nil {
+		slog.Error("failed to record integration activity", "integration_id", activity.IntegrationID, "type", activity.Type, "error", err)
+	}
+}
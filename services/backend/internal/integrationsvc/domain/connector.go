@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/73ai/infragpt/services/backend"
 	"github.com/google/uuid"
@@ -15,6 +16,9 @@ type Connector interface {
 	ValidateCredentials(creds backend.Credentials) error
 	RefreshCredentials(creds backend.Credentials) (backend.Credentials, error)
 	RevokeCredentials(creds backend.Credentials) error
+	// Permissions returns the scopes or permissions granted to the credentials,
+	// keyed by name. Connectors without scopes return an empty map.
+	Permissions(creds backend.Credentials) (map[string]string, error)
 
 	// Webhook methods
 	ConfigureWebhooks(integrationID string, creds backend.Credentials) error
@@ -27,5 +31,51 @@ type Connector interface {
 	ProcessEvent(ctx context.Context, event any) error
 
 	// Sync method - performs connector-specific synchronization operations
-	Sync(ctx context.Context, integration backend.Integration, params map[string]string) error
+	// and reports what changed
+	Sync(ctx context.Context, integration backend.Integration, params map[string]string) (backend.SyncResult, error)
+}
+
+// WebhookRouter is implemented by connectors that can receive webhooks on the
+// backend's main HTTP server rather than on a port of their own.
+type WebhookRouter interface {
+	// RegisterRoutes adds the connector's webhook routes to mux, passing
+	// verified events to handler. It returns false, registering nothing, when
+	// the connector is configured to serve webhooks on its own port.
+	RegisterRoutes(mux *http.ServeMux, handler func(ctx context.Context, event any) error) bool
+}
+
+// SyncStatusReporter is implemented by connectors that track synced resources.
+type SyncStatusReporter interface {
+	SyncStatus(ctx context.Context, integration backend.Integration) (backend.IntegrationSyncStatus, error)
+}
+
+// RepositoryLister is implemented by connectors that sync source repositories.
+type RepositoryLister interface {
+	Repositories(ctx context.Context, integration backend.Integration) ([]backend.SyncedRepository, error)
+}
+
+// RepositorySelector is implemented by connectors whose repositories can be
+// deselected for agent access.
+type RepositorySelector interface {
+	SetRepositoriesEnabled(ctx context.Context, integration backend.Integration, repositoryIDs []int64, enabled bool) (int, error)
+}
+
+// PullRequestFileLister is implemented by connectors that can list the files
+// a pull request changes.
+type PullRequestFileLister interface {
+	PullRequestFiles(ctx context.Context, integration backend.Integration, repository string, number int) ([]string, error)
+}
+
+// CredentialMinter is implemented by connectors that can derive short-lived,
+// narrowly scoped credentials from the stored ones.
+type CredentialMinter interface {
+	MintCredentials(ctx context.Context, creds backend.Credentials, scope backend.CredentialScope) (backend.MintedCredentials, error)
+}
+
+// InstallationLocker serializes work on a connector installation across
+// backend replicas, such as two redirects claiming the same installation.
+type InstallationLocker interface {
+	// LockInstallation blocks until the installation's lock is held and
+	// returns a function that releases it.
+	LockInstallation(ctx context.Context, connectorType backend.ConnectorType, installationID string) (unlock func(), err error)
 }
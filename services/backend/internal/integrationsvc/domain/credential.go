@@ -21,6 +21,7 @@ type IntegrationCredential struct {
 
 type CredentialRepository interface {
 	Store(ctx context.Context, cred IntegrationCredential) error
+	// FindByIntegration returns ErrCredentialNotFound when the integration has no credential.
 	FindByIntegration(ctx context.Context, integrationID uuid.UUID) (IntegrationCredential, error)
 	Update(ctx context.Context, cred IntegrationCredential) error
 	Delete(ctx context.Context, integrationID uuid.UUID) error
@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/google/uuid"
+)
+
+// CredentialAccessRepository is the append-only log of credential reads.
+type CredentialAccessRepository interface {
+	RecordAccess(ctx context.Context, access backend.CredentialAccess) error
+	Accesses(ctx context.Context, query backend.CredentialAccessQuery) (backend.CredentialAccessPage, error)
+	// CountAccessesSince counts an integration's accesses at or after since.
+	CountAccessesSince(ctx context.Context, integrationID uuid.UUID, since time.Time) (int, error)
+}
@@ -3,5 +3,8 @@ package domain
 import "errors"
 
 var (
-	ErrIntegrationNotFound = errors.New("integration not found")
+	ErrCredentialNotFound      = errors.New("credential not found")
+	ErrInvalidBundle           = errors.New("invalid integration bundle")
+	ErrInvalidBundlePassphrase = errors.New("invalid integration bundle passphrase")
+	ErrSyncInProgress          = errors.New("integration sync already in progress")
 )
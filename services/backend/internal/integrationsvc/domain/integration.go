@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/73ai/infragpt/services/backend"
 	"github.com/google/uuid"
@@ -16,8 +17,41 @@ type IntegrationRepository interface {
 	FindByOrganizationAndStatus(ctx context.Context, orgID uuid.UUID, status backend.IntegrationStatus) ([]backend.Integration, error)
 	FindByOrganizationTypeAndStatus(ctx context.Context, orgID uuid.UUID, connectorType backend.ConnectorType, status backend.IntegrationStatus) ([]backend.Integration, error)
 	FindByBotIDAndType(ctx context.Context, botID string, connectorType backend.ConnectorType) (backend.Integration, error)
+	// FindByConnectorOrganizationIDAndType returns the integrations connected to
+	// an account of the provider, such as a GitHub user or organization.
+	FindByConnectorOrganizationIDAndType(ctx context.Context, connectorOrganizationID string, connectorType backend.ConnectorType) ([]backend.Integration, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status backend.IntegrationStatus) error
 	UpdateLastUsed(ctx context.Context, id uuid.UUID) error
+	// FindDueForSync returns active integrations of a connector type that have
+	// not synced since syncedBefore, least recently synced first.
+	FindDueForSync(ctx context.Context, connectorType backend.ConnectorType, syncedBefore time.Time) ([]backend.Integration, error)
+	// ClaimSync reports whether the caller may run the scheduled sync of an
+	// integration. A claim holds for ttl, so replicas finding the same
+	// integration due sync it once between them.
+	ClaimSync(ctx context.Context, id uuid.UUID, ttl time.Duration) (bool, error)
+	// RecordAuthorization records that the authorization identified by key
+	// created or returned the integration, so that a retried callback served
+	// by another replica returns it too.
+	RecordAuthorization(ctx context.Context, key string, id uuid.UUID) error
+	// FindByAuthorization returns the integration recorded for key within
+	// maxAge, or backend.ErrIntegrationNotFound.
+	FindByAuthorization(ctx context.Context, key string, maxAge time.Duration) (backend.Integration, error)
+	UpdateLastSynced(ctx context.Context, id uuid.UUID, syncedAt time.Time) error
 	UpdateMetadata(ctx context.Context, id uuid.UUID, metadata map[string]string) error
+	UpdateGrants(ctx context.Context, id uuid.UUID, grants []backend.IntegrationGrant) error
 	Delete(ctx context.Context, id uuid.UUID) error
 }
+
+// IntegrationData counts the records deleted with an integration.
+type IntegrationData struct {
+	Credentials  int
+	Repositories int
+	Activities   int
+}
+
+type IntegrationDataRepository interface {
+	// DeleteIntegrationData deletes the integration with its credentials,
+	// synced repositories and activity in one transaction. With dryRun the
+	// transaction is rolled back, so the counts report what would be deleted.
+	DeleteIntegrationData(ctx context.Context, integrationID uuid.UUID, dryRun bool) (IntegrationData, error)
+}
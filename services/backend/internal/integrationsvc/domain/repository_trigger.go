@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/google/uuid"
+)
+
+type RepositoryTriggerRepository interface {
+	Store(ctx context.Context, trigger backend.RepositoryTrigger) error
+	// Update returns backend.ErrRepositoryTriggerNotFound when the
+	// organization has no trigger with the ID.
+	Update(ctx context.Context, trigger backend.RepositoryTrigger) error
+	// Delete returns backend.ErrRepositoryTriggerNotFound when the
+	// organization has no trigger with the ID.
+	Delete(ctx context.Context, organizationID, id uuid.UUID) error
+	FindByOrganization(ctx context.Context, organizationID uuid.UUID) ([]backend.RepositoryTrigger, error)
+	// FindAll returns the triggers of every organization, for matching events
+	// before their integration is looked up.
+	FindAll(ctx context.Context) ([]backend.RepositoryTrigger, error)
+	// DeleteByOrganization returns how many triggers were deleted, or would
+	// be when dryRun is set.
+	DeleteByOrganization(ctx context.Context, organizationID uuid.UUID, dryRun bool) (int, error)
+}
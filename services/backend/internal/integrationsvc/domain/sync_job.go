@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/google/uuid"
+)
+
+var ErrSyncJobNotRunning = errors.New("sync job is not running")
+
+type SyncJobStatus string
+
+const (
+	SyncJobStatusRunning   SyncJobStatus = "running"
+	SyncJobStatusSucceeded SyncJobStatus = "succeeded"
+	SyncJobStatusFailed    SyncJobStatus = "failed"
+)
+
+// SyncJob is one run of a connector sync. The worker running it refreshes
+// HeartbeatAt until it finishes, so a running job with a stale heartbeat was
+// lost with its worker.
+type SyncJob struct {
+	ID            uuid.UUID
+	IntegrationID uuid.UUID
+	Status        SyncJobStatus
+	// Attempt is 1 for a requested or scheduled sync and counts up as a lost
+	// sync is rescheduled.
+	Attempt     int
+	Parameters  map[string]string
+	Error       string
+	StartedAt   time.Time
+	HeartbeatAt time.Time
+	FinishedAt  *time.Time
+	Result      backend.SyncResult
+}
+
+type SyncJobRepository interface {
+	Start(ctx context.Context, job SyncJob) error
+	// Heartbeat returns ErrSyncJobNotRunning once the job has finished or
+	// been failed by FailStale.
+	Heartbeat(ctx context.Context, id uuid.UUID, at time.Time) error
+	// Finish records the job's Status, Error, Result and FinishedAt.
+	Finish(ctx context.Context, job SyncJob) error
+	// FailStale marks running jobs whose last heartbeat is before staleBefore
+	// as failed and returns them. Each job is returned to one caller only, so
+	// replicas can reap concurrently.
+	FailStale(ctx context.Context, staleBefore time.Time, errorMessage string, at time.Time) ([]SyncJob, error)
+	Health(ctx context.Context, integrationID uuid.UUID) (backend.IntegrationSyncHealth, error)
+}
@@ -0,0 +1,57 @@
+package domaintest
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+)
+
+type activityRepository struct {
+	mu         sync.RWMutex
+	activities []backend.IntegrationActivity
+}
+
+// NewActivityRepository returns an in-memory activity feed.
+func NewActivityRepository() domain.ActivityRepository {
+	return &activityRepository{}
+}
+
+func (r *activityRepository) RecordActivity(ctx context.Context, activity backend.IntegrationActivity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	activity.Details = maps.Clone(activity.Details)
+	r.activities = append(r.activities, activity)
+	return nil
+}
+
+func (r *activityRepository) Activities(ctx context.Context, query backend.IntegrationActivityQuery) (backend.IntegrationActivityPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matching []backend.IntegrationActivity
+	for _, activity := range slices.Backward(r.activities) {
+		if activity.IntegrationID != query.IntegrationID || activity.OrganizationID != query.OrganizationID {
+			continue
+		}
+		if activity.CreatedAt.Before(query.Since) || (!query.Until.IsZero() && !activity.CreatedAt.Before(query.Until)) {
+			continue
+		}
+		activity.Details = maps.Clone(activity.Details)
+		matching = append(matching, activity)
+	}
+	slices.SortStableFunc(matching, func(a, b backend.IntegrationActivity) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+
+	start := min(max(query.Offset, 0), len(matching))
+	end := len(matching)
+	if query.Limit > 0 {
+		end = min(start+query.Limit, len(matching))
+	}
+	return backend.IntegrationActivityPage{Activities: matching[start:end], Total: len(matching)}, nil
+}
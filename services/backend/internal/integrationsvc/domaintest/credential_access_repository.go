@@ -0,0 +1,71 @@
+package domaintest
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+type credentialAccessRepository struct {
+	mu       sync.RWMutex
+	accesses []backend.CredentialAccess
+}
+
+// NewCredentialAccessRepository returns an in-memory credential access log.
+func NewCredentialAccessRepository() domain.CredentialAccessRepository {
+	return &credentialAccessRepository{}
+}
+
+func (r *credentialAccessRepository) RecordAccess(ctx context.Context, access backend.CredentialAccess) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	access.Fields = slices.Clone(access.Fields)
+	r.accesses = append(r.accesses, access)
+	return nil
+}
+
+func (r *credentialAccessRepository) Accesses(ctx context.Context, query backend.CredentialAccessQuery) (backend.CredentialAccessPage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matching []backend.CredentialAccess
+	for _, access := range slices.Backward(r.accesses) {
+		if access.IntegrationID != query.IntegrationID {
+			continue
+		}
+		if access.AccessedAt.Before(query.Since) || (!query.Until.IsZero() && !access.AccessedAt.Before(query.Until)) {
+			continue
+		}
+		access.Fields = slices.Clone(access.Fields)
+		matching = append(matching, access)
+	}
+	slices.SortStableFunc(matching, func(a, b backend.CredentialAccess) int {
+		return b.AccessedAt.Compare(a.AccessedAt)
+	})
+
+	start := min(max(query.Offset, 0), len(matching))
+	end := len(matching)
+	if query.Limit > 0 {
+		end = min(start+query.Limit, len(matching))
+	}
+	return backend.CredentialAccessPage{Accesses: matching[start:end], Total: len(matching)}, nil
+}
+
+func (r *credentialAccessRepository) CountAccessesSince(ctx context.Context, integrationID uuid.UUID, since time.Time) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, access := range r.accesses {
+		if access.IntegrationID == integrationID && !access.AccessedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
@@ -0,0 +1,98 @@
+package domaintest
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+type credentialRepository struct {
+	mu           sync.RWMutex
+	integrations domain.IntegrationRepository
+	credentials  map[uuid.UUID]domain.IntegrationCredential
+}
+
+// NewCredentialRepository returns an in-memory credential store. Credentials can
+// only be stored for integrations known to integrations, mirroring the foreign key
+// of the Postgres schema.
+func NewCredentialRepository(integrations domain.IntegrationRepository) domain.CredentialRepository {
+	return &credentialRepository{
+		integrations: integrations,
+		credentials:  make(map[uuid.UUID]domain.IntegrationCredential),
+	}
+}
+
+func (r *credentialRepository) Store(ctx context.Context, cred domain.IntegrationCredential) error {
+	if _, err := r.integrations.FindByID(ctx, cred.IntegrationID); err != nil {
+		return fmt.Errorf("failed to store credential: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.credentials[cred.IntegrationID]; exists {
+		return fmt.Errorf("credential for integration %s already exists", cred.IntegrationID)
+	}
+
+	cred.Data = maps.Clone(cred.Data)
+	r.credentials[cred.IntegrationID] = cred
+	return nil
+}
+
+func (r *credentialRepository) FindByIntegration(ctx context.Context, integrationID uuid.UUID) (domain.IntegrationCredential, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cred, exists := r.credentials[integrationID]
+	if !exists {
+		return domain.IntegrationCredential{}, fmt.Errorf("%w for integration %s", domain.ErrCredentialNotFound, integrationID)
+	}
+
+	cred.Data = maps.Clone(cred.Data)
+	return cred, nil
+}
+
+func (r *credentialRepository) Update(ctx context.Context, cred domain.IntegrationCredential) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.credentials[cred.IntegrationID]
+	if !exists {
+		return nil
+	}
+
+	existing.CredentialType = cred.CredentialType
+	existing.Data = maps.Clone(cred.Data)
+	existing.ExpiresAt = cred.ExpiresAt
+	existing.EncryptionKeyID = cred.EncryptionKeyID
+	existing.UpdatedAt = time.Now()
+	r.credentials[cred.IntegrationID] = existing
+	return nil
+}
+
+func (r *credentialRepository) Delete(ctx context.Context, integrationID uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.credentials, integrationID)
+	return nil
+}
+
+func (r *credentialRepository) FindExpiring(ctx context.Context, before time.Time) ([]domain.IntegrationCredential, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []domain.IntegrationCredential
+	for _, cred := range r.credentials {
+		if cred.ExpiresAt != nil && cred.ExpiresAt.Before(before) {
+			cred.Data = maps.Clone(cred.Data)
+			result = append(result, cred)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,45 @@
+package domaintest
+
+import (
+	"context"
+	"errors"
+
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+// integrationDataRepository deletes through the in-memory repositories, which
+// do not store synced repositories or activity per integration.
+type integrationDataRepository struct {
+	integrations domain.IntegrationRepository
+	credentials  domain.CredentialRepository
+}
+
+func NewIntegrationDataRepository(integrations domain.IntegrationRepository, credentials domain.CredentialRepository) domain.IntegrationDataRepository {
+	return &integrationDataRepository{
+		integrations: integrations,
+		credentials:  credentials,
+	}
+}
+
+func (r *integrationDataRepository) DeleteIntegrationData(ctx context.Context, integrationID uuid.UUID, dryRun bool) (domain.IntegrationData, error) {
+	var data domain.IntegrationData
+	_, err := r.credentials.FindByIntegration(ctx, integrationID)
+	switch {
+	case err == nil:
+		data.Credentials = 1
+	case !errors.Is(err, domain.ErrCredentialNotFound):
+		return domain.IntegrationData{}, err
+	}
+
+	if dryRun {
+		return data, nil
+	}
+	if err := r.credentials.Delete(ctx, integrationID); err != nil {
+		return domain.IntegrationData{}, err
+	}
+	if err := r.integrations.Delete(ctx, integrationID); err != nil {
+		return domain.IntegrationData{}, err
+	}
+	return data, nil
+}
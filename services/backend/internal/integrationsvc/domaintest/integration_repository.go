@@ -0,0 +1,262 @@
+package domaintest
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+type integrationRepository struct {
+	mu           sync.RWMutex
+	integrations map[uuid.UUID]backend.Integration
+	syncClaims   map[uuid.UUID]time.Time
+	authorized   map[string]authorization
+}
+
+type authorization struct {
+	integrationID uuid.UUID
+	recordedAt    time.Time
+}
+
+func NewIntegrationRepository() domain.IntegrationRepository {
+	return &integrationRepository{
+		integrations: make(map[uuid.UUID]backend.Integration),
+		syncClaims:   make(map[uuid.UUID]time.Time),
+		authorized:   make(map[string]authorization),
+	}
+}
+
+func (r *integrationRepository) Store(ctx context.Context, integration backend.Integration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.integrations[integration.ID]; exists {
+		return fmt.Errorf("integration %s already exists", integration.ID)
+	}
+	for _, existing := range r.integrations {
+		if existing.OrganizationID == integration.OrganizationID && existing.ConnectorType == integration.ConnectorType {
+			return fmt.Errorf("%w for organization %s and connector %s", backend.ErrIntegrationAlreadyExists, integration.OrganizationID, integration.ConnectorType)
+		}
+	}
+
+	if len(integration.Grants) == 0 {
+		integration.Grants = backend.DefaultIntegrationGrants
+	}
+	r.integrations[integration.ID] = clone(integration)
+	return nil
+}
+
+func (r *integrationRepository) Update(ctx context.Context, integration backend.Integration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, exists := r.integrations[integration.ID]
+	if !exists {
+		return nil
+	}
+
+	integration.OrganizationID = existing.OrganizationID
+	integration.UserID = existing.UserID
+	integration.CreatedAt = existing.CreatedAt
+	integration.LastSyncedAt = existing.LastSyncedAt
+	integration.Grants = existing.Grants
+	r.integrations[integration.ID] = clone(integration)
+	return nil
+}
+
+func (r *integrationRepository) FindByID(ctx context.Context, id uuid.UUID) (backend.Integration, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	integration, exists := r.integrations[id]
+	if !exists {
+		return backend.Integration{}, fmt.Errorf("integration %s: %w", id, backend.ErrIntegrationNotFound)
+	}
+	return clone(integration), nil
+}
+
+func (r *integrationRepository) FindByOrganization(ctx context.Context, orgID uuid.UUID) ([]backend.Integration, error) {
+	return r.filter(func(i backend.Integration) bool {
+		return i.OrganizationID == orgID
+	}), nil
+}
+
+func (r *integrationRepository) FindByOrganizationAndType(ctx context.Context, orgID uuid.UUID, connectorType backend.ConnectorType) ([]backend.Integration, error) {
+	return r.filter(func(i backend.Integration) bool {
+		return i.OrganizationID == orgID && i.ConnectorType == connectorType
+	}), nil
+}
+
+func (r *integrationRepository) FindByOrganizationAndStatus(ctx context.Context, orgID uuid.UUID, status backend.IntegrationStatus) ([]backend.Integration, error) {
+	return r.filter(func(i backend.Integration) bool {
+		return i.OrganizationID == orgID && i.Status == status
+	}), nil
+}
+
+func (r *integrationRepository) FindByOrganizationTypeAndStatus(ctx context.Context, orgID uuid.UUID, connectorType backend.ConnectorType, status backend.IntegrationStatus) ([]backend.Integration, error) {
+	return r.filter(func(i backend.Integration) bool {
+		return i.OrganizationID == orgID && i.ConnectorType == connectorType && i.Status == status
+	}), nil
+}
+
+func (r *integrationRepository) FindByConnectorOrganizationIDAndType(ctx context.Context, connectorOrganizationID string, connectorType backend.ConnectorType) ([]backend.Integration, error) {
+	return r.filter(func(i backend.Integration) bool {
+		return i.ConnectorOrganizationID == connectorOrganizationID && i.ConnectorType == connectorType
+	}), nil
+}
+
+func (r *integrationRepository) FindByBotIDAndType(ctx context.Context, botID string, connectorType backend.ConnectorType) (backend.Integration, error) {
+	matches := r.filter(func(i backend.Integration) bool {
+		return i.BotID == botID && i.ConnectorType == connectorType
+	})
+	if len(matches) == 0 {
+		return backend.Integration{}, backend.ErrIntegrationNotFound
+	}
+	return matches[0], nil
+}
+
+func (r *integrationRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status backend.IntegrationStatus) error {
+	return r.modify(id, func(i *backend.Integration) {
+		i.Status = status
+	})
+}
+
+func (r *integrationRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID) error {
+	return r.modify(id, func(i *backend.Integration) {
+		now := time.Now()
+		i.LastUsedAt = &now
+	})
+}
+
+func (r *integrationRepository) FindDueForSync(ctx context.Context, connectorType backend.ConnectorType, syncedBefore time.Time) ([]backend.Integration, error) {
+	due := r.filter(func(i backend.Integration) bool {
+		return i.ConnectorType == connectorType && i.Status == backend.IntegrationStatusActive &&
+			(i.LastSyncedAt == nil || i.LastSyncedAt.Before(syncedBefore))
+	})
+	sort.SliceStable(due, func(i, j int) bool {
+		if due[i].LastSyncedAt == nil || due[j].LastSyncedAt == nil {
+			return due[i].LastSyncedAt == nil && due[j].LastSyncedAt != nil
+		}
+		return due[i].LastSyncedAt.Before(*due[j].LastSyncedAt)
+	})
+	return due, nil
+}
+
+func (r *integrationRepository) ClaimSync(ctx context.Context, id uuid.UUID, ttl time.Duration) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if claimedAt, ok := r.syncClaims[id]; ok && claimedAt.After(now.Add(-ttl)) {
+		return false, nil
+	}
+	r.syncClaims[id] = now
+	return true, nil
+}
+
+func (r *integrationRepository) RecordAuthorization(ctx context.Context, key string, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.integrations[id]; !exists {
+		return fmt.Errorf("integration %s does not exist", id)
+	}
+	r.authorized[key] = authorization{integrationID: id, recordedAt: time.Now()}
+	return nil
+}
+
+func (r *integrationRepository) FindByAuthorization(ctx context.Context, key string, maxAge time.Duration) (backend.Integration, error) {
+	r.mu.RLock()
+	a, ok := r.authorized[key]
+	r.mu.RUnlock()
+	if !ok || !a.recordedAt.After(time.Now().Add(-maxAge)) {
+		return backend.Integration{}, backend.ErrIntegrationNotFound
+	}
+	return r.FindByID(ctx, a.integrationID)
+}
+
+func (r *integrationRepository) UpdateLastSynced(ctx context.Context, id uuid.UUID, syncedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	integration, exists := r.integrations[id]
+	if !exists {
+		return nil
+	}
+
+	integration.LastSyncedAt = &syncedAt
+	r.integrations[id] = integration
+	return nil
+}
+
+func (r *integrationRepository) UpdateMetadata(ctx context.Context, id uuid.UUID, metadata map[string]string) error {
+	return r.modify(id, func(i *backend.Integration) {
+		i.Metadata = maps.Clone(metadata)
+	})
+}
+
+func (r *integrationRepository) UpdateGrants(ctx context.Context, id uuid.UUID, grants []backend.IntegrationGrant) error {
+	return r.modify(id, func(i *backend.Integration) {
+		i.Grants = slices.Clone(grants)
+	})
+}
+
+func (r *integrationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.integrations, id)
+	delete(r.syncClaims, id)
+	for key, a := range r.authorized {
+		if a.integrationID == id {
+			delete(r.authorized, key)
+		}
+	}
+	return nil
+}
+
+func (r *integrationRepository) filter(match func(backend.Integration) bool) []backend.Integration {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var result []backend.Integration
+	for _, integration := range r.integrations {
+		if match(integration) {
+			result = append(result, clone(integration))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	return result
+}
+
+func (r *integrationRepository) modify(id uuid.UUID, update func(*backend.Integration)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	integration, exists := r.integrations[id]
+	if !exists {
+		return nil
+	}
+
+	update(&integration)
+	integration.UpdatedAt = time.Now()
+	r.integrations[id] = integration
+	return nil
+}
+
+func clone(integration backend.Integration) backend.Integration {
+	integration.Metadata = maps.Clone(integration.Metadata)
+	integration.Grants = slices.Clone(integration.Grants)
+	return integration
+}
@@ -0,0 +1,95 @@
+package domaintest
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+type repositoryTriggerRepository struct {
+	mu       sync.RWMutex
+	triggers []backend.RepositoryTrigger
+}
+
+// NewRepositoryTriggerRepository returns an in-memory trigger store that
+// lists triggers in creation order.
+func NewRepositoryTriggerRepository() domain.RepositoryTriggerRepository {
+	return &repositoryTriggerRepository{}
+}
+
+func (r *repositoryTriggerRepository) Store(ctx context.Context, trigger backend.RepositoryTrigger) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.triggers = append(r.triggers, trigger)
+	return nil
+}
+
+func (r *repositoryTriggerRepository) Update(ctx context.Context, trigger backend.RepositoryTrigger) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.index(trigger.OrganizationID, trigger.ID)
+	if i < 0 {
+		return backend.ErrRepositoryTriggerNotFound
+	}
+	trigger.CreatedAt = r.triggers[i].CreatedAt
+	r.triggers[i] = trigger
+	return nil
+}
+
+func (r *repositoryTriggerRepository) Delete(ctx context.Context, organizationID, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.index(organizationID, id)
+	if i < 0 {
+		return backend.ErrRepositoryTriggerNotFound
+	}
+	r.triggers = slices.Delete(r.triggers, i, i+1)
+	return nil
+}
+
+func (r *repositoryTriggerRepository) FindByOrganization(ctx context.Context, organizationID uuid.UUID) ([]backend.RepositoryTrigger, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var triggers []backend.RepositoryTrigger
+	for _, trigger := range r.triggers {
+		if trigger.OrganizationID == organizationID {
+			triggers = append(triggers, trigger)
+		}
+	}
+	return triggers, nil
+}
+
+func (r *repositoryTriggerRepository) FindAll(ctx context.Context) ([]backend.RepositoryTrigger, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.triggers), nil
+}
+
+func (r *repositoryTriggerRepository) DeleteByOrganization(ctx context.Context, organizationID uuid.UUID, dryRun bool) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := slices.DeleteFunc(slices.Clone(r.triggers), func(trigger backend.RepositoryTrigger) bool {
+		return trigger.OrganizationID == organizationID
+	})
+	deleted := len(r.triggers) - len(kept)
+	if !dryRun {
+		r.triggers = kept
+	}
+	return deleted, nil
+}
+
+func (r *repositoryTriggerRepository) index(organizationID, id uuid.UUID) int {
+	return slices.IndexFunc(r.triggers, func(trigger backend.RepositoryTrigger) bool {
+		return trigger.ID == id && trigger.OrganizationID == organizationID
+	})
+}
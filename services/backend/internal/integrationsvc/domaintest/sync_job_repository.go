@@ -0,0 +1,119 @@
+package domaintest
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+type syncJobRepository struct {
+	mu   sync.Mutex
+	jobs []domain.SyncJob
+}
+
+// NewSyncJobRepository returns an in-memory sync job store.
+func NewSyncJobRepository() domain.SyncJobRepository {
+	return &syncJobRepository{}
+}
+
+func (r *syncJobRepository) Start(ctx context.Context, job domain.SyncJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	job.Parameters = maps.Clone(job.Parameters)
+	r.jobs = append(r.jobs, job)
+	return nil
+}
+
+func (r *syncJobRepository) Heartbeat(ctx context.Context, id uuid.UUID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.jobs {
+		if r.jobs[i].ID == id && r.jobs[i].Status == domain.SyncJobStatusRunning {
+			r.jobs[i].HeartbeatAt = at
+			return nil
+		}
+	}
+	return domain.ErrSyncJobNotRunning
+}
+
+func (r *syncJobRepository) Finish(ctx context.Context, job domain.SyncJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.jobs {
+		if r.jobs[i].ID == job.ID {
+			r.jobs[i].Status = job.Status
+			r.jobs[i].Error = job.Error
+			r.jobs[i].FinishedAt = job.FinishedAt
+			r.jobs[i].Result = job.Result
+			r.jobs[i].Result.Errors = slices.Clone(job.Result.Errors)
+		}
+	}
+	return nil
+}
+
+func (r *syncJobRepository) FailStale(ctx context.Context, staleBefore time.Time, errorMessage string, at time.Time) ([]domain.SyncJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var failed []domain.SyncJob
+	for i := range r.jobs {
+		job := &r.jobs[i]
+		if job.Status != domain.SyncJobStatusRunning || !job.HeartbeatAt.Before(staleBefore) {
+			continue
+		}
+		job.Status = domain.SyncJobStatusFailed
+		job.Error = errorMessage
+		job.FinishedAt = &at
+
+		reaped := *job
+		reaped.Parameters = maps.Clone(job.Parameters)
+		failed = append(failed, reaped)
+	}
+	return failed, nil
+}
+
+func (r *syncJobRepository) Health(ctx context.Context, integrationID uuid.UUID) (backend.IntegrationSyncHealth, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var health backend.IntegrationSyncHealth
+	var lastSucceeded *domain.SyncJob
+	for i, job := range r.jobs {
+		if job.IntegrationID != integrationID {
+			continue
+		}
+		if job.Status == domain.SyncJobStatusRunning {
+			health.Running = true
+		}
+		if job.Status == domain.SyncJobStatusSucceeded && (lastSucceeded == nil || job.StartedAt.After(lastSucceeded.StartedAt)) {
+			lastSucceeded = &r.jobs[i]
+		}
+	}
+
+	var failuresSince time.Time
+	if lastSucceeded != nil {
+		failuresSince = lastSucceeded.StartedAt
+		if lastSucceeded.FinishedAt != nil {
+			finishedAt := *lastSucceeded.FinishedAt
+			health.LastSuccessfulSyncAt = &finishedAt
+		}
+		result := lastSucceeded.Result
+		result.Errors = slices.Clone(result.Errors)
+		health.LastSyncResult = &result
+	}
+	for _, job := range r.jobs {
+		if job.IntegrationID == integrationID && job.Status == domain.SyncJobStatusFailed && job.StartedAt.After(failuresSince) {
+			health.ConsecutiveFailures++
+		}
+	}
+	return health, nil
+}
@@ -0,0 +1,87 @@
+package integrationsvc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/73ai/infragpt/services/backend"
+)
+
+func (s *service) SetIntegrationGrants(ctx context.Context, cmd backend.SetIntegrationGrantsCommand) (backend.Integration, error) {
+	grants, err := normalizeGrants(cmd.Grants)
+	if err != nil {
+		return backend.Integration{}, err
+	}
+
+	integration, err := s.Integration(ctx, backend.IntegrationQuery{
+		IntegrationID:  cmd.IntegrationID,
+		OrganizationID: cmd.OrganizationID,
+	})
+	if err != nil {
+		return backend.Integration{}, err
+	}
+
+	if err := s.integrationRepository.UpdateGrants(ctx, integration.ID, grants); err != nil {
+		return backend.Integration{}, fmt.Errorf("failed to update integration grants: %w", err)
+	}
+	s.recordActivity(ctx, integration, backend.IntegrationActivityGrantsChanged, map[string]string{
+		"from": joinGrants(integration.Grants),
+		"to":   joinGrants(grants),
+	})
+
+	integration.Grants = grants
+	return integration, nil
+}
+
+// CheckGrant is the single place deciding whether InfraGPT may act on an
+// integration; callers must not read Integration.Grants themselves.
+func (s *service) CheckGrant(ctx context.Context, query backend.CheckGrantQuery) error {
+	integration, err := s.Integration(ctx, backend.IntegrationQuery{
+		IntegrationID:  query.IntegrationID,
+		OrganizationID: query.OrganizationID,
+	})
+	if err != nil {
+		return err
+	}
+	if !integration.Granted(query.Grant) {
+		return fmt.Errorf("%w: %s over %s integration %s", backend.ErrGrantMissing, query.Grant, integration.ConnectorType, integration.ID)
+	}
+	return nil
+}
+
+// writeGrantRequires maps each write grant to the read grant it builds on.
+var writeGrantRequires = map[backend.IntegrationGrant]backend.IntegrationGrant{
+	backend.IntegrationGrantWriteContent: backend.IntegrationGrantReadContent,
+	backend.IntegrationGrantWriteInfra:   backend.IntegrationGrantReadInfra,
+}
+
+// normalizeGrants rejects unknown grants and write grants without their read
+// grant, and returns the grants deduplicated in DefaultIntegrationGrants order.
+func normalizeGrants(grants []backend.IntegrationGrant) ([]backend.IntegrationGrant, error) {
+	for _, grant := range grants {
+		if !grant.Valid() {
+			return nil, fmt.Errorf("%w: unknown grant %q", backend.ErrInvalidGrant, grant)
+		}
+		if read, ok := writeGrantRequires[grant]; ok && !slices.Contains(grants, read) {
+			return nil, fmt.Errorf("%w: %s requires %s", backend.ErrInvalidGrant, grant, read)
+		}
+	}
+
+	normalized := []backend.IntegrationGrant{}
+	for _, grant := range backend.DefaultIntegrationGrants {
+		if slices.Contains(grants, grant) {
+			normalized = append(normalized, grant)
+		}
+	}
+	return normalized, nil
+}
+
+func joinGrants(grants []backend.IntegrationGrant) string {
+	strs := make([]string, len(grants))
+	for i, grant := range grants {
+		strs[i] = string(grant)
+	}
+	return strings.Join(strs, ",")
+}
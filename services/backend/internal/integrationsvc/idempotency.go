@@ -0,0 +1,124 @@
+package integrationsvc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+)
+
+const authorizationTTL = 10 * time.Minute
+
+// authorizationCache remembers completed authorizations for a short time so
+// that a refreshed or retried redirect returns the integration created by the
+// first callback instead of racing it. It only covers callbacks served by this
+// replica; authorizeOnce records completed authorizations in the integration
+// repository for the others.
+type authorizationCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*authorizationEntry
+}
+
+type authorizationEntry struct {
+	done        chan struct{}
+	integration backend.Integration
+	err         error
+	expiresAt   time.Time
+}
+
+func newAuthorizationCache(ttl time.Duration) *authorizationCache {
+	return &authorizationCache{
+		ttl:     ttl,
+		entries: make(map[string]*authorizationEntry),
+	}
+}
+
+// do runs authorize once per key. Concurrent callers with the same key wait for
+// the first one and share its result. Failed authorizations are not cached.
+func (c *authorizationCache) do(key string, authorize func() (backend.Integration, error)) (backend.Integration, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	for k, e := range c.entries {
+		if isClosed(e.done) && now.After(e.expiresAt) {
+			delete(c.entries, k)
+		}
+	}
+
+	if e, ok := c.entries[key]; ok {
+		c.mu.Unlock()
+		<-e.done
+		return e.integration, e.err
+	}
+
+	e := &authorizationEntry{done: make(chan struct{})}
+	c.entries[key] = e
+	c.mu.Unlock()
+
+	e.integration, e.err = authorize()
+	e.expiresAt = time.Now().Add(c.ttl)
+
+	if e.err != nil {
+		c.mu.Lock()
+		delete(c.entries, key)
+		c.mu.Unlock()
+	}
+	close(e.done)
+
+	return e.integration, e.err
+}
+
+// authorizeOnce returns the integration recorded for key within
+// authorizationTTL, or authorizes and records it. An authorization failing
+// because the integration already exists looks for the record again, since
+// another replica may have completed the same authorization meanwhile.
+func (s *service) authorizeOnce(ctx context.Context, key string, cmd backend.AuthorizeIntegrationCommand) (backend.Integration, error) {
+	integration, err := s.integrationRepository.FindByAuthorization(ctx, key, authorizationTTL)
+	if err == nil {
+		return integration, nil
+	}
+	if !errors.Is(err, backend.ErrIntegrationNotFound) {
+		slog.Error("Failed to look up integration authorization", "connector_type", cmd.ConnectorType, "error", err)
+	}
+
+	integration, err = s.authorizeIntegration(ctx, cmd)
+	if errors.Is(err, backend.ErrIntegrationAlreadyExists) {
+		if recorded, findErr := s.integrationRepository.FindByAuthorization(ctx, key, authorizationTTL); findErr == nil {
+			return recorded, nil
+		}
+	}
+	if err != nil {
+		return backend.Integration{}, err
+	}
+
+	if err := s.integrationRepository.RecordAuthorization(ctx, key, integration.ID); err != nil {
+		slog.Error("Failed to record integration authorization", "integration_id", integration.ID, "error", err)
+	}
+	return integration, nil
+}
+
+func isClosed(ch chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// authorizationKey identifies an authorization by what it authorizes. The
+// caller's IdempotencyKey narrows the key but never replaces the connector,
+// state and installation, so a reused key cannot return another
+// authorization's integration.
+func authorizationKey(cmd backend.AuthorizeIntegrationCommand) string {
+	parts := []string{string(cmd.ConnectorType), cmd.State, cmd.InstallationID, cmd.IdempotencyKey}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,149 @@
+package integrationsvc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domaintest"
+	"github.com/google/uuid"
+)
+
+func TestAuthorizationCache(t *testing.T) {
+	t.Run("concurrent callers share one authorization", func(t *testing.T) {
+		cache := newAuthorizationCache(time.Minute)
+		var calls atomic.Int32
+		want := backend.Integration{ID: uuid.New()}
+
+		var wg sync.WaitGroup
+		results := make([]backend.Integration, 5)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], _ = cache.do("key", func() (backend.Integration, error) {
+					calls.Add(1)
+					time.Sleep(10 * time.Millisecond)
+					return want, nil
+				})
+			}(i)
+		}
+		wg.Wait()
+
+		if calls.Load() != 1 {
+			t.Fatalf("authorize called %d times, want 1", calls.Load())
+		}
+		for _, got := range results {
+			if got.ID != want.ID {
+				t.Errorf("got integration %s, want %s", got.ID, want.ID)
+			}
+		}
+	})
+
+	t.Run("failures are retried", func(t *testing.T) {
+		cache := newAuthorizationCache(time.Minute)
+		_, err := cache.do("key", func() (backend.Integration, error) {
+			return backend.Integration{}, errors.New("github unavailable")
+		})
+		if err == nil {
+			t.Fatal("expected error from first attempt")
+		}
+
+		want := backend.Integration{ID: uuid.New()}
+		got, err := cache.do("key", func() (backend.Integration, error) {
+			return want, nil
+		})
+		if err != nil || got.ID != want.ID {
+			t.Fatalf("retry = (%s, %v), want (%s, nil)", got.ID, err, want.ID)
+		}
+	})
+
+	t.Run("derived key depends on state and installation only", func(t *testing.T) {
+		a := authorizationKey(backend.AuthorizeIntegrationCommand{ConnectorType: backend.ConnectorTypeGithub, State: "s", InstallationID: "1", Code: "x"})
+		b := authorizationKey(backend.AuthorizeIntegrationCommand{ConnectorType: backend.ConnectorTypeGithub, State: "s", InstallationID: "1", Code: "y"})
+		c := authorizationKey(backend.AuthorizeIntegrationCommand{ConnectorType: backend.ConnectorTypeGithub, State: "s", InstallationID: "2"})
+		if a != b {
+			t.Error("keys differ for the same state and installation")
+		}
+		if a == c {
+			t.Error("keys match for different installations")
+		}
+	})
+
+	t.Run("idempotency key does not replace state", func(t *testing.T) {
+		a := authorizationKey(backend.AuthorizeIntegrationCommand{ConnectorType: backend.ConnectorTypeGithub, State: "s1", InstallationID: "1", IdempotencyKey: "k"})
+		b := authorizationKey(backend.AuthorizeIntegrationCommand{ConnectorType: backend.ConnectorTypeGithub, State: "s2", InstallationID: "1", IdempotencyKey: "k"})
+		c := authorizationKey(backend.AuthorizeIntegrationCommand{ConnectorType: backend.ConnectorTypeGithub, State: "s1", InstallationID: "1", IdempotencyKey: "k"})
+		if a == b {
+			t.Error("keys match for the same idempotency key and different states")
+		}
+		if a != c {
+			t.Error("keys differ for the same idempotency key and state")
+		}
+	})
+}
+
+// stateConnector accepts any state as one for its organization and counts the
+// authorizations it completes.
+type stateConnector struct {
+	domain.Connector
+	organizationID uuid.UUID
+	completed      *atomic.Int32
+}
+
+func (c stateConnector) ParseState(state string) (uuid.UUID, uuid.UUID, error) {
+	return c.organizationID, uuid.New(), nil
+}
+
+func (c stateConnector) CompleteAuthorization(authData backend.AuthorizationData) (backend.Credentials, error) {
+	c.completed.Add(1)
+	return backend.Credentials{Type: backend.CredentialTypeToken, Data: map[string]string{"token": "t"}}, nil
+}
+
+func (c stateConnector) ConfigureWebhooks(integrationID string, creds backend.Credentials) error {
+	return nil
+}
+
+func TestAuthorizeIntegrationAcrossReplicas(t *testing.T) {
+	ctx := context.Background()
+	integrations := domaintest.NewIntegrationRepository()
+	credentials := domaintest.NewCredentialRepository(integrations)
+	connector := stateConnector{organizationID: uuid.New(), completed: &atomic.Int32{}}
+
+	// Each replica has its own authorization cache but shares the database.
+	newReplica := func() backend.IntegrationService {
+		return NewService(ServiceConfig{
+			IntegrationRepository: integrations,
+			CredentialRepository:  credentials,
+			Connectors:            map[backend.ConnectorType]domain.Connector{backend.ConnectorTypeGithub: connector},
+		})
+	}
+	first, second := newReplica(), newReplica()
+
+	cmd := backend.AuthorizeIntegrationCommand{ConnectorType: backend.ConnectorTypeGithub, State: "state", InstallationID: "42"}
+	created, err := first.AuthorizeIntegration(ctx, cmd)
+	if err != nil {
+		t.Fatalf("AuthorizeIntegration() error = %v", err)
+	}
+
+	retried, err := second.AuthorizeIntegration(ctx, cmd)
+	if err != nil {
+		t.Fatalf("AuthorizeIntegration() retried on another replica error = %v", err)
+	}
+	if retried.ID != created.ID {
+		t.Errorf("retried authorization returned integration %s, want %s", retried.ID, created.ID)
+	}
+	if got := connector.completed.Load(); got != 1 {
+		t.Errorf("completed %d authorizations, want 1", got)
+	}
+
+	cmd.InstallationID = "43"
+	if _, err := second.AuthorizeIntegration(ctx, cmd); !errors.Is(err, backend.ErrIntegrationAlreadyExists) {
+		t.Errorf("AuthorizeIntegration() of another installation error = %v, want %v", err, backend.ErrIntegrationAlreadyExists)
+	}
+}
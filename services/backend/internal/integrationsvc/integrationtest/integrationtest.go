@@ -0,0 +1,352 @@
+// Package integrationtest checks that an implementation of
+// backend.IntegrationService behaves like the production service, using fake
+// connectors in place of the real providers.
+package integrationtest
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+type fixture interface {
+	// Service returns a service with no integrations whose connectors are
+	// connectors.
+	Service(t *testing.T, connectors map[backend.ConnectorType]domain.Connector) backend.IntegrationService
+}
+
+func Ensure(t *testing.T, f fixture) {
+	setup := func(t *testing.T) (backend.IntegrationService, *connector, *connector) {
+		t.Helper()
+		github := newConnector(true)
+		slack := newConnector(false)
+		svc := f.Service(t, map[backend.ConnectorType]domain.Connector{
+			backend.ConnectorTypeGithub: github,
+			backend.ConnectorTypeSlack:  slack,
+		})
+		return svc, github, slack
+	}
+
+	authorize := func(t *testing.T, svc backend.IntegrationService, connectorType backend.ConnectorType, organizationID uuid.UUID, installationID string) backend.Integration {
+		t.Helper()
+		intent, err := svc.NewIntegration(context.Background(), backend.NewIntegrationCommand{
+			OrganizationID: organizationID,
+			UserID:         uuid.New(),
+			ConnectorType:  connectorType,
+		})
+		if err != nil {
+			t.Fatalf("NewIntegration() error = %v", err)
+		}
+		integration, err := svc.AuthorizeIntegration(context.Background(), backend.AuthorizeIntegrationCommand{
+			ConnectorType:  connectorType,
+			State:          stateOf(intent),
+			InstallationID: installationID,
+		})
+		if err != nil {
+			t.Fatalf("AuthorizeIntegration() error = %v", err)
+		}
+		return integration
+	}
+
+	t.Run("NewIntegration", func(t *testing.T) {
+		ctx := context.Background()
+		svc, _, _ := setup(t)
+		orgID := uuid.New()
+
+		intent, err := svc.NewIntegration(ctx, backend.NewIntegrationCommand{OrganizationID: orgID, UserID: uuid.New(), ConnectorType: backend.ConnectorTypeGithub})
+		if err != nil || intent.URL == "" {
+			t.Fatalf("NewIntegration() = %+v, %v, want an authorization URL", intent, err)
+		}
+
+		authorize(t, svc, backend.ConnectorTypeGithub, orgID, "1001")
+
+		_, err = svc.NewIntegration(ctx, backend.NewIntegrationCommand{OrganizationID: orgID, UserID: uuid.New(), ConnectorType: backend.ConnectorTypeGithub})
+		if !errors.Is(err, backend.ErrIntegrationAlreadyExists) {
+			t.Errorf("NewIntegration() of a duplicate error = %v, want ErrIntegrationAlreadyExists", err)
+		}
+		if _, err := svc.NewIntegration(ctx, backend.NewIntegrationCommand{OrganizationID: uuid.New(), UserID: uuid.New(), ConnectorType: backend.ConnectorTypeGithub}); err != nil {
+			t.Errorf("NewIntegration() in another organization error = %v", err)
+		}
+		_, err = svc.NewIntegration(ctx, backend.NewIntegrationCommand{OrganizationID: orgID, UserID: uuid.New(), ConnectorType: backend.ConnectorTypePagerDuty})
+		if !errors.Is(err, backend.ErrUnsupportedConnector) {
+			t.Errorf("NewIntegration() without a connector error = %v, want ErrUnsupportedConnector", err)
+		}
+	})
+
+	t.Run("AuthorizeIntegration", func(t *testing.T) {
+		ctx := context.Background()
+		svc, _, _ := setup(t)
+		orgID, userID := uuid.New(), uuid.New()
+
+		intent, err := svc.NewIntegration(ctx, backend.NewIntegrationCommand{OrganizationID: orgID, UserID: userID, ConnectorType: backend.ConnectorTypeGithub})
+		if err != nil {
+			t.Fatalf("NewIntegration() error = %v", err)
+		}
+		state := stateOf(intent)
+
+		tampered := strings.Replace(state, orgID.String(), uuid.New().String(), 1)
+		if _, err := svc.AuthorizeIntegration(ctx, backend.AuthorizeIntegrationCommand{ConnectorType: backend.ConnectorTypeGithub, State: tampered, InstallationID: "2001"}); err == nil {
+			t.Errorf("AuthorizeIntegration() with a tampered state succeeded, want an error")
+		}
+		if _, err := svc.AuthorizeIntegration(ctx, backend.AuthorizeIntegrationCommand{ConnectorType: backend.ConnectorTypeGithub, State: state}); err == nil {
+			t.Errorf("AuthorizeIntegration() without an installation ID succeeded, want an error")
+		}
+		if found, err := svc.Integrations(ctx, backend.IntegrationsQuery{OrganizationID: orgID}); err != nil || len(found) != 0 {
+			t.Fatalf("Integrations() after failed authorizations = %+v, %v, want none", found, err)
+		}
+
+		integration, err := svc.AuthorizeIntegration(ctx, backend.AuthorizeIntegrationCommand{ConnectorType: backend.ConnectorTypeGithub, State: state, InstallationID: "2001"})
+		if err != nil {
+			t.Fatalf("AuthorizeIntegration() error = %v", err)
+		}
+		if integration.OrganizationID != orgID || integration.UserID != userID || integration.BotID != "2001" ||
+			integration.Status != backend.IntegrationStatusActive || integration.ConnectorOrganizationID != "acme" {
+			t.Errorf("AuthorizeIntegration() = %+v, want an active integration of installation 2001 for the state's organization and user", integration)
+		}
+		if found, err := svc.Integration(ctx, backend.IntegrationQuery{IntegrationID: integration.ID, OrganizationID: orgID}); err != nil || found.BotID != "2001" {
+			t.Errorf("Integration() = %+v, %v, want the authorized integration", found, err)
+		}
+		if _, err := svc.AuthorizeIntegration(ctx, backend.AuthorizeIntegrationCommand{ConnectorType: backend.ConnectorTypeSlack, State: state}); err == nil {
+			t.Errorf("AuthorizeIntegration() with another connector's state succeeded, want an error")
+		}
+	})
+
+	t.Run("AuthorizeIntegration claims an installed GitHub App", func(t *testing.T) {
+		ctx := context.Background()
+		svc, github, _ := setup(t)
+		orgID := uuid.New()
+
+		installed := authorize(t, svc, backend.ConnectorTypeGithub, orgID, "3001")
+
+		// GitHub redirects again when the app's installation is changed, by
+		// which time the installation has already been claimed.
+		state, err := github.state(orgID, uuid.New())
+		if err != nil {
+			t.Fatalf("failed to sign state: %v", err)
+		}
+		claimed, err := svc.AuthorizeIntegration(ctx, backend.AuthorizeIntegrationCommand{ConnectorType: backend.ConnectorTypeGithub, State: state, InstallationID: "3001"})
+		if err != nil {
+			t.Fatalf("AuthorizeIntegration() of a claimed installation error = %v", err)
+		}
+		if claimed.ID != installed.ID {
+			t.Errorf("AuthorizeIntegration() of a claimed installation = %v, want the existing integration %v", claimed.ID, installed.ID)
+		}
+		if found, err := svc.Integrations(ctx, backend.IntegrationsQuery{OrganizationID: orgID}); err != nil || len(found) != 1 {
+			t.Errorf("Integrations() = %+v, %v, want only the claimed integration", found, err)
+		}
+
+		state, err = github.state(uuid.New(), uuid.New())
+		if err != nil {
+			t.Fatalf("failed to sign state: %v", err)
+		}
+		_, err = svc.AuthorizeIntegration(ctx, backend.AuthorizeIntegrationCommand{ConnectorType: backend.ConnectorTypeGithub, State: state, InstallationID: "3001"})
+		if !errors.Is(err, backend.ErrIntegrationNotFound) {
+			t.Errorf("AuthorizeIntegration() of an installation claimed by another organization error = %v, want ErrIntegrationNotFound", err)
+		}
+	})
+
+	t.Run("RevokeIntegration", func(t *testing.T) {
+		ctx := context.Background()
+		svc, github, _ := setup(t)
+		orgID := uuid.New()
+		integration := authorize(t, svc, backend.ConnectorTypeGithub, orgID, "4001")
+
+		err := svc.RevokeIntegration(ctx, backend.RevokeIntegrationCommand{IntegrationID: integration.ID, OrganizationID: uuid.New()})
+		if !errors.Is(err, backend.ErrIntegrationNotFound) {
+			t.Errorf("RevokeIntegration() from another organization error = %v, want ErrIntegrationNotFound", err)
+		}
+		if github.revokedCount() != 0 {
+			t.Errorf("RevokeIntegration() from another organization revoked credentials")
+		}
+		if _, err := svc.Integration(ctx, backend.IntegrationQuery{IntegrationID: integration.ID, OrganizationID: orgID}); err != nil {
+			t.Fatalf("Integration() after a rejected revoke error = %v", err)
+		}
+
+		if err := svc.RevokeIntegration(ctx, backend.RevokeIntegrationCommand{IntegrationID: integration.ID, OrganizationID: orgID}); err != nil {
+			t.Fatalf("RevokeIntegration() error = %v", err)
+		}
+		if github.revokedCount() != 1 {
+			t.Errorf("RevokeIntegration() revoked %d credentials with the connector, want 1", github.revokedCount())
+		}
+		_, err = svc.Integration(ctx, backend.IntegrationQuery{IntegrationID: integration.ID, OrganizationID: orgID})
+		if !errors.Is(err, backend.ErrIntegrationNotFound) {
+			t.Errorf("Integration() after revoking error = %v, want ErrIntegrationNotFound", err)
+		}
+	})
+
+	t.Run("Integrations filters by type and status", func(t *testing.T) {
+		ctx := context.Background()
+		svc, _, _ := setup(t)
+		orgID := uuid.New()
+		github := authorize(t, svc, backend.ConnectorTypeGithub, orgID, "5001")
+		slack := authorize(t, svc, backend.ConnectorTypeSlack, orgID, "")
+		authorize(t, svc, backend.ConnectorTypeSlack, uuid.New(), "")
+
+		tests := []struct {
+			name  string
+			query backend.IntegrationsQuery
+			want  []uuid.UUID
+		}{
+			{"all", backend.IntegrationsQuery{OrganizationID: orgID}, []uuid.UUID{github.ID, slack.ID}},
+			{"type", backend.IntegrationsQuery{OrganizationID: orgID, ConnectorType: backend.ConnectorTypeSlack}, []uuid.UUID{slack.ID}},
+			{"status", backend.IntegrationsQuery{OrganizationID: orgID, Status: backend.IntegrationStatusActive}, []uuid.UUID{github.ID, slack.ID}},
+			{"other status", backend.IntegrationsQuery{OrganizationID: orgID, Status: backend.IntegrationStatusSuspended}, nil},
+			{"type and status", backend.IntegrationsQuery{OrganizationID: orgID, ConnectorType: backend.ConnectorTypeGithub, Status: backend.IntegrationStatusActive}, []uuid.UUID{github.ID}},
+			{"type without integrations", backend.IntegrationsQuery{OrganizationID: orgID, ConnectorType: backend.ConnectorTypePagerDuty}, nil},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				found, err := svc.Integrations(ctx, tt.query)
+				if err != nil {
+					t.Fatalf("Integrations() error = %v", err)
+				}
+				got := make(map[uuid.UUID]bool)
+				for _, integration := range found {
+					got[integration.ID] = true
+				}
+				if len(found) != len(tt.want) {
+					t.Fatalf("Integrations() returned %d integrations, want %d", len(found), len(tt.want))
+				}
+				for _, id := range tt.want {
+					if !got[id] {
+						t.Errorf("Integrations() is missing %v", id)
+					}
+				}
+			})
+		}
+	})
+}
+
+func stateOf(intent backend.IntegrationAuthorizationIntent) string {
+	_, state, _ := strings.Cut(intent.URL, "state=")
+	return state
+}
+
+// connector signs its state like the real connectors do. With
+// requireInstallation it behaves like the GitHub App connector: authorizing
+// needs an installation ID, and a second authorization of an installation
+// reports it as already claimed.
+type connector struct {
+	domain.Connector
+	key                 []byte
+	requireInstallation bool
+
+	mu        sync.Mutex
+	installed map[string]bool
+	revoked   int
+}
+
+func newConnector(requireInstallation bool) *connector {
+	return &connector{
+		key:                 []byte(uuid.NewString()),
+		requireInstallation: requireInstallation,
+		installed:           make(map[string]bool),
+	}
+}
+
+func (c *connector) state(organizationID, userID uuid.UUID) (string, error) {
+	payload := organizationID.String() + ":" + userID.String() + ":" + uuid.NewString()
+	mac := hmac.New(sha256.New, c.key)
+	if _, err := mac.Write([]byte(payload)); err != nil {
+		return "", err
+	}
+	return payload + ":" + hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+func (c *connector) InitiateAuthorization(organizationID string, userID string) (backend.IntegrationAuthorizationIntent, error) {
+	orgID, err := uuid.Parse(organizationID)
+	if err != nil {
+		return backend.IntegrationAuthorizationIntent{}, err
+	}
+	uID, err := uuid.Parse(userID)
+	if err != nil {
+		return backend.IntegrationAuthorizationIntent{}, err
+	}
+	state, err := c.state(orgID, uID)
+	if err != nil {
+		return backend.IntegrationAuthorizationIntent{}, err
+	}
+	return backend.IntegrationAuthorizationIntent{Type: backend.AuthorizationTypeOAuth2, URL: "https://example.com/authorize?state=" + state}, nil
+}
+
+func (c *connector) ParseState(state string) (uuid.UUID, uuid.UUID, error) {
+	i := strings.LastIndex(state, ":")
+	if i < 0 {
+		return uuid.Nil, uuid.Nil, errors.New("invalid state format")
+	}
+	payload, signature := state[:i], state[i+1:]
+
+	mac := hmac.New(sha256.New, c.key)
+	if _, err := mac.Write([]byte(payload)); err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	if !hmac.Equal([]byte(signature), []byte(hex.EncodeToString(mac.Sum(nil)))) {
+		return uuid.Nil, uuid.Nil, errors.New("invalid state signature")
+	}
+
+	parts := strings.Split(payload, ":")
+	organizationID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid organization ID: %w", err)
+	}
+	userID, err := uuid.Parse(parts[1])
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+	return organizationID, userID, nil
+}
+
+func (c *connector) CompleteAuthorization(authData backend.AuthorizationData) (backend.Credentials, error) {
+	if _, _, err := c.ParseState(authData.State); err != nil {
+		return backend.Credentials{}, err
+	}
+	if !c.requireInstallation {
+		return backend.Credentials{
+			Type:             backend.CredentialTypeOAuth2,
+			Data:             map[string]string{"access_token": uuid.NewString()},
+			OrganizationInfo: &backend.OrganizationInfo{ExternalID: "acme", Name: "Acme"},
+		}, nil
+	}
+	if authData.InstallationID == "" {
+		return backend.Credentials{}, errors.New("installation ID is required")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.installed[authData.InstallationID] {
+		return backend.Credentials{Data: map[string]string{"claimed": "true"}}, nil
+	}
+	c.installed[authData.InstallationID] = true
+	return backend.Credentials{
+		Type:             backend.CredentialTypeToken,
+		Data:             map[string]string{"installation_id": authData.InstallationID},
+		OrganizationInfo: &backend.OrganizationInfo{ExternalID: "acme", Name: "Acme"},
+	}, nil
+}
+
+func (c *connector) ConfigureWebhooks(integrationID string, creds backend.Credentials) error {
+	return nil
+}
+
+func (c *connector) RevokeCredentials(creds backend.Credentials) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.revoked++
+	return nil
+}
+
+func (c *connector) revokedCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.revoked
+}
@@ -0,0 +1,34 @@
+package integrationsvc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/google/uuid"
+)
+
+// onboardingSteps maps connectors to the onboarding step an active integration
+// of theirs completes.
+var onboardingSteps = map[backend.ConnectorType]backend.OnboardingStep{
+	backend.ConnectorTypeGithub: backend.OnboardingStepConnectGitHub,
+	backend.ConnectorTypeGCP:    backend.OnboardingStepConnectCloud,
+	backend.ConnectorTypeAWS:    backend.OnboardingStepConnectCloud,
+}
+
+var _ backend.OnboardingReporter = (*service)(nil)
+
+func (s *service) CompletedOnboardingSteps(ctx context.Context, organizationID uuid.UUID) ([]backend.OnboardingStep, error) {
+	integrations, err := s.readIntegrationRepository.FindByOrganizationAndStatus(ctx, organizationID, backend.IntegrationStatusActive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find integrations: %w", err)
+	}
+
+	var steps []backend.OnboardingStep
+	for _, integration := range integrations {
+		if step, ok := onboardingSteps[integration.ConnectorType]; ok {
+			steps = append(steps, step)
+		}
+	}
+	return steps, nil
+}
@@ -0,0 +1,76 @@
+package integrationsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+)
+
+// DeleteOrganizationData revokes each integration with its provider before
+// deleting it. An integration whose revocation fails is kept, with its
+// credentials, so that running the deletion again retries it.
+func (s *service) DeleteOrganizationData(ctx context.Context, cmd backend.DeleteOrganizationCommand) ([]backend.DeletedResource, error) {
+	integrations, err := s.integrationRepository.FindByOrganization(ctx, cmd.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find integrations: %w", err)
+	}
+
+	var deleted int
+	var data domain.IntegrationData
+	var errs []error
+	for _, integration := range integrations {
+		if !cmd.DryRun {
+			if err := s.revokeConnectorCredentials(ctx, integration); err != nil {
+				errs = append(errs, fmt.Errorf("failed to revoke %s integration %s: %w", integration.ConnectorType, integration.ID, err))
+				continue
+			}
+		}
+
+		d, err := s.integrationDataRepository.DeleteIntegrationData(ctx, integration.ID, cmd.DryRun)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete %s integration %s: %w", integration.ConnectorType, integration.ID, err))
+			continue
+		}
+		deleted++
+		data.Credentials += d.Credentials
+		data.Repositories += d.Repositories
+		data.Activities += d.Activities
+	}
+
+	triggers, err := s.triggerRepository.DeleteByOrganization(ctx, cmd.OrganizationID, cmd.DryRun)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to delete repository triggers: %w", err))
+	}
+
+	return []backend.DeletedResource{
+		{Type: "integrations", Count: deleted},
+		{Type: "integration_credentials", Count: data.Credentials},
+		{Type: "repositories", Count: data.Repositories},
+		{Type: "integration_activity", Count: data.Activities},
+		{Type: "repository_triggers", Count: triggers},
+	}, errors.Join(errs...)
+}
+
+func (s *service) revokeConnectorCredentials(ctx context.Context, integration backend.Integration) error {
+	connector, exists := s.connectors[integration.ConnectorType]
+	if !exists {
+		return nil
+	}
+
+	credential, err := s.credentialRepository.FindByIntegration(withCredentialAccessReason(ctx, "organization_deletion"), integration.ID)
+	if errors.Is(err, domain.ErrCredentialNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find credentials: %w", err)
+	}
+
+	return connector.RevokeCredentials(backend.Credentials{
+		Type:      credential.CredentialType,
+		Data:      credential.Data,
+		ExpiresAt: credential.ExpiresAt,
+	})
+}
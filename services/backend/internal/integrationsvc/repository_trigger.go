@@ -0,0 +1,365 @@
+package integrationsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+// triggerConnectors are the connectors whose webhooks deliver repository events.
+var triggerConnectors = []backend.ConnectorType{backend.ConnectorTypeGithub}
+
+func (s *service) RepositoryTriggers(ctx context.Context, query backend.RepositoryTriggersQuery) ([]backend.RepositoryTrigger, error) {
+	triggers, err := s.triggerRepository.FindByOrganization(ctx, query.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository triggers: %w", err)
+	}
+	return triggers, nil
+}
+
+func (s *service) CreateRepositoryTrigger(ctx context.Context, cmd backend.CreateRepositoryTriggerCommand) (backend.RepositoryTrigger, error) {
+	now := time.Now()
+	trigger := backend.RepositoryTrigger{
+		ID:                uuid.New(),
+		OrganizationID:    cmd.OrganizationID,
+		RepositoryPattern: strings.TrimSpace(cmd.RepositoryPattern),
+		EventType:         cmd.EventType,
+		PathGlob:          strings.TrimPrefix(strings.TrimSpace(cmd.PathGlob), "/"),
+		Channel:           strings.TrimSpace(cmd.Channel),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := s.validateRepositoryTrigger(ctx, trigger); err != nil {
+		return backend.RepositoryTrigger{}, err
+	}
+
+	if err := s.triggerRepository.Store(ctx, trigger); err != nil {
+		return backend.RepositoryTrigger{}, fmt.Errorf("failed to store repository trigger: %w", err)
+	}
+	s.triggers.invalidate()
+	return trigger, nil
+}
+
+func (s *service) UpdateRepositoryTrigger(ctx context.Context, cmd backend.UpdateRepositoryTriggerCommand) (backend.RepositoryTrigger, error) {
+	triggers, err := s.RepositoryTriggers(ctx, backend.RepositoryTriggersQuery{OrganizationID: cmd.OrganizationID})
+	if err != nil {
+		return backend.RepositoryTrigger{}, err
+	}
+	i := slices.IndexFunc(triggers, func(trigger backend.RepositoryTrigger) bool { return trigger.ID == cmd.ID })
+	if i < 0 {
+		return backend.RepositoryTrigger{}, backend.ErrRepositoryTriggerNotFound
+	}
+
+	trigger := triggers[i]
+	trigger.RepositoryPattern = strings.TrimSpace(cmd.RepositoryPattern)
+	trigger.EventType = cmd.EventType
+	trigger.PathGlob = strings.TrimPrefix(strings.TrimSpace(cmd.PathGlob), "/")
+	trigger.Channel = strings.TrimSpace(cmd.Channel)
+	trigger.UpdatedAt = time.Now()
+	if err := s.validateRepositoryTrigger(ctx, trigger); err != nil {
+		return backend.RepositoryTrigger{}, err
+	}
+
+	if err := s.triggerRepository.Update(ctx, trigger); err != nil {
+		return backend.RepositoryTrigger{}, fmt.Errorf("failed to update repository trigger: %w", err)
+	}
+	s.triggers.invalidate()
+	return trigger, nil
+}
+
+func (s *service) DeleteRepositoryTrigger(ctx context.Context, cmd backend.DeleteRepositoryTriggerCommand) error {
+	if err := s.triggerRepository.Delete(ctx, cmd.OrganizationID, cmd.ID); err != nil {
+		return fmt.Errorf("failed to delete repository trigger: %w", err)
+	}
+	s.triggers.invalidate()
+	return nil
+}
+
+// validateRepositoryTrigger requires the repository pattern to match at least
+// one repository synced for agent access, so that a typo does not leave a
+// trigger that never fires.
+func (s *service) validateRepositoryTrigger(ctx context.Context, trigger backend.RepositoryTrigger) error {
+	if trigger.EventType != backend.RepositoryEventPush && trigger.EventType != backend.RepositoryEventPullRequest {
+		return fmt.Errorf("%w: event type must be %s or %s", backend.ErrInvalidRepositoryTrigger, backend.RepositoryEventPush, backend.RepositoryEventPullRequest)
+	}
+	if trigger.Channel == "" {
+		return fmt.Errorf("%w: a Slack channel is required", backend.ErrInvalidRepositoryTrigger)
+	}
+	if trigger.RepositoryPattern == "" {
+		return fmt.Errorf("%w: a repository pattern is required", backend.ErrInvalidRepositoryTrigger)
+	}
+	if _, err := path.Match(trigger.RepositoryPattern, ""); err != nil {
+		return fmt.Errorf("%w: %q is not a valid repository pattern", backend.ErrInvalidRepositoryTrigger, trigger.RepositoryPattern)
+	}
+	if !validPathGlob(trigger.PathGlob) {
+		return fmt.Errorf("%w: %q is not a valid path glob", backend.ErrInvalidRepositoryTrigger, trigger.PathGlob)
+	}
+
+	repositories, err := s.triggerRepositories(ctx, trigger.OrganizationID)
+	if err != nil {
+		return err
+	}
+	if !slices.ContainsFunc(repositories, func(repo backend.SyncedRepository) bool {
+		return repo.Enabled && matchesRepository(trigger.RepositoryPattern, repo.FullName)
+	}) {
+		return fmt.Errorf("%w: %q matches none of the organization's synced repositories", backend.ErrInvalidRepositoryTrigger, trigger.RepositoryPattern)
+	}
+	return nil
+}
+
+// triggerRepositories lists the repositories of the organization's active
+// integrations that deliver repository events.
+func (s *service) triggerRepositories(ctx context.Context, organizationID uuid.UUID) ([]backend.SyncedRepository, error) {
+	var repositories []backend.SyncedRepository
+	for _, connectorType := range triggerConnectors {
+		lister, ok := s.connectors[connectorType].(domain.RepositoryLister)
+		if !ok {
+			continue
+		}
+		integrations, err := s.integrationRepository.FindByOrganizationTypeAndStatus(ctx, organizationID, connectorType, backend.IntegrationStatusActive)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find integrations: %w", err)
+		}
+		for _, integration := range integrations {
+			repos, err := lister.Repositories(ctx, integration)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repositories: %w", err)
+			}
+			repositories = append(repositories, repos...)
+		}
+	}
+	return repositories, nil
+}
+
+// dispatchRepositoryEvent tells the listener about event once for each channel
+// with a matching trigger in the organization that owns the installation
+// identified by botID.
+func (s *service) dispatchRepositoryEvent(ctx context.Context, connectorType backend.ConnectorType, botID string, event backend.RepositoryEvent) error {
+	if s.repositoryEventListener == nil {
+		return nil
+	}
+
+	// Pull request payloads do not list the changed files, so path globs are
+	// checked once the event is known to concern a triggering organization.
+	filesListed := event.Type != backend.RepositoryEventPullRequest
+	candidates, err := s.triggers.candidates(ctx, event, filesListed)
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	integration, err := s.integrationRepository.FindByBotIDAndType(ctx, botID, connectorType)
+	if errors.Is(err, backend.ErrIntegrationNotFound) {
+		slog.Info("ignoring repository event for unknown installation", "connector_type", connectorType, "bot_id", botID)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find integration: %w", err)
+	}
+	if err := integration.CheckCredentialsUsable(); err != nil {
+		slog.Info("ignoring repository event for unusable integration", "integration_id", integration.ID, "status", integration.Status)
+		return nil
+	}
+
+	candidates = slices.DeleteFunc(candidates, func(trigger backend.RepositoryTrigger) bool {
+		return trigger.OrganizationID != integration.OrganizationID
+	})
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	enabled, err := s.repositoryEnabled(ctx, integration, event.Repository)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		slog.Info("ignoring repository event for repository without agent access", "integration_id", integration.ID, "repository", event.Repository)
+		return nil
+	}
+
+	if !filesListed && slices.ContainsFunc(candidates, func(trigger backend.RepositoryTrigger) bool { return trigger.PathGlob != "" }) {
+		lister, ok := s.connectors[connectorType].(domain.PullRequestFileLister)
+		if !ok {
+			return fmt.Errorf("%w: %s cannot list pull request files", backend.ErrUnsupportedConnector, connectorType)
+		}
+		if event.ChangedFiles, err = lister.PullRequestFiles(ctx, integration, event.Repository, event.PullRequestNumber); err != nil {
+			return fmt.Errorf("failed to list pull request files: %w", err)
+		}
+		filesListed = true
+	}
+
+	var channels []string
+	for _, trigger := range candidates {
+		if slices.Contains(channels, trigger.Channel) || !triggerMatches(trigger, event, filesListed) {
+			continue
+		}
+		channels = append(channels, trigger.Channel)
+		s.repositoryEventListener.RepositoryEventTriggered(ctx, backend.RepositoryTriggerMatch{
+			Trigger:     trigger,
+			Integration: integration,
+			Event:       event,
+		})
+	}
+	if len(channels) == 0 {
+		return nil
+	}
+
+	s.recordActivity(ctx, integration, backend.IntegrationActivityWebhookProcessed, map[string]string{
+		"event_type": string(event.Type),
+		"repository": event.Repository,
+		"channels":   strconv.Itoa(len(channels)),
+	})
+	return nil
+}
+
+func (s *service) repositoryEnabled(ctx context.Context, integration backend.Integration, fullName string) (bool, error) {
+	lister, ok := s.connectors[integration.ConnectorType].(domain.RepositoryLister)
+	if !ok {
+		return false, nil
+	}
+	repositories, err := lister.Repositories(ctx, integration)
+	if err != nil {
+		return false, fmt.Errorf("failed to list repositories: %w", err)
+	}
+	return slices.ContainsFunc(repositories, func(repo backend.SyncedRepository) bool {
+		return repo.Enabled && strings.EqualFold(repo.FullName, fullName)
+	}), nil
+}
+
+// triggerRefreshInterval bounds how long a trigger changed on another replica
+// takes to apply here.
+const triggerRefreshInterval = time.Minute
+
+// triggerIndex caches the triggers of every organization so that events no
+// trigger wants are dropped without looking up their integration.
+type triggerIndex struct {
+	repository domain.RepositoryTriggerRepository
+
+	mu       sync.Mutex
+	triggers []backend.RepositoryTrigger
+	loadedAt time.Time
+}
+
+// candidates returns the triggers that match event, leaving out path globs
+// unless filesListed.
+func (i *triggerIndex) candidates(ctx context.Context, event backend.RepositoryEvent, filesListed bool) ([]backend.RepositoryTrigger, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if time.Since(i.loadedAt) > triggerRefreshInterval {
+		triggers, err := i.repository.FindAll(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load repository triggers: %w", err)
+		}
+		i.triggers, i.loadedAt = triggers, time.Now()
+	}
+
+	var matched []backend.RepositoryTrigger
+	for _, trigger := range i.triggers {
+		if triggerMatches(trigger, event, filesListed) {
+			matched = append(matched, trigger)
+		}
+	}
+	return matched, nil
+}
+
+func (i *triggerIndex) invalidate() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.loadedAt = time.Time{}
+}
+
+func triggerMatches(trigger backend.RepositoryTrigger, event backend.RepositoryEvent, filesListed bool) bool {
+	if trigger.EventType != event.Type || !matchesRepository(trigger.RepositoryPattern, event.Repository) {
+		return false
+	}
+	if trigger.PathGlob == "" || !filesListed {
+		return true
+	}
+	return slices.ContainsFunc(event.ChangedFiles, func(file string) bool {
+		return matchPathGlob(trigger.PathGlob, file)
+	})
+}
+
+func matchesRepository(pattern, fullName string) bool {
+	matched, _ := path.Match(strings.ToLower(pattern), strings.ToLower(fullName))
+	return matched
+}
+
+// matchPathGlob matches file against glob one directory at a time, where a
+// "**" segment stands for any number of directories. A glob without a slash
+// matches the file name in any directory.
+func matchPathGlob(glob, file string) bool {
+	if !strings.Contains(glob, "/") {
+		matched, _ := path.Match(glob, path.Base(file))
+		return matched
+	}
+	return matchPathSegments(strings.Split(glob, "/"), strings.Split(file, "/"))
+}
+
+func matchPathSegments(glob, file []string) bool {
+	for len(glob) > 0 {
+		if glob[0] == "**" {
+			for i := range len(file) + 1 {
+				if matchPathSegments(glob[1:], file[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(file) == 0 {
+			return false
+		}
+		if matched, _ := path.Match(glob[0], file[0]); !matched {
+			return false
+		}
+		glob, file = glob[1:], file[1:]
+	}
+	return len(file) == 0
+}
+
+func validPathGlob(glob string) bool {
+	for _, segment := range strings.Split(glob, "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// RepositoryEventNotifier lets the conversation service, which is built after
+// the integration service, receive repository trigger matches.
+type RepositoryEventNotifier struct {
+	mu        sync.RWMutex
+	listeners []backend.RepositoryEventListener
+}
+
+func (n *RepositoryEventNotifier) Listen(listener backend.RepositoryEventListener) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.listeners = append(n.listeners, listener)
+}
+
+func (n *RepositoryEventNotifier) RepositoryEventTriggered(ctx context.Context, match backend.RepositoryTriggerMatch) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	for _, listener := range n.listeners {
+		listener.RepositoryEventTriggered(ctx, match)
+	}
+}
@@ -0,0 +1,170 @@
+package integrationsvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domaintest"
+	"github.com/google/uuid"
+)
+
+type pullRequestConnector struct {
+	repositoryConnector
+	files []string
+}
+
+func (c pullRequestConnector) PullRequestFiles(ctx context.Context, integration backend.Integration, repository string, number int) ([]string, error) {
+	return c.files, nil
+}
+
+type triggerRecorder struct {
+	matches []backend.RepositoryTriggerMatch
+}
+
+func (r *triggerRecorder) RepositoryEventTriggered(ctx context.Context, match backend.RepositoryTriggerMatch) {
+	r.matches = append(r.matches, match)
+}
+
+func TestMatchPathGlob(t *testing.T) {
+	tests := []struct {
+		glob string
+		file string
+		want bool
+	}{
+		{"terraform/**", "terraform/main.tf", true},
+		{"terraform/**", "terraform/modules/vpc/main.tf", true},
+		{"terraform/**", "docs/terraform/main.tf", false},
+		{"**/*.tf", "main.tf", true},
+		{"**/*.tf", "envs/prod/main.tf", true},
+		{"*.tf", "envs/prod/main.tf", true},
+		{"envs/*/main.tf", "envs/prod/main.tf", true},
+		{"envs/*/main.tf", "envs/prod/eu/main.tf", false},
+		{"charts/**/values.yaml", "charts/api/values.yaml", true},
+		{"charts/**/values.yaml", "charts/api/templates/deployment.yaml", false},
+	}
+	for _, tt := range tests {
+		if got := matchPathGlob(tt.glob, tt.file); got != tt.want {
+			t.Errorf("matchPathGlob(%q, %q) = %v, want %v", tt.glob, tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestRepositoryTriggers(t *testing.T) {
+	ctx := context.Background()
+
+	integrations := domaintest.NewIntegrationRepository()
+	listener := &triggerRecorder{}
+	connector := pullRequestConnector{
+		repositoryConnector: repositoryConnector{repositories: []backend.SyncedRepository{
+			{ID: 1, FullName: "acme/infra", Enabled: true},
+			{ID: 2, FullName: "acme/web", Enabled: true},
+			{ID: 3, FullName: "acme/legacy"},
+		}},
+		files: []string{"README.md", "terraform/prod/main.tf"},
+	}
+	svc := NewService(ServiceConfig{
+		IntegrationRepository:       integrations,
+		CredentialRepository:        domaintest.NewCredentialRepository(integrations),
+		Connectors:                  map[backend.ConnectorType]domain.Connector{backend.ConnectorTypeGithub: connector},
+		RepositoryTriggerRepository: domaintest.NewRepositoryTriggerRepository(),
+		RepositoryEventListener:     listener,
+	}).(*service)
+
+	orgID := uuid.New()
+	for _, integration := range []backend.Integration{
+		{ID: uuid.New(), OrganizationID: orgID, ConnectorType: backend.ConnectorTypeGithub, Status: backend.IntegrationStatusActive, BotID: "100"},
+		{ID: uuid.New(), OrganizationID: uuid.New(), ConnectorType: backend.ConnectorTypeGithub, Status: backend.IntegrationStatusActive, BotID: "200"},
+	} {
+		if err := integrations.Store(ctx, integration); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+	}
+
+	for _, cmd := range []backend.CreateRepositoryTriggerCommand{
+		{OrganizationID: orgID, RepositoryPattern: "acme/legacy", EventType: backend.RepositoryEventPush, Channel: "C1"},
+		{OrganizationID: orgID, RepositoryPattern: "acme/*", EventType: "release", Channel: "C1"},
+		{OrganizationID: orgID, RepositoryPattern: "acme/*", EventType: backend.RepositoryEventPush},
+		{OrganizationID: orgID, RepositoryPattern: "acme/[", EventType: backend.RepositoryEventPush, Channel: "C1"},
+	} {
+		if _, err := svc.CreateRepositoryTrigger(ctx, cmd); !errors.Is(err, backend.ErrInvalidRepositoryTrigger) {
+			t.Errorf("CreateRepositoryTrigger(%+v) error = %v, want ErrInvalidRepositoryTrigger", cmd, err)
+		}
+	}
+
+	push, err := svc.CreateRepositoryTrigger(ctx, backend.CreateRepositoryTriggerCommand{
+		OrganizationID: orgID, RepositoryPattern: "acme/*", EventType: backend.RepositoryEventPush, PathGlob: "terraform/**", Channel: "C1",
+	})
+	if err != nil {
+		t.Fatalf("CreateRepositoryTrigger(push) error = %v", err)
+	}
+	if _, err := svc.CreateRepositoryTrigger(ctx, backend.CreateRepositoryTriggerCommand{
+		OrganizationID: orgID, RepositoryPattern: "ACME/infra", EventType: backend.RepositoryEventPullRequest, PathGlob: "*.tf", Channel: "C2",
+	}); err != nil {
+		t.Fatalf("CreateRepositoryTrigger(pull request) error = %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		botID        string
+		event        backend.RepositoryEvent
+		wantChannels []string
+	}{
+		{
+			name:         "push touching the glob",
+			botID:        "100",
+			event:        backend.RepositoryEvent{Type: backend.RepositoryEventPush, Repository: "acme/web", ChangedFiles: []string{"terraform/main.tf"}},
+			wantChannels: []string{"C1"},
+		},
+		{
+			name:  "push outside the glob",
+			botID: "100",
+			event: backend.RepositoryEvent{Type: backend.RepositoryEventPush, Repository: "acme/web", ChangedFiles: []string{"src/app.ts"}},
+		},
+		{
+			name:  "push to a repository without agent access",
+			botID: "100",
+			event: backend.RepositoryEvent{Type: backend.RepositoryEventPush, Repository: "acme/legacy", ChangedFiles: []string{"terraform/main.tf"}},
+		},
+		{
+			name:  "push from another organization's installation",
+			botID: "200",
+			event: backend.RepositoryEvent{Type: backend.RepositoryEventPush, Repository: "acme/web", ChangedFiles: []string{"terraform/main.tf"}},
+		},
+		{
+			name:         "pull request with listed files",
+			botID:        "100",
+			event:        backend.RepositoryEvent{Type: backend.RepositoryEventPullRequest, Repository: "acme/infra", PullRequestNumber: 7},
+			wantChannels: []string{"C2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listener.matches = nil
+			if err := svc.dispatchRepositoryEvent(ctx, backend.ConnectorTypeGithub, tt.botID, tt.event); err != nil {
+				t.Fatalf("dispatchRepositoryEvent() error = %v", err)
+			}
+			var channels []string
+			for _, match := range listener.matches {
+				channels = append(channels, match.Trigger.Channel)
+			}
+			if len(channels) != len(tt.wantChannels) || (len(channels) > 0 && channels[0] != tt.wantChannels[0]) {
+				t.Errorf("triggered channels = %v, want %v", channels, tt.wantChannels)
+			}
+		})
+	}
+
+	if err := svc.DeleteRepositoryTrigger(ctx, backend.DeleteRepositoryTriggerCommand{ID: push.ID, OrganizationID: orgID}); err != nil {
+		t.Fatalf("DeleteRepositoryTrigger() error = %v", err)
+	}
+	listener.matches = nil
+	event := backend.RepositoryEvent{Type: backend.RepositoryEventPush, Repository: "acme/web", ChangedFiles: []string{"terraform/main.tf"}}
+	if err := svc.dispatchRepositoryEvent(ctx, backend.ConnectorTypeGithub, "100", event); err != nil {
+		t.Fatalf("dispatchRepositoryEvent() error = %v", err)
+	}
+	if len(listener.matches) != 0 {
+		t.Errorf("deleted trigger still fired: %+v", listener.matches)
+	}
+}
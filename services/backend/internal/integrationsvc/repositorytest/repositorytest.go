@@ -0,0 +1,1104 @@
+// Package repositorytest holds contract tests that every implementation of the
+// integration service repositories must pass.
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/github"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+type fixture interface {
+	IntegrationRepository() domain.IntegrationRepository
+	CredentialRepository() domain.CredentialRepository
+	GitHubRepositoryRepository() github.GitHubRepositoryRepository
+	ActivityRepository() domain.ActivityRepository
+	CredentialAccessRepository() domain.CredentialAccessRepository
+	RepositoryTriggerRepository() domain.RepositoryTriggerRepository
+	SyncJobRepository() domain.SyncJobRepository
+	// Reset removes all stored data so each test starts from an empty store.
+	Reset(t *testing.T)
+}
+
+func Ensure(t *testing.T, f fixture) {
+	t.Run("IntegrationRepository", func(t *testing.T) {
+		ensureIntegrationRepository(t, f)
+	})
+	t.Run("CredentialRepository", func(t *testing.T) {
+		ensureCredentialRepository(t, f)
+	})
+	t.Run("GitHubRepositoryRepository", func(t *testing.T) {
+		ensureGitHubRepositoryRepository(t, f)
+	})
+	t.Run("ActivityRepository", func(t *testing.T) {
+		ensureActivityRepository(t, f)
+	})
+	t.Run("CredentialAccessRepository", func(t *testing.T) {
+		ensureCredentialAccessRepository(t, f)
+	})
+	t.Run("RepositoryTriggerRepository", func(t *testing.T) {
+		ensureRepositoryTriggerRepository(t, f)
+	})
+	t.Run("SyncJobRepository", func(t *testing.T) {
+		ensureSyncJobRepository(t, f)
+	})
+}
+
+func ensureIntegrationRepository(t *testing.T, f fixture) {
+	t.Run("stores and finds by id", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		repo := f.IntegrationRepository()
+
+		integration := newIntegration(uuid.New(), backend.ConnectorTypeGithub)
+		integration.Metadata = map[string]string{"account_login": "acme"}
+		mustStore(t, repo, integration)
+
+		got, err := repo.FindByID(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if got.OrganizationID != integration.OrganizationID || got.ConnectorType != integration.ConnectorType ||
+			got.Status != integration.Status || got.BotID != integration.BotID {
+			t.Errorf("FindByID() = %+v, want %+v", got, integration)
+		}
+		if got.Metadata["account_login"] != "acme" {
+			t.Errorf("Metadata = %v, want account_login=acme", got.Metadata)
+		}
+	})
+
+	t.Run("fails to find unknown id", func(t *testing.T) {
+		f.Reset(t)
+
+		if _, err := f.IntegrationRepository().FindByID(context.Background(), uuid.New()); err == nil {
+			t.Error("FindByID() error = nil, want error")
+		}
+	})
+
+	t.Run("rejects a second integration of the same type for an organization", func(t *testing.T) {
+		f.Reset(t)
+		repo := f.IntegrationRepository()
+		orgID := uuid.New()
+
+		mustStore(t, repo, newIntegration(orgID, backend.ConnectorTypeGithub))
+		if err := repo.Store(context.Background(), newIntegration(orgID, backend.ConnectorTypeGithub)); !errors.Is(err, backend.ErrIntegrationAlreadyExists) {
+			t.Errorf("Store() error = %v, want ErrIntegrationAlreadyExists", err)
+		}
+	})
+
+	t.Run("updates an integration", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		repo := f.IntegrationRepository()
+
+		integration := newIntegration(uuid.New(), backend.ConnectorTypeGithub)
+		mustStore(t, repo, integration)
+
+		now := time.Now().UTC()
+		integration.Status = backend.IntegrationStatusSuspended
+		integration.ConnectorOrganizationID = "acme"
+		integration.LastUsedAt = &now
+		integration.UpdatedAt = now
+		if err := repo.Update(ctx, integration); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if got.Status != backend.IntegrationStatusSuspended || got.ConnectorOrganizationID != "acme" || got.LastUsedAt == nil {
+			t.Errorf("FindByID() after Update = %+v", got)
+		}
+	})
+
+	t.Run("updates status, last used and metadata", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		repo := f.IntegrationRepository()
+
+		integration := newIntegration(uuid.New(), backend.ConnectorTypeGithub)
+		mustStore(t, repo, integration)
+
+		if err := repo.UpdateStatus(ctx, integration.ID, backend.IntegrationStatusInactive); err != nil {
+			t.Fatalf("UpdateStatus() error = %v", err)
+		}
+		if err := repo.UpdateLastUsed(ctx, integration.ID); err != nil {
+			t.Fatalf("UpdateLastUsed() error = %v", err)
+		}
+		if err := repo.UpdateMetadata(ctx, integration.ID, map[string]string{"key": "value"}); err != nil {
+			t.Fatalf("UpdateMetadata() error = %v", err)
+		}
+
+		got, err := repo.FindByID(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if got.Status != backend.IntegrationStatusInactive {
+			t.Errorf("Status = %s, want %s", got.Status, backend.IntegrationStatusInactive)
+		}
+		if got.LastUsedAt == nil {
+			t.Error("LastUsedAt = nil, want a timestamp")
+		}
+		if got.Metadata["key"] != "value" {
+			t.Errorf("Metadata = %v, want key=value", got.Metadata)
+		}
+	})
+
+	t.Run("defaults and updates grants", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		repo := f.IntegrationRepository()
+
+		integration := newIntegration(uuid.New(), backend.ConnectorTypeGCP)
+		mustStore(t, repo, integration)
+
+		got, err := repo.FindByID(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if !slices.Equal(got.Grants, backend.DefaultIntegrationGrants) {
+			t.Errorf("Grants = %v, want the defaults %v", got.Grants, backend.DefaultIntegrationGrants)
+		}
+
+		readOnly := []backend.IntegrationGrant{backend.IntegrationGrantReadContent, backend.IntegrationGrantReadInfra}
+		if err := repo.UpdateGrants(ctx, integration.ID, readOnly); err != nil {
+			t.Fatalf("UpdateGrants() error = %v", err)
+		}
+		got.Status = backend.IntegrationStatusSuspended
+		if err := repo.Update(ctx, got); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err = repo.FindByID(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("FindByID() error = %v", err)
+		}
+		if !slices.Equal(got.Grants, readOnly) {
+			t.Errorf("Grants = %v after UpdateGrants and Update, want %v", got.Grants, readOnly)
+		}
+	})
+
+	t.Run("deletes an integration", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		repo := f.IntegrationRepository()
+
+		integration := newIntegration(uuid.New(), backend.ConnectorTypeGithub)
+		mustStore(t, repo, integration)
+
+		if err := repo.Delete(ctx, integration.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.FindByID(ctx, integration.ID); err == nil {
+			t.Error("FindByID() after Delete error = nil, want error")
+		}
+	})
+
+	t.Run("filters by organization, type and status", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		repo := f.IntegrationRepository()
+		orgID := uuid.New()
+
+		active := newIntegration(orgID, backend.ConnectorTypeGithub)
+		inactive := newIntegration(orgID, backend.ConnectorTypeSlack)
+		inactive.Status = backend.IntegrationStatusInactive
+		mustStore(t, repo, active)
+		mustStore(t, repo, inactive)
+		mustStore(t, repo, newIntegration(uuid.New(), backend.ConnectorTypeGithub))
+
+		all, err := repo.FindByOrganization(ctx, orgID)
+		if err != nil {
+			t.Fatalf("FindByOrganization() error = %v", err)
+		}
+		if len(all) != 2 {
+			t.Errorf("FindByOrganization() returned %d integrations, want 2", len(all))
+		}
+
+		byType, err := repo.FindByOrganizationAndType(ctx, orgID, backend.ConnectorTypeSlack)
+		if err != nil {
+			t.Fatalf("FindByOrganizationAndType() error = %v", err)
+		}
+		if len(byType) != 1 || byType[0].ID != inactive.ID {
+			t.Errorf("FindByOrganizationAndType() = %v, want only %s", byType, inactive.ID)
+		}
+
+		byStatus, err := repo.FindByOrganizationAndStatus(ctx, orgID, backend.IntegrationStatusActive)
+		if err != nil {
+			t.Fatalf("FindByOrganizationAndStatus() error = %v", err)
+		}
+		if len(byStatus) != 1 || byStatus[0].ID != active.ID {
+			t.Errorf("FindByOrganizationAndStatus() = %v, want only %s", byStatus, active.ID)
+		}
+
+		matching, err := repo.FindByOrganizationTypeAndStatus(ctx, orgID, backend.ConnectorTypeGithub, backend.IntegrationStatusActive)
+		if err != nil {
+			t.Fatalf("FindByOrganizationTypeAndStatus() error = %v", err)
+		}
+		if len(matching) != 1 || matching[0].ID != active.ID {
+			t.Errorf("FindByOrganizationTypeAndStatus() = %v, want only %s", matching, active.ID)
+		}
+
+		none, err := repo.FindByOrganizationTypeAndStatus(ctx, orgID, backend.ConnectorTypeGithub, backend.IntegrationStatusInactive)
+		if err != nil {
+			t.Fatalf("FindByOrganizationTypeAndStatus() error = %v", err)
+		}
+		if len(none) != 0 {
+			t.Errorf("FindByOrganizationTypeAndStatus() = %v, want none", none)
+		}
+	})
+
+	t.Run("finds by bot id and type", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		repo := f.IntegrationRepository()
+
+		integration := newIntegration(uuid.New(), backend.ConnectorTypeGithub)
+		mustStore(t, repo, integration)
+
+		got, err := repo.FindByBotIDAndType(ctx, integration.BotID, backend.ConnectorTypeGithub)
+		if err != nil {
+			t.Fatalf("FindByBotIDAndType() error = %v", err)
+		}
+		if got.ID != integration.ID {
+			t.Errorf("FindByBotIDAndType() = %s, want %s", got.ID, integration.ID)
+		}
+
+		_, err = repo.FindByBotIDAndType(ctx, integration.BotID, backend.ConnectorTypeSlack)
+		if !errors.Is(err, backend.ErrIntegrationNotFound) {
+			t.Errorf("FindByBotIDAndType() with other type error = %v, want %v", err, backend.ErrIntegrationNotFound)
+		}
+	})
+
+	t.Run("finds active integrations due for sync", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		repo := f.IntegrationRepository()
+		now := time.Now().UTC().Truncate(time.Second)
+
+		never := newIntegration(uuid.New(), backend.ConnectorTypeGithub)
+		stale := newIntegration(uuid.New(), backend.ConnectorTypeGithub)
+		fresh := newIntegration(uuid.New(), backend.ConnectorTypeGithub)
+		suspended := newIntegration(uuid.New(), backend.ConnectorTypeGithub)
+		suspended.Status = backend.IntegrationStatusSuspended
+		slack := newIntegration(uuid.New(), backend.ConnectorTypeSlack)
+		for _, integration := range []backend.Integration{never, stale, fresh, suspended, slack} {
+			mustStore(t, repo, integration)
+		}
+
+		if err := repo.UpdateLastSynced(ctx, stale.ID, now.Add(-2*time.Hour)); err != nil {
+			t.Fatalf("UpdateLastSynced() error = %v", err)
+		}
+		if err := repo.UpdateLastSynced(ctx, fresh.ID, now); err != nil {
+			t.Fatalf("UpdateLastSynced() error = %v", err)
+		}
+
+		due, err := repo.FindDueForSync(ctx, backend.ConnectorTypeGithub, now.Add(-time.Hour))
+		if err != nil {
+			t.Fatalf("FindDueForSync() error = %v", err)
+		}
+		if len(due) != 2 || due[0].ID != never.ID || due[1].ID != stale.ID {
+			t.Fatalf("FindDueForSync() = %v, want never then stale", due)
+		}
+		if due[1].LastSyncedAt == nil || !due[1].LastSyncedAt.Equal(now.Add(-2*time.Hour)) {
+			t.Errorf("LastSyncedAt = %v, want %v", due[1].LastSyncedAt, now.Add(-2*time.Hour))
+		}
+	})
+
+	t.Run("claims a sync once until the claim expires", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		repo := f.IntegrationRepository()
+
+		integration := newIntegration(uuid.New(), backend.ConnectorTypeGithub)
+		mustStore(t, repo, integration)
+
+		for _, tt := range []struct {
+			name string
+			ttl  time.Duration
+			want bool
+		}{
+			{name: "first claim", ttl: time.Hour, want: true},
+			{name: "claimed", ttl: time.Hour, want: false},
+			{name: "claim expired", ttl: 0, want: true},
+		} {
+			if tt.ttl == 0 {
+				time.Sleep(10 * time.Millisecond)
+			}
+			claimed, err := repo.ClaimSync(ctx, integration.ID, tt.ttl)
+			if err != nil {
+				t.Fatalf("ClaimSync() for %s error = %v", tt.name, err)
+			}
+			if claimed != tt.want {
+				t.Errorf("ClaimSync() for %s = %v, want %v", tt.name, claimed, tt.want)
+			}
+		}
+	})
+
+	t.Run("records authorizations until they expire", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		repo := f.IntegrationRepository()
+
+		integration := newIntegration(uuid.New(), backend.ConnectorTypeGithub)
+		mustStore(t, repo, integration)
+
+		if _, err := repo.FindByAuthorization(ctx, "key", time.Hour); !errors.Is(err, backend.ErrIntegrationNotFound) {
+			t.Fatalf("FindByAuthorization() before recording error = %v, want %v", err, backend.ErrIntegrationNotFound)
+		}
+		if err := repo.RecordAuthorization(ctx, "key", integration.ID); err != nil {
+			t.Fatalf("RecordAuthorization() error = %v", err)
+		}
+		found, err := repo.FindByAuthorization(ctx, "key", time.Hour)
+		if err != nil {
+			t.Fatalf("FindByAuthorization() error = %v", err)
+		}
+		if found.ID != integration.ID {
+			t.Errorf("FindByAuthorization() = %s, want %s", found.ID, integration.ID)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		if _, err := repo.FindByAuthorization(ctx, "key", 0); !errors.Is(err, backend.ErrIntegrationNotFound) {
+			t.Errorf("FindByAuthorization() of an expired record error = %v, want %v", err, backend.ErrIntegrationNotFound)
+		}
+
+		if err := repo.Delete(ctx, integration.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.FindByAuthorization(ctx, "key", time.Hour); !errors.Is(err, backend.ErrIntegrationNotFound) {
+			t.Errorf("FindByAuthorization() after Delete() error = %v, want %v", err, backend.ErrIntegrationNotFound)
+		}
+	})
+}
+
+func ensureCredentialRepository(t *testing.T, f fixture) {
+	t.Run("stores and finds by integration", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		integration := storedIntegration(t, f)
+		repo := f.CredentialRepository()
+
+		cred := newCredential(integration.ID)
+		if err := repo.Store(ctx, cred); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+
+		got, err := repo.FindByIntegration(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("FindByIntegration() error = %v", err)
+		}
+		if got.CredentialType != cred.CredentialType || got.Data["token"] != cred.Data["token"] {
+			t.Errorf("FindByIntegration() = %+v, want %+v", got, cred)
+		}
+	})
+
+	t.Run("rejects credentials for an unknown integration", func(t *testing.T) {
+		f.Reset(t)
+
+		if err := f.CredentialRepository().Store(context.Background(), newCredential(uuid.New())); err == nil {
+			t.Error("Store() error = nil, want foreign key violation")
+		}
+	})
+
+	t.Run("rejects a second credential for an integration", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		integration := storedIntegration(t, f)
+		repo := f.CredentialRepository()
+
+		if err := repo.Store(ctx, newCredential(integration.ID)); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+		if err := repo.Store(ctx, newCredential(integration.ID)); err == nil {
+			t.Error("Store() error = nil, want unique constraint violation")
+		}
+	})
+
+	t.Run("updates a credential", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		integration := storedIntegration(t, f)
+		repo := f.CredentialRepository()
+
+		cred := newCredential(integration.ID)
+		if err := repo.Store(ctx, cred); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+
+		expiresAt := time.Now().UTC().Add(time.Hour)
+		cred.Data = map[string]string{"token": "rotated"}
+		cred.ExpiresAt = &expiresAt
+		if err := repo.Update(ctx, cred); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		got, err := repo.FindByIntegration(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("FindByIntegration() error = %v", err)
+		}
+		if got.Data["token"] != "rotated" || got.ExpiresAt == nil {
+			t.Errorf("FindByIntegration() after Update = %+v", got)
+		}
+	})
+
+	t.Run("deletes a credential", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		integration := storedIntegration(t, f)
+		repo := f.CredentialRepository()
+
+		if err := repo.Store(ctx, newCredential(integration.ID)); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+		if err := repo.Delete(ctx, integration.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if _, err := repo.FindByIntegration(ctx, integration.ID); err == nil {
+			t.Error("FindByIntegration() after Delete error = nil, want error")
+		}
+	})
+
+	t.Run("finds expiring credentials", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		repo := f.CredentialRepository()
+		now := time.Now().UTC()
+
+		expiring := newCredential(storedIntegration(t, f).ID)
+		soon := now.Add(time.Minute)
+		expiring.ExpiresAt = &soon
+
+		later := newCredential(storedIntegration(t, f).ID)
+		inADay := now.Add(24 * time.Hour)
+		later.ExpiresAt = &inADay
+
+		permanent := newCredential(storedIntegration(t, f).ID)
+
+		for _, cred := range []domain.IntegrationCredential{expiring, later, permanent} {
+			if err := repo.Store(ctx, cred); err != nil {
+				t.Fatalf("Store() error = %v", err)
+			}
+		}
+
+		got, err := repo.FindExpiring(ctx, now.Add(time.Hour))
+		if err != nil {
+			t.Fatalf("FindExpiring() error = %v", err)
+		}
+		if len(got) != 1 || got[0].IntegrationID != expiring.IntegrationID {
+			t.Errorf("FindExpiring() = %v, want only the credential of %s", got, expiring.IntegrationID)
+		}
+	})
+}
+
+func ensureGitHubRepositoryRepository(t *testing.T, f fixture) {
+	t.Run("stores and lists by integration", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		integration := storedIntegration(t, f)
+		repo := f.GitHubRepositoryRepository()
+
+		for _, r := range []github.GitHubRepository{
+			newGitHubRepository(integration.ID, 1, "acme/b"),
+			newGitHubRepository(integration.ID, 2, "acme/a"),
+		} {
+			if err := repo.Store(ctx, r); err != nil {
+				t.Fatalf("Store() error = %v", err)
+			}
+		}
+
+		got, err := repo.ListByIntegrationID(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("ListByIntegrationID() error = %v", err)
+		}
+		if len(got) != 2 || got[0].RepositoryFullName != "acme/a" || got[1].RepositoryFullName != "acme/b" {
+			t.Errorf("ListByIntegrationID() = %v, want acme/a and acme/b ordered by name", got)
+		}
+	})
+
+	t.Run("upserts on the github repository id", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		integration := storedIntegration(t, f)
+		repo := f.GitHubRepositoryRepository()
+
+		r := newGitHubRepository(integration.ID, 1, "acme/old")
+		if err := repo.Store(ctx, r); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+		r.ID = uuid.New()
+		r.RepositoryFullName = "acme/new"
+		if err := repo.Store(ctx, r); err != nil {
+			t.Fatalf("Store() second time error = %v", err)
+		}
+
+		got, err := repo.ListByIntegrationID(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("ListByIntegrationID() error = %v", err)
+		}
+		if len(got) != 1 || got[0].RepositoryFullName != "acme/new" {
+			t.Errorf("ListByIntegrationID() = %v, want a single acme/new", got)
+		}
+	})
+
+	t.Run("keeps the enabled flag across upserts", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		integration := storedIntegration(t, f)
+		repo := f.GitHubRepositoryRepository()
+
+		for i, name := range []string{"acme/a", "acme/b"} {
+			if err := repo.Store(ctx, newGitHubRepository(integration.ID, int64(i+1), name)); err != nil {
+				t.Fatalf("Store() error = %v", err)
+			}
+		}
+		updated, err := repo.SetEnabled(ctx, integration.ID, []int64{1, 99}, false)
+		if err != nil {
+			t.Fatalf("SetEnabled() error = %v", err)
+		}
+		if updated != 1 {
+			t.Errorf("SetEnabled() = %d, want 1", updated)
+		}
+
+		resynced := newGitHubRepository(integration.ID, 1, "acme/a")
+		resynced.Enabled = true
+		if err := repo.Store(ctx, resynced); err != nil {
+			t.Fatalf("Store() again error = %v", err)
+		}
+
+		got, err := repo.ListByIntegrationID(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("ListByIntegrationID() error = %v", err)
+		}
+		if len(got) != 2 || got[0].Enabled || !got[1].Enabled {
+			t.Errorf("ListByIntegrationID() = %+v, want acme/a disabled and acme/b enabled", got)
+		}
+	})
+
+	t.Run("stores profiles and keeps them across upserts", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		integration := storedIntegration(t, f)
+		repo := f.GitHubRepositoryRepository()
+
+		if err := repo.Store(ctx, newGitHubRepository(integration.ID, 1, "acme/a")); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+		got, err := repo.GetByGitHubID(ctx, integration.ID, 1)
+		if err != nil {
+			t.Fatalf("GetByGitHubID() error = %v", err)
+		}
+		if got.Profile != nil {
+			t.Errorf("Profile = %+v, want nil before profiling", got.Profile)
+		}
+
+		profile := github.RepositoryProfile{
+			Languages:       map[string]int64{"Go": 5000, "HCL": 1200},
+			PrimaryLanguage: "Go",
+			Markers:         map[string][]string{github.MarkerGo: {"go.mod"}, github.MarkerTerraform: {"infra/main.tf"}},
+			TreeTruncated:   true,
+			ProfiledAt:      time.Now().UTC().Truncate(time.Second),
+		}
+		if err := repo.UpdateProfile(ctx, integration.ID, 1, profile); err != nil {
+			t.Fatalf("UpdateProfile() error = %v", err)
+		}
+		if err := repo.Store(ctx, newGitHubRepository(integration.ID, 1, "acme/a")); err != nil {
+			t.Fatalf("Store() again error = %v", err)
+		}
+
+		list, err := repo.ListByIntegrationID(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("ListByIntegrationID() error = %v", err)
+		}
+		if len(list) != 1 || list[0].Profile == nil {
+			t.Fatalf("ListByIntegrationID() = %+v, want acme/a with a profile", list)
+		}
+		gotProfile := *list[0].Profile
+		if !gotProfile.ProfiledAt.Equal(profile.ProfiledAt) {
+			t.Errorf("ProfiledAt = %v, want %v", gotProfile.ProfiledAt, profile.ProfiledAt)
+		}
+		gotProfile.ProfiledAt = profile.ProfiledAt
+		if !reflect.DeepEqual(gotProfile, profile) {
+			t.Errorf("Profile = %+v, want %+v", gotProfile, profile)
+		}
+	})
+
+	t.Run("rejects repositories for an unknown integration", func(t *testing.T) {
+		f.Reset(t)
+
+		err := f.GitHubRepositoryRepository().Store(context.Background(), newGitHubRepository(uuid.New(), 1, "acme/a"))
+		if err == nil {
+			t.Error("Store() error = nil, want foreign key violation")
+		}
+	})
+
+	t.Run("gets by github id", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		integration := storedIntegration(t, f)
+		repo := f.GitHubRepositoryRepository()
+
+		if err := repo.Store(ctx, newGitHubRepository(integration.ID, 42, "acme/a")); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+
+		got, err := repo.GetByGitHubID(ctx, integration.ID, 42)
+		if err != nil {
+			t.Fatalf("GetByGitHubID() error = %v", err)
+		}
+		if got.RepositoryFullName != "acme/a" {
+			t.Errorf("GetByGitHubID() = %+v, want acme/a", got)
+		}
+	})
+
+	t.Run("updates permissions and last sync time", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		integration := storedIntegration(t, f)
+		repo := f.GitHubRepositoryRepository()
+
+		if err := repo.Store(ctx, newGitHubRepository(integration.ID, 1, "acme/a")); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+
+		if err := repo.UpdatePermissions(ctx, integration.ID, 1, github.RepositoryPermissions{Admin: true, Push: true, Pull: true}); err != nil {
+			t.Fatalf("UpdatePermissions() error = %v", err)
+		}
+		syncedAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+		if err := repo.UpdateLastSyncTime(ctx, integration.ID, syncedAt); err != nil {
+			t.Fatalf("UpdateLastSyncTime() error = %v", err)
+		}
+
+		got, err := repo.GetByGitHubID(ctx, integration.ID, 1)
+		if err != nil {
+			t.Fatalf("GetByGitHubID() error = %v", err)
+		}
+		if !got.PermissionAdmin || !got.PermissionPush || !got.PermissionPull {
+			t.Errorf("permissions = %+v, want all granted", got)
+		}
+		if !got.LastSyncedAt.Equal(syncedAt) {
+			t.Errorf("LastSyncedAt = %v, want %v", got.LastSyncedAt, syncedAt)
+		}
+
+		// A sync upserting the repository again must not reset its
+		// permissions, or permission changes can't be detected.
+		if err := repo.Store(ctx, newGitHubRepository(integration.ID, 1, "acme/a")); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+		got, err = repo.GetByGitHubID(ctx, integration.ID, 1)
+		if err != nil {
+			t.Fatalf("GetByGitHubID() error = %v", err)
+		}
+		if !got.PermissionAdmin || !got.PermissionPush || !got.PermissionPull {
+			t.Errorf("permissions after upsert = %+v, want all still granted", got)
+		}
+	})
+
+	t.Run("deletes single and multiple repositories", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		integration := storedIntegration(t, f)
+		repo := f.GitHubRepositoryRepository()
+
+		for i, name := range []string{"acme/a", "acme/b", "acme/c", "acme/d"} {
+			if err := repo.Store(ctx, newGitHubRepository(integration.ID, int64(i+1), name)); err != nil {
+				t.Fatalf("Store() error = %v", err)
+			}
+		}
+
+		if err := repo.DeleteByGitHubID(ctx, integration.ID, 1); err != nil {
+			t.Fatalf("DeleteByGitHubID() error = %v", err)
+		}
+		deletions, err := repo.BulkDelete(ctx, integration.ID, []int64{2, 3, 1})
+		if err != nil {
+			t.Fatalf("BulkDelete() error = %v", err)
+		}
+		want := []github.RepositoryDeletion{{RepositoryID: 2, Removed: true}, {RepositoryID: 3, Removed: true}, {RepositoryID: 1}}
+		if !reflect.DeepEqual(deletions, want) {
+			t.Errorf("BulkDelete() = %+v, want %+v", deletions, want)
+		}
+
+		got, err := repo.ListByIntegrationID(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("ListByIntegrationID() error = %v", err)
+		}
+		if len(got) != 1 || got[0].GitHubRepositoryID != 4 {
+			t.Errorf("ListByIntegrationID() = %v, want only repository 4", got)
+		}
+	})
+
+	t.Run("removes repositories with their integration", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		integration := storedIntegration(t, f)
+		repo := f.GitHubRepositoryRepository()
+
+		if err := repo.Store(ctx, newGitHubRepository(integration.ID, 1, "acme/a")); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+		if err := f.IntegrationRepository().Delete(ctx, integration.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		got, err := repo.ListByIntegrationID(ctx, integration.ID)
+		if err != nil {
+			t.Fatalf("ListByIntegrationID() error = %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("ListByIntegrationID() = %v, want none", got)
+		}
+	})
+}
+
+func newIntegration(orgID uuid.UUID, connectorType backend.ConnectorType) backend.Integration {
+	now := time.Now().UTC()
+	return backend.Integration{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		UserID:         uuid.New(),
+		ConnectorType:  connectorType,
+		Status:         backend.IntegrationStatusActive,
+		BotID:          uuid.NewString(),
+		Metadata:       map[string]string{},
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+func newCredential(integrationID uuid.UUID) domain.IntegrationCredential {
+	now := time.Now().UTC()
+	return domain.IntegrationCredential{
+		ID:              uuid.New(),
+		IntegrationID:   integrationID,
+		CredentialType:  backend.CredentialTypeToken,
+		Data:            map[string]string{"token": "secret"},
+		EncryptionKeyID: "test",
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
+
+func newGitHubRepository(integrationID uuid.UUID, githubID int64, fullName string) github.GitHubRepository {
+	now := time.Now().UTC()
+	return github.GitHubRepository{
+		ID:                 uuid.New(),
+		IntegrationID:      integrationID,
+		GitHubRepositoryID: githubID,
+		RepositoryName:     fullName,
+		RepositoryFullName: fullName,
+		RepositoryURL:      "https://github.com/" + fullName,
+		DefaultBranch:      "main",
+		PermissionPull:     true,
+		CreatedAt:          now,
+		UpdatedAt:          now,
+		LastSyncedAt:       now,
+	}
+}
+
+func storedIntegration(t *testing.T, f fixture) backend.Integration {
+	t.Helper()
+	integration := newIntegration(uuid.New(), backend.ConnectorTypeGithub)
+	mustStore(t, f.IntegrationRepository(), integration)
+	return integration
+}
+
+func mustStore(t *testing.T, repo domain.IntegrationRepository, integration backend.Integration) {
+	t.Helper()
+	if err := repo.Store(context.Background(), integration); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+}
+
+func ensureActivityRepository(t *testing.T, f fixture) {
+	t.Run("lists an integration's activity newest first within a time range", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		repo := f.ActivityRepository()
+		integrationID, organizationID := uuid.New(), uuid.New()
+		start := time.Now().UTC().Truncate(time.Second)
+
+		for i, activityType := range []backend.IntegrationActivityType{
+			backend.IntegrationActivityAuthorized,
+			backend.IntegrationActivitySyncStarted,
+			backend.IntegrationActivitySyncCompleted,
+		} {
+			err := repo.RecordActivity(ctx, backend.IntegrationActivity{
+				ID:             uuid.New(),
+				IntegrationID:  integrationID,
+				OrganizationID: organizationID,
+				Type:           activityType,
+				Details:        map[string]string{"step": string(activityType)},
+				CreatedAt:      start.Add(time.Duration(i) * time.Minute),
+			})
+			if err != nil {
+				t.Fatalf("RecordActivity() error = %v", err)
+			}
+		}
+		err := repo.RecordActivity(ctx, backend.IntegrationActivity{
+			ID:             uuid.New(),
+			IntegrationID:  uuid.New(),
+			OrganizationID: organizationID,
+			Type:           backend.IntegrationActivityAuthorized,
+			CreatedAt:      start,
+		})
+		if err != nil {
+			t.Fatalf("RecordActivity() error = %v", err)
+		}
+
+		page, err := repo.Activities(ctx, backend.IntegrationActivityQuery{IntegrationID: integrationID, OrganizationID: organizationID, Limit: 2})
+		if err != nil {
+			t.Fatalf("Activities() error = %v", err)
+		}
+		if page.Total != 3 || len(page.Activities) != 2 {
+			t.Fatalf("Activities() = %d entries of %d, want 2 of 3", len(page.Activities), page.Total)
+		}
+		if got := page.Activities[0]; got.Type != backend.IntegrationActivitySyncCompleted || got.Details["step"] != "sync_completed" || !got.CreatedAt.Equal(start.Add(2*time.Minute)) {
+			t.Errorf("Activities()[0] = %+v, want the sync_completed entry", got)
+		}
+
+		page, err = repo.Activities(ctx, backend.IntegrationActivityQuery{
+			IntegrationID:  integrationID,
+			OrganizationID: organizationID,
+			Since:          start.Add(time.Minute),
+			Until:          start.Add(2 * time.Minute),
+			Limit:          10,
+		})
+		if err != nil {
+			t.Fatalf("Activities() error = %v", err)
+		}
+		if page.Total != 1 || len(page.Activities) != 1 || page.Activities[0].Type != backend.IntegrationActivitySyncStarted {
+			t.Errorf("Activities() = %+v, want only sync_started", page)
+		}
+
+		page, err = repo.Activities(ctx, backend.IntegrationActivityQuery{IntegrationID: integrationID, OrganizationID: uuid.New(), Limit: 10})
+		if err != nil || page.Total != 0 || len(page.Activities) != 0 {
+			t.Errorf("Activities() for another organization = %+v, %v, want none", page, err)
+		}
+	})
+}
+
+func ensureCredentialAccessRepository(t *testing.T, f fixture) {
+	t.Run("lists and counts an integration's accesses newest first", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		repo := f.CredentialAccessRepository()
+		integrationID, organizationID := uuid.New(), uuid.New()
+		start := time.Now().UTC().Truncate(time.Second)
+
+		device := backend.CredentialAccessor{
+			Type:   backend.CredentialAccessorDevice,
+			ID:     uuid.NewString(),
+			UserID: uuid.NewString(),
+			IP:     "203.0.113.7",
+			Reason: "/device/credentials/gcp",
+		}
+		service := backend.CredentialAccessor{Type: backend.CredentialAccessorService, ID: "integrationsvc", Reason: "sync"}
+		for i, accessor := range []backend.CredentialAccessor{device, service, service} {
+			err := repo.RecordAccess(ctx, backend.CredentialAccess{
+				ID:             uuid.New(),
+				IntegrationID:  integrationID,
+				OrganizationID: organizationID,
+				Accessor:       accessor,
+				Fields:         []string{"service_account_json"},
+				AccessedAt:     start.Add(time.Duration(i) * time.Minute),
+			})
+			if err != nil {
+				t.Fatalf("RecordAccess() error = %v", err)
+			}
+		}
+		err := repo.RecordAccess(ctx, backend.CredentialAccess{
+			ID:             uuid.New(),
+			IntegrationID:  uuid.New(),
+			OrganizationID: organizationID,
+			Accessor:       service,
+			AccessedAt:     start,
+		})
+		if err != nil {
+			t.Fatalf("RecordAccess() error = %v", err)
+		}
+
+		page, err := repo.Accesses(ctx, backend.CredentialAccessQuery{IntegrationID: integrationID, Limit: 2})
+		if err != nil {
+			t.Fatalf("Accesses() error = %v", err)
+		}
+		if page.Total != 3 || len(page.Accesses) != 2 {
+			t.Fatalf("Accesses() = %d entries of %d, want 2 of 3", len(page.Accesses), page.Total)
+		}
+
+		page, err = repo.Accesses(ctx, backend.CredentialAccessQuery{IntegrationID: integrationID, Until: start.Add(time.Minute), Limit: 10})
+		if err != nil {
+			t.Fatalf("Accesses() error = %v", err)
+		}
+		if page.Total != 1 || page.Accesses[0].Accessor != device || len(page.Accesses[0].Fields) != 1 || !page.Accesses[0].AccessedAt.Equal(start) {
+			t.Errorf("Accesses() = %+v, want only the device access", page)
+		}
+
+		count, err := repo.CountAccessesSince(ctx, integrationID, start.Add(time.Minute))
+		if err != nil {
+			t.Fatalf("CountAccessesSince() error = %v", err)
+		}
+		if count != 2 {
+			t.Errorf("CountAccessesSince() = %d, want 2", count)
+		}
+	})
+}
+
+func ensureRepositoryTriggerRepository(t *testing.T, f fixture) {
+	t.Run("stores, updates and deletes an organization's triggers", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		repo := f.RepositoryTriggerRepository()
+		orgID := uuid.New()
+		now := time.Now().UTC().Truncate(time.Second)
+
+		trigger := backend.RepositoryTrigger{
+			ID:                uuid.New(),
+			OrganizationID:    orgID,
+			RepositoryPattern: "acme/infra-*",
+			EventType:         backend.RepositoryEventPullRequest,
+			PathGlob:          "**/*.tf",
+			Channel:           "C123",
+			CreatedAt:         now,
+			UpdatedAt:         now,
+		}
+		other := trigger
+		other.ID, other.OrganizationID = uuid.New(), uuid.New()
+		for _, tr := range []backend.RepositoryTrigger{trigger, other} {
+			if err := repo.Store(ctx, tr); err != nil {
+				t.Fatalf("Store() error = %v", err)
+			}
+		}
+
+		trigger.EventType = backend.RepositoryEventPush
+		trigger.Channel = "C456"
+		trigger.UpdatedAt = now.Add(time.Minute)
+		if err := repo.Update(ctx, trigger); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		triggers, err := repo.FindByOrganization(ctx, orgID)
+		if err != nil {
+			t.Fatalf("FindByOrganization() error = %v", err)
+		}
+		if len(triggers) != 1 || triggers[0].EventType != backend.RepositoryEventPush || triggers[0].Channel != "C456" || triggers[0].PathGlob != "**/*.tf" {
+			t.Fatalf("FindByOrganization() = %+v, want the updated trigger", triggers)
+		}
+
+		all, err := repo.FindAll(ctx)
+		if err != nil {
+			t.Fatalf("FindAll() error = %v", err)
+		}
+		if len(all) != 2 {
+			t.Errorf("FindAll() returned %d triggers, want 2", len(all))
+		}
+
+		if err := repo.Delete(ctx, other.OrganizationID, trigger.ID); !errors.Is(err, backend.ErrRepositoryTriggerNotFound) {
+			t.Errorf("Delete() from another organization error = %v, want ErrRepositoryTriggerNotFound", err)
+		}
+		if err := repo.Delete(ctx, orgID, trigger.ID); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if err := repo.Update(ctx, trigger); !errors.Is(err, backend.ErrRepositoryTriggerNotFound) {
+			t.Errorf("Update() of deleted trigger error = %v, want ErrRepositoryTriggerNotFound", err)
+		}
+
+		deleted, err := repo.DeleteByOrganization(ctx, other.OrganizationID, true)
+		if err != nil || deleted != 1 {
+			t.Fatalf("DeleteByOrganization(dry run) = %d, %v, want 1", deleted, err)
+		}
+		if _, err := repo.DeleteByOrganization(ctx, other.OrganizationID, false); err != nil {
+			t.Fatalf("DeleteByOrganization() error = %v", err)
+		}
+		if all, _ := repo.FindAll(ctx); len(all) != 0 {
+			t.Errorf("FindAll() after deletion = %+v, want none", all)
+		}
+	})
+}
+
+func ensureSyncJobRepository(t *testing.T, f fixture) {
+	t.Run("fails stale jobs once and summarises health", func(t *testing.T) {
+		f.Reset(t)
+		ctx := context.Background()
+		repo := f.SyncJobRepository()
+		integrationID := uuid.New()
+		now := time.Now().UTC().Truncate(time.Second)
+
+		start := func(startedAt time.Time, attempt int) domain.SyncJob {
+			t.Helper()
+			job := domain.SyncJob{
+				ID:            uuid.New(),
+				IntegrationID: integrationID,
+				Status:        domain.SyncJobStatusRunning,
+				Attempt:       attempt,
+				Parameters:    map[string]string{"scope": "repositories"},
+				StartedAt:     startedAt,
+				HeartbeatAt:   startedAt,
+			}
+			if err := repo.Start(ctx, job); err != nil {
+				t.Fatalf("Start() error = %v", err)
+			}
+			return job
+		}
+
+		finish := func(job domain.SyncJob, status domain.SyncJobStatus, errorMessage string, finishedAt time.Time, result backend.SyncResult) {
+			t.Helper()
+			job.Status, job.Error, job.FinishedAt, job.Result = status, errorMessage, &finishedAt, result
+			if err := repo.Finish(ctx, job); err != nil {
+				t.Fatalf("Finish() error = %v", err)
+			}
+		}
+
+		succeeded := start(now.Add(-3*time.Hour), 1)
+		finish(succeeded, domain.SyncJobStatusSucceeded, "", now.Add(-2*time.Hour),
+			backend.SyncResult{Added: 2, Updated: 1, Errors: []string{"archived: not found"}})
+		failed := start(now.Add(-time.Hour), 1)
+		finish(failed, domain.SyncJobStatusFailed, "rate limited", now.Add(-time.Hour), backend.SyncResult{})
+		lost := start(now.Add(-30*time.Minute), 1)
+		alive := start(now.Add(-30*time.Minute), 1)
+		if err := repo.Heartbeat(ctx, alive.ID, now); err != nil {
+			t.Fatalf("Heartbeat() error = %v", err)
+		}
+
+		reaped, err := repo.FailStale(ctx, now.Add(-5*time.Minute), "lost", now)
+		if err != nil {
+			t.Fatalf("FailStale() error = %v", err)
+		}
+		if len(reaped) != 1 || reaped[0].ID != lost.ID || reaped[0].Status != domain.SyncJobStatusFailed ||
+			reaped[0].Parameters["scope"] != "repositories" {
+			t.Fatalf("FailStale() = %+v, want only the lost job, failed with its parameters", reaped)
+		}
+		if again, err := repo.FailStale(ctx, now.Add(-5*time.Minute), "lost", now); err != nil || len(again) != 0 {
+			t.Errorf("FailStale() again = %+v, %v, want nothing", again, err)
+		}
+		if err := repo.Heartbeat(ctx, lost.ID, now); !errors.Is(err, domain.ErrSyncJobNotRunning) {
+			t.Errorf("Heartbeat() of a failed job error = %v, want ErrSyncJobNotRunning", err)
+		}
+
+		health, err := repo.Health(ctx, integrationID)
+		if err != nil {
+			t.Fatalf("Health() error = %v", err)
+		}
+		if health.LastSuccessfulSyncAt == nil || !health.LastSuccessfulSyncAt.Equal(now.Add(-2*time.Hour)) {
+			t.Errorf("LastSuccessfulSyncAt = %v, want %v", health.LastSuccessfulSyncAt, now.Add(-2*time.Hour))
+		}
+		if r := health.LastSyncResult; r == nil || r.Added != 2 || r.Updated != 1 || r.Removed != 0 ||
+			len(r.Errors) != 1 || r.Errors[0] != "archived: not found" {
+			t.Errorf("LastSyncResult = %+v, want the successful sync's result", r)
+		}
+		if health.ConsecutiveFailures != 2 || !health.Running {
+			t.Errorf("Health() = %+v, want 2 consecutive failures and a running sync", health)
+		}
+
+		if other, err := repo.Health(ctx, uuid.New()); err != nil || other != (backend.IntegrationSyncHealth{}) {
+			t.Errorf("Health() of an integration without jobs = %+v, %v, want zero", other, err)
+		}
+	})
+}
@@ -4,31 +4,99 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/azuredevops"
 	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/github"
 	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
 	"github.com/google/uuid"
 )
 
 type service struct {
-	integrationRepository domain.IntegrationRepository
-	credentialRepository  domain.CredentialRepository
-	connectors            map[backend.ConnectorType]domain.Connector
+	integrationRepository     domain.IntegrationRepository
+	readIntegrationRepository domain.IntegrationRepository
+	credentialRepository      domain.CredentialRepository
+	activityRepository        domain.ActivityRepository
+	integrationDataRepository domain.IntegrationDataRepository
+	// credentialAccessRepository backs CredentialAccessHistory; reads are
+	// recorded by the credential repository itself.
+	credentialAccessRepository domain.CredentialAccessRepository
+	mintedCredentials          *mintedCredentialCache
+	connectors                 map[backend.ConnectorType]domain.Connector
+	featureFlags               backend.FeatureFlags
+	flaggedConnectors          []backend.ConnectorType
+	authorizations             *authorizationCache
+	syncSchedules              map[backend.ConnectorType]syncSchedule
+	syncing                    *inFlightSyncs
+	syncJobs                   domain.SyncJobRepository
+	syncJobPolicy              syncJobPolicy
+	// routedWebhooks are connectors whose webhooks the main HTTP server serves.
+	routedWebhooks          map[backend.ConnectorType]bool
+	triggerRepository       domain.RepositoryTriggerRepository
+	triggers                *triggerIndex
+	repositoryEventListener backend.RepositoryEventListener
 }
 
 type ServiceConfig struct {
 	IntegrationRepository domain.IntegrationRepository
-	CredentialRepository  domain.CredentialRepository
-	Connectors            map[backend.ConnectorType]domain.Connector
+	// ReadIntegrationRepository serves Integrations, such as from a read
+	// replica. IntegrationRepository is used when it is nil.
+	ReadIntegrationRepository domain.IntegrationRepository
+	CredentialRepository      domain.CredentialRepository
+	ActivityRepository        domain.ActivityRepository
+	// IntegrationDataRepository deletes integrations when an organization offboards.
+	IntegrationDataRepository  domain.IntegrationDataRepository
+	CredentialAccessRepository domain.CredentialAccessRepository
+	Connectors                 map[backend.ConnectorType]domain.Connector
+	FeatureFlags               backend.FeatureFlags
+	// FlaggedConnectors are only offered to organizations with backend.ConnectorFeatureFlag enabled.
+	FlaggedConnectors []backend.ConnectorType
+	// SyncSchedules enables periodic background syncs per connector type.
+	SyncSchedules map[backend.ConnectorType]syncSchedule
+	// SyncJobRepository tracks running syncs so that syncs lost with their
+	// worker are failed and optionally rescheduled. It may be nil.
+	SyncJobRepository           domain.SyncJobRepository
+	SyncJobPolicy               syncJobPolicy
+	RepositoryTriggerRepository domain.RepositoryTriggerRepository
+	// RepositoryEventListener is told about pushes and pull requests that
+	// match a repository trigger.
+	RepositoryEventListener backend.RepositoryEventListener
 }
 
 func NewService(config ServiceConfig) backend.IntegrationService {
+	if config.SyncJobPolicy == (syncJobPolicy{}) {
+		config.SyncJobPolicy = SyncConfig{}.jobPolicy()
+	}
+	if config.ReadIntegrationRepository == nil {
+		config.ReadIntegrationRepository = config.IntegrationRepository
+	}
+
 	return &service{
-		integrationRepository: config.IntegrationRepository,
-		credentialRepository:  config.CredentialRepository,
-		connectors:            config.Connectors,
+		integrationRepository:      config.IntegrationRepository,
+		readIntegrationRepository:  config.ReadIntegrationRepository,
+		credentialRepository:       config.CredentialRepository,
+		activityRepository:         config.ActivityRepository,
+		integrationDataRepository:  config.IntegrationDataRepository,
+		credentialAccessRepository: config.CredentialAccessRepository,
+		mintedCredentials:          newMintedCredentialCache(),
+		connectors:                 config.Connectors,
+		featureFlags:               config.FeatureFlags,
+		flaggedConnectors:          config.FlaggedConnectors,
+		authorizations:             newAuthorizationCache(authorizationTTL),
+		syncSchedules:              config.SyncSchedules,
+		syncing:                    newInFlightSyncs(),
+		syncJobs:                   config.SyncJobRepository,
+		syncJobPolicy:              config.SyncJobPolicy,
+		routedWebhooks:             make(map[backend.ConnectorType]bool),
+		triggerRepository:          config.RepositoryTriggerRepository,
+		triggers:                   &triggerIndex{repository: config.RepositoryTriggerRepository},
+		repositoryEventListener:    config.RepositoryEventListener,
 	}
 }
 
@@ -39,21 +107,47 @@ func (s *service) NewIntegration(ctx context.Context, cmd backend.NewIntegration
 	}
 
 	if len(existingActiveIntegrations) > 0 {
-		return backend.IntegrationAuthorizationIntent{}, fmt.Errorf("integration already exists for connector type %s", cmd.ConnectorType)
+		return backend.IntegrationAuthorizationIntent{}, fmt.Errorf("%w for connector type %s", backend.ErrIntegrationAlreadyExists, cmd.ConnectorType)
 	}
 
 	connector, exists := s.connectors[cmd.ConnectorType]
-	if !exists {
-		return backend.IntegrationAuthorizationIntent{}, fmt.Errorf("unsupported connector type: %s", cmd.ConnectorType)
+	if !exists || !s.connectorEnabled(ctx, cmd.OrganizationID, cmd.ConnectorType) {
+		return backend.IntegrationAuthorizationIntent{}, fmt.Errorf("%w: %s", backend.ErrUnsupportedConnector, cmd.ConnectorType)
 	}
 
 	return connector.InitiateAuthorization(cmd.OrganizationID.String(), cmd.UserID.String())
 }
 
+func (s *service) connectorEnabled(ctx context.Context, organizationID uuid.UUID, connectorType backend.ConnectorType) bool {
+	if !slices.Contains(s.flaggedConnectors, connectorType) {
+		return true
+	}
+	return s.featureFlags != nil && s.featureFlags.Enabled(ctx, organizationID, backend.ConnectorFeatureFlag(connectorType))
+}
+
 func (s *service) AuthorizeIntegration(ctx context.Context, cmd backend.AuthorizeIntegrationCommand) (backend.Integration, error) {
+	if cmd.IdempotencyKey == "" && cmd.State == "" && cmd.InstallationID == "" {
+		return s.authorizeIntegration(ctx, cmd)
+	}
+
+	key := authorizationKey(cmd)
+	return s.authorizations.do(key, func() (backend.Integration, error) {
+		return s.authorizeOnce(ctx, key, cmd)
+	})
+}
+
+func (s *service) authorizeIntegration(ctx context.Context, cmd backend.AuthorizeIntegrationCommand) (backend.Integration, error) {
 	connector, exists := s.connectors[cmd.ConnectorType]
 	if !exists {
-		return backend.Integration{}, fmt.Errorf("unsupported connector type: %s", cmd.ConnectorType)
+		return backend.Integration{}, fmt.Errorf("%w: %s", backend.ErrUnsupportedConnector, cmd.ConnectorType)
+	}
+
+	grants := backend.DefaultIntegrationGrants
+	if len(cmd.Grants) > 0 {
+		var err error
+		if grants, err = normalizeGrants(cmd.Grants); err != nil {
+			return backend.Integration{}, err
+		}
 	}
 
 	authData := backend.AuthorizationData{
@@ -81,11 +175,12 @@ func (s *service) AuthorizeIntegration(ctx context.Context, cmd backend.Authoriz
 
 		for _, integration := range existingActiveIntegrations {
 			if integration.BotID == cmd.InstallationID {
+				s.recordActivity(ctx, integration, backend.IntegrationActivityAuthorized, map[string]string{"installation_id": cmd.InstallationID})
 				return integration, nil
 			}
 		}
 
-		return backend.Integration{}, fmt.Errorf("claimed integration not found")
+		return backend.Integration{}, fmt.Errorf("claimed %w", backend.ErrIntegrationNotFound)
 	}
 
 	organizationID, userID, err := connector.ParseState(cmd.State)
@@ -99,7 +194,7 @@ func (s *service) AuthorizeIntegration(ctx context.Context, cmd backend.Authoriz
 	}
 
 	if len(existingActiveIntegrations) > 0 {
-		return backend.Integration{}, fmt.Errorf("integration already exists for connector type %s in organization %s", cmd.ConnectorType, organizationID)
+		return backend.Integration{}, fmt.Errorf("%w for connector type %s in organization %s", backend.ErrIntegrationAlreadyExists, cmd.ConnectorType, organizationID)
 	}
 
 	now := time.Now()
@@ -113,6 +208,7 @@ func (s *service) AuthorizeIntegration(ctx context.Context, cmd backend.Authoriz
 		CreatedAt:      now,
 		UpdatedAt:      now,
 		LastUsedAt:     &now,
+		Grants:         grants,
 	}
 
 	if cmd.InstallationID != "" {
@@ -146,6 +242,13 @@ func (s *service) AuthorizeIntegration(ctx context.Context, cmd backend.Authoriz
 		return backend.Integration{}, fmt.Errorf("failed to store credentials: %w", err)
 	}
 
+	// Without webhooks the integration still picks up changes on its
+	// scheduled syncs, so a failure does not undo the authorization.
+	if err := connector.ConfigureWebhooks(integration.ID.String(), credentials); err != nil {
+		slog.Warn("failed to configure webhooks", "integration_id", integration.ID, "connector_type", cmd.ConnectorType, "error", err)
+	}
+
+	s.recordActivity(ctx, integration, backend.IntegrationActivityAuthorized, map[string]string{"connector_org_id": integration.ConnectorOrganizationID})
 	return integration, nil
 }
 
@@ -156,10 +259,10 @@ func (s *service) RevokeIntegration(ctx context.Context, cmd backend.RevokeInteg
 	}
 
 	if integration.OrganizationID != cmd.OrganizationID {
-		return fmt.Errorf("integration not found for organization")
+		return backend.ErrIntegrationNotFound
 	}
 
-	credential, err := s.credentialRepository.FindByIntegration(ctx, cmd.IntegrationID)
+	credential, err := s.credentialRepository.FindByIntegration(withCredentialAccessReason(ctx, "revoke"), cmd.IntegrationID)
 	if err != nil {
 		return fmt.Errorf("failed to find credentials: %w", err)
 	}
@@ -184,24 +287,25 @@ func (s *service) RevokeIntegration(ctx context.Context, cmd backend.RevokeInteg
 	if err := s.integrationRepository.Delete(ctx, cmd.IntegrationID); err != nil {
 		return fmt.Errorf("failed to delete integration: %w", err)
 	}
+	s.recordActivity(ctx, integration, backend.IntegrationActivityRevoked, map[string]string{"connector_type": string(integration.ConnectorType)})
 
 	return nil
 }
 
 func (s *service) Integrations(ctx context.Context, query backend.IntegrationsQuery) ([]backend.Integration, error) {
 	if query.ConnectorType != "" && query.Status != "" {
-		return s.integrationRepository.FindByOrganizationTypeAndStatus(ctx, query.OrganizationID, query.ConnectorType, query.Status)
+		return s.readIntegrationRepository.FindByOrganizationTypeAndStatus(ctx, query.OrganizationID, query.ConnectorType, query.Status)
 	}
 
 	if query.ConnectorType != "" {
-		return s.integrationRepository.FindByOrganizationAndType(ctx, query.OrganizationID, query.ConnectorType)
+		return s.readIntegrationRepository.FindByOrganizationAndType(ctx, query.OrganizationID, query.ConnectorType)
 	}
 
 	if query.Status != "" {
-		return s.integrationRepository.FindByOrganizationAndStatus(ctx, query.OrganizationID, query.Status)
+		return s.readIntegrationRepository.FindByOrganizationAndStatus(ctx, query.OrganizationID, query.Status)
 	}
 
-	return s.integrationRepository.FindByOrganization(ctx, query.OrganizationID)
+	return s.readIntegrationRepository.FindByOrganization(ctx, query.OrganizationID)
 }
 
 func (s *service) Integration(ctx context.Context, query backend.IntegrationQuery) (backend.Integration, error) {
@@ -211,12 +315,131 @@ func (s *service) Integration(ctx context.Context, query backend.IntegrationQuer
 	}
 
 	if integration.OrganizationID != query.OrganizationID {
-		return backend.Integration{}, fmt.Errorf("integration not found for organization")
+		return backend.Integration{}, backend.ErrIntegrationNotFound
 	}
 
 	return integration, nil
 }
 
+func (s *service) IntegrationSyncStatus(ctx context.Context, query backend.IntegrationQuery) (backend.IntegrationSyncStatus, error) {
+	integration, err := s.Integration(ctx, query)
+	if err != nil {
+		return backend.IntegrationSyncStatus{}, err
+	}
+
+	status := backend.IntegrationSyncStatus{LastSyncedAt: integration.LastSyncedAt}
+	if reporter, ok := s.connectors[integration.ConnectorType].(domain.SyncStatusReporter); ok {
+		reported, err := reporter.SyncStatus(ctx, integration)
+		if err != nil {
+			return backend.IntegrationSyncStatus{}, fmt.Errorf("failed to get sync status: %w", err)
+		}
+		if reported.LastSyncedAt == nil {
+			reported.LastSyncedAt = integration.LastSyncedAt
+		}
+		status = reported
+	}
+
+	if s.syncJobs != nil {
+		health, err := s.syncJobs.Health(ctx, integration.ID)
+		if err != nil {
+			return backend.IntegrationSyncStatus{}, fmt.Errorf("failed to get sync health: %w", err)
+		}
+		status.Health = &health
+	}
+
+	return status, nil
+}
+
+const (
+	defaultRepositoryPageSize = 50
+	maxRepositoryPageSize     = 200
+)
+
+func (s *service) IntegrationRepositories(ctx context.Context, query backend.IntegrationRepositoriesQuery) (backend.SyncedRepositoriesPage, error) {
+	integration, err := s.Integration(ctx, backend.IntegrationQuery{
+		IntegrationID:  query.IntegrationID,
+		OrganizationID: query.OrganizationID,
+	})
+	if err != nil {
+		return backend.SyncedRepositoriesPage{}, err
+	}
+
+	lister, ok := s.connectors[integration.ConnectorType].(domain.RepositoryLister)
+	if !ok {
+		return backend.SyncedRepositoriesPage{}, fmt.Errorf("%w: %s does not sync repositories", backend.ErrUnsupportedConnector, integration.ConnectorType)
+	}
+
+	repositories, err := lister.Repositories(ctx, integration)
+	if err != nil {
+		return backend.SyncedRepositoriesPage{}, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	if name := strings.ToLower(query.Name); name != "" {
+		repositories = slices.DeleteFunc(repositories, func(repo backend.SyncedRepository) bool {
+			return !strings.Contains(strings.ToLower(repo.FullName), name)
+		})
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultRepositoryPageSize
+	}
+	limit = min(limit, maxRepositoryPageSize)
+	start := min(max(query.Offset, 0), len(repositories))
+	end := min(start+limit, len(repositories))
+
+	return backend.SyncedRepositoriesPage{
+		Repositories: repositories[start:end],
+		Total:        len(repositories),
+	}, nil
+}
+
+func (s *service) SetRepositoriesEnabled(ctx context.Context, cmd backend.SetRepositoriesEnabledCommand) (int, error) {
+	if len(cmd.RepositoryIDs) == 0 && cmd.NamePattern == "" {
+		return 0, fmt.Errorf("%w: repository IDs or a name pattern are required", backend.ErrInvalidRepositorySelection)
+	}
+	pattern := strings.ToLower(cmd.NamePattern)
+	if _, err := path.Match(pattern, ""); err != nil {
+		return 0, fmt.Errorf("%w: %q is not a valid pattern", backend.ErrInvalidRepositorySelection, cmd.NamePattern)
+	}
+
+	integration, err := s.Integration(ctx, backend.IntegrationQuery{
+		IntegrationID:  cmd.IntegrationID,
+		OrganizationID: cmd.OrganizationID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	connector := s.connectors[integration.ConnectorType]
+	lister, ok := connector.(domain.RepositoryLister)
+	selector, canSelect := connector.(domain.RepositorySelector)
+	if !ok || !canSelect {
+		return 0, fmt.Errorf("%w: %s does not sync repositories", backend.ErrUnsupportedConnector, integration.ConnectorType)
+	}
+
+	repositoryIDs := slices.Clone(cmd.RepositoryIDs)
+	if pattern != "" {
+		repositories, err := lister.Repositories(ctx, integration)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list repositories: %w", err)
+		}
+		for _, repo := range repositories {
+			if matched, _ := path.Match(pattern, strings.ToLower(repo.FullName)); matched {
+				repositoryIDs = append(repositoryIDs, repo.ID)
+			}
+		}
+	}
+	slices.Sort(repositoryIDs)
+	repositoryIDs = slices.Compact(repositoryIDs)
+
+	updated, err := selector.SetRepositoriesEnabled(ctx, integration, repositoryIDs, cmd.Enabled)
+	if err != nil {
+		return 0, fmt.Errorf("failed to set repositories enabled: %w", err)
+	}
+	return updated, nil
+}
+
 func (s *service) IntegrationCredentials(ctx context.Context, query backend.IntegrationCredentialsQuery) (backend.Credentials, error) {
 	integration, err := s.integrationRepository.FindByID(ctx, query.IntegrationID)
 	if err != nil {
@@ -224,10 +447,13 @@ func (s *service) IntegrationCredentials(ctx context.Context, query backend.Inte
 	}
 
 	if integration.OrganizationID != query.OrganizationID {
-		return backend.Credentials{}, fmt.Errorf("integration not found for organization")
+		return backend.Credentials{}, backend.ErrIntegrationNotFound
+	}
+	if err := integration.CheckCredentialsUsable(); err != nil {
+		return backend.Credentials{}, err
 	}
 
-	credential, err := s.credentialRepository.FindByIntegration(ctx, query.IntegrationID)
+	credential, err := s.credentialRepository.FindByIntegration(withCredentialAccessReason(ctx, "credentials"), query.IntegrationID)
 	if err != nil {
 		return backend.Credentials{}, fmt.Errorf("failed to find credentials: %w", err)
 	}
@@ -239,6 +465,34 @@ func (s *service) IntegrationCredentials(ctx context.Context, query backend.Inte
 	}, nil
 }
 
+func (s *service) IntegrationPermissions(ctx context.Context, query backend.IntegrationQuery) (map[string]string, error) {
+	integration, err := s.Integration(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	connector, exists := s.connectors[integration.ConnectorType]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", backend.ErrUnsupportedConnector, integration.ConnectorType)
+	}
+
+	credential, err := s.credentialRepository.FindByIntegration(withCredentialAccessReason(ctx, "permissions"), integration.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find credentials: %w", err)
+	}
+
+	permissions, err := connector.Permissions(backend.Credentials{
+		Type:      credential.CredentialType,
+		Data:      credential.Data,
+		ExpiresAt: credential.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get permissions: %w", err)
+	}
+
+	return permissions, nil
+}
+
 func (s *service) SyncIntegration(ctx context.Context, cmd backend.SyncIntegrationCommand) error {
 	integration, err := s.integrationRepository.FindByID(ctx, cmd.IntegrationID)
 	if err != nil {
@@ -246,16 +500,14 @@ func (s *service) SyncIntegration(ctx context.Context, cmd backend.SyncIntegrati
 	}
 
 	if integration.OrganizationID != cmd.OrganizationID {
-		return fmt.Errorf("integration not found for organization")
+		return backend.ErrIntegrationNotFound
 	}
-
-	connector, exists := s.connectors[integration.ConnectorType]
-	if !exists {
-		return fmt.Errorf("unsupported connector type: %s", integration.ConnectorType)
+	if err := integration.CheckCredentialsUsable(); err != nil {
+		return err
 	}
 
-	if err := connector.Sync(ctx, integration, cmd.Parameters); err != nil {
-		return fmt.Errorf("failed to sync integration: %w", err)
+	if err := s.syncIntegration(ctx, integration, cmd.Parameters); err != nil {
+		return err
 	}
 
 	now := time.Now()
@@ -269,6 +521,76 @@ func (s *service) SyncIntegration(ctx context.Context, cmd backend.SyncIntegrati
 	return nil
 }
 
+// syncIntegration runs a connector sync and records when it finished. Manual and
+// scheduled syncs share it so that an integration never syncs twice at once.
+func (s *service) syncIntegration(ctx context.Context, integration backend.Integration, params map[string]string) error {
+	return s.syncIntegrationAttempt(ctx, integration, params, 1)
+}
+
+func (s *service) syncIntegrationAttempt(ctx context.Context, integration backend.Integration, params map[string]string, attempt int) error {
+	connector, exists := s.connectors[integration.ConnectorType]
+	if !exists {
+		return fmt.Errorf("%w: %s", backend.ErrUnsupportedConnector, integration.ConnectorType)
+	}
+
+	if !s.syncing.start(integration.ID) {
+		return domain.ErrSyncInProgress
+	}
+	defer s.syncing.finish(integration.ID)
+
+	s.recordActivity(ctx, integration, backend.IntegrationActivitySyncStarted, nil)
+	finishJob := s.startSyncJob(ctx, integration, params, attempt)
+	result, err := connector.Sync(withCredentialAccessReason(ctx, "sync"), integration, params)
+	finishJob(result, err)
+	if err != nil {
+		s.recordActivity(ctx, integration, backend.IntegrationActivitySyncFailed, map[string]string{"error": err.Error()})
+		return fmt.Errorf("failed to sync integration: %w", err)
+	}
+	slog.Info("integration sync completed",
+		"integration_id", integration.ID,
+		"connector_type", integration.ConnectorType,
+		"added", result.Added,
+		"removed", result.Removed,
+		"updated", result.Updated,
+		"errors", len(result.Errors))
+	s.recordActivity(ctx, integration, backend.IntegrationActivitySyncCompleted, s.syncCounts(ctx, integration, result))
+
+	if err := s.integrationRepository.UpdateLastSynced(ctx, integration.ID, time.Now()); err != nil {
+		return fmt.Errorf("failed to record last sync time: %w", err)
+	}
+
+	return nil
+}
+
+// syncCounts summarises a finished sync for the activity feed.
+func (s *service) syncCounts(ctx context.Context, integration backend.Integration, result backend.SyncResult) map[string]string {
+	counts := map[string]string{
+		"added":   strconv.Itoa(result.Added),
+		"removed": strconv.Itoa(result.Removed),
+		"updated": strconv.Itoa(result.Updated),
+		"errors":  strconv.Itoa(len(result.Errors)),
+	}
+
+	reporter, ok := s.connectors[integration.ConnectorType].(domain.SyncStatusReporter)
+	if !ok {
+		return counts
+	}
+
+	status, err := reporter.SyncStatus(ctx, integration)
+	if err != nil {
+		slog.Warn("failed to get sync status for activity feed", "integration_id", integration.ID, "error", err)
+		return counts
+	}
+
+	if status.RepositoryCount != nil {
+		counts["repository_count"] = strconv.Itoa(*status.RepositoryCount)
+	}
+	if status.AccessibleRepositoryCount != nil {
+		counts["accessible_repository_count"] = strconv.Itoa(*status.AccessibleRepositoryCount)
+	}
+	return counts
+}
+
 func (s *service) ValidateCredentials(ctx context.Context, connectorType backend.ConnectorType, credentials map[string]any) (backend.CredentialValidationResult, error) {
 	connector, exists := s.connectors[connectorType]
 	if !exists {
@@ -320,14 +642,39 @@ func (s *service) ValidateCredentials(ctx context.Context, connectorType backend
 	}, nil
 }
 
-// Subscribe starts webhook subscriptions for all connectors
+func (s *service) RegisterWebhookRoutes(mux *http.ServeMux) {
+	for connectorType, connector := range s.connectors {
+		router, ok := connector.(domain.WebhookRouter)
+		if !ok {
+			continue
+		}
+		if router.RegisterRoutes(mux, s.handleConnectorEvent) {
+			s.routedWebhooks[connectorType] = true
+			slog.Info("connector webhooks served by the main http server", "connector_type", connectorType)
+		}
+	}
+}
+
+// Subscribe starts webhook subscriptions and scheduled syncs for all connectors.
+// Connectors whose webhooks were registered on the main server are not
+// subscribed again.
 func (s *service) Subscribe(ctx context.Context) error {
 	for connectorType, connector := range s.connectors {
-		go func(connectorType backend.ConnectorType, connector domain.Connector) {
-			if err := connector.Subscribe(ctx, s.handleConnectorEvent); err != nil {
-				slog.Error("connector subscription failed", "connector_type", connectorType, "error", err)
-			}
-		}(connectorType, connector)
+		if !s.routedWebhooks[connectorType] {
+			go func(connectorType backend.ConnectorType, connector domain.Connector) {
+				if err := connector.Subscribe(ctx, s.handleConnectorEvent); err != nil {
+					slog.Error("connector subscription failed", "connector_type", connectorType, "error", err)
+				}
+			}(connectorType, connector)
+		}
+
+		if schedule, ok := s.syncSchedules[connectorType]; ok {
+			go s.runSyncSchedule(ctx, connectorType, schedule)
+		}
+	}
+
+	if s.syncJobs != nil {
+		go s.runSyncReaper(ctx)
 	}
 
 	return nil
@@ -336,10 +683,18 @@ func (s *service) Subscribe(ctx context.Context) error {
 func (s *service) handleConnectorEvent(ctx context.Context, event any) error {
 	switch e := event.(type) {
 	case github.WebhookEvent:
+		if repositoryEvent, ok := e.RepositoryEvent(); ok {
+			return s.dispatchRepositoryEvent(ctx, backend.ConnectorTypeGithub, e.InstallationID, repositoryEvent)
+		}
 		if connector, exists := s.connectors[backend.ConnectorTypeGithub]; exists {
 			return connector.ProcessEvent(ctx, e)
 		}
 		return fmt.Errorf("GitHub connector not found")
+	case azuredevops.WebhookEvent:
+		if connector, exists := s.connectors[backend.ConnectorTypeAzureDevOps]; exists {
+			return connector.ProcessEvent(ctx, e)
+		}
+		return fmt.Errorf("Azure DevOps connector not found")
 	default:
 		slog.Debug("received unknown event type", "event_type", fmt.Sprintf("%T", event))
 		return nil
@@ -0,0 +1,627 @@
+package integrationsvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domaintest"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/integrationtest"
+	"github.com/google/uuid"
+)
+
+type inMemoryFixture struct{}
+
+func (inMemoryFixture) Service(t *testing.T, connectors map[backend.ConnectorType]domain.Connector) backend.IntegrationService {
+	integrations := domaintest.NewIntegrationRepository()
+	credentials := domaintest.NewCredentialRepository(integrations)
+	return NewService(ServiceConfig{
+		IntegrationRepository:       integrations,
+		CredentialRepository:        credentials,
+		ActivityRepository:          domaintest.NewActivityRepository(),
+		IntegrationDataRepository:   domaintest.NewIntegrationDataRepository(integrations, credentials),
+		CredentialAccessRepository:  domaintest.NewCredentialAccessRepository(),
+		SyncJobRepository:           domaintest.NewSyncJobRepository(),
+		RepositoryTriggerRepository: domaintest.NewRepositoryTriggerRepository(),
+		Connectors:                  connectors,
+	})
+}
+
+func TestService(t *testing.T) {
+	integrationtest.Ensure(t, inMemoryFixture{})
+}
+
+type repositoryConnector struct {
+	domain.Connector
+	repositories []backend.SyncedRepository
+}
+
+func (c repositoryConnector) Repositories(ctx context.Context, integration backend.Integration) ([]backend.SyncedRepository, error) {
+	return c.repositories, nil
+}
+
+func TestIntegrationRepositories(t *testing.T) {
+	ctx := context.Background()
+
+	var repositories []backend.SyncedRepository
+	for i := range 5 {
+		repositories = append(repositories, backend.SyncedRepository{ID: int64(i), FullName: fmt.Sprintf("acme/service-%d", i)})
+	}
+	repositories = append(repositories, backend.SyncedRepository{ID: 99, FullName: "acme/Infra"})
+
+	integrations := domaintest.NewIntegrationRepository()
+	svc := NewService(ServiceConfig{
+		IntegrationRepository: integrations,
+		CredentialRepository:  domaintest.NewCredentialRepository(integrations),
+		Connectors: map[backend.ConnectorType]domain.Connector{
+			backend.ConnectorTypeGithub: repositoryConnector{repositories: repositories},
+			backend.ConnectorTypeSlack:  tokenConnector{},
+		},
+	})
+
+	orgID := uuid.New()
+	github := backend.Integration{ID: uuid.New(), OrganizationID: orgID, ConnectorType: backend.ConnectorTypeGithub}
+	slack := backend.Integration{ID: uuid.New(), OrganizationID: orgID, ConnectorType: backend.ConnectorTypeSlack}
+	for _, integration := range []backend.Integration{github, slack} {
+		if err := integrations.Store(ctx, integration); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		query     backend.IntegrationRepositoriesQuery
+		wantIDs   []int64
+		wantTotal int
+	}{
+		{
+			name:      "first page",
+			query:     backend.IntegrationRepositoriesQuery{Limit: 2},
+			wantIDs:   []int64{0, 1},
+			wantTotal: 6,
+		},
+		{
+			name:      "last page",
+			query:     backend.IntegrationRepositoriesQuery{Limit: 4, Offset: 4},
+			wantIDs:   []int64{4, 99},
+			wantTotal: 6,
+		},
+		{
+			name:      "offset past the end",
+			query:     backend.IntegrationRepositoriesQuery{Offset: 10},
+			wantTotal: 6,
+		},
+		{
+			name:      "name filter ignores case",
+			query:     backend.IntegrationRepositoriesQuery{Name: "infra"},
+			wantIDs:   []int64{99},
+			wantTotal: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.query.IntegrationID = github.ID
+			tt.query.OrganizationID = orgID
+
+			page, err := svc.IntegrationRepositories(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("IntegrationRepositories() error = %v", err)
+			}
+			if page.Total != tt.wantTotal {
+				t.Errorf("Total = %d, want %d", page.Total, tt.wantTotal)
+			}
+			var ids []int64
+			for _, repo := range page.Repositories {
+				ids = append(ids, repo.ID)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.wantIDs) {
+				t.Errorf("repository IDs = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+
+	t.Run("other organization", func(t *testing.T) {
+		_, err := svc.IntegrationRepositories(ctx, backend.IntegrationRepositoriesQuery{IntegrationID: github.ID, OrganizationID: uuid.New()})
+		if !errors.Is(err, backend.ErrIntegrationNotFound) {
+			t.Errorf("IntegrationRepositories() error = %v, want %v", err, backend.ErrIntegrationNotFound)
+		}
+	})
+
+	t.Run("connector without repositories", func(t *testing.T) {
+		_, err := svc.IntegrationRepositories(ctx, backend.IntegrationRepositoriesQuery{IntegrationID: slack.ID, OrganizationID: orgID})
+		if !errors.Is(err, backend.ErrUnsupportedConnector) {
+			t.Errorf("IntegrationRepositories() error = %v, want %v", err, backend.ErrUnsupportedConnector)
+		}
+	})
+}
+
+type syncingConnector struct {
+	domain.Connector
+	result backend.SyncResult
+	err    error
+}
+
+func (c syncingConnector) Sync(ctx context.Context, integration backend.Integration, params map[string]string) (backend.SyncResult, error) {
+	return c.result, c.err
+}
+
+func (c syncingConnector) SyncStatus(ctx context.Context, integration backend.Integration) (backend.IntegrationSyncStatus, error) {
+	count := 3
+	return backend.IntegrationSyncStatus{RepositoryCount: &count}, nil
+}
+
+func TestIntegrationActivity(t *testing.T) {
+	ctx := context.Background()
+
+	activity := domaintest.NewActivityRepository()
+	integrations := recordingIntegrationRepository{domaintest.NewIntegrationRepository(), activity}
+	connector := &syncingConnector{result: backend.SyncResult{Added: 2, Updated: 1}}
+	svc := NewService(ServiceConfig{
+		IntegrationRepository: integrations,
+		CredentialRepository:  domaintest.NewCredentialRepository(integrations),
+		ActivityRepository:    activity,
+		Connectors: map[backend.ConnectorType]domain.Connector{
+			backend.ConnectorTypeGithub: connector,
+		},
+	})
+
+	orgID := uuid.New()
+	integration := backend.Integration{ID: uuid.New(), OrganizationID: orgID, ConnectorType: backend.ConnectorTypeGithub, Status: backend.IntegrationStatusActive}
+	if err := integrations.Store(ctx, integration); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	sync := backend.SyncIntegrationCommand{IntegrationID: integration.ID, OrganizationID: orgID}
+	if err := svc.SyncIntegration(ctx, sync); err != nil {
+		t.Fatalf("SyncIntegration() error = %v", err)
+	}
+	connector.err = errors.New("rate limited")
+	if err := svc.SyncIntegration(ctx, sync); err == nil {
+		t.Fatal("SyncIntegration() error = nil, want the connector's error")
+	}
+	if err := integrations.UpdateStatus(ctx, integration.ID, backend.IntegrationStatusSuspended); err != nil {
+		t.Fatalf("UpdateStatus() error = %v", err)
+	}
+
+	page, err := svc.IntegrationActivity(ctx, backend.IntegrationActivityQuery{IntegrationID: integration.ID, OrganizationID: orgID})
+	if err != nil {
+		t.Fatalf("IntegrationActivity() error = %v", err)
+	}
+
+	var got []backend.IntegrationActivityType
+	for _, entry := range page.Activities {
+		got = append(got, entry.Type)
+	}
+	want := []backend.IntegrationActivityType{
+		backend.IntegrationActivityStatusChanged,
+		backend.IntegrationActivitySyncFailed,
+		backend.IntegrationActivitySyncStarted,
+		backend.IntegrationActivitySyncCompleted,
+		backend.IntegrationActivitySyncStarted,
+	}
+	if !slices.Equal(got, want) {
+		t.Fatalf("IntegrationActivity() types = %v, want %v", got, want)
+	}
+	if details := page.Activities[0].Details; details["from"] != "active" || details["to"] != "suspended" {
+		t.Errorf("status_changed details = %v, want active to suspended", details)
+	}
+	if details := page.Activities[3].Details; details["repository_count"] != "3" || details["added"] != "2" ||
+		details["updated"] != "1" || details["removed"] != "0" {
+		t.Errorf("sync_completed details = %v, want repository_count 3 and the sync result", details)
+	}
+
+	t.Run("other organization", func(t *testing.T) {
+		_, err := svc.IntegrationActivity(ctx, backend.IntegrationActivityQuery{IntegrationID: integration.ID, OrganizationID: uuid.New()})
+		if !errors.Is(err, backend.ErrIntegrationNotFound) {
+			t.Errorf("IntegrationActivity() error = %v, want ErrIntegrationNotFound", err)
+		}
+	})
+}
+
+func TestRevokedIntegrationActivity(t *testing.T) {
+	ctx := context.Background()
+
+	var revokeErr error
+	var revoked []string
+	activity := domaintest.NewActivityRepository()
+	integrations := recordingIntegrationRepository{domaintest.NewIntegrationRepository(), activity}
+	credentials := domaintest.NewCredentialRepository(integrations)
+	svc := NewService(ServiceConfig{
+		IntegrationRepository: integrations,
+		CredentialRepository:  credentials,
+		ActivityRepository:    activity,
+		Connectors: map[backend.ConnectorType]domain.Connector{
+			backend.ConnectorTypeGithub: revokeConnector{err: &revokeErr, revoked: &revoked},
+		},
+	})
+
+	orgID := uuid.New()
+	integration := backend.Integration{
+		ID:             uuid.New(),
+		OrganizationID: orgID,
+		ConnectorType:  backend.ConnectorTypeGithub,
+		Status:         backend.IntegrationStatusActive,
+		Metadata:       map[string]string{"permission_contents": "read", "permission_issues": "write", "account": "acme"},
+	}
+	if err := integrations.Store(ctx, integration); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	if err := credentials.Store(ctx, domain.IntegrationCredential{IntegrationID: integration.ID, Data: map[string]string{"token": "t"}}); err != nil {
+		t.Fatalf("Store() credential error = %v", err)
+	}
+
+	err := integrations.UpdateMetadata(ctx, integration.ID, map[string]string{"permission_contents": "write", "permission_checks": "read", "account": "acme-inc"})
+	if err != nil {
+		t.Fatalf("UpdateMetadata() error = %v", err)
+	}
+	if err := svc.RevokeIntegration(ctx, backend.RevokeIntegrationCommand{IntegrationID: integration.ID, OrganizationID: orgID}); err != nil {
+		t.Fatalf("RevokeIntegration() error = %v", err)
+	}
+
+	page, err := svc.IntegrationActivity(ctx, backend.IntegrationActivityQuery{IntegrationID: integration.ID, OrganizationID: orgID})
+	if err != nil {
+		t.Fatalf("IntegrationActivity() of a revoked integration error = %v", err)
+	}
+	if len(page.Activities) != 2 || page.Activities[0].Type != backend.IntegrationActivityRevoked ||
+		page.Activities[1].Type != backend.IntegrationActivityPermissionsChanged {
+		t.Fatalf("IntegrationActivity() = %+v, want revoked after permissions_changed", page.Activities)
+	}
+	want := map[string]string{"contents": "write", "checks": "read", "issues": "none"}
+	if got := page.Activities[1].Details; !maps.Equal(got, want) {
+		t.Errorf("permissions_changed details = %v, want %v", got, want)
+	}
+
+	_, err = svc.IntegrationActivity(ctx, backend.IntegrationActivityQuery{IntegrationID: integration.ID, OrganizationID: uuid.New()})
+	if !errors.Is(err, backend.ErrIntegrationNotFound) {
+		t.Errorf("IntegrationActivity() from another organization error = %v, want ErrIntegrationNotFound", err)
+	}
+}
+
+type countingConnector struct {
+	domain.Connector
+	mu      sync.Mutex
+	synced  []uuid.UUID
+	release chan struct{}
+}
+
+func (c *countingConnector) Sync(ctx context.Context, integration backend.Integration, params map[string]string) (backend.SyncResult, error) {
+	if c.release != nil {
+		<-c.release
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.synced = append(c.synced, integration.ID)
+	return backend.SyncResult{}, nil
+}
+
+func TestSyncDue(t *testing.T) {
+	ctx := context.Background()
+
+	integrations := domaintest.NewIntegrationRepository()
+	connector := &countingConnector{}
+	svc := NewService(ServiceConfig{
+		IntegrationRepository: integrations,
+		CredentialRepository:  domaintest.NewCredentialRepository(integrations),
+		ActivityRepository:    domaintest.NewActivityRepository(),
+		Connectors: map[backend.ConnectorType]domain.Connector{
+			backend.ConnectorTypeGithub: connector,
+		},
+	}).(*service)
+
+	stale := backend.Integration{ID: uuid.New(), OrganizationID: uuid.New(), ConnectorType: backend.ConnectorTypeGithub, Status: backend.IntegrationStatusActive}
+	fresh := backend.Integration{ID: uuid.New(), OrganizationID: uuid.New(), ConnectorType: backend.ConnectorTypeGithub, Status: backend.IntegrationStatusActive}
+	suspended := backend.Integration{ID: uuid.New(), OrganizationID: uuid.New(), ConnectorType: backend.ConnectorTypeGithub, Status: backend.IntegrationStatusSuspended}
+	for _, integration := range []backend.Integration{stale, fresh, suspended} {
+		if err := integrations.Store(ctx, integration); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+	}
+	if err := integrations.UpdateLastSynced(ctx, fresh.ID, time.Now()); err != nil {
+		t.Fatalf("UpdateLastSynced() error = %v", err)
+	}
+
+	svc.syncDue(ctx, backend.ConnectorTypeGithub, time.Hour)
+
+	if !slices.Equal(connector.synced, []uuid.UUID{stale.ID}) {
+		t.Fatalf("synced = %v, want only %s", connector.synced, stale.ID)
+	}
+	got, err := integrations.FindByID(ctx, stale.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if got.LastSyncedAt == nil {
+		t.Error("LastSyncedAt = nil, want the scheduled sync's time")
+	}
+
+	t.Run("skips an integration that is already syncing", func(t *testing.T) {
+		connector.release = make(chan struct{})
+		cmd := backend.SyncIntegrationCommand{IntegrationID: fresh.ID, OrganizationID: fresh.OrganizationID}
+
+		done := make(chan error)
+		go func() { done <- svc.SyncIntegration(ctx, cmd) }()
+		for !svc.syncing.running(fresh.ID) {
+			time.Sleep(time.Millisecond)
+		}
+
+		if err := svc.SyncIntegration(ctx, cmd); !errors.Is(err, domain.ErrSyncInProgress) {
+			t.Errorf("SyncIntegration() while syncing error = %v, want %v", err, domain.ErrSyncInProgress)
+		}
+		close(connector.release)
+		if err := <-done; err != nil {
+			t.Errorf("SyncIntegration() error = %v", err)
+		}
+	})
+
+	t.Run("syncs an integration on one replica", func(t *testing.T) {
+		due := backend.Integration{ID: uuid.New(), OrganizationID: uuid.New(), ConnectorType: backend.ConnectorTypeGithub, Status: backend.IntegrationStatusActive}
+		if err := integrations.Store(ctx, due); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+		replica := &countingConnector{}
+		other := NewService(ServiceConfig{
+			IntegrationRepository: integrations,
+			CredentialRepository:  domaintest.NewCredentialRepository(integrations),
+			ActivityRepository:    domaintest.NewActivityRepository(),
+			Connectors: map[backend.ConnectorType]domain.Connector{
+				backend.ConnectorTypeGithub: replica,
+			},
+		}).(*service)
+		connector.synced = nil
+		connector.release = nil
+
+		svc.syncDue(ctx, backend.ConnectorTypeGithub, time.Hour)
+		if err := integrations.UpdateLastSynced(ctx, due.ID, time.Time{}); err != nil {
+			t.Fatalf("UpdateLastSynced() error = %v", err)
+		}
+		other.syncDue(ctx, backend.ConnectorTypeGithub, time.Hour)
+
+		if !slices.Equal(connector.synced, []uuid.UUID{due.ID}) || len(replica.synced) != 0 {
+			t.Errorf("synced = %v on the first replica and %v on the second, want %s once", connector.synced, replica.synced, due.ID)
+		}
+	})
+}
+
+func TestReapLostSyncs(t *testing.T) {
+	ctx := context.Background()
+
+	integrations := domaintest.NewIntegrationRepository()
+	syncJobs := domaintest.NewSyncJobRepository()
+	connector := &countingConnector{}
+	svc := NewService(ServiceConfig{
+		IntegrationRepository: integrations,
+		CredentialRepository:  domaintest.NewCredentialRepository(integrations),
+		ActivityRepository:    domaintest.NewActivityRepository(),
+		SyncJobRepository:     syncJobs,
+		SyncJobPolicy:         SyncConfig{RescheduleLost: true, MaxAttempts: 2}.jobPolicy(),
+		Connectors: map[backend.ConnectorType]domain.Connector{
+			backend.ConnectorTypeGithub: connector,
+		},
+	}).(*service)
+
+	integration := backend.Integration{ID: uuid.New(), OrganizationID: uuid.New(), ConnectorType: backend.ConnectorTypeGithub, Status: backend.IntegrationStatusActive}
+	if err := integrations.Store(ctx, integration); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+	startLostJob := func(attempt int) {
+		t.Helper()
+		lostAt := time.Now().Add(-time.Hour)
+		err := syncJobs.Start(ctx, domain.SyncJob{
+			ID:            uuid.New(),
+			IntegrationID: integration.ID,
+			Status:        domain.SyncJobStatusRunning,
+			Attempt:       attempt,
+			StartedAt:     lostAt,
+			HeartbeatAt:   lostAt,
+		})
+		if err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+	}
+	health := func() backend.IntegrationSyncHealth {
+		t.Helper()
+		status, err := svc.IntegrationSyncStatus(ctx, backend.IntegrationQuery{IntegrationID: integration.ID, OrganizationID: integration.OrganizationID})
+		if err != nil {
+			t.Fatalf("IntegrationSyncStatus() error = %v", err)
+		}
+		if status.Health == nil {
+			t.Fatal("IntegrationSyncStatus().Health = nil")
+		}
+		return *status.Health
+	}
+
+	startLostJob(2)
+	svc.reapLostSyncs(ctx)
+
+	if len(connector.synced) != 0 {
+		t.Fatalf("synced = %v, want a lost last attempt not rescheduled", connector.synced)
+	}
+	if got := health(); got.ConsecutiveFailures != 1 || got.LastSuccessfulSyncAt != nil || got.Running {
+		t.Errorf("health after the lost sync = %+v, want one failure and nothing running", got)
+	}
+
+	startLostJob(1)
+	svc.reapLostSyncs(ctx)
+
+	if !slices.Equal(connector.synced, []uuid.UUID{integration.ID}) {
+		t.Fatalf("synced = %v, want the lost sync rescheduled once", connector.synced)
+	}
+	if got := health(); got.ConsecutiveFailures != 0 || got.LastSuccessfulSyncAt == nil || got.Running {
+		t.Errorf("health after the rescheduled sync = %+v, want a success and no failures", got)
+	}
+}
+
+// revokeConnector records revocations and fails while err is set.
+type revokeConnector struct {
+	domain.Connector
+	err     *error
+	revoked *[]string
+}
+
+func (c revokeConnector) RevokeCredentials(creds backend.Credentials) error {
+	if *c.err != nil {
+		return *c.err
+	}
+	*c.revoked = append(*c.revoked, creds.Data["token"])
+	return nil
+}
+
+func TestDeleteOrganizationData(t *testing.T) {
+	ctx := context.Background()
+
+	var githubErr, slackErr error
+	var revoked []string
+	integrations := domaintest.NewIntegrationRepository()
+	credentials := domaintest.NewCredentialRepository(integrations)
+	svc := NewService(ServiceConfig{
+		IntegrationRepository:       integrations,
+		CredentialRepository:        credentials,
+		IntegrationDataRepository:   domaintest.NewIntegrationDataRepository(integrations, credentials),
+		RepositoryTriggerRepository: domaintest.NewRepositoryTriggerRepository(),
+		Connectors: map[backend.ConnectorType]domain.Connector{
+			backend.ConnectorTypeGithub: revokeConnector{err: &githubErr, revoked: &revoked},
+			backend.ConnectorTypeSlack:  revokeConnector{err: &slackErr, revoked: &revoked},
+		},
+	})
+
+	orgID := uuid.New()
+	other := backend.Integration{ID: uuid.New(), OrganizationID: uuid.New(), ConnectorType: backend.ConnectorTypeGithub}
+	github := backend.Integration{ID: uuid.New(), OrganizationID: orgID, ConnectorType: backend.ConnectorTypeGithub}
+	slack := backend.Integration{ID: uuid.New(), OrganizationID: orgID, ConnectorType: backend.ConnectorTypeSlack}
+	for _, integration := range []backend.Integration{other, github, slack} {
+		if err := integrations.Store(ctx, integration); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+		err := credentials.Store(ctx, domain.IntegrationCredential{
+			IntegrationID: integration.ID,
+			Data:          map[string]string{"token": string(integration.ConnectorType) + "-" + integration.ID.String()},
+		})
+		if err != nil {
+			t.Fatalf("Store() credential error = %v", err)
+		}
+	}
+
+	count := func(resources []backend.DeletedResource, resourceType string) int {
+		for _, resource := range resources {
+			if resource.Type == resourceType {
+				return resource.Count
+			}
+		}
+		return -1
+	}
+	remaining := func() int {
+		found, err := integrations.FindByOrganization(ctx, orgID)
+		if err != nil {
+			t.Fatalf("FindByOrganization() error = %v", err)
+		}
+		return len(found)
+	}
+
+	resources, err := svc.DeleteOrganizationData(ctx, backend.DeleteOrganizationCommand{OrganizationID: orgID, DryRun: true})
+	if err != nil {
+		t.Fatalf("DeleteOrganizationData(dry run) error = %v", err)
+	}
+	if got := count(resources, "integrations"); got != 2 {
+		t.Errorf("dry run integrations = %d, want 2", got)
+	}
+	if got := count(resources, "integration_credentials"); got != 2 {
+		t.Errorf("dry run credentials = %d, want 2", got)
+	}
+	if remaining() != 2 || len(revoked) != 0 {
+		t.Fatalf("dry run changed state: %d integrations left, revoked %v", remaining(), revoked)
+	}
+
+	githubErr = errors.New("github unavailable")
+	resources, err = svc.DeleteOrganizationData(ctx, backend.DeleteOrganizationCommand{OrganizationID: orgID})
+	if err == nil {
+		t.Fatal("DeleteOrganizationData() error = nil, want the failed GitHub revocation")
+	}
+	if got := count(resources, "integrations"); got != 1 {
+		t.Errorf("integrations deleted = %d, want 1", got)
+	}
+	if _, err := credentials.FindByIntegration(ctx, github.ID); err != nil {
+		t.Errorf("GitHub credentials deleted despite failed revocation: %v", err)
+	}
+
+	githubErr = nil
+	if _, err := svc.DeleteOrganizationData(ctx, backend.DeleteOrganizationCommand{OrganizationID: orgID}); err != nil {
+		t.Fatalf("DeleteOrganizationData() resume error = %v", err)
+	}
+	if remaining() != 0 {
+		t.Errorf("%d integrations left after resuming", remaining())
+	}
+	if len(revoked) != 2 {
+		t.Errorf("revoked = %v, want the Slack and GitHub credentials once each", revoked)
+	}
+	if _, err := integrations.FindByID(ctx, other.ID); err != nil {
+		t.Errorf("other organization's integration deleted: %v", err)
+	}
+}
+
+func TestIntegrationsReadReplica(t *testing.T) {
+	ctx := context.Background()
+	orgID := uuid.New()
+	primary := domaintest.NewIntegrationRepository()
+	replica := domaintest.NewIntegrationRepository()
+
+	// The replica lags behind: it only has the integration written before the
+	// newest one.
+	stored := backend.Integration{ID: uuid.New(), OrganizationID: orgID, ConnectorType: backend.ConnectorTypeGithub, Status: backend.IntegrationStatusActive}
+	latest := backend.Integration{ID: uuid.New(), OrganizationID: orgID, ConnectorType: backend.ConnectorTypeSlack, Status: backend.IntegrationStatusActive}
+	for _, integration := range []backend.Integration{stored, latest} {
+		if err := primary.Store(ctx, integration); err != nil {
+			t.Fatalf("Store() error = %v", err)
+		}
+	}
+	if err := replica.Store(ctx, stored); err != nil {
+		t.Fatalf("Store() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		replica domain.IntegrationRepository
+		wantIDs []uuid.UUID
+	}{
+		{name: "reads from the replica", replica: replica, wantIDs: []uuid.UUID{stored.ID}},
+		{name: "reads from the primary without a replica", wantIDs: []uuid.UUID{stored.ID, latest.ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewService(ServiceConfig{
+				IntegrationRepository:     primary,
+				ReadIntegrationRepository: tt.replica,
+				CredentialRepository:      domaintest.NewCredentialRepository(primary),
+			})
+
+			integrations, err := svc.Integrations(ctx, backend.IntegrationsQuery{OrganizationID: orgID})
+			if err != nil {
+				t.Fatalf("Integrations() error = %v", err)
+			}
+			var gotIDs []uuid.UUID
+			for _, integration := range integrations {
+				gotIDs = append(gotIDs, integration.ID)
+			}
+			if !sameIDs(gotIDs, tt.wantIDs) {
+				t.Errorf("Integrations() = %v, want %v", gotIDs, tt.wantIDs)
+			}
+
+			integration, err := svc.Integration(ctx, backend.IntegrationQuery{IntegrationID: latest.ID, OrganizationID: orgID})
+			if err != nil || integration.ID != latest.ID {
+				t.Errorf("Integration() = %v, %v, want the latest integration read from the primary", integration.ID, err)
+			}
+		})
+	}
+}
+
+func sameIDs(got, want []uuid.UUID) bool {
+	sortIDs := func(ids []uuid.UUID) []uuid.UUID {
+		return slices.SortedFunc(slices.Values(ids), func(a, b uuid.UUID) int { return slices.Compare(a[:], b[:]) })
+	}
+	return slices.Equal(sortIDs(got), sortIDs(want))
+}
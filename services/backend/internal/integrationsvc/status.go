@@ -0,0 +1,88 @@
+package integrationsvc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+// StatusNotifier fans integration status changes out to listeners added after
+// the integration service is built, so services that both list integrations
+// and react to their changes can be wired without a construction cycle.
+type StatusNotifier struct {
+	mu        sync.RWMutex
+	listeners []backend.IntegrationStatusListener
+}
+
+func (n *StatusNotifier) Listen(listener backend.IntegrationStatusListener) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.listeners = append(n.listeners, listener)
+}
+
+func (n *StatusNotifier) IntegrationStatusChanged(ctx context.Context, integration backend.Integration) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	for _, listener := range n.listeners {
+		listener.IntegrationStatusChanged(ctx, integration)
+	}
+}
+
+// notifyingIntegrationRepository reports status changes made through any path,
+// including connectors handling webhooks, to listener.
+type notifyingIntegrationRepository struct {
+	domain.IntegrationRepository
+	listener backend.IntegrationStatusListener
+}
+
+func (r notifyingIntegrationRepository) Store(ctx context.Context, integration backend.Integration) error {
+	if err := r.IntegrationRepository.Store(ctx, integration); err != nil {
+		return err
+	}
+	r.listener.IntegrationStatusChanged(ctx, integration)
+	return nil
+}
+
+func (r notifyingIntegrationRepository) Update(ctx context.Context, integration backend.Integration) error {
+	before, findErr := r.IntegrationRepository.FindByID(ctx, integration.ID)
+	if err := r.IntegrationRepository.Update(ctx, integration); err != nil {
+		return err
+	}
+	if findErr == nil && before.Status != integration.Status {
+		r.notify(ctx, integration.ID)
+	}
+	return nil
+}
+
+func (r notifyingIntegrationRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status backend.IntegrationStatus) error {
+	if err := r.IntegrationRepository.UpdateStatus(ctx, id, status); err != nil {
+		return err
+	}
+	r.notify(ctx, id)
+	return nil
+}
+
+func (r notifyingIntegrationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	before, findErr := r.IntegrationRepository.FindByID(ctx, id)
+	if err := r.IntegrationRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+	if findErr == nil {
+		before.Status = backend.IntegrationStatusDeleted
+		r.listener.IntegrationStatusChanged(ctx, before)
+	}
+	return nil
+}
+
+func (r notifyingIntegrationRepository) notify(ctx context.Context, id uuid.UUID) {
+	integration, err := r.IntegrationRepository.FindByID(ctx, id)
+	if err != nil {
+		return
+	}
+	r.listener.IntegrationStatusChanged(ctx, integration)
+}
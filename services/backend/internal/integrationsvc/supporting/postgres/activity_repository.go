@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+)
+
+// openActivityRangeEnd stands in for an unbounded Until so the range stays a
+// single indexed query.
+var openActivityRangeEnd = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+type activityRepository struct {
+	queries *Queries
+}
+
+func NewActivityRepository(sqlDB *sql.DB) domain.ActivityRepository {
+	return &activityRepository{
+		queries: New(pgretry.Wrap(sqlDB)),
+	}
+}
+
+func (r *activityRepository) RecordActivity(ctx context.Context, activity backend.IntegrationActivity) error {
+	details, err := json.Marshal(activity.Details)
+	if err != nil {
+		return fmt.Errorf("failed to marshal activity details: %w", err)
+	}
+	if activity.Details == nil {
+		details = []byte("{}")
+	}
+
+	err = r.queries.StoreIntegrationActivity(ctx, StoreIntegrationActivityParams{
+		ID:             activity.ID,
+		IntegrationID:  activity.IntegrationID,
+		OrganizationID: activity.OrganizationID,
+		ActivityType:   string(activity.Type),
+		Details:        details,
+		CreatedAt:      activity.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store integration activity: %w", err)
+	}
+	return nil
+}
+
+func (r *activityRepository) Activities(ctx context.Context, query backend.IntegrationActivityQuery) (backend.IntegrationActivityPage, error) {
+	until := query.Until
+	if until.IsZero() {
+		until = openActivityRangeEnd
+	}
+
+	rows, err := r.queries.ListIntegrationActivity(ctx, ListIntegrationActivityParams{
+		IntegrationID:  query.IntegrationID,
+		OrganizationID: query.OrganizationID,
+		CreatedAt:      query.Since,
+		CreatedAt_2:    until,
+		Limit:          int32(query.Limit),
+		Offset:         int32(query.Offset),
+	})
+	if err != nil {
+		return backend.IntegrationActivityPage{}, fmt.Errorf("failed to list integration activity: %w", err)
+	}
+
+	total, err := r.queries.CountIntegrationActivity(ctx, CountIntegrationActivityParams{
+		IntegrationID:  query.IntegrationID,
+		OrganizationID: query.OrganizationID,
+		CreatedAt:      query.Since,
+		CreatedAt_2:    until,
+	})
+	if err != nil {
+		return backend.IntegrationActivityPage{}, fmt.Errorf("failed to count integration activity: %w", err)
+	}
+
+	activities := make([]backend.IntegrationActivity, 0, len(rows))
+	for _, row := range rows {
+		var details map[string]string
+		if err := json.Unmarshal(row.Details, &details); err != nil {
+			return backend.IntegrationActivityPage{}, fmt.Errorf("failed to unmarshal activity details: %w", err)
+		}
+		activities = append(activities, backend.IntegrationActivity{
+			ID:             row.ID,
+			IntegrationID:  row.IntegrationID,
+			OrganizationID: row.OrganizationID,
+			Type:           backend.IntegrationActivityType(row.ActivityType),
+			Details:        details,
+			CreatedAt:      row.CreatedAt,
+		})
+	}
+
+	return backend.IntegrationActivityPage{Activities: activities, Total: int(total)}, nil
+}
@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/azuredevops"
+	"github.com/google/uuid"
+)
+
+type azureDevOpsRepositoryRepository struct {
+	queries *Queries
+}
+
+func NewAzureDevOpsRepositoryRepository(db *sql.DB) azuredevops.AzureDevOpsRepositoryRepository {
+	return &azureDevOpsRepositoryRepository{queries: New(pgretry.Wrap(db))}
+}
+
+func (r *azureDevOpsRepositoryRepository) Store(ctx context.Context, repo azuredevops.AzureDevOpsRepository) error {
+	err := r.queries.UpsertAzureDevOpsRepository(ctx, UpsertAzureDevOpsRepositoryParams{
+		IntegrationID:      repo.IntegrationID,
+		AzureRepositoryID:  repo.RepositoryID,
+		ProjectID:          repo.ProjectID,
+		ProjectName:        repo.ProjectName,
+		RepositoryName:     repo.RepositoryName,
+		RepositoryFullName: repo.RepositoryFullName,
+		RepositoryUrl:      repo.RepositoryURL,
+		IsPrivate:          repo.IsPrivate,
+		DefaultBranch:      repo.DefaultBranch,
+		CreatedAt:          repo.CreatedAt,
+		UpdatedAt:          repo.UpdatedAt,
+		LastSyncedAt:       repo.LastSyncedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upsert azure devops repository: %w", err)
+	}
+
+	return nil
+}
+
+func (r *azureDevOpsRepositoryRepository) ListByIntegrationID(ctx context.Context, integrationID uuid.UUID) ([]azuredevops.AzureDevOpsRepository, error) {
+	dbRepos, err := r.queries.FindAzureDevOpsRepositoriesByIntegrationID(ctx, integrationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list azure devops repositories: %w", err)
+	}
+
+	var repositories []azuredevops.AzureDevOpsRepository
+	for _, dbRepo := range dbRepos {
+		repositories = append(repositories, azuredevops.AzureDevOpsRepository{
+			ID:                 dbRepo.ID,
+			IntegrationID:      dbRepo.IntegrationID,
+			RepositoryID:       dbRepo.AzureRepositoryID,
+			ProjectID:          dbRepo.ProjectID,
+			ProjectName:        dbRepo.ProjectName,
+			RepositoryName:     dbRepo.RepositoryName,
+			RepositoryFullName: dbRepo.RepositoryFullName,
+			RepositoryURL:      dbRepo.RepositoryUrl,
+			IsPrivate:          dbRepo.IsPrivate,
+			DefaultBranch:      dbRepo.DefaultBranch,
+			CreatedAt:          dbRepo.CreatedAt,
+			UpdatedAt:          dbRepo.UpdatedAt,
+			LastSyncedAt:       dbRepo.LastSyncedAt,
+			Enabled:            dbRepo.Enabled,
+		})
+	}
+
+	return repositories, nil
+}
+
+func (r *azureDevOpsRepositoryRepository) SetEnabled(ctx context.Context, integrationID uuid.UUID, ids []int64, enabled bool) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	updated, err := r.queries.SetAzureDevOpsRepositoriesEnabled(ctx, SetAzureDevOpsRepositoriesEnabledParams{
+		Enabled:       enabled,
+		IntegrationID: integrationID,
+		Column3:       ids,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to set azure devops repositories enabled: %w", err)
+	}
+
+	return int(updated), nil
+}
+
+func (r *azureDevOpsRepositoryRepository) BulkDelete(ctx context.Context, integrationID uuid.UUID, repositoryIDs []uuid.UUID) error {
+	if len(repositoryIDs) == 0 {
+		return nil
+	}
+
+	err := r.queries.BulkDeleteAzureDevOpsRepositories(ctx, BulkDeleteAzureDevOpsRepositoriesParams{
+		IntegrationID: integrationID,
+		Column2:       repositoryIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to bulk delete azure devops repositories: %w", err)
+	}
+
+	return nil
+}
+
+func (r *azureDevOpsRepositoryRepository) UpdateLastSyncTime(ctx context.Context, integrationID uuid.UUID, syncTime time.Time) error {
+	err := r.queries.UpdateAzureDevOpsRepositoryLastSyncTime(ctx, UpdateAzureDevOpsRepositoryLastSyncTimeParams{
+		LastSyncedAt:  syncTime,
+		IntegrationID: integrationID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update last sync time: %w", err)
+	}
+
+	return nil
+}
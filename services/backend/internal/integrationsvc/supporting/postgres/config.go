@@ -3,6 +3,7 @@ package postgres
 import (
 	"database/sql"
 
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
 	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
 )
 
@@ -26,7 +27,7 @@ type IntegrationDB struct {
 func NewIntegrationDB(db *sql.DB) *IntegrationDB {
 	return &IntegrationDB{
 		db:      db,
-		Queries: New(db),
+		Queries: New(pgretry.Wrap(db)),
 	}
 }
 
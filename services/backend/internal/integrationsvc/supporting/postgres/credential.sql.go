@@ -23,6 +23,18 @@ func (q *Queries) DeleteCredential(ctx context.Context, integrationID uuid.UUID)
 	return err
 }
 
+const deleteCredentialsByIntegration = `-- name: DeleteCredentialsByIntegration :execrows
+DELETE FROM integration_credentials WHERE integration_id = $1
+`
+
+func (q *Queries) DeleteCredentialsByIntegration(ctx context.Context, integrationID uuid.UUID) (int64, error) {
+	result, err := q.exec(ctx, q.deleteCredentialsByIntegrationStmt, deleteCredentialsByIntegration, integrationID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const findCredentialByIntegration = `-- name: FindCredentialByIntegration :one
 SELECT id, integration_id, credential_type, credential_data_encrypted,
        expires_at, encryption_key_id, created_at, updated_at
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+type credentialAccessRepository struct {
+	queries *Queries
+}
+
+func NewCredentialAccessRepository(sqlDB *sql.DB) domain.CredentialAccessRepository {
+	return &credentialAccessRepository{
+		queries: New(pgretry.Wrap(sqlDB)),
+	}
+}
+
+func (r *credentialAccessRepository) RecordAccess(ctx context.Context, access backend.CredentialAccess) error {
+	fields := access.Fields
+	if fields == nil {
+		fields = []string{}
+	}
+
+	err := r.queries.StoreCredentialAccess(ctx, StoreCredentialAccessParams{
+		ID:             access.ID,
+		IntegrationID:  access.IntegrationID,
+		OrganizationID: access.OrganizationID,
+		AccessorType:   string(access.Accessor.Type),
+		AccessorID:     access.Accessor.ID,
+		UserID:         access.Accessor.UserID,
+		IpAddress:      access.Accessor.IP,
+		Reason:         access.Accessor.Reason,
+		Fields:         fields,
+		AccessedAt:     access.AccessedAt,
+		ConversationID: access.Accessor.ConversationID,
+		ToolCallID:     access.Accessor.ToolCallID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store credential access: %w", err)
+	}
+	return nil
+}
+
+func (r *credentialAccessRepository) Accesses(ctx context.Context, query backend.CredentialAccessQuery) (backend.CredentialAccessPage, error) {
+	until := query.Until
+	if until.IsZero() {
+		until = openActivityRangeEnd
+	}
+
+	rows, err := r.queries.ListCredentialAccess(ctx, ListCredentialAccessParams{
+		IntegrationID: query.IntegrationID,
+		AccessedAt:    query.Since,
+		AccessedAt_2:  until,
+		Limit:         int32(query.Limit),
+		Offset:        int32(query.Offset),
+	})
+	if err != nil {
+		return backend.CredentialAccessPage{}, fmt.Errorf("failed to list credential access: %w", err)
+	}
+
+	total, err := r.queries.CountCredentialAccess(ctx, CountCredentialAccessParams{
+		IntegrationID: query.IntegrationID,
+		AccessedAt:    query.Since,
+		AccessedAt_2:  until,
+	})
+	if err != nil {
+		return backend.CredentialAccessPage{}, fmt.Errorf("failed to count credential access: %w", err)
+	}
+
+	accesses := make([]backend.CredentialAccess, 0, len(rows))
+	for _, row := range rows {
+		accesses = append(accesses, backend.CredentialAccess{
+			ID:             row.ID,
+			IntegrationID:  row.IntegrationID,
+			OrganizationID: row.OrganizationID,
+			Accessor: backend.CredentialAccessor{
+				Type:           backend.CredentialAccessorType(row.AccessorType),
+				ID:             row.AccessorID,
+				UserID:         row.UserID,
+				IP:             row.IpAddress,
+				Reason:         row.Reason,
+				ConversationID: row.ConversationID,
+				ToolCallID:     row.ToolCallID,
+			},
+			Fields:     row.Fields,
+			AccessedAt: row.AccessedAt,
+		})
+	}
+
+	return backend.CredentialAccessPage{Accesses: accesses, Total: int(total)}, nil
+}
+
+func (r *credentialAccessRepository) CountAccessesSince(ctx context.Context, integrationID uuid.UUID, since time.Time) (int, error) {
+	count, err := r.queries.CountCredentialAccess(ctx, CountCredentialAccessParams{
+		IntegrationID: integrationID,
+		AccessedAt:    since,
+		AccessedAt_2:  openActivityRangeEnd,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count credential access: %w", err)
+	}
+	return int(count), nil
+}
@@ -3,10 +3,12 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
 	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
 	"github.com/google/uuid"
 )
@@ -23,7 +25,7 @@ func NewCredentialRepository(sqlDB *sql.DB) (domain.CredentialRepository, error)
 	}
 
 	return &credentialRepository{
-		queries:    New(sqlDB),
+		queries:    New(pgretry.Wrap(sqlDB)),
 		encryption: encryption,
 	}, nil
 }
@@ -56,6 +58,9 @@ func (r *credentialRepository) Store(ctx context.Context, cred domain.Integratio
 
 func (r *credentialRepository) FindByIntegration(ctx context.Context, integrationID uuid.UUID) (domain.IntegrationCredential, error) {
 	dbCredential, err := r.queries.FindCredentialByIntegration(ctx, integrationID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.IntegrationCredential{}, domain.ErrCredentialNotFound
+	}
 	if err != nil {
 		return domain.IntegrationCredential{}, fmt.Errorf("failed to find credential: %w", err)
 	}
@@ -24,18 +24,66 @@ func New(db DBTX) *Queries {
 func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	q := Queries{db: db}
 	var err error
+	if q.bulkDeleteAzureDevOpsRepositoriesStmt, err = db.PrepareContext(ctx, bulkDeleteAzureDevOpsRepositories); err != nil {
+		return nil, fmt.Errorf("error preparing query BulkDeleteAzureDevOpsRepositories: %w", err)
+	}
 	if q.bulkDeleteGitHubRepositoriesStmt, err = db.PrepareContext(ctx, bulkDeleteGitHubRepositories); err != nil {
 		return nil, fmt.Errorf("error preparing query BulkDeleteGitHubRepositories: %w", err)
 	}
+	if q.claimIntegrationSyncStmt, err = db.PrepareContext(ctx, claimIntegrationSync); err != nil {
+		return nil, fmt.Errorf("error preparing query ClaimIntegrationSync: %w", err)
+	}
+	if q.countCredentialAccessStmt, err = db.PrepareContext(ctx, countCredentialAccess); err != nil {
+		return nil, fmt.Errorf("error preparing query CountCredentialAccess: %w", err)
+	}
+	if q.countIntegrationActivityStmt, err = db.PrepareContext(ctx, countIntegrationActivity); err != nil {
+		return nil, fmt.Errorf("error preparing query CountIntegrationActivity: %w", err)
+	}
+	if q.countIntegrationSyncJobsSinceStmt, err = db.PrepareContext(ctx, countIntegrationSyncJobsSince); err != nil {
+		return nil, fmt.Errorf("error preparing query CountIntegrationSyncJobsSince: %w", err)
+	}
+	if q.countRepositoryTriggersByOrganizationStmt, err = db.PrepareContext(ctx, countRepositoryTriggersByOrganization); err != nil {
+		return nil, fmt.Errorf("error preparing query CountRepositoryTriggersByOrganization: %w", err)
+	}
+	if q.deleteAzureDevOpsRepositoriesByIntegrationStmt, err = db.PrepareContext(ctx, deleteAzureDevOpsRepositoriesByIntegration); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteAzureDevOpsRepositoriesByIntegration: %w", err)
+	}
 	if q.deleteCredentialStmt, err = db.PrepareContext(ctx, deleteCredential); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteCredential: %w", err)
 	}
+	if q.deleteCredentialsByIntegrationStmt, err = db.PrepareContext(ctx, deleteCredentialsByIntegration); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCredentialsByIntegration: %w", err)
+	}
+	if q.deleteGitHubRepositoriesByIntegrationStmt, err = db.PrepareContext(ctx, deleteGitHubRepositoriesByIntegration); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteGitHubRepositoriesByIntegration: %w", err)
+	}
 	if q.deleteGitHubRepositoryByGitHubIDStmt, err = db.PrepareContext(ctx, deleteGitHubRepositoryByGitHubID); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteGitHubRepositoryByGitHubID: %w", err)
 	}
 	if q.deleteIntegrationStmt, err = db.PrepareContext(ctx, deleteIntegration); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteIntegration: %w", err)
 	}
+	if q.deleteIntegrationActivityByIntegrationStmt, err = db.PrepareContext(ctx, deleteIntegrationActivityByIntegration); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteIntegrationActivityByIntegration: %w", err)
+	}
+	if q.deleteIntegrationSyncJobsByIntegrationStmt, err = db.PrepareContext(ctx, deleteIntegrationSyncJobsByIntegration); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteIntegrationSyncJobsByIntegration: %w", err)
+	}
+	if q.deleteRepositoryTriggerStmt, err = db.PrepareContext(ctx, deleteRepositoryTrigger); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteRepositoryTrigger: %w", err)
+	}
+	if q.deleteRepositoryTriggersByOrganizationStmt, err = db.PrepareContext(ctx, deleteRepositoryTriggersByOrganization); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteRepositoryTriggersByOrganization: %w", err)
+	}
+	if q.failStaleIntegrationSyncJobsStmt, err = db.PrepareContext(ctx, failStaleIntegrationSyncJobs); err != nil {
+		return nil, fmt.Errorf("error preparing query FailStaleIntegrationSyncJobs: %w", err)
+	}
+	if q.findAllRepositoryTriggersStmt, err = db.PrepareContext(ctx, findAllRepositoryTriggers); err != nil {
+		return nil, fmt.Errorf("error preparing query FindAllRepositoryTriggers: %w", err)
+	}
+	if q.findAzureDevOpsRepositoriesByIntegrationIDStmt, err = db.PrepareContext(ctx, findAzureDevOpsRepositoriesByIntegrationID); err != nil {
+		return nil, fmt.Errorf("error preparing query FindAzureDevOpsRepositoriesByIntegrationID: %w", err)
+	}
 	if q.findCredentialByIntegrationStmt, err = db.PrepareContext(ctx, findCredentialByIntegration); err != nil {
 		return nil, fmt.Errorf("error preparing query FindCredentialByIntegration: %w", err)
 	}
@@ -54,6 +102,12 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.findIntegrationByIDStmt, err = db.PrepareContext(ctx, findIntegrationByID); err != nil {
 		return nil, fmt.Errorf("error preparing query FindIntegrationByID: %w", err)
 	}
+	if q.findIntegrationIDByAuthorizationStmt, err = db.PrepareContext(ctx, findIntegrationIDByAuthorization); err != nil {
+		return nil, fmt.Errorf("error preparing query FindIntegrationIDByAuthorization: %w", err)
+	}
+	if q.findIntegrationsByConnectorOrganizationIDAndTypeStmt, err = db.PrepareContext(ctx, findIntegrationsByConnectorOrganizationIDAndType); err != nil {
+		return nil, fmt.Errorf("error preparing query FindIntegrationsByConnectorOrganizationIDAndType: %w", err)
+	}
 	if q.findIntegrationsByOrganizationStmt, err = db.PrepareContext(ctx, findIntegrationsByOrganization); err != nil {
 		return nil, fmt.Errorf("error preparing query FindIntegrationsByOrganization: %w", err)
 	}
@@ -66,12 +120,57 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.findIntegrationsByOrganizationTypeAndStatusStmt, err = db.PrepareContext(ctx, findIntegrationsByOrganizationTypeAndStatus); err != nil {
 		return nil, fmt.Errorf("error preparing query FindIntegrationsByOrganizationTypeAndStatus: %w", err)
 	}
+	if q.findIntegrationsDueForSyncStmt, err = db.PrepareContext(ctx, findIntegrationsDueForSync); err != nil {
+		return nil, fmt.Errorf("error preparing query FindIntegrationsDueForSync: %w", err)
+	}
+	if q.findRepositoryTriggersByOrganizationStmt, err = db.PrepareContext(ctx, findRepositoryTriggersByOrganization); err != nil {
+		return nil, fmt.Errorf("error preparing query FindRepositoryTriggersByOrganization: %w", err)
+	}
+	if q.finishIntegrationSyncJobStmt, err = db.PrepareContext(ctx, finishIntegrationSyncJob); err != nil {
+		return nil, fmt.Errorf("error preparing query FinishIntegrationSyncJob: %w", err)
+	}
+	if q.getLastSucceededIntegrationSyncJobStmt, err = db.PrepareContext(ctx, getLastSucceededIntegrationSyncJob); err != nil {
+		return nil, fmt.Errorf("error preparing query GetLastSucceededIntegrationSyncJob: %w", err)
+	}
+	if q.heartbeatIntegrationSyncJobStmt, err = db.PrepareContext(ctx, heartbeatIntegrationSyncJob); err != nil {
+		return nil, fmt.Errorf("error preparing query HeartbeatIntegrationSyncJob: %w", err)
+	}
+	if q.listCredentialAccessStmt, err = db.PrepareContext(ctx, listCredentialAccess); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCredentialAccess: %w", err)
+	}
+	if q.listIntegrationActivityStmt, err = db.PrepareContext(ctx, listIntegrationActivity); err != nil {
+		return nil, fmt.Errorf("error preparing query ListIntegrationActivity: %w", err)
+	}
+	if q.recordIntegrationAuthorizationStmt, err = db.PrepareContext(ctx, recordIntegrationAuthorization); err != nil {
+		return nil, fmt.Errorf("error preparing query RecordIntegrationAuthorization: %w", err)
+	}
+	if q.setAzureDevOpsRepositoriesEnabledStmt, err = db.PrepareContext(ctx, setAzureDevOpsRepositoriesEnabled); err != nil {
+		return nil, fmt.Errorf("error preparing query SetAzureDevOpsRepositoriesEnabled: %w", err)
+	}
+	if q.setGitHubRepositoriesEnabledStmt, err = db.PrepareContext(ctx, setGitHubRepositoriesEnabled); err != nil {
+		return nil, fmt.Errorf("error preparing query SetGitHubRepositoriesEnabled: %w", err)
+	}
 	if q.storeCredentialStmt, err = db.PrepareContext(ctx, storeCredential); err != nil {
 		return nil, fmt.Errorf("error preparing query StoreCredential: %w", err)
 	}
+	if q.storeCredentialAccessStmt, err = db.PrepareContext(ctx, storeCredentialAccess); err != nil {
+		return nil, fmt.Errorf("error preparing query StoreCredentialAccess: %w", err)
+	}
 	if q.storeIntegrationStmt, err = db.PrepareContext(ctx, storeIntegration); err != nil {
 		return nil, fmt.Errorf("error preparing query StoreIntegration: %w", err)
 	}
+	if q.storeIntegrationActivityStmt, err = db.PrepareContext(ctx, storeIntegrationActivity); err != nil {
+		return nil, fmt.Errorf("error preparing query StoreIntegrationActivity: %w", err)
+	}
+	if q.storeIntegrationSyncJobStmt, err = db.PrepareContext(ctx, storeIntegrationSyncJob); err != nil {
+		return nil, fmt.Errorf("error preparing query StoreIntegrationSyncJob: %w", err)
+	}
+	if q.storeRepositoryTriggerStmt, err = db.PrepareContext(ctx, storeRepositoryTrigger); err != nil {
+		return nil, fmt.Errorf("error preparing query StoreRepositoryTrigger: %w", err)
+	}
+	if q.updateAzureDevOpsRepositoryLastSyncTimeStmt, err = db.PrepareContext(ctx, updateAzureDevOpsRepositoryLastSyncTime); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateAzureDevOpsRepositoryLastSyncTime: %w", err)
+	}
 	if q.updateCredentialStmt, err = db.PrepareContext(ctx, updateCredential); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateCredential: %w", err)
 	}
@@ -81,9 +180,18 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateGitHubRepositoryPermissionsStmt, err = db.PrepareContext(ctx, updateGitHubRepositoryPermissions); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateGitHubRepositoryPermissions: %w", err)
 	}
+	if q.updateGitHubRepositoryProfileStmt, err = db.PrepareContext(ctx, updateGitHubRepositoryProfile); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateGitHubRepositoryProfile: %w", err)
+	}
 	if q.updateIntegrationStmt, err = db.PrepareContext(ctx, updateIntegration); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateIntegration: %w", err)
 	}
+	if q.updateIntegrationGrantsStmt, err = db.PrepareContext(ctx, updateIntegrationGrants); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateIntegrationGrants: %w", err)
+	}
+	if q.updateIntegrationLastSyncedStmt, err = db.PrepareContext(ctx, updateIntegrationLastSynced); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateIntegrationLastSynced: %w", err)
+	}
 	if q.updateIntegrationLastUsedStmt, err = db.PrepareContext(ctx, updateIntegrationLastUsed); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateIntegrationLastUsed: %w", err)
 	}
@@ -93,6 +201,12 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateIntegrationStatusStmt, err = db.PrepareContext(ctx, updateIntegrationStatus); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateIntegrationStatus: %w", err)
 	}
+	if q.updateRepositoryTriggerStmt, err = db.PrepareContext(ctx, updateRepositoryTrigger); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateRepositoryTrigger: %w", err)
+	}
+	if q.upsertAzureDevOpsRepositoryStmt, err = db.PrepareContext(ctx, upsertAzureDevOpsRepository); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertAzureDevOpsRepository: %w", err)
+	}
 	if q.upsertGitHubRepositoryStmt, err = db.PrepareContext(ctx, upsertGitHubRepository); err != nil {
 		return nil, fmt.Errorf("error preparing query UpsertGitHubRepository: %w", err)
 	}
@@ -101,16 +215,61 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 
 func (q *Queries) Close() error {
 	var err error
+	if q.bulkDeleteAzureDevOpsRepositoriesStmt != nil {
+		if cerr := q.bulkDeleteAzureDevOpsRepositoriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing bulkDeleteAzureDevOpsRepositoriesStmt: %w", cerr)
+		}
+	}
 	if q.bulkDeleteGitHubRepositoriesStmt != nil {
 		if cerr := q.bulkDeleteGitHubRepositoriesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing bulkDeleteGitHubRepositoriesStmt: %w", cerr)
 		}
 	}
+	if q.claimIntegrationSyncStmt != nil {
+		if cerr := q.claimIntegrationSyncStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing claimIntegrationSyncStmt: %w", cerr)
+		}
+	}
+	if q.countCredentialAccessStmt != nil {
+		if cerr := q.countCredentialAccessStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countCredentialAccessStmt: %w", cerr)
+		}
+	}
+	if q.countIntegrationActivityStmt != nil {
+		if cerr := q.countIntegrationActivityStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countIntegrationActivityStmt: %w", cerr)
+		}
+	}
+	if q.countIntegrationSyncJobsSinceStmt != nil {
+		if cerr := q.countIntegrationSyncJobsSinceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countIntegrationSyncJobsSinceStmt: %w", cerr)
+		}
+	}
+	if q.countRepositoryTriggersByOrganizationStmt != nil {
+		if cerr := q.countRepositoryTriggersByOrganizationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countRepositoryTriggersByOrganizationStmt: %w", cerr)
+		}
+	}
+	if q.deleteAzureDevOpsRepositoriesByIntegrationStmt != nil {
+		if cerr := q.deleteAzureDevOpsRepositoriesByIntegrationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteAzureDevOpsRepositoriesByIntegrationStmt: %w", cerr)
+		}
+	}
 	if q.deleteCredentialStmt != nil {
 		if cerr := q.deleteCredentialStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteCredentialStmt: %w", cerr)
 		}
 	}
+	if q.deleteCredentialsByIntegrationStmt != nil {
+		if cerr := q.deleteCredentialsByIntegrationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCredentialsByIntegrationStmt: %w", cerr)
+		}
+	}
+	if q.deleteGitHubRepositoriesByIntegrationStmt != nil {
+		if cerr := q.deleteGitHubRepositoriesByIntegrationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteGitHubRepositoriesByIntegrationStmt: %w", cerr)
+		}
+	}
 	if q.deleteGitHubRepositoryByGitHubIDStmt != nil {
 		if cerr := q.deleteGitHubRepositoryByGitHubIDStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteGitHubRepositoryByGitHubIDStmt: %w", cerr)
@@ -121,6 +280,41 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteIntegrationStmt: %w", cerr)
 		}
 	}
+	if q.deleteIntegrationActivityByIntegrationStmt != nil {
+		if cerr := q.deleteIntegrationActivityByIntegrationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteIntegrationActivityByIntegrationStmt: %w", cerr)
+		}
+	}
+	if q.deleteIntegrationSyncJobsByIntegrationStmt != nil {
+		if cerr := q.deleteIntegrationSyncJobsByIntegrationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteIntegrationSyncJobsByIntegrationStmt: %w", cerr)
+		}
+	}
+	if q.deleteRepositoryTriggerStmt != nil {
+		if cerr := q.deleteRepositoryTriggerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteRepositoryTriggerStmt: %w", cerr)
+		}
+	}
+	if q.deleteRepositoryTriggersByOrganizationStmt != nil {
+		if cerr := q.deleteRepositoryTriggersByOrganizationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteRepositoryTriggersByOrganizationStmt: %w", cerr)
+		}
+	}
+	if q.failStaleIntegrationSyncJobsStmt != nil {
+		if cerr := q.failStaleIntegrationSyncJobsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing failStaleIntegrationSyncJobsStmt: %w", cerr)
+		}
+	}
+	if q.findAllRepositoryTriggersStmt != nil {
+		if cerr := q.findAllRepositoryTriggersStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findAllRepositoryTriggersStmt: %w", cerr)
+		}
+	}
+	if q.findAzureDevOpsRepositoriesByIntegrationIDStmt != nil {
+		if cerr := q.findAzureDevOpsRepositoriesByIntegrationIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findAzureDevOpsRepositoriesByIntegrationIDStmt: %w", cerr)
+		}
+	}
 	if q.findCredentialByIntegrationStmt != nil {
 		if cerr := q.findCredentialByIntegrationStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing findCredentialByIntegrationStmt: %w", cerr)
@@ -151,6 +345,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing findIntegrationByIDStmt: %w", cerr)
 		}
 	}
+	if q.findIntegrationIDByAuthorizationStmt != nil {
+		if cerr := q.findIntegrationIDByAuthorizationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findIntegrationIDByAuthorizationStmt: %w", cerr)
+		}
+	}
+	if q.findIntegrationsByConnectorOrganizationIDAndTypeStmt != nil {
+		if cerr := q.findIntegrationsByConnectorOrganizationIDAndTypeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findIntegrationsByConnectorOrganizationIDAndTypeStmt: %w", cerr)
+		}
+	}
 	if q.findIntegrationsByOrganizationStmt != nil {
 		if cerr := q.findIntegrationsByOrganizationStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing findIntegrationsByOrganizationStmt: %w", cerr)
@@ -171,16 +375,91 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing findIntegrationsByOrganizationTypeAndStatusStmt: %w", cerr)
 		}
 	}
+	if q.findIntegrationsDueForSyncStmt != nil {
+		if cerr := q.findIntegrationsDueForSyncStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findIntegrationsDueForSyncStmt: %w", cerr)
+		}
+	}
+	if q.findRepositoryTriggersByOrganizationStmt != nil {
+		if cerr := q.findRepositoryTriggersByOrganizationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing findRepositoryTriggersByOrganizationStmt: %w", cerr)
+		}
+	}
+	if q.finishIntegrationSyncJobStmt != nil {
+		if cerr := q.finishIntegrationSyncJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing finishIntegrationSyncJobStmt: %w", cerr)
+		}
+	}
+	if q.getLastSucceededIntegrationSyncJobStmt != nil {
+		if cerr := q.getLastSucceededIntegrationSyncJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getLastSucceededIntegrationSyncJobStmt: %w", cerr)
+		}
+	}
+	if q.heartbeatIntegrationSyncJobStmt != nil {
+		if cerr := q.heartbeatIntegrationSyncJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing heartbeatIntegrationSyncJobStmt: %w", cerr)
+		}
+	}
+	if q.listCredentialAccessStmt != nil {
+		if cerr := q.listCredentialAccessStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCredentialAccessStmt: %w", cerr)
+		}
+	}
+	if q.listIntegrationActivityStmt != nil {
+		if cerr := q.listIntegrationActivityStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listIntegrationActivityStmt: %w", cerr)
+		}
+	}
+	if q.recordIntegrationAuthorizationStmt != nil {
+		if cerr := q.recordIntegrationAuthorizationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recordIntegrationAuthorizationStmt: %w", cerr)
+		}
+	}
+	if q.setAzureDevOpsRepositoriesEnabledStmt != nil {
+		if cerr := q.setAzureDevOpsRepositoriesEnabledStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setAzureDevOpsRepositoriesEnabledStmt: %w", cerr)
+		}
+	}
+	if q.setGitHubRepositoriesEnabledStmt != nil {
+		if cerr := q.setGitHubRepositoriesEnabledStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setGitHubRepositoriesEnabledStmt: %w", cerr)
+		}
+	}
 	if q.storeCredentialStmt != nil {
 		if cerr := q.storeCredentialStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing storeCredentialStmt: %w", cerr)
 		}
 	}
+	if q.storeCredentialAccessStmt != nil {
+		if cerr := q.storeCredentialAccessStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing storeCredentialAccessStmt: %w", cerr)
+		}
+	}
 	if q.storeIntegrationStmt != nil {
 		if cerr := q.storeIntegrationStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing storeIntegrationStmt: %w", cerr)
 		}
 	}
+	if q.storeIntegrationActivityStmt != nil {
+		if cerr := q.storeIntegrationActivityStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing storeIntegrationActivityStmt: %w", cerr)
+		}
+	}
+	if q.storeIntegrationSyncJobStmt != nil {
+		if cerr := q.storeIntegrationSyncJobStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing storeIntegrationSyncJobStmt: %w", cerr)
+		}
+	}
+	if q.storeRepositoryTriggerStmt != nil {
+		if cerr := q.storeRepositoryTriggerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing storeRepositoryTriggerStmt: %w", cerr)
+		}
+	}
+	if q.updateAzureDevOpsRepositoryLastSyncTimeStmt != nil {
+		if cerr := q.updateAzureDevOpsRepositoryLastSyncTimeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateAzureDevOpsRepositoryLastSyncTimeStmt: %w", cerr)
+		}
+	}
 	if q.updateCredentialStmt != nil {
 		if cerr := q.updateCredentialStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateCredentialStmt: %w", cerr)
@@ -196,11 +475,26 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateGitHubRepositoryPermissionsStmt: %w", cerr)
 		}
 	}
+	if q.updateGitHubRepositoryProfileStmt != nil {
+		if cerr := q.updateGitHubRepositoryProfileStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateGitHubRepositoryProfileStmt: %w", cerr)
+		}
+	}
 	if q.updateIntegrationStmt != nil {
 		if cerr := q.updateIntegrationStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateIntegrationStmt: %w", cerr)
 		}
 	}
+	if q.updateIntegrationGrantsStmt != nil {
+		if cerr := q.updateIntegrationGrantsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateIntegrationGrantsStmt: %w", cerr)
+		}
+	}
+	if q.updateIntegrationLastSyncedStmt != nil {
+		if cerr := q.updateIntegrationLastSyncedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateIntegrationLastSyncedStmt: %w", cerr)
+		}
+	}
 	if q.updateIntegrationLastUsedStmt != nil {
 		if cerr := q.updateIntegrationLastUsedStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateIntegrationLastUsedStmt: %w", cerr)
@@ -216,6 +510,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateIntegrationStatusStmt: %w", cerr)
 		}
 	}
+	if q.updateRepositoryTriggerStmt != nil {
+		if cerr := q.updateRepositoryTriggerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateRepositoryTriggerStmt: %w", cerr)
+		}
+	}
+	if q.upsertAzureDevOpsRepositoryStmt != nil {
+		if cerr := q.upsertAzureDevOpsRepositoryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertAzureDevOpsRepositoryStmt: %w", cerr)
+		}
+	}
 	if q.upsertGitHubRepositoryStmt != nil {
 		if cerr := q.upsertGitHubRepositoryStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing upsertGitHubRepositoryStmt: %w", cerr)
@@ -258,61 +562,137 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                                              DBTX
-	tx                                              *sql.Tx
-	bulkDeleteGitHubRepositoriesStmt                *sql.Stmt
-	deleteCredentialStmt                            *sql.Stmt
-	deleteGitHubRepositoryByGitHubIDStmt            *sql.Stmt
-	deleteIntegrationStmt                           *sql.Stmt
-	findCredentialByIntegrationStmt                 *sql.Stmt
-	findExpiringCredentialsStmt                     *sql.Stmt
-	findGitHubRepositoriesByIntegrationIDStmt       *sql.Stmt
-	findGitHubRepositoryByGitHubIDStmt              *sql.Stmt
-	findIntegrationByBotIDAndTypeStmt               *sql.Stmt
-	findIntegrationByIDStmt                         *sql.Stmt
-	findIntegrationsByOrganizationStmt              *sql.Stmt
-	findIntegrationsByOrganizationAndStatusStmt     *sql.Stmt
-	findIntegrationsByOrganizationAndTypeStmt       *sql.Stmt
-	findIntegrationsByOrganizationTypeAndStatusStmt *sql.Stmt
-	storeCredentialStmt                             *sql.Stmt
-	storeIntegrationStmt                            *sql.Stmt
-	updateCredentialStmt                            *sql.Stmt
-	updateGitHubRepositoryLastSyncTimeStmt          *sql.Stmt
-	updateGitHubRepositoryPermissionsStmt           *sql.Stmt
-	updateIntegrationStmt                           *sql.Stmt
-	updateIntegrationLastUsedStmt                   *sql.Stmt
-	updateIntegrationMetadataStmt                   *sql.Stmt
-	updateIntegrationStatusStmt                     *sql.Stmt
-	upsertGitHubRepositoryStmt                      *sql.Stmt
+	db                                                   DBTX
+	tx                                                   *sql.Tx
+	bulkDeleteAzureDevOpsRepositoriesStmt                *sql.Stmt
+	bulkDeleteGitHubRepositoriesStmt                     *sql.Stmt
+	claimIntegrationSyncStmt                             *sql.Stmt
+	countCredentialAccessStmt                            *sql.Stmt
+	countIntegrationActivityStmt                         *sql.Stmt
+	countIntegrationSyncJobsSinceStmt                    *sql.Stmt
+	countRepositoryTriggersByOrganizationStmt            *sql.Stmt
+	deleteAzureDevOpsRepositoriesByIntegrationStmt       *sql.Stmt
+	deleteCredentialStmt                                 *sql.Stmt
+	deleteCredentialsByIntegrationStmt                   *sql.Stmt
+	deleteGitHubRepositoriesByIntegrationStmt            *sql.Stmt
+	deleteGitHubRepositoryByGitHubIDStmt                 *sql.Stmt
+	deleteIntegrationStmt                                *sql.Stmt
+	deleteIntegrationActivityByIntegrationStmt           *sql.Stmt
+	deleteIntegrationSyncJobsByIntegrationStmt           *sql.Stmt
+	deleteRepositoryTriggerStmt                          *sql.Stmt
+	deleteRepositoryTriggersByOrganizationStmt           *sql.Stmt
+	failStaleIntegrationSyncJobsStmt                     *sql.Stmt
+	findAllRepositoryTriggersStmt                        *sql.Stmt
+	findAzureDevOpsRepositoriesByIntegrationIDStmt       *sql.Stmt
+	findCredentialByIntegrationStmt                      *sql.Stmt
+	findExpiringCredentialsStmt                          *sql.Stmt
+	findGitHubRepositoriesByIntegrationIDStmt            *sql.Stmt
+	findGitHubRepositoryByGitHubIDStmt                   *sql.Stmt
+	findIntegrationByBotIDAndTypeStmt                    *sql.Stmt
+	findIntegrationByIDStmt                              *sql.Stmt
+	findIntegrationIDByAuthorizationStmt                 *sql.Stmt
+	findIntegrationsByConnectorOrganizationIDAndTypeStmt *sql.Stmt
+	findIntegrationsByOrganizationStmt                   *sql.Stmt
+	findIntegrationsByOrganizationAndStatusStmt          *sql.Stmt
+	findIntegrationsByOrganizationAndTypeStmt            *sql.Stmt
+	findIntegrationsByOrganizationTypeAndStatusStmt      *sql.Stmt
+	findIntegrationsDueForSyncStmt                       *sql.Stmt
+	findRepositoryTriggersByOrganizationStmt             *sql.Stmt
+	finishIntegrationSyncJobStmt                         *sql.Stmt
+	getLastSucceededIntegrationSyncJobStmt               *sql.Stmt
+	heartbeatIntegrationSyncJobStmt                      *sql.Stmt
+	listCredentialAccessStmt                             *sql.Stmt
+	listIntegrationActivityStmt                          *sql.Stmt
+	recordIntegrationAuthorizationStmt                   *sql.Stmt
+	setAzureDevOpsRepositoriesEnabledStmt                *sql.Stmt
+	setGitHubRepositoriesEnabledStmt                     *sql.Stmt
+	storeCredentialStmt                                  *sql.Stmt
+	storeCredentialAccessStmt                            *sql.Stmt
+	storeIntegrationStmt                                 *sql.Stmt
+	storeIntegrationActivityStmt                         *sql.Stmt
+	storeIntegrationSyncJobStmt                          *sql.Stmt
+	storeRepositoryTriggerStmt                           *sql.Stmt
+	updateAzureDevOpsRepositoryLastSyncTimeStmt          *sql.Stmt
+	updateCredentialStmt                                 *sql.Stmt
+	updateGitHubRepositoryLastSyncTimeStmt               *sql.Stmt
+	updateGitHubRepositoryPermissionsStmt                *sql.Stmt
+	updateGitHubRepositoryProfileStmt                    *sql.Stmt
+	updateIntegrationStmt                                *sql.Stmt
+	updateIntegrationGrantsStmt                          *sql.Stmt
+	updateIntegrationLastSyncedStmt                      *sql.Stmt
+	updateIntegrationLastUsedStmt                        *sql.Stmt
+	updateIntegrationMetadataStmt                        *sql.Stmt
+	updateIntegrationStatusStmt                          *sql.Stmt
+	updateRepositoryTriggerStmt                          *sql.Stmt
+	upsertAzureDevOpsRepositoryStmt                      *sql.Stmt
+	upsertGitHubRepositoryStmt                           *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                                   tx,
-		tx:                                   tx,
-		bulkDeleteGitHubRepositoriesStmt:     q.bulkDeleteGitHubRepositoriesStmt,
-		deleteCredentialStmt:                 q.deleteCredentialStmt,
-		deleteGitHubRepositoryByGitHubIDStmt: q.deleteGitHubRepositoryByGitHubIDStmt,
-		deleteIntegrationStmt:                q.deleteIntegrationStmt,
-		findCredentialByIntegrationStmt:      q.findCredentialByIntegrationStmt,
-		findExpiringCredentialsStmt:          q.findExpiringCredentialsStmt,
-		findGitHubRepositoriesByIntegrationIDStmt:       q.findGitHubRepositoriesByIntegrationIDStmt,
-		findGitHubRepositoryByGitHubIDStmt:              q.findGitHubRepositoryByGitHubIDStmt,
-		findIntegrationByBotIDAndTypeStmt:               q.findIntegrationByBotIDAndTypeStmt,
-		findIntegrationByIDStmt:                         q.findIntegrationByIDStmt,
-		findIntegrationsByOrganizationStmt:              q.findIntegrationsByOrganizationStmt,
-		findIntegrationsByOrganizationAndStatusStmt:     q.findIntegrationsByOrganizationAndStatusStmt,
-		findIntegrationsByOrganizationAndTypeStmt:       q.findIntegrationsByOrganizationAndTypeStmt,
-		findIntegrationsByOrganizationTypeAndStatusStmt: q.findIntegrationsByOrganizationTypeAndStatusStmt,
-		storeCredentialStmt:                             q.storeCredentialStmt,
-		storeIntegrationStmt:                            q.storeIntegrationStmt,
-		updateCredentialStmt:                            q.updateCredentialStmt,
-		updateGitHubRepositoryLastSyncTimeStmt:          q.updateGitHubRepositoryLastSyncTimeStmt,
-		updateGitHubRepositoryPermissionsStmt:           q.updateGitHubRepositoryPermissionsStmt,
-		updateIntegrationStmt:                           q.updateIntegrationStmt,
-		updateIntegrationLastUsedStmt:                   q.updateIntegrationLastUsedStmt,
-		updateIntegrationMetadataStmt:                   q.updateIntegrationMetadataStmt,
-		updateIntegrationStatusStmt:                     q.updateIntegrationStatusStmt,
-		upsertGitHubRepositoryStmt:                      q.upsertGitHubRepositoryStmt,
+		db:                                    tx,
+		tx:                                    tx,
+		bulkDeleteAzureDevOpsRepositoriesStmt: q.bulkDeleteAzureDevOpsRepositoriesStmt,
+		bulkDeleteGitHubRepositoriesStmt:      q.bulkDeleteGitHubRepositoriesStmt,
+		claimIntegrationSyncStmt:              q.claimIntegrationSyncStmt,
+		countCredentialAccessStmt:             q.countCredentialAccessStmt,
+		countIntegrationActivityStmt:          q.countIntegrationActivityStmt,
+		countIntegrationSyncJobsSinceStmt:     q.countIntegrationSyncJobsSinceStmt,
+		countRepositoryTriggersByOrganizationStmt:            q.countRepositoryTriggersByOrganizationStmt,
+		deleteAzureDevOpsRepositoriesByIntegrationStmt:       q.deleteAzureDevOpsRepositoriesByIntegrationStmt,
+		deleteCredentialStmt:                                 q.deleteCredentialStmt,
+		deleteCredentialsByIntegrationStmt:                   q.deleteCredentialsByIntegrationStmt,
+		deleteGitHubRepositoriesByIntegrationStmt:            q.deleteGitHubRepositoriesByIntegrationStmt,
+		deleteGitHubRepositoryByGitHubIDStmt:                 q.deleteGitHubRepositoryByGitHubIDStmt,
+		deleteIntegrationStmt:                                q.deleteIntegrationStmt,
+		deleteIntegrationActivityByIntegrationStmt:           q.deleteIntegrationActivityByIntegrationStmt,
+		deleteIntegrationSyncJobsByIntegrationStmt:           q.deleteIntegrationSyncJobsByIntegrationStmt,
+		deleteRepositoryTriggerStmt:                          q.deleteRepositoryTriggerStmt,
+		deleteRepositoryTriggersByOrganizationStmt:           q.deleteRepositoryTriggersByOrganizationStmt,
+		failStaleIntegrationSyncJobsStmt:                     q.failStaleIntegrationSyncJobsStmt,
+		findAllRepositoryTriggersStmt:                        q.findAllRepositoryTriggersStmt,
+		findAzureDevOpsRepositoriesByIntegrationIDStmt:       q.findAzureDevOpsRepositoriesByIntegrationIDStmt,
+		findCredentialByIntegrationStmt:                      q.findCredentialByIntegrationStmt,
+		findExpiringCredentialsStmt:                          q.findExpiringCredentialsStmt,
+		findGitHubRepositoriesByIntegrationIDStmt:            q.findGitHubRepositoriesByIntegrationIDStmt,
+		findGitHubRepositoryByGitHubIDStmt:                   q.findGitHubRepositoryByGitHubIDStmt,
+		findIntegrationByBotIDAndTypeStmt:                    q.findIntegrationByBotIDAndTypeStmt,
+		findIntegrationByIDStmt:                              q.findIntegrationByIDStmt,
+		findIntegrationIDByAuthorizationStmt:                 q.findIntegrationIDByAuthorizationStmt,
+		findIntegrationsByConnectorOrganizationIDAndTypeStmt: q.findIntegrationsByConnectorOrganizationIDAndTypeStmt,
+		findIntegrationsByOrganizationStmt:                   q.findIntegrationsByOrganizationStmt,
+		findIntegrationsByOrganizationAndStatusStmt:          q.findIntegrationsByOrganizationAndStatusStmt,
+		findIntegrationsByOrganizationAndTypeStmt:            q.findIntegrationsByOrganizationAndTypeStmt,
+		findIntegrationsByOrganizationTypeAndStatusStmt:      q.findIntegrationsByOrganizationTypeAndStatusStmt,
+		findIntegrationsDueForSyncStmt:                       q.findIntegrationsDueForSyncStmt,
+		findRepositoryTriggersByOrganizationStmt:             q.findRepositoryTriggersByOrganizationStmt,
+		finishIntegrationSyncJobStmt:                         q.finishIntegrationSyncJobStmt,
+		getLastSucceededIntegrationSyncJobStmt:               q.getLastSucceededIntegrationSyncJobStmt,
+		heartbeatIntegrationSyncJobStmt:                      q.heartbeatIntegrationSyncJobStmt,
+		listCredentialAccessStmt:                             q.listCredentialAccessStmt,
+		listIntegrationActivityStmt:                          q.listIntegrationActivityStmt,
+		recordIntegrationAuthorizationStmt:                   q.recordIntegrationAuthorizationStmt,
+		setAzureDevOpsRepositoriesEnabledStmt:                q.setAzureDevOpsRepositoriesEnabledStmt,
+		setGitHubRepositoriesEnabledStmt:                     q.setGitHubRepositoriesEnabledStmt,
+		storeCredentialStmt:                                  q.storeCredentialStmt,
+		storeCredentialAccessStmt:                            q.storeCredentialAccessStmt,
+		storeIntegrationStmt:                                 q.storeIntegrationStmt,
+		storeIntegrationActivityStmt:                         q.storeIntegrationActivityStmt,
+		storeIntegrationSyncJobStmt:                          q.storeIntegrationSyncJobStmt,
+		storeRepositoryTriggerStmt:                           q.storeRepositoryTriggerStmt,
+		updateAzureDevOpsRepositoryLastSyncTimeStmt:          q.updateAzureDevOpsRepositoryLastSyncTimeStmt,
+		updateCredentialStmt:                                 q.updateCredentialStmt,
+		updateGitHubRepositoryLastSyncTimeStmt:               q.updateGitHubRepositoryLastSyncTimeStmt,
+		updateGitHubRepositoryPermissionsStmt:                q.updateGitHubRepositoryPermissionsStmt,
+		updateGitHubRepositoryProfileStmt:                    q.updateGitHubRepositoryProfileStmt,
+		updateIntegrationStmt:                                q.updateIntegrationStmt,
+		updateIntegrationGrantsStmt:                          q.updateIntegrationGrantsStmt,
+		updateIntegrationLastSyncedStmt:                      q.updateIntegrationLastSyncedStmt,
+		updateIntegrationLastUsedStmt:                        q.updateIntegrationLastUsedStmt,
+		updateIntegrationMetadataStmt:                        q.updateIntegrationMetadataStmt,
+		updateIntegrationStatusStmt:                          q.updateIntegrationStatusStmt,
+		updateRepositoryTriggerStmt:                          q.updateRepositoryTriggerStmt,
+		upsertAzureDevOpsRepositoryStmt:                      q.upsertAzureDevOpsRepositoryStmt,
+		upsertGitHubRepositoryStmt:                           q.upsertGitHubRepositoryStmt,
 	}
 }
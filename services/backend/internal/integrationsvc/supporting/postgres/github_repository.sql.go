@@ -12,11 +12,13 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"github.com/sqlc-dev/pqtype"
 )
 
-const bulkDeleteGitHubRepositories = `-- name: BulkDeleteGitHubRepositories :exec
+const bulkDeleteGitHubRepositories = `-- name: BulkDeleteGitHubRepositories :many
 DELETE FROM github_repositories 
 WHERE integration_id = $1 AND github_repository_id = ANY($2::bigint[])
+RETURNING github_repository_id
 `
 
 type BulkDeleteGitHubRepositoriesParams struct {
@@ -24,9 +26,39 @@ type BulkDeleteGitHubRepositoriesParams struct {
 	Column2       []int64   `json:"column_2"`
 }
 
-func (q *Queries) BulkDeleteGitHubRepositories(ctx context.Context, arg BulkDeleteGitHubRepositoriesParams) error {
-	_, err := q.exec(ctx, q.bulkDeleteGitHubRepositoriesStmt, bulkDeleteGitHubRepositories, arg.IntegrationID, pq.Array(arg.Column2))
-	return err
+func (q *Queries) BulkDeleteGitHubRepositories(ctx context.Context, arg BulkDeleteGitHubRepositoriesParams) ([]int64, error) {
+	rows, err := q.query(ctx, q.bulkDeleteGitHubRepositoriesStmt, bulkDeleteGitHubRepositories, arg.IntegrationID, pq.Array(arg.Column2))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var github_repository_id int64
+		if err := rows.Scan(&github_repository_id); err != nil {
+			return nil, err
+		}
+		items = append(items, github_repository_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deleteGitHubRepositoriesByIntegration = `-- name: DeleteGitHubRepositoriesByIntegration :execrows
+DELETE FROM github_repositories WHERE integration_id = $1
+`
+
+func (q *Queries) DeleteGitHubRepositoriesByIntegration(ctx context.Context, integrationID uuid.UUID) (int64, error) {
+	result, err := q.exec(ctx, q.deleteGitHubRepositoriesByIntegrationStmt, deleteGitHubRepositoriesByIntegration, integrationID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const deleteGitHubRepositoryByGitHubID = `-- name: DeleteGitHubRepositoryByGitHubID :exec
@@ -49,7 +81,8 @@ SELECT id, integration_id, github_repository_id, repository_name,
     repository_full_name, repository_url, is_private, default_branch,
     permission_admin, permission_push, permission_pull,
     repository_description, repository_language, created_at, updated_at,
-    last_synced_at, github_created_at, github_updated_at, github_pushed_at
+    last_synced_at, github_created_at, github_updated_at, github_pushed_at, enabled,
+    repository_profile
 FROM github_repositories 
 WHERE integration_id = $1
 ORDER BY repository_full_name
@@ -84,6 +117,8 @@ func (q *Queries) FindGitHubRepositoriesByIntegrationID(ctx context.Context, int
 			&i.GithubCreatedAt,
 			&i.GithubUpdatedAt,
 			&i.GithubPushedAt,
+			&i.Enabled,
+			&i.RepositoryProfile,
 		); err != nil {
 			return nil, err
 		}
@@ -103,7 +138,8 @@ SELECT id, integration_id, github_repository_id, repository_name,
     repository_full_name, repository_url, is_private, default_branch,
     permission_admin, permission_push, permission_pull,
     repository_description, repository_language, created_at, updated_at,
-    last_synced_at, github_created_at, github_updated_at, github_pushed_at
+    last_synced_at, github_created_at, github_updated_at, github_pushed_at, enabled,
+    repository_profile
 FROM github_repositories 
 WHERE integration_id = $1 AND github_repository_id = $2
 `
@@ -136,10 +172,32 @@ func (q *Queries) FindGitHubRepositoryByGitHubID(ctx context.Context, arg FindGi
 		&i.GithubCreatedAt,
 		&i.GithubUpdatedAt,
 		&i.GithubPushedAt,
+		&i.Enabled,
+		&i.RepositoryProfile,
 	)
 	return i, err
 }
 
+const setGitHubRepositoriesEnabled = `-- name: SetGitHubRepositoriesEnabled :execrows
+UPDATE github_repositories
+SET enabled = $1, updated_at = NOW()
+WHERE integration_id = $2 AND github_repository_id = ANY($3::bigint[])
+`
+
+type SetGitHubRepositoriesEnabledParams struct {
+	Enabled       bool      `json:"enabled"`
+	IntegrationID uuid.UUID `json:"integration_id"`
+	Column3       []int64   `json:"column_3"`
+}
+
+func (q *Queries) SetGitHubRepositoriesEnabled(ctx context.Context, arg SetGitHubRepositoriesEnabledParams) (int64, error) {
+	result, err := q.exec(ctx, q.setGitHubRepositoriesEnabledStmt, setGitHubRepositoriesEnabled, arg.Enabled, arg.IntegrationID, pq.Array(arg.Column3))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateGitHubRepositoryLastSyncTime = `-- name: UpdateGitHubRepositoryLastSyncTime :exec
 UPDATE github_repositories 
 SET last_synced_at = $1, updated_at = NOW()
@@ -184,6 +242,23 @@ func (q *Queries) UpdateGitHubRepositoryPermissions(ctx context.Context, arg Upd
 	return err
 }
 
+const updateGitHubRepositoryProfile = `-- name: UpdateGitHubRepositoryProfile :exec
+UPDATE github_repositories
+SET repository_profile = $1, updated_at = NOW()
+WHERE integration_id = $2 AND github_repository_id = $3
+`
+
+type UpdateGitHubRepositoryProfileParams struct {
+	RepositoryProfile  pqtype.NullRawMessage `json:"repository_profile"`
+	IntegrationID      uuid.UUID             `json:"integration_id"`
+	GithubRepositoryID int64                 `json:"github_repository_id"`
+}
+
+func (q *Queries) UpdateGitHubRepositoryProfile(ctx context.Context, arg UpdateGitHubRepositoryProfileParams) error {
+	_, err := q.exec(ctx, q.updateGitHubRepositoryProfileStmt, updateGitHubRepositoryProfile, arg.RepositoryProfile, arg.IntegrationID, arg.GithubRepositoryID)
+	return err
+}
+
 const upsertGitHubRepository = `-- name: UpsertGitHubRepository :exec
 
 INSERT INTO github_repositories (
@@ -200,9 +275,6 @@ DO UPDATE SET
     repository_url = EXCLUDED.repository_url,
     is_private = EXCLUDED.is_private,
     default_branch = EXCLUDED.default_branch,
-    permission_admin = EXCLUDED.permission_admin,
-    permission_push = EXCLUDED.permission_push,
-    permission_pull = EXCLUDED.permission_pull,
     repository_description = EXCLUDED.repository_description,
     repository_language = EXCLUDED.repository_language,
     updated_at = EXCLUDED.updated_at,
@@ -3,11 +3,15 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"time"
 
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
 	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/github"
 	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
 )
 
 // timeFromNullTime converts sql.NullTime to time.Time
@@ -23,7 +27,7 @@ type githubRepositoryRepository struct {
 }
 
 func NewGitHubRepositoryRepository(db *sql.DB) github.GitHubRepositoryRepository {
-	return &githubRepositoryRepository{queries: New(db)}
+	return &githubRepositoryRepository{queries: New(pgretry.Wrap(db))}
 }
 
 func (r *githubRepositoryRepository) Store(ctx context.Context, repo github.GitHubRepository) error {
@@ -84,6 +88,10 @@ func (r *githubRepositoryRepository) ListByIntegrationID(ctx context.Context, in
 			GitHubCreatedAt:       timeFromNullTime(dbRepo.GithubCreatedAt),
 			GitHubUpdatedAt:       timeFromNullTime(dbRepo.GithubUpdatedAt),
 			GitHubPushedAt:        timeFromNullTime(dbRepo.GithubPushedAt),
+			Enabled:               dbRepo.Enabled,
+		}
+		if repo.Profile, err = repositoryProfile(dbRepo.RepositoryProfile); err != nil {
+			return nil, fmt.Errorf("failed to decode profile of github repository %d: %w", dbRepo.GithubRepositoryID, err)
 		}
 		repositories = append(repositories, repo)
 	}
@@ -124,6 +132,10 @@ func (r *githubRepositoryRepository) GetByGitHubID(ctx context.Context, integrat
 		GitHubCreatedAt:       timeFromNullTime(dbRepo.GithubCreatedAt),
 		GitHubUpdatedAt:       timeFromNullTime(dbRepo.GithubUpdatedAt),
 		GitHubPushedAt:        timeFromNullTime(dbRepo.GithubPushedAt),
+		Enabled:               dbRepo.Enabled,
+	}
+	if repo.Profile, err = repositoryProfile(dbRepo.RepositoryProfile); err != nil {
+		return github.GitHubRepository{}, fmt.Errorf("failed to decode profile of github repository %d: %w", dbRepo.GithubRepositoryID, err)
 	}
 
 	return repo, nil
@@ -158,18 +170,60 @@ func (r *githubRepositoryRepository) UpdatePermissions(ctx context.Context, inte
 	return nil
 }
 
-func (r *githubRepositoryRepository) BulkDelete(ctx context.Context, integrationID uuid.UUID, repositoryIDs []int64) error {
+func (r *githubRepositoryRepository) SetEnabled(ctx context.Context, integrationID uuid.UUID, repositoryIDs []int64, enabled bool) (int, error) {
 	if len(repositoryIDs) == 0 {
-		return nil
+		return 0, nil
 	}
 
-	err := r.queries.BulkDeleteGitHubRepositories(ctx, BulkDeleteGitHubRepositoriesParams{
+	updated, err := r.queries.SetGitHubRepositoriesEnabled(ctx, SetGitHubRepositoriesEnabledParams{
+		Enabled:       enabled,
+		IntegrationID: integrationID,
+		Column3:       repositoryIDs,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to set github repositories enabled: %w", err)
+	}
+
+	return int(updated), nil
+}
+
+func (r *githubRepositoryRepository) BulkDelete(ctx context.Context, integrationID uuid.UUID, repositoryIDs []int64) ([]github.RepositoryDeletion, error) {
+	if len(repositoryIDs) == 0 {
+		return nil, nil
+	}
+
+	deletedIDs, err := r.queries.BulkDeleteGitHubRepositories(ctx, BulkDeleteGitHubRepositoriesParams{
 		IntegrationID: integrationID,
 		Column2:       repositoryIDs,
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to bulk delete github repositories: %w", err)
+		return nil, fmt.Errorf("failed to bulk delete github repositories: %w", err)
+	}
+
+	deletions := make([]github.RepositoryDeletion, len(repositoryIDs))
+	for i, id := range repositoryIDs {
+		deletions[i] = github.RepositoryDeletion{
+			RepositoryID: id,
+			Removed:      slices.Contains(deletedIDs, id),
+		}
+	}
+	return deletions, nil
+}
+
+func (r *githubRepositoryRepository) UpdateProfile(ctx context.Context, integrationID uuid.UUID, repositoryID int64, profile github.RepositoryProfile) error {
+	profileJSON, err := json.Marshal(profile)
+	if err != nil {
+		return fmt.Errorf("failed to marshal repository profile: %w", err)
+	}
+
+	err = r.queries.UpdateGitHubRepositoryProfile(ctx, UpdateGitHubRepositoryProfileParams{
+		RepositoryProfile:  pqtype.NullRawMessage{RawMessage: profileJSON, Valid: true},
+		IntegrationID:      integrationID,
+		GithubRepositoryID: repositoryID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update repository profile: %w", err)
 	}
 
 	return nil
@@ -188,6 +242,17 @@ func (r *githubRepositoryRepository) UpdateLastSyncTime(ctx context.Context, int
 	return nil
 }
 
+func repositoryProfile(raw pqtype.NullRawMessage) (*github.RepositoryProfile, error) {
+	if !raw.Valid {
+		return nil, nil
+	}
+	var profile github.RepositoryProfile
+	if err := json.Unmarshal(raw.RawMessage, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
 func nullString(s string) sql.NullString {
 	if s == "" {
 		return sql.NullString{Valid: false}
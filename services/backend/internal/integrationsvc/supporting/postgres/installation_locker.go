@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+)
+
+// installationLocker takes a session-level advisory lock keyed on the
+// connector type and installation ID, holding a dedicated connection until
+// the lock is released.
+type installationLocker struct {
+	db *sql.DB
+}
+
+func NewInstallationLocker(db *sql.DB) domain.InstallationLocker {
+	return &installationLocker{db: db}
+}
+
+const (
+	lockInstallation   = `SELECT pg_advisory_lock(hashtextextended($1, 0))`
+	unlockInstallation = `SELECT pg_advisory_unlock(hashtextextended($1, 0))`
+)
+
+func (l *installationLocker) LockInstallation(ctx context.Context, connectorType backend.ConnectorType, installationID string) (func(), error) {
+	key := string(connectorType) + ":" + installationID
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection for installation lock: %w", err)
+	}
+	if _, err := conn.ExecContext(ctx, lockInstallation, key); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to lock installation %s: %w", key, err)
+	}
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := conn.ExecContext(ctx, unlockInstallation, key); err != nil {
+			// Discard the connection instead of returning it to the pool, so
+			// ending its session releases the lock.
+			slog.Error("failed to unlock installation", "key", key, "error", err)
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+		conn.Close()
+	}, nil
+}
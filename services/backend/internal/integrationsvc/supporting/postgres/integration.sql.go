@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/sqlc-dev/pqtype"
 )
 
@@ -27,7 +28,7 @@ func (q *Queries) DeleteIntegration(ctx context.Context, id uuid.UUID) error {
 const findIntegrationByBotIDAndType = `-- name: FindIntegrationByBotIDAndType :one
 SELECT id, organization_id, user_id, connector_type, status,
        bot_id, connector_user_id, connector_organization_id,
-       metadata, created_at, updated_at, last_used_at
+       metadata, created_at, updated_at, last_used_at, last_synced_at, grants
 FROM integrations
 WHERE bot_id = $1 AND connector_type = $2
 `
@@ -53,6 +54,8 @@ func (q *Queries) FindIntegrationByBotIDAndType(ctx context.Context, arg FindInt
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastUsedAt,
+		&i.LastSyncedAt,
+		pq.Array(&i.Grants),
 	)
 	return i, err
 }
@@ -60,7 +63,7 @@ func (q *Queries) FindIntegrationByBotIDAndType(ctx context.Context, arg FindInt
 const findIntegrationByID = `-- name: FindIntegrationByID :one
 SELECT id, organization_id, user_id, connector_type, status,
        bot_id, connector_user_id, connector_organization_id,
-       metadata, created_at, updated_at, last_used_at
+       metadata, created_at, updated_at, last_used_at, last_synced_at, grants
 FROM integrations
 WHERE id = $1
 `
@@ -81,14 +84,68 @@ func (q *Queries) FindIntegrationByID(ctx context.Context, id uuid.UUID) (Integr
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastUsedAt,
+		&i.LastSyncedAt,
+		pq.Array(&i.Grants),
 	)
 	return i, err
 }
 
+const findIntegrationsByConnectorOrganizationIDAndType = `-- name: FindIntegrationsByConnectorOrganizationIDAndType :many
+SELECT id, organization_id, user_id, connector_type, status,
+       bot_id, connector_user_id, connector_organization_id,
+       metadata, created_at, updated_at, last_used_at, last_synced_at, grants
+FROM integrations
+WHERE connector_organization_id = $1 AND connector_type = $2
+ORDER BY created_at DESC
+`
+
+type FindIntegrationsByConnectorOrganizationIDAndTypeParams struct {
+	ConnectorOrganizationID sql.NullString `json:"connector_organization_id"`
+	ConnectorType           string         `json:"connector_type"`
+}
+
+func (q *Queries) FindIntegrationsByConnectorOrganizationIDAndType(ctx context.Context, arg FindIntegrationsByConnectorOrganizationIDAndTypeParams) ([]Integration, error) {
+	rows, err := q.query(ctx, q.findIntegrationsByConnectorOrganizationIDAndTypeStmt, findIntegrationsByConnectorOrganizationIDAndType, arg.ConnectorOrganizationID, arg.ConnectorType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Integration
+	for rows.Next() {
+		var i Integration
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.UserID,
+			&i.ConnectorType,
+			&i.Status,
+			&i.BotID,
+			&i.ConnectorUserID,
+			&i.ConnectorOrganizationID,
+			&i.Metadata,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LastUsedAt,
+			&i.LastSyncedAt,
+			pq.Array(&i.Grants),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const findIntegrationsByOrganization = `-- name: FindIntegrationsByOrganization :many
 SELECT id, organization_id, user_id, connector_type, status,
        bot_id, connector_user_id, connector_organization_id,
-       metadata, created_at, updated_at, last_used_at
+       metadata, created_at, updated_at, last_used_at, last_synced_at, grants
 FROM integrations
 WHERE organization_id = $1
 ORDER BY created_at DESC
@@ -116,6 +173,8 @@ func (q *Queries) FindIntegrationsByOrganization(ctx context.Context, organizati
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.LastUsedAt,
+			&i.LastSyncedAt,
+			pq.Array(&i.Grants),
 		); err != nil {
 			return nil, err
 		}
@@ -133,7 +192,7 @@ func (q *Queries) FindIntegrationsByOrganization(ctx context.Context, organizati
 const findIntegrationsByOrganizationAndStatus = `-- name: FindIntegrationsByOrganizationAndStatus :many
 SELECT id, organization_id, user_id, connector_type, status,
        bot_id, connector_user_id, connector_organization_id,
-       metadata, created_at, updated_at, last_used_at
+       metadata, created_at, updated_at, last_used_at, last_synced_at, grants
 FROM integrations
 WHERE organization_id = $1 AND status = $2
 ORDER BY created_at DESC
@@ -166,6 +225,8 @@ func (q *Queries) FindIntegrationsByOrganizationAndStatus(ctx context.Context, a
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.LastUsedAt,
+			&i.LastSyncedAt,
+			pq.Array(&i.Grants),
 		); err != nil {
 			return nil, err
 		}
@@ -183,7 +244,7 @@ func (q *Queries) FindIntegrationsByOrganizationAndStatus(ctx context.Context, a
 const findIntegrationsByOrganizationAndType = `-- name: FindIntegrationsByOrganizationAndType :many
 SELECT id, organization_id, user_id, connector_type, status,
        bot_id, connector_user_id, connector_organization_id,
-       metadata, created_at, updated_at, last_used_at
+       metadata, created_at, updated_at, last_used_at, last_synced_at, grants
 FROM integrations
 WHERE organization_id = $1 AND connector_type = $2
 ORDER BY created_at DESC
@@ -216,6 +277,8 @@ func (q *Queries) FindIntegrationsByOrganizationAndType(ctx context.Context, arg
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.LastUsedAt,
+			&i.LastSyncedAt,
+			pq.Array(&i.Grants),
 		); err != nil {
 			return nil, err
 		}
@@ -233,7 +296,7 @@ func (q *Queries) FindIntegrationsByOrganizationAndType(ctx context.Context, arg
 const findIntegrationsByOrganizationTypeAndStatus = `-- name: FindIntegrationsByOrganizationTypeAndStatus :many
 SELECT id, organization_id, user_id, connector_type, status,
        bot_id, connector_user_id, connector_organization_id,
-       metadata, created_at, updated_at, last_used_at
+       metadata, created_at, updated_at, last_used_at, last_synced_at, grants
 FROM integrations
 WHERE organization_id = $1 AND connector_type = $2 AND status = $3
 ORDER BY created_at DESC
@@ -267,6 +330,61 @@ func (q *Queries) FindIntegrationsByOrganizationTypeAndStatus(ctx context.Contex
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.LastUsedAt,
+			&i.LastSyncedAt,
+			pq.Array(&i.Grants),
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findIntegrationsDueForSync = `-- name: FindIntegrationsDueForSync :many
+SELECT id, organization_id, user_id, connector_type, status,
+       bot_id, connector_user_id, connector_organization_id,
+       metadata, created_at, updated_at, last_used_at, last_synced_at, grants
+FROM integrations
+WHERE connector_type = $1 AND status = 'active'
+  AND (last_synced_at IS NULL OR last_synced_at < $2)
+ORDER BY last_synced_at NULLS FIRST
+`
+
+type FindIntegrationsDueForSyncParams struct {
+	ConnectorType string       `json:"connector_type"`
+	LastSyncedAt  sql.NullTime `json:"last_synced_at"`
+}
+
+func (q *Queries) FindIntegrationsDueForSync(ctx context.Context, arg FindIntegrationsDueForSyncParams) ([]Integration, error) {
+	rows, err := q.query(ctx, q.findIntegrationsDueForSyncStmt, findIntegrationsDueForSync, arg.ConnectorType, arg.LastSyncedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Integration
+	for rows.Next() {
+		var i Integration
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.UserID,
+			&i.ConnectorType,
+			&i.Status,
+			&i.BotID,
+			&i.ConnectorUserID,
+			&i.ConnectorOrganizationID,
+			&i.Metadata,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.LastUsedAt,
+			&i.LastSyncedAt,
+			pq.Array(&i.Grants),
 		); err != nil {
 			return nil, err
 		}
@@ -285,9 +403,9 @@ const storeIntegration = `-- name: StoreIntegration :exec
 INSERT INTO integrations (
     id, organization_id, user_id, connector_type, status, 
     bot_id, connector_user_id, connector_organization_id, 
-    metadata, created_at, updated_at, last_used_at
+    metadata, created_at, updated_at, last_used_at, grants
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
 )
 `
 
@@ -304,6 +422,7 @@ type StoreIntegrationParams struct {
 	CreatedAt               time.Time             `json:"created_at"`
 	UpdatedAt               time.Time             `json:"updated_at"`
 	LastUsedAt              sql.NullTime          `json:"last_used_at"`
+	Grants                  []string              `json:"grants"`
 }
 
 func (q *Queries) StoreIntegration(ctx context.Context, arg StoreIntegrationParams) error {
@@ -320,6 +439,7 @@ func (q *Queries) StoreIntegration(ctx context.Context, arg StoreIntegrationPara
 		arg.CreatedAt,
 		arg.UpdatedAt,
 		arg.LastUsedAt,
+		pq.Array(arg.Grants),
 	)
 	return err
 }
@@ -364,6 +484,38 @@ func (q *Queries) UpdateIntegration(ctx context.Context, arg UpdateIntegrationPa
 	return err
 }
 
+const updateIntegrationGrants = `-- name: UpdateIntegrationGrants :exec
+UPDATE integrations
+SET grants = $2, updated_at = NOW()
+WHERE id = $1
+`
+
+type UpdateIntegrationGrantsParams struct {
+	ID     uuid.UUID `json:"id"`
+	Grants []string  `json:"grants"`
+}
+
+func (q *Queries) UpdateIntegrationGrants(ctx context.Context, arg UpdateIntegrationGrantsParams) error {
+	_, err := q.exec(ctx, q.updateIntegrationGrantsStmt, updateIntegrationGrants, arg.ID, pq.Array(arg.Grants))
+	return err
+}
+
+const updateIntegrationLastSynced = `-- name: UpdateIntegrationLastSynced :exec
+UPDATE integrations
+SET last_synced_at = $2
+WHERE id = $1
+`
+
+type UpdateIntegrationLastSyncedParams struct {
+	ID           uuid.UUID    `json:"id"`
+	LastSyncedAt sql.NullTime `json:"last_synced_at"`
+}
+
+func (q *Queries) UpdateIntegrationLastSynced(ctx context.Context, arg UpdateIntegrationLastSyncedParams) error {
+	_, err := q.exec(ctx, q.updateIntegrationLastSyncedStmt, updateIntegrationLastSynced, arg.ID, arg.LastSyncedAt)
+	return err
+}
+
 const updateIntegrationLastUsed = `-- name: UpdateIntegrationLastUsed :exec
 UPDATE integrations
 SET last_used_at = NOW(), updated_at = NOW()
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+type integrationDataRepository struct {
+	db      *sql.DB
+	queries *Queries
+}
+
+func NewIntegrationDataRepository(sqlDB *sql.DB) domain.IntegrationDataRepository {
+	return &integrationDataRepository{
+		db:      sqlDB,
+		queries: New(pgretry.Wrap(sqlDB)),
+	}
+}
+
+func (r *integrationDataRepository) DeleteIntegrationData(ctx context.Context, integrationID uuid.UUID, dryRun bool) (domain.IntegrationData, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.IntegrationData{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	qtx := r.queries.WithTx(tx)
+
+	activities, err := qtx.DeleteIntegrationActivityByIntegration(ctx, integrationID)
+	if err != nil {
+		return domain.IntegrationData{}, fmt.Errorf("failed to delete integration activity: %w", err)
+	}
+	if _, err := qtx.DeleteIntegrationSyncJobsByIntegration(ctx, integrationID); err != nil {
+		return domain.IntegrationData{}, fmt.Errorf("failed to delete sync jobs: %w", err)
+	}
+	repositories, err := qtx.DeleteGitHubRepositoriesByIntegration(ctx, integrationID)
+	if err != nil {
+		return domain.IntegrationData{}, fmt.Errorf("failed to delete repositories: %w", err)
+	}
+	azureRepositories, err := qtx.DeleteAzureDevOpsRepositoriesByIntegration(ctx, integrationID)
+	if err != nil {
+		return domain.IntegrationData{}, fmt.Errorf("failed to delete azure devops repositories: %w", err)
+	}
+	credentials, err := qtx.DeleteCredentialsByIntegration(ctx, integrationID)
+	if err != nil {
+		return domain.IntegrationData{}, fmt.Errorf("failed to delete credentials: %w", err)
+	}
+	if err := qtx.DeleteIntegration(ctx, integrationID); err != nil {
+		return domain.IntegrationData{}, fmt.Errorf("failed to delete integration: %w", err)
+	}
+
+	data := domain.IntegrationData{
+		Credentials:  int(credentials),
+		Repositories: int(repositories + azureRepositories),
+		Activities:   int(activities),
+	}
+	if dryRun {
+		return data, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return domain.IntegrationData{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return data, nil
+}
@@ -9,8 +9,10 @@ import (
 	"time"
 
 	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
 	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/sqlc-dev/pqtype"
 )
 
@@ -20,7 +22,7 @@ type integrationRepository struct {
 
 func NewIntegrationRepository(sqlDB *sql.DB) domain.IntegrationRepository {
 	return &integrationRepository{
-		queries: New(sqlDB),
+		queries: New(pgretry.Wrap(sqlDB)),
 	}
 }
 
@@ -59,7 +61,12 @@ func (r *integrationRepository) Store(ctx context.Context, integration backend.I
 		lastUsedAt = sql.NullTime{Time: *integration.LastUsedAt, Valid: true}
 	}
 
-	return r.queries.StoreIntegration(ctx, StoreIntegrationParams{
+	grants := integration.Grants
+	if len(grants) == 0 {
+		grants = backend.DefaultIntegrationGrants
+	}
+
+	err = r.queries.StoreIntegration(ctx, StoreIntegrationParams{
 		ID:                      integrationID,
 		OrganizationID:          organizationID,
 		UserID:                  userID,
@@ -72,7 +79,13 @@ func (r *integrationRepository) Store(ctx context.Context, integration backend.I
 		CreatedAt:               integration.CreatedAt,
 		UpdatedAt:               integration.UpdatedAt,
 		LastUsedAt:              lastUsedAt,
+		Grants:                  grantStrings(grants),
 	})
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return fmt.Errorf("%w for organization %s and connector %s", backend.ErrIntegrationAlreadyExists, organizationID, integration.ConnectorType)
+	}
+	return err
 }
 
 func (r *integrationRepository) Update(ctx context.Context, integration backend.Integration) error {
@@ -124,6 +137,9 @@ func (r *integrationRepository) Update(ctx context.Context, integration backend.
 func (r *integrationRepository) FindByID(ctx context.Context, id uuid.UUID) (backend.Integration, error) {
 	dbIntegration, err := r.queries.FindIntegrationByID(ctx, id)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return backend.Integration{}, backend.ErrIntegrationNotFound
+		}
 		return backend.Integration{}, fmt.Errorf("failed to find integration: %w", err)
 	}
 
@@ -223,6 +239,85 @@ func (r *integrationRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID
 	return r.queries.UpdateIntegrationLastUsed(ctx, id)
 }
 
+func (r *integrationRepository) FindDueForSync(ctx context.Context, connectorType backend.ConnectorType, syncedBefore time.Time) ([]backend.Integration, error) {
+	dbIntegrations, err := r.queries.FindIntegrationsDueForSync(ctx, FindIntegrationsDueForSyncParams{
+		ConnectorType: string(connectorType),
+		LastSyncedAt:  sql.NullTime{Time: syncedBefore, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find integrations due for sync: %w", err)
+	}
+
+	integrations := make([]backend.Integration, len(dbIntegrations))
+	for i, dbIntegration := range dbIntegrations {
+		integration, err := r.toSpecIntegration(dbIntegration)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map integration: %w", err)
+		}
+		integrations[i] = integration
+	}
+
+	return integrations, nil
+}
+
+func (r *integrationRepository) ClaimSync(ctx context.Context, id uuid.UUID, ttl time.Duration) (bool, error) {
+	claimed, err := r.queries.ClaimIntegrationSync(ctx, ClaimIntegrationSyncParams{
+		IntegrationID: id,
+		ClaimSeconds:  int32(ttl.Seconds()),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to claim integration sync: %w", err)
+	}
+	return claimed > 0, nil
+}
+
+func (r *integrationRepository) RecordAuthorization(ctx context.Context, key string, id uuid.UUID) error {
+	err := r.queries.RecordIntegrationAuthorization(ctx, RecordIntegrationAuthorizationParams{
+		AuthorizationKey: key,
+		IntegrationID:    id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record integration authorization: %w", err)
+	}
+	return nil
+}
+
+func (r *integrationRepository) FindByAuthorization(ctx context.Context, key string, maxAge time.Duration) (backend.Integration, error) {
+	id, err := r.queries.FindIntegrationIDByAuthorization(ctx, FindIntegrationIDByAuthorizationParams{
+		AuthorizationKey: key,
+		MaxAgeSeconds:    int32(maxAge.Seconds()),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return backend.Integration{}, backend.ErrIntegrationNotFound
+		}
+		return backend.Integration{}, fmt.Errorf("failed to find integration authorization: %w", err)
+	}
+	return r.FindByID(ctx, id)
+}
+
+func (r *integrationRepository) UpdateLastSynced(ctx context.Context, id uuid.UUID, syncedAt time.Time) error {
+	return r.queries.UpdateIntegrationLastSynced(ctx, UpdateIntegrationLastSyncedParams{
+		ID:           id,
+		LastSyncedAt: sql.NullTime{Time: syncedAt, Valid: true},
+	})
+}
+
+func (r *integrationRepository) UpdateGrants(ctx context.Context, id uuid.UUID, grants []backend.IntegrationGrant) error {
+	return r.queries.UpdateIntegrationGrants(ctx, UpdateIntegrationGrantsParams{
+		ID:     id,
+		Grants: grantStrings(grants),
+	})
+}
+
+func grantStrings(grants []backend.IntegrationGrant) []string {
+	strs := make([]string, len(grants))
+	for i, grant := range grants {
+		strs[i] = string(grant)
+	}
+	return strs
+}
+
 func (r *integrationRepository) UpdateMetadata(ctx context.Context, id uuid.UUID, metadata map[string]string) error {
 	metadataMap := make(map[string]any)
 	for k, v := range metadata {
@@ -245,6 +340,27 @@ func (r *integrationRepository) Delete(ctx context.Context, id uuid.UUID) error
 	return r.queries.DeleteIntegration(ctx, id)
 }
 
+func (r *integrationRepository) FindByConnectorOrganizationIDAndType(ctx context.Context, connectorOrganizationID string, connectorType backend.ConnectorType) ([]backend.Integration, error) {
+	dbIntegrations, err := r.queries.FindIntegrationsByConnectorOrganizationIDAndType(ctx, FindIntegrationsByConnectorOrganizationIDAndTypeParams{
+		ConnectorOrganizationID: sql.NullString{String: connectorOrganizationID, Valid: true},
+		ConnectorType:           string(connectorType),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find integrations by connector organization ID: %w", err)
+	}
+
+	integrations := make([]backend.Integration, len(dbIntegrations))
+	for i, dbIntegration := range dbIntegrations {
+		integration, err := r.toSpecIntegration(dbIntegration)
+		if err != nil {
+			return nil, fmt.Errorf("failed to map integration: %w", err)
+		}
+		integrations[i] = integration
+	}
+
+	return integrations, nil
+}
+
 func (r *integrationRepository) FindByBotIDAndType(ctx context.Context, botID string, connectorType backend.ConnectorType) (backend.Integration, error) {
 	dbIntegration, err := r.queries.FindIntegrationByBotIDAndType(ctx, FindIntegrationByBotIDAndTypeParams{
 		BotID:         sql.NullString{String: botID, Valid: true},
@@ -252,7 +368,7 @@ func (r *integrationRepository) FindByBotIDAndType(ctx context.Context, botID st
 	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return backend.Integration{}, domain.ErrIntegrationNotFound
+			return backend.Integration{}, backend.ErrIntegrationNotFound
 		}
 		return backend.Integration{}, fmt.Errorf("failed to find integration by bot ID: %w", err)
 	}
@@ -280,6 +396,16 @@ func (r *integrationRepository) toSpecIntegration(dbIntegration Integration) (ba
 		lastUsedAt = &dbIntegration.LastUsedAt.Time
 	}
 
+	var lastSyncedAt *time.Time
+	if dbIntegration.LastSyncedAt.Valid {
+		lastSyncedAt = &dbIntegration.LastSyncedAt.Time
+	}
+
+	grants := make([]backend.IntegrationGrant, len(dbIntegration.Grants))
+	for i, grant := range dbIntegration.Grants {
+		grants[i] = backend.IntegrationGrant(grant)
+	}
+
 	return backend.Integration{
 		ID:                      dbIntegration.ID,
 		OrganizationID:          dbIntegration.OrganizationID,
@@ -293,5 +419,7 @@ func (r *integrationRepository) toSpecIntegration(dbIntegration Integration) (ba
 		CreatedAt:               dbIntegration.CreatedAt,
 		UpdatedAt:               dbIntegration.UpdatedAt,
 		LastUsedAt:              lastUsedAt,
+		LastSyncedAt:            lastSyncedAt,
+		Grants:                  grants,
 	}, nil
 }
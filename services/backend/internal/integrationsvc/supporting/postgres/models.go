@@ -6,32 +6,52 @@ package postgres
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sqlc-dev/pqtype"
 )
 
+type AzureDevopsRepository struct {
+	ID                 int64     `json:"id"`
+	IntegrationID      uuid.UUID `json:"integration_id"`
+	AzureRepositoryID  uuid.UUID `json:"azure_repository_id"`
+	ProjectID          uuid.UUID `json:"project_id"`
+	ProjectName        string    `json:"project_name"`
+	RepositoryName     string    `json:"repository_name"`
+	RepositoryFullName string    `json:"repository_full_name"`
+	RepositoryUrl      string    `json:"repository_url"`
+	IsPrivate          bool      `json:"is_private"`
+	DefaultBranch      string    `json:"default_branch"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+	LastSyncedAt       time.Time `json:"last_synced_at"`
+	Enabled            bool      `json:"enabled"`
+}
+
 type GithubRepository struct {
-	ID                    uuid.UUID      `json:"id"`
-	IntegrationID         uuid.UUID      `json:"integration_id"`
-	GithubRepositoryID    int64          `json:"github_repository_id"`
-	RepositoryName        string         `json:"repository_name"`
-	RepositoryFullName    string         `json:"repository_full_name"`
-	RepositoryUrl         string         `json:"repository_url"`
-	IsPrivate             bool           `json:"is_private"`
-	DefaultBranch         sql.NullString `json:"default_branch"`
-	PermissionAdmin       bool           `json:"permission_admin"`
-	PermissionPush        bool           `json:"permission_push"`
-	PermissionPull        bool           `json:"permission_pull"`
-	RepositoryDescription sql.NullString `json:"repository_description"`
-	RepositoryLanguage    sql.NullString `json:"repository_language"`
-	CreatedAt             time.Time      `json:"created_at"`
-	UpdatedAt             time.Time      `json:"updated_at"`
-	LastSyncedAt          time.Time      `json:"last_synced_at"`
-	GithubCreatedAt       sql.NullTime   `json:"github_created_at"`
-	GithubUpdatedAt       sql.NullTime   `json:"github_updated_at"`
-	GithubPushedAt        sql.NullTime   `json:"github_pushed_at"`
+	ID                    uuid.UUID             `json:"id"`
+	IntegrationID         uuid.UUID             `json:"integration_id"`
+	GithubRepositoryID    int64                 `json:"github_repository_id"`
+	RepositoryName        string                `json:"repository_name"`
+	RepositoryFullName    string                `json:"repository_full_name"`
+	RepositoryUrl         string                `json:"repository_url"`
+	IsPrivate             bool                  `json:"is_private"`
+	DefaultBranch         sql.NullString        `json:"default_branch"`
+	PermissionAdmin       bool                  `json:"permission_admin"`
+	PermissionPush        bool                  `json:"permission_push"`
+	PermissionPull        bool                  `json:"permission_pull"`
+	RepositoryDescription sql.NullString        `json:"repository_description"`
+	RepositoryLanguage    sql.NullString        `json:"repository_language"`
+	CreatedAt             time.Time             `json:"created_at"`
+	UpdatedAt             time.Time             `json:"updated_at"`
+	LastSyncedAt          time.Time             `json:"last_synced_at"`
+	GithubCreatedAt       sql.NullTime          `json:"github_created_at"`
+	GithubUpdatedAt       sql.NullTime          `json:"github_updated_at"`
+	GithubPushedAt        sql.NullTime          `json:"github_pushed_at"`
+	Enabled               bool                  `json:"enabled"`
+	RepositoryProfile     pqtype.NullRawMessage `json:"repository_profile"`
 }
 
 type Integration struct {
@@ -47,6 +67,23 @@ type Integration struct {
 	CreatedAt               time.Time             `json:"created_at"`
 	UpdatedAt               time.Time             `json:"updated_at"`
 	LastUsedAt              sql.NullTime          `json:"last_used_at"`
+	LastSyncedAt            sql.NullTime          `json:"last_synced_at"`
+	Grants                  []string              `json:"grants"`
+}
+
+type IntegrationActivity struct {
+	ID             uuid.UUID       `json:"id"`
+	IntegrationID  uuid.UUID       `json:"integration_id"`
+	OrganizationID uuid.UUID       `json:"organization_id"`
+	ActivityType   string          `json:"activity_type"`
+	Details        json.RawMessage `json:"details"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+type IntegrationAuthorization struct {
+	AuthorizationKey string    `json:"authorization_key"`
+	IntegrationID    uuid.UUID `json:"integration_id"`
+	CreatedAt        time.Time `json:"created_at"`
 }
 
 type IntegrationCredential struct {
@@ -59,3 +96,47 @@ type IntegrationCredential struct {
 	CreatedAt               time.Time    `json:"created_at"`
 	UpdatedAt               time.Time    `json:"updated_at"`
 }
+
+type IntegrationCredentialAccess struct {
+	ID             uuid.UUID `json:"id"`
+	IntegrationID  uuid.UUID `json:"integration_id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	AccessorType   string    `json:"accessor_type"`
+	AccessorID     string    `json:"accessor_id"`
+	UserID         string    `json:"user_id"`
+	IpAddress      string    `json:"ip_address"`
+	Reason         string    `json:"reason"`
+	Fields         []string  `json:"fields"`
+	AccessedAt     time.Time `json:"accessed_at"`
+	ConversationID string    `json:"conversation_id"`
+	ToolCallID     string    `json:"tool_call_id"`
+}
+
+type IntegrationSyncClaim struct {
+	IntegrationID uuid.UUID `json:"integration_id"`
+	ClaimedAt     time.Time `json:"claimed_at"`
+}
+
+type IntegrationSyncJob struct {
+	ID            uuid.UUID       `json:"id"`
+	IntegrationID uuid.UUID       `json:"integration_id"`
+	Status        string          `json:"status"`
+	Attempt       int32           `json:"attempt"`
+	Parameters    json.RawMessage `json:"parameters"`
+	Error         string          `json:"error"`
+	StartedAt     time.Time       `json:"started_at"`
+	HeartbeatAt   time.Time       `json:"heartbeat_at"`
+	FinishedAt    sql.NullTime    `json:"finished_at"`
+	Result        json.RawMessage `json:"result"`
+}
+
+type RepositoryTrigger struct {
+	ID                uuid.UUID `json:"id"`
+	OrganizationID    uuid.UUID `json:"organization_id"`
+	RepositoryPattern string    `json:"repository_pattern"`
+	EventType         string    `json:"event_type"`
+	PathGlob          string    `json:"path_glob"`
+	ChannelID         string    `json:"channel_id"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
@@ -12,30 +12,68 @@ import (
 )
 
 type Querier interface {
-	BulkDeleteGitHubRepositories(ctx context.Context, arg BulkDeleteGitHubRepositoriesParams) error
+	BulkDeleteAzureDevOpsRepositories(ctx context.Context, arg BulkDeleteAzureDevOpsRepositoriesParams) error
+	BulkDeleteGitHubRepositories(ctx context.Context, arg BulkDeleteGitHubRepositoriesParams) ([]int64, error)
+	ClaimIntegrationSync(ctx context.Context, arg ClaimIntegrationSyncParams) (int64, error)
+	CountCredentialAccess(ctx context.Context, arg CountCredentialAccessParams) (int64, error)
+	CountIntegrationActivity(ctx context.Context, arg CountIntegrationActivityParams) (int64, error)
+	CountIntegrationSyncJobsSince(ctx context.Context, arg CountIntegrationSyncJobsSinceParams) (int64, error)
+	CountRepositoryTriggersByOrganization(ctx context.Context, organizationID uuid.UUID) (int64, error)
+	DeleteAzureDevOpsRepositoriesByIntegration(ctx context.Context, integrationID uuid.UUID) (int64, error)
 	DeleteCredential(ctx context.Context, integrationID uuid.UUID) error
+	DeleteCredentialsByIntegration(ctx context.Context, integrationID uuid.UUID) (int64, error)
+	DeleteGitHubRepositoriesByIntegration(ctx context.Context, integrationID uuid.UUID) (int64, error)
 	DeleteGitHubRepositoryByGitHubID(ctx context.Context, arg DeleteGitHubRepositoryByGitHubIDParams) error
 	DeleteIntegration(ctx context.Context, id uuid.UUID) error
+	DeleteIntegrationActivityByIntegration(ctx context.Context, integrationID uuid.UUID) (int64, error)
+	DeleteIntegrationSyncJobsByIntegration(ctx context.Context, integrationID uuid.UUID) (int64, error)
+	DeleteRepositoryTrigger(ctx context.Context, arg DeleteRepositoryTriggerParams) (int64, error)
+	DeleteRepositoryTriggersByOrganization(ctx context.Context, organizationID uuid.UUID) (int64, error)
+	FailStaleIntegrationSyncJobs(ctx context.Context, arg FailStaleIntegrationSyncJobsParams) ([]IntegrationSyncJob, error)
+	FindAllRepositoryTriggers(ctx context.Context) ([]RepositoryTrigger, error)
+	FindAzureDevOpsRepositoriesByIntegrationID(ctx context.Context, integrationID uuid.UUID) ([]AzureDevopsRepository, error)
 	FindCredentialByIntegration(ctx context.Context, integrationID uuid.UUID) (IntegrationCredential, error)
 	FindExpiringCredentials(ctx context.Context, expiresAt sql.NullTime) ([]IntegrationCredential, error)
 	FindGitHubRepositoriesByIntegrationID(ctx context.Context, integrationID uuid.UUID) ([]GithubRepository, error)
 	FindGitHubRepositoryByGitHubID(ctx context.Context, arg FindGitHubRepositoryByGitHubIDParams) (GithubRepository, error)
 	FindIntegrationByBotIDAndType(ctx context.Context, arg FindIntegrationByBotIDAndTypeParams) (Integration, error)
 	FindIntegrationByID(ctx context.Context, id uuid.UUID) (Integration, error)
+	FindIntegrationIDByAuthorization(ctx context.Context, arg FindIntegrationIDByAuthorizationParams) (uuid.UUID, error)
+	FindIntegrationsByConnectorOrganizationIDAndType(ctx context.Context, arg FindIntegrationsByConnectorOrganizationIDAndTypeParams) ([]Integration, error)
 	FindIntegrationsByOrganization(ctx context.Context, organizationID uuid.UUID) ([]Integration, error)
 	FindIntegrationsByOrganizationAndStatus(ctx context.Context, arg FindIntegrationsByOrganizationAndStatusParams) ([]Integration, error)
 	FindIntegrationsByOrganizationAndType(ctx context.Context, arg FindIntegrationsByOrganizationAndTypeParams) ([]Integration, error)
 	FindIntegrationsByOrganizationTypeAndStatus(ctx context.Context, arg FindIntegrationsByOrganizationTypeAndStatusParams) ([]Integration, error)
+	FindIntegrationsDueForSync(ctx context.Context, arg FindIntegrationsDueForSyncParams) ([]Integration, error)
+	FindRepositoryTriggersByOrganization(ctx context.Context, organizationID uuid.UUID) ([]RepositoryTrigger, error)
+	FinishIntegrationSyncJob(ctx context.Context, arg FinishIntegrationSyncJobParams) error
+	GetLastSucceededIntegrationSyncJob(ctx context.Context, integrationID uuid.UUID) (GetLastSucceededIntegrationSyncJobRow, error)
+	HeartbeatIntegrationSyncJob(ctx context.Context, arg HeartbeatIntegrationSyncJobParams) (int64, error)
+	ListCredentialAccess(ctx context.Context, arg ListCredentialAccessParams) ([]IntegrationCredentialAccess, error)
+	ListIntegrationActivity(ctx context.Context, arg ListIntegrationActivityParams) ([]IntegrationActivity, error)
+	RecordIntegrationAuthorization(ctx context.Context, arg RecordIntegrationAuthorizationParams) error
+	SetAzureDevOpsRepositoriesEnabled(ctx context.Context, arg SetAzureDevOpsRepositoriesEnabledParams) (int64, error)
+	SetGitHubRepositoriesEnabled(ctx context.Context, arg SetGitHubRepositoriesEnabledParams) (int64, error)
 	StoreCredential(ctx context.Context, arg StoreCredentialParams) error
+	StoreCredentialAccess(ctx context.Context, arg StoreCredentialAccessParams) error
 	StoreIntegration(ctx context.Context, arg StoreIntegrationParams) error
+	StoreIntegrationActivity(ctx context.Context, arg StoreIntegrationActivityParams) error
+	StoreIntegrationSyncJob(ctx context.Context, arg StoreIntegrationSyncJobParams) error
+	StoreRepositoryTrigger(ctx context.Context, arg StoreRepositoryTriggerParams) error
+	UpdateAzureDevOpsRepositoryLastSyncTime(ctx context.Context, arg UpdateAzureDevOpsRepositoryLastSyncTimeParams) error
 	UpdateCredential(ctx context.Context, arg UpdateCredentialParams) error
 	UpdateGitHubRepositoryLastSyncTime(ctx context.Context, arg UpdateGitHubRepositoryLastSyncTimeParams) error
 	UpdateGitHubRepositoryPermissions(ctx context.Context, arg UpdateGitHubRepositoryPermissionsParams) error
+	UpdateGitHubRepositoryProfile(ctx context.Context, arg UpdateGitHubRepositoryProfileParams) error
 	UpdateIntegration(ctx context.Context, arg UpdateIntegrationParams) error
+	UpdateIntegrationGrants(ctx context.Context, arg UpdateIntegrationGrantsParams) error
+	UpdateIntegrationLastSynced(ctx context.Context, arg UpdateIntegrationLastSyncedParams) error
 	UpdateIntegrationLastUsed(ctx context.Context, id uuid.UUID) error
 	UpdateIntegrationMetadata(ctx context.Context, arg UpdateIntegrationMetadataParams) error
 	UpdateIntegrationStatus(ctx context.Context, arg UpdateIntegrationStatusParams) error
+	UpdateRepositoryTrigger(ctx context.Context, arg UpdateRepositoryTriggerParams) (int64, error)
 	// GitHub Repository Queries
+	UpsertAzureDevOpsRepository(ctx context.Context, arg UpsertAzureDevOpsRepositoryParams) error
 	UpsertGitHubRepository(ctx context.Context, arg UpsertGitHubRepositoryParams) error
 }
 
@@ -0,0 +1,44 @@
+-- Azure DevOps Repository Queries
+
+-- name: UpsertAzureDevOpsRepository :exec
+INSERT INTO azure_devops_repositories (
+    integration_id, azure_repository_id, project_id, project_name,
+    repository_name, repository_full_name, repository_url, is_private,
+    default_branch, created_at, updated_at, last_synced_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+ON CONFLICT (integration_id, azure_repository_id)
+DO UPDATE SET
+    project_id = EXCLUDED.project_id,
+    project_name = EXCLUDED.project_name,
+    repository_name = EXCLUDED.repository_name,
+    repository_full_name = EXCLUDED.repository_full_name,
+    repository_url = EXCLUDED.repository_url,
+    is_private = EXCLUDED.is_private,
+    default_branch = EXCLUDED.default_branch,
+    updated_at = EXCLUDED.updated_at,
+    last_synced_at = EXCLUDED.last_synced_at;
+
+-- name: FindAzureDevOpsRepositoriesByIntegrationID :many
+SELECT id, integration_id, azure_repository_id, project_id, project_name,
+    repository_name, repository_full_name, repository_url, is_private,
+    default_branch, created_at, updated_at, last_synced_at, enabled
+FROM azure_devops_repositories
+WHERE integration_id = $1
+ORDER BY repository_full_name;
+
+-- name: BulkDeleteAzureDevOpsRepositories :exec
+DELETE FROM azure_devops_repositories
+WHERE integration_id = $1 AND azure_repository_id = ANY($2::uuid[]);
+
+-- name: SetAzureDevOpsRepositoriesEnabled :execrows
+UPDATE azure_devops_repositories
+SET enabled = $1, updated_at = NOW()
+WHERE integration_id = $2 AND id = ANY($3::bigint[]);
+
+-- name: UpdateAzureDevOpsRepositoryLastSyncTime :exec
+UPDATE azure_devops_repositories
+SET last_synced_at = $1, updated_at = NOW()
+WHERE integration_id = $2;
+
+-- name: DeleteAzureDevOpsRepositoriesByIntegration :execrows
+DELETE FROM azure_devops_repositories WHERE integration_id = $1;
@@ -30,4 +30,7 @@ SELECT id, integration_id, credential_type, credential_data_encrypted,
        expires_at, encryption_key_id, created_at, updated_at
 FROM integration_credentials
 WHERE expires_at IS NOT NULL AND expires_at < $1
-ORDER BY expires_at ASC;
\ No newline at end of file
+ORDER BY expires_at ASC;
+
+-- name: DeleteCredentialsByIntegration :execrows
+DELETE FROM integration_credentials WHERE integration_id = $1;
@@ -15,9 +15,6 @@ DO UPDATE SET
     repository_url = EXCLUDED.repository_url,
     is_private = EXCLUDED.is_private,
     default_branch = EXCLUDED.default_branch,
-    permission_admin = EXCLUDED.permission_admin,
-    permission_push = EXCLUDED.permission_push,
-    permission_pull = EXCLUDED.permission_pull,
     repository_description = EXCLUDED.repository_description,
     repository_language = EXCLUDED.repository_language,
     updated_at = EXCLUDED.updated_at,
@@ -30,7 +27,8 @@ SELECT id, integration_id, github_repository_id, repository_name,
     repository_full_name, repository_url, is_private, default_branch,
     permission_admin, permission_push, permission_pull,
     repository_description, repository_language, created_at, updated_at,
-    last_synced_at, github_created_at, github_updated_at, github_pushed_at
+    last_synced_at, github_created_at, github_updated_at, github_pushed_at, enabled,
+    repository_profile
 FROM github_repositories 
 WHERE integration_id = $1
 ORDER BY repository_full_name;
@@ -40,7 +38,8 @@ SELECT id, integration_id, github_repository_id, repository_name,
     repository_full_name, repository_url, is_private, default_branch,
     permission_admin, permission_push, permission_pull,
     repository_description, repository_language, created_at, updated_at,
-    last_synced_at, github_created_at, github_updated_at, github_pushed_at
+    last_synced_at, github_created_at, github_updated_at, github_pushed_at, enabled,
+    repository_profile
 FROM github_repositories 
 WHERE integration_id = $1 AND github_repository_id = $2;
 
@@ -56,11 +55,25 @@ SET permission_admin = $1,
     updated_at = NOW()
 WHERE integration_id = $4 AND github_repository_id = $5;
 
--- name: BulkDeleteGitHubRepositories :exec
+-- name: BulkDeleteGitHubRepositories :many
 DELETE FROM github_repositories 
-WHERE integration_id = $1 AND github_repository_id = ANY($2::bigint[]);
+WHERE integration_id = $1 AND github_repository_id = ANY($2::bigint[])
+RETURNING github_repository_id;
+
+-- name: UpdateGitHubRepositoryProfile :exec
+UPDATE github_repositories
+SET repository_profile = $1, updated_at = NOW()
+WHERE integration_id = $2 AND github_repository_id = $3;
 
 -- name: UpdateGitHubRepositoryLastSyncTime :exec
 UPDATE github_repositories 
 SET last_synced_at = $1, updated_at = NOW()
-WHERE integration_id = $2;
\ No newline at end of file
+WHERE integration_id = $2;
+
+-- name: DeleteGitHubRepositoriesByIntegration :execrows
+DELETE FROM github_repositories WHERE integration_id = $1;
+
+-- name: SetGitHubRepositoriesEnabled :execrows
+UPDATE github_repositories
+SET enabled = $1, updated_at = NOW()
+WHERE integration_id = $2 AND github_repository_id = ANY($3::bigint[]);
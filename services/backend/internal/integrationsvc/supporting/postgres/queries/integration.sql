@@ -2,22 +2,22 @@
 INSERT INTO integrations (
     id, organization_id, user_id, connector_type, status, 
     bot_id, connector_user_id, connector_organization_id, 
-    metadata, created_at, updated_at, last_used_at
+    metadata, created_at, updated_at, last_used_at, grants
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
 );
 
 -- name: FindIntegrationByID :one
 SELECT id, organization_id, user_id, connector_type, status,
        bot_id, connector_user_id, connector_organization_id,
-       metadata, created_at, updated_at, last_used_at
+       metadata, created_at, updated_at, last_used_at, last_synced_at, grants
 FROM integrations
 WHERE id = $1;
 
 -- name: FindIntegrationsByOrganization :many
 SELECT id, organization_id, user_id, connector_type, status,
        bot_id, connector_user_id, connector_organization_id,
-       metadata, created_at, updated_at, last_used_at
+       metadata, created_at, updated_at, last_used_at, last_synced_at, grants
 FROM integrations
 WHERE organization_id = $1
 ORDER BY created_at DESC;
@@ -25,15 +25,23 @@ ORDER BY created_at DESC;
 -- name: FindIntegrationsByOrganizationAndType :many
 SELECT id, organization_id, user_id, connector_type, status,
        bot_id, connector_user_id, connector_organization_id,
-       metadata, created_at, updated_at, last_used_at
+       metadata, created_at, updated_at, last_used_at, last_synced_at, grants
 FROM integrations
 WHERE organization_id = $1 AND connector_type = $2
 ORDER BY created_at DESC;
 
+-- name: FindIntegrationsByConnectorOrganizationIDAndType :many
+SELECT id, organization_id, user_id, connector_type, status,
+       bot_id, connector_user_id, connector_organization_id,
+       metadata, created_at, updated_at, last_used_at, last_synced_at, grants
+FROM integrations
+WHERE connector_organization_id = $1 AND connector_type = $2
+ORDER BY created_at DESC;
+
 -- name: FindIntegrationsByOrganizationAndStatus :many
 SELECT id, organization_id, user_id, connector_type, status,
        bot_id, connector_user_id, connector_organization_id,
-       metadata, created_at, updated_at, last_used_at
+       metadata, created_at, updated_at, last_used_at, last_synced_at, grants
 FROM integrations
 WHERE organization_id = $1 AND status = $2
 ORDER BY created_at DESC;
@@ -41,7 +49,7 @@ ORDER BY created_at DESC;
 -- name: FindIntegrationsByOrganizationTypeAndStatus :many
 SELECT id, organization_id, user_id, connector_type, status,
        bot_id, connector_user_id, connector_organization_id,
-       metadata, created_at, updated_at, last_used_at
+       metadata, created_at, updated_at, last_used_at, last_synced_at, grants
 FROM integrations
 WHERE organization_id = $1 AND connector_type = $2 AND status = $3
 ORDER BY created_at DESC;
@@ -63,7 +71,7 @@ WHERE id = $1;
 -- name: FindIntegrationByBotIDAndType :one
 SELECT id, organization_id, user_id, connector_type, status,
        bot_id, connector_user_id, connector_organization_id,
-       metadata, created_at, updated_at, last_used_at
+       metadata, created_at, updated_at, last_used_at, last_synced_at, grants
 FROM integrations
 WHERE bot_id = $1 AND connector_type = $2;
 
@@ -82,4 +90,23 @@ SET connector_type = $2,
     metadata = $7,
     updated_at = $8,
     last_used_at = $9
+WHERE id = $1;
+
+-- name: FindIntegrationsDueForSync :many
+SELECT id, organization_id, user_id, connector_type, status,
+       bot_id, connector_user_id, connector_organization_id,
+       metadata, created_at, updated_at, last_used_at, last_synced_at, grants
+FROM integrations
+WHERE connector_type = $1 AND status = 'active'
+  AND (last_synced_at IS NULL OR last_synced_at < $2)
+ORDER BY last_synced_at NULLS FIRST;
+
+-- name: UpdateIntegrationGrants :exec
+UPDATE integrations
+SET grants = $2, updated_at = NOW()
+WHERE id = $1;
+
+-- name: UpdateIntegrationLastSynced :exec
+UPDATE integrations
+SET last_synced_at = $2
 WHERE id = $1;
\ No newline at end of file
@@ -0,0 +1,17 @@
+-- name: StoreIntegrationActivity :exec
+INSERT INTO integration_activity (id, integration_id, organization_id, activity_type, details, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ListIntegrationActivity :many
+SELECT id, integration_id, organization_id, activity_type, details, created_at
+FROM integration_activity
+WHERE integration_id = $1 AND organization_id = $2 AND created_at >= $3 AND created_at < $4
+ORDER BY created_at DESC, id DESC
+LIMIT $5 OFFSET $6;
+
+-- name: CountIntegrationActivity :one
+SELECT COUNT(*) FROM integration_activity
+WHERE integration_id = $1 AND organization_id = $2 AND created_at >= $3 AND created_at < $4;
+
+-- name: DeleteIntegrationActivityByIntegration :execrows
+DELETE FROM integration_activity WHERE integration_id = $1;
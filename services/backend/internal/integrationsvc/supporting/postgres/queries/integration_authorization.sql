@@ -0,0 +1,10 @@
+-- name: RecordIntegrationAuthorization :exec
+INSERT INTO integration_authorizations (authorization_key, integration_id, created_at)
+VALUES (@authorization_key, @integration_id, NOW())
+ON CONFLICT (authorization_key) DO UPDATE
+SET integration_id = EXCLUDED.integration_id, created_at = EXCLUDED.created_at;
+
+-- name: FindIntegrationIDByAuthorization :one
+SELECT integration_id FROM integration_authorizations
+WHERE authorization_key = @authorization_key
+  AND created_at > NOW() - @max_age_seconds::int * INTERVAL '1 second';
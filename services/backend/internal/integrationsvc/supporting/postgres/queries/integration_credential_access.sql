@@ -0,0 +1,14 @@
+-- name: StoreCredentialAccess :exec
+INSERT INTO integration_credential_access (id, integration_id, organization_id, accessor_type, accessor_id, user_id, ip_address, reason, fields, accessed_at, conversation_id, tool_call_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
+
+-- name: ListCredentialAccess :many
+SELECT id, integration_id, organization_id, accessor_type, accessor_id, user_id, ip_address, reason, fields, accessed_at, conversation_id, tool_call_id
+FROM integration_credential_access
+WHERE integration_id = $1 AND accessed_at >= $2 AND accessed_at < $3
+ORDER BY accessed_at DESC, id DESC
+LIMIT $4 OFFSET $5;
+
+-- name: CountCredentialAccess :one
+SELECT COUNT(*) FROM integration_credential_access
+WHERE integration_id = $1 AND accessed_at >= $2 AND accessed_at < $3;
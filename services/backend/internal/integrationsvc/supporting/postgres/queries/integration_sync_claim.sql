@@ -0,0 +1,6 @@
+-- name: ClaimIntegrationSync :execrows
+INSERT INTO integration_sync_claims (integration_id, claimed_at)
+VALUES (@integration_id, NOW())
+ON CONFLICT (integration_id) DO UPDATE
+SET claimed_at = EXCLUDED.claimed_at
+WHERE integration_sync_claims.claimed_at < NOW() - @claim_seconds::int * INTERVAL '1 second';
@@ -0,0 +1,33 @@
+-- name: StoreIntegrationSyncJob :exec
+INSERT INTO integration_sync_jobs (id, integration_id, status, attempt, parameters, started_at, heartbeat_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: HeartbeatIntegrationSyncJob :execrows
+UPDATE integration_sync_jobs
+SET heartbeat_at = $1
+WHERE id = $2 AND status = 'running';
+
+-- name: FinishIntegrationSyncJob :exec
+UPDATE integration_sync_jobs
+SET status = $1, error = $2, result = $3, finished_at = $4
+WHERE id = $5;
+
+-- name: FailStaleIntegrationSyncJobs :many
+UPDATE integration_sync_jobs
+SET status = 'failed', error = $1, finished_at = $2
+WHERE status = 'running' AND heartbeat_at < $3
+RETURNING id, integration_id, status, attempt, parameters, error, started_at, heartbeat_at, finished_at, result;
+
+-- name: GetLastSucceededIntegrationSyncJob :one
+SELECT started_at, finished_at, result
+FROM integration_sync_jobs
+WHERE integration_id = $1 AND status = 'succeeded'
+ORDER BY started_at DESC
+LIMIT 1;
+
+-- name: CountIntegrationSyncJobsSince :one
+SELECT COUNT(*) FROM integration_sync_jobs
+WHERE integration_id = $1 AND status = $2 AND started_at > $3;
+
+-- name: DeleteIntegrationSyncJobsByIntegration :execrows
+DELETE FROM integration_sync_jobs WHERE integration_id = $1;
@@ -0,0 +1,27 @@
+-- name: StoreRepositoryTrigger :exec
+INSERT INTO repository_triggers (id, organization_id, repository_pattern, event_type, path_glob, channel_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: UpdateRepositoryTrigger :execrows
+UPDATE repository_triggers
+SET repository_pattern = $1, event_type = $2, path_glob = $3, channel_id = $4, updated_at = $5
+WHERE id = $6 AND organization_id = $7;
+
+-- name: DeleteRepositoryTrigger :execrows
+DELETE FROM repository_triggers WHERE id = $1 AND organization_id = $2;
+
+-- name: FindRepositoryTriggersByOrganization :many
+SELECT id, organization_id, repository_pattern, event_type, path_glob, channel_id, created_at, updated_at
+FROM repository_triggers
+WHERE organization_id = $1
+ORDER BY created_at, id;
+
+-- name: FindAllRepositoryTriggers :many
+SELECT id, organization_id, repository_pattern, event_type, path_glob, channel_id, created_at, updated_at
+FROM repository_triggers;
+
+-- name: CountRepositoryTriggersByOrganization :one
+SELECT COUNT(*) FROM repository_triggers WHERE organization_id = $1;
+
+-- name: DeleteRepositoryTriggersByOrganization :execrows
+DELETE FROM repository_triggers WHERE organization_id = $1;
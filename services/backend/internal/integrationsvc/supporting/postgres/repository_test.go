@@ -0,0 +1,92 @@
+package postgres_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/postgrestest"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/connectors/github"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/integrationtest"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/repositorytest"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/supporting/postgres"
+)
+
+func TestMain(m *testing.M) {
+	postgrestest.Main(m)
+}
+
+type fixture struct {
+	db          *sql.DB
+	credentials domain.CredentialRepository
+}
+
+func (f fixture) IntegrationRepository() domain.IntegrationRepository {
+	return postgres.NewIntegrationRepository(f.db)
+}
+
+func (f fixture) CredentialRepository() domain.CredentialRepository {
+	return f.credentials
+}
+
+func (f fixture) GitHubRepositoryRepository() github.GitHubRepositoryRepository {
+	return postgres.NewGitHubRepositoryRepository(f.db)
+}
+
+func (f fixture) ActivityRepository() domain.ActivityRepository {
+	return postgres.NewActivityRepository(f.db)
+}
+
+func (f fixture) CredentialAccessRepository() domain.CredentialAccessRepository {
+	return postgres.NewCredentialAccessRepository(f.db)
+}
+
+func (f fixture) RepositoryTriggerRepository() domain.RepositoryTriggerRepository {
+	return postgres.NewRepositoryTriggerRepository(f.db)
+}
+
+func (f fixture) SyncJobRepository() domain.SyncJobRepository {
+	return postgres.NewSyncJobRepository(f.db)
+}
+
+func (f fixture) Reset(t *testing.T) {
+	postgrestest.Truncate(t, f.db, "integrations", "integration_credentials", "github_repositories", "integration_activity", "integration_credential_access", "repository_triggers", "integration_sync_jobs", "integration_sync_claims", "integration_authorizations")
+}
+
+func TestRepositories(t *testing.T) {
+	db := postgrestest.DB(t)
+
+	credentials, err := postgres.NewCredentialRepository(db)
+	if err != nil {
+		t.Fatalf("failed to create credential repository: %v", err)
+	}
+
+	repositorytest.Ensure(t, fixture{db: db, credentials: credentials})
+}
+
+func (f fixture) Service(t *testing.T, connectors map[backend.ConnectorType]domain.Connector) backend.IntegrationService {
+	f.Reset(t)
+	return integrationsvc.NewService(integrationsvc.ServiceConfig{
+		IntegrationRepository:       f.IntegrationRepository(),
+		CredentialRepository:        f.credentials,
+		ActivityRepository:          f.ActivityRepository(),
+		IntegrationDataRepository:   postgres.NewIntegrationDataRepository(f.db),
+		CredentialAccessRepository:  f.CredentialAccessRepository(),
+		SyncJobRepository:           f.SyncJobRepository(),
+		RepositoryTriggerRepository: f.RepositoryTriggerRepository(),
+		Connectors:                  connectors,
+	})
+}
+
+func TestService(t *testing.T) {
+	db := postgrestest.DB(t)
+
+	credentials, err := postgres.NewCredentialRepository(db)
+	if err != nil {
+		t.Fatalf("failed to create credential repository: %v", err)
+	}
+
+	integrationtest.Ensure(t, fixture{db: db, credentials: credentials})
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+type repositoryTriggerRepository struct {
+	queries *Queries
+}
+
+func NewRepositoryTriggerRepository(db *sql.DB) domain.RepositoryTriggerRepository {
+	return &repositoryTriggerRepository{queries: New(pgretry.Wrap(db))}
+}
+
+func (r *repositoryTriggerRepository) Store(ctx context.Context, trigger backend.RepositoryTrigger) error {
+	err := r.queries.StoreRepositoryTrigger(ctx, StoreRepositoryTriggerParams{
+		ID:                trigger.ID,
+		OrganizationID:    trigger.OrganizationID,
+		RepositoryPattern: trigger.RepositoryPattern,
+		EventType:         string(trigger.EventType),
+		PathGlob:          trigger.PathGlob,
+		ChannelID:         trigger.Channel,
+		CreatedAt:         trigger.CreatedAt,
+		UpdatedAt:         trigger.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store repository trigger: %w", err)
+	}
+	return nil
+}
+
+func (r *repositoryTriggerRepository) Update(ctx context.Context, trigger backend.RepositoryTrigger) error {
+	updated, err := r.queries.UpdateRepositoryTrigger(ctx, UpdateRepositoryTriggerParams{
+		RepositoryPattern: trigger.RepositoryPattern,
+		EventType:         string(trigger.EventType),
+		PathGlob:          trigger.PathGlob,
+		ChannelID:         trigger.Channel,
+		UpdatedAt:         trigger.UpdatedAt,
+		ID:                trigger.ID,
+		OrganizationID:    trigger.OrganizationID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update repository trigger: %w", err)
+	}
+	if updated == 0 {
+		return backend.ErrRepositoryTriggerNotFound
+	}
+	return nil
+}
+
+func (r *repositoryTriggerRepository) Delete(ctx context.Context, organizationID, id uuid.UUID) error {
+	deleted, err := r.queries.DeleteRepositoryTrigger(ctx, DeleteRepositoryTriggerParams{
+		ID:             id,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete repository trigger: %w", err)
+	}
+	if deleted == 0 {
+		return backend.ErrRepositoryTriggerNotFound
+	}
+	return nil
+}
+
+func (r *repositoryTriggerRepository) FindByOrganization(ctx context.Context, organizationID uuid.UUID) ([]backend.RepositoryTrigger, error) {
+	rows, err := r.queries.FindRepositoryTriggersByOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository triggers: %w", err)
+	}
+	return repositoryTriggers(rows), nil
+}
+
+func (r *repositoryTriggerRepository) FindAll(ctx context.Context) ([]backend.RepositoryTrigger, error) {
+	rows, err := r.queries.FindAllRepositoryTriggers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository triggers: %w", err)
+	}
+	return repositoryTriggers(rows), nil
+}
+
+func (r *repositoryTriggerRepository) DeleteByOrganization(ctx context.Context, organizationID uuid.UUID, dryRun bool) (int, error) {
+	var count int64
+	var err error
+	if dryRun {
+		count, err = r.queries.CountRepositoryTriggersByOrganization(ctx, organizationID)
+	} else {
+		count, err = r.queries.DeleteRepositoryTriggersByOrganization(ctx, organizationID)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete repository triggers: %w", err)
+	}
+	return int(count), nil
+}
+
+func repositoryTriggers(rows []RepositoryTrigger) []backend.RepositoryTrigger {
+	triggers := make([]backend.RepositoryTrigger, len(rows))
+	for i, row := range rows {
+		triggers[i] = backend.RepositoryTrigger{
+			ID:                row.ID,
+			OrganizationID:    row.OrganizationID,
+			RepositoryPattern: row.RepositoryPattern,
+			EventType:         backend.RepositoryEventType(row.EventType),
+			PathGlob:          row.PathGlob,
+			Channel:           row.ChannelID,
+			CreatedAt:         row.CreatedAt,
+			UpdatedAt:         row.UpdatedAt,
+		}
+	}
+	return triggers
+}
@@ -0,0 +1,27 @@
+-- Azure DevOps Repository Tracking
+-- Tracks the repositories a personal access token can read, in parallel to github_repositories
+
+CREATE TABLE azure_devops_repositories (
+    -- Numeric ID exposed as the repository ID, since Azure DevOps uses GUIDs
+    id BIGSERIAL PRIMARY KEY,
+    integration_id UUID NOT NULL REFERENCES integrations(id) ON DELETE CASCADE,
+    azure_repository_id UUID NOT NULL, -- Azure DevOps' repository GUID
+    project_id UUID NOT NULL,
+    project_name VARCHAR(255) NOT NULL,
+    repository_name VARCHAR(255) NOT NULL,
+    repository_full_name VARCHAR(512) NOT NULL, -- project/repo format
+    repository_url VARCHAR(512) NOT NULL,
+    is_private BOOLEAN NOT NULL DEFAULT true,
+    default_branch VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_synced_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    -- Deselected repositories are hidden from the agent
+    enabled BOOLEAN NOT NULL DEFAULT true,
+
+    UNIQUE(integration_id, azure_repository_id)
+);
+
+CREATE INDEX idx_azure_devops_repos_integration ON azure_devops_repositories (integration_id);
+CREATE INDEX idx_azure_devops_repos_full_name ON azure_devops_repositories (repository_full_name);
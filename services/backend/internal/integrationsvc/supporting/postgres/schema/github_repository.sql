@@ -27,6 +27,12 @@ CREATE TABLE github_repositories (
     github_created_at TIMESTAMP,
     github_updated_at TIMESTAMP,
     github_pushed_at TIMESTAMP,
+
+    -- Deselected repositories are hidden from the agent
+    enabled BOOLEAN NOT NULL DEFAULT true,
+
+    -- Languages and marker files, with when they were profiled
+    repository_profile JSONB,
     
     UNIQUE(integration_id, github_repository_id)
 );
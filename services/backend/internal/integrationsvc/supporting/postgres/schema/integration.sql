@@ -3,7 +3,7 @@ CREATE TABLE integrations (
     organization_id UUID NOT NULL,
     user_id UUID NOT NULL,
     connector_type VARCHAR(50) NOT NULL,
-    status VARCHAR(20) NOT NULL,
+    status VARCHAR(32) NOT NULL,
     bot_id VARCHAR(255),
     connector_user_id VARCHAR(255),
     connector_organization_id VARCHAR(255),
@@ -11,6 +11,8 @@ CREATE TABLE integrations (
     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
     last_used_at TIMESTAMP,
+    last_synced_at TIMESTAMP,
+    grants TEXT[] NOT NULL DEFAULT '{read_content,write_content,read_infra,write_infra}',
     
     UNIQUE(organization_id, connector_type)
 );
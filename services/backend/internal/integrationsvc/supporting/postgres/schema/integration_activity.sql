@@ -0,0 +1,10 @@
+CREATE TABLE integration_activity (
+    id UUID PRIMARY KEY,
+    integration_id UUID NOT NULL,
+    organization_id UUID NOT NULL,
+    activity_type VARCHAR(64) NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_integration_activity_integration_created ON integration_activity (integration_id, created_at DESC);
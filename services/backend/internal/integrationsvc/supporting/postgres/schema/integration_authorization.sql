@@ -0,0 +1,5 @@
+CREATE TABLE integration_authorizations (
+    authorization_key TEXT PRIMARY KEY,
+    integration_id UUID NOT NULL REFERENCES integrations(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
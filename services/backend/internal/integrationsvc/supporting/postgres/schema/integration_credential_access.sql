@@ -0,0 +1,16 @@
+CREATE TABLE integration_credential_access (
+    id UUID PRIMARY KEY,
+    integration_id UUID NOT NULL,
+    organization_id UUID NOT NULL,
+    accessor_type VARCHAR(16) NOT NULL,
+    accessor_id VARCHAR(255) NOT NULL DEFAULT '',
+    user_id VARCHAR(255) NOT NULL DEFAULT '',
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    fields TEXT[] NOT NULL DEFAULT '{}',
+    accessed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    conversation_id VARCHAR(255) NOT NULL DEFAULT '',
+    tool_call_id VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_integration_credential_access_integration_accessed ON integration_credential_access (integration_id, accessed_at DESC);
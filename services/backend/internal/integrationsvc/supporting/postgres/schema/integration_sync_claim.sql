@@ -0,0 +1,4 @@
+CREATE TABLE integration_sync_claims (
+    integration_id UUID PRIMARY KEY REFERENCES integrations(id) ON DELETE CASCADE,
+    claimed_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,15 @@
+CREATE TABLE integration_sync_jobs (
+    id UUID PRIMARY KEY,
+    integration_id UUID NOT NULL,
+    status VARCHAR(32) NOT NULL,
+    attempt INTEGER NOT NULL DEFAULT 1,
+    parameters JSONB NOT NULL DEFAULT '{}',
+    error TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    heartbeat_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    result JSONB NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX idx_integration_sync_jobs_integration_started ON integration_sync_jobs (integration_id, started_at DESC);
+CREATE INDEX idx_integration_sync_jobs_running_heartbeat ON integration_sync_jobs (heartbeat_at) WHERE status = 'running';
@@ -0,0 +1,12 @@
+CREATE TABLE repository_triggers (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL,
+    repository_pattern VARCHAR(512) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    path_glob VARCHAR(512) NOT NULL DEFAULT '',
+    channel_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_repository_triggers_organization ON repository_triggers (organization_id);
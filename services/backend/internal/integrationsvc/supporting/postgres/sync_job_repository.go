@@ -0,0 +1,205 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+type syncJobRepository struct {
+	queries *Queries
+}
+
+func NewSyncJobRepository(sqlDB *sql.DB) domain.SyncJobRepository {
+	return &syncJobRepository{queries: New(pgretry.Wrap(sqlDB))}
+}
+
+func (r *syncJobRepository) Start(ctx context.Context, job domain.SyncJob) error {
+	parameters, err := json.Marshal(job.Parameters)
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync parameters: %w", err)
+	}
+	if job.Parameters == nil {
+		parameters = []byte("{}")
+	}
+
+	err = r.queries.StoreIntegrationSyncJob(ctx, StoreIntegrationSyncJobParams{
+		ID:            job.ID,
+		IntegrationID: job.IntegrationID,
+		Status:        string(job.Status),
+		Attempt:       int32(job.Attempt),
+		Parameters:    parameters,
+		StartedAt:     job.StartedAt,
+		HeartbeatAt:   job.HeartbeatAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store sync job: %w", err)
+	}
+	return nil
+}
+
+func (r *syncJobRepository) Heartbeat(ctx context.Context, id uuid.UUID, at time.Time) error {
+	updated, err := r.queries.HeartbeatIntegrationSyncJob(ctx, HeartbeatIntegrationSyncJobParams{
+		HeartbeatAt: at,
+		ID:          id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record sync job heartbeat: %w", err)
+	}
+	if updated == 0 {
+		return domain.ErrSyncJobNotRunning
+	}
+	return nil
+}
+
+func (r *syncJobRepository) Finish(ctx context.Context, job domain.SyncJob) error {
+	result, err := json.Marshal(toSyncResultJSON(job.Result))
+	if err != nil {
+		return fmt.Errorf("failed to marshal sync result: %w", err)
+	}
+
+	var finishedAt sql.NullTime
+	if job.FinishedAt != nil {
+		finishedAt = sql.NullTime{Time: *job.FinishedAt, Valid: true}
+	}
+	err = r.queries.FinishIntegrationSyncJob(ctx, FinishIntegrationSyncJobParams{
+		Status:     string(job.Status),
+		Error:      job.Error,
+		Result:     result,
+		FinishedAt: finishedAt,
+		ID:         job.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to finish sync job: %w", err)
+	}
+	return nil
+}
+
+func (r *syncJobRepository) FailStale(ctx context.Context, staleBefore time.Time, errorMessage string, at time.Time) ([]domain.SyncJob, error) {
+	rows, err := r.queries.FailStaleIntegrationSyncJobs(ctx, FailStaleIntegrationSyncJobsParams{
+		Error:       errorMessage,
+		FinishedAt:  sql.NullTime{Time: at, Valid: true},
+		HeartbeatAt: staleBefore,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fail stale sync jobs: %w", err)
+	}
+
+	jobs := make([]domain.SyncJob, 0, len(rows))
+	for _, row := range rows {
+		job, err := toSyncJob(row)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func (r *syncJobRepository) Health(ctx context.Context, integrationID uuid.UUID) (backend.IntegrationSyncHealth, error) {
+	var health backend.IntegrationSyncHealth
+
+	var failuresSince time.Time
+	succeeded, err := r.queries.GetLastSucceededIntegrationSyncJob(ctx, integrationID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return backend.IntegrationSyncHealth{}, fmt.Errorf("failed to get last successful sync job: %w", err)
+	default:
+		failuresSince = succeeded.StartedAt
+		if succeeded.FinishedAt.Valid {
+			health.LastSuccessfulSyncAt = &succeeded.FinishedAt.Time
+		}
+		result, err := fromSyncResultJSON(succeeded.Result)
+		if err != nil {
+			return backend.IntegrationSyncHealth{}, err
+		}
+		health.LastSyncResult = &result
+	}
+
+	failures, err := r.queries.CountIntegrationSyncJobsSince(ctx, CountIntegrationSyncJobsSinceParams{
+		IntegrationID: integrationID,
+		Status:        string(domain.SyncJobStatusFailed),
+		StartedAt:     failuresSince,
+	})
+	if err != nil {
+		return backend.IntegrationSyncHealth{}, fmt.Errorf("failed to count failed sync jobs: %w", err)
+	}
+	health.ConsecutiveFailures = int(failures)
+
+	running, err := r.queries.CountIntegrationSyncJobsSince(ctx, CountIntegrationSyncJobsSinceParams{
+		IntegrationID: integrationID,
+		Status:        string(domain.SyncJobStatusRunning),
+	})
+	if err != nil {
+		return backend.IntegrationSyncHealth{}, fmt.Errorf("failed to count running sync jobs: %w", err)
+	}
+	health.Running = running > 0
+
+	return health, nil
+}
+
+func toSyncJob(row IntegrationSyncJob) (domain.SyncJob, error) {
+	var parameters map[string]string
+	if err := json.Unmarshal(row.Parameters, &parameters); err != nil {
+		return domain.SyncJob{}, fmt.Errorf("failed to unmarshal sync parameters: %w", err)
+	}
+
+	result, err := fromSyncResultJSON(row.Result)
+	if err != nil {
+		return domain.SyncJob{}, err
+	}
+
+	job := domain.SyncJob{
+		ID:            row.ID,
+		IntegrationID: row.IntegrationID,
+		Status:        domain.SyncJobStatus(row.Status),
+		Attempt:       int(row.Attempt),
+		Parameters:    parameters,
+		Error:         row.Error,
+		StartedAt:     row.StartedAt,
+		HeartbeatAt:   row.HeartbeatAt,
+		Result:        result,
+	}
+	if row.FinishedAt.Valid {
+		job.FinishedAt = &row.FinishedAt.Time
+	}
+	return job, nil
+}
+
+type syncResultJSON struct {
+	Added   int      `json:"added"`
+	Removed int      `json:"removed"`
+	Updated int      `json:"updated"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+func toSyncResultJSON(result backend.SyncResult) syncResultJSON {
+	return syncResultJSON{
+		Added:   result.Added,
+		Removed: result.Removed,
+		Updated: result.Updated,
+		Errors:  result.Errors,
+	}
+}
+
+func fromSyncResultJSON(data []byte) (backend.SyncResult, error) {
+	var result syncResultJSON
+	if err := json.Unmarshal(data, &result); err != nil {
+		return backend.SyncResult{}, fmt.Errorf("failed to unmarshal sync result: %w", err)
+	}
+	return backend.SyncResult{
+		Added:   result.Added,
+		Removed: result.Removed,
+		Updated: result.Updated,
+		Errors:  result.Errors,
+	}, nil
+}
@@ -0,0 +1,177 @@
+package integrationsvc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultSyncHeartbeatSeconds  = 30
+	defaultSyncStaleAfterSeconds = 300
+	defaultSyncMaxAttempts       = 3
+)
+
+// lostSyncError is recorded for syncs whose worker stopped sending heartbeats,
+// such as a pod killed mid-sync.
+const lostSyncError = "sync worker stopped sending heartbeats"
+
+type syncJobPolicy struct {
+	heartbeat   time.Duration
+	staleAfter  time.Duration
+	reschedule  bool
+	maxAttempts int
+}
+
+func (c SyncConfig) jobPolicy() syncJobPolicy {
+	heartbeatSeconds := defaultSyncHeartbeatSeconds
+	if c.HeartbeatSeconds > 0 {
+		heartbeatSeconds = c.HeartbeatSeconds
+	}
+	// A sync is only stale once it has missed a few heartbeats.
+	staleAfterSeconds := max(defaultSyncStaleAfterSeconds, 3*heartbeatSeconds)
+	if c.StaleAfterSeconds > 0 {
+		staleAfterSeconds = max(c.StaleAfterSeconds, 3*heartbeatSeconds)
+	}
+	maxAttempts := defaultSyncMaxAttempts
+	if c.MaxAttempts > 0 {
+		maxAttempts = c.MaxAttempts
+	}
+
+	return syncJobPolicy{
+		heartbeat:   time.Duration(heartbeatSeconds) * time.Second,
+		staleAfter:  time.Duration(staleAfterSeconds) * time.Second,
+		reschedule:  c.RescheduleLost,
+		maxAttempts: maxAttempts,
+	}
+}
+
+// startSyncJob records a running sync job and keeps its heartbeat fresh until
+// the returned function is called with the sync's outcome. Sync jobs are
+// bookkeeping, so failing to record one does not stop the sync.
+func (s *service) startSyncJob(ctx context.Context, integration backend.Integration, params map[string]string, attempt int) func(backend.SyncResult, error) {
+	if s.syncJobs == nil {
+		return func(backend.SyncResult, error) {}
+	}
+
+	now := time.Now()
+	job := domain.SyncJob{
+		ID:            uuid.New(),
+		IntegrationID: integration.ID,
+		Status:        domain.SyncJobStatusRunning,
+		Attempt:       attempt,
+		Parameters:    params,
+		StartedAt:     now,
+		HeartbeatAt:   now,
+	}
+	if err := s.syncJobs.Start(ctx, job); err != nil {
+		slog.Error("failed to record sync job", "integration_id", integration.ID, "error", err)
+		return func(backend.SyncResult, error) {}
+	}
+
+	heartbeatCtx, stop := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.sendSyncHeartbeats(heartbeatCtx, job.ID)
+	}()
+
+	return func(result backend.SyncResult, syncErr error) {
+		stop()
+		<-done
+
+		finishedAt := time.Now()
+		job.Status = domain.SyncJobStatusSucceeded
+		job.Result = result
+		job.FinishedAt = &finishedAt
+		if syncErr != nil {
+			job.Status, job.Error = domain.SyncJobStatusFailed, syncErr.Error()
+		}
+		if err := s.syncJobs.Finish(context.WithoutCancel(ctx), job); err != nil {
+			slog.Error("failed to finish sync job", "integration_id", integration.ID, "job_id", job.ID, "error", err)
+		}
+	}
+}
+
+func (s *service) sendSyncHeartbeats(ctx context.Context, jobID uuid.UUID) {
+	ticker := time.NewTicker(s.syncJobPolicy.heartbeat)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := s.syncJobs.Heartbeat(ctx, jobID, time.Now())
+		switch {
+		case errors.Is(err, domain.ErrSyncJobNotRunning):
+			slog.Warn("sync job was failed as lost while still running", "job_id", jobID)
+			return
+		case err != nil && ctx.Err() == nil:
+			slog.Warn("failed to record sync job heartbeat", "job_id", jobID, "error", err)
+		}
+	}
+}
+
+func (s *service) runSyncReaper(ctx context.Context) {
+	ticker := time.NewTicker(s.syncJobPolicy.staleAfter / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		s.reapLostSyncs(ctx)
+	}
+}
+
+// reapLostSyncs fails running syncs whose worker stopped sending heartbeats
+// and, when the policy allows, runs them again. Connectors upsert what they
+// sync, so rerunning a sync that was partly written is safe.
+func (s *service) reapLostSyncs(ctx context.Context) {
+	now := time.Now()
+	jobs, err := s.syncJobs.FailStale(ctx, now.Add(-s.syncJobPolicy.staleAfter), lostSyncError, now)
+	if err != nil {
+		slog.Error("failed to reap lost integration syncs", "error", err)
+		return
+	}
+
+	for _, job := range jobs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		integration, err := s.integrationRepository.FindByID(ctx, job.IntegrationID)
+		if err != nil {
+			slog.Warn("failed to find integration of lost sync", "integration_id", job.IntegrationID, "job_id", job.ID, "error", err)
+			continue
+		}
+		slog.Warn("integration sync lost with its worker", "integration_id", job.IntegrationID, "job_id", job.ID, "attempt", job.Attempt, "last_heartbeat", job.HeartbeatAt)
+		s.recordActivity(ctx, integration, backend.IntegrationActivitySyncFailed, map[string]string{
+			"error":   lostSyncError,
+			"attempt": strconv.Itoa(job.Attempt),
+		})
+
+		if !s.syncJobPolicy.reschedule || job.Attempt >= s.syncJobPolicy.maxAttempts || integration.CheckCredentialsUsable() != nil {
+			continue
+		}
+		err = s.syncIntegrationAttempt(ctx, integration, job.Parameters, job.Attempt+1)
+		switch {
+		case errors.Is(err, domain.ErrSyncInProgress):
+			slog.Debug("skipping rescheduled sync already in progress", "integration_id", integration.ID)
+		case err != nil:
+			slog.Error("rescheduled integration sync failed", "integration_id", integration.ID, "attempt", job.Attempt+1, "error", err)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package integrationsvc
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/integrationsvc/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultSyncIntervalMinutes = 360
+	defaultSyncJitterMinutes   = 10
+	syncCheckInterval          = 5 * time.Minute
+)
+
+// SyncConfig controls the background sync that catches up on missed webhooks.
+type SyncConfig struct {
+	Disabled        bool `mapstructure:"disabled"`
+	IntervalMinutes int  `mapstructure:"interval_minutes"`
+	JitterMinutes   int  `mapstructure:"jitter_minutes"`
+	// ConnectorIntervalMinutes overrides IntervalMinutes per connector type.
+	// A negative value turns scheduled syncs off for that connector.
+	ConnectorIntervalMinutes map[string]int `mapstructure:"connector_interval_minutes"`
+
+	// HeartbeatSeconds is how often a running sync records that its worker
+	// is still alive.
+	HeartbeatSeconds int `mapstructure:"heartbeat_seconds"`
+	// StaleAfterSeconds is how long a sync may go without a heartbeat before
+	// it is failed as lost with its worker.
+	StaleAfterSeconds int `mapstructure:"stale_after_seconds"`
+	// RescheduleLost runs lost syncs again, up to MaxAttempts runs in total.
+	RescheduleLost bool `mapstructure:"reschedule_lost"`
+	MaxAttempts    int  `mapstructure:"max_attempts"`
+}
+
+type syncSchedule struct {
+	// interval is how long an integration may go without syncing.
+	interval time.Duration
+	jitter   time.Duration
+}
+
+func (c SyncConfig) schedules(connectorTypes []backend.ConnectorType) map[backend.ConnectorType]syncSchedule {
+	if c.Disabled {
+		return nil
+	}
+
+	intervalMinutes := defaultSyncIntervalMinutes
+	if c.IntervalMinutes > 0 {
+		intervalMinutes = c.IntervalMinutes
+	}
+	jitterMinutes := defaultSyncJitterMinutes
+	if c.JitterMinutes > 0 {
+		jitterMinutes = c.JitterMinutes
+	}
+
+	schedules := make(map[backend.ConnectorType]syncSchedule)
+	for _, connectorType := range connectorTypes {
+		minutes := intervalMinutes
+		if override, ok := c.ConnectorIntervalMinutes[string(connectorType)]; ok && override != 0 {
+			minutes = override
+		}
+		if minutes < 0 {
+			continue
+		}
+		schedules[connectorType] = syncSchedule{
+			interval: time.Duration(minutes) * time.Minute,
+			jitter:   time.Duration(jitterMinutes) * time.Minute,
+		}
+	}
+	return schedules
+}
+
+// next is the wait before the schedule checks for due integrations again. The
+// jitter keeps replicas and connector types from hitting providers together.
+func (s syncSchedule) next() time.Duration {
+	wait := min(s.interval, syncCheckInterval)
+	if s.jitter > 0 {
+		wait += rand.N(s.jitter)
+	}
+	return wait
+}
+
+func (s *service) runSyncSchedule(ctx context.Context, connectorType backend.ConnectorType, schedule syncSchedule) {
+	slog.Info("scheduled integration syncs enabled", "connector_type", connectorType, "interval", schedule.interval)
+
+	timer := time.NewTimer(schedule.next())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		s.syncDue(ctx, connectorType, schedule.interval)
+		timer.Reset(schedule.next())
+	}
+}
+
+// syncDue syncs the active integrations of a connector type that have not
+// synced within interval, least recently synced first. Every replica runs the
+// schedule; each due integration is claimed for interval first so that only
+// one of them syncs it.
+func (s *service) syncDue(ctx context.Context, connectorType backend.ConnectorType, interval time.Duration) {
+	integrations, err := s.integrationRepository.FindDueForSync(ctx, connectorType, time.Now().Add(-interval))
+	if err != nil {
+		slog.Error("failed to find integrations due for sync", "connector_type", connectorType, "error", err)
+		return
+	}
+
+	for _, integration := range integrations {
+		if ctx.Err() != nil {
+			return
+		}
+
+		claimed, err := s.integrationRepository.ClaimSync(ctx, integration.ID, interval)
+		if err != nil {
+			slog.Error("failed to claim scheduled integration sync", "integration_id", integration.ID, "error", err)
+			continue
+		}
+		if !claimed {
+			slog.Debug("skipping scheduled sync claimed by another replica", "integration_id", integration.ID)
+			continue
+		}
+
+		err = s.syncIntegration(ctx, integration, nil)
+		switch {
+		case errors.Is(err, domain.ErrSyncInProgress):
+			slog.Debug("skipping scheduled sync already in progress", "integration_id", integration.ID)
+		case err != nil:
+			slog.Error("scheduled integration sync failed", "integration_id", integration.ID, "connector_type", connectorType, "error", err)
+		}
+	}
+}
+
+// inFlightSyncs tracks the integrations currently syncing in this process.
+type inFlightSyncs struct {
+	mu  sync.Mutex
+	ids map[uuid.UUID]struct{}
+}
+
+func newInFlightSyncs() *inFlightSyncs {
+	return &inFlightSyncs{ids: make(map[uuid.UUID]struct{})}
+}
+
+// start reports whether the caller may sync the integration. Callers that get
+// true must call finish.
+func (f *inFlightSyncs) start(id uuid.UUID) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, ok := f.ids[id]; ok {
+		return false
+	}
+	f.ids[id] = struct{}{}
+	return true
+}
+
+func (f *inFlightSyncs) finish(id uuid.UUID) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.ids, id)
+}
+
+func (f *inFlightSyncs) running(id uuid.UUID) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.ids[id]
+	return ok
+}
@@ -0,0 +1,21 @@
+package onboardingsvc
+
+import (
+	"database/sql"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/onboardingsvc/supporting/postgres"
+)
+
+type Config struct {
+	Database *sql.DB `mapstructure:"-"`
+	// Reporters detect completed steps from the data of the services that
+	// own it. More can be added with AddReporter once they are built.
+	Reporters []backend.OnboardingReporter `mapstructure:"-"`
+	// Listener is notified when a step is completed. It may be nil.
+	Listener backend.OnboardingStepListener `mapstructure:"-"`
+}
+
+func (c Config) New() *Service {
+	return NewService(postgres.NewOnboardingRepository(c.Database), c.Reporters, c.Listener)
+}
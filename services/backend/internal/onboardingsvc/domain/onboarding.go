@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/google/uuid"
+)
+
+// StepState is a stored step status. Steps without one are pending.
+type StepState struct {
+	Status    backend.OnboardingStepStatus
+	Manual    bool
+	UpdatedAt time.Time
+}
+
+type OnboardingRepository interface {
+	Steps(ctx context.Context, organizationID uuid.UUID) (map[backend.OnboardingStep]StepState, error)
+	// SetStep stores the step's state unless the step is already completed,
+	// and reports whether it was stored.
+	SetStep(ctx context.Context, organizationID uuid.UUID, step backend.OnboardingStep, state StepState) (bool, error)
+}
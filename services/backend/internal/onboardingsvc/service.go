@@ -0,0 +1,155 @@
+package onboardingsvc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/onboardingsvc/domain"
+	"github.com/google/uuid"
+)
+
+type Service struct {
+	repository domain.OnboardingRepository
+	listener   backend.OnboardingStepListener
+	now        func() time.Time
+
+	mu        sync.RWMutex
+	reporters []backend.OnboardingReporter
+}
+
+var _ backend.OnboardingService = (*Service)(nil)
+
+func NewService(repository domain.OnboardingRepository, reporters []backend.OnboardingReporter, listener backend.OnboardingStepListener) *Service {
+	return &Service{
+		repository: repository,
+		listener:   listener,
+		now:        time.Now,
+		reporters:  slices.Clone(reporters),
+	}
+}
+
+// AddReporter adds a reporter built after the service, such as the
+// conversation service, which itself consults onboarding.
+func (s *Service) AddReporter(reporter backend.OnboardingReporter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.reporters = append(s.reporters, reporter)
+}
+
+func (s *Service) Onboarding(ctx context.Context, query backend.OnboardingQuery) (backend.Onboarding, error) {
+	detected, err := s.detectedSteps(ctx, query.OrganizationID)
+	if err != nil {
+		return backend.Onboarding{}, err
+	}
+
+	for _, step := range detected {
+		if err := s.complete(ctx, query.OrganizationID, step, false); err != nil {
+			return backend.Onboarding{}, err
+		}
+	}
+	return s.onboarding(ctx, query.OrganizationID)
+}
+
+func (s *Service) MarkOnboardingStep(ctx context.Context, cmd backend.MarkOnboardingStepCommand) (backend.Onboarding, error) {
+	if !slices.Contains(backend.OnboardingSteps, cmd.Step) {
+		return backend.Onboarding{}, fmt.Errorf("%w: %q", backend.ErrInvalidOnboardingStep, cmd.Step)
+	}
+
+	switch cmd.Status {
+	case backend.OnboardingStepStatusCompleted:
+		if err := s.complete(ctx, cmd.OrganizationID, cmd.Step, true); err != nil {
+			return backend.Onboarding{}, err
+		}
+	case backend.OnboardingStepStatusSkipped, backend.OnboardingStepStatusPending:
+		stored, err := s.repository.SetStep(ctx, cmd.OrganizationID, cmd.Step, domain.StepState{
+			Status:    cmd.Status,
+			Manual:    true,
+			UpdatedAt: s.now(),
+		})
+		if err != nil {
+			return backend.Onboarding{}, fmt.Errorf("failed to mark onboarding step: %w", err)
+		}
+		if !stored {
+			return backend.Onboarding{}, fmt.Errorf("%w: %s", backend.ErrOnboardingStepCompleted, cmd.Step)
+		}
+	default:
+		return backend.Onboarding{}, fmt.Errorf("%w: unknown status %q", backend.ErrInvalidOnboardingStep, cmd.Status)
+	}
+
+	return s.Onboarding(ctx, backend.OnboardingQuery{OrganizationID: cmd.OrganizationID})
+}
+
+// complete stores the step as completed and notifies the listener the first
+// time it is, so a step detected by concurrent requests is announced once.
+func (s *Service) complete(ctx context.Context, organizationID uuid.UUID, step backend.OnboardingStep, manual bool) error {
+	completedAt := s.now()
+	stored, err := s.repository.SetStep(ctx, organizationID, step, domain.StepState{
+		Status:    backend.OnboardingStepStatusCompleted,
+		Manual:    manual,
+		UpdatedAt: completedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete onboarding step: %w", err)
+	}
+	if !stored {
+		return nil
+	}
+
+	slog.Info("onboarding step completed", "organization_id", organizationID, "step", step, "manual", manual)
+	if s.listener != nil {
+		s.listener.OnboardingStepCompleted(ctx, backend.OnboardingStepCompletion{
+			OrganizationID: organizationID,
+			Step:           step,
+			Manual:         manual,
+			CompletedAt:    completedAt,
+		})
+	}
+	return nil
+}
+
+func (s *Service) detectedSteps(ctx context.Context, organizationID uuid.UUID) ([]backend.OnboardingStep, error) {
+	s.mu.RLock()
+	reporters := slices.Clone(s.reporters)
+	s.mu.RUnlock()
+
+	var detected []backend.OnboardingStep
+	for _, reporter := range reporters {
+		steps, err := reporter.CompletedOnboardingSteps(ctx, organizationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect completed onboarding steps: %w", err)
+		}
+		detected = append(detected, steps...)
+	}
+	return detected, nil
+}
+
+func (s *Service) onboarding(ctx context.Context, organizationID uuid.UUID) (backend.Onboarding, error) {
+	states, err := s.repository.Steps(ctx, organizationID)
+	if err != nil {
+		return backend.Onboarding{}, fmt.Errorf("failed to get onboarding steps: %w", err)
+	}
+
+	onboarding := backend.Onboarding{
+		OrganizationID: organizationID,
+		Steps:          make([]backend.OnboardingStepState, len(backend.OnboardingSteps)),
+	}
+	for i, step := range backend.OnboardingSteps {
+		state, ok := states[step]
+		if !ok {
+			state.Status = backend.OnboardingStepStatusPending
+		}
+		onboarding.Steps[i] = backend.OnboardingStepState{
+			Step:      step,
+			Status:    state.Status,
+			Manual:    state.Manual,
+			UpdatedAt: state.UpdatedAt,
+		}
+	}
+	return onboarding, nil
+}
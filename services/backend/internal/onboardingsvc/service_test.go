@@ -0,0 +1,129 @@
+package onboardingsvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/onboardingsvc/domain"
+	"github.com/google/uuid"
+)
+
+type memoryOnboardingRepository struct {
+	steps map[uuid.UUID]map[backend.OnboardingStep]domain.StepState
+}
+
+func (m *memoryOnboardingRepository) Steps(ctx context.Context, organizationID uuid.UUID) (map[backend.OnboardingStep]domain.StepState, error) {
+	steps := make(map[backend.OnboardingStep]domain.StepState)
+	for step, state := range m.steps[organizationID] {
+		steps[step] = state
+	}
+	return steps, nil
+}
+
+func (m *memoryOnboardingRepository) SetStep(ctx context.Context, organizationID uuid.UUID, step backend.OnboardingStep, state domain.StepState) (bool, error) {
+	if m.steps[organizationID] == nil {
+		m.steps[organizationID] = make(map[backend.OnboardingStep]domain.StepState)
+	}
+	if m.steps[organizationID][step].Status == backend.OnboardingStepStatusCompleted {
+		return false, nil
+	}
+	m.steps[organizationID][step] = state
+	return true, nil
+}
+
+type fakeReporter []backend.OnboardingStep
+
+func (f *fakeReporter) CompletedOnboardingSteps(ctx context.Context, organizationID uuid.UUID) ([]backend.OnboardingStep, error) {
+	return *f, nil
+}
+
+type recordingListener []backend.OnboardingStepCompletion
+
+func (r *recordingListener) OnboardingStepCompleted(ctx context.Context, completion backend.OnboardingStepCompletion) {
+	*r = append(*r, completion)
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+	org := uuid.New()
+	reporter := &fakeReporter{}
+	listener := &recordingListener{}
+	svc := NewService(&memoryOnboardingRepository{steps: make(map[uuid.UUID]map[backend.OnboardingStep]domain.StepState)}, nil, listener)
+	svc.AddReporter(reporter)
+
+	now := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	onboarding, err := svc.Onboarding(ctx, backend.OnboardingQuery{OrganizationID: org})
+	if err != nil {
+		t.Fatalf("Onboarding() error = %v", err)
+	}
+	if next, ok := onboarding.NextStep(); !ok || next != backend.OnboardingStepConnectSlack {
+		t.Errorf("NextStep() = %q, %v, want connect_slack", next, ok)
+	}
+
+	*reporter = fakeReporter{backend.OnboardingStepConnectSlack, backend.OnboardingStepConnectGitHub}
+	for range 2 {
+		if onboarding, err = svc.Onboarding(ctx, backend.OnboardingQuery{OrganizationID: org}); err != nil {
+			t.Fatalf("Onboarding() error = %v", err)
+		}
+	}
+	if len(*listener) != 2 || (*listener)[0].Step != backend.OnboardingStepConnectSlack || (*listener)[0].Manual {
+		t.Errorf("completions = %+v, want slack and github detected once each", *listener)
+	}
+	if next, _ := onboarding.NextStep(); next != backend.OnboardingStepConnectCloud {
+		t.Errorf("NextStep() = %q, want connect_cloud", next)
+	}
+
+	onboarding, err = svc.MarkOnboardingStep(ctx, backend.MarkOnboardingStepCommand{
+		OrganizationID: org,
+		Step:           backend.OnboardingStepConnectCloud,
+		Status:         backend.OnboardingStepStatusSkipped,
+	})
+	if err != nil {
+		t.Fatalf("MarkOnboardingStep(skipped) error = %v", err)
+	}
+	if got := onboarding.Status(backend.OnboardingStepConnectCloud); got != backend.OnboardingStepStatusSkipped {
+		t.Errorf("connect_cloud status = %q, want skipped", got)
+	}
+	if next, _ := onboarding.NextStep(); next != backend.OnboardingStepInviteTeammates {
+		t.Errorf("NextStep() = %q, want invite_teammates", next)
+	}
+
+	onboarding, err = svc.MarkOnboardingStep(ctx, backend.MarkOnboardingStepCommand{
+		OrganizationID: org,
+		Step:           backend.OnboardingStepInviteTeammates,
+		Status:         backend.OnboardingStepStatusCompleted,
+	})
+	if err != nil {
+		t.Fatalf("MarkOnboardingStep(completed) error = %v", err)
+	}
+	if last := (*listener)[len(*listener)-1]; last.Step != backend.OnboardingStepInviteTeammates || !last.Manual || !last.CompletedAt.Equal(now) {
+		t.Errorf("last completion = %+v, want manual invite_teammates", last)
+	}
+
+	t.Run("completed steps stay completed", func(t *testing.T) {
+		_, err := svc.MarkOnboardingStep(ctx, backend.MarkOnboardingStepCommand{
+			OrganizationID: org,
+			Step:           backend.OnboardingStepConnectGitHub,
+			Status:         backend.OnboardingStepStatusPending,
+		})
+		if !errors.Is(err, backend.ErrOnboardingStepCompleted) {
+			t.Errorf("MarkOnboardingStep() error = %v, want ErrOnboardingStepCompleted", err)
+		}
+	})
+
+	t.Run("invalid step", func(t *testing.T) {
+		for _, cmd := range []backend.MarkOnboardingStepCommand{
+			{OrganizationID: org, Step: "connect_pagerduty", Status: backend.OnboardingStepStatusSkipped},
+			{OrganizationID: org, Step: backend.OnboardingStepFirstConversation, Status: "done"},
+		} {
+			if _, err := svc.MarkOnboardingStep(ctx, cmd); !errors.Is(err, backend.ErrInvalidOnboardingStep) {
+				t.Errorf("MarkOnboardingStep(%+v) error = %v, want ErrInvalidOnboardingStep", cmd, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package onboardingsvc
+
+import (
+	"context"
+	"sync"
+
+	"github.com/73ai/infragpt/services/backend"
+)
+
+// StepNotifier fans onboarding step completions out to listeners added after
+// the onboarding service is built, like integrationsvc.StatusNotifier.
+type StepNotifier struct {
+	mu        sync.RWMutex
+	listeners []backend.OnboardingStepListener
+}
+
+func (n *StepNotifier) Listen(listener backend.OnboardingStepListener) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.listeners = append(n.listeners, listener)
+}
+
+func (n *StepNotifier) OnboardingStepCompleted(ctx context.Context, completion backend.OnboardingStepCompletion) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	for _, listener := range n.listeners {
+		listener.OnboardingStepCompleted(ctx, completion)
+	}
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
+	"github.com/73ai/infragpt/services/backend/internal/onboardingsvc/domain"
+	"github.com/google/uuid"
+)
+
+type onboardingRepository struct {
+	queries *Queries
+}
+
+func NewOnboardingRepository(sqlDB *sql.DB) domain.OnboardingRepository {
+	return &onboardingRepository{
+		queries: New(pgretry.Wrap(sqlDB)),
+	}
+}
+
+func (r *onboardingRepository) Steps(ctx context.Context, organizationID uuid.UUID) (map[backend.OnboardingStep]domain.StepState, error) {
+	rows, err := r.queries.FindOnboardingStepsByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list onboarding steps: %w", err)
+	}
+
+	steps := make(map[backend.OnboardingStep]domain.StepState, len(rows))
+	for _, row := range rows {
+		steps[backend.OnboardingStep(row.Step)] = domain.StepState{
+			Status:    backend.OnboardingStepStatus(row.Status),
+			Manual:    row.Manual,
+			UpdatedAt: row.UpdatedAt,
+		}
+	}
+	return steps, nil
+}
+
+func (r *onboardingRepository) SetStep(ctx context.Context, organizationID uuid.UUID, step backend.OnboardingStep, state domain.StepState) (bool, error) {
+	stored, err := r.queries.UpsertOnboardingStep(ctx, UpsertOnboardingStepParams{
+		OrganizationID: organizationID,
+		Step:           string(step),
+		Status:         string(state.Status),
+		Manual:         state.Manual,
+		UpdatedAt:      state.UpdatedAt,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to store onboarding step: %w", err)
+	}
+	return stored > 0, nil
+}
@@ -0,0 +1,11 @@
+-- name: FindOnboardingStepsByOrganizationID :many
+SELECT organization_id, step, status, manual, updated_at
+FROM organization_onboarding_steps
+WHERE organization_id = $1;
+
+-- name: UpsertOnboardingStep :execrows
+INSERT INTO organization_onboarding_steps (organization_id, step, status, manual, updated_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (organization_id, step)
+DO UPDATE SET status = EXCLUDED.status, manual = EXCLUDED.manual, updated_at = EXCLUDED.updated_at
+WHERE organization_onboarding_steps.status <> 'completed';
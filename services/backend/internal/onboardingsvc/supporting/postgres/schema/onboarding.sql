@@ -0,0 +1,8 @@
+CREATE TABLE organization_onboarding_steps (
+    organization_id UUID NOT NULL,
+    step VARCHAR(32) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    manual BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, step)
+);
@@ -0,0 +1,57 @@
+package organizationsvc
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/73ai/infragpt/services/backend"
+)
+
+// Step deletes the organization's data held by one service.
+type Step struct {
+	Name  string
+	Owner backend.OrganizationDataOwner
+}
+
+type Config struct {
+	// Steps run in order, so services whose data refers to another service's
+	// data go first.
+	Steps []Step
+}
+
+func (c Config) New() backend.OrganizationService {
+	return &service{steps: c.Steps}
+}
+
+type service struct {
+	steps []Step
+}
+
+func (s *service) DeleteOrganization(ctx context.Context, cmd backend.DeleteOrganizationCommand) (backend.OrganizationDeletionReport, error) {
+	report := backend.OrganizationDeletionReport{
+		OrganizationID: cmd.OrganizationID,
+		DryRun:         cmd.DryRun,
+	}
+
+	for _, step := range s.steps {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		resources, err := step.Owner.DeleteOrganizationData(ctx, cmd)
+		result := backend.OrganizationDeletionStep{Name: step.Name, Resources: resources}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		report.Steps = append(report.Steps, result)
+
+		if err != nil {
+			slog.Error("organization deletion stopped", "organization_id", cmd.OrganizationID, "step", step.Name, "dry_run", cmd.DryRun, "error", err)
+			return report, nil
+		}
+	}
+
+	report.Complete = true
+	slog.Info("organization deleted", "organization_id", cmd.OrganizationID, "dry_run", cmd.DryRun)
+	return report, nil
+}
@@ -0,0 +1,81 @@
+package organizationsvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/google/uuid"
+)
+
+type fakeOwner struct {
+	records int
+	err     error
+	calls   int
+}
+
+func (o *fakeOwner) DeleteOrganizationData(ctx context.Context, cmd backend.DeleteOrganizationCommand) ([]backend.DeletedResource, error) {
+	o.calls++
+	if o.err != nil {
+		return nil, o.err
+	}
+	resources := []backend.DeletedResource{{Type: "records", Count: o.records}}
+	if !cmd.DryRun {
+		o.records = 0
+	}
+	return resources, nil
+}
+
+func TestDeleteOrganization(t *testing.T) {
+	ctx := context.Background()
+	conversations := &fakeOwner{records: 3}
+	integrations := &fakeOwner{records: 2, err: errors.New("github unavailable")}
+	devices := &fakeOwner{records: 1}
+	svc := Config{Steps: []Step{
+		{Name: "conversations", Owner: conversations},
+		{Name: "integrations", Owner: integrations},
+		{Name: "devices", Owner: devices},
+	}}.New()
+	cmd := backend.DeleteOrganizationCommand{OrganizationID: uuid.New()}
+
+	report, err := svc.DeleteOrganization(ctx, backend.DeleteOrganizationCommand{OrganizationID: cmd.OrganizationID, DryRun: true})
+	if err != nil {
+		t.Fatalf("DeleteOrganization(dry run) error = %v", err)
+	}
+	if report.Complete || len(report.Steps) != 2 || report.Steps[0].Resources[0].Count != 3 {
+		t.Errorf("dry run report = %+v, want conversations counted and a stop at integrations", report)
+	}
+	if conversations.records != 3 {
+		t.Errorf("dry run deleted conversations, %d left", conversations.records)
+	}
+
+	report, err = svc.DeleteOrganization(ctx, cmd)
+	if err != nil {
+		t.Fatalf("DeleteOrganization() error = %v", err)
+	}
+	if report.Complete {
+		t.Error("report.Complete = true, want false after a failed step")
+	}
+	if got := report.Steps[len(report.Steps)-1]; got.Name != "integrations" || got.Error == "" {
+		t.Errorf("last step = %+v, want the failed integrations step", got)
+	}
+	if devices.calls != 0 {
+		t.Errorf("devices step ran %d times after the failure, want 0", devices.calls)
+	}
+
+	integrations.err = nil
+	report, err = svc.DeleteOrganization(ctx, cmd)
+	if err != nil {
+		t.Fatalf("DeleteOrganization() resume error = %v", err)
+	}
+	if !report.Complete || len(report.Steps) != 3 {
+		t.Fatalf("resumed report = %+v, want all three steps complete", report)
+	}
+	if got := report.Steps[0].Resources[0].Count; got != 0 {
+		t.Errorf("resumed conversations count = %d, want 0 already deleted", got)
+	}
+	if integrations.records != 0 || devices.records != 0 {
+		t.Errorf("records left: integrations %d, devices %d", integrations.records, devices.records)
+	}
+}
@@ -0,0 +1,33 @@
+package quotasvc
+
+import (
+	"database/sql"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/quotasvc/supporting/postgres"
+)
+
+type Config struct {
+	Database *sql.DB `mapstructure:"-"`
+	// Periods sets how often each metric's usage resets: day, week or month.
+	// Requests reset daily and LLM tokens monthly unless set here.
+	Periods map[string]string `mapstructure:"periods"`
+}
+
+func (c Config) New() (*Service, error) {
+	periods := maps.Clone(defaultPeriods)
+	for metric, period := range c.Periods {
+		if !slices.Contains(quotaMetrics, backend.QuotaMetric(metric)) {
+			return nil, fmt.Errorf("unknown quota metric %q", metric)
+		}
+		if !slices.Contains(quotaPeriods, backend.QuotaPeriod(period)) {
+			return nil, fmt.Errorf("invalid period %q for quota metric %q, want day, week or month", period, metric)
+		}
+		periods[backend.QuotaMetric(metric)] = backend.QuotaPeriod(period)
+	}
+
+	return NewService(postgres.NewQuotaRepository(c.Database), periods), nil
+}
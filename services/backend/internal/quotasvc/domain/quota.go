@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrQuotaNotFound = errors.New("quota not found")
+	ErrInvalidQuota  = errors.New("invalid quota")
+)
+
+type QuotaRepository interface {
+	SetLimit(ctx context.Context, organizationID uuid.UUID, metric backend.QuotaMetric, limit int64) error
+	UnsetLimit(ctx context.Context, organizationID uuid.UUID, metric backend.QuotaMetric) error
+	Limits(ctx context.Context, organizationID uuid.UUID) (map[backend.QuotaMetric]int64, error)
+	AddUsage(ctx context.Context, organizationID uuid.UUID, metric backend.QuotaMetric, periodStart time.Time, amount int64) error
+	// Usage returns zero when nothing was used in the period.
+	Usage(ctx context.Context, organizationID uuid.UUID, metric backend.QuotaMetric, periodStart time.Time) (int64, error)
+}
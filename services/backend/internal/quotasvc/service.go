@@ -0,0 +1,157 @@
+package quotasvc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/quotasvc/domain"
+	"github.com/google/uuid"
+)
+
+var (
+	quotaMetrics = []backend.QuotaMetric{backend.QuotaMetricRequests, backend.QuotaMetricLLMTokens}
+	quotaPeriods = []backend.QuotaPeriod{backend.QuotaPeriodDay, backend.QuotaPeriodWeek, backend.QuotaPeriodMonth}
+
+	defaultPeriods = map[backend.QuotaMetric]backend.QuotaPeriod{
+		backend.QuotaMetricRequests:  backend.QuotaPeriodDay,
+		backend.QuotaMetricLLMTokens: backend.QuotaPeriodMonth,
+	}
+)
+
+type Service struct {
+	repository domain.QuotaRepository
+	periods    map[backend.QuotaMetric]backend.QuotaPeriod
+	now        func() time.Time
+}
+
+var _ backend.QuotaService = (*Service)(nil)
+
+func NewService(repository domain.QuotaRepository, periods map[backend.QuotaMetric]backend.QuotaPeriod) *Service {
+	return &Service{
+		repository: repository,
+		periods:    periods,
+		now:        time.Now,
+	}
+}
+
+func (s *Service) CheckQuotas(ctx context.Context, organizationID uuid.UUID) error {
+	usage, err := s.usage(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+
+	for _, u := range usage {
+		if u.Limit == 0 || u.Used < u.Limit {
+			continue
+		}
+		slog.Warn("organization over quota",
+			"organization_id", organizationID, "metric", u.Metric, "used", u.Used,
+			"limit", u.Limit, "period", u.Period, "resets_at", u.ResetsAt)
+		return fmt.Errorf("%w: your organization has used its %s limit of %d %s. It resets at %s",
+			backend.ErrQuotaExceeded, periodAdjective(u.Period), u.Limit, metricLabel(u.Metric),
+			u.ResetsAt.Format("2006-01-02 15:04 MST"))
+	}
+	return nil
+}
+
+func (s *Service) RecordUsage(ctx context.Context, organizationID uuid.UUID, metric backend.QuotaMetric, amount int64) {
+	if amount <= 0 {
+		return
+	}
+	start, _ := periodBounds(s.periods[metric], s.now())
+	if err := s.repository.AddUsage(ctx, organizationID, metric, start, amount); err != nil {
+		slog.Error("failed to record quota usage", "organization_id", organizationID, "metric", metric, "amount", amount, "error", err)
+	}
+}
+
+func (s *Service) QuotaUsage(ctx context.Context, query backend.QuotaUsageQuery) ([]backend.QuotaUsage, error) {
+	return s.usage(ctx, query.OrganizationID)
+}
+
+func (s *Service) SetQuota(ctx context.Context, cmd backend.SetQuotaCommand) error {
+	if !slices.Contains(quotaMetrics, cmd.Metric) {
+		return fmt.Errorf("%w: unknown metric %q", domain.ErrInvalidQuota, cmd.Metric)
+	}
+	if cmd.Limit <= 0 {
+		return fmt.Errorf("%w: limit must be positive, unset the quota to make it unlimited", domain.ErrInvalidQuota)
+	}
+
+	if err := s.repository.SetLimit(ctx, cmd.OrganizationID, cmd.Metric, cmd.Limit); err != nil {
+		return fmt.Errorf("failed to set quota: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) UnsetQuota(ctx context.Context, cmd backend.UnsetQuotaCommand) error {
+	if err := s.repository.UnsetLimit(ctx, cmd.OrganizationID, cmd.Metric); err != nil {
+		return fmt.Errorf("failed to unset quota: %w", err)
+	}
+	return nil
+}
+
+// usage returns the organization's usage of every metric in its current
+// period, with a zero limit for unlimited metrics.
+func (s *Service) usage(ctx context.Context, organizationID uuid.UUID) ([]backend.QuotaUsage, error) {
+	limits, err := s.repository.Limits(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quotas: %w", err)
+	}
+
+	now := s.now()
+	usage := make([]backend.QuotaUsage, len(quotaMetrics))
+	for i, metric := range quotaMetrics {
+		period := s.periods[metric]
+		start, end := periodBounds(period, now)
+		used, err := s.repository.Usage(ctx, organizationID, metric, start)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s usage: %w", metric, err)
+		}
+		usage[i] = backend.QuotaUsage{
+			Metric:   metric,
+			Limit:    limits[metric],
+			Used:     used,
+			Period:   period,
+			ResetsAt: end,
+		}
+	}
+	return usage, nil
+}
+
+// periodBounds returns the start and end of the period containing now. Days
+// start at midnight UTC, weeks on Monday and months on the first.
+func periodBounds(period backend.QuotaPeriod, now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case backend.QuotaPeriodWeek:
+		start := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		return start, start.AddDate(0, 0, 7)
+	case backend.QuotaPeriodMonth:
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return start, start.AddDate(0, 1, 0)
+	default:
+		return day, day.AddDate(0, 0, 1)
+	}
+}
+
+func periodAdjective(period backend.QuotaPeriod) string {
+	switch period {
+	case backend.QuotaPeriodWeek:
+		return "weekly"
+	case backend.QuotaPeriodMonth:
+		return "monthly"
+	default:
+		return "daily"
+	}
+}
+
+func metricLabel(metric backend.QuotaMetric) string {
+	if metric == backend.QuotaMetricLLMTokens {
+		return "LLM tokens"
+	}
+	return string(metric)
+}
@@ -0,0 +1,137 @@
+package quotasvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/quotasvc/domain"
+	"github.com/google/uuid"
+)
+
+type usageKey struct {
+	organizationID uuid.UUID
+	metric         backend.QuotaMetric
+	periodStart    time.Time
+}
+
+type memoryQuotaRepository struct {
+	limits map[uuid.UUID]map[backend.QuotaMetric]int64
+	usage  map[usageKey]int64
+}
+
+func newMemoryQuotaRepository() *memoryQuotaRepository {
+	return &memoryQuotaRepository{
+		limits: make(map[uuid.UUID]map[backend.QuotaMetric]int64),
+		usage:  make(map[usageKey]int64),
+	}
+}
+
+func (m *memoryQuotaRepository) SetLimit(ctx context.Context, organizationID uuid.UUID, metric backend.QuotaMetric, limit int64) error {
+	if m.limits[organizationID] == nil {
+		m.limits[organizationID] = make(map[backend.QuotaMetric]int64)
+	}
+	m.limits[organizationID][metric] = limit
+	return nil
+}
+
+func (m *memoryQuotaRepository) UnsetLimit(ctx context.Context, organizationID uuid.UUID, metric backend.QuotaMetric) error {
+	if _, ok := m.limits[organizationID][metric]; !ok {
+		return domain.ErrQuotaNotFound
+	}
+	delete(m.limits[organizationID], metric)
+	return nil
+}
+
+func (m *memoryQuotaRepository) Limits(ctx context.Context, organizationID uuid.UUID) (map[backend.QuotaMetric]int64, error) {
+	limits := make(map[backend.QuotaMetric]int64)
+	for metric, limit := range m.limits[organizationID] {
+		limits[metric] = limit
+	}
+	return limits, nil
+}
+
+func (m *memoryQuotaRepository) AddUsage(ctx context.Context, organizationID uuid.UUID, metric backend.QuotaMetric, periodStart time.Time, amount int64) error {
+	m.usage[usageKey{organizationID, metric, periodStart}] += amount
+	return nil
+}
+
+func (m *memoryQuotaRepository) Usage(ctx context.Context, organizationID uuid.UUID, metric backend.QuotaMetric, periodStart time.Time) (int64, error) {
+	return m.usage[usageKey{organizationID, metric, periodStart}], nil
+}
+
+func TestService(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(newMemoryQuotaRepository(), defaultPeriods)
+
+	now := time.Date(2025, 3, 14, 15, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	orgID := uuid.New()
+	for range 5 {
+		svc.RecordUsage(ctx, orgID, backend.QuotaMetricRequests, 1)
+	}
+	if err := svc.CheckQuotas(ctx, orgID); err != nil {
+		t.Fatalf("CheckQuotas() without limits error = %v, want unlimited", err)
+	}
+
+	if err := svc.SetQuota(ctx, backend.SetQuotaCommand{OrganizationID: orgID, Metric: backend.QuotaMetricRequests, Limit: 5}); err != nil {
+		t.Fatalf("SetQuota() error = %v", err)
+	}
+	if err := svc.CheckQuotas(ctx, orgID); !errors.Is(err, backend.ErrQuotaExceeded) {
+		t.Fatalf("CheckQuotas() at the limit error = %v, want ErrQuotaExceeded", err)
+	}
+	if err := svc.CheckQuotas(ctx, uuid.New()); err != nil {
+		t.Errorf("CheckQuotas() for another organization error = %v, want nil", err)
+	}
+
+	usage, err := svc.QuotaUsage(ctx, backend.QuotaUsageQuery{OrganizationID: orgID})
+	if err != nil {
+		t.Fatalf("QuotaUsage() error = %v", err)
+	}
+	want := []backend.QuotaUsage{
+		{Metric: backend.QuotaMetricRequests, Limit: 5, Used: 5, Period: backend.QuotaPeriodDay, ResetsAt: time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{Metric: backend.QuotaMetricLLMTokens, Period: backend.QuotaPeriodMonth, ResetsAt: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	if len(usage) != len(want) || usage[0] != want[0] || usage[1] != want[1] {
+		t.Errorf("QuotaUsage() = %+v, want %+v", usage, want)
+	}
+
+	now = now.Add(12 * time.Hour)
+	if err := svc.CheckQuotas(ctx, orgID); err != nil {
+		t.Errorf("CheckQuotas() the next day error = %v, want the daily quota reset", err)
+	}
+
+	t.Run("invalid quota", func(t *testing.T) {
+		for _, cmd := range []backend.SetQuotaCommand{
+			{OrganizationID: orgID, Metric: "storage", Limit: 10},
+			{OrganizationID: orgID, Metric: backend.QuotaMetricLLMTokens, Limit: 0},
+		} {
+			if err := svc.SetQuota(ctx, cmd); !errors.Is(err, domain.ErrInvalidQuota) {
+				t.Errorf("SetQuota(%+v) error = %v, want ErrInvalidQuota", cmd, err)
+			}
+		}
+	})
+}
+
+func TestPeriodBounds(t *testing.T) {
+	now := time.Date(2025, 3, 14, 15, 30, 0, 0, time.UTC) // a Friday
+	tests := []struct {
+		period    backend.QuotaPeriod
+		wantStart time.Time
+		wantEnd   time.Time
+	}{
+		{backend.QuotaPeriodDay, time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{backend.QuotaPeriodWeek, time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC)},
+		{backend.QuotaPeriodMonth, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		start, end := periodBounds(tt.period, now)
+		if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+			t.Errorf("periodBounds(%s) = %v, %v, want %v, %v", tt.period, start, end, tt.wantStart, tt.wantEnd)
+		}
+	}
+}
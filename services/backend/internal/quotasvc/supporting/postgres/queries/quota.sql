@@ -0,0 +1,25 @@
+-- name: UpsertQuota :exec
+INSERT INTO organization_quotas (organization_id, metric, quota_limit)
+VALUES ($1, $2, $3)
+ON CONFLICT (organization_id, metric)
+DO UPDATE SET quota_limit = EXCLUDED.quota_limit, updated_at = NOW();
+
+-- name: DeleteQuota :execrows
+DELETE FROM organization_quotas
+WHERE organization_id = $1 AND metric = $2;
+
+-- name: FindQuotasByOrganizationID :many
+SELECT organization_id, metric, quota_limit, created_at, updated_at
+FROM organization_quotas
+WHERE organization_id = $1
+ORDER BY metric;
+
+-- name: AddQuotaUsage :exec
+INSERT INTO organization_quota_usage (organization_id, metric, period_start, used)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (organization_id, metric, period_start)
+DO UPDATE SET used = organization_quota_usage.used + EXCLUDED.used;
+
+-- name: QuotaUsage :one
+SELECT used FROM organization_quota_usage
+WHERE organization_id = $1 AND metric = $2 AND period_start = $3;
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/pgretry"
+	"github.com/73ai/infragpt/services/backend/internal/quotasvc/domain"
+	"github.com/google/uuid"
+)
+
+type quotaRepository struct {
+	queries *Queries
+}
+
+func NewQuotaRepository(sqlDB *sql.DB) domain.QuotaRepository {
+	return &quotaRepository{
+		queries: New(pgretry.Wrap(sqlDB)),
+	}
+}
+
+func (r *quotaRepository) SetLimit(ctx context.Context, organizationID uuid.UUID, metric backend.QuotaMetric, limit int64) error {
+	err := r.queries.UpsertQuota(ctx, UpsertQuotaParams{
+		OrganizationID: organizationID,
+		Metric:         string(metric),
+		QuotaLimit:     limit,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set quota: %w", err)
+	}
+	return nil
+}
+
+func (r *quotaRepository) UnsetLimit(ctx context.Context, organizationID uuid.UUID, metric backend.QuotaMetric) error {
+	deleted, err := r.queries.DeleteQuota(ctx, DeleteQuotaParams{
+		OrganizationID: organizationID,
+		Metric:         string(metric),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unset quota: %w", err)
+	}
+	if deleted == 0 {
+		return domain.ErrQuotaNotFound
+	}
+	return nil
+}
+
+func (r *quotaRepository) Limits(ctx context.Context, organizationID uuid.UUID) (map[backend.QuotaMetric]int64, error) {
+	rows, err := r.queries.FindQuotasByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quotas: %w", err)
+	}
+
+	limits := make(map[backend.QuotaMetric]int64, len(rows))
+	for _, row := range rows {
+		limits[backend.QuotaMetric(row.Metric)] = row.QuotaLimit
+	}
+	return limits, nil
+}
+
+func (r *quotaRepository) AddUsage(ctx context.Context, organizationID uuid.UUID, metric backend.QuotaMetric, periodStart time.Time, amount int64) error {
+	err := r.queries.AddQuotaUsage(ctx, AddQuotaUsageParams{
+		OrganizationID: organizationID,
+		Metric:         string(metric),
+		PeriodStart:    periodStart,
+		Used:           amount,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add quota usage: %w", err)
+	}
+	return nil
+}
+
+func (r *quotaRepository) Usage(ctx context.Context, organizationID uuid.UUID, metric backend.QuotaMetric, periodStart time.Time) (int64, error) {
+	used, err := r.queries.QuotaUsage(ctx, QuotaUsageParams{
+		OrganizationID: organizationID,
+		Metric:         string(metric),
+		PeriodStart:    periodStart,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get quota usage: %w", err)
+	}
+	return used, nil
+}
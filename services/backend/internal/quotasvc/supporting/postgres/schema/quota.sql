@@ -0,0 +1,16 @@
+CREATE TABLE organization_quotas (
+    organization_id UUID NOT NULL,
+    metric VARCHAR(32) NOT NULL,
+    quota_limit BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, metric)
+);
+
+CREATE TABLE organization_quota_usage (
+    organization_id UUID NOT NULL,
+    metric VARCHAR(32) NOT NULL,
+    period_start TIMESTAMP WITH TIME ZONE NOT NULL,
+    used BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (organization_id, metric, period_start)
+);
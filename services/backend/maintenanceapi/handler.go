@@ -0,0 +1,89 @@
+package maintenanceapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/73ai/infragpt/services/backend/internal/generic/maintenance"
+)
+
+type httpHandler struct {
+	http.ServeMux
+	mode *maintenance.Mode
+}
+
+func (h *httpHandler) init() {
+	h.HandleFunc("/maintenance/status/", h.status())
+	h.HandleFunc("/maintenance/set/", h.set())
+}
+
+func NewHandler(mode *maintenance.Mode,
+	adminMiddleware func(handler http.Handler) http.Handler) http.Handler {
+	h := &httpHandler{
+		mode: mode,
+	}
+
+	h.init()
+	return adminMiddleware(h)
+}
+
+type statusResponse struct {
+	ReadOnly          bool `json:"read_only"`
+	RetryAfterSeconds int  `json:"retry_after_seconds"`
+}
+
+func (h *httpHandler) statusResponse() statusResponse {
+	return statusResponse{
+		ReadOnly:          h.mode.ReadOnly(),
+		RetryAfterSeconds: int(h.mode.RetryAfter().Seconds()),
+	}
+}
+
+func (h *httpHandler) status() func(w http.ResponseWriter, r *http.Request) {
+	type request struct{}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (statusResponse, error) {
+		return h.statusResponse(), nil
+	})
+}
+
+func (h *httpHandler) set() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		ReadOnly *bool `json:"read_only"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (statusResponse, error) {
+		if req.ReadOnly == nil {
+			return statusResponse{}, httperrors.Validation("read_only is required", "read_only")
+		}
+
+		h.mode.SetReadOnly(*req.ReadOnly)
+		return h.statusResponse(), nil
+	})
+}
+
+func ApiHandlerFunc[T any, R any](handler func(context.Context, T) (R, error)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var request T
+		if r.Method == http.MethodPost && r.Body != nil {
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
+				return
+			}
+		}
+
+		response, err := handler(ctx, request)
+		if err != nil {
+			httperrors.Write(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
@@ -0,0 +1,5 @@
+-- Migration: Record the LLM model used for each message
+-- Run this against the backend database
+-- NULL means the agent's default model was used
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS model VARCHAR(100);
@@ -0,0 +1,12 @@
+-- Migration: Per-organization feature flag overrides
+-- Run this against the backend database
+-- Flags without a row fall back to feature_flags.defaults in config, then off
+
+CREATE TABLE IF NOT EXISTS organization_feature_flags (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    flag_key VARCHAR(100) NOT NULL,
+    flag_value VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, flag_key)
+);
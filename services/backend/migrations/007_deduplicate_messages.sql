@@ -0,0 +1,12 @@
+-- Migration: Deduplicate messages delivered more than once by Slack retries
+-- Run this against the backend database
+-- New messages carry their Slack event and client message IDs and are stored
+-- once. Existing rows have neither ID, so the unique indexes do not touch them;
+-- copies stored by earlier retries are removed separately with
+-- `backend remove-retried-messages`, which counts them unless run with -delete.
+
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS slack_event_id VARCHAR(64);
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS client_msg_id VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_slack_event_id ON messages(slack_event_id) WHERE slack_event_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_client_msg_id ON messages(conversation_id, client_msg_id) WHERE client_msg_id IS NOT NULL;
@@ -0,0 +1,6 @@
+-- Migration: Allow the needs_reauthorization integration status
+-- Run this against the backend database
+-- Integrations restored from an export bundle whose credentials no longer
+-- validate are marked needs_reauthorization, which does not fit VARCHAR(20)
+
+ALTER TABLE integrations ALTER COLUMN status TYPE VARCHAR(32);
@@ -0,0 +1,14 @@
+-- Migration: Default context bindings for Slack channels
+-- Run this against the backend database
+-- Repositories, Kubernetes namespaces and GCP projects bound to a channel are
+-- passed to the agent for every conversation in that channel.
+
+CREATE TABLE IF NOT EXISTS channel_contexts (
+    team_id VARCHAR(36) NOT NULL,
+    channel_id VARCHAR(36) NOT NULL,
+    repositories TEXT[] NOT NULL DEFAULT '{}',
+    kubernetes_namespaces TEXT[] NOT NULL DEFAULT '{}',
+    gcp_projects TEXT[] NOT NULL DEFAULT '{}',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (team_id, channel_id)
+);
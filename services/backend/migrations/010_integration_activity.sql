@@ -0,0 +1,15 @@
+-- Migration: Per-integration activity feed
+-- Run this against the backend database
+-- Records authorizations, syncs, webhooks, status transitions and validation
+-- failures so support can see an integration's recent history.
+
+CREATE TABLE IF NOT EXISTS integration_activity (
+    id UUID PRIMARY KEY,
+    integration_id UUID NOT NULL,
+    organization_id UUID NOT NULL,
+    activity_type VARCHAR(64) NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_integration_activity_integration_created ON integration_activity (integration_id, created_at DESC);
@@ -0,0 +1,24 @@
+-- Migration: Command execution audit log
+-- Run this against the backend database
+-- Records every command the agent asks to run, the policy decision and the
+-- start of its output.
+
+CREATE TABLE IF NOT EXISTS command_audit_log (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL,
+    integration_id UUID,
+    conversation_id VARCHAR(255) NOT NULL DEFAULT '',
+    binary_name VARCHAR(64) NOT NULL,
+    args TEXT[] NOT NULL DEFAULT '{}',
+    decision VARCHAR(16) NOT NULL,
+    rule VARCHAR(64) NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    exit_code INTEGER,
+    timed_out BOOLEAN NOT NULL DEFAULT FALSE,
+    output TEXT NOT NULL DEFAULT '',
+    output_truncated BOOLEAN NOT NULL DEFAULT FALSE,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_command_audit_log_org_started ON command_audit_log (organization_id, started_at DESC);
@@ -0,0 +1,19 @@
+-- Migration: Thumbs up/down feedback on agent replies
+-- Run this against the backend database
+-- One row per Slack user and rated reply; rating again updates the row.
+
+CREATE TABLE IF NOT EXISTS message_feedback (
+    feedback_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    message_id UUID NOT NULL REFERENCES messages(message_id) ON DELETE CASCADE,
+    conversation_id UUID NOT NULL REFERENCES conversations(conversation_id) ON DELETE CASCADE,
+    invocation_message_id UUID REFERENCES messages(message_id) ON DELETE SET NULL,
+    team_id VARCHAR(36) NOT NULL,
+    user_id VARCHAR(36) NOT NULL,
+    rating VARCHAR(16) NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE(message_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_message_feedback_team_created ON message_feedback(team_id, created_at);
@@ -0,0 +1,10 @@
+-- Migration: Track the bot's channel introductions
+-- Run this against the backend database
+-- The bot introduces itself when added to a channel, at most once per cooldown.
+
+CREATE TABLE IF NOT EXISTS channel_intros (
+    team_id VARCHAR(36) NOT NULL,
+    channel_id VARCHAR(36) NOT NULL,
+    posted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (team_id, channel_id)
+);
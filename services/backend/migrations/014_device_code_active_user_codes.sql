@@ -0,0 +1,23 @@
+-- Migration: Unique device flow user codes among active codes only
+-- Run this against the backend database
+-- Used and expired codes no longer block a new flow from drawing the same
+-- user code; the service retries when a generated code is still active.
+
+CREATE TABLE IF NOT EXISTS device_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    device_code TEXT UNIQUE NOT NULL,
+    user_code VARCHAR(10) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    organization_id UUID,
+    user_id UUID,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE device_codes DROP CONSTRAINT IF EXISTS device_codes_user_code_key;
+DROP INDEX IF EXISTS idx_device_codes_user_code;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_device_codes_active_user_code ON device_codes (user_code)
+    WHERE status IN ('pending', 'authorized');
+CREATE INDEX IF NOT EXISTS idx_device_codes_device_code ON device_codes (device_code);
+CREATE INDEX IF NOT EXISTS idx_device_codes_expires_at ON device_codes (expires_at);
@@ -0,0 +1,17 @@
+-- Migration: Map Slack users to InfraGPT users
+-- Run this against the backend database
+-- Slack users are matched to a member of the workspace's organization by email
+-- on their first message; admins can override the match.
+
+CREATE TABLE IF NOT EXISTS slack_user_mappings (
+    team_id VARCHAR(36) NOT NULL,
+    slack_user_id VARCHAR(36) NOT NULL,
+    organization_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    source VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (team_id, slack_user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_slack_user_mappings_organization ON slack_user_mappings(organization_id);
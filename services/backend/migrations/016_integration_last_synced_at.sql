@@ -0,0 +1,5 @@
+-- Migration: Record when each integration last synced
+-- Run this against the backend database
+-- The background sync scheduler uses this to skip integrations synced recently.
+
+ALTER TABLE integrations ADD COLUMN IF NOT EXISTS last_synced_at TIMESTAMP;
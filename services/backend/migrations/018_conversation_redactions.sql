@@ -0,0 +1,10 @@
+-- Migration: Count secrets redacted from conversations
+-- Run this against the backend database
+-- Incremented whenever credentials are stripped from a message before it is
+-- sent to the agent or posted to Slack.
+
+CREATE TABLE IF NOT EXISTS conversation_redactions (
+    conversation_id UUID PRIMARY KEY REFERENCES conversations(conversation_id) ON DELETE CASCADE,
+    redaction_count INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,5 @@
+-- Migration: Let organizations deselect synced GitHub repositories
+-- Run this against the backend database
+-- Disabled repositories stay synced but cannot be bound to channels for the agent.
+
+ALTER TABLE github_repositories ADD COLUMN IF NOT EXISTS enabled BOOLEAN NOT NULL DEFAULT true;
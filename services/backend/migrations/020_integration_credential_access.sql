@@ -0,0 +1,31 @@
+-- Migration: Credential access audit trail
+-- Run this against the backend database
+-- Records every read of integration credentials. Like the command audit log
+-- it is append-only and kept indefinitely, including after the integration is
+-- deleted.
+
+CREATE TABLE IF NOT EXISTS integration_credential_access (
+    id UUID PRIMARY KEY,
+    integration_id UUID NOT NULL,
+    organization_id UUID NOT NULL,
+    accessor_type VARCHAR(16) NOT NULL,
+    accessor_id VARCHAR(255) NOT NULL DEFAULT '',
+    user_id VARCHAR(255) NOT NULL DEFAULT '',
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    fields TEXT[] NOT NULL DEFAULT '{}',
+    accessed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_integration_credential_access_integration_accessed ON integration_credential_access (integration_id, accessed_at DESC);
+
+CREATE OR REPLACE FUNCTION reject_credential_access_changes() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'integration_credential_access is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS integration_credential_access_append_only ON integration_credential_access;
+CREATE TRIGGER integration_credential_access_append_only
+    BEFORE UPDATE OR DELETE ON integration_credential_access
+    FOR EACH ROW EXECUTE FUNCTION reject_credential_access_changes();
@@ -0,0 +1,13 @@
+-- Migration: Serve every workspace of an Enterprise Grid organization
+-- Run this against the backend database
+-- An org-wide install stores one bot token under the enterprise ID; workspaces
+-- are linked to their enterprise as their events arrive so the token and the
+-- organization can be resolved from the workspace's team ID.
+
+CREATE TABLE IF NOT EXISTS slack_workspaces (
+    team_id VARCHAR(36) PRIMARY KEY,
+    enterprise_id VARCHAR(36) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_slack_workspaces_enterprise ON slack_workspaces(enterprise_id);
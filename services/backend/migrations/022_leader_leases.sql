@@ -0,0 +1,10 @@
+-- Migration: Leader election leases
+-- Run this against the backend database
+-- Work that must run on a single replica, such as the Slack Socket Mode
+-- subscription, is guarded by a lease that its holder renews while it runs.
+
+CREATE TABLE IF NOT EXISTS leader_leases (
+    name VARCHAR(64) PRIMARY KEY,
+    holder VARCHAR(255) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
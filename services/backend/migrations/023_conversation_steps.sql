@@ -0,0 +1,18 @@
+-- Migration: Conversation step timeline
+-- Run this against the backend database
+-- Records each tool the agent used while answering in a conversation, such as
+-- the commands it ran through the execution service, for replaying what it did.
+
+CREATE TABLE IF NOT EXISTS conversation_steps (
+    step_id UUID PRIMARY KEY,
+    conversation_id UUID NOT NULL REFERENCES conversations(conversation_id) ON DELETE CASCADE,
+    tool VARCHAR(64) NOT NULL,
+    arguments TEXT[] NOT NULL DEFAULT '{}',
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    outcome VARCHAR(16) NOT NULL,
+    result TEXT NOT NULL DEFAULT '',
+    result_truncated BOOLEAN NOT NULL DEFAULT FALSE,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversation_steps_conversation_started ON conversation_steps(conversation_id, started_at);
@@ -0,0 +1,26 @@
+-- Migration: Sync repositories from Azure DevOps
+-- Run this against the backend database
+-- Azure DevOps repositories are kept apart from github_repositories. They are
+-- identified by GUID, so each row gets a numeric ID to expose as the
+-- repository ID.
+
+CREATE TABLE IF NOT EXISTS azure_devops_repositories (
+    id BIGSERIAL PRIMARY KEY,
+    integration_id UUID NOT NULL REFERENCES integrations(id) ON DELETE CASCADE,
+    azure_repository_id UUID NOT NULL,
+    project_id UUID NOT NULL,
+    project_name VARCHAR(255) NOT NULL,
+    repository_name VARCHAR(255) NOT NULL,
+    repository_full_name VARCHAR(512) NOT NULL,
+    repository_url VARCHAR(512) NOT NULL,
+    is_private BOOLEAN NOT NULL DEFAULT true,
+    default_branch VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_synced_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    UNIQUE(integration_id, azure_repository_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_azure_devops_repos_integration ON azure_devops_repositories (integration_id);
+CREATE INDEX IF NOT EXISTS idx_azure_devops_repos_full_name ON azure_devops_repositories (repository_full_name);
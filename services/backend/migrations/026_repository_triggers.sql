@@ -0,0 +1,17 @@
+-- Migration: Start conversations from repository events
+-- Run this against the backend database
+-- A trigger maps pushes or pull requests in repositories matching a pattern,
+-- optionally touching files matching a glob, to a Slack channel.
+
+CREATE TABLE IF NOT EXISTS repository_triggers (
+    id UUID PRIMARY KEY,
+    organization_id UUID NOT NULL,
+    repository_pattern VARCHAR(512) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    path_glob VARCHAR(512) NOT NULL DEFAULT '',
+    channel_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_repository_triggers_organization ON repository_triggers (organization_id);
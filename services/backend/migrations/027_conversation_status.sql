@@ -0,0 +1,17 @@
+-- Migration: Conversation handoff to a human
+-- Run this against the backend database
+-- Escalated conversations are handed off to a human and the agent stays quiet
+-- in them until someone resumes it. Every status change is kept for auditing.
+
+ALTER TABLE conversations ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'active';
+
+CREATE TABLE IF NOT EXISTS conversation_status_changes (
+    change_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    conversation_id UUID NOT NULL REFERENCES conversations(conversation_id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL,
+    changed_by VARCHAR(36) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversation_status_changes_conversation ON conversation_status_changes(conversation_id, changed_at);
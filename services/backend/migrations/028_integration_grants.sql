@@ -0,0 +1,6 @@
+-- Migration: Capabilities granted over each integration
+-- Run this against the backend database
+-- Existing integrations keep full access; organizations can narrow it to
+-- read-only afterwards.
+
+ALTER TABLE integrations ADD COLUMN IF NOT EXISTS grants TEXT[] NOT NULL DEFAULT '{read_content,write_content,read_infra,write_infra}';
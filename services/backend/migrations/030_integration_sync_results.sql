@@ -0,0 +1,6 @@
+-- Migration: Record what each integration sync changed
+-- Run this against the backend database
+-- Counts of added, removed and updated resources and the per-resource errors
+-- reported by the connector.
+
+ALTER TABLE integration_sync_jobs ADD COLUMN IF NOT EXISTS result JSONB NOT NULL DEFAULT '{}';
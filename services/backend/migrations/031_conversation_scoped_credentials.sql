@@ -0,0 +1,9 @@
+-- Migration: Conversation-scoped credentials
+-- Run this against the backend database
+-- Agent operations receive short-lived credentials minted per conversation;
+-- the credential access log and the command audit log name the conversation
+-- and tool call they were minted for.
+
+ALTER TABLE integration_credential_access ADD COLUMN IF NOT EXISTS conversation_id VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE integration_credential_access ADD COLUMN IF NOT EXISTS tool_call_id VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE command_audit_log ADD COLUMN IF NOT EXISTS tool_call_id VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,13 @@
+-- Migration: Organization onboarding
+-- Run this against the backend database
+-- Onboarding steps each organization completed or skipped. Steps without a
+-- row are pending; completed steps are never changed again.
+
+CREATE TABLE IF NOT EXISTS organization_onboarding_steps (
+    organization_id UUID NOT NULL,
+    step VARCHAR(32) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    manual BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (organization_id, step)
+);
@@ -0,0 +1,6 @@
+-- Migration: GitHub repository profiles
+-- Run this against the backend database
+-- Languages and marker files found when a repository was last profiled, with
+-- the time it was profiled. NULL until the first profile.
+
+ALTER TABLE github_repositories ADD COLUMN IF NOT EXISTS repository_profile JSONB;
@@ -0,0 +1,10 @@
+-- Migration: Claim scheduled integration syncs
+-- Run this against the backend database
+-- Every replica checks for integrations due for a scheduled sync; the one that
+-- claims an integration syncs it, and the others skip it until the claim
+-- expires.
+
+CREATE TABLE IF NOT EXISTS integration_sync_claims (
+    integration_id UUID PRIMARY KEY REFERENCES integrations(id) ON DELETE CASCADE,
+    claimed_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
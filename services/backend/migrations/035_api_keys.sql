@@ -0,0 +1,18 @@
+-- Migration: Organization API keys
+-- Run this against the backend database
+-- Keys for the public API, issued to an organization by one of its members and
+-- valid until revoked. Only a SHA-256 hash of each key is stored; key_prefix
+-- is kept so members can tell their keys apart.
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL,
+    key_hash TEXT UNIQUE NOT NULL,
+    organization_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_organization_id ON api_keys (organization_id, created_at DESC);
@@ -0,0 +1,11 @@
+-- Migration: Record completed integration authorizations
+-- Run this against the backend database
+-- A retried or refreshed authorization callback may reach another replica; it
+-- finds the integration the first callback created here instead of failing
+-- because the integration already exists.
+
+CREATE TABLE IF NOT EXISTS integration_authorizations (
+    authorization_key TEXT PRIMARY KEY,
+    integration_id UUID NOT NULL REFERENCES integrations(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,124 @@
+// Package migrations embeds the SQL migrations so the backend can tell which
+// of them a database is missing. Migrations are applied by hand and are not
+// tracked in the database, so Pending infers their state from the tables,
+// indexes and columns each one creates.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+var (
+	createRelationPattern = regexp.MustCompile(`(?im)^\s*CREATE\s+(?:UNIQUE\s+)?(?:TABLE|INDEX)\s+IF\s+NOT\s+EXISTS\s+([a-z0-9_]+)`)
+	dropRelationPattern   = regexp.MustCompile(`(?im)^\s*DROP\s+(?:TABLE|INDEX)\s+IF\s+EXISTS\s+([a-z0-9_]+)`)
+	addColumnPattern      = regexp.MustCompile(`(?im)^\s*ALTER\s+TABLE\s+([a-z0-9_]+)\s+ADD\s+COLUMN\s+IF\s+NOT\s+EXISTS\s+([a-z0-9_]+)`)
+)
+
+type column struct {
+	table, name string
+}
+
+// migration lists the objects a migration leaves behind once later
+// migrations have run; objects a later migration drops are not expected.
+type migration struct {
+	name      string
+	relations []string
+	columns   []column
+}
+
+func parse(fsys fs.FS) ([]migration, error) {
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+	sort.Strings(names)
+
+	var migrations []migration
+	// dropped holds the index of the last migration dropping each relation.
+	dropped := make(map[string]int)
+	for i, name := range names {
+		contents, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", name, err)
+		}
+		m := migration{name: name}
+		for _, match := range createRelationPattern.FindAllStringSubmatch(string(contents), -1) {
+			m.relations = append(m.relations, match[1])
+		}
+		for _, match := range dropRelationPattern.FindAllStringSubmatch(string(contents), -1) {
+			dropped[match[1]] = i
+		}
+		for _, match := range addColumnPattern.FindAllStringSubmatch(string(contents), -1) {
+			m.columns = append(m.columns, column{table: match[1], name: match[2]})
+		}
+		migrations = append(migrations, m)
+	}
+
+	for i := range migrations {
+		var relations []string
+		for _, relation := range migrations[i].relations {
+			if at, ok := dropped[relation]; !ok || at < i {
+				relations = append(relations, relation)
+			}
+		}
+		migrations[i].relations = relations
+	}
+	return migrations, nil
+}
+
+// Pending returns the names of the migrations whose tables, indexes or
+// columns are missing from the database, in the order they should be applied.
+func Pending(ctx context.Context, db *sql.DB) ([]string, error) {
+	migrations, err := parse(files)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []string
+	for _, m := range migrations {
+		applied, err := isApplied(ctx, db, m)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check migration %s: %w", m.name, err)
+		}
+		if !applied {
+			pending = append(pending, m.name)
+		}
+	}
+	return pending, nil
+}
+
+func isApplied(ctx context.Context, db *sql.DB, m migration) (bool, error) {
+	for _, relation := range m.relations {
+		var exists bool
+		if err := db.QueryRowContext(ctx, `SELECT to_regclass($1) IS NOT NULL`, relation).Scan(&exists); err != nil {
+			return false, err
+		}
+		if !exists {
+			return false, nil
+		}
+	}
+	for _, c := range m.columns {
+		var exists bool
+		err := db.QueryRowContext(ctx, `
+SELECT EXISTS (
+    SELECT 1 FROM information_schema.columns
+    WHERE table_schema = ANY (current_schemas(false)) AND table_name = $1 AND column_name = $2
+)`, c.table, c.name).Scan(&exists)
+		if err != nil {
+			return false, err
+		}
+		if !exists {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,55 @@
+package migrations
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestParse(t *testing.T) {
+	fsys := fstest.MapFS{
+		"001_tables.sql": {Data: []byte(`
+CREATE TABLE IF NOT EXISTS users (id UUID PRIMARY KEY);
+CREATE TABLE IF NOT EXISTS invites (id UUID PRIMARY KEY);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invites_id ON invites (id);
+`)},
+		"002_drop_invites.sql": {Data: []byte(`
+DROP INDEX IF EXISTS idx_invites_id;
+DROP TABLE IF EXISTS invites;
+`)},
+		"003_users_email.sql": {Data: []byte(`
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email TEXT;
+CREATE INDEX IF NOT EXISTS idx_users_email ON users (email);
+`)},
+	}
+
+	got, err := parse(fsys)
+	if err != nil {
+		t.Fatalf("parse() error = %v", err)
+	}
+	want := []migration{
+		{name: "001_tables.sql", relations: []string{"users"}},
+		{name: "002_drop_invites.sql"},
+		{name: "003_users_email.sql", relations: []string{"idx_users_email"}, columns: []column{{table: "users", name: "email"}}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parse() = %+v, want %+v", got, want)
+	}
+}
+
+func TestParseEmbedded(t *testing.T) {
+	migrations, err := parse(files)
+	if err != nil {
+		t.Fatalf("parse() error = %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("parse() found no embedded migrations")
+	}
+	for _, m := range migrations {
+		for _, relation := range m.relations {
+			if relation == "unclaimed_installations" {
+				t.Errorf("%s expects unclaimed_installations, which a later migration drops", m.name)
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidOnboardingStep = errors.New("invalid onboarding step")
+	// ErrOnboardingStepCompleted is returned when skipping or reopening a step
+	// that is already completed.
+	ErrOnboardingStepCompleted = errors.New("onboarding step already completed")
+)
+
+// OnboardingStep is one of the steps a new organization is guided through.
+type OnboardingStep string
+
+const (
+	OnboardingStepConnectSlack      OnboardingStep = "connect_slack"
+	OnboardingStepConnectGitHub     OnboardingStep = "connect_github"
+	OnboardingStepConnectCloud      OnboardingStep = "connect_cloud"
+	OnboardingStepInviteTeammates   OnboardingStep = "invite_teammates"
+	OnboardingStepFirstConversation OnboardingStep = "first_conversation"
+)
+
+// OnboardingSteps lists the steps in the order they are suggested.
+var OnboardingSteps = []OnboardingStep{
+	OnboardingStepConnectSlack,
+	OnboardingStepConnectGitHub,
+	OnboardingStepConnectCloud,
+	OnboardingStepInviteTeammates,
+	OnboardingStepFirstConversation,
+}
+
+// OnboardingStepStatus moves from pending to skipped or completed. Skipped
+// steps can be reopened or completed; completed steps stay completed.
+type OnboardingStepStatus string
+
+const (
+	OnboardingStepStatusPending   OnboardingStepStatus = "pending"
+	OnboardingStepStatusSkipped   OnboardingStepStatus = "skipped"
+	OnboardingStepStatusCompleted OnboardingStepStatus = "completed"
+)
+
+// OnboardingService tracks each organization's progress through onboarding.
+type OnboardingService interface {
+	// Onboarding reports the status of every step. Steps the organization's
+	// data shows as done, such as a connected integration, are completed
+	// without being marked.
+	Onboarding(ctx context.Context, query OnboardingQuery) (Onboarding, error)
+	// MarkOnboardingStep skips, reopens or completes a step by hand.
+	MarkOnboardingStep(ctx context.Context, cmd MarkOnboardingStepCommand) (Onboarding, error)
+}
+
+// OnboardingReporter is implemented by services that hold the data onboarding
+// steps are detected from.
+type OnboardingReporter interface {
+	// CompletedOnboardingSteps returns the steps the service's data shows the
+	// organization has done.
+	CompletedOnboardingSteps(ctx context.Context, organizationID uuid.UUID) ([]OnboardingStep, error)
+}
+
+// OnboardingStepListener is notified once when each step of an organization is
+// completed, whether detected or marked by hand.
+type OnboardingStepListener interface {
+	OnboardingStepCompleted(ctx context.Context, completion OnboardingStepCompletion)
+}
+
+type OnboardingQuery struct {
+	OrganizationID uuid.UUID
+}
+
+type MarkOnboardingStepCommand struct {
+	OrganizationID uuid.UUID
+	Step           OnboardingStep
+	Status         OnboardingStepStatus
+}
+
+// Onboarding holds an organization's steps in the order of OnboardingSteps.
+type Onboarding struct {
+	OrganizationID uuid.UUID
+	Steps          []OnboardingStepState
+}
+
+type OnboardingStepState struct {
+	Step   OnboardingStep
+	Status OnboardingStepStatus
+	// Manual is true when the status was marked by hand rather than detected.
+	Manual bool
+	// UpdatedAt is when the status last changed, and zero for steps still in
+	// their initial pending state.
+	UpdatedAt time.Time
+}
+
+// NextStep returns the first pending step, or false when every step is
+// skipped or completed.
+func (o Onboarding) NextStep() (OnboardingStep, bool) {
+	for _, step := range o.Steps {
+		if step.Status == OnboardingStepStatusPending {
+			return step.Step, true
+		}
+	}
+	return "", false
+}
+
+// Status returns the status of step, which is pending for unknown steps.
+func (o Onboarding) Status(step OnboardingStep) OnboardingStepStatus {
+	for _, s := range o.Steps {
+		if s.Step == step {
+			return s.Status
+		}
+	}
+	return OnboardingStepStatusPending
+}
+
+type OnboardingStepCompletion struct {
+	OrganizationID uuid.UUID
+	Step           OnboardingStep
+	Manual         bool
+	CompletedAt    time.Time
+}
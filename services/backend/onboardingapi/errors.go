@@ -0,0 +1,18 @@
+package onboardingapi
+
+import (
+	"net/http"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+)
+
+const (
+	codeInvalidStep   = "invalid_onboarding_step"
+	codeStepCompleted = "onboarding_step_completed"
+)
+
+var errorMappings = []httperrors.Mapping{
+	{Target: backend.ErrInvalidOnboardingStep, HttpStatus: http.StatusBadRequest, Code: codeInvalidStep},
+	{Target: backend.ErrOnboardingStepCompleted, HttpStatus: http.StatusConflict, Code: codeStepCompleted},
+}
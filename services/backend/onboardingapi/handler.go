@@ -0,0 +1,155 @@
+package onboardingapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/google/uuid"
+)
+
+type httpHandler struct {
+	http.ServeMux
+	svc backend.OnboardingService
+}
+
+func (h *httpHandler) init() {
+	h.HandleFunc("/onboarding/status/", h.status())
+	h.HandleFunc("/onboarding/steps/mark/", h.markStep())
+}
+
+func NewHandler(onboardingService backend.OnboardingService,
+	authMiddleware func(handler http.Handler) http.Handler) http.Handler {
+	h := &httpHandler{
+		svc: onboardingService,
+	}
+
+	h.init()
+	return authMiddleware(h)
+}
+
+type onboardingStep struct {
+	Step   string `json:"step"`
+	Status string `json:"status"`
+	Manual bool   `json:"manual"`
+	// UpdatedAt is empty for steps that are still in their initial state.
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+type onboardingResponse struct {
+	OrganizationID string           `json:"organization_id"`
+	Steps          []onboardingStep `json:"steps"`
+	// NextStep is empty once every step is completed or skipped.
+	NextStep string `json:"next_step"`
+}
+
+func newOnboardingResponse(onboarding backend.Onboarding) onboardingResponse {
+	resp := onboardingResponse{
+		OrganizationID: onboarding.OrganizationID.String(),
+		Steps:          make([]onboardingStep, len(onboarding.Steps)),
+	}
+	for i, step := range onboarding.Steps {
+		resp.Steps[i] = onboardingStep{
+			Step:   string(step.Step),
+			Status: string(step.Status),
+			Manual: step.Manual,
+		}
+		if !step.UpdatedAt.IsZero() {
+			resp.Steps[i].UpdatedAt = step.UpdatedAt.Format(time.RFC3339)
+		}
+	}
+	if next, ok := onboarding.NextStep(); ok {
+		resp.NextStep = string(next)
+	}
+	return resp
+}
+
+// status reports the organization's onboarding steps. It takes organization_id
+// as a query parameter on GET, or in the JSON body on POST.
+func (h *httpHandler) status() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		OrganizationID string `json:"organization_id"`
+	}
+
+	status := func(ctx context.Context, req request) (onboardingResponse, error) {
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return onboardingResponse{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		onboarding, err := h.svc.Onboarding(ctx, backend.OnboardingQuery{OrganizationID: organizationID})
+		if err != nil {
+			return onboardingResponse{}, err
+		}
+		return newOnboardingResponse(onboarding), nil
+	}
+
+	post := ApiHandlerFunc(status)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			post(w, r)
+			return
+		}
+
+		resp, err := status(r.Context(), request{OrganizationID: r.URL.Query().Get("organization_id")})
+		writeResponse(w, r, resp, err)
+	}
+}
+
+// markStep skips, reopens or completes a step. Status is skipped, pending or
+// completed.
+func (h *httpHandler) markStep() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		OrganizationID string `json:"organization_id"`
+		Step           string `json:"step"`
+		Status         string `json:"status"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (onboardingResponse, error) {
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return onboardingResponse{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		onboarding, err := h.svc.MarkOnboardingStep(ctx, backend.MarkOnboardingStepCommand{
+			OrganizationID: organizationID,
+			Step:           backend.OnboardingStep(req.Step),
+			Status:         backend.OnboardingStepStatus(req.Status),
+		})
+		if err != nil {
+			return onboardingResponse{}, err
+		}
+		return newOnboardingResponse(onboarding), nil
+	})
+}
+
+func ApiHandlerFunc[T any, R any](handler func(context.Context, T) (R, error)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var request T
+		if r.Method == http.MethodPost && r.Body != nil {
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
+				return
+			}
+		}
+
+		response, err := handler(ctx, request)
+		writeResponse(w, r, response, err)
+	}
+}
+
+func writeResponse[R any](w http.ResponseWriter, r *http.Request, response R, err error) {
+	if err != nil {
+		httperrors.Write(w, r, err, errorMappings...)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
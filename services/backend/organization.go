@@ -0,0 +1,52 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// OrganizationService removes an organization's data from every service when
+// the customer offboards.
+type OrganizationService interface {
+	// DeleteOrganization deletes the organization's data service by service in
+	// dependency order and stops at the first step that fails. Running it again
+	// resumes from that step.
+	DeleteOrganization(ctx context.Context, cmd DeleteOrganizationCommand) (OrganizationDeletionReport, error)
+}
+
+// OrganizationDataOwner is implemented by services that store data for an
+// organization. DeleteOrganizationData must be safe to run again after a
+// partial failure.
+type OrganizationDataOwner interface {
+	DeleteOrganizationData(ctx context.Context, cmd DeleteOrganizationCommand) ([]DeletedResource, error)
+}
+
+type DeleteOrganizationCommand struct {
+	OrganizationID uuid.UUID
+	// DryRun reports what would be deleted without deleting or revoking anything.
+	DryRun bool
+}
+
+type OrganizationDeletionReport struct {
+	OrganizationID uuid.UUID
+	DryRun         bool
+	Steps          []OrganizationDeletionStep
+	// Complete is false when a step failed; later steps did not run.
+	Complete bool
+}
+
+type OrganizationDeletionStep struct {
+	Name      string
+	Resources []DeletedResource
+	// Error is set when the step failed. Resources of a failed step only
+	// count what was deleted before the failure.
+	Error string
+}
+
+// DeletedResource counts the deleted records of one kind, or with DryRun the
+// records that would be deleted.
+type DeletedResource struct {
+	Type  string
+	Count int
+}
@@ -0,0 +1,108 @@
+package organizationapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/google/uuid"
+)
+
+type httpHandler struct {
+	http.ServeMux
+	svc backend.OrganizationService
+}
+
+func (h *httpHandler) init() {
+	h.HandleFunc("/organizations/delete/", h.delete())
+}
+
+func NewHandler(organizationService backend.OrganizationService,
+	adminMiddleware func(handler http.Handler) http.Handler) http.Handler {
+	h := &httpHandler{
+		svc: organizationService,
+	}
+
+	h.init()
+	return adminMiddleware(h)
+}
+
+type deletedResource struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+}
+
+type deletionStep struct {
+	Name      string            `json:"name"`
+	Resources []deletedResource `json:"resources"`
+	Error     string            `json:"error,omitempty"`
+}
+
+func (h *httpHandler) delete() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		OrganizationID string `json:"organization_id"`
+		DryRun         bool   `json:"dry_run"`
+	}
+	type response struct {
+		OrganizationID string         `json:"organization_id"`
+		DryRun         bool           `json:"dry_run"`
+		Complete       bool           `json:"complete"`
+		Steps          []deletionStep `json:"steps"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		report, err := h.svc.DeleteOrganization(ctx, backend.DeleteOrganizationCommand{
+			OrganizationID: organizationID,
+			DryRun:         req.DryRun,
+		})
+		if err != nil {
+			return response{}, err
+		}
+
+		resp := response{
+			OrganizationID: report.OrganizationID.String(),
+			DryRun:         report.DryRun,
+			Complete:       report.Complete,
+			Steps:          make([]deletionStep, len(report.Steps)),
+		}
+		for i, step := range report.Steps {
+			resources := make([]deletedResource, len(step.Resources))
+			for j, resource := range step.Resources {
+				resources[j] = deletedResource{Type: resource.Type, Count: resource.Count}
+			}
+			resp.Steps[i] = deletionStep{Name: step.Name, Resources: resources, Error: step.Error}
+		}
+		return resp, nil
+	})
+}
+
+func ApiHandlerFunc[T any, R any](handler func(context.Context, T) (R, error)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var request T
+		if r.Method == http.MethodPost && r.Body != nil {
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
+				return
+			}
+		}
+
+		response, err := handler(ctx, request)
+		if err != nil {
+			httperrors.Write(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
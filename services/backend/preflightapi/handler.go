@@ -0,0 +1,97 @@
+package preflightapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/73ai/infragpt/services/backend/internal/generic/preflight"
+)
+
+type httpHandler struct {
+	http.ServeMux
+	checker *preflight.Checker
+}
+
+func (h *httpHandler) init() {
+	h.HandleFunc("/preflight/run/", h.run())
+}
+
+func NewHandler(checker *preflight.Checker,
+	adminMiddleware func(handler http.Handler) http.Handler) http.Handler {
+	h := &httpHandler{
+		checker: checker,
+	}
+
+	h.init()
+	return adminMiddleware(h)
+}
+
+// NewProbeHandler serves the unauthenticated probes: /livez answers as long as
+// the process serves HTTP, /readyz only while every check passes. Readiness
+// reports which checks failed but not why, since the messages name config
+// keys and endpoints.
+func NewProbeHandler(checker *preflight.Checker) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/livez", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		type check struct {
+			Name   string `json:"name"`
+			Passed bool   `json:"passed"`
+		}
+		results := checker.Cached(r.Context())
+		checks := make([]check, 0, len(results))
+		for _, result := range results {
+			checks = append(checks, check{Name: result.Name, Passed: result.Passed})
+		}
+
+		status := http.StatusOK
+		if len(preflight.Failed(results)) > 0 {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(map[string]any{"checks": checks})
+	})
+	return mux
+}
+
+func (h *httpHandler) run() func(w http.ResponseWriter, r *http.Request) {
+	type request struct{}
+	type response struct {
+		Passed bool               `json:"passed"`
+		Checks []preflight.Result `json:"checks"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		results := h.checker.Run(ctx)
+		return response{Passed: len(preflight.Failed(results)) == 0, Checks: results}, nil
+	})
+}
+
+func ApiHandlerFunc[T any, R any](handler func(context.Context, T) (R, error)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var request T
+		if r.Method == http.MethodPost && r.Body != nil {
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
+				return
+			}
+		}
+
+		response, err := handler(ctx, request)
+		if err != nil {
+			httperrors.Write(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
@@ -0,0 +1,103 @@
+package publicapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc"
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc/domain"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// methodPrefix selects the calls of the public API. The public listener
+// refuses every other method, so internal services such as BackendService
+// can't be reached through it even if they are registered on its server.
+const methodPrefix = "/infragpt.v1."
+
+// APIKeyValidator resolves an API key to the organization it was issued to
+// and the member who issued it. *devicesvc.Service validates the keys
+// organizations issue through /device/api-keys/create.
+type APIKeyValidator interface {
+	ValidateAPIKey(ctx context.Context, apiKey string) (devicesvc.ValidateAPIKeyResult, error)
+}
+
+type principalKey struct{}
+
+// ServerOptions returns interceptors that authenticate calls to the public API
+// with the bearer API key in their "authorization" metadata and reject calls
+// to any other service. The server must only serve the public API.
+func ServerOptions(validator APIKeyValidator) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			if !strings.HasPrefix(info.FullMethod, methodPrefix) {
+				return nil, notPublic(info.FullMethod)
+			}
+			ctx, err := authenticate(ctx, validator)
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if !strings.HasPrefix(info.FullMethod, methodPrefix) {
+				return notPublic(info.FullMethod)
+			}
+			ctx, err := authenticate(ss.Context(), validator)
+			if err != nil {
+				return err
+			}
+			return handler(srv, authenticatedStream{ServerStream: ss, ctx: ctx})
+		}),
+	}
+}
+
+func notPublic(method string) error {
+	return status.Errorf(codes.Unimplemented, "unknown method %s", method)
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func authenticate(ctx context.Context, validator APIKeyValidator) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing API key")
+	}
+	scheme, apiKey, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || apiKey == "" {
+		return nil, status.Error(codes.Unauthenticated, "authorization must be a bearer API key")
+	}
+
+	result, err := validator.ValidateAPIKey(ctx, apiKey)
+	switch {
+	case errors.Is(err, domain.ErrAPIKeyNotFound):
+		return nil, status.Error(codes.Unauthenticated, "invalid API key")
+	case errors.Is(err, domain.ErrAPIKeyRevoked):
+		return nil, status.Error(codes.Unauthenticated, "API key has been revoked")
+	case err != nil:
+		return nil, toStatus(err)
+	}
+	return context.WithValue(ctx, principalKey{}, result), nil
+}
+
+// principal returns the organization and user of the API key the call was
+// authenticated with.
+func principal(ctx context.Context) (organizationID, userID uuid.UUID, err error) {
+	result, ok := ctx.Value(principalKey{}).(devicesvc.ValidateAPIKeyResult)
+	if !ok {
+		return uuid.Nil, uuid.Nil, status.Error(codes.Unauthenticated, "missing API key")
+	}
+	return result.OrganizationID, result.UserID, nil
+}
@@ -0,0 +1,47 @@
+package publicapi
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errorMappings = []httperrors.Mapping{
+	{Target: backend.ErrIntegrationNotFound, HttpStatus: http.StatusNotFound, Code: httperrors.CodeNotFound},
+	{Target: backend.ErrQuotaExceeded, HttpStatus: http.StatusTooManyRequests, Code: httperrors.CodeRateLimited},
+	{Target: domain.ErrWorkspaceNotLinked, HttpStatus: http.StatusNotFound, Code: httperrors.CodeNotFound},
+}
+
+// toStatus reports err with the gRPC code matching the HTTP status the other
+// APIs use for it. Unrecognised errors become codes.Internal with a generic
+// message, as they do over HTTP.
+func toStatus(err error) error {
+	e := httperrors.Classify(err, errorMappings...)
+
+	code := codes.Internal
+	switch e.HttpStatus {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		code = codes.ResourceExhausted
+	case http.StatusServiceUnavailable:
+		code = codes.Unavailable
+	}
+	if code == codes.Internal {
+		slog.Error("public api call failed", "error", err)
+	}
+	return status.Error(code, e.Message)
+}
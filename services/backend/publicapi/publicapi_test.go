@@ -0,0 +1,296 @@
+package publicapi_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	infragpt "github.com/73ai/infragpt/clients/go"
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/backendapi"
+	"github.com/73ai/infragpt/services/backend/backendapi/proto"
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc"
+	"github.com/73ai/infragpt/services/backend/internal/devicesvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/73ai/infragpt/services/backend/publicapi"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeValidator map[string]devicesvc.ValidateAPIKeyResult
+
+func (f fakeValidator) ValidateAPIKey(ctx context.Context, apiKey string) (devicesvc.ValidateAPIKeyResult, error) {
+	result, ok := f[apiKey]
+	if !ok {
+		return devicesvc.ValidateAPIKeyResult{}, domain.ErrAPIKeyNotFound
+	}
+	return result, nil
+}
+
+type fakeIntegrations struct {
+	backend.IntegrationService
+	integrations []backend.Integration
+}
+
+func (f *fakeIntegrations) Integrations(ctx context.Context, query backend.IntegrationsQuery) ([]backend.Integration, error) {
+	var found []backend.Integration
+	for _, integration := range f.integrations {
+		if integration.OrganizationID == query.OrganizationID &&
+			(query.ConnectorType == "" || integration.ConnectorType == query.ConnectorType) {
+			found = append(found, integration)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeIntegrations) Integration(ctx context.Context, query backend.IntegrationQuery) (backend.Integration, error) {
+	for _, integration := range f.integrations {
+		if integration.ID == query.IntegrationID && integration.OrganizationID == query.OrganizationID {
+			return integration, nil
+		}
+	}
+	return backend.Integration{}, backend.ErrIntegrationNotFound
+}
+
+type fakeConversations struct {
+	backend.ConversationService
+	organizationID uuid.UUID
+	conversation   backend.Conversation
+	messages       []backend.ConversationMessage
+	started        []backend.StartConversationCommand
+	startErr       error
+}
+
+func (f *fakeConversations) StartConversation(ctx context.Context, cmd backend.StartConversationCommand) (backend.Conversation, error) {
+	if f.startErr != nil {
+		return backend.Conversation{}, f.startErr
+	}
+	f.started = append(f.started, cmd)
+	return f.conversation, nil
+}
+
+func (f *fakeConversations) Conversation(ctx context.Context, query backend.ConversationQuery) (backend.Conversation, error) {
+	if query.ConversationID != f.conversation.ID || query.OrganizationID != f.organizationID {
+		return backend.Conversation{}, httperrors.NotFound("conversation not found")
+	}
+	return f.conversation, nil
+}
+
+func (f *fakeConversations) ConversationHistory(ctx context.Context, query backend.ConversationHistoryQuery) ([]backend.ConversationMessage, error) {
+	if query.ConversationID != f.conversation.ID || query.OrganizationID != f.organizationID {
+		return nil, httperrors.NotFound("conversation not found")
+	}
+	return f.messages, nil
+}
+
+type harness struct {
+	conversations *fakeConversations
+	integrations  *fakeIntegrations
+	dial          func(ctx context.Context, _ string) (net.Conn, error)
+}
+
+func newHarness(t *testing.T, validator fakeValidator, organizationID uuid.UUID) *harness {
+	t.Helper()
+
+	h := &harness{
+		conversations: &fakeConversations{organizationID: organizationID},
+		integrations:  &fakeIntegrations{},
+	}
+	listener := bufconn.Listen(1 << 20)
+	h.dial = func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}
+
+	server := publicapi.NewServer(validator, h.conversations, h.integrations)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	return h
+}
+
+func (h *harness) client(t *testing.T, apiKey string) *infragpt.Client {
+	t.Helper()
+
+	client, err := infragpt.NewClient(infragpt.Config{
+		Endpoint:       "passthrough:///bufnet",
+		APIKey:         apiKey,
+		Insecure:       true,
+		InitialBackoff: time.Millisecond,
+		DialOptions:    []grpc.DialOption{grpc.WithContextDialer(h.dial)},
+	})
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestPublicAPI(t *testing.T) {
+	ctx := context.Background()
+	organizationID, otherOrganizationID := uuid.New(), uuid.New()
+	userID := uuid.New()
+	validator := fakeValidator{
+		"key-acme":  {OrganizationID: organizationID, UserID: userID},
+		"key-other": {OrganizationID: otherOrganizationID, UserID: uuid.New()},
+	}
+	h := newHarness(t, validator, organizationID)
+	client := h.client(t, "key-acme")
+
+	syncedAt := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	github := backend.Integration{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		ConnectorType:  backend.ConnectorTypeGithub,
+		Status:         backend.IntegrationStatusActive,
+		Grants:         []backend.IntegrationGrant{backend.IntegrationGrantReadContent},
+		LastSyncedAt:   &syncedAt,
+	}
+	h.integrations.integrations = []backend.Integration{
+		github,
+		{ID: uuid.New(), OrganizationID: organizationID, ConnectorType: backend.ConnectorTypeSlack, Status: backend.IntegrationStatusActive},
+		{ID: uuid.New(), OrganizationID: otherOrganizationID, ConnectorType: backend.ConnectorTypeGithub, Status: backend.IntegrationStatusActive},
+	}
+
+	t.Run("rejects missing and unknown API keys", func(t *testing.T) {
+		if _, err := h.client(t, "unknown").ListIntegrations(ctx, infragpt.ListIntegrationsOptions{}); !errors.Is(err, infragpt.ErrUnauthenticated) {
+			t.Errorf("ListIntegrations() error = %v, want ErrUnauthenticated", err)
+		}
+		if _, err := infragpt.NewClient(infragpt.Config{Endpoint: "passthrough:///bufnet"}); err == nil {
+			t.Error("NewClient() without an API key succeeded")
+		}
+	})
+
+	t.Run("lists the organization's integrations", func(t *testing.T) {
+		integrations, err := client.ListIntegrations(ctx, infragpt.ListIntegrationsOptions{ConnectorType: "github"})
+		if err != nil {
+			t.Fatalf("ListIntegrations() error = %v", err)
+		}
+		if len(integrations) != 1 || integrations[0].ID != github.ID.String() {
+			t.Fatalf("ListIntegrations() = %+v, want only the organization's GitHub integration", integrations)
+		}
+		if got := integrations[0]; got.Status != "active" || len(got.Grants) != 1 || got.Grants[0] != "read_content" || !got.LastSyncedAt.Equal(syncedAt) {
+			t.Errorf("integration = %+v", got)
+		}
+
+		got, err := client.GetIntegration(ctx, github.ID.String())
+		if err != nil || got.ConnectorType != "github" {
+			t.Errorf("GetIntegration() = %+v, %v", got, err)
+		}
+		other := h.integrations.integrations[2].ID.String()
+		if _, err := client.GetIntegration(ctx, other); !errors.Is(err, infragpt.ErrNotFound) {
+			t.Errorf("GetIntegration() of another organization error = %v, want ErrNotFound", err)
+		}
+		if _, err := client.GetIntegration(ctx, "not-a-uuid"); !errors.Is(err, infragpt.ErrInvalidArgument) {
+			t.Errorf("GetIntegration() with a bad id error = %v, want ErrInvalidArgument", err)
+		}
+	})
+
+	createdAt := time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)
+	h.conversations.conversation = backend.Conversation{
+		ID:        uuid.New(),
+		TeamID:    "T1",
+		ChannelID: "C1",
+		ThreadTS:  "1717322400.000100",
+		Status:    backend.ConversationStatusActive,
+		CreatedAt: createdAt,
+	}
+
+	t.Run("starts a conversation for the API key's user", func(t *testing.T) {
+		conversation, err := client.StartConversation(ctx, infragpt.StartConversationRequest{ChannelID: "C1", Message: "why is payments down?"})
+		if err != nil {
+			t.Fatalf("StartConversation() error = %v", err)
+		}
+		if conversation.ID != h.conversations.conversation.ID.String() || conversation.ThreadTS != "1717322400.000100" ||
+			conversation.Status != infragpt.ConversationStatusActive || !conversation.CreatedAt.Equal(createdAt) {
+			t.Errorf("StartConversation() = %+v", conversation)
+		}
+		want := backend.StartConversationCommand{OrganizationID: organizationID, UserID: userID, ChannelID: "C1", Message: "why is payments down?"}
+		if len(h.conversations.started) != 1 || h.conversations.started[0] != want {
+			t.Errorf("started = %+v, want %+v", h.conversations.started, want)
+		}
+	})
+
+	t.Run("reports quota and validation errors", func(t *testing.T) {
+		h.conversations.startErr = backend.ErrQuotaExceeded
+		if _, err := client.StartConversation(ctx, infragpt.StartConversationRequest{ChannelID: "C1", Message: "hi"}); !errors.Is(err, infragpt.ErrQuotaExceeded) {
+			t.Errorf("StartConversation() error = %v, want ErrQuotaExceeded", err)
+		}
+		h.conversations.startErr = httperrors.Validation("message is required", "message")
+		_, err := client.StartConversation(ctx, infragpt.StartConversationRequest{ChannelID: "C1"})
+		var apiErr *infragpt.Error
+		if !errors.Is(err, infragpt.ErrInvalidArgument) || !errors.As(err, &apiErr) || apiErr.Message != "message is required" {
+			t.Errorf("StartConversation() error = %v, want ErrInvalidArgument", err)
+		}
+		h.conversations.startErr = errors.New("connection refused to db-1.internal")
+		if _, err := client.StartConversation(ctx, infragpt.StartConversationRequest{ChannelID: "C1", Message: "hi"}); !errors.As(err, &apiErr) || apiErr.Message != "internal server error" {
+			t.Errorf("StartConversation() error = %v, want an internal error without details", err)
+		}
+		h.conversations.startErr = nil
+	})
+
+	t.Run("reads the conversation and its messages", func(t *testing.T) {
+		conversationID := h.conversations.conversation.ID.String()
+		h.conversations.messages = []backend.ConversationMessage{
+			{ID: uuid.New(), SenderID: "api", SenderName: "InfraGPT API", Text: "why is payments down?", Steps: []backend.ConversationStep{
+				{ID: uuid.New(), Tool: "kubectl", Arguments: []string{"get", "pods"}, Duration: 1500 * time.Millisecond, Outcome: backend.ConversationStepSucceeded},
+			}},
+			{ID: uuid.New(), SenderID: "bot", Text: "The payments pod is OOMKilled.", IsBot: true},
+		}
+
+		conversation, err := client.GetConversation(ctx, conversationID)
+		if err != nil || conversation.ChannelID != "C1" {
+			t.Errorf("GetConversation() = %+v, %v", conversation, err)
+		}
+
+		messages, err := client.ListMessages(ctx, conversationID)
+		if err != nil {
+			t.Fatalf("ListMessages() error = %v", err)
+		}
+		if len(messages) != 2 || !messages[1].FromAgent || messages[1].Text != "The payments pod is OOMKilled." {
+			t.Fatalf("ListMessages() = %+v", messages)
+		}
+		if steps := messages[0].Steps; len(steps) != 1 || steps[0].Tool != "kubectl" || steps[0].Duration != 1500*time.Millisecond || steps[0].Outcome != "succeeded" {
+			t.Errorf("steps = %+v", steps)
+		}
+
+		other := h.client(t, "key-other")
+		if _, err := other.GetConversation(ctx, conversationID); !errors.Is(err, infragpt.ErrNotFound) {
+			t.Errorf("GetConversation() from another organization error = %v, want ErrNotFound", err)
+		}
+		if _, err := other.ListMessages(ctx, conversationID); !errors.Is(err, infragpt.ErrNotFound) {
+			t.Errorf("ListMessages() from another organization error = %v, want ErrNotFound", err)
+		}
+	})
+}
+
+func TestServerOptionsRefuseInternalServices(t *testing.T) {
+	validator := fakeValidator{"key-acme": {OrganizationID: uuid.New(), UserID: uuid.New()}}
+	conversations := &fakeConversations{}
+
+	// Internal services registered on a public server by mistake stay
+	// unreachable, even with a valid API key.
+	listener := bufconn.Listen(1 << 20)
+	server := backendapi.NewGRPCServer(conversations, nil, publicapi.ServerOptions(validator)...)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer key-acme")
+	_, err = proto.NewBackendServiceClient(conn).SendReply(ctx, &proto.SendReplyCommand{ConversationId: uuid.NewString(), Message: "hi"})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("SendReply() error = %v, want %v", err, codes.Unimplemented)
+	}
+}
@@ -0,0 +1,219 @@
+// Package publicapi serves the versioned public gRPC API that customers
+// automate InfraGPT with. Its protobuf definitions and Go client live in the
+// clients/go module.
+package publicapi
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/73ai/infragpt/clients/go/proto/infragpt/v1"
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// NewServer returns a gRPC server that serves only the public API. Serve it on
+// its own listener; the internal gRPC port is not authenticated.
+func NewServer(validator APIKeyValidator, conversations backend.ConversationService, integrations backend.IntegrationService, opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(append(opts, ServerOptions(validator)...)...)
+	Register(server, conversations, integrations)
+	return server
+}
+
+// Register adds the public API to server, which must be created with
+// ServerOptions so its calls are authenticated and must not serve the internal
+// services.
+func Register(server *grpc.Server, conversations backend.ConversationService, integrations backend.IntegrationService) {
+	pb.RegisterIntegrationServiceServer(server, &integrationServer{svc: integrations})
+	pb.RegisterConversationServiceServer(server, &conversationServer{svc: conversations})
+}
+
+type integrationServer struct {
+	pb.UnimplementedIntegrationServiceServer
+	svc backend.IntegrationService
+}
+
+func (s *integrationServer) ListIntegrations(ctx context.Context, req *pb.ListIntegrationsRequest) (*pb.ListIntegrationsResponse, error) {
+	organizationID, _, err := principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	integrations, err := s.svc.Integrations(ctx, backend.IntegrationsQuery{
+		OrganizationID: organizationID,
+		ConnectorType:  backend.ConnectorType(req.ConnectorType),
+		Status:         backend.IntegrationStatus(req.Status),
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListIntegrationsResponse{Integrations: make([]*pb.Integration, len(integrations))}
+	for i, integration := range integrations {
+		resp.Integrations[i] = newIntegration(integration)
+	}
+	return resp, nil
+}
+
+func (s *integrationServer) GetIntegration(ctx context.Context, req *pb.GetIntegrationRequest) (*pb.Integration, error) {
+	organizationID, _, err := principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	integrationID, err := parseID(req.Id, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	integration, err := s.svc.Integration(ctx, backend.IntegrationQuery{
+		IntegrationID:  integrationID,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return newIntegration(integration), nil
+}
+
+type conversationServer struct {
+	pb.UnimplementedConversationServiceServer
+	svc backend.ConversationService
+}
+
+func (s *conversationServer) StartConversation(ctx context.Context, req *pb.StartConversationRequest) (*pb.Conversation, error) {
+	organizationID, userID, err := principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conversation, err := s.svc.StartConversation(ctx, backend.StartConversationCommand{
+		OrganizationID: organizationID,
+		UserID:         userID,
+		ChannelID:      req.ChannelId,
+		Message:        req.Message,
+		Model:          req.Model,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return newConversation(conversation), nil
+}
+
+func (s *conversationServer) GetConversation(ctx context.Context, req *pb.GetConversationRequest) (*pb.Conversation, error) {
+	organizationID, _, err := principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conversationID, err := parseID(req.Id, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	conversation, err := s.svc.Conversation(ctx, backend.ConversationQuery{
+		ConversationID: conversationID,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return newConversation(conversation), nil
+}
+
+func (s *conversationServer) ListMessages(ctx context.Context, req *pb.ListMessagesRequest) (*pb.ListMessagesResponse, error) {
+	organizationID, _, err := principal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conversationID, err := parseID(req.ConversationId, "conversation_id")
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := s.svc.ConversationHistory(ctx, backend.ConversationHistoryQuery{
+		ConversationID: conversationID,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	resp := &pb.ListMessagesResponse{Messages: make([]*pb.Message, len(messages))}
+	for i, message := range messages {
+		steps := make([]*pb.Step, len(message.Steps))
+		for j, step := range message.Steps {
+			steps[j] = &pb.Step{
+				Id:              step.ID.String(),
+				Tool:            step.Tool,
+				Arguments:       step.Arguments,
+				DurationMs:      step.Duration.Milliseconds(),
+				Outcome:         string(step.Outcome),
+				Result:          step.Result,
+				ResultTruncated: step.ResultTruncated,
+				StartedAt:       timestamp(step.StartedAt),
+			}
+		}
+		resp.Messages[i] = &pb.Message{
+			Id:         message.ID.String(),
+			SenderId:   message.SenderID,
+			SenderName: message.SenderName,
+			Text:       message.Text,
+			FromAgent:  message.IsBot,
+			CreatedAt:  timestamp(message.CreatedAt),
+			Steps:      steps,
+		}
+	}
+	return resp, nil
+}
+
+func parseID(id, field string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, status.Errorf(codes.InvalidArgument, "invalid %s", field)
+	}
+	return parsed, nil
+}
+
+func newIntegration(integration backend.Integration) *pb.Integration {
+	grants := make([]string, len(integration.Grants))
+	for i, grant := range integration.Grants {
+		grants[i] = string(grant)
+	}
+	resp := &pb.Integration{
+		Id:            integration.ID.String(),
+		ConnectorType: string(integration.ConnectorType),
+		Status:        string(integration.Status),
+		Grants:        grants,
+		CreatedAt:     timestamp(integration.CreatedAt),
+		UpdatedAt:     timestamp(integration.UpdatedAt),
+	}
+	if integration.LastSyncedAt != nil {
+		resp.LastSyncedAt = timestamp(*integration.LastSyncedAt)
+	}
+	return resp
+}
+
+func newConversation(conversation backend.Conversation) *pb.Conversation {
+	conversationStatus := pb.ConversationStatus_CONVERSATION_STATUS_ACTIVE
+	if conversation.Status == backend.ConversationStatusEscalated {
+		conversationStatus = pb.ConversationStatus_CONVERSATION_STATUS_ESCALATED
+	}
+	return &pb.Conversation{
+		Id:        conversation.ID.String(),
+		ChannelId: conversation.ChannelID,
+		ThreadTs:  conversation.ThreadTS,
+		Status:    conversationStatus,
+		CreatedAt: timestamp(conversation.CreatedAt),
+	}
+}
+
+// timestamp leaves zero times unset.
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
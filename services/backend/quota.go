@@ -0,0 +1,75 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrQuotaExceeded is returned when an organization has used up one of its
+// quotas for the current period.
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+type QuotaMetric string
+
+const (
+	// QuotaMetricRequests counts messages handed to the agent.
+	QuotaMetricRequests QuotaMetric = "requests"
+	// QuotaMetricLLMTokens counts the LLM tokens the agent reports using.
+	QuotaMetricLLMTokens QuotaMetric = "llm_tokens"
+)
+
+// QuotaPeriod is how often usage of a quota resets, at midnight UTC on the
+// period's first day.
+type QuotaPeriod string
+
+const (
+	QuotaPeriodDay   QuotaPeriod = "day"
+	QuotaPeriodWeek  QuotaPeriod = "week"
+	QuotaPeriodMonth QuotaPeriod = "month"
+)
+
+// Quotas is the enforcement side consulted on the request path.
+// Organizations without a limit for a metric are unlimited.
+type Quotas interface {
+	// CheckQuotas returns an error wrapping ErrQuotaExceeded when the
+	// organization has used up any of its quotas.
+	CheckQuotas(ctx context.Context, organizationID uuid.UUID) error
+	// RecordUsage adds to the organization's usage in the current period.
+	// Failures are logged rather than returned so usage never blocks a reply.
+	RecordUsage(ctx context.Context, organizationID uuid.UUID, metric QuotaMetric, amount int64)
+}
+
+type QuotaService interface {
+	Quotas
+	QuotaUsage(ctx context.Context, query QuotaUsageQuery) ([]QuotaUsage, error)
+	SetQuota(ctx context.Context, cmd SetQuotaCommand) error
+	UnsetQuota(ctx context.Context, cmd UnsetQuotaCommand) error
+}
+
+type QuotaUsageQuery struct {
+	OrganizationID uuid.UUID
+}
+
+// QuotaUsage is an organization's usage of a metric in the current period.
+type QuotaUsage struct {
+	Metric QuotaMetric
+	// Limit is zero when the metric is unlimited.
+	Limit    int64
+	Used     int64
+	Period   QuotaPeriod
+	ResetsAt time.Time
+}
+
+type SetQuotaCommand struct {
+	OrganizationID uuid.UUID
+	Metric         QuotaMetric
+	Limit          int64
+}
+
+type UnsetQuotaCommand struct {
+	OrganizationID uuid.UUID
+	Metric         QuotaMetric
+}
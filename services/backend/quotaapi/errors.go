@@ -0,0 +1,13 @@
+package quotaapi
+
+import (
+	"net/http"
+
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/73ai/infragpt/services/backend/internal/quotasvc/domain"
+)
+
+var errorMappings = []httperrors.Mapping{
+	{Target: domain.ErrQuotaNotFound, HttpStatus: http.StatusNotFound, Code: httperrors.CodeNotFound},
+	{Target: domain.ErrInvalidQuota, HttpStatus: http.StatusBadRequest, Code: httperrors.CodeValidation},
+}
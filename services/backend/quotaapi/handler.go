@@ -0,0 +1,146 @@
+package quotaapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/google/uuid"
+)
+
+type httpHandler struct {
+	http.ServeMux
+	svc backend.QuotaService
+}
+
+func (h *httpHandler) init() {
+	h.HandleFunc("/quotas/usage/", h.usage())
+	h.HandleFunc("/quotas/set/", h.set())
+	h.HandleFunc("/quotas/unset/", h.unset())
+}
+
+func NewHandler(quotaService backend.QuotaService,
+	adminMiddleware func(handler http.Handler) http.Handler) http.Handler {
+	h := &httpHandler{
+		svc: quotaService,
+	}
+
+	h.init()
+	return adminMiddleware(h)
+}
+
+type quotaUsage struct {
+	Metric string `json:"metric"`
+	// Limit is null when the metric is unlimited.
+	Limit    *int64 `json:"limit"`
+	Used     int64  `json:"used"`
+	Period   string `json:"period"`
+	ResetsAt string `json:"resets_at"`
+}
+
+func (h *httpHandler) usage() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		OrganizationID string `json:"organization_id"`
+	}
+	type response struct {
+		Quotas []quotaUsage `json:"quotas"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		usage, err := h.svc.QuotaUsage(ctx, backend.QuotaUsageQuery{OrganizationID: organizationID})
+		if err != nil {
+			return response{}, err
+		}
+
+		resp := response{Quotas: make([]quotaUsage, len(usage))}
+		for i, u := range usage {
+			resp.Quotas[i] = quotaUsage{
+				Metric:   string(u.Metric),
+				Used:     u.Used,
+				Period:   string(u.Period),
+				ResetsAt: u.ResetsAt.Format(time.RFC3339),
+			}
+			if u.Limit > 0 {
+				limit := u.Limit
+				resp.Quotas[i].Limit = &limit
+			}
+		}
+		return resp, nil
+	})
+}
+
+func (h *httpHandler) set() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		OrganizationID string `json:"organization_id"`
+		Metric         string `json:"metric"`
+		Limit          int64  `json:"limit"`
+	}
+	type response struct{}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		err = h.svc.SetQuota(ctx, backend.SetQuotaCommand{
+			OrganizationID: organizationID,
+			Metric:         backend.QuotaMetric(req.Metric),
+			Limit:          req.Limit,
+		})
+		return response{}, err
+	})
+}
+
+func (h *httpHandler) unset() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		OrganizationID string `json:"organization_id"`
+		Metric         string `json:"metric"`
+	}
+	type response struct{}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		err = h.svc.UnsetQuota(ctx, backend.UnsetQuotaCommand{
+			OrganizationID: organizationID,
+			Metric:         backend.QuotaMetric(req.Metric),
+		})
+		return response{}, err
+	})
+}
+
+func ApiHandlerFunc[T any, R any](handler func(context.Context, T) (R, error)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var request T
+		if r.Method == http.MethodPost && r.Body != nil {
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
+				return
+			}
+		}
+
+		response, err := handler(ctx, request)
+		if err != nil {
+			httperrors.Write(w, r, err, errorMappings...)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}
@@ -0,0 +1,100 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidRepositoryTrigger is returned when a trigger has a malformed
+	// pattern or matches none of the organization's synced repositories.
+	ErrInvalidRepositoryTrigger  = errors.New("invalid repository trigger")
+	ErrRepositoryTriggerNotFound = errors.New("repository trigger not found")
+)
+
+type RepositoryEventType string
+
+const (
+	RepositoryEventPush        RepositoryEventType = "push"
+	RepositoryEventPullRequest RepositoryEventType = "pull_request"
+)
+
+// RepositoryEvent is a push or pull request in a connected repository.
+type RepositoryEvent struct {
+	Type RepositoryEventType
+	// Repository is the full name, such as "acme/infra".
+	Repository string
+	// Action is the pull request action, such as "opened"; it is empty for pushes.
+	Action            string
+	Branch            string
+	CommitSHA         string
+	PullRequestNumber int
+	// Title is the pull request title or the head commit message.
+	Title  string
+	URL    string
+	Sender string
+	// ChangedFiles are the paths the push or pull request touches. They are
+	// only listed for pull requests when a trigger filters on paths.
+	ChangedFiles []string
+}
+
+// RepositoryTrigger starts a conversation in a Slack channel when a matching
+// event happens in one of the organization's repositories.
+type RepositoryTrigger struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	// RepositoryPattern is a glob over repository full names, such as
+	// "acme/infra-*". Matching is case-insensitive.
+	RepositoryPattern string
+	EventType         RepositoryEventType
+	// PathGlob limits the trigger to events that touch a matching file, such
+	// as "modules/**/*.tf". A glob without a slash matches file names in any
+	// directory. Empty matches every event.
+	PathGlob string
+	// Channel is the ID of the Slack channel conversations start in.
+	Channel   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// RepositoryTriggerMatch is a repository event that matched a trigger.
+type RepositoryTriggerMatch struct {
+	Trigger     RepositoryTrigger
+	Integration Integration
+	Event       RepositoryEvent
+}
+
+// RepositoryEventListener is told about repository events that match one of
+// the organization's triggers, once per channel.
+type RepositoryEventListener interface {
+	RepositoryEventTriggered(ctx context.Context, match RepositoryTriggerMatch)
+}
+
+type RepositoryTriggersQuery struct {
+	OrganizationID uuid.UUID
+}
+
+type CreateRepositoryTriggerCommand struct {
+	OrganizationID    uuid.UUID
+	RepositoryPattern string
+	EventType         RepositoryEventType
+	PathGlob          string
+	Channel           string
+}
+
+type UpdateRepositoryTriggerCommand struct {
+	ID                uuid.UUID
+	OrganizationID    uuid.UUID
+	RepositoryPattern string
+	EventType         RepositoryEventType
+	PathGlob          string
+	Channel           string
+}
+
+type DeleteRepositoryTriggerCommand struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+}
@@ -0,0 +1,14 @@
+package slackuserapi
+
+import (
+	"net/http"
+
+	"github.com/73ai/infragpt/services/backend/internal/conversationsvc/domain"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+)
+
+var errorMappings = []httperrors.Mapping{
+	{Target: domain.ErrInvalidSlackUserMapping, HttpStatus: http.StatusBadRequest, Code: httperrors.CodeValidation},
+	{Target: domain.ErrSlackUserMappingNotFound, HttpStatus: http.StatusNotFound, Code: httperrors.CodeNotFound},
+	{Target: domain.ErrWorkspaceNotLinked, HttpStatus: http.StatusNotFound, Code: httperrors.CodeNotFound},
+}
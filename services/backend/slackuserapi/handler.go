@@ -0,0 +1,163 @@
+package slackuserapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/73ai/infragpt/services/backend"
+	"github.com/73ai/infragpt/services/backend/internal/generic/httperrors"
+	"github.com/google/uuid"
+)
+
+type httpHandler struct {
+	http.ServeMux
+	svc backend.ConversationService
+}
+
+func (h *httpHandler) init() {
+	h.HandleFunc("/slack-users/list/", h.list())
+	h.HandleFunc("/slack-users/map/", h.mapUser())
+	h.HandleFunc("/slack-users/unmap/", h.unmapUser())
+}
+
+func NewHandler(conversationService backend.ConversationService,
+	adminMiddleware func(handler http.Handler) http.Handler) http.Handler {
+	h := &httpHandler{
+		svc: conversationService,
+	}
+
+	h.init()
+	return adminMiddleware(h)
+}
+
+type slackUserMapping struct {
+	TeamID      string `json:"team_id"`
+	SlackUserID string `json:"slack_user_id"`
+	UserID      string `json:"user_id"`
+	Source      string `json:"source"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+func toSlackUserMapping(m backend.SlackUserMapping) slackUserMapping {
+	return slackUserMapping{
+		TeamID:      m.TeamID,
+		SlackUserID: m.SlackUserID,
+		UserID:      m.UserID.String(),
+		Source:      string(m.Source),
+		UpdatedAt:   m.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (h *httpHandler) list() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		OrganizationID string `json:"organization_id"`
+	}
+	type response struct {
+		Mappings []slackUserMapping `json:"mappings"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+
+		mappings, err := h.svc.SlackUserMappings(ctx, backend.SlackUserMappingsQuery{OrganizationID: organizationID})
+		if err != nil {
+			return response{}, err
+		}
+
+		resp := response{Mappings: make([]slackUserMapping, len(mappings))}
+		for i, m := range mappings {
+			resp.Mappings[i] = toSlackUserMapping(m)
+		}
+		return resp, nil
+	})
+}
+
+func (h *httpHandler) mapUser() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		OrganizationID string `json:"organization_id"`
+		TeamID         string `json:"team_id,omitempty"`
+		SlackUserID    string `json:"slack_user_id"`
+		UserID         string `json:"user_id"`
+	}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (slackUserMapping, error) {
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return slackUserMapping{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+		userID, err := uuid.Parse(req.UserID)
+		if err != nil {
+			return slackUserMapping{}, httperrors.Validation("invalid user_id", "user_id")
+		}
+		if req.SlackUserID == "" {
+			return slackUserMapping{}, httperrors.Validation("slack_user_id is required", "slack_user_id")
+		}
+
+		mapping, err := h.svc.MapSlackUser(ctx, backend.MapSlackUserCommand{
+			OrganizationID: organizationID,
+			TeamID:         req.TeamID,
+			SlackUserID:    req.SlackUserID,
+			UserID:         userID,
+		})
+		if err != nil {
+			return slackUserMapping{}, err
+		}
+
+		return toSlackUserMapping(mapping), nil
+	})
+}
+
+func (h *httpHandler) unmapUser() func(w http.ResponseWriter, r *http.Request) {
+	type request struct {
+		OrganizationID string `json:"organization_id"`
+		TeamID         string `json:"team_id,omitempty"`
+		SlackUserID    string `json:"slack_user_id"`
+	}
+	type response struct{}
+
+	return ApiHandlerFunc(func(ctx context.Context, req request) (response, error) {
+		organizationID, err := uuid.Parse(req.OrganizationID)
+		if err != nil {
+			return response{}, httperrors.Validation("invalid organization_id", "organization_id")
+		}
+		if req.SlackUserID == "" {
+			return response{}, httperrors.Validation("slack_user_id is required", "slack_user_id")
+		}
+
+		err = h.svc.UnmapSlackUser(ctx, backend.UnmapSlackUserCommand{
+			OrganizationID: organizationID,
+			TeamID:         req.TeamID,
+			SlackUserID:    req.SlackUserID,
+		})
+		return response{}, err
+	})
+}
+
+func ApiHandlerFunc[T any, R any](handler func(context.Context, T) (R, error)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var request T
+		if r.Method == http.MethodPost && r.Body != nil {
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				httperrors.Write(w, r, httperrors.Validation("invalid JSON payload"))
+				return
+			}
+		}
+
+		response, err := handler(ctx, request)
+		if err != nil {
+			httperrors.Write(w, r, err, errorMappings...)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(response)
+	}
+}